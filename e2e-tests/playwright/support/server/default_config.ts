@@ -378,6 +378,15 @@ const defaultServerConfig: AdminConfig = {
         VaryByRemoteAddr: true,
         VaryByUser: false,
         VaryByHeader: '',
+        StoreType: 'memory',
+        PostsPerSec: 0,
+        PostsMaxBurst: 0,
+        SearchPerSec: 0,
+        SearchMaxBurst: 0,
+        FileUploadPerSec: 0,
+        FileUploadMaxBurst: 0,
+        AccessTokenPerSec: 0,
+        AccessTokenMaxBurst: 0,
     },
     PrivacySettings: {
         ShowEmailAddress: true,
@@ -630,6 +639,13 @@ const defaultServerConfig: AdminConfig = {
         EnableAutocomplete: false,
         BatchSize: 10000,
     },
+    PostgresSearchSettings: {
+        EnableIndexing: false,
+        EnableSearching: false,
+        EnableAutocomplete: false,
+        TextSearchConfig: 'english',
+        BatchSize: 10000,
+    },
     DataRetentionSettings: {
         EnableMessageDeletion: false,
         EnableFileDeletion: false,
@@ -60,6 +60,26 @@ func TestCreateChannelBookmark(t *testing.T) {
 		require.Equal(t, cb.DisplayName, channelBookmark.DisplayName)
 	})
 
+	t.Run("a user should be able to create a file bookmark with an uploaded file", func(t *testing.T) {
+		uploadResponse, _, err := th.Client.UploadBookmarkFile(context.Background(), []byte("data"), th.BasicChannel.Id, "test.txt")
+		require.NoError(t, err)
+		require.Len(t, uploadResponse.FileInfos, 1)
+		require.Equal(t, model.BookmarkFileOwner, uploadResponse.FileInfos[0].CreatorId)
+
+		channelBookmark := &model.ChannelBookmark{
+			ChannelId:   th.BasicChannel.Id,
+			DisplayName: "File bookmark test",
+			FileId:      uploadResponse.FileInfos[0].Id,
+			Type:        model.ChannelBookmarkFile,
+		}
+
+		cb, resp, err := th.Client.CreateChannelBookmark(context.Background(), channelBookmark)
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		require.NotNil(t, cb.FileInfo)
+		require.Equal(t, channelBookmark.FileId, cb.FileInfo.Id)
+	})
+
 	t.Run("a user should be able to create a channel bookmark in a private channel", func(t *testing.T) {
 		channelBookmark := &model.ChannelBookmark{
 			ChannelId:   th.BasicPrivateChannel.Id,
@@ -148,6 +148,34 @@ func updateCategoryOrderForTeamForUser(c *Context, w http.ResponseWriter, r *htt
 	}
 }
 
+func previewCategoryRulesForTeamForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	var rules model.SidebarCategoryRules
+	if err := json.NewDecoder(r.Body).Decode(&rules); err != nil {
+		c.SetInvalidParamWithErr("rules", err)
+		return
+	}
+
+	channelIDs, appErr := c.App.PreviewSidebarCategoryRules(c.AppContext, c.Params.UserId, c.Params.TeamId, rules)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if _, err := w.Write([]byte(model.ArrayToJSON(channelIDs))); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func getCategoryForTeamForUser(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId().RequireTeamId().RequireCategoryId()
 	if c.Err != nil {
@@ -550,6 +550,58 @@ func TestUpdateCategoriesForTeamForUser(t *testing.T) {
 	})
 }
 
+func TestPreviewCategoryRulesForTeamForUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("should return the channels matched by the rules", func(t *testing.T) {
+		user, client := setupUserForSubtest(t, th)
+
+		channelIDs, _, err := client.PreviewSidebarCategoryRulesForTeamForUser(context.Background(), user.Id, th.BasicTeam.Id, model.SidebarCategoryRules{
+			{Type: model.SidebarCategoryRuleNamePrefix, Value: th.BasicChannel.Name},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{th.BasicChannel.Id}, channelIDs)
+	})
+
+	t.Run("should reject invalid rules", func(t *testing.T) {
+		user, client := setupUserForSubtest(t, th)
+
+		_, resp, err := client.PreviewSidebarCategoryRulesForTeamForUser(context.Background(), user.Id, th.BasicTeam.Id, model.SidebarCategoryRules{
+			{Type: "unknown"},
+		})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("should not preview the channels of another user", func(t *testing.T) {
+		user, _ := setupUserForSubtest(t, th)
+		_, client := setupUserForSubtest(t, th)
+
+		_, resp, err := client.PreviewSidebarCategoryRulesForTeamForUser(context.Background(), user.Id, th.BasicTeam.Id, model.SidebarCategoryRules{
+			{Type: model.SidebarCategoryRuleShared},
+		})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("should create a category with rules", func(t *testing.T) {
+		user, client := setupUserForSubtest(t, th)
+
+		rules := model.SidebarCategoryRules{{Type: model.SidebarCategoryRuleBotDirectMessages}}
+		created, _, err := client.CreateSidebarCategoryForTeamForUser(context.Background(), user.Id, th.BasicTeam.Id, &model.SidebarCategoryWithChannels{
+			SidebarCategory: model.SidebarCategory{
+				UserId:      user.Id,
+				TeamId:      th.BasicTeam.Id,
+				DisplayName: "bots",
+			},
+			Rules: rules,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, rules, created.Rules)
+	})
+}
+
 func setupUserForSubtest(t *testing.T, th *TestHelper) (*model.User, *model.Client4) {
 	password := "password"
 	user, appErr := th.App.CreateUser(th.Context, &model.User{
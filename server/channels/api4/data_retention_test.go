@@ -1240,3 +1240,109 @@ func TestRemoveChannelsFromPolicy(t *testing.T) {
 		CheckUnauthorizedStatus(t, resp)
 	})
 }
+
+func TestGetDataRetentionDryRun(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	ok := th.App.Srv().SetLicense(model.NewTestLicense("data_retention"))
+	require.True(t, ok, "SetLicense should return true")
+
+	channelPolicy, err := th.App.Srv().Store().RetentionPolicy().Save(&model.RetentionPolicyWithTeamAndChannelIDs{
+		RetentionPolicy: model.RetentionPolicy{
+			DisplayName:      "Channel policy",
+			PostDurationDays: model.NewPointer(int64(30)),
+		},
+		ChannelIDs: []string{th.BasicChannel.Id},
+	})
+	require.NoError(t, err)
+
+	oldPost, err := th.App.Srv().Store().Post().Save(th.Context, &model.Post{
+		ChannelId: th.BasicChannel.Id,
+		UserId:    th.BasicUser.Id,
+		Message:   "old message",
+		CreateAt:  1000,
+	})
+	require.NoError(t, err)
+	_, err = th.App.Srv().Store().Reaction().Save(&model.Reaction{
+		UserId:    th.BasicUser.Id,
+		PostId:    oldPost.Id,
+		EmojiName: "smile",
+	})
+	require.NoError(t, err)
+
+	mockDataRetentionInterface := &mocks.DataRetentionInterface{}
+	mockDataRetentionInterface.On("GetGlobalPolicy").Return(&model.GlobalRetentionPolicy{}, nil)
+	mockDataRetentionInterface.On("GetPolicy", channelPolicy.ID).Return(channelPolicy, nil)
+	mockDataRetentionInterface.On("GetPolicy", mock.AnythingOfType("string")).Return(nil, model.NewAppError("GetPolicy", "app.data_retention.get_policy.app_error", nil, "", http.StatusNotFound))
+	th.App.Srv().Channels().DataRetention = mockDataRetentionInterface
+
+	findChannel := func(dryRun *model.RetentionPolicyDryRun, channelID string) *model.RetentionPolicyDryRunChannel {
+		for _, channel := range dryRun.Channels {
+			if channel.ChannelID == channelID {
+				return channel
+			}
+		}
+		return nil
+	}
+
+	t.Run("all policies", func(t *testing.T) {
+		dryRun, resp, err := th.SystemAdminClient.GetDataRetentionDryRun(context.Background())
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+
+		channel := findChannel(dryRun, th.BasicChannel.Id)
+		require.NotNil(t, channel)
+		assert.Equal(t, channelPolicy.ID, channel.PolicyID)
+		assert.Equal(t, th.BasicTeam.Id, channel.TeamID)
+		assert.Equal(t, int64(1), channel.PostCount)
+		assert.Equal(t, int64(1), channel.ReactionCount)
+		assert.Equal(t, int64(1000), channel.OldestPostAt)
+		assert.Equal(t, int64(1000), channel.NewestPostAt)
+		assert.GreaterOrEqual(t, dryRun.Total.PostCount, int64(1))
+	})
+
+	t.Run("single policy", func(t *testing.T) {
+		dryRun, resp, err := th.SystemAdminClient.GetDataRetentionPolicyDryRun(context.Background(), channelPolicy.ID)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+
+		require.Len(t, dryRun.Policies, 1)
+		assert.Equal(t, channelPolicy.ID, dryRun.Policies[0].PolicyID)
+		require.Len(t, dryRun.Teams, 1)
+		assert.Equal(t, th.BasicTeam.Id, dryRun.Teams[0].TeamID)
+		require.Len(t, dryRun.Channels, 1)
+		assert.Equal(t, int64(1), dryRun.Total.PostCount)
+
+		// The dry run must not delete anything.
+		_, appErr := th.App.GetSinglePost(th.Context, oldPost.Id, false)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("non-existent policy", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetDataRetentionPolicyDryRun(context.Background(), model.NewId())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("no permission", func(t *testing.T) {
+		_, resp, err := th.Client.GetDataRetentionDryRun(context.Background())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.GetDataRetentionPolicyDryRun(context.Background(), channelPolicy.ID)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("without license", func(t *testing.T) {
+		th.App.Srv().Channels().DataRetention = nil
+		defer func() {
+			th.App.Srv().Channels().DataRetention = mockDataRetentionInterface
+		}()
+
+		_, resp, err := th.SystemAdminClient.GetDataRetentionDryRun(context.Background())
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+}
@@ -18,6 +18,11 @@ func (api *API) InitDrafts() {
 
 	api.BaseRoutes.ChannelForUser.Handle("/drafts/{thread_id:[A-Za-z0-9]+}", api.APISessionRequired(deleteDraft)).Methods(http.MethodDelete)
 	api.BaseRoutes.ChannelForUser.Handle("/drafts", api.APISessionRequired(deleteDraft)).Methods(http.MethodDelete)
+
+	api.BaseRoutes.ChannelForUser.Handle("/drafts/{thread_id:[A-Za-z0-9]+}/revisions", api.APISessionRequired(getDraftHistory)).Methods(http.MethodGet)
+	api.BaseRoutes.ChannelForUser.Handle("/drafts/revisions", api.APISessionRequired(getDraftHistory)).Methods(http.MethodGet)
+	api.BaseRoutes.ChannelForUser.Handle("/drafts/{thread_id:[A-Za-z0-9]+}/revisions/{draft_version:[0-9]+}/restore", api.APISessionRequired(restoreDraftRevision)).Methods(http.MethodPost)
+	api.BaseRoutes.ChannelForUser.Handle("/drafts/revisions/{draft_version:[0-9]+}/restore", api.APISessionRequired(restoreDraftRevision)).Methods(http.MethodPost)
 }
 
 func upsertDraft(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -142,3 +147,64 @@ func deleteDraft(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	ReturnStatusOK(w)
 }
+
+func getDraftHistory(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !*c.App.Config().ServiceSettings.AllowSyncedDrafts {
+		c.Err = model.NewAppError("getDraftHistory", "api.drafts.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	if c.AppContext.Session().UserId != c.Params.UserId {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	history, err := c.App.GetDraftHistory(c.AppContext, c.Params.UserId, c.Params.ChannelId, c.Params.ThreadId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func restoreDraftRevision(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireChannelId().RequireDraftVersion()
+	if c.Err != nil {
+		return
+	}
+
+	if !*c.App.Config().ServiceSettings.AllowSyncedDrafts {
+		c.Err = model.NewAppError("restoreDraftRevision", "api.drafts.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	if c.AppContext.Session().UserId != c.Params.UserId {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionCreatePost) {
+		c.SetPermissionError(model.PermissionCreatePost)
+		return
+	}
+
+	connectionID := r.Header.Get(model.ConnectionId)
+
+	draft, err := c.App.RestoreDraftRevision(c.AppContext, c.Params.UserId, c.Params.ChannelId, c.Params.ThreadId, c.Params.DraftVersion, connectionID)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(draft); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -5,6 +5,7 @@ package api4
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"testing"
 	"time"
@@ -225,4 +226,83 @@ func TestDeleteDraft(t *testing.T) {
 	assert.Equal(t, draft2.Message, draftResp[0].Message)
 	assert.Equal(t, draft2.ChannelId, draftResp[0].ChannelId)
 	assert.Len(t, draftResp, 1)
+
+	// try to delete draft2, which is the draft of a thread
+	_, _, err = client.DeleteDraft(context.Background(), user.Id, channel2.Id, draft2.RootId)
+	require.NoError(t, err)
+
+	draftResp, _, err = client.GetDrafts(context.Background(), user.Id, team.Id)
+	require.NoError(t, err)
+	assert.Empty(t, draftResp)
+}
+
+func TestDraftHistory(t *testing.T) {
+	os.Setenv("MM_FEATUREFLAGS_GLOBALDRAFTS", "true")
+	defer os.Unsetenv("MM_FEATUREFLAGS_GLOBALDRAFTS")
+	os.Setenv("MM_SERVICESETTINGS_ALLOWSYNCEDDRAFTS", "true")
+	defer os.Unsetenv("MM_SERVICESETTINGS_ALLOWSYNCEDDRAFTS")
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.AllowSyncedDrafts = true })
+
+	client := th.Client
+	channel := th.BasicChannel
+	user := th.BasicUser
+	rootID := th.BasicPost.Id
+
+	first, _, err := client.UpsertDraft(context.Background(), &model.Draft{
+		UserId:    user.Id,
+		ChannelId: channel.Id,
+		RootId:    rootID,
+		Message:   "first",
+	})
+	require.NoError(t, err)
+
+	second, _, err := client.UpsertDraft(context.Background(), &model.Draft{
+		UserId:    user.Id,
+		ChannelId: channel.Id,
+		RootId:    rootID,
+		Message:   "second",
+		Version:   first.Version,
+	})
+	require.NoError(t, err)
+
+	// saving over an outdated version is a conflict
+	_, resp, err := client.UpsertDraft(context.Background(), &model.Draft{
+		UserId:    user.Id,
+		ChannelId: channel.Id,
+		RootId:    rootID,
+		Message:   "from another device",
+		Version:   first.Version,
+	})
+	require.Error(t, err)
+	checkHTTPStatus(t, resp, http.StatusConflict)
+
+	history, _, err := client.GetDraftHistory(context.Background(), user.Id, channel.Id, rootID)
+	require.NoError(t, err)
+	assert.Equal(t, "second", history.Draft.Message)
+	assert.Equal(t, second.Version, history.Draft.Version)
+	require.Len(t, history.Revisions, 1)
+	assert.Equal(t, "first", history.Revisions[0].Message)
+
+	draft, _, err := client.RestoreDraftRevision(context.Background(), user.Id, channel.Id, rootID, first.Version)
+	require.NoError(t, err)
+	assert.Equal(t, "first", draft.Message)
+
+	// another user can't see the history
+	_, resp, err = th.Client.GetDraftHistory(context.Background(), th.BasicUser2.Id, channel.Id, rootID)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	// the history of a missing draft is not found
+	_, resp, err = client.GetDraftHistory(context.Background(), user.Id, channel.Id, "")
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+
+	// restoring a missing revision is not found
+	_, resp, err = client.RestoreDraftRevision(context.Background(), user.Id, channel.Id, rootID, draft.Version+1)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
 }
@@ -17,8 +17,10 @@ import (
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/channels/app"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imaging"
 	"github.com/mattermost/mattermost/server/v8/channels/audit"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/web"
 )
 
@@ -547,15 +549,43 @@ func getFileThumbnail(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	fileReader, err := c.App.FileReader(info.ThumbnailPath)
-	if err != nil {
-		c.Err = err
-		c.Err.StatusCode = http.StatusNotFound
-		return
+	writeImageResponse(c, w, r, info, info.ThumbnailPath, ThumbnailImageType, forceDownload)
+}
+
+// writeImageResponse writes the preview or thumbnail image at the given path,
+// encoded in the best format accepted by the client unless it's downloaded.
+func writeImageResponse(c *Context, w http.ResponseWriter, r *http.Request, info *model.FileInfo, path, contentType string, forceDownload bool) {
+	var fileReader filestore.ReadCloseSeeker
+	var err *model.AppError
+	if format := imaging.AnimatedPreviewFormat(path); format != "" {
+		// Animated previews are served as is, re-encoding would lose frames.
+		contentType = imaging.FormatContentType(format)
+	} else if c.App.ImageVariantsEnabled() && !forceDownload {
+		w.Header().Add("Vary", "Accept")
+		if format := imaging.NegotiateFormat(r.Header.Get("Accept")); format != "" {
+			fileReader, err = c.App.ImageVariantReader(c.AppContext, path, format)
+			if err != nil {
+				// Images uploaded before variants were enabled don't have any.
+				if err.StatusCode != http.StatusNotFound {
+					c.Logger.Warn("Unable to get the image variant, falling back to the original image", mlog.String("path", path), mlog.String("format", format), mlog.Err(err))
+				}
+			} else {
+				contentType = imaging.FormatContentType(format)
+			}
+		}
+	}
+
+	if fileReader == nil {
+		fileReader, err = c.App.FileReader(path)
+		if err != nil {
+			c.Err = err
+			c.Err.StatusCode = http.StatusNotFound
+			return
+		}
 	}
 	defer fileReader.Close()
 
-	web.WriteFileResponse(info.Name, ThumbnailImageType, 0, time.Unix(0, info.UpdateAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, fileReader, forceDownload, w, r)
+	web.WriteFileResponse(info.Name, contentType, 0, time.Unix(0, info.UpdateAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, fileReader, forceDownload, w, r)
 }
 
 func getFileLink(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -647,15 +677,7 @@ func getFilePreview(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	fileReader, err := c.App.FileReader(info.PreviewPath)
-	if err != nil {
-		c.Err = err
-		c.Err.StatusCode = http.StatusNotFound
-		return
-	}
-	defer fileReader.Close()
-
-	web.WriteFileResponse(info.Name, PreviewImageType, 0, time.Unix(0, info.UpdateAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, fileReader, forceDownload, w, r)
+	writeImageResponse(c, w, r, info, info.PreviewPath, PreviewImageType, forceDownload)
 }
 
 func getFileInfo(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -257,8 +257,9 @@ func TestUploadFiles(t *testing.T) {
 			expectedImageMiniPreview: []bool{true, true, false, false, true},
 			expectedCreatorId:        th.BasicUser.Id,
 		},
-		// Upload a bunch of images. testgif.gif is an animated GIF,
-		// so it does not have HasPreviewImage set.
+		// Upload a bunch of images. testgif.gif is an animated GIF whose
+		// animated preview wouldn't be smaller than the original, so it does
+		// not have HasPreviewImage set.
 		{
 			title:                    "Happy images",
 			names:                    []string{"test.png", "testgif.gif"},
@@ -901,6 +902,36 @@ func TestGetFileThumbnail(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEqual(t, 0, len(data), "should not be empty")
 
+	t.Run("negotiated format", func(t *testing.T) {
+		getThumbnailType := func(fileId, accept string) string {
+			r, err := client.DoAPIRequestWithHeaders(context.Background(), http.MethodGet, client.APIURL+"/files/"+fileId+"/thumbnail", "", map[string]string{"Accept": accept})
+			require.NoError(t, err)
+			defer closeBody(r)
+			return r.Header.Get("Content-Type")
+		}
+
+		enabled := *th.App.Config().FileSettings.EnableImageVariants
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.EnableImageVariants = enabled })
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.EnableImageVariants = true })
+		require.True(t, th.App.ImageVariantsEnabled())
+
+		variantResp, _, err := client.UploadFile(context.Background(), sent, channel.Id, "variant.png")
+		require.NoError(t, err)
+		variantFileId := variantResp.FileInfos[0].Id
+
+		for accept, contentType := range map[string]string{
+			"image/avif,image/webp,*/*": "image/avif",
+			"image/webp,*/*":            "image/webp",
+			"image/*,*/*":               ThumbnailImageType,
+		} {
+			assert.Equal(t, contentType, getThumbnailType(variantFileId, accept), accept)
+
+			// Variants are generated with the thumbnail, so the files
+			// uploaded while they were disabled are served as is.
+			assert.Equal(t, ThumbnailImageType, getThumbnailType(fileId, accept), accept)
+		}
+	})
+
 	_, resp, err := client.GetFileThumbnail(context.Background(), "junk")
 	require.Error(t, err)
 	CheckBadRequestStatus(t, resp)
@@ -0,0 +1,205 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+)
+
+func (api *API) InitPersistentNotificationEscalation() {
+	api.BaseRoutes.ChannelEscalations.Handle("", api.APISessionRequired(getPersistentNotificationEscalations)).Methods(http.MethodGet)
+	api.BaseRoutes.ChannelEscalations.Handle("", api.APISessionRequired(createPersistentNotificationEscalation)).Methods(http.MethodPost)
+	api.BaseRoutes.ChannelEscalation.Handle("", api.APISessionRequired(deletePersistentNotificationEscalation)).Methods(http.MethodDelete)
+	api.BaseRoutes.Post.Handle("/persistent_notification_escalations", api.APISessionRequired(getPostPersistentNotificationEscalations)).Methods(http.MethodGet)
+}
+
+func requirePersistentNotificationsEnabled(c *Context, where string) {
+	if !c.App.IsPersistentNotificationsEnabled() {
+		c.Err = model.NewAppError(where, "api.persistent_notification_escalation.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+}
+
+// hasPermissionToManageEscalations checks that the session can manage the
+// properties of the channel. Direct and group messages have no escalation
+// chain since they don't have channel properties permissions.
+func hasPermissionToManageEscalations(c *Context, channel *model.Channel) {
+	var permission *model.Permission
+	switch channel.Type {
+	case model.ChannelTypeOpen:
+		permission = model.PermissionManagePublicChannelProperties
+	case model.ChannelTypePrivate:
+		permission = model.PermissionManagePrivateChannelProperties
+	default:
+		c.Err = model.NewAppError("hasPermissionToManageEscalations", "api.persistent_notification_escalation.direct_or_group_channels.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), channel.Id, permission) {
+		c.SetPermissionError(permission)
+	}
+}
+
+func getPersistentNotificationEscalations(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	requirePersistentNotificationsEnabled(c, "getPersistentNotificationEscalations")
+	if c.Err != nil {
+		return
+	}
+
+	channel, appErr := c.App.GetChannel(c.AppContext, c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if !c.App.SessionHasPermissionToReadChannel(c.AppContext, *c.AppContext.Session(), channel) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	escalations, appErr := c.App.GetPersistentNotificationEscalations(channel.Id)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(escalations); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func createPersistentNotificationEscalation(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	requirePersistentNotificationsEnabled(c, "createPersistentNotificationEscalation")
+	if c.Err != nil {
+		return
+	}
+
+	var escalation *model.PersistentNotificationEscalation
+	if err := json.NewDecoder(r.Body).Decode(&escalation); err != nil || escalation == nil {
+		c.SetInvalidParamWithErr("escalation", err)
+		return
+	}
+	escalation.Id = ""
+	escalation.ChannelId = c.Params.ChannelId
+	escalation.CreatorId = c.AppContext.Session().UserId
+
+	auditRec := c.MakeAuditRecord("createPersistentNotificationEscalation", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameterAuditable(auditRec, "escalation", escalation)
+
+	channel, appErr := c.App.GetChannel(c.AppContext, c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	hasPermissionToManageEscalations(c, channel)
+	if c.Err != nil {
+		return
+	}
+
+	// Escalation messages can only be posted where the user could post them.
+	if escalation.Type == model.PersistentNotificationEscalationTypeChannel &&
+		!c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), escalation.FallbackChannelId, model.PermissionCreatePost) {
+		c.SetPermissionError(model.PermissionCreatePost)
+		return
+	}
+
+	saved, appErr := c.App.CreatePersistentNotificationEscalation(c.AppContext, escalation)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(saved)
+	auditRec.AddEventObjectType("persistentNotificationEscalation")
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(saved); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deletePersistentNotificationEscalation(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+	if !model.IsValidId(c.Params.EscalationId) {
+		c.SetInvalidURLParam("escalation_id")
+		return
+	}
+
+	requirePersistentNotificationsEnabled(c, "deletePersistentNotificationEscalation")
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deletePersistentNotificationEscalation", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "channel_id", c.Params.ChannelId)
+	audit.AddEventParameter(auditRec, "escalation_id", c.Params.EscalationId)
+
+	channel, appErr := c.App.GetChannel(c.AppContext, c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	hasPermissionToManageEscalations(c, channel)
+	if c.Err != nil {
+		return
+	}
+
+	if appErr := c.App.DeletePersistentNotificationEscalation(channel.Id, c.Params.EscalationId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
+func getPostPersistentNotificationEscalations(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	requirePersistentNotificationsEnabled(c, "getPostPersistentNotificationEscalations")
+	if c.Err != nil {
+		return
+	}
+
+	post, appErr := c.App.GetPostIfAuthorized(c.AppContext, c.Params.PostId, c.AppContext.Session(), false)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	escalations, appErr := c.App.GetPostPersistentNotificationEscalations(post)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(escalations); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
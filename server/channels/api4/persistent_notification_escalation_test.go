@@ -0,0 +1,100 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestPersistentNotificationEscalations(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	th.App.Srv().SetLicense(model.NewTestLicenseSKU(model.LicenseShortSkuProfessional))
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.PostPriority = true
+		*cfg.ServiceSettings.AllowPersistentNotifications = true
+	})
+
+	fallback := th.CreatePublicChannel()
+
+	t.Run("should return not implemented when persistent notifications are disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.AllowPersistentNotifications = false
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.AllowPersistentNotifications = true
+		})
+
+		_, resp, err := client.GetPersistentNotificationEscalations(context.Background(), th.BasicChannel.Id)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	var step *model.PersistentNotificationEscalation
+	t.Run("create, list and delete a step", func(t *testing.T) {
+		var resp *model.Response
+		var err error
+		step, resp, err = client.CreatePersistentNotificationEscalation(context.Background(), &model.PersistentNotificationEscalation{
+			ChannelId:         th.BasicChannel.Id,
+			AfterCount:        2,
+			Type:              model.PersistentNotificationEscalationTypeChannel,
+			FallbackChannelId: fallback.Id,
+		})
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, th.BasicUser.Id, step.CreatorId)
+
+		escalations, _, err := client.GetPersistentNotificationEscalations(context.Background(), th.BasicChannel.Id)
+		require.NoError(t, err)
+		require.Len(t, escalations, 1)
+		assert.Equal(t, step.Id, escalations[0].Id)
+
+		_, err = client.DeletePersistentNotificationEscalation(context.Background(), th.BasicChannel.Id, step.Id)
+		require.NoError(t, err)
+
+		resp, err = client.DeletePersistentNotificationEscalation(context.Background(), th.BasicChannel.Id, step.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("should not allow direct messages", func(t *testing.T) {
+		dm := th.CreateDmChannel(th.BasicUser2)
+		_, resp, err := client.CreatePersistentNotificationEscalation(context.Background(), &model.PersistentNotificationEscalation{
+			ChannelId:         dm.Id,
+			AfterCount:        2,
+			Type:              model.PersistentNotificationEscalationTypeChannel,
+			FallbackChannelId: fallback.Id,
+		})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("should require permission to manage the channel", func(t *testing.T) {
+		th.RemovePermissionFromRole(model.PermissionManagePublicChannelProperties.Id, model.ChannelUserRoleId)
+		defer th.AddPermissionToRole(model.PermissionManagePublicChannelProperties.Id, model.ChannelUserRoleId)
+
+		_, resp, err := client.CreatePersistentNotificationEscalation(context.Background(), &model.PersistentNotificationEscalation{
+			ChannelId:         th.BasicChannel.Id,
+			AfterCount:        2,
+			Type:              model.PersistentNotificationEscalationTypeChannel,
+			FallbackChannelId: fallback.Id,
+		})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("should return not found for posts without persistent notification", func(t *testing.T) {
+		_, resp, err := client.GetPostPersistentNotificationEscalations(context.Background(), th.BasicPost.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
@@ -2814,6 +2814,44 @@ func TestGetPost(t *testing.T) {
 	CheckNotFoundStatus(t, resp)
 }
 
+func TestGetPostEtagWithTranslationLanguage(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	_, resp, err := client.GetPost(context.Background(), th.BasicPost.Id, "")
+	require.NoError(t, err)
+	etag := resp.Etag
+
+	setLanguage := func(language string) {
+		t.Helper()
+		_, err := client.UpdatePreferences(context.Background(), th.BasicUser.Id, model.Preferences{{
+			UserId:   th.BasicUser.Id,
+			Category: model.PreferenceCategoryDisplaySettings,
+			Name:     model.PreferenceNameTranslationLanguage,
+			Value:    language,
+		}})
+		require.NoError(t, err)
+	}
+
+	setLanguage("es")
+	post, resp, err := client.GetPost(context.Background(), th.BasicPost.Id, etag)
+	require.NoError(t, err)
+	CheckOKStatus(t, resp)
+	require.NotNil(t, post, "a change of the translation language should invalidate the ETag")
+	require.NotEqual(t, etag, resp.Etag)
+
+	post, resp, err = client.GetPost(context.Background(), th.BasicPost.Id, resp.Etag)
+	require.NoError(t, err)
+	CheckEtag(t, post, resp)
+
+	setLanguage("fr")
+	post, resp, err = client.GetPost(context.Background(), th.BasicPost.Id, resp.Etag)
+	require.NoError(t, err)
+	CheckOKStatus(t, resp)
+	require.NotNil(t, post)
+}
+
 func TestDeletePost(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -4656,3 +4694,44 @@ func TestUnacknowledgePost(t *testing.T) {
 	require.Error(t, err)
 	CheckUnauthorizedStatus(t, resp)
 }
+
+func TestGetAndDeletePostReminders(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	client := th.Client
+
+	targetTime := time.Now().UTC().Add(time.Hour).Unix()
+	_, err := client.SetPostReminder(context.Background(), &model.PostReminder{
+		TargetTime: targetTime,
+		PostId:     th.BasicPost.Id,
+		UserId:     th.BasicUser.Id,
+	})
+	require.NoError(t, err)
+
+	reminders, _, err := client.GetPostReminders(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, th.BasicPost.Id, reminders[0].PostId)
+	assert.Equal(t, targetTime, reminders[0].TargetTime)
+
+	_, resp, err := client.GetPostReminders(context.Background(), th.BasicUser2.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	resp, err = client.DeletePostReminder(context.Background(), th.BasicUser2.Id, th.BasicPost.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	resp, err = client.DeletePostReminder(context.Background(), th.BasicUser.Id, th.BasicPost.Id)
+	require.NoError(t, err)
+	CheckOKStatus(t, resp)
+
+	reminders, _, err = client.GetPostReminders(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	assert.Empty(t, reminders)
+
+	resp, err = client.DeletePostReminder(context.Background(), th.BasicUser.Id, th.BasicPost.Id)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+}
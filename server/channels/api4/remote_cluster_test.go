@@ -664,3 +664,52 @@ func TestDeleteRemoteCluster(t *testing.T) {
 		require.NotZero(t, deletedRC.DeleteAt)
 	})
 }
+
+func TestPingRemoteCluster(t *testing.T) {
+	t.Run("Should not work if the remote cluster service is not enabled", func(t *testing.T) {
+		th := Setup(t)
+		defer th.TearDown()
+
+		rc, resp, err := th.SystemAdminClient.PingRemoteCluster(context.Background(), model.NewId())
+		CheckNotImplementedStatus(t, resp)
+		require.Error(t, err)
+		require.Nil(t, rc)
+	})
+
+	th := setupForSharedChannels(t).InitBasic()
+	defer th.TearDown()
+
+	// nothing listens on this port, so the ping fails right away
+	rc, appErr := th.App.AddRemoteCluster(&model.RemoteCluster{Name: "remotecluster", SiteURL: "http://localhost:1", Token: model.NewId(), CreatorId: th.SystemAdminUser.Id})
+	require.Nil(t, appErr)
+
+	t.Run("Should not work if the user doesn't have the right permissions", func(t *testing.T) {
+		_, resp, err := th.Client.PingRemoteCluster(context.Background(), rc.RemoteId)
+		CheckForbiddenStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	t.Run("should return not found if the id doesn't exist", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.PingRemoteCluster(context.Background(), model.NewId())
+		CheckNotFoundStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	t.Run("should not ping a remote that hasn't accepted the invitation", func(t *testing.T) {
+		pending, appErr := th.App.AddRemoteCluster(&model.RemoteCluster{Name: "pending", SiteURL: model.SiteURLPending + model.NewId(), CreatorId: th.SystemAdminUser.Id})
+		require.Nil(t, appErr)
+
+		_, resp, err := th.SystemAdminClient.PingRemoteCluster(context.Background(), pending.RemoteId)
+		CheckBadRequestStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	t.Run("should return the sanitized remote cluster", func(t *testing.T) {
+		pinged, resp, err := th.SystemAdminClient.PingRemoteCluster(context.Background(), rc.RemoteId)
+		CheckOKStatus(t, resp)
+		require.NoError(t, err)
+		require.Equal(t, rc.RemoteId, pinged.RemoteId)
+		require.Empty(t, pinged.Token)
+		require.False(t, pinged.IsOnline())
+	})
+}
@@ -23,10 +23,12 @@ var notAllowedPermissions = []string{
 
 func (api *API) InitRole() {
 	api.BaseRoutes.Roles.Handle("", api.APISessionRequired(getAllRoles)).Methods(http.MethodGet)
+	api.BaseRoutes.Roles.Handle("", api.APISessionRequired(createRole)).Methods(http.MethodPost)
 	api.BaseRoutes.Roles.Handle("/{role_id:[A-Za-z0-9]+}", api.APISessionRequiredTrustRequester(getRole)).Methods(http.MethodGet)
 	api.BaseRoutes.Roles.Handle("/name/{role_name:[a-z0-9_]+}", api.APISessionRequiredTrustRequester(getRoleByName)).Methods(http.MethodGet)
 	api.BaseRoutes.Roles.Handle("/names", api.APISessionRequiredTrustRequester(getRolesByNames)).Methods(http.MethodPost)
 	api.BaseRoutes.Roles.Handle("/{role_id:[A-Za-z0-9]+}/patch", api.APISessionRequired(patchRole)).Methods(http.MethodPut)
+	api.BaseRoutes.Roles.Handle("/{role_id:[A-Za-z0-9]+}", api.APISessionRequired(deleteRole)).Methods(http.MethodDelete)
 }
 
 func getAllRoles(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -236,3 +238,84 @@ func patchRole(c *Context, w http.ResponseWriter, r *http.Request) {
 		c.Logger.Warn("Error while writing response", mlog.Err(err))
 	}
 }
+
+func createRole(c *Context, w http.ResponseWriter, r *http.Request) {
+	var role model.Role
+	if err := json.NewDecoder(r.Body).Decode(&role); err != nil {
+		c.SetInvalidParamWithErr("role", err)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createRole", audit.Fail)
+	audit.AddEventParameterAuditable(auditRec, "role", &role)
+	defer c.LogAuditRec(auditRec)
+
+	// custom roles can be assigned to any user, so only system admins can create them.
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	if !role.IsValidWithoutId() {
+		c.SetInvalidParam("role")
+		return
+	}
+
+	for _, permission := range role.Permissions {
+		for _, notAllowedPermission := range notAllowedPermissions {
+			if permission == notAllowedPermission {
+				c.Err = model.NewAppError("Api4.CreateRole", "api.roles.patch_roles.not_allowed_permission.error", nil, "Cannot add permission: "+permission, http.StatusNotImplemented)
+				return
+			}
+		}
+	}
+	role.Permissions = model.RemoveDuplicateStrings(role.Permissions)
+
+	if _, appErr := c.App.GetRoleByName(r.Context(), role.Name); appErr == nil {
+		c.Err = model.NewAppError("Api4.CreateRole", "api.roles.create_role.name_taken.app_error", map[string]any{"Name": role.Name}, "", http.StatusBadRequest)
+		return
+	}
+
+	created, appErr := c.App.CreateRole(&role)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.AddEventResultState(created)
+	auditRec.AddEventObjectType("role")
+	auditRec.Success()
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(created); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteRole(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireRoleId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteRole", audit.Fail)
+	audit.AddEventParameter(auditRec, "role_id", c.Params.RoleId)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	role, appErr := c.App.DeleteRole(c.Params.RoleId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.AddEventPriorState(role)
+	auditRec.AddEventObjectType("role")
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
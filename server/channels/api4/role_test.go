@@ -361,3 +361,92 @@ func TestPatchRole(t *testing.T) {
 		})
 	})
 }
+
+func TestCreateRole(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	t.Run("NormalClient", func(t *testing.T) {
+		_, resp, err := th.Client.CreateRole(context.Background(), &model.Role{Name: "custom_" + model.NewId()[:8], DisplayName: "Custom"})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		name := "custom_" + model.NewId()[:8]
+
+		role, resp, err := client.CreateRole(context.Background(), &model.Role{
+			Name:          name,
+			DisplayName:   "Custom",
+			Permissions:   []string{model.PermissionCreatePost.Id, model.PermissionCreatePost.Id},
+			BuiltIn:       true,
+			SchemeManaged: true,
+		})
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.NotEmpty(t, role.Id)
+		assert.Equal(t, name, role.Name)
+		assert.Equal(t, []string{model.PermissionCreatePost.Id}, role.Permissions)
+		assert.False(t, role.BuiltIn)
+		assert.False(t, role.SchemeManaged)
+
+		_, resp, err = client.CreateRole(context.Background(), &model.Role{Name: name, DisplayName: "Again"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		_, resp, err := client.CreateRole(context.Background(), &model.Role{Name: "Invalid Name", DisplayName: "Invalid"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = client.CreateRole(context.Background(), &model.Role{Name: "custom_" + model.NewId()[:8], DisplayName: "Invalid", Permissions: []string{"not_a_permission"}})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = client.CreateRole(context.Background(), &model.Role{Name: "custom_" + model.NewId()[:8], DisplayName: "Admin", Permissions: []string{model.PermissionManageSystem.Id}})
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	}, "invalid roles")
+}
+
+func TestDeleteRole(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	systemUserRole, appErr := th.App.GetRoleByName(context.Background(), model.SystemUserRoleId)
+	require.Nil(t, appErr)
+
+	t.Run("NormalClient", func(t *testing.T) {
+		resp, err := th.Client.DeleteRole(context.Background(), systemUserRole.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		role, appErr := th.App.CreateRole(&model.Role{Name: "custom_" + model.NewId()[:8], DisplayName: "Custom"})
+		require.Nil(t, appErr)
+
+		resp, err := client.DeleteRole(context.Background(), role.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+
+		deleted, appErr := th.App.GetRole(role.Id)
+		require.Nil(t, appErr)
+		assert.NotZero(t, deleted.DeleteAt)
+
+		// the name of a deleted role stays taken
+		_, resp, err = client.CreateRole(context.Background(), &model.Role{Name: role.Name, DisplayName: "Again"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+		CheckErrorID(t, err, "api.roles.create_role.name_taken.app_error")
+
+		resp, err = client.DeleteRole(context.Background(), systemUserRole.Id)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		resp, err = client.DeleteRole(context.Background(), model.NewId())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
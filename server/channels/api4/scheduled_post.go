@@ -20,7 +20,10 @@ func (api *API) InitScheduledPost() {
 	api.BaseRoutes.Posts.Handle("/schedule", api.APISessionRequired(createSchedulePost)).Methods(http.MethodPost)
 	api.BaseRoutes.Posts.Handle("/schedule/{scheduled_post_id:[A-Za-z0-9]+}", api.APISessionRequired(updateScheduledPost)).Methods(http.MethodPut)
 	api.BaseRoutes.Posts.Handle("/schedule/{scheduled_post_id:[A-Za-z0-9]+}", api.APISessionRequired(deleteScheduledPost)).Methods(http.MethodDelete)
+	api.BaseRoutes.Posts.Handle("/schedule/{scheduled_post_id:[A-Za-z0-9]+}/pause", api.APISessionRequired(pauseScheduledPost)).Methods(http.MethodPost)
+	api.BaseRoutes.Posts.Handle("/schedule/{scheduled_post_id:[A-Za-z0-9]+}/resume", api.APISessionRequired(resumeScheduledPost)).Methods(http.MethodPost)
 	api.BaseRoutes.Posts.Handle("/scheduled/team/{team_id:[A-Za-z0-9]+}", api.APISessionRequired(getTeamScheduledPosts)).Methods(http.MethodGet)
+	api.BaseRoutes.Posts.Handle("/scheduled/series", api.APISessionRequired(getScheduledPostSeries)).Methods(http.MethodGet)
 }
 
 func scheduledPostChecks(where string, c *Context, scheduledPost *model.ScheduledPost) {
@@ -227,3 +230,74 @@ func deleteScheduledPost(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func getScheduledPostSeries(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireScheduledPostsEnabled(c)
+	if c.Err != nil {
+		return
+	}
+
+	scheduledPosts, appErr := c.App.GetUserScheduledPostSeries(c.AppContext, c.AppContext.Session().UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(scheduledPosts); err != nil {
+		mlog.Error("failed to encode scheduled posts to return API response", mlog.Err(err))
+		return
+	}
+}
+
+func pauseScheduledPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	setScheduledPostSeriesPaused(c, w, r, true)
+}
+
+func resumeScheduledPost(c *Context, w http.ResponseWriter, r *http.Request) {
+	setScheduledPostSeriesPaused(c, w, r, false)
+}
+
+func setScheduledPostSeriesPaused(c *Context, w http.ResponseWriter, r *http.Request, paused bool) {
+	requireScheduledPostsEnabled(c)
+	if c.Err != nil {
+		return
+	}
+
+	scheduledPostId := mux.Vars(r)["scheduled_post_id"]
+	if scheduledPostId == "" {
+		c.SetInvalidURLParam("scheduled_post_id")
+		return
+	}
+
+	event := "resumeScheduledPost"
+	if paused {
+		event = "pauseScheduledPost"
+	}
+	auditRec := c.MakeAuditRecord(event, audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "scheduledPostId", scheduledPostId)
+
+	userId := c.AppContext.Session().UserId
+	connectionID := r.Header.Get(model.ConnectionId)
+
+	var scheduledPost *model.ScheduledPost
+	var appErr *model.AppError
+	if paused {
+		scheduledPost, appErr = c.App.PauseScheduledPost(c.AppContext, userId, scheduledPostId, connectionID)
+	} else {
+		scheduledPost, appErr = c.App.ResumeScheduledPost(c.AppContext, userId, scheduledPostId, connectionID)
+	}
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(scheduledPost)
+	auditRec.AddEventObjectType("scheduledPost")
+
+	if err := json.NewEncoder(w).Encode(scheduledPost); err != nil {
+		mlog.Error("failed to encode scheduled post to return API response", mlog.Err(err))
+		return
+	}
+}
@@ -15,6 +15,9 @@ import (
 func (api *API) InitSharedChannels() {
 	api.BaseRoutes.SharedChannels.Handle("/{team_id:[A-Za-z0-9]+}", api.APISessionRequired(getSharedChannels)).Methods(http.MethodGet)
 	api.BaseRoutes.SharedChannels.Handle("/remote_info/{remote_id:[A-Za-z0-9]+}", api.APISessionRequired(getRemoteClusterInfo)).Methods(http.MethodGet)
+	api.BaseRoutes.SharedChannels.Handle("/channels/{channel_id:[A-Za-z0-9]+}", api.APISessionRequired(shareChannel)).Methods(http.MethodPost)
+	api.BaseRoutes.SharedChannels.Handle("/channels/{channel_id:[A-Za-z0-9]+}", api.APISessionRequired(unshareChannel)).Methods(http.MethodDelete)
+	api.BaseRoutes.SharedChannels.Handle("/channels/{channel_id:[A-Za-z0-9]+}/status", api.APISessionRequired(getSharedChannelSyncStatus)).Methods(http.MethodGet)
 
 	api.BaseRoutes.SharedChannelRemotes.Handle("", api.APISessionRequired(getSharedChannelRemotesByRemoteCluster)).Methods(http.MethodGet)
 	api.BaseRoutes.ChannelForRemote.Handle("/invite", api.APISessionRequired(inviteRemoteClusterToChannel)).Methods(http.MethodPost)
@@ -246,3 +249,144 @@ func uninviteRemoteClusterToChannel(c *Context, w http.ResponseWriter, r *http.R
 	auditRec.Success()
 	ReturnStatusOK(w)
 }
+
+func shareChannel(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSharedChannels) {
+		c.SetPermissionError(model.PermissionManageSharedChannels)
+		return
+	}
+
+	// make sure remote cluster service is enabled.
+	if _, appErr := c.App.GetRemoteClusterService(); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	var sc model.SharedChannel
+	if jsonErr := json.NewDecoder(r.Body).Decode(&sc); jsonErr != nil {
+		c.SetInvalidParamWithErr("shared_channel", jsonErr)
+		return
+	}
+
+	channel, appErr := c.App.GetChannel(c.AppContext, c.Params.ChannelId)
+	if appErr != nil {
+		c.SetInvalidURLParam("channel_id")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("shareChannel", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "channel_id", c.Params.ChannelId)
+
+	// missing share properties are defaulted from the channel.
+	sc.ChannelId = channel.Id
+	sc.TeamId = channel.TeamId
+	sc.Type = channel.Type
+	sc.Home = true
+	sc.RemoteId = ""
+	sc.CreatorId = c.AppContext.Session().UserId
+
+	shared, err := c.App.ShareChannel(c.AppContext, &sc)
+	if err != nil {
+		if appErr, ok := err.(*model.AppError); ok {
+			c.Err = appErr
+		} else {
+			c.Err = model.NewAppError("shareChannel", "api.shared_channel.share_channel_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+		return
+	}
+
+	auditRec.Success()
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(shared); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func unshareChannel(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSharedChannels) {
+		c.SetPermissionError(model.PermissionManageSharedChannels)
+		return
+	}
+
+	// make sure remote cluster service is enabled.
+	if _, appErr := c.App.GetRemoteClusterService(); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if _, appErr := c.App.GetChannel(c.AppContext, c.Params.ChannelId); appErr != nil {
+		c.SetInvalidURLParam("channel_id")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("unshareChannel", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "channel_id", c.Params.ChannelId)
+
+	deleted, err := c.App.UnshareChannel(c.Params.ChannelId)
+	if err != nil {
+		if appErr, ok := err.(*model.AppError); ok {
+			c.Err = appErr
+		} else {
+			c.Err = model.NewAppError("unshareChannel", "api.shared_channel.unshare_channel_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		return
+	}
+	if !deleted {
+		c.Err = model.NewAppError("unshareChannel", "api.shared_channel.channel_not_shared.app_error", nil, "", http.StatusNotFound)
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
+func getSharedChannelSyncStatus(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSharedChannels) {
+		c.SetPermissionError(model.PermissionManageSharedChannels)
+		return
+	}
+
+	// make sure remote cluster service is enabled.
+	if _, appErr := c.App.GetRemoteClusterService(); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	shared, err := c.App.HasSharedChannel(c.Params.ChannelId)
+	if err != nil {
+		c.Err = model.NewAppError("getSharedChannelSyncStatus", "api.shared_channel.get_sync_status_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+	if !shared {
+		c.Err = model.NewAppError("getSharedChannelSyncStatus", "api.shared_channel.channel_not_shared.app_error", nil, "", http.StatusNotFound)
+		return
+	}
+
+	status, err := c.App.GetSharedChannelRemotesSyncStatus(c.Params.ChannelId)
+	if err != nil {
+		c.Err = model.NewAppError("getSharedChannelSyncStatus", "api.shared_channel.get_sync_status_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -622,3 +622,92 @@ func TestUninviteRemoteClusterToChannel(t *testing.T) {
 		t.Skip("Requires server2server communication: ToBeImplemented")
 	})
 }
+
+func TestShareChannel(t *testing.T) {
+	t.Run("Should not work if the remote cluster service is not enabled", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		_, resp, err := th.SystemAdminClient.ShareChannel(context.Background(), &model.SharedChannel{ChannelId: th.BasicChannel.Id})
+		CheckNotImplementedStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	th := setupForSharedChannels(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("Should not work if the user doesn't have the right permissions", func(t *testing.T) {
+		_, resp, err := th.Client.ShareChannel(context.Background(), &model.SharedChannel{ChannelId: th.BasicChannel.Id})
+		CheckForbiddenStatus(t, resp)
+		require.Error(t, err)
+
+		resp, err = th.Client.UnshareChannel(context.Background(), th.BasicChannel.Id)
+		CheckForbiddenStatus(t, resp)
+		require.Error(t, err)
+
+		_, resp, err = th.Client.GetSharedChannelSyncStatus(context.Background(), th.BasicChannel.Id)
+		CheckForbiddenStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	t.Run("should not work if the channel is nonexistent", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.ShareChannel(context.Background(), &model.SharedChannel{ChannelId: model.NewId()})
+		CheckBadRequestStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	t.Run("should share the channel with defaults from the channel", func(t *testing.T) {
+		sc, resp, err := th.SystemAdminClient.ShareChannel(context.Background(), &model.SharedChannel{ChannelId: th.BasicChannel.Id, ReadOnly: true})
+		CheckCreatedStatus(t, resp)
+		require.NoError(t, err)
+		require.Equal(t, th.BasicChannel.Id, sc.ChannelId)
+		require.Equal(t, th.BasicChannel.TeamId, sc.TeamId)
+		require.Equal(t, th.BasicChannel.Name, sc.ShareName)
+		require.Equal(t, th.BasicChannel.DisplayName, sc.ShareDisplayName)
+		require.Equal(t, th.SystemAdminUser.Id, sc.CreatorId)
+		require.True(t, sc.Home)
+		require.True(t, sc.ReadOnly)
+	})
+
+	t.Run("should return the sync status of the remotes of the channel", func(t *testing.T) {
+		rc, appErr := th.App.AddRemoteCluster(&model.RemoteCluster{Name: "rc", DisplayName: "Remote", SiteURL: "http://example.com", CreatorId: th.SystemAdminUser.Id})
+		require.Nil(t, appErr)
+
+		_, err := th.App.SaveSharedChannelRemote(&model.SharedChannelRemote{
+			ChannelId:        th.BasicChannel.Id,
+			CreatorId:        th.SystemAdminUser.Id,
+			RemoteId:         rc.RemoteId,
+			IsInviteAccepted: true,
+		})
+		require.NoError(t, err)
+
+		status, resp, err := th.SystemAdminClient.GetSharedChannelSyncStatus(context.Background(), th.BasicChannel.Id)
+		CheckOKStatus(t, resp)
+		require.NoError(t, err)
+		require.Len(t, status, 1)
+		require.Equal(t, rc.RemoteId, status[0].RemoteId)
+		require.Equal(t, "Remote", status[0].DisplayName)
+		require.Zero(t, status[0].PendingAttachments)
+		require.Empty(t, status[0].LastSyncError)
+	})
+
+	t.Run("should unshare the channel", func(t *testing.T) {
+		resp, err := th.SystemAdminClient.UnshareChannel(context.Background(), th.BasicChannel.Id)
+		CheckOKStatus(t, resp)
+		require.NoError(t, err)
+
+		shared, err := th.App.HasSharedChannel(th.BasicChannel.Id)
+		require.NoError(t, err)
+		require.False(t, shared)
+	})
+
+	t.Run("should return not found if the channel is not shared", func(t *testing.T) {
+		resp, err := th.SystemAdminClient.UnshareChannel(context.Background(), th.BasicChannel.Id)
+		CheckNotFoundStatus(t, resp)
+		require.Error(t, err)
+
+		_, resp, err = th.SystemAdminClient.GetSharedChannelSyncStatus(context.Background(), th.BasicChannel.Id)
+		CheckNotFoundStatus(t, resp)
+		require.Error(t, err)
+	})
+}
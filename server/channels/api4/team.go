@@ -1364,7 +1364,9 @@ func importTeam(c *Context, w http.ResponseWriter, r *http.Request) {
 		var err *model.AppError
 		if err, log = c.App.SlackImport(c.AppContext, fileData, fileSize, c.Params.TeamId); err != nil {
 			c.Err = err
-			c.Err.StatusCode = http.StatusBadRequest
+			if c.Err.StatusCode != http.StatusForbidden {
+				c.Err.StatusCode = http.StatusBadRequest
+			}
 		}
 		data["results"] = base64.StdEncoding.EncodeToString(log.Bytes())
 	default:
@@ -56,6 +56,9 @@ func (api *API) InitUser() {
 	api.BaseRoutes.User.Handle("/email/verify/member", api.APISessionRequired(verifyUserEmailWithoutToken)).Methods(http.MethodPost)
 	api.BaseRoutes.User.Handle("/terms_of_service", api.APISessionRequired(saveUserTermsOfService)).Methods(http.MethodPost)
 	api.BaseRoutes.User.Handle("/terms_of_service", api.APISessionRequired(getUserTermsOfService)).Methods(http.MethodGet)
+	api.BaseRoutes.User.Handle("/out_of_office", api.APISessionRequired(getOutOfOffice)).Methods(http.MethodGet)
+	api.BaseRoutes.User.Handle("/out_of_office", api.APISessionRequired(setOutOfOffice)).Methods(http.MethodPut)
+	api.BaseRoutes.User.Handle("/out_of_office", api.APISessionRequired(deleteOutOfOffice)).Methods(http.MethodDelete)
 
 	api.BaseRoutes.User.Handle("/auth", api.APISessionRequiredTrustRequester(updateUserAuth)).Methods(http.MethodPut)
 
@@ -3518,3 +3521,111 @@ func getUsersWithInvalidEmails(c *Context, w http.ResponseWriter, r *http.Reques
 		c.Logger.Warn("Error writing response", mlog.Err(err))
 	}
 }
+
+// checkOutOfOfficePermission checks that the session can manage the out of
+// office settings of the user in the request. Users manage their own, and
+// admins can manage them for others.
+func checkOutOfOfficePermission(c *Context) {
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	if c.AppContext.Session().UserId == c.Params.UserId {
+		return
+	}
+
+	user, appErr := c.App.GetUser(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	// Cannot update a system admin unless user making request is a systemadmin also
+	if user.IsSystemAdmin() && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+	}
+}
+
+func getOutOfOffice(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	checkOutOfOfficePermission(c)
+	if c.Err != nil {
+		return
+	}
+
+	outOfOffice, appErr := c.App.GetOutOfOffice(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(outOfOffice); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func setOutOfOffice(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	var outOfOffice model.OutOfOffice
+	if jsonErr := json.NewDecoder(r.Body).Decode(&outOfOffice); jsonErr != nil {
+		c.SetInvalidParamWithErr("out_of_office", jsonErr)
+		return
+	}
+	outOfOffice.UserId = c.Params.UserId
+
+	auditRec := c.MakeAuditRecord("setOutOfOffice", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "user_id", c.Params.UserId)
+	audit.AddEventParameterAuditable(auditRec, "out_of_office", &outOfOffice)
+
+	checkOutOfOfficePermission(c)
+	if c.Err != nil {
+		return
+	}
+
+	saved, appErr := c.App.SetOutOfOffice(c.AppContext, &outOfOffice)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(saved)
+
+	if err := json.NewEncoder(w).Encode(saved); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteOutOfOffice(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteOutOfOffice", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "user_id", c.Params.UserId)
+
+	checkOutOfOfficePermission(c)
+	if c.Err != nil {
+		return
+	}
+
+	if appErr := c.App.DeleteOutOfOffice(c.AppContext, c.Params.UserId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
@@ -8653,3 +8653,76 @@ func TestRevokeAllSessionsForUser(t *testing.T) {
 		CheckForbiddenStatus(t, resp)
 	})
 }
+
+func TestOutOfOffice(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	now := model.GetMillis()
+	outOfOffice := &model.OutOfOffice{
+		StartAt:        now - time.Hour.Milliseconds(),
+		EndAt:          now + time.Hour.Milliseconds(),
+		DMMessage:      "I'm on vacation",
+		MentionMessage: "I'm on vacation, I'll read this when I'm back",
+		DelegateUserId: th.BasicUser2.Id,
+	}
+
+	t.Run("set for self", func(t *testing.T) {
+		_, resp, err := th.Client.GetOutOfOffice(context.Background(), th.BasicUser.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+
+		saved, _, err := th.Client.SetOutOfOffice(context.Background(), th.BasicUser.Id, outOfOffice)
+		require.NoError(t, err)
+		assert.Equal(t, th.BasicUser.Id, saved.UserId)
+		assert.True(t, saved.Active)
+
+		got, _, err := th.Client.GetOutOfOffice(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		assert.Equal(t, saved, got)
+
+		status, _, err := th.Client.GetUserStatus(context.Background(), th.BasicUser.Id, "")
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusOutOfOffice, status.Status)
+
+		_, err = th.Client.DeleteOutOfOffice(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+
+		_, resp, err = th.Client.GetOutOfOffice(context.Background(), th.BasicUser.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		invalid := *outOfOffice
+		invalid.EndAt = invalid.StartAt
+		_, resp, err := th.Client.SetOutOfOffice(context.Background(), th.BasicUser.Id, &invalid)
+		CheckBadRequestStatus(t, resp)
+		CheckErrorID(t, err, "model.out_of_office.is_valid.schedule.app_error")
+	})
+
+	t.Run("cannot set for other users", func(t *testing.T) {
+		_, resp, err := th.Client.SetOutOfOffice(context.Background(), th.BasicUser2.Id, outOfOffice)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.GetOutOfOffice(context.Background(), th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = th.Client.DeleteOutOfOffice(context.Background(), th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("admins can set for other users", func(t *testing.T) {
+		forOther := *outOfOffice
+		forOther.DelegateUserId = th.BasicUser.Id
+		saved, _, err := th.SystemAdminClient.SetOutOfOffice(context.Background(), th.BasicUser2.Id, &forOther)
+		require.NoError(t, err)
+		assert.Equal(t, th.BasicUser2.Id, saved.UserId)
+
+		_, err = th.SystemAdminClient.DeleteOutOfOffice(context.Background(), th.BasicUser2.Id)
+		require.NoError(t, err)
+	})
+}
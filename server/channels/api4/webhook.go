@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/gorilla/mux"
+
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/channels/audit"
@@ -21,10 +23,13 @@ func (api *API) InitWebhook() {
 
 	api.BaseRoutes.OutgoingHooks.Handle("", api.APISessionRequired(createOutgoingHook)).Methods(http.MethodPost)
 	api.BaseRoutes.OutgoingHooks.Handle("", api.APISessionRequired(getOutgoingHooks)).Methods(http.MethodGet)
+	api.BaseRoutes.OutgoingHooks.Handle("/render", api.APISessionRequired(renderOutgoingHookPayload)).Methods(http.MethodPost)
 	api.BaseRoutes.OutgoingHook.Handle("", api.APISessionRequired(getOutgoingHook)).Methods(http.MethodGet)
 	api.BaseRoutes.OutgoingHook.Handle("", api.APISessionRequired(updateOutgoingHook)).Methods(http.MethodPut)
 	api.BaseRoutes.OutgoingHook.Handle("", api.APISessionRequired(deleteOutgoingHook)).Methods(http.MethodDelete)
 	api.BaseRoutes.OutgoingHook.Handle("/regen_token", api.APISessionRequired(regenOutgoingHookToken)).Methods(http.MethodPost)
+	api.BaseRoutes.OutgoingHook.Handle("/deliveries", api.APISessionRequired(getOutgoingHookDeliveries)).Methods(http.MethodGet)
+	api.BaseRoutes.OutgoingHook.Handle("/deliveries/{delivery_id:[A-Za-z0-9]+}/redeliver", api.APISessionRequired(redeliverOutgoingHook)).Methods(http.MethodPost)
 }
 
 func createIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -96,8 +101,18 @@ func updateIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var data json.RawMessage
+	if jsonErr := json.NewDecoder(r.Body).Decode(&data); jsonErr != nil {
+		c.SetInvalidParamWithErr("incoming_webhook", jsonErr)
+		return
+	}
+	var fields map[string]json.RawMessage
+	if jsonErr := json.Unmarshal(data, &fields); jsonErr != nil {
+		c.SetInvalidParamWithErr("incoming_webhook", jsonErr)
+		return
+	}
 	var updatedHook model.IncomingWebhook
-	if jsonErr := json.NewDecoder(r.Body).Decode(&updatedHook); jsonErr != nil {
+	if jsonErr := json.Unmarshal(data, &updatedHook); jsonErr != nil {
 		c.SetInvalidParamWithErr("incoming_webhook", jsonErr)
 		return
 	}
@@ -126,6 +141,14 @@ func updateIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 		updatedHook.TeamId = oldHook.TeamId
 	}
 
+	// Clients that don't know about the payload format and the secret must not reset them.
+	if _, ok := fields["payload_format"]; !ok {
+		updatedHook.PayloadFormat = oldHook.PayloadFormat
+	}
+	if _, ok := fields["secret"]; !ok {
+		updatedHook.Secret = oldHook.Secret
+	}
+
 	if updatedHook.TeamId != oldHook.TeamId {
 		c.Err = model.NewAppError("updateIncomingHook", "api.webhook.team_mismatch.app_error", nil, "user_id="+c.AppContext.Session().UserId, http.StatusBadRequest)
 		return
@@ -470,6 +493,40 @@ func createOutgoingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func renderOutgoingHookPayload(c *Context, w http.ResponseWriter, r *http.Request) {
+	var hook model.OutgoingWebhook
+	if jsonErr := json.NewDecoder(r.Body).Decode(&hook); jsonErr != nil {
+		c.SetInvalidParamWithErr("outgoing_webhook", jsonErr)
+		return
+	}
+
+	if !model.IsValidId(hook.TeamId) {
+		c.SetInvalidParam("team_id")
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), hook.TeamId, model.PermissionManageOutgoingWebhooks) {
+		c.SetPermissionError(model.PermissionManageOutgoingWebhooks)
+		return
+	}
+
+	user, err := c.App.GetUser(c.AppContext.Session().UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	rendered, err := c.App.RenderOutgoingWebhookPayload(c.AppContext, &hook, user)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(rendered); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func getOutgoingHooks(c *Context, w http.ResponseWriter, r *http.Request) {
 	var (
 		query     = r.URL.Query()
@@ -622,6 +679,94 @@ func regenOutgoingHookToken(c *Context, w http.ResponseWriter, r *http.Request)
 	}
 }
 
+func getOutgoingHookDeliveries(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId()
+	if c.Err != nil {
+		return
+	}
+
+	hook, err := c.App.GetOutgoingWebhook(c.Params.HookId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), hook.TeamId, model.PermissionManageOutgoingWebhooks) {
+		c.SetPermissionError(model.PermissionManageOutgoingWebhooks)
+		return
+	}
+
+	if c.AppContext.Session().UserId != hook.CreatorId && !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), hook.TeamId, model.PermissionManageOthersOutgoingWebhooks) {
+		c.SetPermissionError(model.PermissionManageOthersOutgoingWebhooks)
+		return
+	}
+
+	deliveries, err := c.App.GetOutgoingWebhookDeliveries(hook.Id, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(deliveries); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func redeliverOutgoingHook(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId()
+	if c.Err != nil {
+		return
+	}
+
+	deliveryID := mux.Vars(r)["delivery_id"]
+	if !model.IsValidId(deliveryID) {
+		c.SetInvalidURLParam("delivery_id")
+		return
+	}
+
+	hook, err := c.App.GetOutgoingWebhook(c.Params.HookId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("redeliverOutgoingHook", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "hook_id", c.Params.HookId)
+	audit.AddEventParameter(auditRec, "delivery_id", deliveryID)
+	auditRec.AddMeta("hook_id", hook.Id)
+	auditRec.AddMeta("hook_display", hook.DisplayName)
+	auditRec.AddMeta("channel_id", hook.ChannelId)
+	auditRec.AddMeta("team_id", hook.TeamId)
+	c.LogAudit("attempt")
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), hook.TeamId, model.PermissionManageOutgoingWebhooks) {
+		c.SetPermissionError(model.PermissionManageOutgoingWebhooks)
+		return
+	}
+
+	if c.AppContext.Session().UserId != hook.CreatorId && !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), hook.TeamId, model.PermissionManageOthersOutgoingWebhooks) {
+		c.LogAudit("fail - inappropriate permissions")
+		c.SetPermissionError(model.PermissionManageOthersOutgoingWebhooks)
+		return
+	}
+
+	delivery, err := c.App.RedeliverOutgoingWebhook(c.AppContext, hook, deliveryID)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.AddEventResultState(delivery)
+	auditRec.AddEventObjectType("outgoing_webhook_delivery")
+	auditRec.Success()
+	c.LogAudit("success")
+
+	if err := json.NewEncoder(w).Encode(delivery); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func deleteOutgoingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireHookId()
 	if c.Err != nil {
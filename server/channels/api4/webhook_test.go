@@ -5,6 +5,10 @@ package api4
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -790,6 +794,64 @@ func TestGetOutgoingWebhook(t *testing.T) {
 	})
 }
 
+func TestRenderOutgoingHookPayload(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = true })
+
+	defaultRolePermissions := th.SaveDefaultRolePermissions()
+	defer func() {
+		th.RestoreDefaultRolePermissions(defaultRolePermissions)
+	}()
+	th.AddPermissionToRole(model.PermissionManageOutgoingWebhooks.Id, model.TeamAdminRoleId)
+	th.RemovePermissionFromRole(model.PermissionManageOutgoingWebhooks.Id, model.TeamUserRoleId)
+
+	hook := &model.OutgoingWebhook{
+		ChannelId:       th.BasicChannel.Id,
+		TeamId:          th.BasicTeam.Id,
+		TriggerWords:    []string{"ship"},
+		PayloadTemplate: `{"text": {{json .Post.Message}}, "user": "{{.User.Username}}", "channel": "{{.Channel.Name}}"}`,
+		Headers:         model.StringMap{"X-Api-Key": "secret"},
+	}
+
+	t.Run("without permission", func(t *testing.T) {
+		_, resp, err := th.Client.RenderOutgoingWebhookPayload(context.Background(), hook)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("renders for the requesting user", func(t *testing.T) {
+		rendered, _, err := th.SystemAdminClient.RenderOutgoingWebhookPayload(context.Background(), hook)
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", rendered.ContentType)
+		assert.Equal(t, map[string]string{"X-Api-Key": "secret"}, rendered.Headers)
+
+		var body map[string]string
+		require.NoError(t, json.Unmarshal([]byte(rendered.Payload), &body))
+		assert.True(t, strings.HasPrefix(body["text"], "ship "))
+		assert.Equal(t, th.SystemAdminUser.Username, body["user"])
+		assert.Equal(t, th.BasicChannel.Name, body["channel"])
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		invalid := *hook
+		invalid.PayloadTemplate = `{"text": "{{.Post.Message}}"}`
+		_, resp, err := th.SystemAdminClient.RenderOutgoingWebhookPayload(context.Background(), &invalid)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+		CheckErrorID(t, err, "model.outgoing_hook.is_valid.payload_template.app_error")
+	})
+
+	t.Run("missing team", func(t *testing.T) {
+		noTeam := *hook
+		noTeam.TeamId = ""
+		_, resp, err := th.SystemAdminClient.RenderOutgoingWebhookPayload(context.Background(), &noTeam)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
 func TestUpdateIncomingHook(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -880,6 +942,30 @@ func TestUpdateIncomingHook(t *testing.T) {
 		assert.Equal(t, createdHook2.CreateAt, updatedHook.CreateAt)
 	}, "RetainCreateAt")
 
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		hook := &model.IncomingWebhook{ChannelId: th.BasicChannel.Id, PayloadFormat: model.IncomingWebhookFormatGitHub, Secret: "secret"}
+		createdHook, _, err := th.SystemAdminClient.CreateIncomingWebhook(context.Background(), hook)
+		require.NoError(t, err)
+
+		// A client that doesn't know about the payload format and the secret leaves them out.
+		r, err := client.DoAPIPut(context.Background(), "/hooks/incoming/"+createdHook.Id, `{"id": "`+createdHook.Id+`", "channel_id": "`+th.BasicChannel.Id+`", "display_name": "Name4"}`)
+		require.NoError(t, err)
+		closeBody(r)
+
+		updatedHook, _, err := client.GetIncomingWebhook(context.Background(), createdHook.Id, "")
+		require.NoError(t, err)
+		assert.Equal(t, "Name4", updatedHook.DisplayName)
+		assert.Equal(t, model.IncomingWebhookFormatGitHub, updatedHook.PayloadFormat)
+		assert.Equal(t, "secret", updatedHook.Secret)
+
+		updatedHook.PayloadFormat = ""
+		updatedHook.Secret = ""
+		updatedHook, _, err = client.UpdateIncomingWebhook(context.Background(), updatedHook)
+		require.NoError(t, err)
+		assert.Empty(t, updatedHook.PayloadFormat)
+		assert.Empty(t, updatedHook.Secret)
+	}, "RetainPayloadFormatAndSecret")
+
 	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
 		createdHook.DisplayName = "Name3"
 
@@ -1051,6 +1137,7 @@ func TestRegenOutgoingHookToken(t *testing.T) {
 	regenHookToken, _, err := th.SystemAdminClient.RegenOutgoingHookToken(context.Background(), rhook.Id)
 	require.NoError(t, err)
 	require.NotEqual(t, rhook.Token, regenHookToken.Token, "regen didn't work properly")
+	require.NotEqual(t, rhook.SigningSecret, regenHookToken.SigningSecret, "regen didn't work properly")
 
 	_, resp, err = client.RegenOutgoingHookToken(context.Background(), rhook.Id)
 	require.Error(t, err)
@@ -1062,6 +1149,57 @@ func TestRegenOutgoingHookToken(t *testing.T) {
 	CheckNotImplementedStatus(t, resp)
 }
 
+func TestOutgoingHookDeliveries(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	hook := &model.OutgoingWebhook{ChannelId: th.BasicChannel.Id, TeamId: th.BasicChannel.TeamId, CallbackURLs: []string{server.URL}, ContentType: "application/json"}
+	rhook, _, err := th.SystemAdminClient.CreateOutgoingWebhook(context.Background(), hook)
+	require.NoError(t, err)
+
+	th.App.TriggerWebhook(th.Context, &model.OutgoingWebhookPayload{Token: rhook.Token}, rhook, th.BasicPost, th.BasicChannel)
+
+	deliveries, _, err := th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), rhook.Id, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, model.OutgoingWebhookDeliveryStatusSuccess, deliveries[0].Status)
+
+	delivery, _, err := th.SystemAdminClient.RedeliverOutgoingWebhook(context.Background(), rhook.Id, deliveries[0].Id)
+	require.NoError(t, err)
+	require.NotEqual(t, deliveries[0].Id, delivery.Id)
+	require.Equal(t, deliveries[0].Payload, delivery.Payload)
+
+	deliveries, _, err = th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), rhook.Id, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+
+	_, resp, err := th.SystemAdminClient.RedeliverOutgoingWebhook(context.Background(), rhook.Id, model.NewId())
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+
+	_, resp, err = client.GetOutgoingWebhookDeliveries(context.Background(), rhook.Id, 0, 10)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp, err = client.RedeliverOutgoingWebhook(context.Background(), rhook.Id, delivery.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = false })
+	_, resp, err = th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), rhook.Id, 0, 10)
+	require.Error(t, err)
+	CheckNotImplementedStatus(t, resp)
+}
+
 func TestUpdateOutgoingHook(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -99,6 +99,9 @@ func (a *App) Notification() einterfaces.NotificationInterface {
 func (a *App) Saml() einterfaces.SamlInterface {
 	return a.ch.Saml
 }
+func (a *App) Translation() einterfaces.TranslationInterface {
+	return a.ch.Translation
+}
 func (a *App) Cloud() einterfaces.CloudInterface {
 	return a.ch.srv.Cloud
 }
@@ -102,6 +102,9 @@ type AppIface interface {
 	// CreateGuest creates a guest and sets several fields of the returned User struct to
 	// their zero values.
 	CreateGuest(c request.CTX, user *model.User) (*model.User, *model.AppError)
+	// CreatePersistentNotificationEscalation adds a step to the escalation chain
+	// of a channel, after checking that its group or fallback channel exists.
+	CreatePersistentNotificationEscalation(rctx request.CTX, escalation *model.PersistentNotificationEscalation) (*model.PersistentNotificationEscalation, *model.AppError)
 	// CreateUser creates a user and sets several fields of the returned User struct to
 	// their zero values.
 	CreateUser(c request.CTX, user *model.User) (*model.User, *model.AppError)
@@ -123,8 +126,16 @@ type AppIface interface {
 	// DeleteGroupConstrainedMemberships deletes team and channel memberships of users who aren't members of the allowed
 	// groups of all group-constrained teams and channels.
 	DeleteGroupConstrainedMemberships(rctx request.CTX) error
+	// DeleteOutOfOffice removes the out of office settings of a user, turning
+	// them off first if they are active.
+	DeleteOutOfOffice(rctx request.CTX, userID string) *model.AppError
 	// DeletePersistentNotification stops the persistent notifications.
 	DeletePersistentNotification(c request.CTX, post *model.Post) *model.AppError
+	// DeletePersistentNotificationEscalation removes a step from the escalation
+	// chain of a channel.
+	DeletePersistentNotificationEscalation(channelID, escalationID string) *model.AppError
+	// DeletePostReminder cancels the reminder of a user about a post.
+	DeletePostReminder(postID, userID string) *model.AppError
 	// DeletePublicKey will delete plugin public key from the config.
 	DeletePublicKey(name string) *model.AppError
 	// DemoteUserToGuest Convert user's roles and all his membership's roles from
@@ -207,6 +218,9 @@ type AppIface interface {
 	// GetMarketplacePlugins returns a list of plugins from the marketplace-server,
 	// and plugins that are installed locally.
 	GetMarketplacePlugins(rctx request.CTX, filter *model.MarketplacePluginFilter) ([]*model.MarketplacePlugin, *model.AppError)
+	// GetPersistentNotificationEscalations returns the escalation chain of the
+	// persistent notifications posted in the given channel.
+	GetPersistentNotificationEscalations(channelID string) ([]*model.PersistentNotificationEscalation, *model.AppError)
 	// GetPluginStatus returns the status for a plugin installed on this server.
 	GetPluginStatus(id string) (*model.PluginStatus, *model.AppError)
 	// GetPluginStatuses returns the status for plugins installed on this server.
@@ -217,6 +231,11 @@ type AppIface interface {
 	// To get the plugins environment when the plugins are disabled, manually acquire the plugins
 	// lock instead.
 	GetPluginsEnvironment() *plugin.Environment
+	// GetPostPersistentNotificationEscalations returns the escalation chain of a
+	// persistent notification post, telling which steps were already reached.
+	GetPostPersistentNotificationEscalations(post *model.Post) (*model.PostPersistentNotificationEscalations, *model.AppError)
+	// GetPostRemindersForUser returns the pending post reminders of a user, the earliest first.
+	GetPostRemindersForUser(userID string) ([]*model.PostReminder, *model.AppError)
 	// GetPostsByIds response bool value indicates, if the post is inaccessible due to cloud plan's limit.
 	GetPostsByIds(postIDs []string) ([]*model.Post, int64, *model.AppError)
 	// GetPostsUsage returns the total posts count rounded down to the most
@@ -228,6 +247,10 @@ type AppIface interface {
 	GetProfileImagePath(user *model.User) (string, *model.AppError)
 	// GetPublicKey will return the actual public key saved in the `name` file.
 	GetPublicKey(name string) ([]byte, *model.AppError)
+	// GetRetentionPolicyDryRun reports what the data retention job would delete if
+	// it ran now, under the given granular policy or, if policyID is empty, under
+	// all of the granular policies and the global policy. Nothing is deleted.
+	GetRetentionPolicyDryRun(policyID string) (*model.RetentionPolicyDryRun, *model.AppError)
 	// GetSanitizedConfig gets the configuration for a system admin without any secrets.
 	GetSanitizedConfig() *model.Config
 	// GetSchemeRolesForChannel Checks if a channel or its team has an override scheme for channel roles and returns the scheme roles or default channel roles.
@@ -245,10 +268,19 @@ type AppIface interface {
 	GetTeamSchemeChannelRoles(c request.CTX, teamID string) (guestRoleName string, userRoleName string, adminRoleName string, err *model.AppError)
 	// GetTotalUsersStats is used for the DM list total
 	GetTotalUsersStats(viewRestrictions *model.ViewUsersRestrictions) (*model.UsersStats, *model.AppError)
+	// GetTranslationLanguageForUser returns the language the user has chosen to have posts translated to, or an empty
+	// string if they have not enabled automatic translation.
+	GetTranslationLanguageForUser(c request.CTX, userID string) string
 	// GetUserStatusesByIds used by apiV4
 	GetUserStatusesByIds(userIDs []string) ([]*model.Status, *model.AppError)
 	// HasRemote returns whether a given channelID is present in the channel remotes or not.
 	HasRemote(channelID string, remoteID string) (bool, error)
+	// ImageVariantReader returns a reader for the preview or thumbnail image
+	// at the given path encoded in the given format. Variants are generated
+	// along with the preview and thumbnail images, so images uploaded before
+	// they were enabled don't have any.
+	// Caller must close the first return value.
+	ImageVariantReader(rctx request.CTX, path, format string) (filestore.ReadCloseSeeker, *model.AppError)
 	// InstallPlugin unpacks and installs a plugin but does not enable or activate it unless the the
 	// plugin was already enabled.
 	InstallPlugin(pluginFile io.ReadSeeker, replace bool) (*model.Manifest, *model.AppError)
@@ -286,33 +318,66 @@ type AppIface interface {
 	// OverrideIconURLIfEmoji changes the post icon override URL prop, if it has an emoji icon,
 	// so that it points to the URL (relative) of the emoji - static if emoji is default, /api if custom.
 	OverrideIconURLIfEmoji(c request.CTX, post *model.Post)
+	// ParseIncomingWebhookPayload verifies and converts the native payload of a service to an incoming webhook request,
+	// according to the payload format of the hook. A nil request is returned for events that don't need to be posted.
+	ParseIncomingWebhookPayload(c request.CTX, hook *model.IncomingWebhook, header http.Header, body []byte) (*model.IncomingWebhookRequest, *model.AppError)
 	// PatchBot applies the given patch to the bot and corresponding user.
 	PatchBot(rctx request.CTX, botUserId string, botPatch *model.BotPatch) (*model.Bot, *model.AppError)
 	// PatchChannelModerationsForChannel Updates a channels scheme roles based on a given ChannelModerationPatch, if the permissions match the higher scoped role the scheme is deleted.
 	PatchChannelModerationsForChannel(c request.CTX, channel *model.Channel, channelModerationsPatch []*model.ChannelModerationPatch) ([]*model.ChannelModeration, *model.AppError)
+	// PauseScheduledPost stops a recurring scheduled post from being posted until it is resumed.
+	PauseScheduledPost(rctx request.CTX, userId, scheduledPostId, connectionId string) (*model.ScheduledPost, *model.AppError)
+	// DeleteRole deletes a custom role. Built-in roles and the roles managed by schemes can't be deleted.
+	DeleteRole(id string) (*model.Role, *model.AppError)
 	// Perform an HTTP POST request to an integration's action endpoint.
 	// Caller must consume and close returned http.Response as necessary.
 	// For internal requests, requests are routed directly to a plugin ServerHTTP hook
 	DoActionRequest(c request.CTX, rawURL string, body []byte) (*http.Response, *model.AppError)
+	// GetSharedChannelRemotesSyncStatus returns the synchronization status of each remote the channel is
+	// shared with.
+	GetSharedChannelRemotesSyncStatus(channelID string) ([]*model.SharedChannelRemoteSyncStatus, error)
+	// ImageVariantsEnabled tells whether WebP and AVIF variants of the preview
+	// and thumbnail images are generated and served.
+	ImageVariantsEnabled() bool
 	// PermanentDeleteBot permanently deletes a bot and its corresponding user.
 	PermanentDeleteBot(rctx request.CTX, botUserId string) *model.AppError
+	// PingRemoteCluster pings a remote cluster immediately, without waiting for the next ping loop, and
+	// returns it with its updated status.
+	PingRemoteCluster(remoteClusterId string) (*model.RemoteCluster, *model.AppError)
 	// PopulateWebConnConfig checks if the connection id already exists in the hub,
 	// and if so, accordingly populates the other fields of the webconn.
 	PopulateWebConnConfig(s *model.Session, cfg *platform.WebConnConfig, seqVal string) (*platform.WebConnConfig, error)
+	// PreviewSidebarCategoryRules returns the IDs of the user's channels on the team that are matched by the rules.
+	PreviewSidebarCategoryRules(c request.CTX, userID, teamID string, rules model.SidebarCategoryRules) ([]string, *model.AppError)
+	// ProcessOutOfOfficeTransitions turns on the out of office settings whose
+	// period has started, and turns off the ones whose period has ended.
+	ProcessOutOfOfficeTransitions(rctx request.CTX) error
 	// PromoteGuestToUser Convert user's roles and all his membership's roles from
 	// guest roles to regular user roles.
 	PromoteGuestToUser(c request.CTX, user *model.User, requestorId string) *model.AppError
 	// ReattachPlugin allows the server to bind to an existing plugin instance launched elsewhere.
 	ReattachPlugin(manifest *model.Manifest, pluginReattachConfig *model.PluginReattachConfig) *model.AppError
+	// RedeliverOutgoingWebhook sends the payload of a past delivery of the hook
+	// again, recording it as a new delivery.
+	RedeliverOutgoingWebhook(c request.CTX, hook *model.OutgoingWebhook, deliveryID string) (*model.OutgoingWebhookDelivery, *model.AppError)
 	// Removes a listener function by the unique ID returned when AddConfigListener was called
 	RemoveConfigListener(id string)
 	// RenameChannel is used to rename the channel Name and the DisplayName fields
 	RenameChannel(c request.CTX, channel *model.Channel, newChannelName string, newDisplayName string) (*model.Channel, *model.AppError)
 	// RenameTeam is used to rename the team Name and the DisplayName fields
 	RenameTeam(team *model.Team, newTeamName string, newDisplayName string) (*model.Team, *model.AppError)
+	// RenderOutgoingWebhookPayload renders the payload template of an outgoing webhook, which doesn't need to be saved,
+	// as it would be sent for a sample post made by the given user.
+	RenderOutgoingWebhookPayload(c request.CTX, hook *model.OutgoingWebhook, user *model.User) (*model.OutgoingWebhookRenderedPayload, *model.AppError)
 	// ResolvePersistentNotification stops the persistent notifications, if a loggedInUserID(except the post owner) reacts, reply or ack on the post.
 	// Post-owner can only delete the original post to stop the notifications.
 	ResolvePersistentNotification(c request.CTX, post *model.Post, loggedInUserID string) *model.AppError
+	// ResumeScheduledPost resumes a paused recurring scheduled post. The occurrences
+	// missed while the series was paused are skipped.
+	ResumeScheduledPost(rctx request.CTX, userId, scheduledPostId, connectionId string) (*model.ScheduledPost, *model.AppError)
+	// RetryOutgoingWebhookDeliveries attempts the deliveries whose retry is due,
+	// and deletes the deliveries older than the retention period.
+	RetryOutgoingWebhookDeliveries() error
 	// RevokeSessionsFromAllUsers will go through all the sessions active
 	// in the server and revoke them
 	RevokeSessionsFromAllUsers() *model.AppError
@@ -324,6 +389,9 @@ type AppIface interface {
 	SearchAllChannels(c request.CTX, term string, opts model.ChannelSearchOpts) (model.ChannelListWithTeamData, int64, *model.AppError)
 	// SearchAllTeams returns a team list and the total count of the results
 	SearchAllTeams(searchOpts *model.TeamSearch) ([]*model.Team, int64, *model.AppError)
+	// SendOutOfOfficeMentionResponses replies on behalf of the users mentioned in
+	// a channel post who are out of office, at most once a day per channel.
+	SendOutOfOfficeMentionResponses(rctx request.CTX, channel *model.Channel, sender *model.User, post *model.Post, mentionedUserIDs []string)
 	// SessionHasPermissionToChannels returns true only if user has access to all channels.
 	SessionHasPermissionToChannels(c request.CTX, session model.Session, channelIDs []string, permission *model.Permission) bool
 	// SessionHasPermissionToManageBot returns nil if the session has access to manage the given bot.
@@ -334,6 +402,9 @@ type AppIface interface {
 	SessionHasPermissionToTeams(c request.CTX, session model.Session, teamIDs []string, permission *model.Permission) bool
 	// SessionIsRegistered determines if a specific session has been registered
 	SessionIsRegistered(session model.Session) bool
+	// SetOutOfOffice saves the out of office settings of a user, turning them on
+	// or off right away if the schedule requires it.
+	SetOutOfOffice(rctx request.CTX, outOfOffice *model.OutOfOffice) (*model.OutOfOffice, *model.AppError)
 	// SetSessionExpireInHours sets the session's expiry the specified number of hours
 	// relative to either the session creation date or the current time, depending
 	// on the `ExtendSessionOnActivity` config setting.
@@ -381,6 +452,11 @@ type AppIface interface {
 	CreateZipFileAndAddFiles(fileBackend filestore.FileBackend, fileDatas []model.FileData, zipFileName, directory string) error
 	// This to be used for places we check the users password when they are already logged in
 	DoubleCheckPassword(rctx request.CTX, user *model.User, password string) *model.AppError
+	// TranslatePostsForUser attaches to the metadata of the given posts their message translated to the preferred
+	// language of the user. Plugins implementing the MessagesWillBeTranslated hook are asked first, and the translation
+	// service, if any, translates the remaining posts. Posts are modified in place, so they must already be prepared for
+	// the client.
+	TranslatePostsForUser(c request.CTX, posts []*model.Post, userID string)
 	// UpdateBotActive marks a bot as active or inactive, along with its corresponding user.
 	UpdateBotActive(rctx request.CTX, botUserId string, active bool) (*model.Bot, *model.AppError)
 	// UpdateBotOwner changes a bot's owner to the given value.
@@ -683,6 +759,7 @@ type AppIface interface {
 	GetDefaultProfileImage(user *model.User) ([]byte, *model.AppError)
 	GetDeletedChannels(c request.CTX, teamID string, offset int, limit int, userID string, skipTeamMembershipCheck bool) (model.ChannelList, *model.AppError)
 	GetDraft(userID, channelID, rootID string) (*model.Draft, *model.AppError)
+	GetDraftHistory(rctx request.CTX, userID, channelID, rootID string) (*model.DraftHistory, *model.AppError)
 	GetDraftsForUser(rctx request.CTX, userID, teamID string) ([]*model.Draft, *model.AppError)
 	GetEditHistoryForPost(postID string) ([]*model.Post, *model.AppError)
 	GetEmoji(c request.CTX, emojiId string) (*model.Emoji, *model.AppError)
@@ -751,7 +828,9 @@ type AppIface interface {
 	GetOnboarding() (*model.System, *model.AppError)
 	GetOpenGraphMetadata(requestURL string) ([]byte, error)
 	GetOrCreateDirectChannel(c request.CTX, userID, otherUserID string, channelOptions ...model.ChannelOption) (*model.Channel, *model.AppError)
+	GetOutOfOffice(userID string) (*model.OutOfOffice, *model.AppError)
 	GetOutgoingWebhook(hookID string) (*model.OutgoingWebhook, *model.AppError)
+	GetOutgoingWebhookDeliveries(hookID string, page, perPage int) ([]*model.OutgoingWebhookDelivery, *model.AppError)
 	GetOutgoingWebhooksForChannelPageByUser(channelID string, userID string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
 	GetOutgoingWebhooksForTeamPage(teamID string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
 	GetOutgoingWebhooksForTeamPageByUser(teamID string, userID string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
@@ -867,6 +946,7 @@ type AppIface interface {
 	GetUserByUsername(username string) (*model.User, *model.AppError)
 	GetUserCountForReport(filter *model.UserReportOptions) (*int64, *model.AppError)
 	GetUserForLogin(c request.CTX, id, loginId string) (*model.User, *model.AppError)
+	GetUserScheduledPostSeries(rctx request.CTX, userId string) ([]*model.ScheduledPost, *model.AppError)
 	GetUserTeamScheduledPosts(rctx request.CTX, userId, teamId string) ([]*model.ScheduledPost, *model.AppError)
 	GetUserTermsOfService(userID string) (*model.UserTermsOfService, *model.AppError)
 	GetUsers(userIDs []string) ([]*model.User, *model.AppError)
@@ -1042,6 +1122,7 @@ type AppIface interface {
 	ResetPermissionsSystem() *model.AppError
 	ResetSamlAuthDataToEmail(includeDeleted bool, dryRun bool, userIDs []string) (numAffected int, appErr *model.AppError)
 	RestoreChannel(c request.CTX, channel *model.Channel, userID string) (*model.Channel, *model.AppError)
+	RestoreDraftRevision(c request.CTX, userID, channelID, rootID string, version int64, connectionID string) (*model.Draft, *model.AppError)
 	RestoreGroup(groupID string) (*model.Group, *model.AppError)
 	RestoreTeam(teamID string) *model.AppError
 	RestrictUsersGetByPermissions(c request.CTX, userID string, options *model.UserGetOptions) (*model.UserGetOptions, *model.AppError)
@@ -1168,6 +1249,7 @@ type AppIface interface {
 	Timezones() *timezones.Timezones
 	ToggleMuteChannel(c request.CTX, channelID, userID string) (*model.ChannelMember, *model.AppError)
 	TotalWebsocketConnections() int
+	Translation() einterfaces.TranslationInterface
 	TriggerWebhook(c request.CTX, payload *model.OutgoingWebhookPayload, hook *model.OutgoingWebhook, post *model.Post, channel *model.Channel)
 	UninviteRemoteFromChannel(channelID, remoteID string) error
 	UnregisterPluginCommand(pluginID, teamID, trigger string)
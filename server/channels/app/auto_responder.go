@@ -26,16 +26,23 @@ func (a *App) SendAutoResponseIfNecessary(rctx request.CTX, channel *model.Chann
 		return false, nil
 	}
 
-	if sender.IsBot {
-		return false, nil
-	}
-
 	receiverId := channel.GetOtherUserIdForDM(sender.Id)
 	if receiverId == "" {
 		// User direct messaged themself, let them test their auto-responder.
 		receiverId = sender.Id
 	}
 
+	// A scheduled out of office takes precedence over the auto-responder,
+	// and has its own rules about who gets a reply.
+	outOfOffice := a.getActiveOutOfOffice(receiverId)
+	if outOfOffice != nil {
+		if outOfOffice.DMMessage == "" || !outOfOffice.ShouldRespondTo(sender) {
+			return false, nil
+		}
+	} else if sender.IsBot {
+		return false, nil
+	}
+
 	receiver, aErr := a.GetUser(receiverId)
 	if aErr != nil {
 		return false, aErr
@@ -49,6 +56,10 @@ func (a *App) SendAutoResponseIfNecessary(rctx request.CTX, channel *model.Chann
 		return false, nil
 	}
 
+	if outOfOffice != nil {
+		return a.sendOutOfOfficeResponse(rctx, channel, receiver, post, outOfOffice, model.OutOfOfficeResponseTypeDM)
+	}
+
 	return a.SendAutoResponse(rctx, channel, receiver, post)
 }
 
@@ -64,6 +75,10 @@ func (a *App) SendAutoResponse(rctx request.CTX, channel *model.Channel, receive
 		return false, nil
 	}
 
+	return a.createAutoResponsePost(rctx, channel, receiver, post, message)
+}
+
+func (a *App) createAutoResponsePost(rctx request.CTX, channel *model.Channel, receiver *model.User, post *model.Post, message string) (bool, *model.AppError) {
 	rootID := post.Id
 	if post.RootId != "" {
 		rootID = post.RootId
@@ -382,6 +382,11 @@ func (a *App) handleCreationEvent(c request.CTX, userID, otherUserID string, cha
 			hooks.ChannelHasBeenCreated(pluginContext, channel)
 			return true
 		}, plugin.ChannelHasBeenCreatedID)
+
+		a.applySidebarCategoryRulesAndLog(c, userID, channel)
+		if otherUserID != userID {
+			a.applySidebarCategoryRulesAndLog(c, otherUserID, channel)
+		}
 	})
 
 	message := model.NewWebSocketEvent(model.WebsocketEventDirectAdded, "", channel.Id, "", nil, "")
@@ -1683,6 +1688,8 @@ func (a *App) AddChannelMember(c request.CTX, userID string, channel *model.Chan
 			hooks.UserHasJoinedChannel(pluginContext, cm, userRequestor)
 			return true
 		}, plugin.UserHasJoinedChannelID)
+
+		a.applySidebarCategoryRulesAndLog(c, user.Id, channel)
 	})
 
 	if opts.UserRequestorID == "" || userID == opts.UserRequestorID {
@@ -2288,6 +2295,8 @@ func (a *App) JoinChannel(c request.CTX, channel *model.Channel, userID string)
 			hooks.UserHasJoinedChannel(pluginContext, cm, nil)
 			return true
 		}, plugin.UserHasJoinedChannelID)
+
+		a.applySidebarCategoryRulesAndLog(c, user.Id, channel)
 	})
 
 	if err := a.postJoinChannelMessage(c, user, channel); err != nil {
@@ -3145,6 +3154,10 @@ func (a *App) PermanentDeleteChannel(c request.CTX, channel *model.Channel) *mod
 		return model.NewAppError("PermanentDeleteChannel", "app.post_persistent_notification.delete_by_channel.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if err := a.Srv().Store().PersistentNotificationEscalation().DeleteByChannel([]string{channel.Id}); err != nil {
+		return model.NewAppError("PermanentDeleteChannel", "app.persistent_notification_escalation.delete_by_channel.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
 	deleteAt := model.GetMillis()
 
 	if nErr := a.Srv().Store().Channel().PermanentDelete(c, channel.Id); nErr != nil {
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"slices"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
@@ -105,6 +106,10 @@ func (a *App) GetSidebarCategory(c request.CTX, categoryId string) (*model.Sideb
 }
 
 func (a *App) CreateSidebarCategory(c request.CTX, userID, teamID string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, *model.AppError) {
+	if appErr := newCategory.Rules.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
 	category, err := a.Srv().Store().Channel().CreateSidebarCategory(userID, teamID, newCategory)
 	if err != nil {
 		var nfErr *store.ErrNotFound
@@ -142,6 +147,12 @@ func (a *App) UpdateSidebarCategoryOrder(c request.CTX, userID, teamID string, c
 }
 
 func (a *App) UpdateSidebarCategories(c request.CTX, userID, teamID string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, *model.AppError) {
+	for _, category := range categories {
+		if appErr := category.Rules.IsValid(); appErr != nil {
+			return nil, appErr
+		}
+	}
+
 	updatedCategories, originalCategories, err := a.Srv().Store().Channel().UpdateSidebarCategories(userID, teamID, categories)
 	if err != nil {
 		return nil, model.NewAppError("UpdateSidebarCategories", "app.channel.sidebar_categories.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -286,3 +297,139 @@ func (a *App) DeleteSidebarCategory(c request.CTX, userID, teamID, categoryId st
 
 	return nil
 }
+
+// PreviewSidebarCategoryRules returns the IDs of the user's channels on the team that are matched by the rules.
+func (a *App) PreviewSidebarCategoryRules(c request.CTX, userID, teamID string, rules model.SidebarCategoryRules) ([]string, *model.AppError) {
+	if appErr := rules.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	channels, appErr := a.GetChannelsForTeamForUser(c, teamID, userID, &model.ChannelSearchOpts{})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	botDMs, appErr := a.botDirectMessages(userID, channels)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	channelIDs := []string{}
+	for _, channel := range channels {
+		if rules.Matches(channel, botDMs[channel.Id]) {
+			channelIDs = append(channelIDs, channel.Id)
+		}
+	}
+
+	return channelIDs, nil
+}
+
+// botDirectMessages returns the set of the direct messages between the user and a bot among the given channels.
+func (a *App) botDirectMessages(userID string, channels model.ChannelList) (map[string]bool, *model.AppError) {
+	channelByOtherUser := make(map[string]string)
+	otherUserIDs := []string{}
+	for _, channel := range channels {
+		if channel.Type != model.ChannelTypeDirect {
+			continue
+		}
+		if otherUserID := channel.GetOtherUserIdForDM(userID); otherUserID != "" {
+			channelByOtherUser[otherUserID] = channel.Id
+			otherUserIDs = append(otherUserIDs, otherUserID)
+		}
+	}
+
+	botDMs := make(map[string]bool)
+	if len(otherUserIDs) == 0 {
+		return botDMs, nil
+	}
+
+	users, appErr := a.GetUsersByIds(otherUserIDs, &store.UserGetByIdsOpts{})
+	if appErr != nil {
+		return nil, appErr
+	}
+	for _, user := range users {
+		if user.IsBot {
+			botDMs[channelByOtherUser[user.Id]] = true
+		}
+	}
+
+	return botDMs, nil
+}
+
+// applySidebarCategoryRules moves a channel the user has just joined into the first of their custom categories with
+// a rule matching it. Channels that the user already placed in Favorites or in a custom category are left alone.
+func (a *App) applySidebarCategoryRules(c request.CTX, userID string, channel *model.Channel) *model.AppError {
+	opts := &store.SidebarCategorySearchOpts{
+		TeamID: channel.TeamId,
+		Type:   model.SidebarCategoryCustom,
+	}
+	if channel.TeamId == "" {
+		// Direct and group messages are in the sidebar of every team
+		opts.ExcludeTeam = true
+	}
+	customCategories, appErr := a.getSidebarCategoriesIfExist(userID, opts)
+	if appErr != nil || !slices.ContainsFunc(customCategories, func(category *model.SidebarCategoryWithChannels) bool {
+		return len(category.Rules) > 0
+	}) {
+		return appErr
+	}
+
+	opts.Type = model.SidebarCategoryFavorites
+	favoritesCategories, appErr := a.getSidebarCategoriesIfExist(userID, opts)
+	if appErr != nil {
+		return appErr
+	}
+
+	botDMs, appErr := a.botDirectMessages(userID, model.ChannelList{channel})
+	if appErr != nil {
+		return appErr
+	}
+
+	// Categories are sorted, so the first matching category of each team is the one the channel goes into
+	placed := make(map[string]bool)
+	for _, category := range append(favoritesCategories, customCategories...) {
+		if slices.Contains(category.Channels, channel.Id) {
+			placed[category.TeamId] = true
+		}
+	}
+	targets := []*model.SidebarCategoryWithChannels{}
+	for _, category := range customCategories {
+		if placed[category.TeamId] || !category.Rules.Matches(channel, botDMs[channel.Id]) {
+			continue
+		}
+
+		placed[category.TeamId] = true
+		targets = append(targets, category)
+	}
+
+	for _, target := range targets {
+		target.Channels = append([]string{channel.Id}, target.Channels...)
+		if _, appErr := a.UpdateSidebarCategories(c, target.UserId, target.TeamId, []*model.SidebarCategoryWithChannels{target}); appErr != nil {
+			return appErr
+		}
+	}
+
+	return nil
+}
+
+// getSidebarCategoriesIfExist returns the sidebar categories of the user without creating the initial ones when
+// the user doesn't have any yet.
+func (a *App) getSidebarCategoriesIfExist(userID string, opts *store.SidebarCategorySearchOpts) (model.SidebarCategoriesWithChannels, *model.AppError) {
+	categories, err := a.Srv().Store().Channel().GetSidebarCategories(userID, opts)
+	if err != nil {
+		return nil, model.NewAppError("getSidebarCategoriesIfExist", "app.channel.sidebar_categories.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return categories.Categories, nil
+}
+
+func (a *App) applySidebarCategoryRulesAndLog(c request.CTX, userID string, channel *model.Channel) {
+	if appErr := a.applySidebarCategoryRules(c, userID, channel); appErr != nil {
+		c.Logger().Warn(
+			"Failed to apply sidebar category rules",
+			mlog.String("user_id", userID),
+			mlog.String("channel_id", channel.Id),
+			mlog.Err(appErr),
+		)
+	}
+}
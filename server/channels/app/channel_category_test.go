@@ -4,7 +4,9 @@
 package app
 
 import (
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -663,3 +665,101 @@ func TestDiffChannelsBetweenCategories(t *testing.T) {
 		)
 	})
 }
+
+func TestSidebarCategoryRules(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	user := th.CreateUser()
+	th.LinkUserToTeam(user, th.BasicTeam)
+
+	incidentName := func(channel *model.Channel) {
+		channel.Name = "inc-" + model.NewId()
+	}
+	incident := th.CreateChannel(th.Context, th.BasicTeam, incidentName)
+	other := th.CreateChannel(th.Context, th.BasicTeam)
+	th.AddUserToChannel(user, incident)
+	th.AddUserToChannel(user, other)
+
+	rules := model.SidebarCategoryRules{
+		{Type: model.SidebarCategoryRuleNamePrefix, Value: "inc-"},
+		{Type: model.SidebarCategoryRuleBotDirectMessages},
+	}
+
+	t.Run("preview the channels matched by the rules", func(t *testing.T) {
+		channelIDs, appErr := th.App.PreviewSidebarCategoryRules(th.Context, user.Id, th.BasicTeam.Id, rules)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{incident.Id}, channelIDs)
+
+		_, appErr = th.App.PreviewSidebarCategoryRules(th.Context, user.Id, th.BasicTeam.Id, model.SidebarCategoryRules{{Type: "unknown"}})
+		require.NotNil(t, appErr)
+	})
+
+	category, appErr := th.App.CreateSidebarCategory(th.Context, user.Id, th.BasicTeam.Id, &model.SidebarCategoryWithChannels{
+		SidebarCategory: model.SidebarCategory{
+			DisplayName: "Incidents",
+		},
+		Rules: rules,
+	})
+	require.Nil(t, appErr)
+	assert.Empty(t, category.Channels, "existing channels shouldn't be moved into the category")
+
+	categoryChannels := func() []string {
+		got, appErr := th.App.GetSidebarCategory(th.Context, category.Id)
+		require.Nil(t, appErr)
+		return got.Channels
+	}
+
+	t.Run("should place a newly joined channel matching a rule", func(t *testing.T) {
+		channel := th.CreateChannel(th.Context, th.BasicTeam, incidentName)
+		_, appErr := th.App.AddChannelMember(th.Context, user.Id, channel, ChannelMemberOpts{})
+		require.Nil(t, appErr)
+
+		require.Eventually(t, func() bool {
+			return slices.Contains(categoryChannels(), channel.Id)
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+
+	t.Run("should place a new direct message with a bot", func(t *testing.T) {
+		bot := th.CreateBot()
+		dm, appErr := th.App.GetOrCreateDirectChannel(th.Context, user.Id, bot.UserId)
+		require.Nil(t, appErr)
+
+		require.Eventually(t, func() bool {
+			return slices.Contains(categoryChannels(), dm.Id)
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+
+	t.Run("should not place channels not matching the rules", func(t *testing.T) {
+		channel := th.CreateChannel(th.Context, th.BasicTeam)
+		_, appErr := th.App.AddChannelMember(th.Context, user.Id, channel, ChannelMemberOpts{})
+		require.Nil(t, appErr)
+
+		require.Nil(t, th.App.applySidebarCategoryRules(th.Context, user.Id, channel))
+		assert.NotContains(t, categoryChannels(), channel.Id)
+	})
+
+	t.Run("should leave channels already placed in a custom category", func(t *testing.T) {
+		channel := th.CreateChannel(th.Context, th.BasicTeam, incidentName)
+		_, appErr := th.App.CreateSidebarCategory(th.Context, user.Id, th.BasicTeam.Id, &model.SidebarCategoryWithChannels{
+			SidebarCategory: model.SidebarCategory{
+				DisplayName: "Manual",
+			},
+			Channels: []string{channel.Id},
+		})
+		require.Nil(t, appErr)
+
+		require.Nil(t, th.App.applySidebarCategoryRules(th.Context, user.Id, channel))
+		assert.NotContains(t, categoryChannels(), channel.Id)
+	})
+
+	t.Run("should reject invalid rules", func(t *testing.T) {
+		_, appErr := th.App.CreateSidebarCategory(th.Context, user.Id, th.BasicTeam.Id, &model.SidebarCategoryWithChannels{
+			SidebarCategory: model.SidebarCategory{
+				DisplayName: "Invalid",
+			},
+			Rules: model.SidebarCategoryRules{{Type: model.SidebarCategoryRuleNamePrefix}},
+		})
+		require.NotNil(t, appErr)
+	})
+}
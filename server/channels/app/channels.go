@@ -61,6 +61,7 @@ type Channels struct {
 	Saml             einterfaces.SamlInterface
 	Notification     einterfaces.NotificationInterface
 	Ldap             einterfaces.LdapInterface
+	Translation      einterfaces.TranslationInterface
 
 	// These are used to prevent concurrent upload requests
 	// for a given upload session which could cause inconsistencies
@@ -114,6 +115,9 @@ func NewChannels(s *Server) (*Channels, error) {
 	if notificationInterface != nil {
 		ch.Notification = notificationInterface(New(ServerConnector(ch)))
 	}
+	if translationInterface != nil {
+		ch.Translation = translationInterface(New(ServerConnector(ch)))
+	}
 	if samlInterface != nil {
 		ch.Saml = samlInterface(New(ServerConnector(ch)))
 		if err := ch.Saml.ConfigureSP(request.EmptyContext(s.Log())); err != nil {
@@ -114,6 +114,38 @@ func (a *App) GetChannelPoliciesForUser(userID string, offset, limit int) (*mode
 	return a.DataRetention().GetChannelPoliciesForUser(userID, offset, limit)
 }
 
+// GetRetentionPolicyDryRun reports what the data retention job would delete if
+// it ran now, under the given granular policy or, if policyID is empty, under
+// all of the granular policies and the global policy. Nothing is deleted.
+func (a *App) GetRetentionPolicyDryRun(policyID string) (*model.RetentionPolicyDryRun, *model.AppError) {
+	if a.DataRetention() == nil {
+		return nil, newLicenseError("GetRetentionPolicyDryRun")
+	}
+
+	var globalPolicyEndTime, globalFileEndTime int64
+	if policyID == "" {
+		globalPolicy, appErr := a.DataRetention().GetGlobalPolicy()
+		if appErr != nil {
+			return nil, appErr
+		}
+		if globalPolicy.MessageDeletionEnabled {
+			globalPolicyEndTime = globalPolicy.MessageRetentionCutoff
+		}
+		if globalPolicy.FileDeletionEnabled {
+			globalFileEndTime = globalPolicy.FileRetentionCutoff
+		}
+	} else if _, appErr := a.DataRetention().GetPolicy(policyID); appErr != nil {
+		return nil, appErr
+	}
+
+	channels, err := a.Srv().Store().RetentionPolicy().GetDryRun(policyID, model.GetMillis(), globalPolicyEndTime, globalFileEndTime)
+	if err != nil {
+		return nil, model.NewAppError("GetRetentionPolicyDryRun", "app.retention_policy.get_dry_run.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return model.NewRetentionPolicyDryRun(channels), nil
+}
+
 func newLicenseError(methodName string) *model.AppError {
 	return model.NewAppError("App."+methodName, "ent.data_retention.generic.license.error",
 		nil, "", http.StatusNotImplemented)
@@ -67,7 +67,18 @@ func (a *App) UpsertDraft(c request.CTX, draft *model.Draft, connectionID string
 
 	dt, nErr := a.Srv().Store().Draft().Upsert(draft)
 	if nErr != nil {
-		return nil, model.NewAppError("CreateDraft", "app.draft.save.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		var cErr *store.ErrConflict
+		var appErr *model.AppError
+		switch {
+		case errors.As(nErr, &cErr):
+			// The draft was changed from another device since the client last saw it. The client can
+			// get the draft history to resolve the conflict and save again with the current version.
+			return nil, model.NewAppError("CreateDraft", "app.draft.save.conflict.app_error", nil, "", http.StatusConflict).Wrap(nErr)
+		case errors.As(nErr, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreateDraft", "app.draft.save.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		}
 	}
 
 	dt = a.prepareDraftWithFileInfos(c, draft.UserId, dt)
@@ -83,6 +94,52 @@ func (a *App) UpsertDraft(c request.CTX, draft *model.Draft, connectionID string
 	return dt, nil
 }
 
+// GetDraftHistory returns a draft along with its previous revisions.
+func (a *App) GetDraftHistory(rctx request.CTX, userID, channelID, rootID string) (*model.DraftHistory, *model.AppError) {
+	draft, appErr := a.GetDraft(userID, channelID, rootID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	revisions, err := a.Srv().Store().Draft().GetRevisions(userID, channelID, rootID)
+	if err != nil {
+		return nil, model.NewAppError("GetDraftHistory", "app.draft.get_revisions.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	history := &model.DraftHistory{
+		Draft:     a.prepareDraftWithFileInfos(rctx, userID, draft),
+		Revisions: revisions,
+	}
+	for _, revision := range history.Revisions {
+		a.prepareDraftWithFileInfos(rctx, userID, revision)
+	}
+
+	return history, nil
+}
+
+// RestoreDraftRevision saves a previous revision of a draft as its latest version.
+func (a *App) RestoreDraftRevision(c request.CTX, userID, channelID, rootID string, version int64, connectionID string) (*model.Draft, *model.AppError) {
+	if !*a.Config().ServiceSettings.AllowSyncedDrafts {
+		return nil, model.NewAppError("RestoreDraftRevision", "app.draft.feature_disabled", nil, "", http.StatusNotImplemented)
+	}
+
+	revision, err := a.Srv().Store().Draft().GetRevision(userID, channelID, rootID, version)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("RestoreDraftRevision", "app.draft.get_revision.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("RestoreDraftRevision", "app.draft.get_revision.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	// Restoring is deliberate, so it overwrites whatever the current version is
+	revision.Version = 0
+
+	return a.UpsertDraft(c, revision, connectionID)
+}
+
 func (a *App) GetDraftsForUser(rctx request.CTX, userID, teamID string) ([]*model.Draft, *model.AppError) {
 	if !*a.Config().ServiceSettings.AllowSyncedDrafts {
 		return nil, model.NewAppError("GetDraftsForUser", "app.draft.feature_disabled", nil, "", http.StatusNotImplemented)
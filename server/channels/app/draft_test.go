@@ -4,6 +4,7 @@
 package app
 
 import (
+	"net/http"
 	"os"
 	"testing"
 	"time"
@@ -359,6 +360,73 @@ func TestGetDraftsForUser(t *testing.T) {
 	})
 }
 
+func TestDraftHistory(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.Server.platform.SetConfigReadOnlyFF(false)
+	defer th.Server.platform.SetConfigReadOnlyFF(true)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.AllowSyncedDrafts = true })
+
+	user := th.BasicUser
+	channel := th.BasicChannel
+
+	first, appErr := th.App.UpsertDraft(th.Context, &model.Draft{
+		UserId:    user.Id,
+		ChannelId: channel.Id,
+		Message:   "first",
+	}, "")
+	require.Nil(t, appErr)
+
+	second, appErr := th.App.UpsertDraft(th.Context, &model.Draft{
+		UserId:    user.Id,
+		ChannelId: channel.Id,
+		Message:   "second",
+		Version:   first.Version,
+	}, "")
+	require.Nil(t, appErr)
+
+	t.Run("conflicting update", func(t *testing.T) {
+		_, appErr := th.App.UpsertDraft(th.Context, &model.Draft{
+			UserId:    user.Id,
+			ChannelId: channel.Id,
+			Message:   "from another device",
+			Version:   first.Version,
+		}, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+	})
+
+	t.Run("get draft history", func(t *testing.T) {
+		history, appErr := th.App.GetDraftHistory(th.Context, user.Id, channel.Id, "")
+		require.Nil(t, appErr)
+
+		assert.Equal(t, "second", history.Draft.Message)
+		assert.Equal(t, second.Version, history.Draft.Version)
+		require.Len(t, history.Revisions, 1)
+		assert.Equal(t, "first", history.Revisions[0].Message)
+		assert.Equal(t, first.Version, history.Revisions[0].Version)
+	})
+
+	t.Run("restore draft revision", func(t *testing.T) {
+		draft, appErr := th.App.RestoreDraftRevision(th.Context, user.Id, channel.Id, "", first.Version, "")
+		require.Nil(t, appErr)
+		assert.Equal(t, "first", draft.Message)
+		assert.Greater(t, draft.Version, second.Version)
+
+		_, appErr = th.App.RestoreDraftRevision(th.Context, user.Id, channel.Id, "", draft.Version+1, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+
+	t.Run("get history of missing draft", func(t *testing.T) {
+		_, appErr := th.App.GetDraftHistory(th.Context, user.Id, channel.Id, model.NewId())
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
+
 func TestDeleteDraft(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
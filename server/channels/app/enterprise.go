@@ -98,6 +98,12 @@ func RegisterIPFilteringInterface(f func(*App) einterfaces.IPFilteringInterface)
 	ipFilteringInterface = f
 }
 
+var translationInterface func(*App) einterfaces.TranslationInterface
+
+func RegisterTranslationInterface(f func(*App) einterfaces.TranslationInterface) {
+	translationInterface = f
+}
+
 func (s *Server) initEnterprise() {
 	if cloudInterface != nil {
 		s.Cloud = cloudInterface(s)
@@ -19,6 +19,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -44,8 +45,13 @@ const (
 	miniPreviewImageWidth      = 16
 	miniPreviewImageHeight     = 16
 	jpegEncQuality             = 90
-	maxUploadInitialBufferSize = 1024 * 1024 // 1MB
-	maxContentExtractionSize   = 1024 * 1024 // 1MB
+	webpEncQuality             = 80
+	avifEncQuality             = 60
+	animatedPreviewWidth       = 640
+	animatedPreviewMaxFrames   = 120
+	animatedPreviewMaxBytes    = 4 * 1024 * 1024 // 4MB
+	maxUploadInitialBufferSize = 1024 * 1024     // 1MB
+	maxContentExtractionSize   = 1024 * 1024     // 1MB
 )
 
 func (a *App) FileBackend() filestore.FileBackend {
@@ -802,6 +808,13 @@ func (a *App) UploadFileX(c request.CTX, channelID, name string, input io.Reader
 		}
 		defer file.Close()
 		t.postprocessImage(file)
+	} else if !t.Raw && t.fileinfo.IsVideo() {
+		file, aerr = a.FileReader(t.fileinfo.Path)
+		if aerr != nil {
+			return nil, aerr
+		}
+		defer file.Close()
+		t.postprocessVideo(file)
 	}
 
 	if _, err := t.saveToDatabase(c, t.fileinfo); err != nil {
@@ -870,8 +883,9 @@ func (t *UploadFileTask) preprocessImage() *model.AppError {
 		t.fileinfo.Width, t.fileinfo.Height = t.fileinfo.Height, t.fileinfo.Width
 	}
 
-	// For animated GIFs disable the preview; since we have to Decode gifs
-	// anyway, cache the decoded image for later.
+	// For GIFs disable the preview until an animated one gets generated in
+	// postprocessImage; since we have to Decode gifs anyway, cache the decoded
+	// image for later.
 	if t.fileinfo.MimeType == "image/gif" {
 		image, format, err := t.imgDecoder.Decode(io.MultiReader(bytes.NewReader(t.buf.Bytes()), t.teeInput))
 		if err == nil && image != nil {
@@ -884,12 +898,18 @@ func (t *UploadFileTask) preprocessImage() *model.AppError {
 	return nil
 }
 
-func (t *UploadFileTask) postprocessImage(file io.Reader) {
+func (t *UploadFileTask) postprocessImage(file io.ReadSeeker) {
 	// don't try to process SVG files
 	if t.fileinfo.IsSvg() {
 		return
 	}
 
+	hasAnimatedPreview := t.writeAnimatedPreview(file)
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		t.Logger.Error("Unable to seek image", mlog.Err(err))
+		return
+	}
+
 	decoded, imgType := t.decoded, t.imageType
 	if decoded == nil {
 		var err error
@@ -907,6 +927,93 @@ func (t *UploadFileTask) postprocessImage(file io.Reader) {
 		return
 	}
 
+	t.writePreviewImages(decoded, imgType, !hasAnimatedPreview)
+}
+
+// writeAnimatedPreview generates a downscaled animated preview for animated
+// GIF and PNG images, within the frame and size budget of previews. It
+// returns whether the preview was written.
+func (t *UploadFileTask) writeAnimatedPreview(file io.ReadSeeker) bool {
+	switch t.fileinfo.MimeType {
+	case "image/gif":
+	case "image/png":
+		animated, err := imaging.IsAnimatedPNG(file)
+		if _, seekErr := file.Seek(0, io.SeekStart); seekErr != nil || err != nil || !animated {
+			return false
+		}
+	default:
+		return false
+	}
+
+	anim, err := t.imgDecoder.DecodeAnimation(file)
+	if err != nil {
+		t.Logger.Debug("Unable to decode animation", mlog.Err(err))
+		return false
+	}
+	if len(anim.Frames) < 2 {
+		return false
+	}
+
+	// Halve the preview until it fits the size budget.
+	var buf bytes.Buffer
+	width, maxFrames := animatedPreviewWidth, animatedPreviewMaxFrames
+	for {
+		buf.Reset()
+		if err = t.imgEncoder.EncodeAnimation(&buf, imaging.GenerateAnimatedPreview(anim, width, maxFrames)); err != nil {
+			t.Logger.Error("Unable to encode animated preview", mlog.Err(err))
+			return false
+		}
+		if buf.Len() <= animatedPreviewMaxBytes {
+			break
+		}
+		width, maxFrames = width/2, maxFrames/2
+		if width < imageThumbnailWidth {
+			t.Logger.Debug("Animated preview exceeds the size budget", mlog.Int("size", buf.Len()))
+			return false
+		}
+	}
+
+	// The original is a better preview than a bigger copy of itself.
+	if int64(buf.Len()) >= t.fileinfo.Size {
+		return false
+	}
+
+	nameWithoutExtension := t.Name[:strings.LastIndex(t.Name, ".")]
+	path := t.pathPrefix() + nameWithoutExtension + imaging.AnimatedPreviewSuffix + "." + anim.Format
+	if _, aerr := t.writeFile(&buf, path); aerr != nil {
+		t.Logger.Error("Unable to upload", mlog.String("path", path), mlog.Err(aerr))
+		return false
+	}
+
+	t.fileinfo.PreviewPath = path
+	t.fileinfo.HasPreviewImage = true
+	return true
+}
+
+// postprocessVideo generates the thumbnail and preview of a video from its
+// first keyframe, when its container and codec are supported. Only VP8 and
+// Motion JPEG videos are, so H.264, VP9 or AV1 videos have no poster frame.
+func (t *UploadFileTask) postprocessVideo(file io.ReadSeeker) {
+	frame, err := t.imgDecoder.DecodeVideoFrame(file, t.maxImageRes)
+	if err != nil {
+		t.Logger.Debug("Unable to decode video frame", mlog.Err(err))
+		return
+	}
+
+	bounds := frame.Bounds()
+	t.fileinfo.Width = bounds.Dx()
+	t.fileinfo.Height = bounds.Dy()
+	t.fileinfo.HasPreviewImage = true
+	nameWithoutExtension := t.Name[:strings.LastIndex(t.Name, ".")]
+	t.fileinfo.PreviewPath = t.pathPrefix() + nameWithoutExtension + "_preview.jpg"
+	t.fileinfo.ThumbnailPath = t.pathPrefix() + nameWithoutExtension + "_thumb.jpg"
+
+	t.writePreviewImages(frame, "jpeg", true)
+}
+
+// writePreviewImages writes the thumbnail, the preview if asked for, and
+// generates the mini preview of the given image.
+func (t *UploadFileTask) writePreviewImages(decoded image.Image, imgType string, withPreview bool) {
 	writeImage := func(img image.Image, path string) {
 		r, w := io.Pipe()
 		go func() {
@@ -934,7 +1041,7 @@ func (t *UploadFileTask) postprocessImage(file io.Reader) {
 	}
 
 	var wg sync.WaitGroup
-	wg.Add(3)
+	wg.Add(2)
 	// Generating thumbnail and preview regardless of HasPreviewImage value.
 	// This is needed on mobile in case of animated GIFs.
 	go func() {
@@ -942,10 +1049,13 @@ func (t *UploadFileTask) postprocessImage(file io.Reader) {
 		writeImage(imaging.GenerateThumbnail(decoded, imageThumbnailWidth, imageThumbnailHeight), t.fileinfo.ThumbnailPath)
 	}()
 
-	go func() {
-		defer wg.Done()
-		writeImage(imaging.GeneratePreview(decoded, imagePreviewWidth), t.fileinfo.PreviewPath)
-	}()
+	if withPreview {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			writeImage(imaging.GeneratePreview(decoded, imagePreviewWidth), t.fileinfo.PreviewPath)
+		}()
+	}
 
 	go func() {
 		defer wg.Done()
@@ -1153,6 +1263,10 @@ func (a *App) generateThumbnailImage(rctx request.CTX, img image.Image, imgType,
 		rctx.Logger().Error("Unable to upload thumbnail", mlog.String("path", thumbnailPath), mlog.Err(err))
 		return
 	}
+
+	if a.ImageVariantsEnabled() {
+		a.generateImageVariants(rctx, thumb, thumbnailPath)
+	}
 }
 
 func (a *App) generatePreviewImage(rctx request.CTX, img image.Image, imgType, previewPath string) {
@@ -1175,6 +1289,86 @@ func (a *App) generatePreviewImage(rctx request.CTX, img image.Image, imgType, p
 		rctx.Logger().Error("Unable to upload preview", mlog.Err(err), mlog.String("path", previewPath))
 		return
 	}
+
+	if a.ImageVariantsEnabled() {
+		a.generateImageVariants(rctx, preview, previewPath)
+	}
+}
+
+// imageVariantPath returns the path of the given preview or thumbnail image
+// once encoded in another format, which is stored next to the original one.
+func imageVariantPath(path, format string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + "." + format
+}
+
+// ImageVariantsEnabled tells whether WebP and AVIF variants of the preview
+// and thumbnail images are generated and served.
+func (a *App) ImageVariantsEnabled() bool {
+	return *a.Config().FileSettings.EnableImageVariants
+}
+
+// ImageVariantReader returns a reader for the preview or thumbnail image
+// at the given path encoded in the given format. Variants are generated
+// along with the preview and thumbnail images, so images uploaded before
+// they were enabled don't have any.
+// Caller must close the first return value.
+func (a *App) ImageVariantReader(rctx request.CTX, path, format string) (filestore.ReadCloseSeeker, *model.AppError) {
+	if !slices.Contains(imaging.EncodedFormats, format) {
+		return nil, model.NewAppError("ImageVariantReader", "app.file.image_variant.unsupported_format.app_error", map[string]any{"Format": format}, "", http.StatusBadRequest)
+	}
+
+	variantPath := imageVariantPath(path, format)
+	exists, appErr := a.FileExists(variantPath)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !exists {
+		return nil, model.NewAppError("ImageVariantReader", "app.file.image_variant.not_found.app_error", nil, "path="+variantPath, http.StatusNotFound)
+	}
+
+	return a.FileReader(variantPath)
+}
+
+// generateImageVariants stores the given preview or thumbnail image encoded
+// in every format that can be served in its place.
+func (a *App) generateImageVariants(rctx request.CTX, img image.Image, path string) {
+	for _, format := range imaging.EncodedFormats {
+		var buf bytes.Buffer
+		var err error
+		switch format {
+		case imaging.FormatWEBP:
+			err = a.ch.imgEncoder.EncodeWEBP(&buf, img, webpEncQuality)
+		case imaging.FormatAVIF:
+			err = a.ch.imgEncoder.EncodeAVIF(&buf, img, avifEncQuality)
+		}
+		if err != nil {
+			rctx.Logger().Error("Unable to encode image variant", mlog.String("path", path), mlog.String("format", format), mlog.Err(err))
+			continue
+		}
+
+		if _, appErr := a.WriteFile(&buf, imageVariantPath(path, format)); appErr != nil {
+			rctx.Logger().Error("Unable to upload image variant", mlog.String("path", path), mlog.String("format", format), mlog.Err(appErr))
+		}
+	}
+}
+
+// removeImageVariants removes the variants of the given preview or thumbnail
+// image that have been generated so far.
+func (a *App) removeImageVariants(rctx request.CTX, path string) {
+	for _, format := range imaging.EncodedFormats {
+		variantPath := imageVariantPath(path, format)
+		if variantPath == path {
+			continue
+		}
+
+		if exists, appErr := a.FileExists(variantPath); appErr != nil || !exists {
+			continue
+		}
+
+		if appErr := a.RemoveFile(variantPath); appErr != nil {
+			rctx.Logger().Warn("Unable to remove image variant", mlog.String("path", variantPath), mlog.Err(appErr))
+		}
+	}
 }
 
 // generateMiniPreview updates mini preview if needed
@@ -1457,8 +1651,10 @@ func (a *App) SearchFilesInTeamForUser(c request.CTX, terms string, userId strin
 }
 
 func (a *App) ExtractContentFromFileInfo(rctx request.CTX, fileInfo *model.FileInfo) error {
-	// We don't process images.
-	if fileInfo.IsImage() {
+	settings := a.extractSettings()
+
+	// We only process images when they can go through OCR.
+	if fileInfo.IsImage() && settings.OCREngine == nil {
 		return nil
 	}
 
@@ -1467,9 +1663,7 @@ func (a *App) ExtractContentFromFileInfo(rctx request.CTX, fileInfo *model.FileI
 		return errors.Wrap(aerr, "failed to open file for extract file content")
 	}
 	defer file.Close()
-	text, err := docextractor.Extract(rctx.Logger(), fileInfo.Name, file, docextractor.ExtractSettings{
-		ArchiveRecursion: *a.Config().FileSettings.ArchiveRecursion,
-	})
+	text, err := docextractor.Extract(rctx.Logger(), fileInfo.Name, file, settings)
 	if err != nil {
 		return errors.Wrap(err, "failed to extract file content")
 	}
@@ -1490,6 +1684,20 @@ func (a *App) ExtractContentFromFileInfo(rctx request.CTX, fileInfo *model.FileI
 	return nil
 }
 
+func (a *App) extractSettings() docextractor.ExtractSettings {
+	fileSettings := a.Config().FileSettings
+	settings := docextractor.ExtractSettings{
+		ArchiveRecursion: *fileSettings.ArchiveRecursion,
+	}
+	if *fileSettings.OCRServiceURL != "" {
+		settings.OCREngine = docextractor.NewRemoteOCREngine(*fileSettings.OCRServiceURL, *fileSettings.OCRServiceSecret)
+		settings.OCRMaxImageSize = *fileSettings.OCRMaxImageSize
+		settings.OCRMaxPDFSize = *fileSettings.OCRMaxPDFSize
+		settings.OCRTimeout = time.Duration(*fileSettings.OCRTimeoutMilliseconds) * time.Millisecond
+	}
+	return settings
+}
+
 // GetLastAccessibleFileTime returns CreateAt time(from cache) of the last accessible post as per the cloud limit
 func (a *App) GetLastAccessibleFileTime() (int64, *model.AppError) {
 	license := a.Srv().License()
@@ -1623,9 +1831,11 @@ func (a *App) RemoveFilesFromFileStore(rctx request.CTX, fileInfos []*model.File
 		a.RemoveFileFromFileStore(rctx, info.Path)
 		if info.PreviewPath != "" {
 			a.RemoveFileFromFileStore(rctx, info.PreviewPath)
+			a.removeImageVariants(rctx, info.PreviewPath)
 		}
 		if info.ThumbnailPath != "" {
 			a.RemoveFileFromFileStore(rctx, info.ThumbnailPath)
+			a.removeImageVariants(rctx, info.ThumbnailPath)
 		}
 	}
 }
@@ -5,10 +5,15 @@ package app
 
 import (
 	"archive/zip"
+	"bytes"
 	"errors"
 	"fmt"
 	"image"
+	"image/color"
+	"image/gif"
 	"io"
+	"math/rand"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -21,6 +26,7 @@ import (
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imaging"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	storemocks "github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
 	"github.com/mattermost/mattermost/server/v8/channels/utils/fileutils"
@@ -400,6 +406,125 @@ func TestGenerateThumbnailImage(t *testing.T) {
 	})
 }
 
+func TestImageVariantReader(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	img := createDummyImage()
+	removeFiles := func() {
+		th.App.RemoveFilesFromFileStore(th.Context, []*model.FileInfo{{
+			Path:          "variant_preview.jpg",
+			PreviewPath:   "variant_preview.jpg",
+			ThumbnailPath: "variant_thumb.jpg",
+		}})
+	}
+	defer removeFiles()
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, appErr := th.App.ImageVariantReader(th.Context, "variant_preview.jpg", "bmp")
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("variants are not generated when disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.EnableImageVariants = false })
+		require.False(t, th.App.ImageVariantsEnabled())
+
+		th.App.generateThumbnailImage(th.Context, img, "jpg", "variant_thumb.jpg")
+		defer removeFiles()
+
+		_, appErr := th.App.ImageVariantReader(th.Context, "variant_thumb.jpg", imaging.FormatWEBP)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.EnableImageVariants = true })
+	require.True(t, th.App.ImageVariantsEnabled())
+	th.App.generatePreviewImage(th.Context, img, "jpg", "variant_preview.jpg")
+	th.App.generateThumbnailImage(th.Context, img, "jpg", "variant_thumb.jpg")
+
+	for _, format := range imaging.EncodedFormats {
+		t.Run(format, func(t *testing.T) {
+			reader, appErr := th.App.ImageVariantReader(th.Context, "variant_thumb.jpg", format)
+			require.Nil(t, appErr)
+			defer reader.Close()
+
+			_, decodedFormat, err := image.DecodeConfig(reader)
+			require.NoError(t, err)
+			assert.Equal(t, format, decodedFormat)
+		})
+	}
+
+	t.Run("variants are removed with the file", func(t *testing.T) {
+		removeFiles()
+
+		for _, path := range []string{"variant_preview.webp", "variant_preview.avif", "variant_thumb.webp", "variant_thumb.avif"} {
+			exists, appErr := th.App.FileExists(path)
+			require.Nil(t, appErr)
+			assert.False(t, exists, path)
+		}
+	})
+}
+
+func TestUploadFileXAnimatedPreview(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	pal := color.Palette{color.Black, color.White, color.NRGBA{R: 0xff, A: 0xff}, color.NRGBA{B: 0xff, A: 0xff}}
+	rnd := rand.New(rand.NewSource(1))
+	g := &gif.GIF{Config: image.Config{Width: 1000, Height: 500}}
+	for i := 0; i < 3; i++ {
+		frame := image.NewPaletted(image.Rect(0, 0, 1000, 500), pal)
+		for j := range frame.Pix {
+			frame.Pix[j] = uint8(rnd.Intn(len(pal)))
+		}
+		g.Image = append(g.Image, frame)
+		g.Delay = append(g.Delay, 10)
+	}
+	var buf bytes.Buffer
+	require.NoError(t, gif.EncodeAll(&buf, g))
+
+	info, appErr := th.App.UploadFileX(th.Context, th.BasicChannel.Id, "animated.gif", bytes.NewReader(buf.Bytes()),
+		UploadFileSetTeamId(th.BasicTeam.Id),
+		UploadFileSetUserId(th.BasicUser.Id),
+		UploadFileSetTimestamp(time.Now()),
+		UploadFileSetContentLength(int64(buf.Len())))
+	require.Nil(t, appErr)
+	defer th.App.RemoveFilesFromFileStore(th.Context, []*model.FileInfo{info})
+
+	assert.True(t, info.HasPreviewImage)
+	assert.Equal(t, imaging.FormatGIF, imaging.AnimatedPreviewFormat(info.PreviewPath))
+
+	data, appErr := th.App.ReadFile(info.PreviewPath)
+	require.Nil(t, appErr)
+	preview, err := gif.DecodeAll(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Len(t, preview.Image, 3)
+	assert.Equal(t, animatedPreviewWidth, preview.Config.Width)
+
+	exists, appErr := th.App.FileExists(info.ThumbnailPath)
+	require.Nil(t, appErr)
+	assert.True(t, exists, "a static thumbnail should still be generated")
+}
+
+func TestUploadFileXUnsupportedVideo(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	data := []byte("\x00\x00\x00\x14ftypisom\x00\x00\x02\x00isom")
+	info, appErr := th.App.UploadFileX(th.Context, th.BasicChannel.Id, "video.mp4", bytes.NewReader(data),
+		UploadFileSetTeamId(th.BasicTeam.Id),
+		UploadFileSetUserId(th.BasicUser.Id),
+		UploadFileSetTimestamp(time.Now()),
+		UploadFileSetContentLength(int64(len(data))))
+	require.Nil(t, appErr, "videos without a decodable frame should still be uploaded")
+	defer th.App.RemoveFilesFromFileStore(th.Context, []*model.FileInfo{info})
+
+	assert.True(t, info.IsVideo())
+	assert.False(t, info.HasPreviewImage)
+	assert.Empty(t, info.PreviewPath)
+}
+
 func createDummyImage() *image.RGBA {
 	width := 200
 	height := 100
@@ -0,0 +1,242 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imaging
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+	"time"
+
+	"github.com/disintegration/imaging"
+)
+
+// Frame disposal methods, as defined by both the GIF and APNG specifications.
+const (
+	disposeNone = iota
+	disposeBackground
+	disposePrevious
+)
+
+// Animation holds the frames of an animated GIF or APNG image.
+type Animation struct {
+	// The format of the animation, either FormatGIF or FormatAPNG.
+	Format string
+	Width  int
+	Height int
+	// The number of times the animation is played, 0 meaning forever.
+	LoopCount int
+	Frames    []AnimationFrame
+}
+
+// AnimationFrame holds a single frame of an animation.
+type AnimationFrame struct {
+	Image image.Image
+	// The area of the canvas the frame is drawn on.
+	Bounds    image.Rectangle
+	Delay     time.Duration
+	Disposal  int
+	BlendOver bool
+	// The palette to encode the frame with, only used for GIF animations.
+	Palette color.Palette
+}
+
+// DecodeAnimation decodes all the frames of the given GIF or APNG image.
+// Images that aren't animated are returned as a single frame animation.
+func (d *Decoder) DecodeAnimation(rd io.Reader) (*Animation, error) {
+	if d.opts.ConcurrencyLevel != 0 {
+		d.sem <- struct{}{}
+		defer func() { <-d.sem }()
+	}
+
+	br := bufio.NewReader(rd)
+	header, err := br.Peek(len(pngSignature))
+	if err != nil {
+		return nil, fmt.Errorf("imaging: failed to read animation header: %w", err)
+	}
+
+	var anim *Animation
+	switch {
+	case bytes.HasPrefix(header, []byte("GIF8")):
+		anim, err = decodeGIFAnimation(br)
+	case bytes.Equal(header, pngSignature):
+		anim, err = decodeAPNG(br)
+	default:
+		err = errors.New("unknown format")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("imaging: failed to decode animation: %w", err)
+	}
+
+	return anim, nil
+}
+
+func decodeGIFAnimation(rd io.Reader) (*Animation, error) {
+	g, err := gif.DecodeAll(rd)
+	if err != nil {
+		return nil, err
+	}
+
+	anim := &Animation{
+		Format:    FormatGIF,
+		Width:     g.Config.Width,
+		Height:    g.Config.Height,
+		LoopCount: max(g.LoopCount, 0),
+		Frames:    make([]AnimationFrame, len(g.Image)),
+	}
+	for i, img := range g.Image {
+		frame := AnimationFrame{
+			Image:     img,
+			Bounds:    img.Bounds(),
+			Delay:     time.Duration(g.Delay[i]) * 10 * time.Millisecond,
+			BlendOver: true,
+			Palette:   img.Palette,
+		}
+		switch g.Disposal[i] {
+		case gif.DisposalBackground:
+			frame.Disposal = disposeBackground
+		case gif.DisposalPrevious:
+			frame.Disposal = disposePrevious
+		}
+		anim.Frames[i] = frame
+	}
+
+	// Some encoders omit the logical screen size.
+	if anim.Width == 0 || anim.Height == 0 {
+		var bounds image.Rectangle
+		for _, frame := range anim.Frames {
+			bounds = bounds.Union(frame.Bounds)
+		}
+		anim.Width, anim.Height = bounds.Max.X, bounds.Max.Y
+	}
+
+	return anim, nil
+}
+
+// GenerateAnimatedPreview generates the preview for the given animation,
+// resizing it to the given width and keeping at most maxFrames frames.
+// Frames are dropped evenly when needed, their delays being added to the
+// ones kept so that the duration of the animation doesn't change.
+func GenerateAnimatedPreview(anim *Animation, width, maxFrames int) *Animation {
+	canvasRect := image.Rect(0, 0, anim.Width, anim.Height)
+	canvas := image.NewNRGBA(canvasRect)
+
+	step := 1
+	if maxFrames > 0 && len(anim.Frames) > maxFrames {
+		step = (len(anim.Frames) + maxFrames - 1) / maxFrames
+	}
+
+	preview := &Animation{
+		Format:    anim.Format,
+		LoopCount: anim.LoopCount,
+	}
+
+	var previous *image.NRGBA
+	var delay time.Duration
+	for i, frame := range anim.Frames {
+		if frame.Disposal == disposePrevious {
+			previous = imaging.Clone(canvas)
+		}
+
+		op := draw.Src
+		if frame.BlendOver {
+			op = draw.Over
+		}
+		draw.Draw(canvas, frame.Bounds, frame.Image, frame.Image.Bounds().Min, op)
+
+		delay += frame.Delay
+		if (i+1)%step == 0 || i == len(anim.Frames)-1 {
+			var img *image.NRGBA
+			if anim.Width > width {
+				img = imaging.Resize(canvas, width, 0, imaging.Linear)
+			} else {
+				img = imaging.Clone(canvas)
+			}
+			preview.Frames = append(preview.Frames, AnimationFrame{
+				Image:    img,
+				Bounds:   img.Bounds(),
+				Delay:    delay,
+				Disposal: disposeBackground,
+				Palette:  frame.Palette,
+			})
+			delay = 0
+		}
+
+		switch frame.Disposal {
+		case disposeBackground:
+			draw.Draw(canvas, frame.Bounds, image.Transparent, image.Point{}, draw.Src)
+		case disposePrevious:
+			if previous != nil {
+				draw.Draw(canvas, canvasRect, previous, image.Point{}, draw.Src)
+			}
+		}
+	}
+
+	if len(preview.Frames) > 0 {
+		bounds := preview.Frames[0].Bounds
+		preview.Width, preview.Height = bounds.Dx(), bounds.Dy()
+	}
+
+	return preview
+}
+
+// EncodeAnimation encodes the given animation in its format and writes the
+// data to the passed writer. Frames are expected to cover the whole canvas.
+func (e *Encoder) EncodeAnimation(wr io.Writer, anim *Animation) error {
+	if e.opts.ConcurrencyLevel > 0 {
+		e.sem <- struct{}{}
+		defer func() {
+			<-e.sem
+		}()
+	}
+
+	var err error
+	switch anim.Format {
+	case FormatGIF:
+		err = encodeGIFAnimation(wr, anim)
+	case FormatAPNG:
+		err = encodeAPNG(wr, anim)
+	default:
+		err = fmt.Errorf("unsupported format %q", anim.Format)
+	}
+	if err != nil {
+		return fmt.Errorf("imaging: failed to encode animation: %w", err)
+	}
+
+	return nil
+}
+
+func encodeGIFAnimation(wr io.Writer, anim *Animation) error {
+	g := &gif.GIF{
+		Image:     make([]*image.Paletted, len(anim.Frames)),
+		Delay:     make([]int, len(anim.Frames)),
+		Disposal:  make([]byte, len(anim.Frames)),
+		LoopCount: anim.LoopCount,
+		Config: image.Config{
+			Width:  anim.Width,
+			Height: anim.Height,
+		},
+	}
+	for i, frame := range anim.Frames {
+		p := frame.Palette
+		if len(p) == 0 {
+			p = palette.Plan9
+		}
+		img := image.NewPaletted(frame.Bounds, p)
+		draw.Draw(img, frame.Bounds, frame.Image, frame.Image.Bounds().Min, draw.Src)
+
+		g.Image[i] = img
+		g.Delay[i] = int(frame.Delay / (10 * time.Millisecond))
+		g.Disposal[i] = gif.DisposalBackground
+	}
+
+	return gif.EncodeAll(wr, g)
+}
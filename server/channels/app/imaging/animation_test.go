@@ -0,0 +1,180 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAnimationPalette = color.Palette{
+	color.Transparent,
+	color.NRGBA{R: 0xff, A: 0xff},
+	color.NRGBA{G: 0xff, A: 0xff},
+	color.NRGBA{B: 0xff, A: 0xff},
+}
+
+func newTestGIF(t *testing.T, width, height, frames int) []byte {
+	t.Helper()
+
+	g := &gif.GIF{
+		Config: image.Config{Width: width, Height: height},
+	}
+	for i := 0; i < frames; i++ {
+		img := image.NewPaletted(image.Rect(0, 0, width, height), testAnimationPalette)
+		for j := range img.Pix {
+			img.Pix[j] = uint8(1 + i%3)
+		}
+		g.Image = append(g.Image, img)
+		g.Delay = append(g.Delay, 10)
+		g.Disposal = append(g.Disposal, gif.DisposalNone)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, gif.EncodeAll(&buf, g))
+	return buf.Bytes()
+}
+
+func TestDecodeAnimation(t *testing.T) {
+	d, err := NewDecoder(DecoderOptions{})
+	require.NoError(t, err)
+
+	t.Run("gif", func(t *testing.T) {
+		anim, err := d.DecodeAnimation(bytes.NewReader(newTestGIF(t, 40, 20, 3)))
+		require.NoError(t, err)
+		assert.Equal(t, FormatGIF, anim.Format)
+		assert.Equal(t, 40, anim.Width)
+		assert.Equal(t, 20, anim.Height)
+		require.Len(t, anim.Frames, 3)
+		assert.Equal(t, 100*time.Millisecond, anim.Frames[0].Delay)
+		assert.True(t, anim.Frames[0].BlendOver)
+	})
+
+	t.Run("static png", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 10, 10))))
+
+		animated, err := IsAnimatedPNG(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		assert.False(t, animated)
+
+		anim, err := d.DecodeAnimation(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, FormatAPNG, anim.Format)
+		assert.Len(t, anim.Frames, 1)
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		_, err := d.DecodeAnimation(bytes.NewReader([]byte("not an animation")))
+		require.Error(t, err)
+	})
+}
+
+func TestGenerateAnimatedPreview(t *testing.T) {
+	d, err := NewDecoder(DecoderOptions{})
+	require.NoError(t, err)
+	e, err := NewEncoder(EncoderOptions{})
+	require.NoError(t, err)
+
+	anim, err := d.DecodeAnimation(bytes.NewReader(newTestGIF(t, 400, 200, 6)))
+	require.NoError(t, err)
+
+	t.Run("resizes and drops frames", func(t *testing.T) {
+		preview := GenerateAnimatedPreview(anim, 100, 4)
+		assert.Equal(t, 100, preview.Width)
+		assert.Equal(t, 50, preview.Height)
+		require.Len(t, preview.Frames, 3)
+		for _, frame := range preview.Frames {
+			assert.Equal(t, 200*time.Millisecond, frame.Delay)
+		}
+	})
+
+	t.Run("gif round trip", func(t *testing.T) {
+		preview := GenerateAnimatedPreview(anim, 100, 10)
+
+		var buf bytes.Buffer
+		require.NoError(t, e.EncodeAnimation(&buf, preview))
+
+		g, err := gif.DecodeAll(&buf)
+		require.NoError(t, err)
+		require.Len(t, g.Image, 6)
+		assert.Equal(t, 100, g.Config.Width)
+		assert.Equal(t, 10, g.Delay[0])
+		r, gr, _, _ := g.Image[1].At(50, 25).RGBA()
+		assert.Equal(t, uint32(0), r)
+		assert.Equal(t, uint32(0xffff), gr)
+	})
+
+	t.Run("apng round trip", func(t *testing.T) {
+		preview := GenerateAnimatedPreview(anim, 100, 10)
+		preview.Format = FormatAPNG
+
+		var buf bytes.Buffer
+		require.NoError(t, e.EncodeAnimation(&buf, preview))
+
+		animated, err := IsAnimatedPNG(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		assert.True(t, animated)
+
+		// Regular decoders only see the first frame.
+		img, err := png.Decode(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 100, 50), img.Bounds())
+
+		decoded, err := d.DecodeAnimation(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		require.Len(t, decoded.Frames, 6)
+		assert.Equal(t, 100*time.Millisecond, decoded.Frames[0].Delay)
+		assert.Equal(t, color.NRGBAModel.Convert(color.NRGBA{B: 0xff, A: 0xff}), color.NRGBAModel.Convert(decoded.Frames[2].Image.At(50, 25)))
+	})
+}
+
+func TestGenerateAnimatedPreviewDisposal(t *testing.T) {
+	canvas := image.Rect(0, 0, 2, 1)
+	red := image.NewUniform(color.NRGBA{R: 0xff, A: 0xff})
+	green := image.NewUniform(color.NRGBA{G: 0xff, A: 0xff})
+
+	anim := &Animation{
+		Format: FormatAPNG,
+		Width:  2,
+		Height: 1,
+		Frames: []AnimationFrame{
+			{Image: red, Bounds: canvas, Disposal: disposeNone},
+			{Image: green, Bounds: image.Rect(1, 0, 2, 1), Disposal: disposePrevious, BlendOver: true},
+			{Image: image.Transparent, Bounds: image.Rect(0, 0, 1, 1), Disposal: disposeBackground, BlendOver: true},
+			{Image: image.Transparent, Bounds: image.Rect(0, 0, 1, 1), Disposal: disposeNone, BlendOver: true},
+		},
+	}
+
+	preview := GenerateAnimatedPreview(anim, 100, 0)
+	require.Len(t, preview.Frames, 4)
+
+	at := func(frame, x int) color.NRGBA {
+		return color.NRGBAModel.Convert(preview.Frames[frame].Image.At(x, 0)).(color.NRGBA)
+	}
+	assert.Equal(t, red.C, at(0, 1))
+	assert.Equal(t, green.C, at(1, 1))
+	// The second frame is disposed to the previous state of the canvas.
+	assert.Equal(t, red.C, at(2, 1))
+	assert.Equal(t, red.C, at(2, 0))
+	// The third frame clears its area.
+	assert.Equal(t, color.NRGBA{}, at(3, 0))
+	assert.Equal(t, red.C, at(3, 1))
+}
+
+func TestAnimatedPreviewFormat(t *testing.T) {
+	assert.Equal(t, FormatGIF, AnimatedPreviewFormat("a/b_preview_animated.gif"))
+	assert.Equal(t, FormatAPNG, AnimatedPreviewFormat("a/b_preview_animated.apng"))
+	assert.Empty(t, AnimatedPreviewFormat("a/b_preview_animated.png"))
+	assert.Empty(t, AnimatedPreviewFormat("a/b_preview.gif"))
+	assert.Empty(t, AnimatedPreviewFormat("a/b_thumb.gif"))
+}
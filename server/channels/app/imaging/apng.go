@@ -0,0 +1,425 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imaging
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"time"
+)
+
+// The APNG specification is at https://wiki.mozilla.org/APNG_Specification.
+// Frames are decoded by rebuilding a regular PNG stream out of each of them
+// so that the standard library decoder can be used.
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// maxPNGChunkLength is the maximum chunk length allowed by the specification.
+const maxPNGChunkLength = 1<<31 - 1
+
+type pngChunk struct {
+	typ  string
+	data []byte
+}
+
+func readPNGChunkHeader(rd io.Reader) (length uint32, typ string, err error) {
+	var header [8]byte
+	if _, err := io.ReadFull(rd, header[:]); err != nil {
+		return 0, "", err
+	}
+	length = binary.BigEndian.Uint32(header[:4])
+	if length > maxPNGChunkLength {
+		return 0, "", errors.New("invalid chunk length")
+	}
+	return length, string(header[4:]), nil
+}
+
+// IsAnimatedPNG reports whether the given PNG image is animated. Only the
+// chunks preceding the image data are read.
+func IsAnimatedPNG(rd io.Reader) (bool, error) {
+	signature := make([]byte, len(pngSignature))
+	if _, err := io.ReadFull(rd, signature); err != nil {
+		return false, fmt.Errorf("imaging: failed to read png signature: %w", err)
+	}
+	if !bytes.Equal(signature, pngSignature) {
+		return false, errors.New("imaging: invalid png signature")
+	}
+
+	for {
+		length, typ, err := readPNGChunkHeader(rd)
+		if err != nil {
+			return false, fmt.Errorf("imaging: failed to read png chunk: %w", err)
+		}
+
+		switch typ {
+		case "acTL":
+			return true, nil
+		case "IDAT", "IEND":
+			// The animation control chunk must come before the image data.
+			return false, nil
+		}
+
+		// Skip the chunk data and CRC.
+		if _, err := io.CopyN(io.Discard, rd, int64(length)+4); err != nil {
+			return false, fmt.Errorf("imaging: failed to read png chunk: %w", err)
+		}
+	}
+}
+
+type apngFrameControl struct {
+	bounds    image.Rectangle
+	delay     time.Duration
+	disposal  int
+	blendOver bool
+}
+
+func parseAPNGFrameControl(data []byte) (apngFrameControl, error) {
+	if len(data) != 26 {
+		return apngFrameControl{}, errors.New("invalid fcTL chunk")
+	}
+
+	width := int(binary.BigEndian.Uint32(data[4:]))
+	height := int(binary.BigEndian.Uint32(data[8:]))
+	x := int(binary.BigEndian.Uint32(data[12:]))
+	y := int(binary.BigEndian.Uint32(data[16:]))
+	delayNum := time.Duration(binary.BigEndian.Uint16(data[20:]))
+	delayDen := time.Duration(binary.BigEndian.Uint16(data[22:]))
+	if delayDen == 0 {
+		delayDen = 100
+	}
+
+	fc := apngFrameControl{
+		bounds:    image.Rect(x, y, x+width, y+height),
+		delay:     delayNum * time.Second / delayDen,
+		blendOver: data[25] == 1,
+	}
+	switch data[24] {
+	case 1:
+		fc.disposal = disposeBackground
+	case 2:
+		fc.disposal = disposePrevious
+	}
+
+	return fc, nil
+}
+
+func decodeAPNG(rd io.Reader) (*Animation, error) {
+	signature := make([]byte, len(pngSignature))
+	if _, err := io.ReadFull(rd, signature); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(signature, pngSignature) {
+		return nil, errors.New("invalid png signature")
+	}
+
+	var ihdr []byte
+	// The chunks other than the frame ones that are needed to decode the
+	// frames, such as the palette and the transparency.
+	var shared []pngChunk
+	anim := &Animation{Format: FormatAPNG}
+
+	var control *apngFrameControl
+	var data bytes.Buffer
+	seenIDAT := false
+	seenACTL := false
+	// The default image of an animated image is not part of the animation
+	// when it isn't preceded by a frame control chunk.
+	skipDefault := false
+
+	flushFrame := func() error {
+		if control == nil {
+			return nil
+		}
+		defer func() {
+			control = nil
+			data.Reset()
+		}()
+		if skipDefault {
+			skipDefault = false
+			return nil
+		}
+
+		img, err := decodeAPNGFrame(ihdr, shared, control.bounds, data.Bytes())
+		if err != nil {
+			return err
+		}
+		anim.Frames = append(anim.Frames, AnimationFrame{
+			Image:     img,
+			Bounds:    control.bounds,
+			Delay:     control.delay,
+			Disposal:  control.disposal,
+			BlendOver: control.blendOver,
+		})
+		return nil
+	}
+
+	for {
+		length, typ, err := readPNGChunkHeader(rd)
+		if err != nil {
+			return nil, err
+		}
+		chunk := make([]byte, length+4)
+		if _, err := io.ReadFull(rd, chunk); err != nil {
+			return nil, err
+		}
+		chunk = chunk[:length]
+
+		switch typ {
+		case "IHDR":
+			if len(chunk) != 13 {
+				return nil, errors.New("invalid IHDR chunk")
+			}
+			ihdr = chunk
+			anim.Width = int(binary.BigEndian.Uint32(chunk[0:]))
+			anim.Height = int(binary.BigEndian.Uint32(chunk[4:]))
+		case "acTL":
+			if len(chunk) != 8 {
+				return nil, errors.New("invalid acTL chunk")
+			}
+			anim.LoopCount = int(binary.BigEndian.Uint32(chunk[4:]))
+			seenACTL = true
+		case "fcTL":
+			if err := flushFrame(); err != nil {
+				return nil, err
+			}
+			fc, err := parseAPNGFrameControl(chunk)
+			if err != nil {
+				return nil, err
+			}
+			if !fc.bounds.In(image.Rect(0, 0, anim.Width, anim.Height)) || fc.bounds.Empty() {
+				return nil, errors.New("invalid frame bounds")
+			}
+			control = &fc
+		case "IDAT":
+			if !seenIDAT {
+				seenIDAT = true
+				if control == nil {
+					skipDefault = seenACTL
+					control = &apngFrameControl{bounds: image.Rect(0, 0, anim.Width, anim.Height)}
+				}
+			}
+			data.Write(chunk)
+		case "fdAT":
+			if len(chunk) < 4 {
+				return nil, errors.New("invalid fdAT chunk")
+			}
+			data.Write(chunk[4:])
+		case "IEND":
+			if err := flushFrame(); err != nil {
+				return nil, err
+			}
+			if len(anim.Frames) == 0 {
+				return nil, errors.New("no frames")
+			}
+			return anim, nil
+		default:
+			if !seenIDAT {
+				shared = append(shared, pngChunk{typ: typ, data: chunk})
+			}
+		}
+	}
+}
+
+func decodeAPNGFrame(ihdr []byte, shared []pngChunk, bounds image.Rectangle, data []byte) (image.Image, error) {
+	if ihdr == nil {
+		return nil, errors.New("missing IHDR chunk")
+	}
+
+	frameIHDR := bytes.Clone(ihdr)
+	binary.BigEndian.PutUint32(frameIHDR[0:], uint32(bounds.Dx()))
+	binary.BigEndian.PutUint32(frameIHDR[4:], uint32(bounds.Dy()))
+
+	chunks := append([]pngChunk{{typ: "IHDR", data: frameIHDR}}, shared...)
+	chunks = append(chunks, pngChunk{typ: "IDAT", data: data}, pngChunk{typ: "IEND"})
+
+	buf := bytes.NewBuffer(bytes.Clone(pngSignature))
+	for _, chunk := range chunks {
+		if err := writePNGChunk(buf, chunk.typ, chunk.data); err != nil {
+			return nil, err
+		}
+	}
+
+	return png.Decode(buf)
+}
+
+func writePNGChunk(wr io.Writer, typ string, data []byte) error {
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[:4], uint32(len(data)))
+	copy(header[4:], typ)
+
+	crc := crc32.NewIEEE()
+	crc.Write(header[4:])
+	crc.Write(data)
+
+	var footer [4]byte
+	binary.BigEndian.PutUint32(footer[:], crc.Sum32())
+
+	for _, b := range [][]byte{header[:], data, footer[:]} {
+		if _, err := wr.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeAPNG encodes the animation as 8-bit RGBA frames. All the frames are
+// expected to cover the whole canvas.
+func encodeAPNG(wr io.Writer, anim *Animation) error {
+	if len(anim.Frames) == 0 {
+		return errors.New("no frames")
+	}
+
+	if _, err := wr.Write(pngSignature); err != nil {
+		return err
+	}
+
+	ihdr := make([]byte, 13)
+	binary.BigEndian.PutUint32(ihdr[0:], uint32(anim.Width))
+	binary.BigEndian.PutUint32(ihdr[4:], uint32(anim.Height))
+	ihdr[8] = 8 // bit depth
+	ihdr[9] = 6 // truecolor with alpha
+	if err := writePNGChunk(wr, "IHDR", ihdr); err != nil {
+		return err
+	}
+
+	actl := make([]byte, 8)
+	binary.BigEndian.PutUint32(actl[0:], uint32(len(anim.Frames)))
+	binary.BigEndian.PutUint32(actl[4:], uint32(anim.LoopCount))
+	if err := writePNGChunk(wr, "acTL", actl); err != nil {
+		return err
+	}
+
+	var seq uint32
+	for i, frame := range anim.Frames {
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl[0:], seq)
+		binary.BigEndian.PutUint32(fctl[4:], uint32(anim.Width))
+		binary.BigEndian.PutUint32(fctl[8:], uint32(anim.Height))
+		binary.BigEndian.PutUint16(fctl[20:], uint16(min(frame.Delay.Milliseconds(), 1<<16-1)))
+		binary.BigEndian.PutUint16(fctl[22:], 1000)
+		fctl[24] = 1 // dispose to background
+		if err := writePNGChunk(wr, "fcTL", fctl); err != nil {
+			return err
+		}
+		seq++
+
+		data, err := compressRGBA(frame.Image, anim.Width, anim.Height)
+		if err != nil {
+			return err
+		}
+
+		if i == 0 {
+			err = writePNGChunk(wr, "IDAT", data)
+		} else {
+			fdat := make([]byte, 4, 4+len(data))
+			binary.BigEndian.PutUint32(fdat, seq)
+			err = writePNGChunk(wr, "fdAT", append(fdat, data...))
+			seq++
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return writePNGChunk(wr, "IEND", nil)
+}
+
+// compressRGBA returns the zlib compressed scanlines of the given image,
+// choosing for each of them the filter with the smallest sum of absolute
+// differences as recommended by the PNG specification.
+func compressRGBA(img image.Image, width, height int) ([]byte, error) {
+	nrgba, ok := img.(*image.NRGBA)
+	if !ok || nrgba.Bounds() != image.Rect(0, 0, width, height) {
+		nrgba = image.NewNRGBA(image.Rect(0, 0, width, height))
+		draw.Draw(nrgba, nrgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	}
+
+	var buf bytes.Buffer
+	zw, err := zlib.NewWriterLevel(&buf, zlib.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+
+	const bpp = 4
+	rowLen := width * bpp
+	prev := make([]byte, rowLen)
+	filtered := make([][]byte, 5)
+	for i := range filtered {
+		filtered[i] = make([]byte, rowLen+1)
+		filtered[i][0] = byte(i)
+	}
+
+	for y := 0; y < height; y++ {
+		cur := nrgba.Pix[y*nrgba.Stride : y*nrgba.Stride+rowLen]
+
+		best, bestSum := 0, -1
+		for f := range filtered {
+			out := filtered[f][1:]
+			sum := 0
+			for x := 0; x < rowLen; x++ {
+				var a, b, c byte
+				if x >= bpp {
+					a = cur[x-bpp]
+					c = prev[x-bpp]
+				}
+				b = prev[x]
+				var v byte
+				switch f {
+				case 0:
+					v = cur[x]
+				case 1:
+					v = cur[x] - a
+				case 2:
+					v = cur[x] - b
+				case 3:
+					v = cur[x] - byte((int(a)+int(b))/2)
+				case 4:
+					v = cur[x] - paeth(a, b, c)
+				}
+				out[x] = v
+				sum += abs(int(int8(v)))
+			}
+			if bestSum < 0 || sum < bestSum {
+				best, bestSum = f, sum
+			}
+		}
+
+		if _, err := zw.Write(filtered[best]); err != nil {
+			return nil, err
+		}
+		copy(prev, cur)
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	if pa <= pb && pa <= pc {
+		return a
+	}
+	if pb <= pc {
+		return b
+	}
+	return c
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
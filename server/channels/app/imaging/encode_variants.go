@@ -0,0 +1,53 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imaging
+
+import (
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/gen2brain/avif"
+	"github.com/gen2brain/webp"
+)
+
+// EncodeWEBP encodes the given image in lossy WebP format and writes the data
+// to the passed writer.
+func (e *Encoder) EncodeWEBP(wr io.Writer, img image.Image, quality int) error {
+	if e.opts.ConcurrencyLevel > 0 {
+		e.sem <- struct{}{}
+		defer func() {
+			<-e.sem
+		}()
+	}
+
+	if err := webp.Encode(wr, img, webp.Options{Quality: quality}); err != nil {
+		return fmt.Errorf("imaging: failed to encode webp: %w", err)
+	}
+
+	return nil
+}
+
+// EncodeAVIF encodes the given image in AVIF format and writes the data to
+// the passed writer.
+func (e *Encoder) EncodeAVIF(wr io.Writer, img image.Image, quality int) error {
+	if e.opts.ConcurrencyLevel > 0 {
+		e.sem <- struct{}{}
+		defer func() {
+			<-e.sem
+		}()
+	}
+
+	encOpts := avif.Options{
+		Quality:           quality,
+		QualityAlpha:      quality,
+		Speed:             avif.DefaultSpeed,
+		ChromaSubsampling: image.YCbCrSubsampleRatio420,
+	}
+	if err := avif.Encode(wr, img, encOpts); err != nil {
+		return fmt.Errorf("imaging: failed to encode avif: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoderEncodeVariants(t *testing.T) {
+	e, err := NewEncoder(EncoderOptions{})
+	require.NotNil(t, e)
+	require.NoError(t, err)
+
+	rawImg := image.NewRGBA(image.Rect(0, 0, 320, 240))
+
+	var buf bytes.Buffer
+	err = e.EncodeWEBP(&buf, rawImg, 75)
+	require.NoError(t, err)
+	_, format, err := image.DecodeConfig(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, FormatWEBP, format)
+
+	buf.Reset()
+	err = e.EncodeAVIF(&buf, rawImg, 60)
+	require.NoError(t, err)
+	_, format, err = image.DecodeConfig(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, FormatAVIF, format)
+}
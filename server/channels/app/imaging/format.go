@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imaging
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatWEBP = "webp"
+	FormatAVIF = "avif"
+	FormatGIF  = "gif"
+	FormatAPNG = "apng"
+)
+
+// EncodedFormats lists the formats that can be served in place of the
+// original preview and thumbnail images, from most to least preferred.
+var EncodedFormats = []string{FormatAVIF, FormatWEBP}
+
+// FormatContentType returns the MIME type of the given encoded format.
+func FormatContentType(format string) string {
+	return "image/" + format
+}
+
+// AnimatedPreviewSuffix ends the name of animated previews, before their
+// extension. Static previews of GIF images are JPEG images stored with a .gif
+// extension, so the extension alone doesn't tell them apart.
+const AnimatedPreviewSuffix = "_preview_animated"
+
+// AnimatedPreviewFormat returns the format of the animated preview stored at
+// the given path, or an empty string if it's a static one.
+func AnimatedPreviewFormat(path string) string {
+	ext := filepath.Ext(path)
+	if !strings.HasSuffix(strings.TrimSuffix(path, ext), AnimatedPreviewSuffix) {
+		return ""
+	}
+	switch format := strings.TrimPrefix(strings.ToLower(ext), "."); format {
+	case FormatGIF, FormatAPNG:
+		return format
+	default:
+		return ""
+	}
+}
+
+// NegotiateFormat returns the encoded format to use for a client sending the
+// given Accept header, or an empty string if the client doesn't explicitly
+// accept any of them. Wildcards are ignored since they don't tell whether a
+// client is able to decode the newer formats.
+func NegotiateFormat(accept string) string {
+	qualities := make(map[string]float64, len(EncodedFormats))
+	for _, mediaRange := range strings.Split(accept, ",") {
+		params := strings.Split(mediaRange, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+		format, ok := strings.CutPrefix(mediaType, "image/")
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range params[1:] {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || strings.TrimSpace(key) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				q = 0
+				break
+			}
+			q = parsed
+		}
+		qualities[format] = q
+	}
+
+	var best string
+	var bestQ float64
+	for _, format := range EncodedFormats {
+		if q := qualities[format]; q > bestQ {
+			best, bestQ = format, q
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imaging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		accept   string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"wildcards only", "image/*,*/*;q=0.8", ""},
+		{"browser defaults", "image/avif,image/webp,image/apng,image/svg+xml,image/*,*/*;q=0.8", FormatAVIF},
+		{"webp only", "image/webp,*/*", FormatWEBP},
+		{"prefers avif on tie", "image/webp,image/avif", FormatAVIF},
+		{"higher quality wins", "image/avif;q=0.5,image/webp;q=0.9", FormatWEBP},
+		{"refused format", "image/avif;q=0,image/webp", FormatWEBP},
+		{"invalid quality", "image/avif;q=abc", ""},
+		{"case and spaces", " Image/WebP ; q=1 ", FormatWEBP},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, NegotiateFormat(tc.accept))
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+
+	"golang.org/x/image/vp8"
+)
+
+// Video codecs whose keyframes can be decoded.
+const (
+	videoCodecVP8   = "vp8"
+	videoCodecMJPEG = "mjpeg"
+)
+
+// maxVideoKeyframeSize is the maximum size of the keyframe read from a video,
+// protecting against corrupted or malicious files.
+const maxVideoKeyframeSize = 32 * 1024 * 1024
+
+var (
+	// ErrUnsupportedVideo is returned when the container of a video isn't
+	// supported or when no video track could be found in it.
+	ErrUnsupportedVideo = errors.New("imaging: unsupported video")
+	// ErrUnsupportedVideoCodec is returned when the codec of the video track
+	// can't be decoded.
+	ErrUnsupportedVideoCodec = errors.New("imaging: unsupported video codec")
+	// ErrVideoFrameTooLarge is returned when the resolution of the keyframe
+	// exceeds the maximum allowed one.
+	ErrVideoFrameTooLarge = errors.New("imaging: video frame resolution is too high")
+)
+
+// videoKeyframe holds the encoded data of a single video frame.
+type videoKeyframe struct {
+	codec string
+	data  []byte
+}
+
+// DecodeVideoFrame decodes the first keyframe of the given MP4 or WebM video,
+// to be used as its poster frame. The resolution of the frame is checked
+// against maxResolution before it's decoded, so that a small file can't
+// allocate a huge image.
+//
+// Only the VP8 and Motion JPEG codecs are supported, as the others, such as
+// H.264, HEVC, VP9 or AV1, have no decoder in Go. ErrUnsupportedVideoCodec
+// is returned for the videos using them, which have no poster frame.
+func (d *Decoder) DecodeVideoFrame(rs io.ReadSeeker, maxResolution int64) (image.Image, error) {
+	if d.opts.ConcurrencyLevel != 0 {
+		d.sem <- struct{}{}
+		defer func() { <-d.sem }()
+	}
+
+	var header [12]byte
+	if _, err := io.ReadFull(rs, header[:]); err != nil {
+		return nil, fmt.Errorf("imaging: failed to read video header: %w", err)
+	}
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("imaging: failed to read video: %w", err)
+	}
+
+	var keyframe *videoKeyframe
+	var err error
+	switch {
+	case bytes.Equal(header[4:8], []byte("ftyp")):
+		keyframe, err = findMP4Keyframe(rs)
+	case bytes.Equal(header[:4], []byte{0x1a, 0x45, 0xdf, 0xa3}):
+		keyframe, err = findWebMKeyframe(rs)
+	default:
+		return nil, ErrUnsupportedVideo
+	}
+	if err != nil {
+		if errors.Is(err, ErrUnsupportedVideo) || errors.Is(err, ErrUnsupportedVideoCodec) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("imaging: failed to read video: %w", err)
+	}
+
+	img, err := decodeVideoKeyframe(keyframe, maxResolution)
+	if err != nil {
+		if errors.Is(err, ErrVideoFrameTooLarge) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("imaging: failed to decode video frame: %w", err)
+	}
+
+	return img, nil
+}
+
+func decodeVideoKeyframe(keyframe *videoKeyframe, maxResolution int64) (image.Image, error) {
+	switch keyframe.codec {
+	case videoCodecVP8:
+		dec := vp8.NewDecoder()
+		dec.Init(bytes.NewReader(keyframe.data), len(keyframe.data))
+		fh, err := dec.DecodeFrameHeader()
+		if err != nil {
+			return nil, err
+		}
+		if !fh.KeyFrame {
+			return nil, errors.New("not a keyframe")
+		}
+		if err := checkVideoFrameResolution(fh.Width, fh.Height, maxResolution); err != nil {
+			return nil, err
+		}
+		return dec.DecodeFrame()
+	case videoCodecMJPEG:
+		config, err := jpeg.DecodeConfig(bytes.NewReader(keyframe.data))
+		if err != nil {
+			return nil, err
+		}
+		if err := checkVideoFrameResolution(config.Width, config.Height, maxResolution); err != nil {
+			return nil, err
+		}
+		return jpeg.Decode(bytes.NewReader(keyframe.data))
+	default:
+		return nil, ErrUnsupportedVideoCodec
+	}
+}
+
+func checkVideoFrameResolution(width, height int, maxResolution int64) error {
+	if int64(width)*int64(height) > maxResolution {
+		return fmt.Errorf("%w: %dx%d", ErrVideoFrameTooLarge, width, height)
+	}
+	return nil
+}
+
+// readVideoData reads size bytes at the given offset of the video.
+func readVideoData(rs io.ReadSeeker, offset, size int64) ([]byte, error) {
+	if size <= 0 || size > maxVideoKeyframeSize {
+		return nil, fmt.Errorf("invalid frame size %d", size)
+	}
+	if _, err := rs.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(rs, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
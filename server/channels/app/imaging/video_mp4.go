@@ -0,0 +1,319 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imaging
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The ISO base media file format (MP4) stores the sample tables of every
+// track in the moov box, which are used to locate the first sync sample of
+// the video track in the file.
+
+// maxMP4MoovSize is the maximum size of the moov box read in memory.
+const maxMP4MoovSize = 64 * 1024 * 1024
+
+var mp4VideoCodecs = map[string]string{
+	"vp08": videoCodecVP8,
+	"jpeg": videoCodecMJPEG,
+	"mjpa": videoCodecMJPEG,
+}
+
+type mp4Box struct {
+	typ  string
+	data []byte
+}
+
+// parseMP4Boxes splits the given data into boxes.
+func parseMP4Boxes(data []byte) ([]mp4Box, error) {
+	var boxes []mp4Box
+	for len(data) > 0 {
+		if len(data) < 8 {
+			return nil, errors.New("truncated box")
+		}
+		size := uint64(binary.BigEndian.Uint32(data))
+		typ := string(data[4:8])
+		headerSize := uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return nil, errors.New("truncated box")
+			}
+			size = binary.BigEndian.Uint64(data[8:])
+			headerSize = 16
+		}
+		if size < headerSize || size > uint64(len(data)) {
+			return nil, fmt.Errorf("invalid %q box size", typ)
+		}
+		boxes = append(boxes, mp4Box{typ: typ, data: data[headerSize:size]})
+		data = data[size:]
+	}
+	return boxes, nil
+}
+
+func findMP4Box(data []byte, path ...string) ([]byte, error) {
+	for _, typ := range path {
+		boxes, err := parseMP4Boxes(data)
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		for _, box := range boxes {
+			if box.typ == typ {
+				data, found = box.data, true
+				break
+			}
+		}
+		if !found {
+			return nil, nil
+		}
+	}
+	return data, nil
+}
+
+// readMP4Moov reads the moov box from the top level boxes of the file.
+func readMP4Moov(rs io.ReadSeeker) ([]byte, error) {
+	var offset int64
+	for {
+		if _, err := rs.Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+		var header [16]byte
+		if _, err := io.ReadFull(rs, header[:8]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, ErrUnsupportedVideo
+			}
+			return nil, err
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[:]))
+		typ := string(header[4:8])
+		headerSize := int64(8)
+		switch size {
+		case 0:
+			// The box extends to the end of the file.
+			end, err := rs.Seek(0, io.SeekEnd)
+			if err != nil {
+				return nil, err
+			}
+			size = end - offset
+			if _, err := rs.Seek(offset+headerSize, io.SeekStart); err != nil {
+				return nil, err
+			}
+		case 1:
+			if _, err := io.ReadFull(rs, header[8:]); err != nil {
+				return nil, err
+			}
+			size = int64(binary.BigEndian.Uint64(header[8:]))
+			headerSize = 16
+		}
+		if size < headerSize {
+			return nil, fmt.Errorf("invalid %q box size", typ)
+		}
+
+		if typ == "moov" {
+			if size > maxMP4MoovSize {
+				return nil, errors.New("moov box too large")
+			}
+			data := make([]byte, size-headerSize)
+			if _, err := io.ReadFull(rs, data); err != nil {
+				return nil, err
+			}
+			return data, nil
+		}
+
+		offset += size
+	}
+}
+
+func findMP4Keyframe(rs io.ReadSeeker) (*videoKeyframe, error) {
+	moov, err := readMP4Moov(rs)
+	if err != nil {
+		return nil, err
+	}
+
+	boxes, err := parseMP4Boxes(moov)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, box := range boxes {
+		if box.typ != "trak" {
+			continue
+		}
+
+		hdlr, err := findMP4Box(box.data, "mdia", "hdlr")
+		if err != nil {
+			return nil, err
+		}
+		// Full box header, pre_defined and then the handler type.
+		if len(hdlr) < 12 || string(hdlr[8:12]) != "vide" {
+			continue
+		}
+
+		stbl, err := findMP4Box(box.data, "mdia", "minf", "stbl")
+		if err != nil {
+			return nil, err
+		}
+		if stbl == nil {
+			continue
+		}
+
+		return findMP4TrackKeyframe(rs, stbl)
+	}
+
+	return nil, ErrUnsupportedVideo
+}
+
+func findMP4TrackKeyframe(rs io.ReadSeeker, stbl []byte) (*videoKeyframe, error) {
+	tables := map[string][]byte{}
+	boxes, err := parseMP4Boxes(stbl)
+	if err != nil {
+		return nil, err
+	}
+	for _, box := range boxes {
+		tables[box.typ] = box.data
+	}
+
+	// The first sample entry of the sample description tells the codec.
+	stsd := tables["stsd"]
+	if len(stsd) < 16 {
+		return nil, errors.New("invalid stsd box")
+	}
+	codec, ok := mp4VideoCodecs[string(stsd[12:16])]
+	if !ok {
+		return nil, ErrUnsupportedVideoCodec
+	}
+
+	// Every sample is a sync sample when there is no sync sample table.
+	sample := uint32(1)
+	if stss, ok := tables["stss"]; ok {
+		if len(stss) < 12 || binary.BigEndian.Uint32(stss[4:]) == 0 {
+			return nil, errors.New("invalid stss box")
+		}
+		sample = binary.BigEndian.Uint32(stss[8:])
+	}
+
+	chunk, firstSample, err := findMP4SampleChunk(tables["stsc"], sample)
+	if err != nil {
+		return nil, err
+	}
+
+	offset, err := readMP4ChunkOffset(tables, chunk)
+	if err != nil {
+		return nil, err
+	}
+
+	// The samples of a chunk are stored one after the other.
+	stsz := tables["stsz"]
+	for s := firstSample; s < sample; s++ {
+		size, err := readMP4SampleSize(stsz, s)
+		if err != nil {
+			return nil, err
+		}
+		offset += int64(size)
+	}
+
+	size, err := readMP4SampleSize(stsz, sample)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := readVideoData(rs, offset, int64(size))
+	if err != nil {
+		return nil, err
+	}
+
+	return &videoKeyframe{codec: codec, data: data}, nil
+}
+
+// readMP4SampleSize returns the size of the given sample, numbered from 1.
+func readMP4SampleSize(stsz []byte, sample uint32) (uint32, error) {
+	if len(stsz) < 12 {
+		return 0, errors.New("invalid stsz box")
+	}
+	fixedSize := binary.BigEndian.Uint32(stsz[4:])
+	count := binary.BigEndian.Uint32(stsz[8:])
+	if sample == 0 || sample > count {
+		return 0, errors.New("invalid sample number")
+	}
+	if fixedSize != 0 {
+		return fixedSize, nil
+	}
+
+	pos := 12 + 4*int(sample-1)
+	if pos+4 > len(stsz) {
+		return 0, errors.New("invalid stsz box")
+	}
+	return binary.BigEndian.Uint32(stsz[pos:]), nil
+}
+
+// findMP4SampleChunk returns the chunk containing the given sample and the
+// first sample of that chunk, both numbered from 1.
+func findMP4SampleChunk(stsc []byte, sample uint32) (chunk, firstSample uint32, err error) {
+	if len(stsc) < 8 {
+		return 0, 0, errors.New("invalid stsc box")
+	}
+	count := int(binary.BigEndian.Uint32(stsc[4:]))
+	if len(stsc) < 8+12*count {
+		return 0, 0, errors.New("invalid stsc box")
+	}
+
+	// Computed on 64 bits since the last run lasts forever.
+	runFirstSample := uint64(1)
+	for i := 0; i < count; i++ {
+		entry := stsc[8+12*i:]
+		runFirstChunk := binary.BigEndian.Uint32(entry)
+		samplesPerChunk := uint64(binary.BigEndian.Uint32(entry[4:]))
+		if samplesPerChunk == 0 {
+			return 0, 0, errors.New("invalid stsc box")
+		}
+
+		// The run lasts until the first chunk of the next entry, or forever.
+		runChunks := uint64(1 << 32)
+		if i+1 < count {
+			next := binary.BigEndian.Uint32(stsc[8+12*(i+1):])
+			if next <= runFirstChunk {
+				return 0, 0, errors.New("invalid stsc box")
+			}
+			runChunks = uint64(next - runFirstChunk)
+		}
+
+		if uint64(sample)-runFirstSample < runChunks*samplesPerChunk {
+			index := (uint64(sample) - runFirstSample) / samplesPerChunk
+			return runFirstChunk + uint32(index), uint32(runFirstSample + index*samplesPerChunk), nil
+		}
+		runFirstSample += runChunks * samplesPerChunk
+		if runFirstSample > uint64(sample) {
+			break
+		}
+	}
+
+	return 0, 0, errors.New("sample not found in stsc box")
+}
+
+func readMP4ChunkOffset(tables map[string][]byte, chunk uint32) (int64, error) {
+	if stco, ok := tables["stco"]; ok {
+		pos := 8 + 4*int(chunk-1)
+		if chunk == 0 || len(stco) < pos+4 {
+			return 0, errors.New("invalid stco box")
+		}
+		return int64(binary.BigEndian.Uint32(stco[pos:])), nil
+	}
+
+	if co64, ok := tables["co64"]; ok {
+		pos := 8 + 8*int(chunk-1)
+		if chunk == 0 || len(co64) < pos+8 {
+			return 0, errors.New("invalid co64 box")
+		}
+		return int64(binary.BigEndian.Uint64(co64[pos:])), nil
+	}
+
+	return 0, errors.New("missing chunk offset box")
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVideoFrame() image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 48))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.NRGBA{R: 0xff, A: 0xff}), image.Point{}, draw.Src)
+	return img
+}
+
+// testVP8Keyframe is a 64x48 red VP8 keyframe, as returned by
+// newTestVideoFrame once encoded, taken from a lossy WebP image.
+var testVP8Keyframe = []byte{
+	0x90, 0x04, 0x00, 0x9d, 0x01, 0x2a, 0x40, 0x00, 0x30, 0x00, 0x3e, 0x91,
+	0x48, 0xa0, 0x4c, 0x25, 0xa4, 0x23, 0x22, 0x22, 0xa8, 0x00, 0xb0, 0x12,
+	0x09, 0x67, 0x06, 0x28, 0x07, 0xef, 0xf9, 0x12, 0x9d, 0x00, 0x02, 0x83,
+	0xeb, 0xe6, 0x82, 0x46, 0x4e, 0xba, 0xbb, 0xc6, 0x00, 0x00, 0xfe, 0xee,
+	0xa6, 0x3f, 0xff, 0x80, 0xdd, 0x7c, 0x5b, 0x4c, 0xbf, 0xff, 0x73, 0x81,
+	0xff, 0x73, 0x81, 0xff, 0x73, 0x81, 0xfc, 0x75, 0xfe, 0x95, 0xfe, 0x7f,
+	0x73, 0x3b, 0x1f, 0x84, 0x38, 0xd0, 0x27, 0x65, 0x00, 0x00,
+}
+
+func newTestJPEGFrame(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, newTestVideoFrame(), nil))
+	return buf.Bytes()
+}
+
+func newMP4Box(typ string, children ...[]byte) []byte {
+	data := bytes.Join(children, nil)
+	box := binary.BigEndian.AppendUint32(nil, uint32(8+len(data)))
+	return append(append(box, typ...), data...)
+}
+
+func newMP4FullBox(typ string, values ...uint32) []byte {
+	data := make([]byte, 4)
+	for _, v := range values {
+		data = binary.BigEndian.AppendUint32(data, v)
+	}
+	return newMP4Box(typ, data)
+}
+
+// newTestMP4 returns a video whose second sample is the first keyframe.
+func newTestMP4(codec string, frame []byte) []byte {
+	ftyp := newMP4Box("ftyp", []byte("isom\x00\x00\x02\x00isom"))
+	skipped := []byte("not a keyframe")
+	mdatOffset := uint32(len(ftyp) + 8)
+
+	stsd := newMP4Box("stsd", make([]byte, 4), binary.BigEndian.AppendUint32(nil, 1), newMP4Box(codec, make([]byte, 78)))
+	trak := newMP4Box("trak",
+		newMP4Box("tkhd", make([]byte, 84)),
+		newMP4Box("mdia",
+			newMP4Box("hdlr", make([]byte, 8), []byte("vide"), make([]byte, 13)),
+			newMP4Box("minf",
+				newMP4Box("stbl",
+					stsd,
+					newMP4FullBox("stss", 1, 2),
+					newMP4FullBox("stsz", 0, 2, uint32(len(skipped)), uint32(len(frame))),
+					newMP4FullBox("stsc", 1, 1, 2, 1),
+					newMP4FullBox("stco", 1, mdatOffset),
+				),
+			),
+		),
+	)
+	sound := newMP4Box("trak", newMP4Box("mdia", newMP4Box("hdlr", make([]byte, 8), []byte("soun"), make([]byte, 13))))
+
+	return bytes.Join([][]byte{
+		ftyp,
+		newMP4Box("mdat", skipped, frame),
+		newMP4Box("moov", newMP4Box("mvhd", make([]byte, 100)), sound, trak),
+	}, nil)
+}
+
+func newEBMLElement(id uint32, children ...[]byte) []byte {
+	data := bytes.Join(children, nil)
+	element := bytes.TrimLeft(binary.BigEndian.AppendUint32(nil, id), "\x00")
+	// Sizes are always written on 8 bytes for simplicity.
+	element = append(element, 0x01)
+	element = append(element, binary.BigEndian.AppendUint64(nil, uint64(len(data)))[1:]...)
+	return append(element, data...)
+}
+
+func newEBMLUnknownSizeElement(id uint32, children ...[]byte) []byte {
+	element := bytes.TrimLeft(binary.BigEndian.AppendUint32(nil, id), "\x00")
+	element = append(element, 0xff)
+	return append(element, bytes.Join(children, nil)...)
+}
+
+// newTestWebM returns a video whose first block is an audio one, and whose
+// second video block is the first keyframe.
+func newTestWebM(codecID string, frame []byte) []byte {
+	block := func(track byte, flags byte, data []byte) []byte {
+		return newEBMLElement(ebmlIDSimpleBlock, []byte{0x80 | track, 0, 0, flags}, data)
+	}
+
+	return bytes.Join([][]byte{
+		newEBMLElement(0x1a45dfa3, newEBMLElement(0x4282, []byte("webm"))),
+		newEBMLUnknownSizeElement(ebmlIDSegment,
+			newEBMLElement(0x1549a966, newEBMLElement(0x2ad7b1, []byte{0x0f, 0x42, 0x40})),
+			newEBMLElement(ebmlIDTracks,
+				newEBMLElement(ebmlIDTrackEntry,
+					newEBMLElement(ebmlIDTrackNumber, []byte{1}),
+					newEBMLElement(ebmlIDTrackType, []byte{2}),
+					newEBMLElement(ebmlIDCodecID, []byte("A_OPUS")),
+				),
+				newEBMLElement(ebmlIDTrackEntry,
+					newEBMLElement(ebmlIDTrackNumber, []byte{2}),
+					newEBMLElement(ebmlIDTrackType, []byte{matroskaTrackTypeVideo}),
+					newEBMLElement(ebmlIDCodecID, []byte(codecID)),
+				),
+			),
+			newEBMLUnknownSizeElement(ebmlIDCluster,
+				newEBMLElement(0xe7, []byte{0}),
+				block(1, 0x80, []byte("audio")),
+				block(2, 0x00, []byte("not a keyframe")),
+				block(2, 0x80, frame),
+			),
+		),
+	}, nil)
+}
+
+func TestDecodeVideoFrame(t *testing.T) {
+	d, err := NewDecoder(DecoderOptions{})
+	require.NoError(t, err)
+
+	vp8Frame := testVP8Keyframe
+	jpegFrame := newTestJPEGFrame(t)
+	const maxResolution = 64 * 48
+
+	testCases := []struct {
+		name  string
+		video []byte
+	}{
+		{"mp4 vp8", newTestMP4("vp08", vp8Frame)},
+		{"mp4 mjpeg", newTestMP4("jpeg", jpegFrame)},
+		{"webm vp8", newTestWebM("V_VP8", vp8Frame)},
+		{"mkv mjpeg", newTestWebM("V_MJPEG", jpegFrame)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			img, err := d.DecodeVideoFrame(bytes.NewReader(tc.video), maxResolution)
+			require.NoError(t, err)
+			assert.Equal(t, image.Rect(0, 0, 64, 48), img.Bounds())
+
+			r, g, b, _ := img.At(32, 24).RGBA()
+			assert.Greater(t, r, uint32(0xe000))
+			assert.Less(t, g, uint32(0x2000))
+			assert.Less(t, b, uint32(0x2000))
+		})
+	}
+
+	t.Run("frames over the maximum resolution", func(t *testing.T) {
+		for _, video := range [][]byte{
+			newTestMP4("vp08", vp8Frame),
+			newTestMP4("jpeg", jpegFrame),
+			newTestWebM("V_VP8", vp8Frame),
+			newTestWebM("V_MJPEG", jpegFrame),
+		} {
+			_, err := d.DecodeVideoFrame(bytes.NewReader(video), maxResolution-1)
+			require.ErrorIs(t, err, ErrVideoFrameTooLarge)
+		}
+	})
+
+	t.Run("unsupported codecs", func(t *testing.T) {
+		for _, video := range [][]byte{
+			newTestMP4("avc1", vp8Frame),
+			newTestMP4("hvc1", vp8Frame),
+			newTestMP4("vp09", vp8Frame),
+			newTestMP4("av01", vp8Frame),
+			newTestWebM("V_MPEG4/ISO/AVC", vp8Frame),
+			newTestWebM("V_VP9", vp8Frame),
+			newTestWebM("V_AV1", vp8Frame),
+		} {
+			_, err := d.DecodeVideoFrame(bytes.NewReader(video), maxResolution)
+			require.ErrorIs(t, err, ErrUnsupportedVideoCodec)
+		}
+	})
+
+	t.Run("unsupported container", func(t *testing.T) {
+		_, err := d.DecodeVideoFrame(bytes.NewReader([]byte("RIFF\x00\x00\x00\x00AVI LIST")), maxResolution)
+		require.ErrorIs(t, err, ErrUnsupportedVideo)
+	})
+
+	t.Run("truncated video", func(t *testing.T) {
+		video := newTestMP4("vp08", vp8Frame)
+		_, err := d.DecodeVideoFrame(bytes.NewReader(video[:len(video)-20]), maxResolution)
+		require.Error(t, err)
+
+		video = newTestWebM("V_VP8", vp8Frame)
+		_, err = d.DecodeVideoFrame(bytes.NewReader(video[:len(video)-20]), maxResolution)
+		require.Error(t, err)
+	})
+}
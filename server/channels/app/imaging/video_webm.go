@@ -0,0 +1,261 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package imaging
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// WebM files are Matroska files, made of EBML elements. The tracks are
+// described before the clusters holding the frames, so the file is read
+// sequentially until the first keyframe of the video track is found.
+
+const (
+	ebmlIDSegment     = 0x18538067
+	ebmlIDTracks      = 0x1654ae6b
+	ebmlIDTrackEntry  = 0xae
+	ebmlIDTrackNumber = 0xd7
+	ebmlIDTrackType   = 0x83
+	ebmlIDCodecID     = 0x86
+	ebmlIDCluster     = 0x1f43b675
+	ebmlIDBlockGroup  = 0xa0
+	ebmlIDBlock       = 0xa1
+	ebmlIDSimpleBlock = 0xa3
+
+	// The size of the elements whose size wasn't known when writing them.
+	ebmlUnknownSize = -1
+
+	matroskaTrackTypeVideo = 1
+
+	// maxWebMTracksSize is the maximum size of the Tracks element read in
+	// memory.
+	maxWebMTracksSize = 1024 * 1024
+
+	// maxWebMElements is the maximum number of elements read before giving up
+	// on finding a keyframe.
+	maxWebMElements = 100000
+)
+
+var webmVideoCodecs = map[string]string{
+	"V_VP8":   videoCodecVP8,
+	"V_MJPEG": videoCodecMJPEG,
+}
+
+type webmTrack struct {
+	number    int64
+	trackType int64
+	codecID   string
+}
+
+// readEBMLVint reads a variable size integer. The length marker is kept for
+// element IDs and removed for element sizes.
+func readEBMLVint(rd io.ByteReader, keepMarker bool) (int64, error) {
+	first, err := rd.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	length := 1
+	for mask := byte(0x80); first&mask == 0; mask >>= 1 {
+		length++
+		if length > 8 {
+			return 0, errors.New("invalid variable size integer")
+		}
+	}
+
+	value := int64(first)
+	if !keepMarker {
+		value &= int64(0xff >> length)
+	}
+	allOnes := value == int64(0xff>>length)
+	for i := 1; i < length; i++ {
+		b, err := rd.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		value = value<<8 | int64(b)
+		allOnes = allOnes && b == 0xff
+	}
+
+	if !keepMarker && allOnes {
+		return ebmlUnknownSize, nil
+	}
+	return value, nil
+}
+
+func readEBMLElementHeader(rd io.ByteReader) (id, size int64, err error) {
+	if id, err = readEBMLVint(rd, true); err != nil {
+		return 0, 0, err
+	}
+	if size, err = readEBMLVint(rd, false); err != nil {
+		return 0, 0, err
+	}
+	return id, size, nil
+}
+
+func readEBMLData(rd io.Reader, size int64, limit int64) ([]byte, error) {
+	if size < 0 || size > limit {
+		return nil, fmt.Errorf("invalid element size %d", size)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(rd, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func parseEBMLUint(data []byte) int64 {
+	var value int64
+	for _, b := range data {
+		value = value<<8 | int64(b)
+	}
+	return value
+}
+
+// parseEBMLElements calls fn for each of the elements held in data.
+func parseEBMLElements(data []byte, fn func(id int64, data []byte)) error {
+	rd := bytes.NewReader(data)
+	for rd.Len() > 0 {
+		id, size, err := readEBMLElementHeader(rd)
+		if err != nil {
+			return err
+		}
+		elementData, err := readEBMLData(rd, size, int64(rd.Len()))
+		if err != nil {
+			return err
+		}
+		fn(id, elementData)
+	}
+	return nil
+}
+
+// findWebMVideoTrack returns the first video track described in the given
+// Tracks element.
+func findWebMVideoTrack(tracks []byte) (*webmTrack, error) {
+	var entries [][]byte
+	if err := parseEBMLElements(tracks, func(id int64, data []byte) {
+		if id == ebmlIDTrackEntry {
+			entries = append(entries, data)
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		var track webmTrack
+		if err := parseEBMLElements(entry, func(id int64, data []byte) {
+			switch id {
+			case ebmlIDTrackNumber:
+				track.number = parseEBMLUint(data)
+			case ebmlIDTrackType:
+				track.trackType = parseEBMLUint(data)
+			case ebmlIDCodecID:
+				track.codecID = string(bytes.TrimRight(data, "\x00"))
+			}
+		}); err != nil {
+			return nil, err
+		}
+		if track.trackType == matroskaTrackTypeVideo {
+			return &track, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// parseWebMBlock returns the frame held in the given block if it belongs to
+// the track and may be a keyframe. Simple blocks flag keyframes, while the
+// frames of regular blocks are checked when decoding them.
+func parseWebMBlock(block []byte, track *webmTrack, simple bool) ([]byte, bool, error) {
+	rd := bytes.NewReader(block)
+	number, err := readEBMLVint(rd, false)
+	if err != nil {
+		return nil, false, err
+	}
+	if number != track.number {
+		return nil, false, nil
+	}
+
+	// Skip the relative timecode.
+	if _, err := rd.Seek(2, io.SeekCurrent); err != nil {
+		return nil, false, err
+	}
+	flags, err := rd.ReadByte()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if simple && flags&0x80 == 0 {
+		return nil, false, nil
+	}
+	// Laced blocks hold several frames, which is never the case of video.
+	if flags&0x06 != 0 {
+		return nil, false, nil
+	}
+
+	return block[len(block)-rd.Len():], true, nil
+}
+
+func findWebMKeyframe(rs io.ReadSeeker) (*videoKeyframe, error) {
+	rd := bufio.NewReader(rs)
+
+	var track *webmTrack
+	for i := 0; i < maxWebMElements; i++ {
+		id, size, err := readEBMLElementHeader(rd)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, ErrUnsupportedVideo
+			}
+			return nil, err
+		}
+
+		switch id {
+		case ebmlIDSegment, ebmlIDCluster, ebmlIDBlockGroup:
+			// Read the children elements, which might be of unknown size.
+			continue
+		case ebmlIDTracks:
+			data, err := readEBMLData(rd, size, maxWebMTracksSize)
+			if err != nil {
+				return nil, err
+			}
+			if track, err = findWebMVideoTrack(data); err != nil {
+				return nil, err
+			}
+			if track == nil {
+				return nil, ErrUnsupportedVideo
+			}
+			if _, ok := webmVideoCodecs[track.codecID]; !ok {
+				return nil, ErrUnsupportedVideoCodec
+			}
+		case ebmlIDSimpleBlock, ebmlIDBlock:
+			data, err := readEBMLData(rd, size, maxVideoKeyframeSize)
+			if err != nil {
+				return nil, err
+			}
+			if track == nil {
+				continue
+			}
+			frame, ok, err := parseWebMBlock(data, track, id == ebmlIDSimpleBlock)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return &videoKeyframe{codec: webmVideoCodecs[track.codecID], data: frame}, nil
+			}
+		default:
+			if size == ebmlUnknownSize {
+				return nil, fmt.Errorf("unexpected element 0x%x of unknown size", id)
+			}
+			if _, err := rd.Discard(int(size)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return nil, ErrUnsupportedVideo
+}
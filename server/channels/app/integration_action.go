@@ -33,6 +33,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
@@ -306,6 +307,9 @@ func (a *App) DoActionRequest(c request.CTX, rawURL string, body []byte) (*http.
 	}
 
 	rawURLPath := path.Clean(rawURL)
+	if rawURLPath == model.PostReminderSnoozeActionURL {
+		return a.doPostReminderSnoozeRequest(c, body)
+	}
 	if strings.HasPrefix(rawURLPath, "/plugins/") || strings.HasPrefix(rawURLPath, "plugins/") {
 		return a.DoLocalRequest(c, rawURLPath, body)
 	}
@@ -343,6 +347,89 @@ func (a *App) DoActionRequest(c request.CTX, rawURL string, body []byte) (*http.
 	return resp, nil
 }
 
+// doPostReminderSnoozeRequest handles the snooze buttons of the reminder messages sent by the system bot,
+// setting the reminder again after the chosen delay.
+func (a *App) doPostReminderSnoozeRequest(c request.CTX, body []byte) (*http.Response, *model.AppError) {
+	var actionRequest model.PostActionIntegrationRequest
+	if err := json.Unmarshal(body, &actionRequest); err != nil {
+		return nil, model.NewAppError("doPostReminderSnoozeRequest", "api.post.do_action.action_integration.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	postID, _ := actionRequest.Context["post_id"].(string)
+	minutes, _ := actionRequest.Context["snooze_minutes"].(float64)
+	if !model.IsValidId(postID) || minutes <= 0 {
+		return nil, model.NewAppError("doPostReminderSnoozeRequest", "app.post_reminder.snooze.invalid.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	reminderPost, appErr := a.GetSinglePost(c, actionRequest.PostId, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	systemBot, appErr := a.GetSystemBot(c)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// Any post can carry an action with this URL, so only trust the reminder messages of the system bot
+	if reminderPost.Type != model.PostTypeReminder || reminderPost.UserId != systemBot.UserId || reminderPost.GetProp("post_id") != postID {
+		return nil, model.NewAppError("doPostReminderSnoozeRequest", "app.post_reminder.snooze.invalid.app_error", nil, "post_id="+reminderPost.Id, http.StatusBadRequest)
+	}
+
+	if !a.SessionHasPermissionToChannelByPost(*c.Session(), postID, model.PermissionReadChannelContent) {
+		return nil, model.MakePermissionError(c.Session(), []*model.Permission{model.PermissionReadChannelContent})
+	}
+
+	user, appErr := a.GetUser(actionRequest.UserId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	targetTime := time.Now().Add(time.Duration(minutes) * time.Minute)
+	if err := a.Srv().Store().Post().SetPostReminder(&model.PostReminder{
+		PostId:     postID,
+		UserId:     user.Id,
+		TargetTime: targetTime.Unix(),
+	}); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("doPostReminderSnoozeRequest", "app.post.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("doPostReminderSnoozeRequest", "app.post_reminder.set.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	location := user.GetTimezoneLocation()
+	if location == nil {
+		location = time.UTC
+	}
+	T := i18n.GetUserTranslations(user.Locale)
+
+	// The reminder can only be snoozed once, so the buttons are removed
+	update := reminderPost.Clone()
+	update.DelProp("attachments")
+
+	respBytes, err := json.Marshal(&model.PostActionIntegrationResponse{
+		Update: update,
+		EphemeralText: T("app.post_reminder.snoozed", map[string]any{
+			"Time": targetTime.In(location).Format("Mon, Jan 2 at 3:04 PM MST"),
+		}),
+	})
+	if err != nil {
+		return nil, model.NewAppError("doPostReminderSnoozeRequest", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(respBytes)),
+	}, nil
+}
+
 type LocalResponseWriter struct {
 	data    []byte
 	headers http.Header
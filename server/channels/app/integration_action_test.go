@@ -1177,3 +1177,76 @@ func TestDoPluginRequest(t *testing.T) {
 	body, _ = io.ReadAll(resp.Body)
 	assert.Equal(t, "param multiple not correct", string(body))
 }
+
+func TestPostReminderSnoozeAction(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	require.NoError(t, th.App.Srv().Store().Post().SetPostReminder(&model.PostReminder{
+		PostId:     th.BasicPost.Id,
+		UserId:     th.BasicUser.Id,
+		TargetTime: time.Now().Unix() - 1,
+	}))
+	th.App.CheckPostReminders(th.Context)
+
+	systemBot, appErr := th.App.GetSystemBot(th.Context)
+	require.Nil(t, appErr)
+	dm, appErr := th.App.GetOrCreateDirectChannel(th.Context, th.BasicUser.Id, systemBot.UserId)
+	require.Nil(t, appErr)
+
+	posts, appErr := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: dm.Id, PerPage: 10})
+	require.Nil(t, appErr)
+	require.Len(t, posts.Order, 1)
+	reminderPost := posts.Posts[posts.Order[0]]
+	require.Equal(t, model.PostTypeReminder, reminderPost.Type)
+
+	attachments := reminderPost.Attachments()
+	require.Len(t, attachments, 1)
+	require.NotEmpty(t, attachments[0].Actions)
+
+	rctx := th.Context.WithSession(&model.Session{UserId: th.BasicUser.Id, Roles: model.SystemUserRoleId})
+
+	t.Run("should set the reminder again", func(t *testing.T) {
+		before := time.Now()
+		_, appErr := th.App.DoPostActionWithCookie(rctx, reminderPost.Id, "snooze1h", th.BasicUser.Id, "", nil)
+		require.Nil(t, appErr)
+
+		reminders, appErr := th.App.GetPostRemindersForUser(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		require.Len(t, reminders, 1)
+		assert.Equal(t, th.BasicPost.Id, reminders[0].PostId)
+		assert.GreaterOrEqual(t, reminders[0].TargetTime, before.Add(time.Hour).Unix())
+
+		updated, appErr := th.App.GetSinglePost(th.Context, reminderPost.Id, false)
+		require.Nil(t, appErr)
+		assert.Empty(t, updated.Attachments())
+	})
+
+	t.Run("should only snooze reminder messages of the system bot", func(t *testing.T) {
+		post := &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			UserId:    th.BasicUser.Id,
+			Type:      model.PostTypeReminder,
+			Props: model.StringInterface{
+				"post_id": th.BasicPost.Id,
+				"attachments": []*model.SlackAttachment{{
+					Actions: []*model.PostAction{{
+						Id:   "snooze1h",
+						Type: model.PostActionTypeButton,
+						Name: "action",
+						Integration: &model.PostActionIntegration{
+							URL:     model.PostReminderSnoozeActionURL,
+							Context: map[string]any{"post_id": th.BasicPost.Id, "snooze_minutes": 60},
+						},
+					}},
+				}},
+			},
+		}
+		post, appErr := th.App.CreatePostAsUser(th.Context, post, "", true)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.DoPostActionWithCookie(rctx, post.Id, "snooze1h", th.BasicUser.Id, "", nil)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+}
@@ -106,6 +106,7 @@ func (a *App) SessionHasPermissionToCreateJob(session model.Session, job *model.
 		model.JobTypeExportProcess,
 		model.JobTypeExportDelete,
 		model.JobTypeCloud,
+		model.JobTypePostgresSearchIndexing,
 		model.JobTypeExtractContent:
 		return a.SessionHasPermissionTo(session, model.PermissionManageJobs), model.PermissionManageJobs
 	}
@@ -140,6 +141,7 @@ func (a *App) SessionHasPermissionToManageJob(session model.Session, job *model.
 		model.JobTypeExportProcess,
 		model.JobTypeExportDelete,
 		model.JobTypeCloud,
+		model.JobTypePostgresSearchIndexing,
 		model.JobTypeExtractContent:
 		permission = model.PermissionManageJobs
 	}
@@ -176,6 +178,9 @@ func (a *App) SessionHasPermissionToReadJob(session model.Session, jobType strin
 		model.JobTypeExportDelete,
 		model.JobTypeCloud,
 		model.JobTypeMobileSessionMetadata,
+		model.JobTypeOutgoingWebhookRetry,
+		model.JobTypeOutOfOffice,
+		model.JobTypePostgresSearchIndexing,
 		model.JobTypeExtractContent:
 		return a.SessionHasPermissionTo(session, model.PermissionReadJobs), model.PermissionReadJobs
 	}
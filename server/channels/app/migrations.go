@@ -26,6 +26,7 @@ const PlaybookRolesCreationMigrationKey = "PlaybookRolesCreationMigrationComplet
 const FirstAdminSetupCompleteKey = model.SystemFirstAdminSetupComplete
 const remainingSchemaMigrationsKey = "RemainingSchemaMigrations"
 const postPriorityConfigDefaultTrueMigrationKey = "PostPriorityConfigDefaultTrueMigrationComplete"
+const outgoingWebhookSigningSecretsMigrationKey = "OutgoingWebhookSigningSecretsMigrationComplete"
 
 // This function migrates the default built in roles from code/config to the database.
 func (a *App) DoAdvancedPermissionsMigration() error {
@@ -576,6 +577,45 @@ func (s *Server) doPostPriorityConfigDefaultTrueMigration() error {
 	return nil
 }
 
+// doOutgoingWebhookSigningSecretsMigration generates a signing secret for the
+// outgoing webhooks created before their payloads were signed, so that every
+// delivery is signed.
+func (s *Server) doOutgoingWebhookSigningSecretsMigration() error {
+	// If the migration is already marked as completed, don't do it again.
+	var nfErr *store.ErrNotFound
+	if _, err := s.Store().System().GetByName(outgoingWebhookSigningSecretsMigrationKey); err == nil {
+		return nil
+	} else if !errors.As(err, &nfErr) {
+		return fmt.Errorf("could not query migration: %w", err)
+	}
+
+	hooks, err := s.Store().Webhook().GetOutgoingList(0, -1)
+	if err != nil {
+		return fmt.Errorf("failed to get outgoing webhooks: %w", err)
+	}
+
+	for _, hook := range hooks {
+		if hook.SigningSecret != "" {
+			continue
+		}
+		hook.SigningSecret = model.NewOutgoingWebhookSigningSecret()
+		if _, err := s.Store().Webhook().UpdateOutgoing(hook); err != nil {
+			return fmt.Errorf("failed to set the signing secret of outgoing webhook %s: %w", hook.Id, err)
+		}
+	}
+
+	system := model.System{
+		Name:  outgoingWebhookSigningSecretsMigrationKey,
+		Value: "true",
+	}
+
+	if err := s.Store().System().SaveOrUpdate(&system); err != nil {
+		return fmt.Errorf("failed to mark outgoing webhook signing secrets migration as completed: %w", err)
+	}
+
+	return nil
+}
+
 func (s *Server) doCloudS3PathMigrations(c request.CTX) error {
 	// This migration is only applicable for cloud environments
 	if os.Getenv("MM_CLOUD_FILESTORE_BIFROST") == "" {
@@ -689,6 +729,7 @@ func (s *Server) doAppMigrations() {
 		{"First Admin Setup Complete Migration", s.doFirstAdminSetupCompleteMigration},
 		{"Remaining Schema Migrations", s.doRemainingSchemaMigrations},
 		{"Post Priority Config Default True Migration", s.doPostPriorityConfigDefaultTrueMigration},
+		{"Outgoing Webhook Signing Secrets Migration", s.doOutgoingWebhookSigningSecretsMigration},
 	}
 
 	for i := range m1 {
@@ -0,0 +1,218 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const outOfOfficeTransitionsBatchSize = 100
+
+func (a *App) GetOutOfOffice(userID string) (*model.OutOfOffice, *model.AppError) {
+	outOfOffice, err := a.Srv().Store().OutOfOffice().Get(userID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetOutOfOffice", "app.out_of_office.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetOutOfOffice", "app.out_of_office.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return outOfOffice, nil
+}
+
+// SetOutOfOffice saves the out of office settings of a user, turning them on
+// or off right away if the schedule requires it.
+func (a *App) SetOutOfOffice(rctx request.CTX, outOfOffice *model.OutOfOffice) (*model.OutOfOffice, *model.AppError) {
+	outOfOffice.Active = false
+	outOfOffice.CreateAt = 0
+	if existing, err := a.Srv().Store().OutOfOffice().Get(outOfOffice.UserId); err == nil {
+		outOfOffice.Active = existing.Active
+		outOfOffice.CreateAt = existing.CreateAt
+	}
+
+	outOfOffice.PreSave()
+	if appErr := outOfOffice.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	if outOfOffice.DelegateUserId != "" {
+		delegate, appErr := a.GetUser(outOfOffice.DelegateUserId)
+		if appErr != nil {
+			return nil, model.NewAppError("SetOutOfOffice", "app.out_of_office.delegate_user.app_error", nil, "", http.StatusBadRequest).Wrap(appErr)
+		}
+		if delegate.DeleteAt != 0 {
+			return nil, model.NewAppError("SetOutOfOffice", "app.out_of_office.delegate_user.app_error", nil, "delegate user is deactivated", http.StatusBadRequest)
+		}
+	}
+
+	saved, err := a.Srv().Store().OutOfOffice().Save(outOfOffice)
+	if err != nil {
+		return nil, model.NewAppError("SetOutOfOffice", "app.out_of_office.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if appErr := a.applyOutOfOfficeSchedule(saved, model.GetMillis()); appErr != nil {
+		return nil, appErr
+	}
+
+	return saved, nil
+}
+
+// DeleteOutOfOffice removes the out of office settings of a user, turning
+// them off first if they are active.
+func (a *App) DeleteOutOfOffice(rctx request.CTX, userID string) *model.AppError {
+	outOfOffice, appErr := a.GetOutOfOffice(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if err := a.Srv().Store().OutOfOffice().Delete(userID); err != nil {
+		return model.NewAppError("DeleteOutOfOffice", "app.out_of_office.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if outOfOffice.Active {
+		a.resetOutOfOfficeStatus(userID)
+	}
+
+	return nil
+}
+
+// ProcessOutOfOfficeTransitions turns on the out of office settings whose
+// period has started, and turns off the ones whose period has ended.
+func (a *App) ProcessOutOfOfficeTransitions(rctx request.CTX) error {
+	now := model.GetMillis()
+	for {
+		outOfOffices, err := a.Srv().Store().OutOfOffice().GetPendingTransitions(now, outOfOfficeTransitionsBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, outOfOffice := range outOfOffices {
+			if appErr := a.applyOutOfOfficeSchedule(outOfOffice, now); appErr != nil {
+				return appErr
+			}
+		}
+
+		if len(outOfOffices) < outOfOfficeTransitionsBatchSize {
+			return nil
+		}
+	}
+}
+
+func (a *App) applyOutOfOfficeSchedule(outOfOffice *model.OutOfOffice, now int64) *model.AppError {
+	scheduled := outOfOffice.IsScheduledAt(now)
+	if scheduled == outOfOffice.Active {
+		return nil
+	}
+
+	if err := a.Srv().Store().OutOfOffice().SetActive(outOfOffice.UserId, scheduled); err != nil {
+		return model.NewAppError("applyOutOfOfficeSchedule", "app.out_of_office.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	outOfOffice.Active = scheduled
+
+	if scheduled {
+		a.SetStatusOutOfOffice(outOfOffice.UserId)
+	} else {
+		a.resetOutOfOfficeStatus(outOfOffice.UserId)
+	}
+
+	return nil
+}
+
+// resetOutOfOfficeStatus sets the user back online, unless the user still has
+// the auto-responder turned on.
+func (a *App) resetOutOfOfficeStatus(userID string) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil || user.NotifyProps[model.AutoResponderActiveNotifyProp] == "true" {
+		return
+	}
+
+	if status, appErr := a.GetStatus(userID); appErr == nil && status.Status == model.StatusOutOfOffice {
+		a.SetStatusOnline(userID, true)
+	}
+}
+
+// getActiveOutOfOffice returns the out of office settings of the user if they
+// are currently turned on. Replies are only sent while the status of the user
+// is out of office, so that setting another status silences them.
+func (a *App) getActiveOutOfOffice(userID string) *model.OutOfOffice {
+	status, appErr := a.GetStatus(userID)
+	if appErr != nil || status.Status != model.StatusOutOfOffice {
+		return nil
+	}
+
+	outOfOffice, err := a.Srv().Store().OutOfOffice().Get(userID)
+	if err != nil {
+		return nil
+	}
+
+	if !outOfOffice.Active || !outOfOffice.IsScheduledAt(model.GetMillis()) {
+		return nil
+	}
+
+	return outOfOffice
+}
+
+// SendOutOfOfficeMentionResponses replies on behalf of the users mentioned in
+// a channel post who are out of office, at most once a day per channel.
+func (a *App) SendOutOfOfficeMentionResponses(rctx request.CTX, channel *model.Channel, sender *model.User, post *model.Post, mentionedUserIDs []string) {
+	if channel.Type == model.ChannelTypeDirect {
+		return
+	}
+
+	for _, userID := range mentionedUserIDs {
+		if userID == sender.Id {
+			continue
+		}
+
+		outOfOffice := a.getActiveOutOfOffice(userID)
+		if outOfOffice == nil || outOfOffice.MentionMessage == "" || !outOfOffice.ShouldRespondTo(sender) {
+			continue
+		}
+
+		autoResponded, err := a.checkIfRespondedToday(post.CreateAt, channel.Id, userID)
+		if err != nil {
+			rctx.Logger().Warn("Failed to check for a previous out of office response", mlog.String("user_id", userID), mlog.String("channel_id", channel.Id), mlog.Err(err))
+			continue
+		}
+		if autoResponded {
+			continue
+		}
+
+		receiver, appErr := a.GetUser(userID)
+		if appErr != nil {
+			rctx.Logger().Warn("Failed to get the out of office user", mlog.String("user_id", userID), mlog.Err(appErr))
+			continue
+		}
+
+		if _, appErr := a.sendOutOfOfficeResponse(rctx, channel, receiver, post, outOfOffice, model.OutOfOfficeResponseTypeMention); appErr != nil {
+			rctx.Logger().Warn("Failed to send the out of office response", mlog.String("user_id", userID), mlog.String("post_id", post.Id), mlog.Err(appErr))
+		}
+	}
+}
+
+func (a *App) sendOutOfOfficeResponse(rctx request.CTX, channel *model.Channel, receiver *model.User, post *model.Post, outOfOffice *model.OutOfOffice, responseType string) (bool, *model.AppError) {
+	message := outOfOffice.Message(responseType)
+	if message == "" {
+		return false, nil
+	}
+
+	if outOfOffice.DelegateUserId != "" {
+		if delegate, appErr := a.GetUser(outOfOffice.DelegateUserId); appErr == nil && delegate.DeleteAt == 0 {
+			T := i18n.GetUserTranslations(receiver.Locale)
+			message += "\n\n" + T("app.out_of_office.delegate", map[string]any{"Username": delegate.Username})
+		}
+	}
+
+	return a.createAutoResponsePost(rctx, channel, receiver, post, message)
+}
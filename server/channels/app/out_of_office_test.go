@@ -0,0 +1,242 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func newTestOutOfOffice(userID string, startAt, endAt int64) *model.OutOfOffice {
+	return &model.OutOfOffice{
+		UserId:         userID,
+		StartAt:        startAt,
+		EndAt:          endAt,
+		DMMessage:      "I'm on vacation",
+		MentionMessage: "I'm on vacation, I'll read this when I'm back",
+	}
+}
+
+func TestSetOutOfOffice(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("turns on right away when the period has started", func(t *testing.T) {
+		user := th.CreateUser()
+		th.App.SetStatusOnline(user.Id, true)
+
+		now := model.GetMillis()
+		saved, appErr := th.App.SetOutOfOffice(th.Context, newTestOutOfOffice(user.Id, now-time.Hour.Milliseconds(), now+time.Hour.Milliseconds()))
+		require.Nil(t, appErr)
+		assert.True(t, saved.Active)
+
+		status, appErr := th.App.GetStatus(user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.StatusOutOfOffice, status.Status)
+
+		appErr = th.App.DeleteOutOfOffice(th.Context, user.Id)
+		require.Nil(t, appErr)
+
+		status, appErr = th.App.GetStatus(user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.StatusOnline, status.Status)
+
+		_, appErr = th.App.GetOutOfOffice(user.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+
+	t.Run("stays off until the period starts", func(t *testing.T) {
+		user := th.CreateUser()
+		th.App.SetStatusOnline(user.Id, true)
+
+		now := model.GetMillis()
+		saved, appErr := th.App.SetOutOfOffice(th.Context, newTestOutOfOffice(user.Id, now+time.Hour.Milliseconds(), now+2*time.Hour.Milliseconds()))
+		require.Nil(t, appErr)
+		assert.False(t, saved.Active)
+
+		status, appErr := th.App.GetStatus(user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.StatusOnline, status.Status)
+	})
+
+	t.Run("delegate must exist", func(t *testing.T) {
+		now := model.GetMillis()
+		outOfOffice := newTestOutOfOffice(th.BasicUser.Id, now, now+time.Hour.Milliseconds())
+		outOfOffice.DelegateUserId = model.NewId()
+
+		_, appErr := th.App.SetOutOfOffice(th.Context, outOfOffice)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.out_of_office.delegate_user.app_error", appErr.Id)
+	})
+}
+
+func TestProcessOutOfOfficeTransitions(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	user := th.CreateUser()
+	th.App.SetStatusOnline(user.Id, true)
+
+	now := model.GetMillis()
+	_, err := th.App.Srv().Store().OutOfOffice().Save(newTestOutOfOffice(user.Id, now-time.Hour.Milliseconds(), now+time.Hour.Milliseconds()))
+	require.NoError(t, err)
+
+	require.NoError(t, th.App.ProcessOutOfOfficeTransitions(th.Context))
+
+	outOfOffice, appErr := th.App.GetOutOfOffice(user.Id)
+	require.Nil(t, appErr)
+	assert.True(t, outOfOffice.Active)
+	status, appErr := th.App.GetStatus(user.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, model.StatusOutOfOffice, status.Status)
+
+	outOfOffice.StartAt = now - 2*time.Hour.Milliseconds()
+	outOfOffice.EndAt = now - time.Hour.Milliseconds()
+	_, err = th.App.Srv().Store().OutOfOffice().Save(outOfOffice)
+	require.NoError(t, err)
+
+	require.NoError(t, th.App.ProcessOutOfOfficeTransitions(th.Context))
+
+	outOfOffice, appErr = th.App.GetOutOfOffice(user.Id)
+	require.Nil(t, appErr)
+	assert.False(t, outOfOffice.Active)
+	status, appErr = th.App.GetStatus(user.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, model.StatusOnline, status.Status)
+}
+
+func TestSendAutoResponseIfNecessaryOutOfOffice(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	setOutOfOffice := func(t *testing.T, outOfOffice *model.OutOfOffice) {
+		t.Helper()
+		_, appErr := th.App.SetOutOfOffice(th.Context, outOfOffice)
+		require.Nil(t, appErr)
+	}
+
+	// The post is created against the basic channel so that the automatic
+	// response isn't sent in the background.
+	createPost := func(t *testing.T, channel *model.Channel, sender *model.User) *model.Post {
+		t.Helper()
+		post, appErr := th.App.CreatePost(th.Context, &model.Post{
+			ChannelId: channel.Id,
+			Message:   NewTestId(),
+			UserId:    sender.Id,
+		}, th.BasicChannel, model.CreatePostFlags{})
+		require.Nil(t, appErr)
+		return post
+	}
+
+	now := model.GetMillis()
+
+	t.Run("replies with the DM message and suggests the delegate", func(t *testing.T) {
+		receiver := th.CreateUser()
+		outOfOffice := newTestOutOfOffice(receiver.Id, now-time.Hour.Milliseconds(), now+time.Hour.Milliseconds())
+		outOfOffice.DelegateUserId = th.BasicUser2.Id
+		setOutOfOffice(t, outOfOffice)
+
+		channel := th.CreateDmChannel(receiver)
+		post := createPost(t, channel, th.BasicUser)
+
+		sent, appErr := th.App.SendAutoResponseIfNecessary(th.Context, channel, th.BasicUser, post)
+		require.Nil(t, appErr)
+		assert.True(t, sent)
+
+		list, appErr := th.App.GetPosts(channel.Id, 0, 1)
+		require.Nil(t, appErr)
+		response := list.Posts[list.Order[0]]
+		assert.Equal(t, model.PostTypeAutoResponder, response.Type)
+		assert.Equal(t, receiver.Id, response.UserId)
+		assert.Contains(t, response.Message, outOfOffice.DMMessage)
+		assert.Contains(t, response.Message, "@"+th.BasicUser2.Username)
+
+		sent, appErr = th.App.SendAutoResponseIfNecessary(th.Context, channel, th.BasicUser, createPost(t, channel, th.BasicUser))
+		require.Nil(t, appErr)
+		assert.False(t, sent, "should only reply once a day")
+	})
+
+	t.Run("does not reply to excluded users", func(t *testing.T) {
+		receiver := th.CreateUser()
+		outOfOffice := newTestOutOfOffice(receiver.Id, now-time.Hour.Milliseconds(), now+time.Hour.Milliseconds())
+		outOfOffice.ExcludedUserIds = model.StringArray{th.BasicUser.Id}
+		setOutOfOffice(t, outOfOffice)
+
+		channel := th.CreateDmChannel(receiver)
+		sent, appErr := th.App.SendAutoResponseIfNecessary(th.Context, channel, th.BasicUser, createPost(t, channel, th.BasicUser))
+		require.Nil(t, appErr)
+		assert.False(t, sent)
+	})
+
+	t.Run("replies to bots unless they are excluded", func(t *testing.T) {
+		receiver := th.CreateUser()
+		outOfOffice := newTestOutOfOffice(receiver.Id, now-time.Hour.Milliseconds(), now+time.Hour.Milliseconds())
+		setOutOfOffice(t, outOfOffice)
+
+		bot := th.CreateBot()
+		botUser, appErr := th.App.GetUser(bot.UserId)
+		require.Nil(t, appErr)
+
+		channel, appErr := th.App.GetOrCreateDirectChannel(th.Context, receiver.Id, botUser.Id)
+		require.Nil(t, appErr)
+
+		outOfOffice.ExcludeBots = true
+		setOutOfOffice(t, outOfOffice)
+		sent, appErr := th.App.SendAutoResponseIfNecessary(th.Context, channel, botUser, createPost(t, channel, botUser))
+		require.Nil(t, appErr)
+		assert.False(t, sent)
+
+		outOfOffice.ExcludeBots = false
+		setOutOfOffice(t, outOfOffice)
+		sent, appErr = th.App.SendAutoResponseIfNecessary(th.Context, channel, botUser, createPost(t, channel, botUser))
+		require.Nil(t, appErr)
+		assert.True(t, sent)
+	})
+}
+
+func TestSendOutOfOfficeMentionResponses(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	now := model.GetMillis()
+	_, appErr := th.App.SetOutOfOffice(th.Context, newTestOutOfOffice(th.BasicUser2.Id, now-time.Hour.Milliseconds(), now+time.Hour.Milliseconds()))
+	require.Nil(t, appErr)
+
+	post, appErr := th.App.CreatePost(th.Context, &model.Post{
+		ChannelId: th.BasicChannel.Id,
+		Message:   "@" + th.BasicUser2.Username + " can you have a look?",
+		UserId:    th.BasicUser.Id,
+	}, th.BasicChannel, model.CreatePostFlags{})
+	require.Nil(t, appErr)
+
+	var response *model.Post
+	require.Eventually(t, func() bool {
+		list, appErr := th.App.GetPostThread(post.Id, model.GetPostsOptions{}, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		for _, p := range list.Posts {
+			if p.Type == model.PostTypeAutoResponder {
+				response = p
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 100*time.Millisecond)
+
+	assert.Equal(t, th.BasicUser2.Id, response.UserId)
+	assert.Equal(t, post.Id, response.RootId)
+	assert.Equal(t, "I'm on vacation, I'll read this when I'm back", response.Message)
+
+	// Only one response is sent per day in a channel.
+	th.App.SendOutOfOfficeMentionResponses(th.Context, th.BasicChannel, th.BasicUser, post, []string{th.BasicUser2.Id})
+	list, appErr := th.App.GetPostThread(post.Id, model.GetPostsOptions{}, th.BasicUser.Id)
+	require.Nil(t, appErr)
+	assert.Len(t, list.Posts, 2)
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// GetPersistentNotificationEscalations returns the escalation chain of the
+// persistent notifications posted in the given channel.
+func (a *App) GetPersistentNotificationEscalations(channelID string) ([]*model.PersistentNotificationEscalation, *model.AppError) {
+	escalations, err := a.Srv().Store().PersistentNotificationEscalation().GetForChannels([]string{channelID})
+	if err != nil {
+		return nil, model.NewAppError("GetPersistentNotificationEscalations", "app.persistent_notification_escalation.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return escalations, nil
+}
+
+// CreatePersistentNotificationEscalation adds a step to the escalation chain
+// of a channel, after checking that its group or fallback channel exists.
+func (a *App) CreatePersistentNotificationEscalation(rctx request.CTX, escalation *model.PersistentNotificationEscalation) (*model.PersistentNotificationEscalation, *model.AppError) {
+	if escalation.AfterCount > *a.Config().ServiceSettings.PersistentNotificationMaxCount {
+		return nil, model.NewAppError("CreatePersistentNotificationEscalation", "app.persistent_notification_escalation.create.after_count.app_error", map[string]any{"Max": *a.Config().ServiceSettings.PersistentNotificationMaxCount}, "", http.StatusBadRequest)
+	}
+
+	escalations, appErr := a.GetPersistentNotificationEscalations(escalation.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if len(escalations) >= model.PersistentNotificationEscalationsMax {
+		return nil, model.NewAppError("CreatePersistentNotificationEscalation", "app.persistent_notification_escalation.create.too_many.app_error", map[string]any{"Max": model.PersistentNotificationEscalationsMax}, "", http.StatusBadRequest)
+	}
+
+	switch escalation.Type {
+	case model.PersistentNotificationEscalationTypeGroup:
+		if _, appErr := a.GetGroup(escalation.GroupId, nil, nil); appErr != nil {
+			return nil, appErr
+		}
+	case model.PersistentNotificationEscalationTypeChannel:
+		fallback, appErr := a.GetChannel(rctx, escalation.FallbackChannelId)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if fallback.DeleteAt != 0 {
+			return nil, model.NewAppError("CreatePersistentNotificationEscalation", "app.persistent_notification_escalation.create.archived_channel.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	saved, err := a.Srv().Store().PersistentNotificationEscalation().Save(escalation)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreatePersistentNotificationEscalation", "app.persistent_notification_escalation.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return saved, nil
+}
+
+// DeletePersistentNotificationEscalation removes a step from the escalation
+// chain of a channel.
+func (a *App) DeletePersistentNotificationEscalation(channelID, escalationID string) *model.AppError {
+	escalation, err := a.Srv().Store().PersistentNotificationEscalation().Get(escalationID)
+	if err == nil && escalation.ChannelId != channelID {
+		err = store.NewErrNotFound("PersistentNotificationEscalation", escalationID)
+	}
+	if err == nil {
+		err = a.Srv().Store().PersistentNotificationEscalation().Delete(escalationID)
+	}
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("DeletePersistentNotificationEscalation", "app.persistent_notification_escalation.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return model.NewAppError("DeletePersistentNotificationEscalation", "app.persistent_notification_escalation.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// GetPostPersistentNotificationEscalations returns the escalation chain of a
+// persistent notification post, telling which steps were already reached.
+func (a *App) GetPostPersistentNotificationEscalations(post *model.Post) (*model.PostPersistentNotificationEscalations, *model.AppError) {
+	notification, err := a.Srv().Store().PostPersistentNotification().GetSingle(post.Id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetPostPersistentNotificationEscalations", "app.persistent_notification_escalation.get_for_post.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetPostPersistentNotificationEscalations", "app.persistent_notification_escalation.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	escalations, appErr := a.GetPersistentNotificationEscalations(post.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	result := &model.PostPersistentNotificationEscalations{
+		PostId:    post.Id,
+		SentCount: notification.SentCount,
+		Steps:     make([]*model.PersistentNotificationEscalationStep, 0, len(escalations)),
+	}
+	for _, escalation := range escalations {
+		result.Steps = append(result.Steps, &model.PersistentNotificationEscalationStep{
+			PersistentNotificationEscalation: escalation,
+			Escalated:                        int(notification.SentCount) >= escalation.AfterCount,
+		})
+	}
+
+	return result, nil
+}
+
+// persistentNotificationEscalationsForPosts returns the escalation steps of the
+// channels of the given posts, by channel.
+func (a *App) persistentNotificationEscalationsForPosts(posts []*model.Post) (map[string][]*model.PersistentNotificationEscalation, error) {
+	channelIds := make(model.StringSet)
+	for _, p := range posts {
+		channelIds.Add(p.ChannelId)
+	}
+
+	escalations, err := a.Srv().Store().PersistentNotificationEscalation().GetForChannels(channelIds.Val())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get persistent notification escalations")
+	}
+
+	channelEscalations := make(map[string][]*model.PersistentNotificationEscalation, len(channelIds))
+	for _, escalation := range escalations {
+		channelEscalations[escalation.ChannelId] = append(channelEscalations[escalation.ChannelId], escalation)
+	}
+	return channelEscalations, nil
+}
+
+// escalatePersistentNotification runs the escalation steps reached by the
+// sentCount-th notification of the post. Failing steps are logged so that
+// they don't prevent the other notifications from being sent.
+func (a *App) escalatePersistentNotification(post *model.Post, channel *model.Channel, team *model.Team, profileMap model.UserMap, channelNotifyProps map[string]map[string]model.StringMap, escalations []*model.PersistentNotificationEscalation, sentCount int) {
+	for _, escalation := range escalations {
+		if escalation.AfterCount != sentCount {
+			continue
+		}
+
+		var err error
+		switch escalation.Type {
+		case model.PersistentNotificationEscalationTypeGroup:
+			err = a.escalatePersistentNotificationToGroup(post, channel, team, profileMap, channelNotifyProps, escalation)
+		case model.PersistentNotificationEscalationTypeChannel:
+			err = a.escalatePersistentNotificationToChannel(post, team, profileMap, escalation)
+		}
+		if err != nil {
+			a.Log().Warn("Failed to escalate persistent notification",
+				mlog.String("post_id", post.Id),
+				mlog.String("escalation_id", escalation.Id),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// escalatePersistentNotificationToGroup notifies the members of the group who
+// can read the post, as if they had been mentioned in it.
+func (a *App) escalatePersistentNotificationToGroup(post *model.Post, channel *model.Channel, team *model.Team, profileMap model.UserMap, channelNotifyProps map[string]map[string]model.StringMap, escalation *model.PersistentNotificationEscalation) error {
+	members, err := a.Srv().Store().Group().GetMemberUsers(escalation.GroupId)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get members of group %s", escalation.GroupId)
+	}
+
+	mentions := &MentionResults{}
+	for _, member := range members {
+		if _, ok := profileMap[member.Id]; ok {
+			mentions.addMention(member.Id, GroupMention)
+		}
+	}
+	if len(mentions.Mentions) == 0 {
+		return nil
+	}
+
+	return a.sendPersistentNotifications(post, channel, team, mentions, profileMap, channelNotifyProps)
+}
+
+// escalatePersistentNotificationToChannel posts a message linking to the post
+// in the fallback channel, on behalf of the system bot.
+func (a *App) escalatePersistentNotificationToChannel(post *model.Post, team *model.Team, profileMap model.UserMap, escalation *model.PersistentNotificationEscalation) error {
+	rctx := request.EmptyContext(a.Log())
+
+	fallback, appErr := a.GetChannel(rctx, escalation.FallbackChannelId)
+	if appErr != nil {
+		return appErr
+	}
+	if fallback.DeleteAt != 0 {
+		return errors.Errorf("fallback channel %s is archived", fallback.Id)
+	}
+
+	systemBot, appErr := a.GetSystemBot(rctx)
+	if appErr != nil {
+		return appErr
+	}
+
+	siteURL := *a.Config().ServiceSettings.SiteURL
+	permalink := fmt.Sprintf("%s/pl/%s", siteURL, post.Id)
+	if team.Name != "" {
+		permalink = makePostLink(siteURL, team.Name, post.Id)
+	}
+
+	username := post.UserId
+	if sender := profileMap[post.UserId]; sender != nil {
+		username = sender.Username
+	}
+
+	escalationPost := &model.Post{
+		ChannelId: fallback.Id,
+		UserId:    systemBot.UserId,
+		Message: i18n.T("app.persistent_notification_escalation.message", map[string]any{
+			"Username":  username,
+			"Count":     escalation.AfterCount,
+			"Permalink": permalink,
+		}),
+	}
+	if _, appErr := a.CreatePost(rctx, escalationPost, fallback, model.CreatePostFlags{}); appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
+// isPersistentNotificationEscalatedTo reports whether the user belongs to a
+// group the post was escalated to after sentCount notifications.
+func (a *App) isPersistentNotificationEscalatedTo(post *model.Post, sentCount int16, userID string) (bool, error) {
+	escalations, err := a.Srv().Store().PersistentNotificationEscalation().GetForChannels([]string{post.ChannelId})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get persistent notification escalations")
+	}
+
+	for _, escalation := range escalations {
+		if escalation.Type != model.PersistentNotificationEscalationTypeGroup || escalation.AfterCount > int(sentCount) {
+			continue
+		}
+		if _, err := a.Srv().Store().Group().GetMember(escalation.GroupId, userID); err == nil {
+			return true, nil
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return false, errors.Wrapf(err, "failed to get member of group %s", escalation.GroupId)
+		}
+	}
+
+	return false, nil
+}
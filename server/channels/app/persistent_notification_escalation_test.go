@@ -0,0 +1,113 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestPersistentNotificationEscalations(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	fallback := th.CreateChannel(th.Context, th.BasicTeam)
+	group := th.CreateGroup()
+
+	groupStep, appErr := th.App.CreatePersistentNotificationEscalation(th.Context, &model.PersistentNotificationEscalation{
+		ChannelId:  th.BasicChannel.Id,
+		AfterCount: 2,
+		Type:       model.PersistentNotificationEscalationTypeGroup,
+		GroupId:    group.Id,
+		CreatorId:  th.BasicUser.Id,
+	})
+	require.Nil(t, appErr)
+
+	channelStep, appErr := th.App.CreatePersistentNotificationEscalation(th.Context, &model.PersistentNotificationEscalation{
+		ChannelId:         th.BasicChannel.Id,
+		AfterCount:        1,
+		Type:              model.PersistentNotificationEscalationTypeChannel,
+		FallbackChannelId: fallback.Id,
+		CreatorId:         th.BasicUser.Id,
+	})
+	require.Nil(t, appErr)
+
+	t.Run("get the chain in order", func(t *testing.T) {
+		escalations, appErr := th.App.GetPersistentNotificationEscalations(th.BasicChannel.Id)
+		require.Nil(t, appErr)
+		require.Len(t, escalations, 2)
+		assert.Equal(t, channelStep.Id, escalations[0].Id)
+		assert.Equal(t, groupStep.Id, escalations[1].Id)
+	})
+
+	t.Run("after count above the maximum number of notifications", func(t *testing.T) {
+		_, appErr := th.App.CreatePersistentNotificationEscalation(th.Context, &model.PersistentNotificationEscalation{
+			ChannelId:  th.BasicChannel.Id,
+			AfterCount: *th.App.Config().ServiceSettings.PersistentNotificationMaxCount + 1,
+			Type:       model.PersistentNotificationEscalationTypeGroup,
+			GroupId:    group.Id,
+			CreatorId:  th.BasicUser.Id,
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("unknown fallback channel", func(t *testing.T) {
+		_, appErr := th.App.CreatePersistentNotificationEscalation(th.Context, &model.PersistentNotificationEscalation{
+			ChannelId:         th.BasicChannel.Id,
+			AfterCount:        1,
+			Type:              model.PersistentNotificationEscalationTypeChannel,
+			FallbackChannelId: model.NewId(),
+			CreatorId:         th.BasicUser.Id,
+		})
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("escalate to the fallback channel", func(t *testing.T) {
+		post, appErr := th.App.CreatePost(th.Context, &model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			Message:   "@" + th.BasicUser2.Username + " the database is down",
+		}, th.BasicChannel, model.CreatePostFlags{})
+		require.Nil(t, appErr)
+
+		escalations, appErr := th.App.GetPersistentNotificationEscalations(th.BasicChannel.Id)
+		require.Nil(t, appErr)
+
+		profileMap := model.UserMap{th.BasicUser.Id: th.BasicUser}
+		th.App.escalatePersistentNotification(post, th.BasicChannel, th.BasicTeam, profileMap, nil, escalations, 1)
+
+		posts, appErr := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: fallback.Id, PerPage: 10})
+		require.Nil(t, appErr)
+
+		var found bool
+		for _, p := range posts.Posts {
+			if p.Type == "" {
+				found = true
+				assert.Contains(t, p.Message, "/"+th.BasicTeam.Name+"/pl/"+post.Id)
+				assert.Contains(t, p.Message, "@"+th.BasicUser.Username)
+			}
+		}
+		assert.True(t, found, "an escalation message should be posted in the fallback channel")
+	})
+
+	t.Run("delete a step", func(t *testing.T) {
+		appErr := th.App.DeletePersistentNotificationEscalation(fallback.Id, groupStep.Id)
+		require.NotNil(t, appErr, "steps can only be deleted from their own channel")
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+		appErr = th.App.DeletePersistentNotificationEscalation(th.BasicChannel.Id, groupStep.Id)
+		require.Nil(t, appErr)
+
+		escalations, appErr := th.App.GetPersistentNotificationEscalations(th.BasicChannel.Id)
+		require.Nil(t, appErr)
+		require.Len(t, escalations, 1)
+		assert.Equal(t, channelStep.Id, escalations[0].Id)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/platform/services/rediscluster"
 )
 
 func (ps *PlatformService) Cluster() einterfaces.ClusterInterface {
@@ -29,7 +30,13 @@ func (ps *PlatformService) NewClusterDiscoveryService() *ClusterDiscoveryService
 }
 
 func (ps *PlatformService) IsLeader() bool {
-	if ps.License() != nil && *ps.Config().ClusterSettings.Enable && ps.clusterIFace != nil {
+	if !*ps.Config().ClusterSettings.Enable || ps.clusterIFace == nil {
+		return true
+	}
+
+	// The Redis transport runs without a license, so it elects its leader
+	// regardless of it.
+	if _, ok := ps.clusterIFace.(*rediscluster.Cluster); ok || ps.License() != nil {
 		return ps.clusterIFace.IsLeader()
 	}
 
@@ -0,0 +1,146 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/redis/rueidis"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/config"
+	"github.com/mattermost/mattermost/server/v8/platform/services/rediscluster"
+)
+
+// redisClusterNode exposes the platform service to the Redis cluster transport.
+type redisClusterNode struct {
+	ps *PlatformService
+}
+
+func (ps *PlatformService) newRedisCluster() (*rediscluster.Cluster, error) {
+	cfg := ps.Config()
+
+	client, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:       []string{*cfg.CacheSettings.RedisAddress},
+		Password:          *cfg.CacheSettings.RedisPassword,
+		SelectDB:          *cfg.CacheSettings.RedisDB,
+		ForceSingleClient: true,
+		DisableCache:      true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rediscluster.New(rediscluster.Options{
+		Client:      client,
+		ClusterName: *cfg.ClusterSettings.ClusterName,
+	}, &redisClusterNode{ps: ps}, ps.Log())
+}
+
+func (n *redisClusterNode) Config() *model.Config {
+	return n.ps.Config()
+}
+
+func (n *redisClusterNode) ClusterInfo() *model.ClusterInfo {
+	cfg := n.ps.Config()
+
+	hostname := *cfg.ClusterSettings.OverrideHostname
+	if hostname == "" {
+		var err error
+		if hostname, err = os.Hostname(); err != nil {
+			n.ps.Log().Warn("Failed to get the hostname", mlog.Err(err))
+		}
+	}
+
+	info := &model.ClusterInfo{
+		Version:    model.CurrentVersion,
+		ConfigHash: n.ps.ClientConfigHash(),
+		IPAddress:  *cfg.ClusterSettings.AdvertiseAddress,
+		Hostname:   hostname,
+	}
+	if n.ps.Store != nil {
+		if version, err := n.ps.Store.GetDBSchemaVersion(); err == nil {
+			info.SchemaVersion = strconv.Itoa(version)
+		}
+	}
+
+	return info
+}
+
+func (n *redisClusterNode) ClusterStats() *model.ClusterStats {
+	stats := &model.ClusterStats{
+		TotalWebsocketConnections: n.ps.TotalWebsocketConnections(),
+	}
+	if n.ps.Store != nil {
+		stats.TotalMasterDbConnections = n.ps.Store.TotalMasterDbConnections()
+		stats.TotalReadDbConnections = n.ps.Store.TotalReadDbConnections()
+	}
+
+	return stats
+}
+
+func (n *redisClusterNode) GetLogs(rctx request.CTX, page, perPage int) ([]string, *model.AppError) {
+	return n.ps.GetLogsSkipSend(rctx, page, perPage, &model.LogFilter{})
+}
+
+func (n *redisClusterNode) GetPluginStatuses() (model.PluginStatuses, *model.AppError) {
+	return n.ps.GetPluginStatuses()
+}
+
+func (n *redisClusterNode) WebConnCountForUser(userID string) int {
+	return n.ps.WebConnCountForUser(userID)
+}
+
+// SupportPacketFiles returns the node specific files of the support packet,
+// stored in a directory named after the node.
+func (n *redisClusterNode) SupportPacketFiles(rctx request.CTX, options *model.SupportPacketOptions) []model.FileData {
+	functions := map[string]func(c request.CTX) (*model.FileData, error){
+		"cpu profile":  n.ps.CreateCPUProfile,
+		"heap profile": n.ps.CreateHeapProfile,
+		"goroutines":   n.ps.CreateGoroutineProfile,
+	}
+	if options.IncludeLogs {
+		functions["mattermost log"] = n.ps.GetLogFile
+		functions["notification log"] = n.ps.GetNotificationLogFile
+	}
+
+	hostname := n.ClusterInfo().Hostname
+
+	var files []model.FileData
+	for name, fn := range functions {
+		fileData, err := fn(rctx)
+		if err != nil {
+			rctx.Logger().Error("Failed to generate file for Support Packet", mlog.String("file", name), mlog.Err(err))
+			continue
+		}
+		if fileData != nil {
+			fileData.Filename = path.Join(hostname, fileData.Filename)
+			files = append(files, *fileData)
+		}
+	}
+
+	return files
+}
+
+// ApplyConfig stores the configuration saved by another node. Database
+// backed configurations are shared, so they only need to be reloaded.
+func (n *redisClusterNode) ApplyConfig(cfg *model.Config) error {
+	if config.IsDatabaseDSN(n.ps.DescribeConfig()) {
+		return n.ps.ReloadConfig()
+	}
+
+	if _, _, appErr := n.ps.SaveConfig(cfg, false); appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
+func (n *redisClusterNode) LeaderChanged() {
+	n.ps.InvokeClusterLeaderChangedListeners()
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestRedisClusterIsLeader(t *testing.T) {
+	th := SetupWithStoreMock(t)
+	defer th.TearDown()
+
+	server := miniredis.RunT(t)
+	th.Service.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ClusterSettings.Enable = true
+		*cfg.ClusterSettings.Transport = model.ClusterTransportRedis
+		*cfg.CacheSettings.RedisAddress = server.Addr()
+	})
+	th.Service.SetLicense(nil)
+
+	cluster, err := th.Service.newRedisCluster()
+	require.NoError(t, err)
+	th.Service.clusterIFace = cluster
+	defer func() {
+		th.Service.clusterIFace = nil
+	}()
+
+	require.False(t, th.Service.IsLeader(), "the node should not lead before taking part in the election")
+
+	cluster.StartInterNodeCommunication()
+	defer cluster.StopInterNodeCommunication()
+
+	require.Eventually(t, th.Service.IsLeader, 5*time.Second, 50*time.Millisecond, "the node should lead once elected, without a license")
+}
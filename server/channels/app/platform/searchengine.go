@@ -83,4 +83,7 @@ func (ps *PlatformService) StopSearchEngine() {
 	if ps.SearchEngine != nil && ps.SearchEngine.BleveEngine != nil && ps.SearchEngine.BleveEngine.IsActive() {
 		ps.SearchEngine.BleveEngine.Stop()
 	}
+	if ps.SearchEngine != nil && ps.SearchEngine.PostgresEngine != nil && ps.SearchEngine.PostgresEngine.IsActive() {
+		ps.SearchEngine.PostgresEngine.Stop()
+	}
 }
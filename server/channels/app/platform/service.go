@@ -29,6 +29,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
 	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
 	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine/bleveengine"
+	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine/postgresengine"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 )
 
@@ -194,10 +195,21 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 		return nil, err
 	}
 	searchEngine.RegisterBleveEngine(bleveEngine)
+	// The Postgres engine is started once the store is created, as it uses its connections.
+	postgresEngine := postgresengine.NewPostgresEngine(ps.Config())
+	searchEngine.RegisterPostgresEngine(postgresEngine)
 	ps.SearchEngine = searchEngine
 
 	// Step 4: Init Enterprise
 	// Depends on step 3 (s.SearchEngine must be non-nil)
+	// The Redis cluster transport takes precedence over the enterprise one.
+	clusterSettings := ps.Config().ClusterSettings
+	if ps.clusterIFace == nil && *clusterSettings.Enable && *clusterSettings.Transport == model.ClusterTransportRedis {
+		ps.clusterIFace, err = ps.newRedisCluster()
+		if err != nil {
+			return nil, fmt.Errorf("unable to create redis cluster: %w", err)
+		}
+	}
 	ps.initEnterprise()
 
 	// Step 5: Init Metrics
@@ -261,7 +273,11 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 	// Step 3: Initialize filestore
 	if ps.filestore == nil {
 		insecure := ps.Config().ServiceSettings.EnableInsecureOutgoingConnections
-		backend, err2 := filestore.NewFileBackend(filestore.NewFileBackendSettingsFromConfig(&ps.Config().FileSettings, license != nil && *license.Features.Compliance, insecure != nil && *insecure))
+		fileBackendSettings := filestore.NewFileBackendSettingsFromConfig(&ps.Config().FileSettings, license != nil && *license.Features.Compliance, insecure != nil && *insecure)
+		if ps.metricsIFace != nil {
+			fileBackendSettings.LocalCacheMetrics = ps.metricsIFace
+		}
+		backend, err2 := filestore.NewFileBackend(fileBackendSettings)
 		if err2 != nil {
 			return nil, fmt.Errorf("failed to initialize filebackend: %w", err2)
 		}
@@ -287,6 +303,15 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 		return nil, fmt.Errorf("cannot create store: %w", err)
 	}
 
+	if ps.sqlStore != nil && ps.sqlStore.DriverName() == model.DatabaseDriverPostgres {
+		postgresEngine.SetDB(ps.sqlStore.GetInternalMasterDB())
+	}
+	if postgresEngine.IsEnabled() {
+		if appErr := postgresEngine.Start(); appErr != nil {
+			ps.Log().Error("Failed to start the Postgres search engine", mlog.Err(appErr))
+		}
+	}
+
 	// Note: we hardcode the session and status cache to LRU because they lead
 	// to a lot of SCAN calls in case of Redis. We could potentially have a
 	// reverse mapping to avoid the scan, but this needs more complicated code.
@@ -2141,3 +2141,57 @@ func TestUserHasJoinedChannel(t *testing.T) {
 		}
 	})
 }
+
+func TestHookMessagesWillBeTranslated(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	var mockAPI plugintest.API
+	mockAPI.On("LoadPluginConfiguration", mock.Anything).Return(nil)
+
+	tearDown, _, _ := SetAppEnvironmentWithPlugins(t, []string{`
+		package main
+
+		import (
+			"strings"
+
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) MessagesWillBeTranslated(posts []*model.Post, language string) map[string]string {
+			translations := map[string]string{}
+			for _, post := range posts {
+				if strings.HasPrefix(post.Message, "translate") {
+					translations[post.Id] = language + ":" + post.Message
+				}
+			}
+			return translations
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`}, th.App, func(*model.Manifest) plugin.API { return &mockAPI })
+	defer tearDown()
+
+	err := th.App.UpdatePreferences(th.Context, th.BasicUser.Id, model.Preferences{{
+		UserId:   th.BasicUser.Id,
+		Category: model.PreferenceCategoryDisplaySettings,
+		Name:     model.PreferenceNameTranslationLanguage,
+		Value:    "fr",
+	}})
+	require.Nil(t, err)
+
+	translated := &model.Post{Id: model.NewId(), UserId: th.BasicUser2.Id, Message: "translate me"}
+	untranslated := &model.Post{Id: model.NewId(), UserId: th.BasicUser2.Id, Message: "leave me"}
+	th.App.TranslatePostsForUser(th.Context, []*model.Post{translated, untranslated}, th.BasicUser.Id)
+
+	require.NotNil(t, translated.Metadata)
+	assert.Equal(t, &model.PostTranslation{Language: "fr", Message: "fr:translate me"}, translated.Metadata.Translation)
+	assert.Nil(t, untranslated.Metadata)
+}
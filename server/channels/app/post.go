@@ -549,7 +549,8 @@ func (a *App) handlePostEvents(c request.CTX, post *model.Post, user *model.User
 	}
 	a.Srv().Store().Post().InvalidateLastPostTimeCache(channel.Id)
 
-	if _, err := a.SendNotifications(c, post, team, channel, user, parentPostList, setOnline); err != nil {
+	mentionedUserIDs, err := a.SendNotifications(c, post, team, channel, user, parentPostList, setOnline)
+	if err != nil {
 		return err
 	}
 
@@ -559,6 +560,7 @@ func (a *App) handlePostEvents(c request.CTX, post *model.Post, user *model.User
 			if err != nil {
 				c.Logger().Error("Failed to send auto response", mlog.String("user_id", user.Id), mlog.String("post_id", post.Id), mlog.Err(err))
 			}
+			a.SendOutOfOfficeMentionResponses(c, channel, user, post, mentionedUserIDs)
 		})
 	}
 
@@ -2197,6 +2199,65 @@ func (a *App) SetPostReminder(rctx request.CTX, postID, userID string, targetTim
 	return nil
 }
 
+// GetPostRemindersForUser returns the pending post reminders of a user, the earliest first.
+func (a *App) GetPostRemindersForUser(userID string) ([]*model.PostReminder, *model.AppError) {
+	reminders, err := a.Srv().Store().Post().GetPostRemindersForUser(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetPostRemindersForUser", "app.post_reminder.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return reminders, nil
+}
+
+// DeletePostReminder cancels the reminder of a user about a post.
+func (a *App) DeletePostReminder(postID, userID string) *model.AppError {
+	if err := a.Srv().Store().Post().DeletePostReminder(postID, userID); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("DeletePostReminder", "app.post_reminder.delete.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return model.NewAppError("DeletePostReminder", "app.post_reminder.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// postReminderSnoozeOptions are the delays offered to snooze a reminder.
+var postReminderSnoozeOptions = []struct {
+	id      string
+	minutes int
+}{
+	{"snooze20m", 20},
+	{"snooze1h", 60},
+	{"snooze3h", 3 * 60},
+	{"snooze1d", 24 * 60},
+}
+
+func postReminderSnoozeAttachments(T i18n.TranslateFunc, postID string) []*model.SlackAttachment {
+	actions := make([]*model.PostAction, 0, len(postReminderSnoozeOptions))
+	for _, option := range postReminderSnoozeOptions {
+		actions = append(actions, &model.PostAction{
+			Id:   option.id,
+			Type: model.PostActionTypeButton,
+			Name: T("app.post_reminder_dm." + option.id),
+			Integration: &model.PostActionIntegration{
+				URL: model.PostReminderSnoozeActionURL,
+				Context: map[string]any{
+					"post_id":        postID,
+					"snooze_minutes": option.minutes,
+				},
+			},
+		})
+	}
+
+	return []*model.SlackAttachment{{
+		Text:    T("app.post_reminder_dm.snooze"),
+		Actions: actions,
+	}}
+}
+
 func (a *App) CheckPostReminders(rctx request.CTX) {
 	rctx = rctx.WithLogger(rctx.Logger().With(mlog.String("component", "post_reminders")))
 	systemBot, appErr := a.GetSystemBot(rctx)
@@ -2253,9 +2314,10 @@ func (a *App) CheckPostReminders(rctx request.CTX) {
 				Type:   model.PostTypeReminder,
 				UserId: systemBot.UserId,
 				Props: model.StringInterface{
-					"team_name": metadata.TeamName,
-					"post_id":   postID,
-					"username":  metadata.Username,
+					"team_name":   metadata.TeamName,
+					"post_id":     postID,
+					"username":    metadata.Username,
+					"attachments": postReminderSnoozeAttachments(T, postID),
 				},
 			}
 
@@ -81,6 +81,12 @@ func (a *App) PreparePostListForClient(c request.CTX, originalList *model.PostLi
 		}
 	}
 
+	posts := make([]*model.Post, 0, len(list.Posts))
+	for _, post := range list.Posts {
+		posts = append(posts, post)
+	}
+	a.TranslatePostsForUser(c, posts, c.Session().UserId)
+
 	return list
 }
 
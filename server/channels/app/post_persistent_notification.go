@@ -27,7 +27,7 @@ func (a *App) ResolvePersistentNotification(c request.CTX, post *model.Post, log
 		return nil
 	}
 
-	_, err := a.Srv().Store().PostPersistentNotification().GetSingle(post.Id)
+	notification, err := a.Srv().Store().PostPersistentNotification().GetSingle(post.Id)
 	if err != nil {
 		var nfErr *store.ErrNotFound
 		switch {
@@ -65,6 +65,15 @@ func (a *App) ResolvePersistentNotification(c request.CTX, post *model.Post, log
 		return model.NewAppError("ResolvePersistentNotification", "app.post_priority.delete_persistent_notification_post.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	// Members of the groups the notifications were escalated to can stop them too
+	if !stopNotifications {
+		escalated, err := a.isPersistentNotificationEscalatedTo(post, notification.SentCount, loggedInUserID)
+		if err != nil {
+			return model.NewAppError("ResolvePersistentNotification", "app.post_priority.delete_persistent_notification_post.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		stopNotifications = escalated
+	}
+
 	// Only mentioned users can stop the notifications
 	if !stopNotifications {
 		return nil
@@ -134,8 +143,23 @@ func (a *App) SendPersistentNotifications() error {
 			return errors.Wrap(err, "failed to get posts by IDs")
 		}
 
-		// Send notifications
-		if err := a.forEachPersistentNotificationPost(posts, a.sendPersistentNotifications); err != nil {
+		sentCounts := make(map[string]int16, len(notificationPosts))
+		for _, p := range notificationPosts {
+			sentCounts[p.PostId] = p.SentCount
+		}
+		escalations, err := a.persistentNotificationEscalationsForPosts(posts)
+		if err != nil {
+			return err
+		}
+
+		// Send notifications, and escalate the ones reaching an escalation step
+		if err := a.forEachPersistentNotificationPost(posts, func(post *model.Post, channel *model.Channel, team *model.Team, mentions *MentionResults, profileMap model.UserMap, channelNotifyProps map[string]map[string]model.StringMap) error {
+			if err := a.sendPersistentNotifications(post, channel, team, mentions, profileMap, channelNotifyProps); err != nil {
+				return err
+			}
+			a.escalatePersistentNotification(post, channel, team, profileMap, channelNotifyProps, escalations[post.ChannelId], int(sentCounts[post.Id])+1)
+			return nil
+		}); err != nil {
 			return err
 		}
 
@@ -136,6 +136,10 @@ func TestResolvePersistentNotification(t *testing.T) {
 		mockStore.On("Group").Return(&mockGroup)
 		mockGroup.On("GetGroups", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*model.Group{}, nil)
 
+		mockEscalation := storemocks.PersistentNotificationEscalationStore{}
+		mockStore.On("PersistentNotificationEscalation").Return(&mockEscalation)
+		mockEscalation.On("GetForChannels", []string{channel.Id}).Return([]*model.PersistentNotificationEscalation{}, nil)
+
 		th.App.Srv().SetLicense(getLicWithSkuShortName(model.LicenseShortSkuProfessional))
 		cfg := th.App.Config()
 		*cfg.ServiceSettings.PostPriority = true
@@ -145,6 +149,60 @@ func TestResolvePersistentNotification(t *testing.T) {
 		require.Nil(t, err)
 		mockPostPersistentNotification.AssertNotCalled(t, "Delete", mock.Anything)
 	})
+
+	t.Run("should delete for member of an escalation group", func(t *testing.T) {
+		th := SetupWithStoreMock(t)
+		defer th.TearDown()
+
+		user1 := &model.User{Id: "uid1", Username: "user-1"}
+		user2 := &model.User{Id: "uid2", Username: "user-2"}
+		user3 := &model.User{Id: "uid3", Username: "user-3"}
+		profileMap := map[string]*model.User{user1.Id: user1, user2.Id: user2, user3.Id: user3}
+		team := &model.Team{Id: "tid"}
+		channel := &model.Channel{Id: "chid", TeamId: team.Id, Type: model.ChannelTypeOpen}
+		post := &model.Post{Id: "pid", ChannelId: channel.Id, Message: "tagging @" + user1.Username, UserId: user2.Id}
+		reached := &model.PersistentNotificationEscalation{Type: model.PersistentNotificationEscalationTypeGroup, GroupId: "reached", AfterCount: 2}
+		notReached := &model.PersistentNotificationEscalation{Type: model.PersistentNotificationEscalationTypeGroup, GroupId: "not-reached", AfterCount: 4}
+
+		mockStore := th.App.Srv().Store().(*storemocks.Store)
+
+		mockPostPersistentNotification := storemocks.PostPersistentNotificationStore{}
+		mockStore.On("PostPersistentNotification").Return(&mockPostPersistentNotification)
+		mockPostPersistentNotification.On("GetSingle", mock.Anything).Return(&model.PostPersistentNotifications{PostId: post.Id, SentCount: 3}, nil)
+		mockPostPersistentNotification.On("Delete", mock.Anything).Return(nil)
+
+		mockChannel := storemocks.ChannelStore{}
+		mockStore.On("Channel").Return(&mockChannel)
+		mockChannel.On("GetChannelsByIds", mock.Anything, mock.Anything).Return([]*model.Channel{channel}, nil)
+		mockChannel.On("GetAllChannelMembersNotifyPropsForChannel", mock.Anything, mock.Anything).Return(map[string]model.StringMap{}, nil)
+
+		mockTeam := storemocks.TeamStore{}
+		mockStore.On("Team").Return(&mockTeam)
+		mockTeam.On("GetMany", mock.Anything).Return([]*model.Team{team}, nil)
+
+		mockUser := storemocks.UserStore{}
+		mockStore.On("User").Return(&mockUser)
+		mockUser.On("GetAllProfilesInChannel", mock.Anything, mock.Anything, mock.Anything).Return(profileMap, nil)
+
+		mockGroup := storemocks.GroupStore{}
+		mockStore.On("Group").Return(&mockGroup)
+		mockGroup.On("GetGroups", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*model.Group{}, nil)
+		mockGroup.On("GetMember", reached.GroupId, user3.Id).Return(&model.GroupMember{GroupId: reached.GroupId, UserId: user3.Id}, nil)
+
+		mockEscalation := storemocks.PersistentNotificationEscalationStore{}
+		mockStore.On("PersistentNotificationEscalation").Return(&mockEscalation)
+		mockEscalation.On("GetForChannels", []string{channel.Id}).Return([]*model.PersistentNotificationEscalation{reached, notReached}, nil)
+
+		th.App.Srv().SetLicense(getLicWithSkuShortName(model.LicenseShortSkuProfessional))
+		cfg := th.App.Config()
+		*cfg.ServiceSettings.PostPriority = true
+		*cfg.ServiceSettings.AllowPersistentNotificationsForGuests = true
+
+		err := th.App.ResolvePersistentNotification(th.Context, post, user3.Id)
+		require.Nil(t, err)
+		mockPostPersistentNotification.AssertCalled(t, "Delete", mock.Anything)
+		mockGroup.AssertNotCalled(t, "GetMember", notReached.GroupId, mock.Anything)
+	})
 }
 
 func TestDeletePersistentNotification(t *testing.T) {
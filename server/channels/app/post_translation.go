@@ -0,0 +1,90 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// GetTranslationLanguageForUser returns the language the user has chosen to have posts translated to, or an empty
+// string if they have not enabled automatic translation.
+func (a *App) GetTranslationLanguageForUser(c request.CTX, userID string) string {
+	preference, err := a.Srv().Store().Preference().Get(userID, model.PreferenceCategoryDisplaySettings, model.PreferenceNameTranslationLanguage)
+	if err != nil {
+		return ""
+	}
+
+	return preference.Value
+}
+
+// TranslatePostsForUser attaches to the metadata of the given posts their message translated to the preferred
+// language of the user. Plugins implementing the MessagesWillBeTranslated hook are asked first, and the translation
+// service, if any, translates the remaining posts. Posts are modified in place, so they must already be prepared for
+// the client.
+func (a *App) TranslatePostsForUser(c request.CTX, posts []*model.Post, userID string) {
+	if userID == "" || len(posts) == 0 {
+		return
+	}
+
+	language := a.GetTranslationLanguageForUser(c, userID)
+	if language == "" {
+		return
+	}
+
+	pending := make(map[string]*model.Post, len(posts))
+	for _, post := range posts {
+		if post == nil || post.DeleteAt != 0 || post.IsSystemMessage() || post.Message == "" || post.UserId == userID {
+			continue
+		}
+		pending[post.Id] = post
+	}
+
+	attach := func(translations map[string]string) {
+		for postID, message := range translations {
+			post, ok := pending[postID]
+			if !ok || message == "" {
+				continue
+			}
+
+			if post.Metadata == nil {
+				post.Metadata = &model.PostMetadata{}
+			}
+			post.Metadata.Translation = &model.PostTranslation{
+				Language: language,
+				Message:  message,
+			}
+			delete(pending, postID)
+		}
+	}
+
+	if len(pending) > 0 {
+		a.ch.RunMultiHook(func(hooks plugin.Hooks) bool {
+			pluginPosts := make([]*model.Post, 0, len(pending))
+			for _, post := range pending {
+				pluginPosts = append(pluginPosts, post.ForPlugin())
+			}
+
+			attach(hooks.MessagesWillBeTranslated(pluginPosts, language))
+
+			return len(pending) > 0
+		}, plugin.MessagesWillBeTranslatedID)
+	}
+
+	if len(pending) > 0 && a.Translation() != nil {
+		servicePosts := make([]*model.Post, 0, len(pending))
+		for _, post := range pending {
+			servicePosts = append(servicePosts, post)
+		}
+
+		translations, appErr := a.Translation().Translate(c, servicePosts, language)
+		if appErr != nil {
+			c.Logger().Warn("Failed to translate posts", mlog.String("language", language), mlog.Err(appErr))
+			return
+		}
+		attach(translations)
+	}
+}
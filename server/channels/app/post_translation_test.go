@@ -0,0 +1,103 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
+	"github.com/mattermost/mattermost/server/v8/platform/services/translation"
+)
+
+func TestTranslatePostsForUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.ch.Translation = translation.NewDictionaryProvider(map[string]map[string]string{
+		"fr": {"hello": "bonjour", "world": "monde"},
+	})
+
+	setLanguage := func(t *testing.T, language string) {
+		t.Helper()
+		err := th.App.UpdatePreferences(th.Context, th.BasicUser.Id, model.Preferences{{
+			UserId:   th.BasicUser.Id,
+			Category: model.PreferenceCategoryDisplaySettings,
+			Name:     model.PreferenceNameTranslationLanguage,
+			Value:    language,
+		}})
+		require.Nil(t, err)
+	}
+
+	newPosts := func() []*model.Post {
+		return []*model.Post{
+			{Id: model.NewId(), UserId: th.BasicUser2.Id, Message: "Hello world"},
+			{Id: model.NewId(), UserId: th.BasicUser2.Id, Message: "nothing known"},
+			{Id: model.NewId(), UserId: th.BasicUser.Id, Message: "hello from me"},
+			{Id: model.NewId(), UserId: th.BasicUser2.Id, Message: "hello", Type: model.PostTypeJoinChannel},
+			{Id: model.NewId(), UserId: th.BasicUser2.Id, Message: "hello", DeleteAt: model.GetMillis()},
+		}
+	}
+
+	t.Run("no preferred language", func(t *testing.T) {
+		posts := newPosts()
+		th.App.TranslatePostsForUser(th.Context, posts, th.BasicUser.Id)
+
+		for _, post := range posts {
+			assert.Nil(t, post.Metadata)
+		}
+	})
+
+	t.Run("translates posts of other users", func(t *testing.T) {
+		setLanguage(t, "fr")
+
+		posts := newPosts()
+		th.App.TranslatePostsForUser(th.Context, posts, th.BasicUser.Id)
+
+		require.NotNil(t, posts[0].Metadata)
+		assert.Equal(t, &model.PostTranslation{Language: "fr", Message: "Bonjour monde"}, posts[0].Metadata.Translation)
+		for _, post := range posts[1:] {
+			assert.Nil(t, post.Metadata)
+		}
+	})
+
+	t.Run("attached to post lists prepared for the client", func(t *testing.T) {
+		setLanguage(t, "fr")
+
+		post := th.CreateMessagePost(th.BasicChannel, "hello")
+		post.UserId = th.BasicUser2.Id
+		list := model.NewPostList()
+		list.AddPost(post)
+		list.AddOrder(post.Id)
+
+		th.Context.Session().UserId = th.BasicUser.Id
+		defer func() { th.Context.Session().UserId = "" }()
+
+		clientList := th.App.PreparePostListForClient(th.Context, list)
+		require.NotNil(t, clientList.Posts[post.Id].Metadata.Translation)
+		assert.Equal(t, "bonjour", clientList.Posts[post.Id].Metadata.Translation.Message)
+	})
+
+	t.Run("translation service error", func(t *testing.T) {
+		setLanguage(t, "de")
+
+		translationMock := &mocks.TranslationInterface{}
+		translationMock.On("Translate", mock.Anything, mock.Anything, "de").Return(nil, model.NewAppError("Translate", "app.translation.error", nil, "", http.StatusInternalServerError))
+		th.App.ch.Translation = translationMock
+		defer func() { th.App.ch.Translation = nil }()
+
+		posts := newPosts()
+		th.App.TranslatePostsForUser(th.Context, posts, th.BasicUser.Id)
+
+		for _, post := range posts {
+			assert.Nil(t, post.Metadata)
+		}
+		translationMock.AssertExpectations(t)
+	})
+}
@@ -4,12 +4,16 @@
 package app
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"math"
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/redis/rueidis"
 	"github.com/throttled/throttled"
 	"github.com/throttled/throttled/store/memstore"
 
@@ -17,34 +21,98 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/platform/services/ratelimit"
 )
 
+const (
+	RateLimitProfileDefault     = "default"
+	RateLimitProfilePosts       = "posts"
+	RateLimitProfileSearch      = "search"
+	RateLimitProfileFileUpload  = "file_upload"
+	RateLimitProfileAccessToken = "access_token"
+
+	rateLimitRedisKeyPrefix = "ratelimit:"
+)
+
+var searchRoutePattern = regexp.MustCompile(`/search(_[a-z]+)?/?$`)
+
 type RateLimiter struct {
-	throttledRateLimiter *throttled.GCRARateLimiter
+	limiters             map[string]*throttled.GCRARateLimiter
 	useAuth              bool
 	useIP                bool
 	header               string
 	trustedProxyIPHeader []string
+	metrics              einterfaces.MetricsInterface
+	redisClient          rueidis.Client
 }
 
+// NewRateLimiter creates a rate limiter keeping its counters in memory.
 func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []string) (*RateLimiter, error) {
 	store, err := memstore.New(*settings.MemoryStoreSize)
 	if err != nil {
 		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_memory_store"))
 	}
 
-	quota := throttled.RateQuota{
-		MaxRate:  throttled.PerSec(*settings.PerSec),
-		MaxBurst: *settings.MaxBurst,
+	return NewRateLimiterWithStore(settings, trustedProxyIPHeader, store)
+}
+
+// NewRedisRateLimiter creates a rate limiter keeping its counters in Redis,
+// so that the limits hold across all the nodes of a cluster.
+func NewRedisRateLimiter(settings *model.RateLimitSettings, cacheSettings *model.CacheSettings, trustedProxyIPHeader []string) (*RateLimiter, error) {
+	client, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:       []string{*cacheSettings.RedisAddress},
+		Password:          *cacheSettings.RedisPassword,
+		SelectDB:          *cacheSettings.RedisDB,
+		ForceSingleClient: true,
+		DisableCache:      true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_redis_store"))
 	}
 
-	throttledRateLimiter, err := throttled.NewGCRARateLimiter(store, quota)
+	rateLimiter, err := NewRateLimiterWithStore(settings, trustedProxyIPHeader, ratelimit.NewRedisStore(client, rateLimitRedisKeyPrefix))
 	if err != nil {
-		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_rate_limiter"))
+		client.Close()
+		return nil, err
+	}
+	rateLimiter.redisClient = client
+
+	return rateLimiter, nil
+}
+
+// NewRateLimiterWithStore creates a rate limiter keeping its counters in the given store.
+func NewRateLimiterWithStore(settings *model.RateLimitSettings, trustedProxyIPHeader []string, store throttled.GCRAStore) (*RateLimiter, error) {
+	quotas := map[string]throttled.RateQuota{
+		RateLimitProfileDefault: {
+			MaxRate:  throttled.PerSec(*settings.PerSec),
+			MaxBurst: *settings.MaxBurst,
+		},
+	}
+	addProfile := func(profile string, perSec, maxBurst *int) {
+		if perSec != nil && *perSec > 0 {
+			quotas[profile] = throttled.RateQuota{
+				MaxRate:  throttled.PerSec(*perSec),
+				MaxBurst: model.SafeDereference(maxBurst),
+			}
+		}
+	}
+	addProfile(RateLimitProfilePosts, settings.PostsPerSec, settings.PostsMaxBurst)
+	addProfile(RateLimitProfileSearch, settings.SearchPerSec, settings.SearchMaxBurst)
+	addProfile(RateLimitProfileFileUpload, settings.FileUploadPerSec, settings.FileUploadMaxBurst)
+	addProfile(RateLimitProfileAccessToken, settings.AccessTokenPerSec, settings.AccessTokenMaxBurst)
+
+	limiters := make(map[string]*throttled.GCRARateLimiter, len(quotas))
+	for profile, quota := range quotas {
+		throttledRateLimiter, err := throttled.NewGCRARateLimiter(store, quota)
+		if err != nil {
+			return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_rate_limiter"))
+		}
+		limiters[profile] = throttledRateLimiter
 	}
 
 	return &RateLimiter{
-		throttledRateLimiter: throttledRateLimiter,
+		limiters:             limiters,
 		useAuth:              *settings.VaryByUser,
 		useIP:                *settings.VaryByRemoteAddr,
 		header:               settings.VaryByHeader,
@@ -52,13 +120,25 @@ func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []st
 	}, nil
 }
 
+// SetMetrics sets the metrics used to report the rejected requests.
+func (rl *RateLimiter) SetMetrics(metrics einterfaces.MetricsInterface) {
+	rl.metrics = metrics
+}
+
+// Close releases the connection to the Redis server, if any.
+func (rl *RateLimiter) Close() {
+	if rl.redisClient != nil {
+		rl.redisClient.Close()
+	}
+}
+
 func (rl *RateLimiter) GenerateKey(r *http.Request) string {
 	key := ""
 
 	if rl.useAuth {
 		token, tokenLocation := ParseAuthTokenFromRequest(r)
 		if tokenLocation != TokenLocationNotFound {
-			key += token
+			key += tokenRateLimitKey(token)
 		} else if rl.useIP { // If we don't find an authentication token and IP based is enabled, fall back to IP
 			key += utils.GetIPAddress(r, rl.trustedProxyIPHeader)
 		}
@@ -75,8 +155,57 @@ func (rl *RateLimiter) GenerateKey(r *http.Request) string {
 	return key
 }
 
+// tokenRateLimitKey returns the key of the requests made with the given
+// token. The key is logged and stored, so it's a hash of the token rather
+// than the token itself.
+func tokenRateLimitKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ProfileForRequest returns the limit profile of the route group the
+// request belongs to, or the default profile if that group has no limits
+// of its own.
+func (rl *RateLimiter) ProfileForRequest(r *http.Request) string {
+	profile := routeGroupProfile(r)
+	if _, ok := rl.limiters[profile]; ok {
+		return profile
+	}
+	return RateLimitProfileDefault
+}
+
+func routeGroupProfile(r *http.Request) string {
+	path := r.URL.Path
+	if !strings.HasPrefix(path, model.APIURLSuffix+"/") {
+		return RateLimitProfileDefault
+	}
+
+	switch {
+	case searchRoutePattern.MatchString(path):
+		return RateLimitProfileSearch
+	case r.Method == http.MethodPost && (path == model.APIURLSuffix+"/files" || strings.HasPrefix(path, model.APIURLSuffix+"/uploads/")):
+		return RateLimitProfileFileUpload
+	case r.Method != http.MethodGet && (path == model.APIURLSuffix+"/posts" || strings.HasPrefix(path, model.APIURLSuffix+"/posts/")):
+		return RateLimitProfilePosts
+	}
+
+	return RateLimitProfileDefault
+}
+
+// RateLimitWriter rate limits the key against the default profile.
 func (rl *RateLimiter) RateLimitWriter(key string, w http.ResponseWriter) bool {
-	limited, context, err := rl.throttledRateLimiter.RateLimit(key, 1)
+	return rl.rateLimitProfile(RateLimitProfileDefault, key, w)
+}
+
+func (rl *RateLimiter) rateLimitProfile(profile, key string, w http.ResponseWriter) bool {
+	limiter, ok := rl.limiters[profile]
+	if !ok {
+		profile = RateLimitProfileDefault
+		limiter = rl.limiters[profile]
+	}
+
+	// Profiles share the store, so their keys need to be kept apart.
+	limited, context, err := limiter.RateLimit(profile+":"+key, 1)
 	if err != nil {
 		mlog.Error("Internal server error when rate limiting. Rate Limiting broken.", mlog.Err(err))
 		return false
@@ -85,7 +214,10 @@ func (rl *RateLimiter) RateLimitWriter(key string, w http.ResponseWriter) bool {
 	setRateLimitHeaders(w, context)
 
 	if limited {
-		mlog.Debug("Denied due to throttling settings code=429", mlog.String("key", key))
+		mlog.Debug("Denied due to throttling settings code=429", mlog.String("key", key), mlog.String("profile", profile))
+		if rl.metrics != nil {
+			rl.metrics.IncrementRateLimitRejection(profile)
+		}
 		http.Error(w, "limit exceeded", http.StatusTooManyRequests)
 	}
 
@@ -99,33 +231,55 @@ func (rl *RateLimiter) UserIdRateLimit(userID string, w http.ResponseWriter) boo
 	return false
 }
 
+// SessionRateLimit rate limits an authenticated request. Requests made with a
+// personal access token are limited per token when the access token profile
+// is configured, other requests are limited per user if enabled.
+func (rl *RateLimiter) SessionRateLimit(session *model.Session, r *http.Request, w http.ResponseWriter) bool {
+	if session.IsUserAccessToken() {
+		// The key is logged and stored, so it must be the id of the token rather than the token itself.
+		tokenID := session.Props[model.SessionPropUserAccessTokenId]
+		if _, ok := rl.limiters[RateLimitProfileAccessToken]; ok && tokenID != "" {
+			return rl.rateLimitProfile(RateLimitProfileAccessToken, tokenID, w)
+		}
+	}
+
+	if rl.useAuth {
+		return rl.rateLimitProfile(rl.ProfileForRequest(r), session.UserId, w)
+	}
+	return false
+}
+
 func (rl *RateLimiter) RateLimitHandler(wrappedHandler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		key := rl.GenerateKey(r)
 
-		if !rl.RateLimitWriter(key, w) {
+		if !rl.rateLimitProfile(rl.ProfileForRequest(r), key, w) {
 			wrappedHandler.ServeHTTP(w, r)
 		}
 	})
 }
 
-// Copied from https://github.com/throttled/throttled http.go
+// Adapted from https://github.com/throttled/throttled http.go. Both the
+// legacy X-RateLimit-* headers and the standard RateLimit-* headers are set.
 func setRateLimitHeaders(w http.ResponseWriter, context throttled.RateLimitResult) {
 	if v := context.Limit; v >= 0 {
-		w.Header().Add("X-RateLimit-Limit", strconv.Itoa(v))
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(v))
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(v))
 	}
 
 	if v := context.Remaining; v >= 0 {
-		w.Header().Add("X-RateLimit-Remaining", strconv.Itoa(v))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(v))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(v))
 	}
 
 	if v := context.ResetAfter; v >= 0 {
 		vi := int(math.Ceil(v.Seconds()))
-		w.Header().Add("X-RateLimit-Reset", strconv.Itoa(vi))
+		w.Header().Set("X-RateLimit-Reset", strconv.Itoa(vi))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(vi))
 	}
 
 	if v := context.RetryAfter; v >= 0 {
 		vi := int(math.Ceil(v.Seconds()))
-		w.Header().Add("Retry-After", strconv.Itoa(vi))
+		w.Header().Set("Retry-After", strconv.Itoa(vi))
 	}
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
 )
 
 func genRateLimitSettings(useAuth, useIP bool, header string) *model.RateLimitSettings {
@@ -60,12 +61,12 @@ func TestGenerateKey(t *testing.T) {
 		expectedKey     string
 	}{
 		{false, false, "", "", "", "", ""},
-		{true, false, "", "resultkey", "notme", "notme", "resultkey"},
+		{true, false, "", "resultkey", "notme", "notme", tokenRateLimitKey("resultkey")},
 		{false, true, "", "notme", "resultkey", "notme", "resultkey"},
 		{false, false, "myheader", "notme", "notme", "resultkey", "resultkey"},
-		{true, true, "", "resultkey", "ipaddr", "notme", "resultkey"},
+		{true, true, "", "resultkey", "ipaddr", "notme", tokenRateLimitKey("resultkey")},
 		{true, true, "", "", "ipaddr", "notme", "ipaddr"},
-		{true, true, "myheader", "resultkey", "ipaddr", "hadd", "resultkeyhadd"},
+		{true, true, "myheader", "resultkey", "ipaddr", "hadd", tokenRateLimitKey("resultkey") + "hadd"},
 		{true, true, "myheader", "", "ipaddr", "hadd", "ipaddrhadd"},
 	}
 
@@ -87,6 +88,9 @@ func TestGenerateKey(t *testing.T) {
 		key := rateLimiter.GenerateKey(req)
 
 		require.Equal(t, tc.expectedKey, key, "Wrong key on test "+strconv.Itoa(testnum))
+		if tc.useAuth && tc.authTokenResult != "" {
+			require.NotContains(t, key, tc.authTokenResult, "The token must not be part of the key on test "+strconv.Itoa(testnum))
+		}
 	}
 }
 
@@ -103,3 +107,122 @@ func TestGenerateKey_TrustedHeader(t *testing.T) {
 	key = rateLimiter.GenerateKey(req)
 	require.Equal(t, "10.10.10.5", key, "Wrong key on test without allowed trusted proxy header")
 }
+
+func TestProfileForRequest(t *testing.T) {
+	settings := genRateLimitSettings(true, true, "")
+	settings.PostsPerSec = model.NewPointer(1)
+	settings.SearchPerSec = model.NewPointer(1)
+	settings.FileUploadPerSec = model.NewPointer(1)
+
+	rateLimiter, err := NewRateLimiter(settings, nil)
+	require.NoError(t, err)
+
+	cases := []struct {
+		method   string
+		path     string
+		expected string
+	}{
+		{http.MethodPost, "/api/v4/posts", RateLimitProfilePosts},
+		{http.MethodPut, "/api/v4/posts/postid/patch", RateLimitProfilePosts},
+		{http.MethodGet, "/api/v4/posts/postid", RateLimitProfileDefault},
+		{http.MethodPost, "/api/v4/teams/teamid/posts/search", RateLimitProfileSearch},
+		{http.MethodPost, "/api/v4/users/search", RateLimitProfileSearch},
+		{http.MethodPost, "/api/v4/teams/teamid/channels/search_archived", RateLimitProfileSearch},
+		{http.MethodPost, "/api/v4/files", RateLimitProfileFileUpload},
+		{http.MethodPost, "/api/v4/uploads/uploadid", RateLimitProfileFileUpload},
+		{http.MethodGet, "/api/v4/files/fileid", RateLimitProfileDefault},
+		{http.MethodPost, "/login/search", RateLimitProfileDefault},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		require.Equal(t, tc.expected, rateLimiter.ProfileForRequest(req), "%s %s", tc.method, tc.path)
+	}
+
+	rateLimiter, err = NewRateLimiter(genRateLimitSettings(true, true, ""), nil)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v4/posts", nil)
+	require.Equal(t, RateLimitProfileDefault, rateLimiter.ProfileForRequest(req), "unconfigured profiles fall back to the default one")
+}
+
+func TestRateLimitHandlerProfiles(t *testing.T) {
+	settings := genRateLimitSettings(false, true, "")
+	settings.PerSec = model.NewPointer(1)
+	settings.MaxBurst = model.NewPointer(5)
+	settings.PostsPerSec = model.NewPointer(1)
+	settings.PostsMaxBurst = model.NewPointer(0)
+
+	rateLimiter, err := NewRateLimiter(settings, nil)
+	require.NoError(t, err)
+
+	metrics := &mocks.MetricsInterface{}
+	metrics.On("IncrementRateLimitRejection", RateLimitProfilePosts).Once()
+	rateLimiter.SetMetrics(metrics)
+
+	handler := rateLimiter.RateLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = "10.0.0.1:80"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(http.MethodPost, "/api/v4/posts")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, "1", rec.Header().Get("X-RateLimit-Limit"))
+
+	rec = serve(http.MethodPost, "/api/v4/posts")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	// Other routes are counted against the default profile.
+	rec = serve(http.MethodGet, "/api/v4/users/me")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "6", rec.Header().Get("RateLimit-Limit"))
+
+	metrics.AssertExpectations(t)
+}
+
+func TestSessionRateLimit(t *testing.T) {
+	settings := genRateLimitSettings(false, true, "")
+	settings.AccessTokenPerSec = model.NewPointer(1)
+	settings.AccessTokenMaxBurst = model.NewPointer(0)
+
+	rateLimiter, err := NewRateLimiter(settings, nil)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v4/users/me", nil)
+	newTokenSession := func(userID string) *model.Session {
+		return &model.Session{UserId: userID, Token: model.NewId(), Props: model.StringMap{
+			model.SessionPropType:              model.SessionTypeUserAccessToken,
+			model.SessionPropUserAccessTokenId: model.NewId(),
+		}}
+	}
+	tokenSession := newTokenSession(model.NewId())
+
+	require.False(t, rateLimiter.SessionRateLimit(tokenSession, req, httptest.NewRecorder()))
+	require.True(t, rateLimiter.SessionRateLimit(tokenSession, req, httptest.NewRecorder()))
+
+	otherToken := newTokenSession(tokenSession.UserId)
+	require.False(t, rateLimiter.SessionRateLimit(otherToken, req, httptest.NewRecorder()), "limits are kept per token")
+
+	// The token itself is never used as a key, as keys are logged and stored.
+	_, result, err := rateLimiter.limiters[RateLimitProfileAccessToken].RateLimit(RateLimitProfileAccessToken+":"+tokenSession.Props[model.SessionPropUserAccessTokenId], 0)
+	require.NoError(t, err)
+	require.Zero(t, result.Remaining)
+	_, result, err = rateLimiter.limiters[RateLimitProfileAccessToken].RateLimit(RateLimitProfileAccessToken+":"+tokenSession.Token, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Remaining)
+
+	// Regular sessions are not limited unless limiting by user is enabled.
+	session := &model.Session{UserId: model.NewId(), Token: model.NewId()}
+	for range 3 {
+		require.False(t, rateLimiter.SessionRateLimit(session, req, httptest.NewRecorder()))
+	}
+}
@@ -197,6 +197,28 @@ func (a *App) GetRemoteClusterService() (remotecluster.RemoteClusterServiceIFace
 	return service, nil
 }
 
+// PingRemoteCluster pings a remote cluster immediately, without waiting for the next ping loop, and
+// returns it with its updated status.
+func (a *App) PingRemoteCluster(remoteClusterId string) (*model.RemoteCluster, *model.AppError) {
+	rcService, appErr := a.GetRemoteClusterService()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	rc, appErr := a.GetRemoteCluster(remoteClusterId, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if !rc.IsConfirmed() {
+		return nil, model.NewAppError("PingRemoteCluster", "api.remote_cluster.ping.not_confirmed.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	rcService.PingNow(rc)
+
+	return a.GetRemoteCluster(remoteClusterId, false)
+}
+
 func (a *App) CreateRemoteClusterInvite(remoteId, siteURL, token, password string) (string, *model.AppError) {
 	invite := &model.RemoteClusterInvite{
 		RemoteId: remoteId,
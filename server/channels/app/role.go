@@ -154,19 +154,44 @@ func (a *App) CreateRole(role *model.Role) (*model.Role, *model.AppError) {
 	role.BuiltIn = false
 	role.SchemeManaged = false
 
-	var err error
-	role, err = a.Srv().Store().Role().Save(role)
+	savedRole, err := a.Srv().Store().Role().Save(role)
 	if err != nil {
 		var invErr *store.ErrInvalidInput
+		var cErr *store.ErrConflict
 		switch {
 		case errors.As(err, &invErr):
 			return nil, model.NewAppError("CreateRole", "app.role.save.invalid_role.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		case errors.As(err, &cErr):
+			return nil, model.NewAppError("CreateRole", "api.roles.create_role.name_taken.app_error", map[string]any{"Name": role.Name}, "", http.StatusBadRequest).Wrap(err)
 		default:
 			return nil, model.NewAppError("CreateRole", "app.role.save.insert.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		}
 	}
 
-	return role, nil
+	return savedRole, nil
+}
+
+// DeleteRole deletes a custom role. Built-in roles and the roles managed by schemes can't be deleted.
+func (a *App) DeleteRole(id string) (*model.Role, *model.AppError) {
+	role, appErr := a.GetRole(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if role.BuiltIn || role.SchemeManaged {
+		return nil, model.NewAppError("DeleteRole", "app.role.delete.not_custom_role.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	deleted, err := a.Srv().Store().Role().Delete(id)
+	if err != nil {
+		return nil, model.NewAppError("DeleteRole", "app.role.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if appErr := a.sendUpdatedRoleEvent(deleted); appErr != nil {
+		return nil, appErr
+	}
+
+	return deleted, nil
 }
 
 func (a *App) UpdateRole(role *model.Role) (*model.Role, *model.AppError) {
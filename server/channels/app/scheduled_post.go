@@ -4,12 +4,15 @@
 package app
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
 )
 
 func (a *App) SaveScheduledPost(rctx request.CTX, scheduledPost *model.ScheduledPost, connectionId string) (*model.ScheduledPost, *model.AppError) {
@@ -113,6 +116,96 @@ func (a *App) DeleteScheduledPost(rctx request.CTX, userId, scheduledPostId, con
 	return scheduledPost, nil
 }
 
+func (a *App) GetUserScheduledPostSeries(rctx request.CTX, userId string) ([]*model.ScheduledPost, *model.AppError) {
+	scheduledPosts, err := a.Srv().Store().ScheduledPost().GetScheduledPostSeriesForUser(userId)
+	if err != nil {
+		return nil, model.NewAppError("App.GetUserScheduledPostSeries", "app.get_user_scheduled_post_series.error", map[string]any{"user_id": userId}, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if scheduledPosts == nil {
+		scheduledPosts = []*model.ScheduledPost{}
+	}
+
+	for _, scheduledPost := range scheduledPosts {
+		a.prepareDraftWithFileInfos(rctx, userId, &scheduledPost.Draft)
+	}
+
+	return scheduledPosts, nil
+}
+
+// PauseScheduledPost stops a recurring scheduled post from being posted until it is resumed.
+func (a *App) PauseScheduledPost(rctx request.CTX, userId, scheduledPostId, connectionId string) (*model.ScheduledPost, *model.AppError) {
+	scheduledPost, appErr := a.getScheduledPostSeries(userId, scheduledPostId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if scheduledPost.IsPaused() {
+		return scheduledPost, nil
+	}
+
+	scheduledPost.PausedAt = model.GetMillis()
+	if err := a.Srv().Store().ScheduledPost().UpdatedScheduledPost(scheduledPost); err != nil {
+		return nil, model.NewAppError("App.PauseScheduledPost", "app.update_scheduled_post.update.error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostUpdated, scheduledPost, connectionId)
+
+	return scheduledPost, nil
+}
+
+// ResumeScheduledPost resumes a paused recurring scheduled post. The occurrences
+// missed while the series was paused are skipped.
+func (a *App) ResumeScheduledPost(rctx request.CTX, userId, scheduledPostId, connectionId string) (*model.ScheduledPost, *model.AppError) {
+	scheduledPost, appErr := a.getScheduledPostSeries(userId, scheduledPostId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if !scheduledPost.IsPaused() {
+		return scheduledPost, nil
+	}
+
+	if now := model.GetMillis(); scheduledPost.ScheduledAt < now {
+		nextOccurrence := scheduledPost.Recurrence.NextOccurrence(scheduledPost.ScheduledAt, now)
+		if nextOccurrence == 0 {
+			return nil, model.NewAppError("App.ResumeScheduledPost", "app.resume_scheduled_post.series_ended.app_error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusBadRequest)
+		}
+		scheduledPost.ScheduledAt = nextOccurrence
+	}
+
+	scheduledPost.PausedAt = 0
+	if err := a.Srv().Store().ScheduledPost().UpdatedScheduledPost(scheduledPost); err != nil {
+		return nil, model.NewAppError("App.ResumeScheduledPost", "app.update_scheduled_post.update.error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostUpdated, scheduledPost, connectionId)
+
+	return scheduledPost, nil
+}
+
+// getScheduledPostSeries returns the recurring scheduled post, making sure it belongs to the user.
+func (a *App) getScheduledPostSeries(userId, scheduledPostId string) (*model.ScheduledPost, *model.AppError) {
+	scheduledPost, err := a.Srv().Store().ScheduledPost().Get(scheduledPostId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) || errors.Is(err, sql.ErrNoRows) {
+			return nil, model.NewAppError("App.getScheduledPostSeries", "app.update_scheduled_post.existing_scheduled_post.not_exist", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusNotFound).Wrap(err)
+		}
+		return nil, model.NewAppError("App.getScheduledPostSeries", "app.update_scheduled_post.get_scheduled_post.error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if scheduledPost.UserId != userId {
+		return nil, model.NewAppError("App.getScheduledPostSeries", "app.update_scheduled_post.update_permission.error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusForbidden)
+	}
+
+	if !scheduledPost.IsRecurring() {
+		return nil, model.NewAppError("App.getScheduledPostSeries", "app.scheduled_post_series.not_recurring.app_error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusBadRequest)
+	}
+
+	return scheduledPost, nil
+}
+
 func (a *App) PublishScheduledPostEvent(rctx request.CTX, eventType model.WebsocketEventType, scheduledPost *model.ScheduledPost, connectionId string) {
 	if scheduledPost == nil {
 		rctx.Logger().Warn("publishScheduledPostEvent called with nil scheduledPost")
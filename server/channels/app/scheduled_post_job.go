@@ -72,7 +72,7 @@ func (a *App) ProcessScheduledPosts(rctx request.CTX) {
 		lastScheduledPostId = scheduledPostsBatch[len(scheduledPostsBatch)-1].Id
 		beforeTime = scheduledPostsBatch[len(scheduledPostsBatch)-1].ScheduledAt
 
-		if err := a.processScheduledPostBatch(rctx, scheduledPostsBatch); err != nil {
+		if err := a.processScheduledPostBatch(rctx, scheduledPostsBatch, afterTime); err != nil {
 			rctx.Logger().Error(
 				"App.ProcessScheduledPosts: failed to process scheduled posts batch",
 				mlog.Int("before_time", beforeTime),
@@ -108,13 +108,21 @@ func (a *App) ProcessScheduledPosts(rctx request.CTX) {
 	}
 }
 
-// processScheduledPostBatch processes one batch
-func (a *App) processScheduledPostBatch(rctx request.CTX, scheduledPosts []*model.ScheduledPost) error {
+// processScheduledPostBatch processes one batch. Recurring scheduled posts older than
+// afterTime are not posted but moved to their next occurrence.
+func (a *App) processScheduledPostBatch(rctx request.CTX, scheduledPosts []*model.ScheduledPost, afterTime int64) error {
 	rctx.Logger().Debug("processScheduledPostBatch called...")
 	var failedScheduledPosts []*model.ScheduledPost
 	var successfulScheduledPostIDs []string
+	var recurringScheduledPosts []*model.ScheduledPost
 
 	for i := range scheduledPosts {
+		if scheduledPosts[i].IsRecurring() && scheduledPosts[i].ScheduledAt < afterTime {
+			rctx.Logger().Debug("processScheduledPostBatch skipping missed occurrence of recurring scheduled post", mlog.String("scheduled_post_id", scheduledPosts[i].Id))
+			recurringScheduledPosts = append(recurringScheduledPosts, scheduledPosts[i])
+			continue
+		}
+
 		rctx.Logger().Trace("processScheduledPostBatch processing scheduled post", mlog.String("scheduled_post_id", scheduledPosts[i].Id))
 		scheduledPost, err := a.postScheduledPost(rctx, scheduledPosts[i])
 		if err != nil {
@@ -123,12 +131,27 @@ func (a *App) processScheduledPostBatch(rctx request.CTX, scheduledPosts []*mode
 			continue
 		}
 
+		if scheduledPost.IsRecurring() && scheduledPost.ErrorCode != "" {
+			// Unlike one-off scheduled posts, a recurring series would keep failing, so it's
+			// stopped with its error code for the user to see instead of being rescheduled.
+			rctx.Logger().Debug("processScheduledPostBatch recurring scheduled post can't be posted", mlog.String("scheduled_post_id", scheduledPosts[i].Id), mlog.String("error_code", scheduledPost.ErrorCode))
+			failedScheduledPosts = append(failedScheduledPosts, scheduledPost)
+			continue
+		}
+
 		rctx.Logger().Trace("processScheduledPostBatch scheduled post processing successful", mlog.String("scheduled_post_id", scheduledPosts[i].Id))
+		if scheduledPost.IsRecurring() {
+			recurringScheduledPosts = append(recurringScheduledPosts, scheduledPost)
+			continue
+		}
 		successfulScheduledPostIDs = append(successfulScheduledPostIDs, scheduledPost.Id)
 	}
 
+	rctx.Logger().Trace("processScheduledPostBatch scheduling next occurrences of recurring scheduled posts...", mlog.Int("count", len(recurringScheduledPosts)))
+	endedSeriesIDs := a.scheduleNextOccurrences(rctx, recurringScheduledPosts)
+
 	rctx.Logger().Trace("processScheduledPostBatch handling successful scheduled posts...", mlog.Int("count", len(successfulScheduledPostIDs)))
-	if err := a.handleSuccessfulScheduledPosts(rctx, successfulScheduledPostIDs); err != nil {
+	if err := a.handleSuccessfulScheduledPosts(rctx, append(successfulScheduledPostIDs, endedSeriesIDs...)); err != nil {
 		return errors.Wrap(err, "App.processScheduledPostBatch: failed to handle successfully posted scheduled posts")
 	}
 
@@ -227,8 +250,11 @@ func (a *App) postScheduledPost(rctx request.CTX, scheduledPost *model.Scheduled
 		return scheduledPost, appErr
 	}
 
-	// send the WS event to delete the just posted scheduledPost from list
-	a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostDeleted, scheduledPost, "")
+	// send the WS event to delete the just posted scheduledPost from list.
+	// Recurring scheduled posts stay in the list until their series ends.
+	if !scheduledPost.IsRecurring() {
+		a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostDeleted, scheduledPost, "")
+	}
 
 	return scheduledPost, nil
 }
@@ -353,6 +379,40 @@ func (a *App) handleSuccessfulScheduledPosts(rctx request.CTX, successfulSchedul
 	return nil
 }
 
+// scheduleNextOccurrences moves recurring scheduled posts to their next occurrence.
+// Series that failed to post must be handled as failed scheduled posts instead.
+// It returns the IDs of the scheduled posts whose series has ended, which can be deleted.
+func (a *App) scheduleNextOccurrences(rctx request.CTX, recurringScheduledPosts []*model.ScheduledPost) []string {
+	var endedSeriesIDs []string
+	now := model.GetMillis()
+
+	for _, scheduledPost := range recurringScheduledPosts {
+		nextOccurrence := scheduledPost.Recurrence.NextOccurrence(scheduledPost.ScheduledAt, now)
+		if nextOccurrence == 0 {
+			rctx.Logger().Debug("scheduleNextOccurrences recurring scheduled post series has ended", mlog.String("scheduled_post_id", scheduledPost.Id))
+			endedSeriesIDs = append(endedSeriesIDs, scheduledPost.Id)
+			a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostDeleted, scheduledPost, "")
+			continue
+		}
+
+		scheduledPost.ScheduledAt = nextOccurrence
+		if err := a.Srv().Store().ScheduledPost().UpdatedScheduledPost(scheduledPost); err != nil {
+			// we intentionally don't stop on error as its possible to continue updating other scheduled posts
+			rctx.Logger().Error(
+				"App.scheduleNextOccurrences: failed to schedule next occurrence of recurring scheduled post",
+				mlog.String("scheduled_post_id", scheduledPost.Id),
+				mlog.Int("next_occurrence", nextOccurrence),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostUpdated, scheduledPost, "")
+	}
+
+	return endedSeriesIDs
+}
+
 func (a *App) handleFailedScheduledPosts(rctx request.CTX, failedScheduledPosts []*model.ScheduledPost) {
 	for _, failedScheduledPost := range failedScheduledPosts {
 		err := a.Srv().Store().ScheduledPost().UpdatedScheduledPost(failedScheduledPost)
@@ -52,6 +52,92 @@ func TestProcessScheduledPosts(t *testing.T) {
 		assert.Len(t, scheduledPosts, 0)
 	})
 
+	t.Run("moves recurring scheduled posts to their next occurrence", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		th.App.Srv().SetLicense(getLicWithSkuShortName(model.LicenseShortSkuProfessional))
+
+		scheduledAt := model.GetMillis() + 1000
+		recurringScheduledPost := &model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   "this is a recurring scheduled post",
+			},
+			ScheduledAt: scheduledAt,
+			Recurrence:  &model.ScheduledPostRecurrence{Frequency: model.ScheduledPostRecurrenceDaily},
+		}
+		_, err := th.Server.Store().ScheduledPost().CreateScheduledPost(recurringScheduledPost)
+		assert.NoError(t, err)
+
+		// a series whose occurrences were missed for more than a day
+		missedScheduledPost := &model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   "this is a missed recurring scheduled post",
+			},
+			ScheduledAt: model.GetMillis() - 3*24*60*60*1000,
+			Recurrence:  &model.ScheduledPostRecurrence{Frequency: model.ScheduledPostRecurrenceDaily},
+		}
+		_, err = th.Server.Store().ScheduledPost().CreateScheduledPost(missedScheduledPost)
+		assert.NoError(t, err)
+
+		time.Sleep(1 * time.Second)
+
+		th.App.ProcessScheduledPosts(th.Context)
+
+		scheduledPosts, err := th.App.Srv().Store().ScheduledPost().GetScheduledPostsForUser(th.BasicUser.Id, th.BasicChannel.TeamId)
+		assert.NoError(t, err)
+		assert.Len(t, scheduledPosts, 2)
+		for _, scheduledPost := range scheduledPosts {
+			assert.Empty(t, scheduledPost.ErrorCode)
+			assert.Greater(t, scheduledPost.ScheduledAt, model.GetMillis())
+		}
+
+		posts, appErr := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: th.BasicChannel.Id, PerPage: 10})
+		assert.Nil(t, appErr)
+		var messages []string
+		for _, post := range posts.Posts {
+			messages = append(messages, post.Message)
+		}
+		assert.Contains(t, messages, "this is a recurring scheduled post")
+		assert.NotContains(t, messages, "this is a missed recurring scheduled post")
+	})
+
+	t.Run("stops recurring scheduled posts whose channel is gone", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		defer th.TearDown()
+
+		th.App.Srv().SetLicense(getLicWithSkuShortName(model.LicenseShortSkuProfessional))
+
+		scheduledAt := model.GetMillis() + 1000
+		recurringScheduledPost := &model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: model.NewId(),
+				Message:   "this is a recurring scheduled post",
+			},
+			ScheduledAt: scheduledAt,
+			Recurrence:  &model.ScheduledPostRecurrence{Frequency: model.ScheduledPostRecurrenceDaily},
+		}
+		createdScheduledPost, err := th.Server.Store().ScheduledPost().CreateScheduledPost(recurringScheduledPost)
+		assert.NoError(t, err)
+
+		time.Sleep(1 * time.Second)
+
+		th.App.ProcessScheduledPosts(th.Context)
+
+		scheduledPost, err := th.App.Srv().Store().ScheduledPost().Get(createdScheduledPost.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, model.ScheduledPostErrorCodeChannelNotFound, scheduledPost.ErrorCode)
+		assert.Equal(t, scheduledAt, scheduledPost.ScheduledAt)
+	})
+
 	t.Run("sets error code for archived channel", func(t *testing.T) {
 		th := Setup(t).InitBasic()
 		defer th.TearDown()
@@ -666,3 +666,69 @@ func TestDeleteScheduledPost(t *testing.T) {
 		require.Nil(t, deletedScheduledPost)
 	})
 }
+
+func TestPauseAndResumeScheduledPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	createScheduledPost := func(t *testing.T, recurrence *model.ScheduledPostRecurrence) *model.ScheduledPost {
+		scheduledPost, appErr := th.App.SaveScheduledPost(th.Context, &model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   "this is a recurring scheduled post",
+			},
+			ScheduledAt: model.GetMillis() + 100000, // 100 seconds in the future
+			Recurrence:  recurrence,
+		}, "")
+		require.Nil(t, appErr)
+
+		t.Cleanup(func() {
+			_ = th.GetSqlStore().ScheduledPost().PermanentlyDeleteScheduledPosts([]string{scheduledPost.Id})
+		})
+
+		return scheduledPost
+	}
+
+	t.Run("should list, pause and resume a series", func(t *testing.T) {
+		scheduledPost := createScheduledPost(t, &model.ScheduledPostRecurrence{Frequency: model.ScheduledPostRecurrenceDaily})
+
+		series, appErr := th.App.GetUserScheduledPostSeries(th.Context, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		require.Len(t, series, 1)
+		require.Equal(t, scheduledPost.Id, series[0].Id)
+
+		paused, appErr := th.App.PauseScheduledPost(th.Context, th.BasicUser.Id, scheduledPost.Id, "")
+		require.Nil(t, appErr)
+		require.NotZero(t, paused.PausedAt)
+
+		// the pause survives edits of the series
+		paused.Message = "this is an updated recurring scheduled post"
+		paused.PausedAt = 0
+		updated, appErr := th.App.UpdateScheduledPost(th.Context, th.BasicUser.Id, paused, "")
+		require.Nil(t, appErr)
+		require.True(t, updated.IsPaused())
+
+		resumed, appErr := th.App.ResumeScheduledPost(th.Context, th.BasicUser.Id, scheduledPost.Id, "")
+		require.Nil(t, appErr)
+		require.Zero(t, resumed.PausedAt)
+		require.Equal(t, scheduledPost.ScheduledAt, resumed.ScheduledAt)
+	})
+
+	t.Run("should not pause a one-off scheduled post", func(t *testing.T) {
+		scheduledPost := createScheduledPost(t, nil)
+
+		_, appErr := th.App.PauseScheduledPost(th.Context, th.BasicUser.Id, scheduledPost.Id, "")
+		require.NotNil(t, appErr)
+		require.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("should not pause someone else's series", func(t *testing.T) {
+		scheduledPost := createScheduledPost(t, &model.ScheduledPostRecurrence{Frequency: model.ScheduledPostRecurrenceWeekly})
+
+		_, appErr := th.App.PauseScheduledPost(th.Context, th.BasicUser2.Id, scheduledPost.Id, "")
+		require.NotNil(t, appErr)
+		require.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+}
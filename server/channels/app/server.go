@@ -57,6 +57,8 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/migrations"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/mobile_session_metadata"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/notify_admin"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/out_of_office"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/outgoing_webhook_retry"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/plugins"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/post_persistent_notifications"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/product_notices"
@@ -72,6 +74,8 @@ import (
 	"github.com/mattermost/mattermost/server/v8/platform/services/remotecluster"
 	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine/bleveengine"
 	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine/bleveengine/indexer"
+	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine/postgresengine"
+	pgindexer "github.com/mattermost/mattermost/server/v8/platform/services/searchengine/postgresengine/indexer"
 	"github.com/mattermost/mattermost/server/v8/platform/services/sharedchannel"
 	"github.com/mattermost/mattermost/server/v8/platform/services/telemetry"
 	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
@@ -668,6 +672,11 @@ func (s *Server) StopHTTPServer() {
 		s.Server.Close()
 		s.Server = nil
 	}
+
+	if s.RateLimiter != nil {
+		s.RateLimiter.Close()
+		s.RateLimiter = nil
+	}
 }
 
 func (s *Server) Shutdown() {
@@ -878,7 +887,13 @@ func (s *Server) Start() error {
 	err := s.FileBackend().TestConnection()
 	if err != nil {
 		if _, ok := err.(*filestore.S3FileBackendNoBucketError); ok {
-			err = s.FileBackend().(*filestore.S3FileBackend).MakeBucket()
+			backend := s.FileBackend()
+			if cachedBackend, ok := backend.(*filestore.CachedFileBackend); ok {
+				backend = cachedBackend.Unwrap()
+			}
+			if s3Backend, ok := backend.(*filestore.S3FileBackend); ok {
+				err = s3Backend.MakeBucket()
+			}
 		}
 		if err != nil {
 			mlog.Error("Problem with file storage settings", mlog.Err(err))
@@ -929,10 +944,20 @@ func (s *Server) Start() error {
 	if *s.platform.Config().RateLimitSettings.Enable {
 		mlog.Info("RateLimiter is enabled")
 
-		rateLimiter, err2 := NewRateLimiter(&s.platform.Config().RateLimitSettings, s.platform.Config().ServiceSettings.TrustedProxyIPHeader)
+		cfg := s.platform.Config()
+		var rateLimiter *RateLimiter
+		var err2 error
+		if *cfg.RateLimitSettings.StoreType == model.RateLimitStoreTypeRedis {
+			rateLimiter, err2 = NewRedisRateLimiter(&cfg.RateLimitSettings, &cfg.CacheSettings, cfg.ServiceSettings.TrustedProxyIPHeader)
+		} else {
+			rateLimiter, err2 = NewRateLimiter(&cfg.RateLimitSettings, cfg.ServiceSettings.TrustedProxyIPHeader)
+		}
 		if err2 != nil {
 			return err2
 		}
+		if metrics := s.GetMetrics(); metrics != nil {
+			rateLimiter.SetMetrics(metrics)
+		}
 
 		s.RateLimiter = rateLimiter
 		handler = rateLimiter.RateLimitHandler(handler)
@@ -1502,6 +1527,12 @@ func (s *Server) initJobs() {
 		nil,
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypePostgresSearchIndexing,
+		pgindexer.MakeWorker(s.Jobs, s.platform.SearchEngine.PostgresEngine.(*postgresengine.PostgresEngine)),
+		nil,
+	)
+
 	s.Jobs.RegisterJobType(
 		model.JobTypeMigrations,
 		migrations.MakeWorker(s.Jobs, s.Store()),
@@ -1637,6 +1668,18 @@ func (s *Server) initJobs() {
 		cleanup_desktop_tokens.MakeScheduler(s.Jobs),
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeOutgoingWebhookRetry,
+		outgoing_webhook_retry.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		outgoing_webhook_retry.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeOutOfOffice,
+		out_of_office.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		out_of_office.MakeScheduler(s.Jobs),
+	)
+
 	s.Jobs.RegisterJobType(
 		model.JobTypeRefreshPostStats,
 		refresh_post_stats.MakeWorker(s.Jobs, *s.platform.Config().SqlSettings.DriverName),
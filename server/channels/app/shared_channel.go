@@ -175,6 +175,15 @@ func (a *App) GetSharedChannelRemotesStatus(channelID string) ([]*model.SharedCh
 	return a.Srv().Store().SharedChannel().GetRemotesStatus(channelID)
 }
 
+// GetSharedChannelRemotesSyncStatus returns the synchronization status of each remote the channel is
+// shared with.
+func (a *App) GetSharedChannelRemotesSyncStatus(channelID string) ([]*model.SharedChannelRemoteSyncStatus, error) {
+	if err := a.checkChannelIsShared(channelID); err != nil {
+		return nil, err
+	}
+	return a.Srv().Store().SharedChannel().GetRemotesSyncStatus(channelID)
+}
+
 // SharedChannelUsers
 
 func (a *App) NotifySharedChannelUserUpdate(user *model.User) {
@@ -33,6 +33,10 @@ func (a *App) SlackImport(c request.CTX, fileData multipart.File, fileSize int64
 		CreateChannel: func(channel *model.Channel, addMember bool) (*model.Channel, *model.AppError) {
 			return a.CreateChannel(c, channel, addMember)
 		},
+		CreateTeam: a.CreateTeam,
+		HasPermissionTo: func(permission *model.Permission) bool {
+			return a.SessionHasPermissionTo(*c.Session(), permission)
+		},
 		DoUploadFile: func(now time.Time, rawTeamId string, rawChannelId string, rawUserId string, rawFilename string, data []byte) (*model.FileInfo, *model.AppError) {
 			return a.DoUploadFile(c, now, rawTeamId, rawChannelId, rawUserId, rawFilename, data, true)
 		},
@@ -0,0 +1,338 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+)
+
+type RemindProvider struct {
+}
+
+const (
+	CmdRemind = "remind"
+
+	// defaultReminderHour is the time of day of reminders set for a day without a time, e.g. "tomorrow".
+	defaultReminderHour = 9
+
+	// maxReminderDelay is how far in the future a reminder can be set.
+	maxReminderDelay = 365 * 24 * time.Hour
+)
+
+var reminderWeekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"sun":       time.Sunday,
+	"monday":    time.Monday,
+	"mon":       time.Monday,
+	"tuesday":   time.Tuesday,
+	"tue":       time.Tuesday,
+	"wednesday": time.Wednesday,
+	"wed":       time.Wednesday,
+	"thursday":  time.Thursday,
+	"thu":       time.Thursday,
+	"friday":    time.Friday,
+	"fri":       time.Friday,
+	"saturday":  time.Saturday,
+	"sat":       time.Saturday,
+}
+
+var reminderUnits = map[string]time.Duration{
+	"m":       time.Minute,
+	"min":     time.Minute,
+	"mins":    time.Minute,
+	"minute":  time.Minute,
+	"minutes": time.Minute,
+	"h":       time.Hour,
+	"hr":      time.Hour,
+	"hrs":     time.Hour,
+	"hour":    time.Hour,
+	"hours":   time.Hour,
+	"d":       24 * time.Hour,
+	"day":     24 * time.Hour,
+	"days":    24 * time.Hour,
+	"w":       7 * 24 * time.Hour,
+	"week":    7 * 24 * time.Hour,
+	"weeks":   7 * 24 * time.Hour,
+}
+
+func init() {
+	app.RegisterCommandProvider(&RemindProvider{})
+}
+
+func (*RemindProvider) GetTrigger() string {
+	return CmdRemind
+}
+
+func (*RemindProvider) GetCommand(a *app.App, T i18n.TranslateFunc) *model.Command {
+	return &model.Command{
+		Trigger:          CmdRemind,
+		AutoComplete:     true,
+		AutoCompleteDesc: T("api.command_remind.desc"),
+		AutoCompleteHint: T("api.command_remind.hint"),
+		DisplayName:      T("api.command_remind.name"),
+	}
+}
+
+func (*RemindProvider) DoCommand(a *app.App, c request.CTX, args *model.CommandArgs, message string) *model.CommandResponse {
+	fields := strings.Fields(message)
+
+	// The post is either given as a permalink or an id, or is the root of the thread the command is run in
+	postID := args.RootId
+	if len(fields) > 0 {
+		if id := reminderPostID(fields[0]); id != "" {
+			postID = id
+			fields = fields[1:]
+		}
+	}
+	if postID == "" {
+		return &model.CommandResponse{Text: args.T("api.command_remind.no_post.app_error"), ResponseType: model.CommandResponseTypeEphemeral}
+	}
+
+	user, appErr := a.GetUser(args.UserId)
+	if appErr != nil {
+		return &model.CommandResponse{Text: args.T("api.command_remind.app_error"), ResponseType: model.CommandResponseTypeEphemeral}
+	}
+
+	location := user.GetTimezoneLocation()
+	if location == nil {
+		location = time.UTC
+	}
+
+	targetTime, ok := parseReminderTime(strings.Join(fields, " "), time.Now().In(location))
+	if !ok {
+		return &model.CommandResponse{Text: args.T("api.command_remind.time.app_error"), ResponseType: model.CommandResponseTypeEphemeral}
+	}
+
+	if !a.SessionHasPermissionToChannelByPost(*c.Session(), postID, model.PermissionReadChannelContent) {
+		return &model.CommandResponse{Text: args.T("api.command_remind.no_post.app_error"), ResponseType: model.CommandResponseTypeEphemeral}
+	}
+
+	if appErr := a.SetPostReminder(c, postID, args.UserId, targetTime.Unix()); appErr != nil {
+		c.Logger().Debug(appErr.Error())
+		return &model.CommandResponse{Text: args.T("api.command_remind.app_error"), ResponseType: model.CommandResponseTypeEphemeral}
+	}
+
+	// SetPostReminder already lets the user know when the reminder is set for
+	return &model.CommandResponse{}
+}
+
+// reminderPostID returns the id of the post referenced by a permalink or an id, or an empty string.
+func reminderPostID(s string) string {
+	if model.IsValidId(s) {
+		return s
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return ""
+	}
+
+	dir, id := path.Split(strings.TrimSuffix(u.Path, "/"))
+	if path.Base(dir) != "pl" || !model.IsValidId(id) {
+		return ""
+	}
+
+	return id
+}
+
+// parseReminderTime parses a natural time expression relative to now, such as "in 20 minutes", "2h", "at 5pm",
+// "tomorrow", "tomorrow at 9:30am", "friday", "next monday at noon" or "next week". Days without a time of day
+// default to 9am, in the location of now.
+func parseReminderTime(expression string, now time.Time) (time.Time, bool) {
+	fields := joinMeridiems(strings.Fields(strings.ToLower(expression)))
+	if len(fields) == 0 {
+		return time.Time{}, false
+	}
+
+	if delay, ok := parseReminderDelay(fields); ok {
+		return now.Add(delay), true
+	}
+
+	// Split off the time of day
+	hour, minute := defaultReminderHour, 0
+	hasTimeOfDay := false
+	if i := slices.Index(fields, "at"); i >= 0 {
+		if i != len(fields)-2 {
+			return time.Time{}, false
+		}
+
+		var ok bool
+		if hour, minute, ok = parseReminderTimeOfDay(fields[i+1], false); !ok {
+			return time.Time{}, false
+		}
+		hasTimeOfDay = true
+		fields = fields[:i]
+	} else if h, m, ok := parseReminderTimeOfDay(fields[len(fields)-1], true); ok {
+		hour, minute = h, m
+		hasTimeOfDay = true
+		fields = fields[:len(fields)-1]
+	}
+
+	day := now
+	switch {
+	case len(fields) == 0:
+		// A time of day alone is the next occurrence of that time
+		if !hasTimeOfDay {
+			return time.Time{}, false
+		}
+		if !atTimeOfDay(now, hour, minute).After(now) {
+			day = now.AddDate(0, 0, 1)
+		}
+	case len(fields) == 1 && (fields[0] == "today" || fields[0] == "tonight"):
+		if !hasTimeOfDay && fields[0] == "tonight" {
+			hour = 20
+		}
+	case len(fields) == 1 && fields[0] == "tomorrow":
+		day = now.AddDate(0, 0, 1)
+	case len(fields) == 2 && fields[0] == "next" && fields[1] == "week":
+		day = now.AddDate(0, 0, daysUntil(now.Weekday(), time.Monday))
+	default:
+		// A day of the week, e.g. "friday", "on friday" or "next friday", is its next occurrence after today
+		if len(fields) > 2 || (len(fields) == 2 && fields[0] != "on" && fields[0] != "next") {
+			return time.Time{}, false
+		}
+		weekday, ok := reminderWeekdays[fields[len(fields)-1]]
+		if !ok {
+			return time.Time{}, false
+		}
+		day = now.AddDate(0, 0, daysUntil(now.Weekday(), weekday))
+	}
+
+	targetTime := atTimeOfDay(day, hour, minute)
+	if !targetTime.After(now) || targetTime.Sub(now) > maxReminderDelay {
+		return time.Time{}, false
+	}
+
+	return targetTime, true
+}
+
+// parseReminderDelay parses a delay such as "in 20 minutes", "in an hour", "3 days" or "2h".
+func parseReminderDelay(fields []string) (time.Duration, bool) {
+	if fields[0] == "in" {
+		fields = fields[1:]
+	}
+
+	var amount int
+	var unit string
+	switch len(fields) {
+	case 1:
+		i := strings.IndexFunc(fields[0], func(r rune) bool { return !unicode.IsDigit(r) })
+		if i <= 0 {
+			return 0, false
+		}
+		amount, _ = strconv.Atoi(fields[0][:i])
+		unit = fields[0][i:]
+	case 2:
+		if fields[0] == "a" || fields[0] == "an" {
+			amount = 1
+		} else {
+			n, err := strconv.Atoi(fields[0])
+			if err != nil {
+				return 0, false
+			}
+			amount = n
+		}
+		unit = fields[1]
+	default:
+		return 0, false
+	}
+
+	unitDuration, ok := reminderUnits[unit]
+	if !ok || amount <= 0 || amount > int(maxReminderDelay/unitDuration) {
+		return 0, false
+	}
+
+	return time.Duration(amount) * unitDuration, true
+}
+
+// parseReminderTimeOfDay parses a time of day such as "9", "9am", "9:30pm", "17:45", "noon" or "midnight". A bare
+// hour is ambiguous outside of "at 9", so it is refused when strict is set.
+func parseReminderTimeOfDay(s string, strict bool) (int, int, bool) {
+	switch s {
+	case "noon":
+		return 12, 0, true
+	case "midnight":
+		return 0, 0, true
+	}
+
+	meridiem := ""
+	if strings.HasSuffix(s, "am") || strings.HasSuffix(s, "pm") {
+		meridiem = s[len(s)-2:]
+		s = s[:len(s)-2]
+	} else if strict && !strings.Contains(s, ":") {
+		return 0, 0, false
+	}
+
+	hourPart, minutePart, hasMinutes := strings.Cut(s, ":")
+	hour, err := strconv.Atoi(hourPart)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	minute := 0
+	if hasMinutes {
+		if len(minutePart) != 2 {
+			return 0, 0, false
+		}
+		if minute, err = strconv.Atoi(minutePart); err != nil || minute < 0 || minute > 59 {
+			return 0, 0, false
+		}
+	}
+
+	if meridiem == "" {
+		if hour < 0 || hour > 23 {
+			return 0, 0, false
+		}
+		return hour, minute, true
+	}
+
+	if hour < 1 || hour > 12 {
+		return 0, 0, false
+	}
+	hour %= 12
+	if meridiem == "pm" {
+		hour += 12
+	}
+
+	return hour, minute, true
+}
+
+// joinMeridiems joins "am" and "pm" to the preceding field, so that "5 pm" is read as "5pm".
+func joinMeridiems(fields []string) []string {
+	joined := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if (field == "am" || field == "pm") && len(joined) > 0 {
+			joined[len(joined)-1] += field
+			continue
+		}
+		joined = append(joined, field)
+	}
+
+	return joined
+}
+
+// daysUntil returns the number of days until the next given weekday, which is never today.
+func daysUntil(from, to time.Weekday) int {
+	days := (int(to) - int(from) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+
+	return days
+}
+
+func atTimeOfDay(day time.Time, hour, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location())
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestParseReminderTime(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// A Wednesday
+	now := time.Date(2024, time.May, 15, 10, 0, 0, 0, location)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2024, month, day, hour, minute, 0, 0, location)
+	}
+
+	for expression, expected := range map[string]time.Time{
+		"in 20 minutes":        now.Add(20 * time.Minute),
+		"20 min":               now.Add(20 * time.Minute),
+		"30m":                  now.Add(30 * time.Minute),
+		"in an hour":           now.Add(time.Hour),
+		"2h":                   now.Add(2 * time.Hour),
+		"in 3 days":            now.Add(3 * 24 * time.Hour),
+		"in a week":            now.Add(7 * 24 * time.Hour),
+		"at 5pm":               at(time.May, 15, 17, 0),
+		"at 5 PM":              at(time.May, 15, 17, 0),
+		"5:30pm":               at(time.May, 15, 17, 30),
+		"at 17:45":             at(time.May, 15, 17, 45),
+		"at 9":                 at(time.May, 16, 9, 0),
+		"at 9am":               at(time.May, 16, 9, 0),
+		"today at noon":        at(time.May, 15, 12, 0),
+		"tonight":              at(time.May, 15, 20, 0),
+		"tomorrow":             at(time.May, 16, 9, 0),
+		"tomorrow at 9:30am":   at(time.May, 16, 9, 30),
+		"tomorrow 2pm":         at(time.May, 16, 14, 0),
+		"friday":               at(time.May, 17, 9, 0),
+		"on mon":               at(time.May, 20, 9, 0),
+		"next wednesday":       at(time.May, 22, 9, 0),
+		"next monday at noon":  at(time.May, 20, 12, 0),
+		"next week":            at(time.May, 20, 9, 0),
+		"Tuesday at midnight":  at(time.May, 21, 0, 0),
+		"  Tomorrow   at 8am ": at(time.May, 16, 8, 0),
+	} {
+		actual, ok := parseReminderTime(expression, now)
+		if assert.Truef(t, ok, "expected %q to be parsed", expression) {
+			assert.Truef(t, expected.Equal(actual), "expected %q to be %v, got %v", expression, expected, actual)
+		}
+	}
+
+	for _, expression := range []string{
+		"",
+		"soon",
+		"in",
+		"in 0 minutes",
+		"in -5 minutes",
+		"in 2 fortnights",
+		"in 500 days",
+		"today at 8am",
+		"at 25:00",
+		"at 13pm",
+		"at 5:5",
+		"tomorrow at",
+		"at 5pm tomorrow",
+		"last friday",
+		"on the friday",
+		"9",
+	} {
+		_, ok := parseReminderTime(expression, now)
+		assert.Falsef(t, ok, "expected %q not to be parsed", expression)
+	}
+}
+
+func TestReminderPostID(t *testing.T) {
+	postID := model.NewId()
+
+	assert.Equal(t, postID, reminderPostID(postID))
+	assert.Equal(t, postID, reminderPostID("https://example.com/team/pl/"+postID))
+	assert.Equal(t, postID, reminderPostID("https://example.com/subpath/team/pl/"+postID+"/"))
+	assert.Equal(t, postID, reminderPostID("/team/pl/"+postID))
+	assert.Empty(t, reminderPostID("tomorrow"))
+	assert.Empty(t, reminderPostID("https://example.com/team/channels/"+postID))
+	assert.Empty(t, reminderPostID("https://example.com/team/pl/notanid"))
+}
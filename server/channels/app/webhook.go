@@ -19,6 +19,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/webhookadapter"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 )
@@ -95,101 +96,347 @@ func (a *App) handleWebhookEvents(c request.CTX, post *model.Post, team *model.T
 }
 
 func (a *App) TriggerWebhook(c request.CTX, payload *model.OutgoingWebhookPayload, hook *model.OutgoingWebhook, post *model.Post, channel *model.Channel) {
-	var jsonBytes []byte
-	var err error
+	var body []byte
+
+	contentType := hook.PayloadContentType()
+	switch {
+	case hook.PayloadTemplate != "":
+		data, appErr := a.outgoingWebhookTemplateData(payload, post, channel)
+		if appErr != nil {
+			c.Logger().Warn("Failed to get the data of the outgoing webhook payload template", mlog.String("hook_id", hook.Id), mlog.Err(appErr))
+			return
+		}
 
-	contentType := "application/x-www-form-urlencoded"
-	if hook.ContentType == "application/json" {
-		contentType = "application/json"
-		jsonBytes, err = json.Marshal(payload)
+		rendered, err := hook.RenderPayload(data)
+		if err != nil {
+			c.Logger().Warn("Failed to render the outgoing webhook payload template", mlog.String("hook_id", hook.Id), mlog.Err(err))
+			return
+		}
+		body = rendered
+	case contentType == "application/json":
+		jsonBytes, err := json.Marshal(payload)
 		if err != nil {
 			c.Logger().Warn("Failed to encode to JSON", mlog.Err(err))
 			return
 		}
+		body = jsonBytes
+	default:
+		body = []byte(payload.ToFormValues())
 	}
 
 	var wg sync.WaitGroup
 
 	for i := range hook.CallbackURLs {
-		var body io.Reader
-		if hook.ContentType == "application/json" {
-			body = bytes.NewReader(jsonBytes)
-		} else {
-			body = strings.NewReader(payload.ToFormValues())
-		}
 		wg.Add(1)
 
-		// Get the callback URL by index to properly capture it for the go func
-		url := hook.CallbackURLs[i]
+		delivery := &model.OutgoingWebhookDelivery{
+			HookId:      hook.Id,
+			PostId:      post.Id,
+			ChannelId:   channel.Id,
+			CallbackURL: hook.CallbackURLs[i],
+			ContentType: contentType,
+			Payload:     string(body),
+		}
 
 		go func() {
 			defer wg.Done()
 
-			var accessToken *model.OutgoingOAuthConnectionToken
+			savedDelivery, err := a.Srv().Store().Webhook().SaveOutgoingDelivery(delivery)
+			if err != nil {
+				// The payload is sent anyway, it just won't show up in the delivery log nor be retried.
+				c.Logger().Warn("Failed to save the outgoing webhook delivery", mlog.String("hook_id", hook.Id), mlog.Err(err))
+			} else {
+				delivery = savedDelivery
+			}
 
-			// Retrieve an access token from a connection if one exists to use for the webhook request
-			if a.Config().ServiceSettings.EnableOutgoingOAuthConnections != nil && *a.Config().ServiceSettings.EnableOutgoingOAuthConnections && a.OutgoingOAuthConnections() != nil {
-				connection, err := a.OutgoingOAuthConnections().GetConnectionForAudience(c, url)
-				if err != nil {
-					c.Logger().Error("Failed to find an outgoing oauth connection for the webhook", mlog.Err(err))
-					return
-				}
+			a.deliverOutgoingWebhook(c, hook, delivery, channel)
+		}()
+	}
+	wg.Wait()
+}
 
-				if connection != nil {
-					accessToken, err = a.OutgoingOAuthConnections().RetrieveTokenForConnection(c, connection)
-					if err != nil {
-						c.Logger().Error("Failed to retrieve token for outgoing oauth connection", mlog.Err(err))
-						return
-					}
-				}
-			}
+// outgoingWebhookTemplateData gathers the data available to the payload template of an outgoing webhook for a post.
+func (a *App) outgoingWebhookTemplateData(payload *model.OutgoingWebhookPayload, post *model.Post, channel *model.Channel) (*model.OutgoingWebhookTemplateData, *model.AppError) {
+	team, appErr := a.GetTeam(payload.TeamId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	user, appErr := a.GetUser(post.UserId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.newOutgoingWebhookTemplateData(payload.Token, payload.TriggerWord, post, channel, team, user), nil
+}
+
+func (a *App) newOutgoingWebhookTemplateData(token, triggerWord string, post *model.Post, channel *model.Channel, team *model.Team, user *model.User) *model.OutgoingWebhookTemplateData {
+	data := &model.OutgoingWebhookTemplateData{
+		Token:       token,
+		TriggerWord: triggerWord,
+		Post: model.OutgoingWebhookTemplatePost{
+			Id:       post.Id,
+			RootId:   post.RootId,
+			Message:  post.Message,
+			Type:     post.Type,
+			CreateAt: post.CreateAt,
+			FileIds:  post.FileIds,
+			Hashtags: post.Hashtags,
+		},
+		Channel: model.OutgoingWebhookTemplateChannel{
+			Id:          channel.Id,
+			Name:        channel.Name,
+			DisplayName: channel.DisplayName,
+			Type:        channel.Type,
+			Header:      channel.Header,
+			Purpose:     channel.Purpose,
+		},
+		Team: model.OutgoingWebhookTemplateTeam{
+			Id:          team.Id,
+			Name:        team.Name,
+			DisplayName: team.DisplayName,
+		},
+		User: model.OutgoingWebhookTemplateUser{
+			Id:       user.Id,
+			Username: user.Username,
+			Nickname: user.Nickname,
+			Position: user.Position,
+			Locale:   user.Locale,
+			IsBot:    user.IsBot,
+		},
+	}
+
+	if *a.Config().PrivacySettings.ShowFullName {
+		data.User.FirstName = user.FirstName
+		data.User.LastName = user.LastName
+	}
+
+	return data
+}
+
+// RenderOutgoingWebhookPayload renders the payload template of an outgoing webhook, which doesn't need to be saved,
+// as it would be sent for a sample post made by the given user.
+func (a *App) RenderOutgoingWebhookPayload(c request.CTX, hook *model.OutgoingWebhook, user *model.User) (*model.OutgoingWebhookRenderedPayload, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("RenderOutgoingWebhookPayload", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if hook.PayloadTemplate == "" {
+		return nil, model.NewAppError("RenderOutgoingWebhookPayload", "app.webhooks.render_outgoing.empty.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if appErr := hook.IsValidPayloadTemplate(); appErr != nil {
+		return nil, appErr
+	}
+
+	team, appErr := a.GetTeam(hook.TeamId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	sample := model.SampleOutgoingWebhookTemplateData()
+	channel := &model.Channel{
+		Id:          sample.Channel.Id,
+		Name:        sample.Channel.Name,
+		DisplayName: sample.Channel.DisplayName,
+		Type:        sample.Channel.Type,
+		TeamId:      team.Id,
+	}
+	if hook.ChannelId != "" {
+		hookChannel, appErr := a.GetChannel(c, hook.ChannelId)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if hookChannel.TeamId != team.Id || hookChannel.Type != model.ChannelTypeOpen {
+			return nil, model.NewAppError("RenderOutgoingWebhookPayload", "api.webhook.create_outgoing.permissions.app_error", nil, "", http.StatusForbidden)
+		}
+		channel = hookChannel
+	}
+
+	triggerWord := ""
+	message := strings.TrimPrefix(sample.Post.Message, sample.TriggerWord+" ")
+	if len(hook.TriggerWords) > 0 {
+		triggerWord = hook.TriggerWords[0]
+		message = triggerWord + " " + message
+	}
+
+	post := &model.Post{
+		Id:        sample.Post.Id,
+		ChannelId: channel.Id,
+		UserId:    user.Id,
+		Message:   message,
+		CreateAt:  sample.Post.CreateAt,
+		FileIds:   sample.Post.FileIds,
+	}
+
+	token := hook.Token
+	if token == "" {
+		token = sample.Token
+	}
+
+	payload, err := hook.RenderPayload(a.newOutgoingWebhookTemplateData(token, triggerWord, post, channel, team, user))
+	if err != nil {
+		return nil, model.NewAppError("RenderOutgoingWebhookPayload", "model.outgoing_hook.is_valid.payload_template.app_error", map[string]any{"Error": err.Error()}, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return &model.OutgoingWebhookRenderedPayload{
+		ContentType: hook.PayloadContentType(),
+		Headers:     hook.Headers,
+		Payload:     string(payload),
+	}, nil
+}
+
+// deliverOutgoingWebhook sends the payload of a delivery, creates a post from
+// the response if any, and records the outcome of the attempt. Transient
+// failures are scheduled to be retried by the outgoing webhook retry job.
+func (a *App) deliverOutgoingWebhook(c request.CTX, hook *model.OutgoingWebhook, delivery *model.OutgoingWebhookDelivery, channel *model.Channel) {
+	result, latency, err := a.sendOutgoingWebhookDelivery(c, hook, delivery)
+
+	delivery.Attempts++
+	delivery.Latency = latency.Milliseconds()
+	delivery.NextAttemptAt = 0
+	if err != nil {
+		delivery.StatusCode = 0
+		delivery.Error = err.Error()
+		delivery.ResponseExcerpt = ""
+	} else {
+		delivery.StatusCode = result.StatusCode
+		delivery.Error = ""
+		delivery.ResponseExcerpt = string(result.Body)
+	}
+
+	switch {
+	case err == nil && result.StatusCode >= 200 && result.StatusCode < 300:
+		delivery.Status = model.OutgoingWebhookDeliveryStatusSuccess
+	case (err != nil || model.IsRetryableOutgoingWebhookStatusCode(result.StatusCode)) && delivery.Attempts <= *a.Config().ServiceSettings.OutgoingWebhookMaxRetries:
+		delivery.Status = model.OutgoingWebhookDeliveryStatusRetrying
+		delivery.NextAttemptAt = model.GetMillisForTime(time.Now().Add(model.OutgoingWebhookRetryDelay(delivery.Attempts)))
+	default:
+		delivery.Status = model.OutgoingWebhookDeliveryStatusFailed
+	}
+
+	if delivery.Id != "" {
+		if _, err := a.Srv().Store().Webhook().UpdateOutgoingDelivery(delivery); err != nil {
+			c.Logger().Warn("Failed to update the outgoing webhook delivery", mlog.String("delivery_id", delivery.Id), mlog.Err(err))
+		}
+	}
+
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			c.Logger().Error("Outgoing Webhook POST timed out. Consider increasing ServiceSettings.OutgoingIntegrationRequestsTimeout.", mlog.Err(err))
+		} else {
+			c.Logger().Error("Outgoing Webhook POST failed", mlog.Err(err))
+		}
+		return
+	}
 
-			webhookResp, err := a.doOutgoingWebhookRequest(url, body, contentType, accessToken)
+	if delivery.Status == model.OutgoingWebhookDeliveryStatusRetrying || channel == nil {
+		return
+	}
+
+	webhookResp, err := result.decode()
+	if err != nil {
+		c.Logger().Error("Outgoing Webhook POST failed", mlog.Err(err))
+		return
+	}
+
+	a.handleOutgoingWebhookResponse(c, hook, channel, delivery.PostId, webhookResp)
+}
+
+// sendOutgoingWebhookDelivery makes a single attempt at sending the payload of
+// a delivery, signed with the hook's secret when it has one.
+func (a *App) sendOutgoingWebhookDelivery(c request.CTX, hook *model.OutgoingWebhook, delivery *model.OutgoingWebhookDelivery) (*outgoingWebhookResult, time.Duration, error) {
+	var accessToken *model.OutgoingOAuthConnectionToken
+
+	// Retrieve an access token from a connection if one exists to use for the webhook request
+	if a.Config().ServiceSettings.EnableOutgoingOAuthConnections != nil && *a.Config().ServiceSettings.EnableOutgoingOAuthConnections && a.OutgoingOAuthConnections() != nil {
+		connection, err := a.OutgoingOAuthConnections().GetConnectionForAudience(c, delivery.CallbackURL)
+		if err != nil {
+			c.Logger().Error("Failed to find an outgoing oauth connection for the webhook", mlog.Err(err))
+			return nil, 0, err
+		}
+
+		if connection != nil {
+			accessToken, err = a.OutgoingOAuthConnections().RetrieveTokenForConnection(c, connection)
 			if err != nil {
-				if errors.Is(err, context.DeadlineExceeded) {
-					c.Logger().Error("Outgoing Webhook POST timed out. Consider increasing ServiceSettings.OutgoingIntegrationRequestsTimeout.", mlog.Err(err))
-				} else {
-					c.Logger().Error("Outgoing Webhook POST failed", mlog.Err(err))
-				}
-				return
+				c.Logger().Error("Failed to retrieve token for outgoing oauth connection", mlog.Err(err))
+				return nil, 0, err
 			}
+		}
+	}
 
-			if webhookResp != nil && (webhookResp.Text != nil || len(webhookResp.Attachments) > 0) {
-				postRootId := ""
-				if webhookResp.ResponseType == model.OutgoingHookResponseTypeComment {
-					postRootId = post.Id
-				}
-				if len(webhookResp.Props) == 0 {
-					webhookResp.Props = make(model.StringInterface)
-				}
-				webhookResp.Props["webhook_display_name"] = hook.DisplayName
+	header := http.Header{}
+	for name, value := range hook.Headers {
+		header.Set(name, value)
+	}
+	header.Set(model.OutgoingWebhookIdHeader, hook.Id)
+	if delivery.Id != "" {
+		header.Set(model.OutgoingWebhookDeliveryIdHeader, delivery.Id)
+	}
+	if hook.SigningSecret != "" {
+		header.Set(model.OutgoingWebhookSignatureHeader, model.SignOutgoingWebhookPayload(hook.SigningSecret, time.Now().Unix(), []byte(delivery.Payload)))
+	}
 
-				text := ""
-				if webhookResp.Text != nil {
-					text = a.ProcessSlackText(*webhookResp.Text)
-				}
-				webhookResp.Attachments = a.ProcessSlackAttachments(webhookResp.Attachments)
-				// attachments is in here for slack compatibility
-				if len(webhookResp.Attachments) > 0 {
-					webhookResp.Props["attachments"] = webhookResp.Attachments
-				}
-				if *a.Config().ServiceSettings.EnablePostUsernameOverride && hook.Username != "" && webhookResp.Username == "" {
-					webhookResp.Username = hook.Username
-				}
+	start := time.Now()
+	result, err := a.doOutgoingWebhookRequest(delivery.CallbackURL, strings.NewReader(delivery.Payload), delivery.ContentType, header, accessToken)
+	return result, time.Since(start), err
+}
 
-				if *a.Config().ServiceSettings.EnablePostIconOverride && hook.IconURL != "" && webhookResp.IconURL == "" {
-					webhookResp.IconURL = hook.IconURL
-				}
-				if _, err := a.CreateWebhookPost(c, hook.CreatorId, channel, text, webhookResp.Username, webhookResp.IconURL, "", webhookResp.Props, webhookResp.Type, postRootId, webhookResp.Priority); err != nil {
-					c.Logger().Error("Failed to create response post.", mlog.Err(err))
-				}
-			}
-		}()
+func (a *App) handleOutgoingWebhookResponse(c request.CTX, hook *model.OutgoingWebhook, channel *model.Channel, postID string, webhookResp *model.OutgoingWebhookResponse) {
+	if webhookResp == nil || (webhookResp.Text == nil && len(webhookResp.Attachments) == 0) {
+		return
 	}
-	wg.Wait()
+
+	postRootId := ""
+	if webhookResp.ResponseType == model.OutgoingHookResponseTypeComment {
+		postRootId = postID
+	}
+	if len(webhookResp.Props) == 0 {
+		webhookResp.Props = make(model.StringInterface)
+	}
+	webhookResp.Props["webhook_display_name"] = hook.DisplayName
+
+	text := ""
+	if webhookResp.Text != nil {
+		text = a.ProcessSlackText(*webhookResp.Text)
+	}
+	webhookResp.Attachments = a.ProcessSlackAttachments(webhookResp.Attachments)
+	// attachments is in here for slack compatibility
+	if len(webhookResp.Attachments) > 0 {
+		webhookResp.Props["attachments"] = webhookResp.Attachments
+	}
+	if *a.Config().ServiceSettings.EnablePostUsernameOverride && hook.Username != "" && webhookResp.Username == "" {
+		webhookResp.Username = hook.Username
+	}
+
+	if *a.Config().ServiceSettings.EnablePostIconOverride && hook.IconURL != "" && webhookResp.IconURL == "" {
+		webhookResp.IconURL = hook.IconURL
+	}
+	if _, err := a.CreateWebhookPost(c, hook.CreatorId, channel, text, webhookResp.Username, webhookResp.IconURL, "", webhookResp.Props, webhookResp.Type, postRootId, webhookResp.Priority); err != nil {
+		c.Logger().Error("Failed to create response post.", mlog.Err(err))
+	}
+}
+
+// outgoingWebhookResult is the response of the server an outgoing webhook
+// payload was sent to.
+type outgoingWebhookResult struct {
+	StatusCode int
+	Body       []byte
 }
 
-func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType string, accessToken *model.OutgoingOAuthConnectionToken) (*model.OutgoingWebhookResponse, error) {
+func (r *outgoingWebhookResult) decode() (*model.OutgoingWebhookResponse, error) {
+	var hookResp model.OutgoingWebhookResponse
+	if jsonErr := json.NewDecoder(bytes.NewReader(r.Body)).Decode(&hookResp); jsonErr != nil {
+		if jsonErr == io.EOF {
+			return nil, nil
+		}
+		return nil, model.NewAppError("doOutgoingWebhookRequest", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
+	}
+
+	return &hookResp, nil
+}
+
+func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType string, header http.Header, accessToken *model.OutgoingOAuthConnectionToken) (*outgoingWebhookResult, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*a.Config().ServiceSettings.OutgoingIntegrationRequestsTimeout)*time.Second)
 	defer cancel()
 
@@ -198,6 +445,9 @@ func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType s
 		return nil, err
 	}
 
+	for key, values := range header {
+		req.Header[key] = values
+	}
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
 
@@ -212,15 +462,12 @@ func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType s
 
 	defer resp.Body.Close()
 
-	var hookResp model.OutgoingWebhookResponse
-	if jsonErr := json.NewDecoder(io.LimitReader(resp.Body, MaxIntegrationResponseSize)).Decode(&hookResp); jsonErr != nil {
-		if jsonErr == io.EOF {
-			return nil, nil
-		}
-		return nil, model.NewAppError("doOutgoingWebhookRequest", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, MaxIntegrationResponseSize))
+	if err != nil {
+		return nil, err
 	}
 
-	return &hookResp, nil
+	return &outgoingWebhookResult{StatusCode: resp.StatusCode, Body: respBody}, nil
 }
 
 func SplitWebhookPost(post *model.Post, maxPostSize int) ([]*model.Post, *model.AppError) {
@@ -432,6 +679,10 @@ func (a *App) UpdateIncomingWebhook(oldHook, updatedHook *model.IncomingWebhook)
 	updatedHook.TeamId = oldHook.TeamId
 	updatedHook.DeleteAt = oldHook.DeleteAt
 
+	if appErr := updatedHook.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
 	newWebhook, err := a.Srv().Store().Webhook().UpdateIncoming(updatedHook)
 	if err != nil {
 		return nil, model.NewAppError("UpdateIncomingWebhook", "app.webhooks.update_incoming.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -614,10 +865,15 @@ func (a *App) UpdateOutgoingWebhook(c request.CTX, oldHook, updatedHook *model.O
 		}
 	}
 
+	if appErr := updatedHook.IsValidPayloadTemplate(); appErr != nil {
+		return nil, appErr
+	}
+
 	updatedHook.CreatorId = oldHook.CreatorId
 	updatedHook.CreateAt = oldHook.CreateAt
 	updatedHook.DeleteAt = oldHook.DeleteAt
 	updatedHook.TeamId = oldHook.TeamId
+	updatedHook.SigningSecret = oldHook.SigningSecret
 	updatedHook.UpdateAt = model.GetMillis()
 
 	webhook, err := a.Srv().Store().Webhook().UpdateOutgoing(updatedHook)
@@ -712,6 +968,7 @@ func (a *App) RegenOutgoingWebhookToken(hook *model.OutgoingWebhook) (*model.Out
 	}
 
 	hook.Token = model.NewId()
+	hook.SigningSecret = model.NewOutgoingWebhookSigningSecret()
 
 	webhook, err := a.Srv().Store().Webhook().UpdateOutgoing(hook)
 	if err != nil {
@@ -721,6 +978,23 @@ func (a *App) RegenOutgoingWebhookToken(hook *model.OutgoingWebhook) (*model.Out
 	return webhook, nil
 }
 
+// ParseIncomingWebhookPayload verifies and converts the native payload of a service to an incoming webhook request,
+// according to the payload format of the hook. A nil request is returned for events that don't need to be posted.
+func (a *App) ParseIncomingWebhookPayload(c request.CTX, hook *model.IncomingWebhook, header http.Header, body []byte) (*model.IncomingWebhookRequest, *model.AppError) {
+	req, err := webhookadapter.Parse(hook.PayloadFormat, header, body, hook.Secret)
+	switch {
+	case errors.Is(err, webhookadapter.ErrIgnoredEvent):
+		c.Logger().Debug("Ignoring incoming webhook event", mlog.String("webhook_id", hook.Id), mlog.String("payload_format", hook.PayloadFormat))
+		return nil, nil
+	case errors.Is(err, webhookadapter.ErrInvalidSignature):
+		return nil, model.NewAppError("ParseIncomingWebhookPayload", "web.incoming_webhook.signature.app_error", nil, "webhook_id="+hook.Id, http.StatusUnauthorized).Wrap(err)
+	case err != nil:
+		return nil, model.NewAppError("ParseIncomingWebhookPayload", "web.incoming_webhook.parse.app_error", nil, "webhook_id="+hook.Id, http.StatusBadRequest).Wrap(err)
+	}
+
+	return req, nil
+}
+
 func (a *App) HandleIncomingWebhook(c request.CTX, hookID string, req *model.IncomingWebhookRequest) *model.AppError {
 	if !*a.Config().ServiceSettings.EnableIncomingWebhooks {
 		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
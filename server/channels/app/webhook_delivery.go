@@ -0,0 +1,151 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const (
+	outgoingWebhookRetryBatchSize    = 100
+	outgoingWebhookRetryConcurrency  = 10
+	outgoingWebhookDeliveryRetention = 14 * 24 * time.Hour
+)
+
+func (a *App) GetOutgoingWebhookDeliveries(hookID string, page, perPage int) ([]*model.OutgoingWebhookDelivery, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("GetOutgoingWebhookDeliveries", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	deliveries, err := a.Srv().Store().Webhook().GetOutgoingDeliveriesForHook(hookID, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetOutgoingWebhookDeliveries", "app.webhooks.get_outgoing_deliveries.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return deliveries, nil
+}
+
+// RedeliverOutgoingWebhook sends the payload of a past delivery of the hook
+// again, recording it as a new delivery.
+func (a *App) RedeliverOutgoingWebhook(c request.CTX, hook *model.OutgoingWebhook, deliveryID string) (*model.OutgoingWebhookDelivery, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("RedeliverOutgoingWebhook", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	original, err := a.Srv().Store().Webhook().GetOutgoingDelivery(deliveryID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("RedeliverOutgoingWebhook", "app.webhooks.get_outgoing_delivery.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("RedeliverOutgoingWebhook", "app.webhooks.get_outgoing_delivery.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	if original.HookId != hook.Id {
+		return nil, model.NewAppError("RedeliverOutgoingWebhook", "app.webhooks.get_outgoing_delivery.app_error", nil, "", http.StatusNotFound)
+	}
+
+	delivery, err := a.Srv().Store().Webhook().SaveOutgoingDelivery(&model.OutgoingWebhookDelivery{
+		HookId:      original.HookId,
+		PostId:      original.PostId,
+		ChannelId:   original.ChannelId,
+		CallbackURL: original.CallbackURL,
+		ContentType: original.ContentType,
+		Payload:     original.Payload,
+	})
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("RedeliverOutgoingWebhook", "app.webhooks.save_outgoing_delivery.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	a.deliverOutgoingWebhook(c, hook, delivery, a.outgoingWebhookDeliveryChannel(c, delivery))
+
+	return delivery, nil
+}
+
+// RetryOutgoingWebhookDeliveries attempts the deliveries whose retry is due,
+// and deletes the deliveries older than the retention period.
+func (a *App) RetryOutgoingWebhookDeliveries() error {
+	rctx := request.EmptyContext(a.Log())
+
+	deliveries, err := a.Srv().Store().Webhook().GetOutgoingDeliveriesToRetry(model.GetMillis(), outgoingWebhookRetryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, outgoingWebhookRetryConcurrency)
+
+	for _, delivery := range deliveries {
+		hook, err := a.Srv().Store().Webhook().GetOutgoing(delivery.HookId)
+		if err != nil {
+			var nfErr *store.ErrNotFound
+			if !errors.As(err, &nfErr) {
+				return err
+			}
+
+			// The hook was deleted since the delivery was first attempted.
+			delivery.Status = model.OutgoingWebhookDeliveryStatusFailed
+			delivery.NextAttemptAt = 0
+			delivery.Error = "outgoing webhook not found"
+			if _, err := a.Srv().Store().Webhook().UpdateOutgoingDelivery(delivery); err != nil {
+				rctx.Logger().Warn("Failed to update the outgoing webhook delivery", mlog.String("delivery_id", delivery.Id), mlog.Err(err))
+			}
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(delivery *model.OutgoingWebhookDelivery) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			a.deliverOutgoingWebhook(rctx, hook, delivery, a.outgoingWebhookDeliveryChannel(rctx, delivery))
+		}(delivery)
+	}
+	wg.Wait()
+
+	deleted, err := a.Srv().Store().Webhook().PermanentDeleteOutgoingDeliveriesBefore(model.GetMillisForTime(time.Now().Add(-outgoingWebhookDeliveryRetention)))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		rctx.Logger().Debug("Deleted old outgoing webhook deliveries", mlog.Int("count", deleted))
+	}
+
+	return nil
+}
+
+// outgoingWebhookDeliveryChannel returns the channel the responses to a
+// delivery are posted in, or nil if it doesn't exist anymore.
+func (a *App) outgoingWebhookDeliveryChannel(c request.CTX, delivery *model.OutgoingWebhookDelivery) *model.Channel {
+	if delivery.ChannelId == "" {
+		return nil
+	}
+
+	channel, err := a.Srv().Store().Channel().Get(delivery.ChannelId, true)
+	if err != nil {
+		c.Logger().Warn("Failed to get the channel of the outgoing webhook delivery", mlog.String("delivery_id", delivery.Id), mlog.Err(err))
+		return nil
+	}
+
+	return channel
+}
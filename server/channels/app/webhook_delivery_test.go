@@ -0,0 +1,192 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestOutgoingWebhookDeliveries(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+		*cfg.ServiceSettings.OutgoingWebhookMaxRetries = 2
+	})
+
+	createHook := func(t *testing.T, callbackURL string) *model.OutgoingWebhook {
+		t.Helper()
+
+		hook, appErr := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+			ChannelId:    th.BasicChannel.Id,
+			TeamId:       th.BasicTeam.Id,
+			CreatorId:    th.BasicUser.Id,
+			CallbackURLs: []string{callbackURL},
+			TriggerWords: []string{model.NewId()},
+			ContentType:  "application/json",
+		})
+		require.Nil(t, appErr)
+		require.Len(t, hook.SigningSecret, model.OutgoingWebhookSigningSecretLength)
+		return hook
+	}
+
+	trigger := func(hook *model.OutgoingWebhook) *model.OutgoingWebhookDelivery {
+		payload := &model.OutgoingWebhookPayload{Token: hook.Token, ChannelId: th.BasicChannel.Id, PostId: th.BasicPost.Id}
+		th.App.TriggerWebhook(th.Context, payload, hook, th.BasicPost, th.BasicChannel)
+
+		deliveries, appErr := th.App.GetOutgoingWebhookDeliveries(hook.Id, 0, 10)
+		require.Nil(t, appErr)
+		require.Len(t, deliveries, 1)
+		return deliveries[0]
+	}
+
+	t.Run("payloads are signed and deliveries recorded", func(t *testing.T) {
+		type request struct {
+			signature  string
+			deliveryID string
+			body       []byte
+		}
+		requests := make(chan request, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			requests <- request{
+				signature:  r.Header.Get(model.OutgoingWebhookSignatureHeader),
+				deliveryID: r.Header.Get(model.OutgoingWebhookDeliveryIdHeader),
+				body:       body,
+			}
+			w.Write([]byte(`{"text": "received"}`))
+		}))
+		defer server.Close()
+
+		hook := createHook(t, server.URL)
+		delivery := trigger(hook)
+		req := <-requests
+		signature, deliveryID, body := req.signature, req.deliveryID, req.body
+
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusSuccess, delivery.Status)
+		assert.Equal(t, http.StatusOK, delivery.StatusCode)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, `{"text": "received"}`, delivery.ResponseExcerpt)
+		assert.Equal(t, delivery.Id, deliveryID)
+		assert.Equal(t, string(body), delivery.Payload)
+
+		var payload model.OutgoingWebhookPayload
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, hook.Token, payload.Token)
+
+		var timestamp int64
+		var v1 string
+		_, err := fmt.Sscanf(signature, "t=%d,v1=%s", &timestamp, &v1)
+		require.NoError(t, err)
+		assert.Equal(t, model.SignOutgoingWebhookPayload(hook.SigningSecret, timestamp, body), signature)
+	})
+
+	t.Run("transient failures are retried", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		hook := createHook(t, server.URL)
+		delivery := trigger(hook)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusRetrying, delivery.Status)
+		assert.Equal(t, http.StatusServiceUnavailable, delivery.StatusCode)
+		assert.NotZero(t, delivery.NextAttemptAt)
+
+		// Make the retry due.
+		delivery.NextAttemptAt = model.GetMillis() - 1
+		_, err := th.App.Srv().Store().Webhook().UpdateOutgoingDelivery(delivery)
+		require.NoError(t, err)
+
+		require.NoError(t, th.App.RetryOutgoingWebhookDeliveries())
+
+		delivery, err = th.App.Srv().Store().Webhook().GetOutgoingDelivery(delivery.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusSuccess, delivery.Status)
+		assert.Equal(t, 2, delivery.Attempts)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("retries are bounded", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		hook := createHook(t, server.URL)
+		delivery := trigger(hook)
+
+		for range 2 {
+			require.Equal(t, model.OutgoingWebhookDeliveryStatusRetrying, delivery.Status)
+
+			delivery.NextAttemptAt = model.GetMillis() - 1
+			_, err := th.App.Srv().Store().Webhook().UpdateOutgoingDelivery(delivery)
+			require.NoError(t, err)
+			require.NoError(t, th.App.RetryOutgoingWebhookDeliveries())
+
+			delivery, err = th.App.Srv().Store().Webhook().GetOutgoingDelivery(delivery.Id)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusFailed, delivery.Status)
+		assert.Equal(t, 3, delivery.Attempts)
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		delivery := trigger(createHook(t, server.URL))
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusFailed, delivery.Status)
+		assert.Equal(t, http.StatusBadRequest, delivery.StatusCode)
+		assert.Zero(t, delivery.NextAttemptAt)
+	})
+
+	t.Run("redeliver", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+		}))
+		defer server.Close()
+
+		hook := createHook(t, server.URL)
+		original := trigger(hook)
+
+		delivery, appErr := th.App.RedeliverOutgoingWebhook(th.Context, hook, original.Id)
+		require.Nil(t, appErr)
+		assert.NotEqual(t, original.Id, delivery.Id)
+		assert.Equal(t, original.Payload, delivery.Payload)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusSuccess, delivery.Status)
+		assert.Equal(t, int32(2), calls.Load())
+
+		deliveries, appErr := th.App.GetOutgoingWebhookDeliveries(hook.Id, 0, 10)
+		require.Nil(t, appErr)
+		assert.Len(t, deliveries, 2)
+
+		otherHook := createHook(t, server.URL)
+		_, appErr = th.App.RedeliverOutgoingWebhook(th.Context, otherHook, original.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
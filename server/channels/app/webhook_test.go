@@ -665,6 +665,34 @@ func TestCreateOutGoingWebhookWithUsernameAndIconURL(t *testing.T) {
 	assert.Equal(t, createdHook.Description, outgoingWebhook.Description)
 }
 
+func TestOutgoingWebhookSigningSecretsMigration(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = true })
+
+	hook, appErr := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+		ChannelId:    th.BasicChannel.Id,
+		TeamId:       th.BasicChannel.TeamId,
+		CallbackURLs: []string{"http://nowhere.com"},
+		CreatorId:    th.BasicUser.Id,
+	})
+	require.Nil(t, appErr)
+
+	// A webhook created before payloads were signed.
+	hook.SigningSecret = ""
+	_, err := th.App.Srv().Store().Webhook().UpdateOutgoing(hook)
+	require.NoError(t, err)
+	_, err = th.App.Srv().Store().System().PermanentDeleteByName(outgoingWebhookSigningSecretsMigrationKey)
+	require.NoError(t, err)
+
+	require.NoError(t, th.App.Srv().doOutgoingWebhookSigningSecretsMigration())
+
+	migrated, err := th.App.Srv().Store().Webhook().GetOutgoing(hook.Id)
+	require.NoError(t, err)
+	assert.Len(t, migrated.SigningSecret, model.OutgoingWebhookSigningSecretLength)
+}
+
 func TestTriggerOutGoingWebhookWithUsernameAndIconURL(t *testing.T) {
 	getPayload := func(hook *model.OutgoingWebhook, th *TestHelper, channel *model.Channel) *model.OutgoingWebhookPayload {
 		return &model.OutgoingWebhookPayload{
@@ -912,6 +940,132 @@ func (r InfiniteReader) Read(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
+func TestTriggerOutgoingWebhookWithPayloadTemplate(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	type request struct {
+		contentType string
+		apiKey      string
+		webhookID   string
+		body        string
+	}
+	requests := make(chan request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- request{
+			contentType: r.Header.Get("Content-Type"),
+			apiKey:      r.Header.Get("X-Api-Key"),
+			webhookID:   r.Header.Get(model.OutgoingWebhookIdHeader),
+			body:        string(body),
+		}
+	}))
+	defer server.Close()
+
+	hook, appErr := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+		ChannelId:       th.BasicChannel.Id,
+		TeamId:          th.BasicTeam.Id,
+		CreatorId:       th.BasicUser.Id,
+		CallbackURLs:    []string{server.URL},
+		TriggerWords:    []string{"deploy"},
+		PayloadTemplate: `{"summary": {{json .Post.Message}}, "source": "{{.Team.Name}}/{{.Channel.Name}}", "author": "{{upper .User.Username}}", "trigger": "{{.TriggerWord}}"}`,
+		Headers:         model.StringMap{"X-Api-Key": "secret"},
+	})
+	require.Nil(t, appErr)
+
+	post := th.CreatePost(th.BasicChannel)
+	post.Message = `deploy "api"`
+	payload := &model.OutgoingWebhookPayload{Token: hook.Token, TeamId: th.BasicTeam.Id, ChannelId: th.BasicChannel.Id, PostId: post.Id, TriggerWord: "deploy"}
+	th.App.TriggerWebhook(th.Context, payload, hook, post, th.BasicChannel)
+
+	select {
+	case r := <-requests:
+		assert.Equal(t, "application/json", r.contentType)
+		assert.Equal(t, "secret", r.apiKey)
+		assert.Equal(t, hook.Id, r.webhookID)
+
+		var body map[string]string
+		require.NoError(t, json.Unmarshal([]byte(r.body), &body))
+		assert.Equal(t, map[string]string{
+			"summary": `deploy "api"`,
+			"source":  th.BasicTeam.Name + "/" + th.BasicChannel.Name,
+			"author":  strings.ToUpper(th.BasicUser.Username),
+			"trigger": "deploy",
+		}, body)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the webhook was not sent")
+	}
+}
+
+func TestCreateOutgoingWebhookWithPayloadTemplate(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+	})
+
+	newHook := func() *model.OutgoingWebhook {
+		return &model.OutgoingWebhook{
+			ChannelId:    th.BasicChannel.Id,
+			TeamId:       th.BasicTeam.Id,
+			CreatorId:    th.BasicUser.Id,
+			CallbackURLs: []string{"http://nowhere.com/" + model.NewId()},
+			TriggerWords: []string{model.NewId()},
+		}
+	}
+
+	t.Run("invalid template", func(t *testing.T) {
+		hook := newHook()
+		hook.PayloadTemplate = `{"text": {{.Post.Unknown}}}`
+		_, appErr := th.App.CreateOutgoingWebhook(hook)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.outgoing_hook.is_valid.payload_template.app_error", appErr.Id)
+	})
+
+	t.Run("reserved header", func(t *testing.T) {
+		hook := newHook()
+		hook.Headers = model.StringMap{"content-type": "text/plain"}
+		_, appErr := th.App.CreateOutgoingWebhook(hook)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.outgoing_hook.is_valid.header_name.app_error", appErr.Id)
+	})
+
+	t.Run("invalid template on update", func(t *testing.T) {
+		hook, appErr := th.App.CreateOutgoingWebhook(newHook())
+		require.Nil(t, appErr)
+
+		updated := *hook
+		updated.PayloadTemplate = `{{if}}`
+		_, appErr = th.App.UpdateOutgoingWebhook(th.Context, hook, &updated)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.outgoing_hook.is_valid.payload_template.app_error", appErr.Id)
+	})
+
+	t.Run("render", func(t *testing.T) {
+		hook := newHook()
+		hook.TriggerWords = []string{"ship"}
+		hook.PayloadTemplate = `{"text": {{json .Post.Message}}, "user": "{{.User.Username}}", "channel": "{{.Channel.Name}}"}`
+		hook.Headers = model.StringMap{"X-Api-Key": "secret"}
+
+		rendered, appErr := th.App.RenderOutgoingWebhookPayload(th.Context, hook, th.BasicUser)
+		require.Nil(t, appErr)
+		assert.Equal(t, "application/json", rendered.ContentType)
+		assert.Equal(t, map[string]string{"X-Api-Key": "secret"}, rendered.Headers)
+
+		var body map[string]string
+		require.NoError(t, json.Unmarshal([]byte(rendered.Payload), &body))
+		assert.True(t, strings.HasPrefix(body["text"], "ship "))
+		assert.Equal(t, th.BasicUser.Username, body["user"])
+		assert.Equal(t, th.BasicChannel.Name, body["channel"])
+	})
+}
+
 func TestDoOutgoingWebhookRequest(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
@@ -921,13 +1075,21 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
 	})
 
+	doOutgoingWebhookRequest := func(url string, accessToken *model.OutgoingOAuthConnectionToken) (*model.OutgoingWebhookResponse, error) {
+		result, err := th.App.doOutgoingWebhookRequest(url, strings.NewReader(""), "application/json", nil, accessToken)
+		if err != nil {
+			return nil, err
+		}
+		return result.decode()
+	}
+
 	t.Run("with a valid response", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			io.Copy(w, strings.NewReader(`{"text": "Hello, World!"}`))
 		}))
 		defer server.Close()
 
-		resp, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json", nil)
+		resp, err := doOutgoingWebhookRequest(server.URL, nil)
 		require.NoError(t, err)
 
 		require.NotNil(t, resp)
@@ -941,7 +1103,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json", nil)
+		_, err := doOutgoingWebhookRequest(server.URL, nil)
 		require.Error(t, err)
 		require.Equal(t, "api.unmarshal_error", err.(*model.AppError).Id)
 	})
@@ -952,7 +1114,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json", nil)
+		_, err := doOutgoingWebhookRequest(server.URL, nil)
 		require.Error(t, err)
 		require.Equal(t, "api.unmarshal_error", err.(*model.AppError).Id)
 	})
@@ -963,7 +1125,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json", nil)
+		_, err := doOutgoingWebhookRequest(server.URL, nil)
 		require.Error(t, err)
 		require.Equal(t, "api.unmarshal_error", err.(*model.AppError).Id)
 	})
@@ -982,7 +1144,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 			cfg.ServiceSettings.OutgoingIntegrationRequestsTimeout = model.NewPointer(int64(1))
 		})
 
-		_, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json", nil)
+		_, err := doOutgoingWebhookRequest(server.URL, nil)
 		require.Error(t, err)
 		require.IsType(t, &url.Error{}, err)
 	})
@@ -999,7 +1161,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 			cfg.ServiceSettings.OutgoingIntegrationRequestsTimeout = model.NewPointer(int64(2))
 		})
 
-		resp, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json", nil)
+		resp, err := doOutgoingWebhookRequest(server.URL, nil)
 		require.NoError(t, err)
 		require.NotNil(t, resp)
 		assert.NotNil(t, resp.Text)
@@ -1011,7 +1173,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		resp, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json", nil)
+		resp, err := doOutgoingWebhookRequest(server.URL, nil)
 		require.NoError(t, err)
 		require.Nil(t, resp)
 	})
@@ -1022,7 +1184,7 @@ func TestDoOutgoingWebhookRequest(t *testing.T) {
 		}))
 		defer server.Close()
 
-		resp, err := th.App.doOutgoingWebhookRequest(server.URL, strings.NewReader(""), "application/json", &model.OutgoingOAuthConnectionToken{
+		resp, err := doOutgoingWebhookRequest(server.URL, &model.OutgoingOAuthConnectionToken{
 			AccessToken: "test",
 			TokenType:   "Bearer",
 		})
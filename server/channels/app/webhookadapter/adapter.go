@@ -0,0 +1,124 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package webhookadapter converts the native payloads of third party services, such as GitHub or Alertmanager, to
+// incoming webhook requests, so that these services can post to Mattermost without a translation proxy.
+package webhookadapter
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	colorGood    = "#2EB886"
+	colorWarning = "#DAA038"
+	colorDanger  = "#A30200"
+	colorInfo    = "#439FE0"
+	colorNeutral = "#808080"
+
+	// maxListedItems is the number of commits, alerts or events listed in a single post.
+	maxListedItems = 10
+)
+
+var (
+	// ErrInvalidSignature is returned when a payload isn't signed with the secret of the webhook.
+	ErrInvalidSignature = errors.New("invalid payload signature")
+
+	// ErrIgnoredEvent is returned for events that don't need to be posted, such as GitHub pings.
+	ErrIgnoredEvent = errors.New("ignored event")
+)
+
+// Adapter verifies and converts the payloads sent by a service.
+type Adapter interface {
+	// Verify checks that the payload was sent by the service, using the secret of the webhook. Payloads are only
+	// verified when the webhook has a secret.
+	Verify(header http.Header, body []byte, secret string) error
+
+	// Convert converts the payload to an incoming webhook request.
+	Convert(header http.Header, body []byte) (*model.IncomingWebhookRequest, error)
+}
+
+var adapters = map[string]Adapter{
+	model.IncomingWebhookFormatGitHub:       &gitHubAdapter{},
+	model.IncomingWebhookFormatGitLab:       &gitLabAdapter{},
+	model.IncomingWebhookFormatAlertmanager: &alertmanagerAdapter{},
+	model.IncomingWebhookFormatCloudEvents:  &cloudEventsAdapter{},
+}
+
+// Get returns the adapter for a payload format of an incoming webhook.
+func Get(format string) (Adapter, bool) {
+	adapter, ok := adapters[format]
+	return adapter, ok
+}
+
+// Parse verifies and converts a payload in the given format.
+func Parse(format string, header http.Header, body []byte, secret string) (*model.IncomingWebhookRequest, error) {
+	adapter, ok := Get(format)
+	if !ok {
+		return nil, fmt.Errorf("unknown payload format %q", format)
+	}
+
+	if secret != "" {
+		if err := adapter.Verify(header, body, secret); err != nil {
+			return nil, err
+		}
+	}
+
+	return adapter.Convert(header, body)
+}
+
+// verifyBearerToken checks that the request is authorized with the secret as a bearer token, which is how
+// Alertmanager and most CloudEvents producers authenticate.
+func verifyBearerToken(header http.Header, secret string) error {
+	token, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// link formats a Markdown link, or only the text when there is no URL.
+func link(text, url string) string {
+	if url == "" {
+		return text
+	}
+
+	return fmt.Sprintf("[%s](%s)", escapeLinkText(text), url)
+}
+
+func escapeLinkText(text string) string {
+	return strings.NewReplacer("[", "\\[", "]", "\\]").Replace(text)
+}
+
+// firstLine returns the first line of a message, such as the summary of a commit message.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(line)
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+
+	return sha
+}
+
+func plural(count int, singular, plural string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, singular)
+	}
+
+	return fmt.Sprintf("%d %s", count, plural)
+}
+
+func shortField(title string, value string) *model.SlackAttachmentField {
+	return &model.SlackAttachmentField{Title: title, Value: value, Short: true}
+}
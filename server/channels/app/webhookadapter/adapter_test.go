@@ -0,0 +1,59 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webhookadapter
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestParse(t *testing.T) {
+	body := []byte(`{"status": "firing", "alerts": [{"status": "firing", "labels": {"alertname": "HighLatency"}}]}`)
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := Parse("slack", http.Header{}, body, "")
+		require.Error(t, err)
+	})
+
+	t.Run("without a secret", func(t *testing.T) {
+		req, err := Parse(model.IncomingWebhookFormatAlertmanager, http.Header{}, body, "")
+		require.NoError(t, err)
+		require.Len(t, req.Attachments, 1)
+	})
+
+	t.Run("with a secret", func(t *testing.T) {
+		_, err := Parse(model.IncomingWebhookFormatAlertmanager, http.Header{}, body, "secret")
+		require.ErrorIs(t, err, ErrInvalidSignature)
+
+		_, err = Parse(model.IncomingWebhookFormatAlertmanager, http.Header{"Authorization": {"Bearer other"}}, body, "secret")
+		require.ErrorIs(t, err, ErrInvalidSignature)
+
+		req, err := Parse(model.IncomingWebhookFormatAlertmanager, http.Header{"Authorization": {"Bearer secret"}}, body, "secret")
+		require.NoError(t, err)
+		require.Len(t, req.Attachments, 1)
+	})
+
+	t.Run("every format has an adapter", func(t *testing.T) {
+		for _, format := range []string{
+			model.IncomingWebhookFormatGitHub,
+			model.IncomingWebhookFormatGitLab,
+			model.IncomingWebhookFormatAlertmanager,
+			model.IncomingWebhookFormatCloudEvents,
+		} {
+			_, ok := Get(format)
+			assert.True(t, ok, format)
+		}
+	})
+}
+
+func TestLink(t *testing.T) {
+	assert.Equal(t, "text", link("text", ""))
+	assert.Equal(t, "[text](https://example.com)", link("text", "https://example.com"))
+	assert.Equal(t, `[\[bot\] text](https://example.com)`, link("[bot] text", "https://example.com"))
+}
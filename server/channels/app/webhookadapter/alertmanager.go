@@ -0,0 +1,161 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webhookadapter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+type alertmanagerAdapter struct{}
+
+type alertmanagerAlert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+}
+
+type alertmanagerPayload struct {
+	Version           string              `json:"version"`
+	Status            string              `json:"status"`
+	Receiver          string              `json:"receiver"`
+	GroupLabels       map[string]string   `json:"groupLabels"`
+	CommonLabels      map[string]string   `json:"commonLabels"`
+	CommonAnnotations map[string]string   `json:"commonAnnotations"`
+	ExternalURL       string              `json:"externalURL"`
+	Alerts            []alertmanagerAlert `json:"alerts"`
+}
+
+// Verify checks the bearer token configured in the http_config of the Alertmanager receiver.
+func (*alertmanagerAdapter) Verify(header http.Header, body []byte, secret string) error {
+	return verifyBearerToken(header, secret)
+}
+
+func (*alertmanagerAdapter) Convert(header http.Header, body []byte) (*model.IncomingWebhookRequest, error) {
+	var payload alertmanagerPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	if payload.Status == "" || len(payload.Alerts) == 0 {
+		return nil, fmt.Errorf("not an Alertmanager notification")
+	}
+
+	firing := 0
+	for _, alert := range payload.Alerts {
+		if alert.Status == "firing" {
+			firing++
+		}
+	}
+
+	group := formatLabels(payload.GroupLabels)
+	if group == "" {
+		group = payload.Receiver
+	}
+	text := fmt.Sprintf("**[%s]** %s", strings.ToUpper(payload.Status), group)
+	if firing > 0 {
+		text += fmt.Sprintf(" - %s firing", plural(firing, "alert", "alerts"))
+	}
+	if resolved := len(payload.Alerts) - firing; resolved > 0 {
+		text += fmt.Sprintf(" - %s resolved", plural(resolved, "alert", "alerts"))
+	}
+
+	attachments := make([]*model.SlackAttachment, 0, min(len(payload.Alerts), maxListedItems))
+	for i, alert := range payload.Alerts {
+		if i == maxListedItems {
+			text += fmt.Sprintf("\n%s not shown", plural(len(payload.Alerts)-maxListedItems, "more alert", "more alerts"))
+			break
+		}
+		attachments = append(attachments, alertAttachment(alert))
+	}
+
+	if payload.ExternalURL != "" {
+		text += fmt.Sprintf("\n%s", link("Open Alertmanager", payload.ExternalURL))
+	}
+
+	return &model.IncomingWebhookRequest{Text: text, Attachments: attachments}, nil
+}
+
+func alertAttachment(alert alertmanagerAlert) *model.SlackAttachment {
+	name := alert.Labels["alertname"]
+	if name == "" {
+		name = "Alert"
+	}
+
+	color := colorDanger
+	switch {
+	case alert.Status == "resolved":
+		color = colorGood
+	case alert.Labels["severity"] == "warning":
+		color = colorWarning
+	case alert.Labels["severity"] == "info":
+		color = colorInfo
+	}
+
+	text := alert.Annotations["description"]
+	if text == "" {
+		text = alert.Annotations["message"]
+	}
+
+	fields := []*model.SlackAttachmentField{}
+	if severity := alert.Labels["severity"]; severity != "" {
+		fields = append(fields, shortField("Severity", severity))
+	}
+	if !alert.StartsAt.IsZero() {
+		fields = append(fields, shortField("Started", alert.StartsAt.UTC().Format(time.RFC1123)))
+	}
+	if alert.Status == "resolved" && !alert.EndsAt.IsZero() {
+		fields = append(fields, shortField("Resolved", alert.EndsAt.UTC().Format(time.RFC1123)))
+	}
+
+	labels := make(map[string]string, len(alert.Labels))
+	for key, value := range alert.Labels {
+		if key != "alertname" && key != "severity" {
+			labels[key] = value
+		}
+	}
+	if formatted := formatLabels(labels); formatted != "" {
+		fields = append(fields, &model.SlackAttachmentField{Title: "Labels", Value: formatted})
+	}
+
+	title := name
+	if summary := alert.Annotations["summary"]; summary != "" {
+		title = fmt.Sprintf("%s: %s", name, summary)
+	}
+
+	return &model.SlackAttachment{
+		Fallback:  fmt.Sprintf("[%s] %s", strings.ToUpper(alert.Status), title),
+		Color:     color,
+		Title:     title,
+		TitleLink: alert.GeneratorURL,
+		Text:      text,
+		Fields:    fields,
+		Footer:    "Alertmanager",
+	}
+}
+
+// formatLabels formats labels sorted by name, such as `env="prod", job="api"`.
+func formatLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", key, labels[key]))
+	}
+
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webhookadapter
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertmanagerConvert(t *testing.T) {
+	adapter := &alertmanagerAdapter{}
+
+	t.Run("not a notification", func(t *testing.T) {
+		_, err := adapter.Convert(http.Header{}, []byte(`{"text": "hello"}`))
+		require.Error(t, err)
+	})
+
+	t.Run("firing and resolved alerts", func(t *testing.T) {
+		req, err := adapter.Convert(http.Header{}, []byte(`{
+			"version": "4",
+			"status": "firing",
+			"receiver": "mattermost",
+			"groupLabels": {"alertname": "HighLatency"},
+			"externalURL": "https://alertmanager.example.com",
+			"alerts": [
+				{
+					"status": "firing",
+					"labels": {"alertname": "HighLatency", "severity": "warning", "job": "api", "env": "prod"},
+					"annotations": {"summary": "Latency is high", "description": "p99 is above 1s"},
+					"startsAt": "2024-01-02T03:04:05Z",
+					"generatorURL": "https://prometheus.example.com/graph"
+				},
+				{
+					"status": "resolved",
+					"labels": {"alertname": "HighLatency", "job": "web"},
+					"startsAt": "2024-01-02T03:04:05Z",
+					"endsAt": "2024-01-02T04:04:05Z"
+				}
+			]
+		}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, "**[FIRING]** alertname=\"HighLatency\" - 1 alert firing - 1 alert resolved\n[Open Alertmanager](https://alertmanager.example.com)", req.Text)
+		require.Len(t, req.Attachments, 2)
+
+		firing := req.Attachments[0]
+		assert.Equal(t, colorWarning, firing.Color)
+		assert.Equal(t, "HighLatency: Latency is high", firing.Title)
+		assert.Equal(t, "https://prometheus.example.com/graph", firing.TitleLink)
+		assert.Equal(t, "p99 is above 1s", firing.Text)
+		assert.Equal(t, `env="prod", job="api"`, firing.Fields[len(firing.Fields)-1].Value)
+
+		resolved := req.Attachments[1]
+		assert.Equal(t, colorGood, resolved.Color)
+		assert.Equal(t, "Resolved", resolved.Fields[1].Title)
+	})
+
+	t.Run("too many alerts", func(t *testing.T) {
+		alerts := make([]string, maxListedItems+2)
+		for i := range alerts {
+			alerts[i] = fmt.Sprintf(`{"status": "firing", "labels": {"alertname": "Alert%d"}}`, i)
+		}
+
+		req, err := adapter.Convert(http.Header{}, []byte(`{"status": "firing", "alerts": [`+strings.Join(alerts, ",")+`]}`))
+		require.NoError(t, err)
+		assert.Len(t, req.Attachments, maxListedItems)
+		assert.Contains(t, req.Text, "2 more alerts not shown")
+	})
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webhookadapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	cloudEventsContentType      = "application/cloudevents+json"
+	cloudEventsBatchContentType = "application/cloudevents-batch+json"
+
+	// cloudEventsHeaderPrefix prefixes the attributes of events sent in binary mode, such as ce-type.
+	cloudEventsHeaderPrefix = "Ce-"
+
+	// maxCloudEventDataLength is how much of the data of an event is shown in its post.
+	maxCloudEventDataLength = 2000
+)
+
+type cloudEventsAdapter struct{}
+
+type cloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject"`
+	Time            string          `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+	DataBase64      string          `json:"data_base64"`
+}
+
+// Verify checks the bearer token sent with the events.
+func (*cloudEventsAdapter) Verify(header http.Header, body []byte, secret string) error {
+	return verifyBearerToken(header, secret)
+}
+
+// Convert accepts events in structured mode, batched mode or binary mode, where the attributes of the event are sent
+// as headers and the body is its data.
+func (*cloudEventsAdapter) Convert(header http.Header, body []byte) (*model.IncomingWebhookRequest, error) {
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+
+	var events []*cloudEvent
+	switch {
+	case mediaType == cloudEventsBatchContentType:
+		if err := json.Unmarshal(body, &events); err != nil {
+			return nil, err
+		}
+	case mediaType == cloudEventsContentType:
+		var event cloudEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	case header.Get(cloudEventsHeaderPrefix+"Specversion") != "":
+		events = append(events, &cloudEvent{
+			SpecVersion:     header.Get(cloudEventsHeaderPrefix + "Specversion"),
+			ID:              header.Get(cloudEventsHeaderPrefix + "Id"),
+			Source:          header.Get(cloudEventsHeaderPrefix + "Source"),
+			Type:            header.Get(cloudEventsHeaderPrefix + "Type"),
+			Subject:         header.Get(cloudEventsHeaderPrefix + "Subject"),
+			Time:            header.Get(cloudEventsHeaderPrefix + "Time"),
+			DataContentType: mediaType,
+			Data:            body,
+		})
+	default:
+		return nil, fmt.Errorf("not a CloudEvent")
+	}
+
+	if len(events) == 0 {
+		return nil, fmt.Errorf("no events")
+	}
+
+	attachments := make([]*model.SlackAttachment, 0, min(len(events), maxListedItems))
+	for i, event := range events {
+		if event == nil || event.SpecVersion == "" || event.Type == "" || event.Source == "" {
+			return nil, fmt.Errorf("missing required attributes of event %d", i)
+		}
+		if i == maxListedItems {
+			break
+		}
+		attachments = append(attachments, cloudEventAttachment(event))
+	}
+
+	request := &model.IncomingWebhookRequest{Attachments: attachments}
+	if len(events) > maxListedItems {
+		request.Text = fmt.Sprintf("Received %s, showing the first %d.", plural(len(events), "event", "events"), maxListedItems)
+	}
+
+	return request, nil
+}
+
+func cloudEventAttachment(event *cloudEvent) *model.SlackAttachment {
+	fields := []*model.SlackAttachmentField{shortField("Source", event.Source)}
+	if event.Subject != "" {
+		fields = append(fields, shortField("Subject", event.Subject))
+	}
+	if event.Time != "" {
+		fields = append(fields, shortField("Time", event.Time))
+	}
+
+	text := ""
+	if data := formatCloudEventData(event); data != "" {
+		text = "```\n" + data + "\n```"
+	}
+
+	return &model.SlackAttachment{
+		Fallback: fmt.Sprintf("%s from %s", event.Type, event.Source),
+		Color:    colorInfo,
+		Title:    event.Type,
+		Text:     text,
+		Fields:   fields,
+		Footer:   "CloudEvents " + event.ID,
+	}
+}
+
+// formatCloudEventData returns the data of the event for display, indented when it's JSON.
+func formatCloudEventData(event *cloudEvent) string {
+	var data string
+	switch {
+	case event.DataBase64 != "":
+		return "(binary data)"
+	case len(event.Data) == 0:
+		return ""
+	case json.Valid(event.Data) && (event.DataContentType == "" || strings.HasSuffix(event.DataContentType, "json")):
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, event.Data, "", "  "); err != nil {
+			data = string(event.Data)
+		} else {
+			data = buf.String()
+		}
+	default:
+		data = string(event.Data)
+	}
+
+	data = strings.ReplaceAll(data, "```", "'''")
+	if len(data) > maxCloudEventDataLength {
+		end := maxCloudEventDataLength
+		for end > 0 && !utf8.RuneStart(data[end]) {
+			end--
+		}
+		data = data[:end] + "\n..."
+	}
+
+	return data
+}
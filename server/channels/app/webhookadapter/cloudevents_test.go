@@ -0,0 +1,79 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webhookadapter
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudEventsConvert(t *testing.T) {
+	adapter := &cloudEventsAdapter{}
+
+	t.Run("structured mode", func(t *testing.T) {
+		req, err := adapter.Convert(http.Header{"Content-Type": {"application/cloudevents+json; charset=utf-8"}}, []byte(`{
+			"specversion": "1.0",
+			"id": "A234-1234-1234",
+			"source": "/mycontext",
+			"type": "com.example.someevent",
+			"subject": "larger-context",
+			"data": {"appinfoA": "abc"}
+		}`))
+		require.NoError(t, err)
+		require.Len(t, req.Attachments, 1)
+
+		attachment := req.Attachments[0]
+		assert.Equal(t, "com.example.someevent", attachment.Title)
+		assert.Equal(t, "```\n{\n  \"appinfoA\": \"abc\"\n}\n```", attachment.Text)
+		assert.Equal(t, "/mycontext", attachment.Fields[0].Value)
+		assert.Equal(t, "larger-context", attachment.Fields[1].Value)
+	})
+
+	t.Run("binary mode", func(t *testing.T) {
+		header := http.Header{
+			"Content-Type":   {"text/plain"},
+			"Ce-Specversion": {"1.0"},
+			"Ce-Id":          {"1"},
+			"Ce-Source":      {"/mycontext"},
+			"Ce-Type":        {"com.example.someevent"},
+		}
+		req, err := adapter.Convert(header, []byte("hello"))
+		require.NoError(t, err)
+		assert.Equal(t, "```\nhello\n```", req.Attachments[0].Text)
+	})
+
+	t.Run("batch mode", func(t *testing.T) {
+		req, err := adapter.Convert(http.Header{"Content-Type": {"application/cloudevents-batch+json"}}, []byte(`[
+			{"specversion": "1.0", "id": "1", "source": "/a", "type": "com.example.first"},
+			{"specversion": "1.0", "id": "2", "source": "/b", "type": "com.example.second"}
+		]`))
+		require.NoError(t, err)
+		require.Len(t, req.Attachments, 2)
+		assert.Equal(t, "com.example.second", req.Attachments[1].Title)
+	})
+
+	t.Run("missing attributes", func(t *testing.T) {
+		_, err := adapter.Convert(http.Header{"Content-Type": {"application/cloudevents+json"}}, []byte(`{"specversion": "1.0", "id": "1"}`))
+		require.Error(t, err)
+
+		_, err = adapter.Convert(http.Header{"Content-Type": {"application/cloudevents-batch+json"}}, []byte(`[null]`))
+		require.Error(t, err)
+	})
+
+	t.Run("not an event", func(t *testing.T) {
+		_, err := adapter.Convert(http.Header{"Content-Type": {"application/json"}}, []byte(`{}`))
+		require.Error(t, err)
+	})
+
+	t.Run("long data", func(t *testing.T) {
+		data := formatCloudEventData(&cloudEvent{DataContentType: "text/plain", Data: []byte(strings.Repeat("é", maxCloudEventDataLength))})
+		assert.True(t, utf8.ValidString(data))
+		assert.True(t, strings.HasSuffix(data, "\n..."))
+	})
+}
@@ -0,0 +1,303 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webhookadapter
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	gitHubEventHeader     = "X-GitHub-Event"
+	gitHubSignatureHeader = "X-Hub-Signature-256"
+)
+
+type gitHubAdapter struct{}
+
+type gitHubUser struct {
+	Login     string `json:"login"`
+	HTMLURL   string `json:"html_url"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type gitHubRepository struct {
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+}
+
+type gitHubCommit struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	URL     string `json:"url"`
+	Author  struct {
+		Name string `json:"name"`
+	} `json:"author"`
+}
+
+type gitHubIssue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	State   string `json:"state"`
+	Merged  bool   `json:"merged"`
+}
+
+type gitHubPayload struct {
+	Action     string           `json:"action"`
+	Sender     gitHubUser       `json:"sender"`
+	Repository gitHubRepository `json:"repository"`
+
+	// push
+	Ref     string         `json:"ref"`
+	Compare string         `json:"compare"`
+	Created bool           `json:"created"`
+	Deleted bool           `json:"deleted"`
+	Forced  bool           `json:"forced"`
+	Commits []gitHubCommit `json:"commits"`
+
+	// pull_request, issues and issue_comment
+	PullRequest *gitHubIssue `json:"pull_request"`
+	Issue       *gitHubIssue `json:"issue"`
+	Comment     *struct {
+		Body    string `json:"body"`
+		HTMLURL string `json:"html_url"`
+	} `json:"comment"`
+
+	// release
+	Release *struct {
+		TagName string `json:"tag_name"`
+		Name    string `json:"name"`
+		Body    string `json:"body"`
+		HTMLURL string `json:"html_url"`
+	} `json:"release"`
+
+	// workflow_run
+	WorkflowRun *struct {
+		Name       string `json:"name"`
+		HeadBranch string `json:"head_branch"`
+		Conclusion string `json:"conclusion"`
+		HTMLURL    string `json:"html_url"`
+	} `json:"workflow_run"`
+}
+
+// Verify checks the HMAC-SHA256 signature GitHub sends in the X-Hub-Signature-256 header.
+func (*gitHubAdapter) Verify(header http.Header, body []byte, secret string) error {
+	signature, ok := strings.CutPrefix(header.Get(gitHubSignatureHeader), "sha256=")
+	if !ok {
+		return ErrInvalidSignature
+	}
+
+	actual, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(actual, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (a *gitHubAdapter) Convert(header http.Header, body []byte) (*model.IncomingWebhookRequest, error) {
+	event := header.Get(gitHubEventHeader)
+	if event == "ping" {
+		return nil, ErrIgnoredEvent
+	}
+
+	var payload gitHubPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	var attachment *model.SlackAttachment
+	switch event {
+	case "push":
+		attachment = a.push(&payload)
+	case "pull_request":
+		attachment = a.issue(&payload, payload.PullRequest, "pull request")
+	case "issues":
+		attachment = a.issue(&payload, payload.Issue, "issue")
+	case "issue_comment":
+		attachment = a.comment(&payload)
+	case "release":
+		attachment = a.release(&payload)
+	case "workflow_run":
+		// Only the outcome of runs is worth a post
+		if payload.Action != "completed" {
+			return nil, ErrIgnoredEvent
+		}
+		attachment = a.workflowRun(&payload)
+	}
+
+	if attachment == nil {
+		if event == "" {
+			return nil, fmt.Errorf("missing %s header", gitHubEventHeader)
+		}
+
+		// Events without a dedicated format are still reported, so that subscribing to them isn't silently useless
+		attachment = &model.SlackAttachment{
+			Color: colorNeutral,
+			Text:  fmt.Sprintf("%s %s", strings.TrimSpace(event+" "+payload.Action), link(payload.Repository.FullName, payload.Repository.HTMLURL)),
+		}
+	}
+
+	attachment.AuthorName = payload.Sender.Login
+	attachment.AuthorLink = payload.Sender.HTMLURL
+	attachment.AuthorIcon = payload.Sender.AvatarURL
+	attachment.Footer = "GitHub"
+	if attachment.Fallback == "" {
+		attachment.Fallback = fmt.Sprintf("[%s] %s %s", payload.Repository.FullName, payload.Sender.Login, strings.TrimSpace(event+" "+payload.Action))
+	}
+
+	return &model.IncomingWebhookRequest{Attachments: []*model.SlackAttachment{attachment}}, nil
+}
+
+func (*gitHubAdapter) push(payload *gitHubPayload) *model.SlackAttachment {
+	branch := strings.TrimPrefix(strings.TrimPrefix(payload.Ref, "refs/heads/"), "refs/tags/")
+	branchURL := payload.Repository.HTMLURL + "/tree/" + branch
+	repository := link(payload.Repository.FullName, payload.Repository.HTMLURL)
+
+	switch {
+	case payload.Deleted:
+		return &model.SlackAttachment{
+			Color: colorNeutral,
+			Text:  fmt.Sprintf("Deleted `%s` in %s", branch, repository),
+		}
+	case payload.Created && len(payload.Commits) == 0:
+		return &model.SlackAttachment{
+			Color: colorInfo,
+			Text:  fmt.Sprintf("Created %s in %s", link(branch, branchURL), repository),
+		}
+	}
+
+	verb := "Pushed"
+	if payload.Forced {
+		verb = "Force-pushed"
+	}
+
+	lines := []string{fmt.Sprintf("%s %s to %s in %s", verb, link(plural(len(payload.Commits), "commit", "commits"), payload.Compare), link(branch, branchURL), repository)}
+	for i, commit := range payload.Commits {
+		if i == maxListedItems {
+			lines = append(lines, fmt.Sprintf("and %d more", len(payload.Commits)-maxListedItems))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("- %s %s - %s", link("`"+shortSHA(commit.ID)+"`", commit.URL), firstLine(commit.Message), commit.Author.Name))
+	}
+
+	return &model.SlackAttachment{
+		Color: colorInfo,
+		Text:  strings.Join(lines, "\n"),
+	}
+}
+
+func (*gitHubAdapter) issue(payload *gitHubPayload, issue *gitHubIssue, kind string) *model.SlackAttachment {
+	if issue == nil {
+		return nil
+	}
+
+	action := payload.Action
+	color := colorInfo
+	switch {
+	case action == "closed" && issue.Merged:
+		action = "merged"
+		color = colorGood
+	case action == "closed":
+		color = colorDanger
+	case action == "opened" || action == "reopened":
+		color = colorGood
+	}
+
+	attachment := &model.SlackAttachment{
+		Color:     color,
+		Pretext:   fmt.Sprintf("%s %s %s in %s", capitalize(action), kind, link(fmt.Sprintf("#%d", issue.Number), issue.HTMLURL), link(payload.Repository.FullName, payload.Repository.HTMLURL)),
+		Title:     fmt.Sprintf("#%d %s", issue.Number, issue.Title),
+		TitleLink: issue.HTMLURL,
+	}
+	if action == "opened" {
+		attachment.Text = issue.Body
+	}
+
+	return attachment
+}
+
+func (*gitHubAdapter) comment(payload *gitHubPayload) *model.SlackAttachment {
+	if payload.Issue == nil || payload.Comment == nil {
+		return nil
+	}
+
+	return &model.SlackAttachment{
+		Color:     colorNeutral,
+		Pretext:   fmt.Sprintf("%s comment on %s in %s", capitalize(payload.Action), link(fmt.Sprintf("#%d", payload.Issue.Number), payload.Comment.HTMLURL), link(payload.Repository.FullName, payload.Repository.HTMLURL)),
+		Title:     fmt.Sprintf("#%d %s", payload.Issue.Number, payload.Issue.Title),
+		TitleLink: payload.Comment.HTMLURL,
+		Text:      payload.Comment.Body,
+	}
+}
+
+func (*gitHubAdapter) release(payload *gitHubPayload) *model.SlackAttachment {
+	if payload.Release == nil {
+		return nil
+	}
+
+	name := payload.Release.Name
+	if name == "" {
+		name = payload.Release.TagName
+	}
+
+	attachment := &model.SlackAttachment{
+		Color:     colorGood,
+		Pretext:   fmt.Sprintf("%s release in %s", capitalize(payload.Action), link(payload.Repository.FullName, payload.Repository.HTMLURL)),
+		Title:     name,
+		TitleLink: payload.Release.HTMLURL,
+	}
+	if payload.Action == "published" {
+		attachment.Text = payload.Release.Body
+	}
+
+	return attachment
+}
+
+func (*gitHubAdapter) workflowRun(payload *gitHubPayload) *model.SlackAttachment {
+	run := payload.WorkflowRun
+	if run == nil {
+		return nil
+	}
+
+	color := colorNeutral
+	switch run.Conclusion {
+	case "success":
+		color = colorGood
+	case "failure", "timed_out":
+		color = colorDanger
+	case "cancelled", "action_required":
+		color = colorWarning
+	}
+
+	return &model.SlackAttachment{
+		Color:     color,
+		Pretext:   fmt.Sprintf("Workflow run in %s", link(payload.Repository.FullName, payload.Repository.HTMLURL)),
+		Title:     fmt.Sprintf("%s: %s", run.Name, run.Conclusion),
+		TitleLink: run.HTMLURL,
+		Fields:    []*model.SlackAttachmentField{shortField("Branch", run.HeadBranch)},
+	}
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + strings.ReplaceAll(s[1:], "_", " ")
+}
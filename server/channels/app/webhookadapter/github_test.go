@@ -0,0 +1,104 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webhookadapter
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubVerify(t *testing.T) {
+	adapter := &gitHubAdapter{}
+	body := []byte(`{"zen": "Keep it logically awesome."}`)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	require.NoError(t, adapter.Verify(http.Header{gitHubSignatureHeader: {signature}}, body, "secret"))
+	require.ErrorIs(t, adapter.Verify(http.Header{gitHubSignatureHeader: {signature}}, body, "other"), ErrInvalidSignature)
+	require.ErrorIs(t, adapter.Verify(http.Header{gitHubSignatureHeader: {signature}}, []byte(`{}`), "secret"), ErrInvalidSignature)
+	require.ErrorIs(t, adapter.Verify(http.Header{gitHubSignatureHeader: {"sha256=zz"}}, body, "secret"), ErrInvalidSignature)
+	require.ErrorIs(t, adapter.Verify(http.Header{}, body, "secret"), ErrInvalidSignature)
+}
+
+func TestGitHubConvert(t *testing.T) {
+	adapter := &gitHubAdapter{}
+	header := func(event string) http.Header {
+		h := http.Header{}
+		h.Set(gitHubEventHeader, event)
+		return h
+	}
+
+	t.Run("ping", func(t *testing.T) {
+		_, err := adapter.Convert(header("ping"), []byte(`{"zen": "Design for failure."}`))
+		require.ErrorIs(t, err, ErrIgnoredEvent)
+	})
+
+	t.Run("missing event", func(t *testing.T) {
+		_, err := adapter.Convert(http.Header{}, []byte(`{}`))
+		require.Error(t, err)
+	})
+
+	t.Run("push", func(t *testing.T) {
+		req, err := adapter.Convert(header("push"), []byte(`{
+			"ref": "refs/heads/main",
+			"compare": "https://github.com/octo/repo/compare/a...b",
+			"repository": {"full_name": "octo/repo", "html_url": "https://github.com/octo/repo"},
+			"sender": {"login": "octocat"},
+			"commits": [{"id": "0123456789abcdef", "message": "Fix the build\n\nDetails", "url": "https://github.com/octo/repo/commit/0123456", "author": {"name": "Octo Cat"}}]
+		}`))
+		require.NoError(t, err)
+		require.Len(t, req.Attachments, 1)
+
+		attachment := req.Attachments[0]
+		assert.Equal(t, "octocat", attachment.AuthorName)
+		assert.Contains(t, attachment.Text, "Pushed [1 commit](https://github.com/octo/repo/compare/a...b) to [main](https://github.com/octo/repo/tree/main)")
+		assert.Contains(t, attachment.Text, "[`0123456`](https://github.com/octo/repo/commit/0123456) Fix the build - Octo Cat")
+		assert.NotContains(t, attachment.Text, "Details")
+	})
+
+	t.Run("merged pull request", func(t *testing.T) {
+		req, err := adapter.Convert(header("pull_request"), []byte(`{
+			"action": "closed",
+			"repository": {"full_name": "octo/repo"},
+			"pull_request": {"number": 42, "title": "Add feature", "html_url": "https://github.com/octo/repo/pull/42", "merged": true}
+		}`))
+		require.NoError(t, err)
+
+		attachment := req.Attachments[0]
+		assert.Equal(t, colorGood, attachment.Color)
+		assert.Equal(t, "Merged pull request [#42](https://github.com/octo/repo/pull/42) in octo/repo", attachment.Pretext)
+		assert.Equal(t, "#42 Add feature", attachment.Title)
+	})
+
+	t.Run("workflow run in progress", func(t *testing.T) {
+		_, err := adapter.Convert(header("workflow_run"), []byte(`{"action": "requested", "workflow_run": {"name": "CI"}}`))
+		require.ErrorIs(t, err, ErrIgnoredEvent)
+	})
+
+	t.Run("failed workflow run", func(t *testing.T) {
+		req, err := adapter.Convert(header("workflow_run"), []byte(`{"action": "completed", "workflow_run": {"name": "CI", "conclusion": "failure", "head_branch": "main"}}`))
+		require.NoError(t, err)
+		assert.Equal(t, colorDanger, req.Attachments[0].Color)
+		assert.Equal(t, "CI: failure", req.Attachments[0].Title)
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		req, err := adapter.Convert(header("star"), []byte(`{"action": "created", "repository": {"full_name": "octo/repo"}}`))
+		require.NoError(t, err)
+		assert.Equal(t, "star created octo/repo", req.Attachments[0].Text)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := adapter.Convert(header("push"), []byte(`not json`))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webhookadapter
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const gitLabTokenHeader = "X-Gitlab-Token"
+
+type gitLabAdapter struct{}
+
+type gitLabProject struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebURL            string `json:"web_url"`
+}
+
+type gitLabUser struct {
+	Name      string `json:"name"`
+	Username  string `json:"username"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type gitLabPayload struct {
+	ObjectKind string        `json:"object_kind"`
+	Project    gitLabProject `json:"project"`
+	User       gitLabUser    `json:"user"`
+
+	// push and tag_push
+	Ref               string `json:"ref"`
+	After             string `json:"after"`
+	UserName          string `json:"user_name"`
+	UserUsername      string `json:"user_username"`
+	UserAvatar        string `json:"user_avatar"`
+	TotalCommitsCount int    `json:"total_commits_count"`
+	Commits           []struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+		URL     string `json:"url"`
+		Author  struct {
+			Name string `json:"name"`
+		} `json:"author"`
+	} `json:"commits"`
+
+	// merge_request, issue, note and pipeline
+	ObjectAttributes struct {
+		IID          int    `json:"iid"`
+		Title        string `json:"title"`
+		Description  string `json:"description"`
+		URL          string `json:"url"`
+		Action       string `json:"action"`
+		State        string `json:"state"`
+		Note         string `json:"note"`
+		NoteableType string `json:"noteable_type"`
+		Ref          string `json:"ref"`
+		Status       string `json:"status"`
+		ID           int64  `json:"id"`
+		Duration     int64  `json:"duration"`
+	} `json:"object_attributes"`
+
+	MergeRequest *struct {
+		IID   int    `json:"iid"`
+		Title string `json:"title"`
+	} `json:"merge_request"`
+	Issue *struct {
+		IID   int    `json:"iid"`
+		Title string `json:"title"`
+	} `json:"issue"`
+}
+
+// Verify checks the secret token GitLab sends in the X-Gitlab-Token header.
+func (*gitLabAdapter) Verify(header http.Header, body []byte, secret string) error {
+	if subtle.ConstantTimeCompare([]byte(header.Get(gitLabTokenHeader)), []byte(secret)) != 1 {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (a *gitLabAdapter) Convert(header http.Header, body []byte) (*model.IncomingWebhookRequest, error) {
+	var payload gitLabPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	var attachment *model.SlackAttachment
+	switch payload.ObjectKind {
+	case "push", "tag_push":
+		attachment = a.push(&payload)
+	case "merge_request":
+		attachment = a.issue(&payload, "merge request", "!")
+	case "issue":
+		attachment = a.issue(&payload, "issue", "#")
+	case "note":
+		attachment = a.note(&payload)
+	case "pipeline":
+		// Only the outcome of pipelines is worth a post
+		switch payload.ObjectAttributes.Status {
+		case "success", "failed", "canceled":
+		default:
+			return nil, ErrIgnoredEvent
+		}
+		attachment = a.pipeline(&payload)
+	case "":
+		return nil, fmt.Errorf("missing object_kind")
+	default:
+		attachment = &model.SlackAttachment{
+			Color: colorNeutral,
+			Text:  fmt.Sprintf("%s %s", strings.ReplaceAll(payload.ObjectKind, "_", " "), link(payload.Project.PathWithNamespace, payload.Project.WebURL)),
+		}
+	}
+
+	// Push events describe the user with flat fields
+	user := payload.User
+	if user.Username == "" {
+		user = gitLabUser{Name: payload.UserName, Username: payload.UserUsername, AvatarURL: payload.UserAvatar}
+	}
+
+	attachment.AuthorName = user.Username
+	attachment.AuthorIcon = user.AvatarURL
+	attachment.Footer = "GitLab"
+	attachment.Fallback = fmt.Sprintf("[%s] %s %s", payload.Project.PathWithNamespace, user.Username, strings.ReplaceAll(payload.ObjectKind, "_", " "))
+
+	return &model.IncomingWebhookRequest{Attachments: []*model.SlackAttachment{attachment}}, nil
+}
+
+func (*gitLabAdapter) push(payload *gitLabPayload) *model.SlackAttachment {
+	ref := strings.TrimPrefix(strings.TrimPrefix(payload.Ref, "refs/heads/"), "refs/tags/")
+	refURL := payload.Project.WebURL + "/-/tree/" + ref
+	project := link(payload.Project.PathWithNamespace, payload.Project.WebURL)
+
+	// GitLab sends an all zero commit as the new head of deleted branches and tags
+	if strings.Trim(payload.After, "0") == "" {
+		return &model.SlackAttachment{
+			Color: colorNeutral,
+			Text:  fmt.Sprintf("Deleted `%s` in %s", ref, project),
+		}
+	}
+
+	if payload.ObjectKind == "tag_push" {
+		return &model.SlackAttachment{
+			Color: colorGood,
+			Text:  fmt.Sprintf("Pushed tag %s to %s", link(ref, refURL), project),
+		}
+	}
+
+	lines := []string{fmt.Sprintf("Pushed %s to %s in %s", plural(payload.TotalCommitsCount, "commit", "commits"), link(ref, refURL), project)}
+	for i, commit := range payload.Commits {
+		if i == maxListedItems {
+			lines = append(lines, fmt.Sprintf("and %d more", len(payload.Commits)-maxListedItems))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("- %s %s - %s", link("`"+shortSHA(commit.ID)+"`", commit.URL), firstLine(commit.Message), commit.Author.Name))
+	}
+
+	return &model.SlackAttachment{
+		Color: colorInfo,
+		Text:  strings.Join(lines, "\n"),
+	}
+}
+
+func (*gitLabAdapter) issue(payload *gitLabPayload, kind, prefix string) *model.SlackAttachment {
+	attributes := payload.ObjectAttributes
+
+	action := attributes.Action
+	color := colorInfo
+	switch action {
+	case "open", "reopen":
+		action += "ed"
+		color = colorGood
+	case "merge":
+		action = "merged"
+		color = colorGood
+	case "close":
+		action = "closed"
+		color = colorDanger
+	case "update":
+		action = "updated"
+	case "approved", "unapproved":
+	case "":
+		action = attributes.State
+	}
+
+	attachment := &model.SlackAttachment{
+		Color:     color,
+		Pretext:   fmt.Sprintf("%s %s %s in %s", capitalize(action), kind, link(fmt.Sprintf("%s%d", prefix, attributes.IID), attributes.URL), link(payload.Project.PathWithNamespace, payload.Project.WebURL)),
+		Title:     fmt.Sprintf("%s%d %s", prefix, attributes.IID, attributes.Title),
+		TitleLink: attributes.URL,
+	}
+	if action == "opened" {
+		attachment.Text = attributes.Description
+	}
+
+	return attachment
+}
+
+func (*gitLabAdapter) note(payload *gitLabPayload) *model.SlackAttachment {
+	attributes := payload.ObjectAttributes
+
+	target := strings.ToLower(attributes.NoteableType)
+	title := ""
+	switch {
+	case payload.MergeRequest != nil:
+		target = fmt.Sprintf("merge request !%d", payload.MergeRequest.IID)
+		title = fmt.Sprintf("!%d %s", payload.MergeRequest.IID, payload.MergeRequest.Title)
+	case payload.Issue != nil:
+		target = fmt.Sprintf("issue #%d", payload.Issue.IID)
+		title = fmt.Sprintf("#%d %s", payload.Issue.IID, payload.Issue.Title)
+	}
+
+	return &model.SlackAttachment{
+		Color:     colorNeutral,
+		Pretext:   fmt.Sprintf("Commented on %s in %s", link(target, attributes.URL), link(payload.Project.PathWithNamespace, payload.Project.WebURL)),
+		Title:     title,
+		TitleLink: attributes.URL,
+		Text:      attributes.Note,
+	}
+}
+
+func (*gitLabAdapter) pipeline(payload *gitLabPayload) *model.SlackAttachment {
+	attributes := payload.ObjectAttributes
+
+	color := colorWarning
+	switch attributes.Status {
+	case "success":
+		color = colorGood
+	case "failed":
+		color = colorDanger
+	}
+
+	pipelineURL := fmt.Sprintf("%s/-/pipelines/%d", payload.Project.WebURL, attributes.ID)
+	return &model.SlackAttachment{
+		Color:     color,
+		Pretext:   fmt.Sprintf("Pipeline in %s", link(payload.Project.PathWithNamespace, payload.Project.WebURL)),
+		Title:     fmt.Sprintf("Pipeline #%d: %s", attributes.ID, attributes.Status),
+		TitleLink: pipelineURL,
+		Fields: []*model.SlackAttachmentField{
+			shortField("Ref", attributes.Ref),
+			shortField("Duration", fmt.Sprintf("%ds", attributes.Duration)),
+		},
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webhookadapter
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitLabVerify(t *testing.T) {
+	adapter := &gitLabAdapter{}
+
+	require.NoError(t, adapter.Verify(http.Header{gitLabTokenHeader: {"secret"}}, nil, "secret"))
+	require.ErrorIs(t, adapter.Verify(http.Header{gitLabTokenHeader: {"other"}}, nil, "secret"), ErrInvalidSignature)
+	require.ErrorIs(t, adapter.Verify(http.Header{}, nil, "secret"), ErrInvalidSignature)
+}
+
+func TestGitLabConvert(t *testing.T) {
+	adapter := &gitLabAdapter{}
+
+	t.Run("missing object kind", func(t *testing.T) {
+		_, err := adapter.Convert(http.Header{}, []byte(`{}`))
+		require.Error(t, err)
+	})
+
+	t.Run("push", func(t *testing.T) {
+		req, err := adapter.Convert(http.Header{}, []byte(`{
+			"object_kind": "push",
+			"ref": "refs/heads/main",
+			"after": "da1560886d4f094c3e6c9ef40349f7d38b5d27d7",
+			"user_username": "jsmith",
+			"total_commits_count": 2,
+			"project": {"path_with_namespace": "group/project", "web_url": "https://gitlab.example.com/group/project"},
+			"commits": [
+				{"id": "b6568db1bc1dcd7f8b4d5a946b0b91f9dacd7327", "message": "Update README", "url": "https://gitlab.example.com/c/1", "author": {"name": "Jordi"}},
+				{"id": "da1560886d4f094c3e6c9ef40349f7d38b5d27d7", "message": "Fix typo", "url": "https://gitlab.example.com/c/2", "author": {"name": "Jordi"}}
+			]
+		}`))
+		require.NoError(t, err)
+
+		attachment := req.Attachments[0]
+		assert.Equal(t, "jsmith", attachment.AuthorName)
+		assert.Contains(t, attachment.Text, "Pushed 2 commits to [main](https://gitlab.example.com/group/project/-/tree/main) in [group/project](https://gitlab.example.com/group/project)")
+		assert.Contains(t, attachment.Text, "[`da15608`](https://gitlab.example.com/c/2) Fix typo - Jordi")
+	})
+
+	t.Run("deleted branch", func(t *testing.T) {
+		req, err := adapter.Convert(http.Header{}, []byte(`{"object_kind": "push", "ref": "refs/heads/old", "after": "0000000000000000000000000000000000000000", "project": {"path_with_namespace": "group/project"}}`))
+		require.NoError(t, err)
+		assert.Equal(t, "Deleted `old` in group/project", req.Attachments[0].Text)
+	})
+
+	t.Run("merged merge request", func(t *testing.T) {
+		req, err := adapter.Convert(http.Header{}, []byte(`{
+			"object_kind": "merge_request",
+			"user": {"username": "jsmith"},
+			"project": {"path_with_namespace": "group/project"},
+			"object_attributes": {"iid": 7, "title": "Add feature", "url": "https://gitlab.example.com/mr/7", "action": "merge"}
+		}`))
+		require.NoError(t, err)
+
+		attachment := req.Attachments[0]
+		assert.Equal(t, colorGood, attachment.Color)
+		assert.Equal(t, "Merged merge request [!7](https://gitlab.example.com/mr/7) in group/project", attachment.Pretext)
+		assert.Equal(t, "!7 Add feature", attachment.Title)
+	})
+
+	t.Run("running pipeline", func(t *testing.T) {
+		_, err := adapter.Convert(http.Header{}, []byte(`{"object_kind": "pipeline", "object_attributes": {"status": "running"}}`))
+		require.ErrorIs(t, err, ErrIgnoredEvent)
+	})
+
+	t.Run("failed pipeline", func(t *testing.T) {
+		req, err := adapter.Convert(http.Header{}, []byte(`{
+			"object_kind": "pipeline",
+			"project": {"path_with_namespace": "group/project", "web_url": "https://gitlab.example.com/group/project"},
+			"object_attributes": {"id": 31, "ref": "main", "status": "failed", "duration": 63}
+		}`))
+		require.NoError(t, err)
+
+		attachment := req.Attachments[0]
+		assert.Equal(t, colorDanger, attachment.Color)
+		assert.Equal(t, "Pipeline #31: failed", attachment.Title)
+		assert.Equal(t, "https://gitlab.example.com/group/project/-/pipelines/31", attachment.TitleLink)
+	})
+}
@@ -0,0 +1,61 @@
+CREATE TABLE IF NOT EXISTS OutgoingWebhookDeliveries (
+	Id varchar(26) NOT NULL,
+	HookId varchar(26) NOT NULL,
+	PostId varchar(26),
+	ChannelId varchar(26),
+	CallbackURL varchar(1024),
+	ContentType varchar(128),
+	Payload mediumtext,
+	Status varchar(32) NOT NULL,
+	Attempts int DEFAULT 0,
+	StatusCode int DEFAULT 0,
+	Latency bigint(20) DEFAULT 0,
+	Error varchar(1024),
+	ResponseExcerpt varchar(1024),
+	CreateAt bigint(20),
+	UpdateAt bigint(20),
+	NextAttemptAt bigint(20) DEFAULT 0,
+	PRIMARY KEY (Id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'OutgoingWebhookDeliveries'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_outgoingwebhookdeliveries_hookid_createat'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_outgoingwebhookdeliveries_hookid_createat ON OutgoingWebhookDeliveries (HookId, CreateAt);'
+));
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'OutgoingWebhookDeliveries'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_outgoingwebhookdeliveries_status_nextattemptat'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_outgoingwebhookdeliveries_status_nextattemptat ON OutgoingWebhookDeliveries (Status, NextAttemptAt);'
+));
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'OutgoingWebhookDeliveries'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_outgoingwebhookdeliveries_createat'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_outgoingwebhookdeliveries_createat ON OutgoingWebhookDeliveries (CreateAt);'
+));
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
@@ -0,0 +1 @@
+-- The Postgres search engine is only available when running on Postgres.
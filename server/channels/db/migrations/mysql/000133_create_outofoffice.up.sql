@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS OutOfOffice (
+	UserId varchar(26) NOT NULL,
+	StartAt bigint(20) NOT NULL,
+	EndAt bigint(20) NOT NULL,
+	DMMessage text,
+	MentionMessage text,
+	DelegateUserId varchar(26),
+	ExcludeBots tinyint(1) DEFAULT 0,
+	ExcludedUserIds text,
+	Active tinyint(1) DEFAULT 0,
+	CreateAt bigint(20),
+	UpdateAt bigint(20),
+	PRIMARY KEY (UserId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'OutOfOffice'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_outofoffice_startat'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_outofoffice_startat ON OutOfOffice (StartAt);'
+));
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'OutOfOffice'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_outofoffice_endat'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_outofoffice_endat ON OutOfOffice (EndAt);'
+));
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS PersistentNotificationEscalations (
+    Id varchar(26) NOT NULL,
+    ChannelId varchar(26) NOT NULL,
+    AfterCount int NOT NULL,
+    Type varchar(32) NOT NULL,
+    GroupId varchar(26) DEFAULT NULL,
+    FallbackChannelId varchar(26) DEFAULT NULL,
+    CreatorId varchar(26) NOT NULL,
+    CreateAt bigint(20) DEFAULT NULL,
+    UpdateAt bigint(20) DEFAULT NULL,
+    DeleteAt bigint(20) DEFAULT NULL,
+    PRIMARY KEY (Id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+SET @preparedStatement = (SELECT IF(
+	(
+		SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE table_name = 'PersistentNotificationEscalations'
+		AND table_schema = DATABASE()
+		AND index_name = 'idx_persistentnotificationescalations_channelid'
+	) > 0,
+	'SELECT 1',
+	'CREATE INDEX idx_persistentnotificationescalations_channelid ON PersistentNotificationEscalations (ChannelId);'
+));
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
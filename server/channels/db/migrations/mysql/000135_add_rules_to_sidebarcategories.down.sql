@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'SidebarCategories'
+        AND table_schema = DATABASE()
+        AND column_name = 'Rules'
+    ) > 0,
+    'ALTER TABLE SidebarCategories DROP COLUMN Rules;',
+    'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
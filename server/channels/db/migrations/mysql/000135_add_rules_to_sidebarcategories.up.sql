@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'SidebarCategories'
+        AND table_schema = DATABASE()
+        AND column_name = 'Rules'
+    ) > 0,
+    'SELECT 1',
+    'ALTER TABLE SidebarCategories ADD Rules text;'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
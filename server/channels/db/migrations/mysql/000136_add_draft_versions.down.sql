@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS DraftRevisions;
+
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Drafts'
+        AND table_schema = DATABASE()
+        AND column_name = 'Version'
+    ) > 0,
+    'ALTER TABLE Drafts DROP COLUMN Version;',
+    'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
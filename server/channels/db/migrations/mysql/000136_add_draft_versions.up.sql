@@ -0,0 +1,28 @@
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Drafts'
+        AND table_schema = DATABASE()
+        AND column_name = 'Version'
+    ) > 0,
+    'SELECT 1',
+    'ALTER TABLE Drafts ADD Version bigint(20) DEFAULT 0;'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
+
+CREATE TABLE IF NOT EXISTS DraftRevisions (
+    UserId varchar(26) NOT NULL,
+    ChannelId varchar(26) NOT NULL,
+    RootId varchar(26) NOT NULL DEFAULT '',
+    Version bigint(20) NOT NULL,
+    CreateAt bigint(20) DEFAULT NULL,
+    UpdateAt bigint(20) DEFAULT NULL,
+    Message text,
+    Props text,
+    FileIds text,
+    Priority text,
+    PRIMARY KEY (UserId, ChannelId, RootId, Version)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
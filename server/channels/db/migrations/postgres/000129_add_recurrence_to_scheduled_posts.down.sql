@@ -0,0 +1,2 @@
+ALTER TABLE scheduledposts DROP COLUMN IF EXISTS pausedat;
+ALTER TABLE scheduledposts DROP COLUMN IF EXISTS recurrence;
@@ -0,0 +1,2 @@
+ALTER TABLE scheduledposts ADD COLUMN IF NOT EXISTS recurrence text;
+ALTER TABLE scheduledposts ADD COLUMN IF NOT EXISTS pausedat bigint DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE outgoingwebhooks ADD COLUMN IF NOT EXISTS signingsecret VARCHAR(64) DEFAULT '';
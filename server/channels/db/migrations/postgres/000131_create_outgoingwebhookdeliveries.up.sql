@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS outgoingwebhookdeliveries (
+	id VARCHAR(26) PRIMARY KEY,
+	hookid VARCHAR(26) NOT NULL,
+	postid VARCHAR(26),
+	channelid VARCHAR(26),
+	callbackurl VARCHAR(1024),
+	contenttype VARCHAR(128),
+	payload text,
+	status VARCHAR(32) NOT NULL,
+	attempts integer DEFAULT 0,
+	statuscode integer DEFAULT 0,
+	latency bigint DEFAULT 0,
+	error VARCHAR(1024),
+	responseexcerpt VARCHAR(1024),
+	createat bigint,
+	updateat bigint,
+	nextattemptat bigint DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_outgoingwebhookdeliveries_hookid_createat ON outgoingwebhookdeliveries (hookid, createat);
+CREATE INDEX IF NOT EXISTS idx_outgoingwebhookdeliveries_status_nextattemptat ON outgoingwebhookdeliveries (status, nextattemptat);
+CREATE INDEX IF NOT EXISTS idx_outgoingwebhookdeliveries_createat ON outgoingwebhookdeliveries (createat);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS searchchannels;
+DROP TABLE IF EXISTS searchusers;
+DROP TABLE IF EXISTS searchfiles;
+DROP TABLE IF EXISTS searchposts;
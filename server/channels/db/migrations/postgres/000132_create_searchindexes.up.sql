@@ -0,0 +1,58 @@
+CREATE TABLE IF NOT EXISTS searchposts (
+	id VARCHAR(26) PRIMARY KEY,
+	teamid VARCHAR(26),
+	channelid VARCHAR(26),
+	userid VARCHAR(26),
+	createat bigint,
+	type VARCHAR(26),
+	message text,
+	hashtags text,
+	messagevector tsvector,
+	hashtagsvector tsvector
+);
+
+CREATE INDEX IF NOT EXISTS idx_searchposts_channelid_createat ON searchposts (channelid, createat);
+CREATE INDEX IF NOT EXISTS idx_searchposts_userid ON searchposts (userid);
+CREATE INDEX IF NOT EXISTS idx_searchposts_messagevector ON searchposts USING gin (messagevector);
+CREATE INDEX IF NOT EXISTS idx_searchposts_hashtagsvector ON searchposts USING gin (hashtagsvector);
+
+CREATE TABLE IF NOT EXISTS searchfiles (
+	id VARCHAR(26) PRIMARY KEY,
+	channelid VARCHAR(26),
+	creatorid VARCHAR(26),
+	postid VARCHAR(26),
+	createat bigint,
+	name text,
+	content text,
+	extension VARCHAR(64),
+	searchvector tsvector
+);
+
+CREATE INDEX IF NOT EXISTS idx_searchfiles_channelid_createat ON searchfiles (channelid, createat);
+CREATE INDEX IF NOT EXISTS idx_searchfiles_creatorid ON searchfiles (creatorid);
+CREATE INDEX IF NOT EXISTS idx_searchfiles_postid ON searchfiles (postid);
+CREATE INDEX IF NOT EXISTS idx_searchfiles_searchvector ON searchfiles USING gin (searchvector);
+
+CREATE TABLE IF NOT EXISTS searchusers (
+	id VARCHAR(26) PRIMARY KEY,
+	teamsids text[],
+	channelsids text[],
+	suggestionswithfullname text[],
+	suggestionswithoutfullname text[]
+);
+
+CREATE INDEX IF NOT EXISTS idx_searchusers_teamsids ON searchusers USING gin (teamsids);
+CREATE INDEX IF NOT EXISTS idx_searchusers_channelsids ON searchusers USING gin (channelsids);
+
+CREATE TABLE IF NOT EXISTS searchchannels (
+	id VARCHAR(26) PRIMARY KEY,
+	teamid VARCHAR(26),
+	type VARCHAR(1),
+	userids text[],
+	teammemberids text[],
+	namesuggest text[]
+);
+
+CREATE INDEX IF NOT EXISTS idx_searchchannels_teamid ON searchchannels (teamid);
+CREATE INDEX IF NOT EXISTS idx_searchchannels_userids ON searchchannels USING gin (userids);
+CREATE INDEX IF NOT EXISTS idx_searchchannels_teammemberids ON searchchannels USING gin (teammemberids);
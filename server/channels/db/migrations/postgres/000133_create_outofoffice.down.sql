@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_outofoffice_startat;
+DROP INDEX IF EXISTS idx_outofoffice_endat;
+DROP TABLE IF EXISTS outofoffice;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS outofoffice (
+	userid VARCHAR(26) PRIMARY KEY,
+	startat bigint NOT NULL,
+	endat bigint NOT NULL,
+	dmmessage text,
+	mentionmessage text,
+	delegateuserid VARCHAR(26),
+	excludebots boolean DEFAULT false,
+	excludeduserids text,
+	active boolean DEFAULT false,
+	createat bigint,
+	updateat bigint
+);
+
+CREATE INDEX IF NOT EXISTS idx_outofoffice_startat ON outofoffice (startat);
+CREATE INDEX IF NOT EXISTS idx_outofoffice_endat ON outofoffice (endat);
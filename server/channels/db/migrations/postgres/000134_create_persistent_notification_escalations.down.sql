@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_persistentnotificationescalations_channelid;
+DROP TABLE IF EXISTS persistentnotificationescalations;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS persistentnotificationescalations (
+    id VARCHAR(26) PRIMARY KEY,
+    channelid VARCHAR(26) NOT NULL,
+    aftercount integer NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    groupid VARCHAR(26),
+    fallbackchannelid VARCHAR(26),
+    creatorid VARCHAR(26) NOT NULL,
+    createat bigint,
+    updateat bigint,
+    deleteat bigint
+);
+
+CREATE INDEX IF NOT EXISTS idx_persistentnotificationescalations_channelid ON persistentnotificationescalations (channelid);
@@ -0,0 +1 @@
+ALTER TABLE sidebarcategories DROP COLUMN IF EXISTS rules;
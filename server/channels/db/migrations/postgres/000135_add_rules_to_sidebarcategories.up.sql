@@ -0,0 +1 @@
+ALTER TABLE sidebarcategories ADD COLUMN IF NOT EXISTS rules text;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS draftrevisions;
+
+ALTER TABLE drafts DROP COLUMN IF EXISTS version;
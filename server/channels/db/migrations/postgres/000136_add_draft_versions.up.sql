@@ -0,0 +1,15 @@
+ALTER TABLE drafts ADD COLUMN IF NOT EXISTS version bigint DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS draftrevisions (
+    userid VARCHAR(26) NOT NULL,
+    channelid VARCHAR(26) NOT NULL,
+    rootid VARCHAR(26) NOT NULL DEFAULT '',
+    version bigint NOT NULL,
+    createat bigint,
+    updateat bigint,
+    message VARCHAR(65535),
+    props VARCHAR(8000),
+    fileids VARCHAR(300),
+    priority text,
+    PRIMARY KEY (userid, channelid, rootid, version)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE outgoingwebhooks DROP COLUMN IF EXISTS headers;
+ALTER TABLE outgoingwebhooks DROP COLUMN IF EXISTS payloadtemplate;
@@ -0,0 +1,2 @@
+ALTER TABLE outgoingwebhooks ADD COLUMN IF NOT EXISTS payloadtemplate text DEFAULT '';
+ALTER TABLE outgoingwebhooks ADD COLUMN IF NOT EXISTS headers text;
@@ -0,0 +1,2 @@
+ALTER TABLE incomingwebhooks DROP COLUMN IF EXISTS secret;
+ALTER TABLE incomingwebhooks DROP COLUMN IF EXISTS payloadformat;
@@ -0,0 +1,2 @@
+ALTER TABLE incomingwebhooks ADD COLUMN IF NOT EXISTS payloadformat VARCHAR(32) DEFAULT '';
+ALTER TABLE incomingwebhooks ADD COLUMN IF NOT EXISTS secret VARCHAR(128) DEFAULT '';
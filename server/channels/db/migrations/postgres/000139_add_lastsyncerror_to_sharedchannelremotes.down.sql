@@ -0,0 +1,2 @@
+ALTER TABLE sharedchannelremotes DROP COLUMN IF EXISTS lastsyncerrorat;
+ALTER TABLE sharedchannelremotes DROP COLUMN IF EXISTS lastsyncerror;
@@ -0,0 +1,2 @@
+ALTER TABLE sharedchannelremotes ADD COLUMN IF NOT EXISTS lastsyncerror VARCHAR(1024) DEFAULT '';
+ALTER TABLE sharedchannelremotes ADD COLUMN IF NOT EXISTS lastsyncerrorat bigint DEFAULT 0;
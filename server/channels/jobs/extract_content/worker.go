@@ -11,6 +11,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/services/docextractor"
 )
 
 var ignoredFiles = map[string]bool{
@@ -64,8 +65,9 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface, store store.Store) *job
 			if len(fileInfos) == 0 {
 				break
 			}
+			ocrEnabled := *jobServer.Config().FileSettings.OCRServiceURL != ""
 			for _, fileInfo := range fileInfos {
-				if !ignoredFiles[fileInfo.Extension] {
+				if !ignoredFiles[fileInfo.Extension] || (ocrEnabled && docextractor.IsOCRSupportedExtension(fileInfo.Extension)) {
 					logger.Debug("Extracting file", mlog.String("filename", fileInfo.Name), mlog.String("filepath", fileInfo.Path))
 
 					err = app.ExtractContentFromFileInfo(request.EmptyContext(logger), fileInfo)
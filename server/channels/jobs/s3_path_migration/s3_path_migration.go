@@ -35,6 +35,9 @@ type S3PathMigrationWorker struct {
 }
 
 func MakeWorker(jobServer *jobs.JobServer, store store.Store, fileBackend filestore.FileBackend) *S3PathMigrationWorker {
+	if cachedBackend, ok := fileBackend.(*filestore.CachedFileBackend); ok {
+		fileBackend = cachedBackend.Unwrap()
+	}
 	// If the type cast fails, it will be nil
 	// which is checked later.
 	s3Backend, _ := fileBackend.(*filestore.S3FileBackend)
@@ -19,52 +19,54 @@ import (
 
 type OpenTracingLayer struct {
 	store.Store
-	AuditStore                      store.AuditStore
-	BotStore                        store.BotStore
-	ChannelStore                    store.ChannelStore
-	ChannelBookmarkStore            store.ChannelBookmarkStore
-	ChannelMemberHistoryStore       store.ChannelMemberHistoryStore
-	ClusterDiscoveryStore           store.ClusterDiscoveryStore
-	CommandStore                    store.CommandStore
-	CommandWebhookStore             store.CommandWebhookStore
-	ComplianceStore                 store.ComplianceStore
-	DesktopTokensStore              store.DesktopTokensStore
-	DraftStore                      store.DraftStore
-	EmojiStore                      store.EmojiStore
-	FileInfoStore                   store.FileInfoStore
-	GroupStore                      store.GroupStore
-	JobStore                        store.JobStore
-	LicenseStore                    store.LicenseStore
-	LinkMetadataStore               store.LinkMetadataStore
-	NotifyAdminStore                store.NotifyAdminStore
-	OAuthStore                      store.OAuthStore
-	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
-	PluginStore                     store.PluginStore
-	PostStore                       store.PostStore
-	PostAcknowledgementStore        store.PostAcknowledgementStore
-	PostPersistentNotificationStore store.PostPersistentNotificationStore
-	PostPriorityStore               store.PostPriorityStore
-	PreferenceStore                 store.PreferenceStore
-	ProductNoticesStore             store.ProductNoticesStore
-	ReactionStore                   store.ReactionStore
-	RemoteClusterStore              store.RemoteClusterStore
-	RetentionPolicyStore            store.RetentionPolicyStore
-	RoleStore                       store.RoleStore
-	ScheduledPostStore              store.ScheduledPostStore
-	SchemeStore                     store.SchemeStore
-	SessionStore                    store.SessionStore
-	SharedChannelStore              store.SharedChannelStore
-	StatusStore                     store.StatusStore
-	SystemStore                     store.SystemStore
-	TeamStore                       store.TeamStore
-	TermsOfServiceStore             store.TermsOfServiceStore
-	ThreadStore                     store.ThreadStore
-	TokenStore                      store.TokenStore
-	UploadSessionStore              store.UploadSessionStore
-	UserStore                       store.UserStore
-	UserAccessTokenStore            store.UserAccessTokenStore
-	UserTermsOfServiceStore         store.UserTermsOfServiceStore
-	WebhookStore                    store.WebhookStore
+	AuditStore                            store.AuditStore
+	BotStore                              store.BotStore
+	ChannelStore                          store.ChannelStore
+	ChannelBookmarkStore                  store.ChannelBookmarkStore
+	ChannelMemberHistoryStore             store.ChannelMemberHistoryStore
+	ClusterDiscoveryStore                 store.ClusterDiscoveryStore
+	CommandStore                          store.CommandStore
+	CommandWebhookStore                   store.CommandWebhookStore
+	ComplianceStore                       store.ComplianceStore
+	DesktopTokensStore                    store.DesktopTokensStore
+	DraftStore                            store.DraftStore
+	EmojiStore                            store.EmojiStore
+	FileInfoStore                         store.FileInfoStore
+	GroupStore                            store.GroupStore
+	JobStore                              store.JobStore
+	LicenseStore                          store.LicenseStore
+	LinkMetadataStore                     store.LinkMetadataStore
+	NotifyAdminStore                      store.NotifyAdminStore
+	OAuthStore                            store.OAuthStore
+	OutOfOfficeStore                      store.OutOfOfficeStore
+	OutgoingOAuthConnectionStore          store.OutgoingOAuthConnectionStore
+	PersistentNotificationEscalationStore store.PersistentNotificationEscalationStore
+	PluginStore                           store.PluginStore
+	PostStore                             store.PostStore
+	PostAcknowledgementStore              store.PostAcknowledgementStore
+	PostPersistentNotificationStore       store.PostPersistentNotificationStore
+	PostPriorityStore                     store.PostPriorityStore
+	PreferenceStore                       store.PreferenceStore
+	ProductNoticesStore                   store.ProductNoticesStore
+	ReactionStore                         store.ReactionStore
+	RemoteClusterStore                    store.RemoteClusterStore
+	RetentionPolicyStore                  store.RetentionPolicyStore
+	RoleStore                             store.RoleStore
+	ScheduledPostStore                    store.ScheduledPostStore
+	SchemeStore                           store.SchemeStore
+	SessionStore                          store.SessionStore
+	SharedChannelStore                    store.SharedChannelStore
+	StatusStore                           store.StatusStore
+	SystemStore                           store.SystemStore
+	TeamStore                             store.TeamStore
+	TermsOfServiceStore                   store.TermsOfServiceStore
+	ThreadStore                           store.ThreadStore
+	TokenStore                            store.TokenStore
+	UploadSessionStore                    store.UploadSessionStore
+	UserStore                             store.UserStore
+	UserAccessTokenStore                  store.UserAccessTokenStore
+	UserTermsOfServiceStore               store.UserTermsOfServiceStore
+	WebhookStore                          store.WebhookStore
 }
 
 func (s *OpenTracingLayer) Audit() store.AuditStore {
@@ -143,10 +145,18 @@ func (s *OpenTracingLayer) OAuth() store.OAuthStore {
 	return s.OAuthStore
 }
 
+func (s *OpenTracingLayer) OutOfOffice() store.OutOfOfficeStore {
+	return s.OutOfOfficeStore
+}
+
 func (s *OpenTracingLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
 	return s.OutgoingOAuthConnectionStore
 }
 
+func (s *OpenTracingLayer) PersistentNotificationEscalation() store.PersistentNotificationEscalationStore {
+	return s.PersistentNotificationEscalationStore
+}
+
 func (s *OpenTracingLayer) Plugin() store.PluginStore {
 	return s.PluginStore
 }
@@ -346,11 +356,21 @@ type OpenTracingLayerOAuthStore struct {
 	Root *OpenTracingLayer
 }
 
+type OpenTracingLayerOutOfOfficeStore struct {
+	store.OutOfOfficeStore
+	Root *OpenTracingLayer
+}
+
 type OpenTracingLayerOutgoingOAuthConnectionStore struct {
 	store.OutgoingOAuthConnectionStore
 	Root *OpenTracingLayer
 }
 
+type OpenTracingLayerPersistentNotificationEscalationStore struct {
+	store.PersistentNotificationEscalationStore
+	Root *OpenTracingLayer
+}
+
 type OpenTracingLayerPluginStore struct {
 	store.PluginStore
 	Root *OpenTracingLayer
@@ -3684,6 +3704,42 @@ func (s *OpenTracingLayerDraftStore) GetLastCreateAtAndUserIdValuesForEmptyDraft
 	return result, resultVar1, err
 }
 
+func (s *OpenTracingLayerDraftStore) GetRevision(userID string, channelID string, rootID string, version int64) (*model.Draft, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "DraftStore.GetRevision")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.DraftStore.GetRevision(userID, channelID, rootID, version)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerDraftStore) GetRevisions(userID string, channelID string, rootID string) ([]*model.Draft, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "DraftStore.GetRevisions")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.DraftStore.GetRevisions(userID, channelID, rootID)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerDraftStore) Upsert(d *model.Draft) (*model.Draft, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "DraftStore.Upsert")
@@ -6068,6 +6124,96 @@ func (s *OpenTracingLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAut
 	return result, err
 }
 
+func (s *OpenTracingLayerOutOfOfficeStore) Delete(userID string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OutOfOfficeStore.Delete")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.OutOfOfficeStore.Delete(userID)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerOutOfOfficeStore) Get(userID string) (*model.OutOfOffice, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OutOfOfficeStore.Get")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.OutOfOfficeStore.Get(userID)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerOutOfOfficeStore) GetPendingTransitions(now int64, limit int) ([]*model.OutOfOffice, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OutOfOfficeStore.GetPendingTransitions")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.OutOfOfficeStore.GetPendingTransitions(now, limit)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerOutOfOfficeStore) Save(outOfOffice *model.OutOfOffice) (*model.OutOfOffice, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OutOfOfficeStore.Save")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.OutOfOfficeStore.Save(outOfOffice)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerOutOfOfficeStore) SetActive(userID string, active bool) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OutOfOfficeStore.SetActive")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.OutOfOfficeStore.SetActive(userID, active)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
 func (s *OpenTracingLayerOutgoingOAuthConnectionStore) DeleteConnection(c request.CTX, id string) error {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OutgoingOAuthConnectionStore.DeleteConnection")
@@ -6158,6 +6304,96 @@ func (s *OpenTracingLayerOutgoingOAuthConnectionStore) UpdateConnection(c reques
 	return result, err
 }
 
+func (s *OpenTracingLayerPersistentNotificationEscalationStore) Delete(id string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PersistentNotificationEscalationStore.Delete")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.PersistentNotificationEscalationStore.Delete(id)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerPersistentNotificationEscalationStore) DeleteByChannel(channelIds []string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PersistentNotificationEscalationStore.DeleteByChannel")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.PersistentNotificationEscalationStore.DeleteByChannel(channelIds)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerPersistentNotificationEscalationStore) Get(id string) (*model.PersistentNotificationEscalation, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PersistentNotificationEscalationStore.Get")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PersistentNotificationEscalationStore.Get(id)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPersistentNotificationEscalationStore) GetForChannels(channelIds []string) ([]*model.PersistentNotificationEscalation, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PersistentNotificationEscalationStore.GetForChannels")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PersistentNotificationEscalationStore.GetForChannels(channelIds)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPersistentNotificationEscalationStore) Save(escalation *model.PersistentNotificationEscalation) (*model.PersistentNotificationEscalation, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PersistentNotificationEscalationStore.Save")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PersistentNotificationEscalationStore.Save(escalation)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PluginStore.CompareAndDelete")
@@ -6405,6 +6641,24 @@ func (s *OpenTracingLayerPostStore) Delete(rctx request.CTX, postID string, time
 	return err
 }
 
+func (s *OpenTracingLayerPostStore) DeletePostReminder(postID string, userID string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.DeletePostReminder")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.PostStore.DeletePostReminder(postID, userID)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
 func (s *OpenTracingLayerPostStore) Get(ctx context.Context, id string, opts model.GetPostsOptions, userID string, sanitizeOptions map[string]bool) (*model.PostList, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.Get")
@@ -6709,6 +6963,24 @@ func (s *OpenTracingLayerPostStore) GetPostReminders(now int64) ([]*model.PostRe
 	return result, err
 }
 
+func (s *OpenTracingLayerPostStore) GetPostRemindersForUser(userID string) ([]*model.PostReminder, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetPostRemindersForUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PostStore.GetPostRemindersForUser(userID)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetPosts")
@@ -8270,6 +8542,24 @@ func (s *OpenTracingLayerRetentionPolicyStore) GetCount() (int64, error) {
 	return result, err
 }
 
+func (s *OpenTracingLayerRetentionPolicyStore) GetDryRun(policyID string, now int64, globalPolicyEndTime int64, globalFileEndTime int64) ([]*model.RetentionPolicyDryRunChannel, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.GetDryRun")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.RetentionPolicyStore.GetDryRun(policyID, now, globalPolicyEndTime, globalFileEndTime)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerRetentionPolicyStore) GetIdsForDeletionByTableName(tableName string, limit int) ([]*model.RetentionIdsForDeletion, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.GetIdsForDeletionByTableName")
@@ -8679,6 +8969,24 @@ func (s *OpenTracingLayerScheduledPostStore) GetPendingScheduledPosts(beforeTime
 	return result, err
 }
 
+func (s *OpenTracingLayerScheduledPostStore) GetScheduledPostSeriesForUser(userId string) ([]*model.ScheduledPost, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ScheduledPostStore.GetScheduledPostSeriesForUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.ScheduledPostStore.GetScheduledPostSeriesForUser(userId)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerScheduledPostStore) GetScheduledPostsForUser(userId string, teamId string) ([]*model.ScheduledPost, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ScheduledPostStore.GetScheduledPostsForUser")
@@ -9417,6 +9725,24 @@ func (s *OpenTracingLayerSharedChannelStore) GetRemotesStatus(channelID string)
 	return result, err
 }
 
+func (s *OpenTracingLayerSharedChannelStore) GetRemotesSyncStatus(channelID string) ([]*model.SharedChannelRemoteSyncStatus, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SharedChannelStore.GetRemotesSyncStatus")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.SharedChannelStore.GetRemotesSyncStatus(channelID)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerSharedChannelStore) GetSingleUser(userID string, channelID string, remoteID string) (*model.SharedChannelUser, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SharedChannelStore.GetSingleUser")
@@ -9651,6 +9977,24 @@ func (s *OpenTracingLayerSharedChannelStore) UpdateRemoteCursor(id string, curso
 	return err
 }
 
+func (s *OpenTracingLayerSharedChannelStore) UpdateRemoteSyncError(channelID string, remoteID string, syncErr string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SharedChannelStore.UpdateRemoteSyncError")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.SharedChannelStore.UpdateRemoteSyncError(channelID, remoteID, syncErr)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
 func (s *OpenTracingLayerSharedChannelStore) UpdateUserLastSyncAt(userID string, channelID string, remoteID string) error {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SharedChannelStore.UpdateUserLastSyncAt")
@@ -13556,6 +13900,60 @@ func (s *OpenTracingLayerWebhookStore) GetOutgoingByTeamByUser(teamID string, us
 	return result, err
 }
 
+func (s *OpenTracingLayerWebhookStore) GetOutgoingDeliveriesForHook(hookID string, offset int, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebhookStore.GetOutgoingDeliveriesForHook")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.WebhookStore.GetOutgoingDeliveriesForHook(hookID, offset, limit)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerWebhookStore) GetOutgoingDeliveriesToRetry(now int64, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebhookStore.GetOutgoingDeliveriesToRetry")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.WebhookStore.GetOutgoingDeliveriesToRetry(now, limit)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerWebhookStore) GetOutgoingDelivery(id string) (*model.OutgoingWebhookDelivery, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebhookStore.GetOutgoingDelivery")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.WebhookStore.GetOutgoingDelivery(id)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebhookStore.GetOutgoingList")
@@ -13677,6 +14075,24 @@ func (s *OpenTracingLayerWebhookStore) PermanentDeleteOutgoingByUser(userID stri
 	return err
 }
 
+func (s *OpenTracingLayerWebhookStore) PermanentDeleteOutgoingDeliveriesBefore(before int64) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebhookStore.PermanentDeleteOutgoingDeliveriesBefore")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.WebhookStore.PermanentDeleteOutgoingDeliveriesBefore(before)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebhookStore.SaveIncoming")
@@ -13713,6 +14129,24 @@ func (s *OpenTracingLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebho
 	return result, err
 }
 
+func (s *OpenTracingLayerWebhookStore) SaveOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebhookStore.SaveOutgoingDelivery")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.WebhookStore.SaveOutgoingDelivery(delivery)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebhookStore.UpdateIncoming")
@@ -13749,6 +14183,24 @@ func (s *OpenTracingLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhoo
 	return result, err
 }
 
+func (s *OpenTracingLayerWebhookStore) UpdateOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebhookStore.UpdateOutgoingDelivery")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.WebhookStore.UpdateOutgoingDelivery(delivery)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayer) Close() {
 	s.Store.Close()
 }
@@ -13809,7 +14261,9 @@ func New(childStore store.Store, ctx context.Context) *OpenTracingLayer {
 	newStore.LinkMetadataStore = &OpenTracingLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
 	newStore.NotifyAdminStore = &OpenTracingLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &OpenTracingLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.OutOfOfficeStore = &OpenTracingLayerOutOfOfficeStore{OutOfOfficeStore: childStore.OutOfOffice(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &OpenTracingLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
+	newStore.PersistentNotificationEscalationStore = &OpenTracingLayerPersistentNotificationEscalationStore{PersistentNotificationEscalationStore: childStore.PersistentNotificationEscalation(), Root: &newStore}
 	newStore.PluginStore = &OpenTracingLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
 	newStore.PostStore = &OpenTracingLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
 	newStore.PostAcknowledgementStore = &OpenTracingLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
@@ -23,52 +23,54 @@ const mySQLDeadlockCode = uint16(1213)
 
 type RetryLayer struct {
 	store.Store
-	AuditStore                      store.AuditStore
-	BotStore                        store.BotStore
-	ChannelStore                    store.ChannelStore
-	ChannelBookmarkStore            store.ChannelBookmarkStore
-	ChannelMemberHistoryStore       store.ChannelMemberHistoryStore
-	ClusterDiscoveryStore           store.ClusterDiscoveryStore
-	CommandStore                    store.CommandStore
-	CommandWebhookStore             store.CommandWebhookStore
-	ComplianceStore                 store.ComplianceStore
-	DesktopTokensStore              store.DesktopTokensStore
-	DraftStore                      store.DraftStore
-	EmojiStore                      store.EmojiStore
-	FileInfoStore                   store.FileInfoStore
-	GroupStore                      store.GroupStore
-	JobStore                        store.JobStore
-	LicenseStore                    store.LicenseStore
-	LinkMetadataStore               store.LinkMetadataStore
-	NotifyAdminStore                store.NotifyAdminStore
-	OAuthStore                      store.OAuthStore
-	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
-	PluginStore                     store.PluginStore
-	PostStore                       store.PostStore
-	PostAcknowledgementStore        store.PostAcknowledgementStore
-	PostPersistentNotificationStore store.PostPersistentNotificationStore
-	PostPriorityStore               store.PostPriorityStore
-	PreferenceStore                 store.PreferenceStore
-	ProductNoticesStore             store.ProductNoticesStore
-	ReactionStore                   store.ReactionStore
-	RemoteClusterStore              store.RemoteClusterStore
-	RetentionPolicyStore            store.RetentionPolicyStore
-	RoleStore                       store.RoleStore
-	ScheduledPostStore              store.ScheduledPostStore
-	SchemeStore                     store.SchemeStore
-	SessionStore                    store.SessionStore
-	SharedChannelStore              store.SharedChannelStore
-	StatusStore                     store.StatusStore
-	SystemStore                     store.SystemStore
-	TeamStore                       store.TeamStore
-	TermsOfServiceStore             store.TermsOfServiceStore
-	ThreadStore                     store.ThreadStore
-	TokenStore                      store.TokenStore
-	UploadSessionStore              store.UploadSessionStore
-	UserStore                       store.UserStore
-	UserAccessTokenStore            store.UserAccessTokenStore
-	UserTermsOfServiceStore         store.UserTermsOfServiceStore
-	WebhookStore                    store.WebhookStore
+	AuditStore                            store.AuditStore
+	BotStore                              store.BotStore
+	ChannelStore                          store.ChannelStore
+	ChannelBookmarkStore                  store.ChannelBookmarkStore
+	ChannelMemberHistoryStore             store.ChannelMemberHistoryStore
+	ClusterDiscoveryStore                 store.ClusterDiscoveryStore
+	CommandStore                          store.CommandStore
+	CommandWebhookStore                   store.CommandWebhookStore
+	ComplianceStore                       store.ComplianceStore
+	DesktopTokensStore                    store.DesktopTokensStore
+	DraftStore                            store.DraftStore
+	EmojiStore                            store.EmojiStore
+	FileInfoStore                         store.FileInfoStore
+	GroupStore                            store.GroupStore
+	JobStore                              store.JobStore
+	LicenseStore                          store.LicenseStore
+	LinkMetadataStore                     store.LinkMetadataStore
+	NotifyAdminStore                      store.NotifyAdminStore
+	OAuthStore                            store.OAuthStore
+	OutOfOfficeStore                      store.OutOfOfficeStore
+	OutgoingOAuthConnectionStore          store.OutgoingOAuthConnectionStore
+	PersistentNotificationEscalationStore store.PersistentNotificationEscalationStore
+	PluginStore                           store.PluginStore
+	PostStore                             store.PostStore
+	PostAcknowledgementStore              store.PostAcknowledgementStore
+	PostPersistentNotificationStore       store.PostPersistentNotificationStore
+	PostPriorityStore                     store.PostPriorityStore
+	PreferenceStore                       store.PreferenceStore
+	ProductNoticesStore                   store.ProductNoticesStore
+	ReactionStore                         store.ReactionStore
+	RemoteClusterStore                    store.RemoteClusterStore
+	RetentionPolicyStore                  store.RetentionPolicyStore
+	RoleStore                             store.RoleStore
+	ScheduledPostStore                    store.ScheduledPostStore
+	SchemeStore                           store.SchemeStore
+	SessionStore                          store.SessionStore
+	SharedChannelStore                    store.SharedChannelStore
+	StatusStore                           store.StatusStore
+	SystemStore                           store.SystemStore
+	TeamStore                             store.TeamStore
+	TermsOfServiceStore                   store.TermsOfServiceStore
+	ThreadStore                           store.ThreadStore
+	TokenStore                            store.TokenStore
+	UploadSessionStore                    store.UploadSessionStore
+	UserStore                             store.UserStore
+	UserAccessTokenStore                  store.UserAccessTokenStore
+	UserTermsOfServiceStore               store.UserTermsOfServiceStore
+	WebhookStore                          store.WebhookStore
 }
 
 func (s *RetryLayer) Audit() store.AuditStore {
@@ -147,10 +149,18 @@ func (s *RetryLayer) OAuth() store.OAuthStore {
 	return s.OAuthStore
 }
 
+func (s *RetryLayer) OutOfOffice() store.OutOfOfficeStore {
+	return s.OutOfOfficeStore
+}
+
 func (s *RetryLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
 	return s.OutgoingOAuthConnectionStore
 }
 
+func (s *RetryLayer) PersistentNotificationEscalation() store.PersistentNotificationEscalationStore {
+	return s.PersistentNotificationEscalationStore
+}
+
 func (s *RetryLayer) Plugin() store.PluginStore {
 	return s.PluginStore
 }
@@ -350,11 +360,21 @@ type RetryLayerOAuthStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerOutOfOfficeStore struct {
+	store.OutOfOfficeStore
+	Root *RetryLayer
+}
+
 type RetryLayerOutgoingOAuthConnectionStore struct {
 	store.OutgoingOAuthConnectionStore
 	Root *RetryLayer
 }
 
+type RetryLayerPersistentNotificationEscalationStore struct {
+	store.PersistentNotificationEscalationStore
+	Root *RetryLayer
+}
+
 type RetryLayerPluginStore struct {
 	store.PluginStore
 	Root *RetryLayer
@@ -4137,6 +4157,48 @@ func (s *RetryLayerDraftStore) GetLastCreateAtAndUserIdValuesForEmptyDraftsMigra
 
 }
 
+func (s *RetryLayerDraftStore) GetRevision(userID string, channelID string, rootID string, version int64) (*model.Draft, error) {
+
+	tries := 0
+	for {
+		result, err := s.DraftStore.GetRevision(userID, channelID, rootID, version)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerDraftStore) GetRevisions(userID string, channelID string, rootID string) ([]*model.Draft, error) {
+
+	tries := 0
+	for {
+		result, err := s.DraftStore.GetRevisions(userID, channelID, rootID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerDraftStore) Upsert(d *model.Draft) (*model.Draft, error) {
 
 	tries := 0
@@ -6900,6 +6962,111 @@ func (s *RetryLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp,
 
 }
 
+func (s *RetryLayerOutOfOfficeStore) Delete(userID string) error {
+
+	tries := 0
+	for {
+		err := s.OutOfOfficeStore.Delete(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerOutOfOfficeStore) Get(userID string) (*model.OutOfOffice, error) {
+
+	tries := 0
+	for {
+		result, err := s.OutOfOfficeStore.Get(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerOutOfOfficeStore) GetPendingTransitions(now int64, limit int) ([]*model.OutOfOffice, error) {
+
+	tries := 0
+	for {
+		result, err := s.OutOfOfficeStore.GetPendingTransitions(now, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerOutOfOfficeStore) Save(outOfOffice *model.OutOfOffice) (*model.OutOfOffice, error) {
+
+	tries := 0
+	for {
+		result, err := s.OutOfOfficeStore.Save(outOfOffice)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerOutOfOfficeStore) SetActive(userID string, active bool) error {
+
+	tries := 0
+	for {
+		err := s.OutOfOfficeStore.SetActive(userID, active)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerOutgoingOAuthConnectionStore) DeleteConnection(c request.CTX, id string) error {
 
 	tries := 0
@@ -7005,6 +7172,111 @@ func (s *RetryLayerOutgoingOAuthConnectionStore) UpdateConnection(c request.CTX,
 
 }
 
+func (s *RetryLayerPersistentNotificationEscalationStore) Delete(id string) error {
+
+	tries := 0
+	for {
+		err := s.PersistentNotificationEscalationStore.Delete(id)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPersistentNotificationEscalationStore) DeleteByChannel(channelIds []string) error {
+
+	tries := 0
+	for {
+		err := s.PersistentNotificationEscalationStore.DeleteByChannel(channelIds)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPersistentNotificationEscalationStore) Get(id string) (*model.PersistentNotificationEscalation, error) {
+
+	tries := 0
+	for {
+		result, err := s.PersistentNotificationEscalationStore.Get(id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPersistentNotificationEscalationStore) GetForChannels(channelIds []string) ([]*model.PersistentNotificationEscalation, error) {
+
+	tries := 0
+	for {
+		result, err := s.PersistentNotificationEscalationStore.GetForChannels(channelIds)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPersistentNotificationEscalationStore) Save(escalation *model.PersistentNotificationEscalation) (*model.PersistentNotificationEscalation, error) {
+
+	tries := 0
+	for {
+		result, err := s.PersistentNotificationEscalationStore.Save(escalation)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 
 	tries := 0
@@ -7284,6 +7556,27 @@ func (s *RetryLayerPostStore) Delete(rctx request.CTX, postID string, timestamp
 
 }
 
+func (s *RetryLayerPostStore) DeletePostReminder(postID string, userID string) error {
+
+	tries := 0
+	for {
+		err := s.PostStore.DeletePostReminder(postID, userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPostStore) Get(ctx context.Context, id string, opts model.GetPostsOptions, userID string, sanitizeOptions map[string]bool) (*model.PostList, error) {
 
 	tries := 0
@@ -7611,6 +7904,27 @@ func (s *RetryLayerPostStore) GetPostReminders(now int64) ([]*model.PostReminder
 
 }
 
+func (s *RetryLayerPostStore) GetPostRemindersForUser(userID string) ([]*model.PostReminder, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPostRemindersForUser(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
 
 	tries := 0
@@ -9423,6 +9737,27 @@ func (s *RetryLayerRetentionPolicyStore) GetCount() (int64, error) {
 
 }
 
+func (s *RetryLayerRetentionPolicyStore) GetDryRun(policyID string, now int64, globalPolicyEndTime int64, globalFileEndTime int64) ([]*model.RetentionPolicyDryRunChannel, error) {
+
+	tries := 0
+	for {
+		result, err := s.RetentionPolicyStore.GetDryRun(policyID, now, globalPolicyEndTime, globalFileEndTime)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerRetentionPolicyStore) GetIdsForDeletionByTableName(tableName string, limit int) ([]*model.RetentionIdsForDeletion, error) {
 
 	tries := 0
@@ -9891,6 +10226,27 @@ func (s *RetryLayerScheduledPostStore) GetPendingScheduledPosts(beforeTime int64
 
 }
 
+func (s *RetryLayerScheduledPostStore) GetScheduledPostSeriesForUser(userId string) ([]*model.ScheduledPost, error) {
+
+	tries := 0
+	for {
+		result, err := s.ScheduledPostStore.GetScheduledPostSeriesForUser(userId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerScheduledPostStore) GetScheduledPostsForUser(userId string, teamId string) ([]*model.ScheduledPost, error) {
 
 	tries := 0
@@ -10752,6 +11108,27 @@ func (s *RetryLayerSharedChannelStore) GetRemotesStatus(channelID string) ([]*mo
 
 }
 
+func (s *RetryLayerSharedChannelStore) GetRemotesSyncStatus(channelID string) ([]*model.SharedChannelRemoteSyncStatus, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.GetRemotesSyncStatus(channelID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerSharedChannelStore) GetSingleUser(userID string, channelID string, remoteID string) (*model.SharedChannelUser, error) {
 
 	tries := 0
@@ -11025,6 +11402,27 @@ func (s *RetryLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor mode
 
 }
 
+func (s *RetryLayerSharedChannelStore) UpdateRemoteSyncError(channelID string, remoteID string, syncErr string) error {
+
+	tries := 0
+	for {
+		err := s.SharedChannelStore.UpdateRemoteSyncError(channelID, remoteID, syncErr)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerSharedChannelStore) UpdateUserLastSyncAt(userID string, channelID string, remoteID string) error {
 
 	tries := 0
@@ -15480,6 +15878,69 @@ func (s *RetryLayerWebhookStore) GetOutgoingByTeamByUser(teamID string, userID s
 
 }
 
+func (s *RetryLayerWebhookStore) GetOutgoingDeliveriesForHook(hookID string, offset int, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+
+	tries := 0
+	for {
+		result, err := s.WebhookStore.GetOutgoingDeliveriesForHook(hookID, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerWebhookStore) GetOutgoingDeliveriesToRetry(now int64, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+
+	tries := 0
+	for {
+		result, err := s.WebhookStore.GetOutgoingDeliveriesToRetry(now, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerWebhookStore) GetOutgoingDelivery(id string) (*model.OutgoingWebhookDelivery, error) {
+
+	tries := 0
+	for {
+		result, err := s.WebhookStore.GetOutgoingDelivery(id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, error) {
 
 	tries := 0
@@ -15612,6 +16073,27 @@ func (s *RetryLayerWebhookStore) PermanentDeleteOutgoingByUser(userID string) er
 
 }
 
+func (s *RetryLayerWebhookStore) PermanentDeleteOutgoingDeliveriesBefore(before int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.WebhookStore.PermanentDeleteOutgoingDeliveriesBefore(before)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 
 	tries := 0
@@ -15654,6 +16136,27 @@ func (s *RetryLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*
 
 }
 
+func (s *RetryLayerWebhookStore) SaveOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+
+	tries := 0
+	for {
+		result, err := s.WebhookStore.SaveOutgoingDelivery(delivery)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 
 	tries := 0
@@ -15696,6 +16199,27 @@ func (s *RetryLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*m
 
 }
 
+func (s *RetryLayerWebhookStore) UpdateOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+
+	tries := 0
+	for {
+		result, err := s.WebhookStore.UpdateOutgoingDelivery(delivery)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayer) Close() {
 	s.Store.Close()
 }
@@ -15756,7 +16280,9 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.LinkMetadataStore = &RetryLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
 	newStore.NotifyAdminStore = &RetryLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &RetryLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.OutOfOfficeStore = &RetryLayerOutOfOfficeStore{OutOfOfficeStore: childStore.OutOfOffice(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &RetryLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
+	newStore.PersistentNotificationEscalationStore = &RetryLayerPersistentNotificationEscalationStore{PersistentNotificationEscalationStore: childStore.PersistentNotificationEscalation(), Root: &newStore}
 	newStore.PluginStore = &RetryLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
 	newStore.PostStore = &RetryLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
 	newStore.PostAcknowledgementStore = &RetryLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
@@ -63,6 +63,8 @@ func genStore() *mocks.Store {
 	mock.On("DesktopTokens").Return(&mocks.DesktopTokensStore{})
 	mock.On("ChannelBookmark").Return(&mocks.ChannelBookmarkStore{})
 	mock.On("ScheduledPost").Return(&mocks.ScheduledPostStore{})
+	mock.On("OutOfOffice").Return(&mocks.OutOfOfficeStore{})
+	mock.On("PersistentNotificationEscalation").Return(&mocks.PersistentNotificationEscalationStore{})
 	return mock
 }
 
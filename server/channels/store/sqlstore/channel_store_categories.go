@@ -293,6 +293,7 @@ func (s SqlChannelStore) MigrateFavoritesToSidebarChannels(lastUserId string, ru
 
 type sidebarCategoryForJoin struct {
 	model.SidebarCategory
+	Rules     model.SidebarCategoryRules
 	ChannelId *string
 }
 
@@ -341,8 +342,8 @@ func (s SqlChannelStore) CreateSidebarCategory(userId, teamId string, newCategor
 		Muted:       newCategory.Muted,
 	}
 	if _, err2 := transaction.NamedExec(`INSERT INTO
-			SidebarCategories(Id, UserId, TeamId, SortOrder, Sorting, Type, DisplayName, Muted, Collapsed)
-			VALUES(:Id, :UserId, :TeamId, :SortOrder, :Sorting, :Type, :DisplayName, :Muted, :Collapsed)`, category); err2 != nil {
+			SidebarCategories(Id, UserId, TeamId, SortOrder, Sorting, Type, DisplayName, Muted, Collapsed, Rules)
+			VALUES(:Id, :UserId, :TeamId, :SortOrder, :Sorting, :Type, :DisplayName, :Muted, :Collapsed, :Rules)`, &sidebarCategoryForJoin{SidebarCategory: *category, Rules: newCategory.Rules}); err2 != nil {
 		return nil, errors.Wrap(err2, "failed to save SidebarCategory")
 	}
 
@@ -413,6 +414,7 @@ func (s SqlChannelStore) CreateSidebarCategory(userId, teamId string, newCategor
 	result := &model.SidebarCategoryWithChannels{
 		SidebarCategory: *category,
 		Channels:        newCategory.Channels,
+		Rules:           newCategory.Rules,
 	}
 
 	return result, nil
@@ -515,6 +517,7 @@ func (s SqlChannelStore) GetSidebarCategory(categoryId string) (*model.SidebarCa
 	result := &model.SidebarCategoryWithChannels{
 		SidebarCategory: categories[0].SidebarCategory,
 		Channels:        make([]string, 0),
+		Rules:           categories[0].Rules,
 	}
 	for _, category := range categories {
 		if category.ChannelId != nil {
@@ -578,6 +581,7 @@ func (s SqlChannelStore) getSidebarCategoriesT(db dbSelecter, userId string, opt
 			prevCategory = &model.SidebarCategoryWithChannels{
 				SidebarCategory: category.SidebarCategory,
 				Channels:        make([]string, 0),
+				Rules:           category.Rules,
 			}
 			oc.Categories = append(oc.Categories, prevCategory)
 			oc.Order = append(oc.Order, category.Id)
@@ -723,6 +727,13 @@ func (s SqlChannelStore) UpdateSidebarCategories(userId, teamId string, categori
 			destCategory.DisplayName = srcCategory.DisplayName
 		}
 
+		// Only custom categories have rules, and omitting them keeps the existing ones
+		if destCategory.Type == model.SidebarCategoryCustom && category.Rules != nil {
+			destCategory.Rules = category.Rules
+		} else {
+			destCategory.Rules = srcCategory.Rules
+		}
+
 		if destCategory.Type != model.SidebarCategoryDirectMessages {
 			destCategory.Channels = make([]string, len(category.Channels))
 			copy(destCategory.Channels, category.Channels)
@@ -741,6 +752,7 @@ func (s SqlChannelStore) UpdateSidebarCategories(userId, teamId string, categori
 			Set("Sorting", destCategory.Sorting).
 			Set("Muted", destCategory.Muted).
 			Set("Collapsed", destCategory.Collapsed).
+			Set("Rules", destCategory.Rules).
 			Where(sq.Eq{"Id": destCategory.Id}).ToSql()
 		if err2 != nil {
 			return nil, nil, errors.Wrap(err2, "update_sidebar_categories_tosql1")
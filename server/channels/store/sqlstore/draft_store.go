@@ -5,6 +5,7 @@ package sqlstore
 
 import (
 	"database/sql"
+	"fmt"
 	"sync"
 
 	sq "github.com/mattermost/squirrel"
@@ -35,6 +36,7 @@ func draftSliceColumns() []string {
 		"FileIds",
 		"Props",
 		"Priority",
+		"Version",
 	}
 }
 
@@ -50,6 +52,37 @@ func draftToSlice(draft *model.Draft) []interface{} {
 		model.ArrayToJSON(draft.FileIds),
 		model.StringInterfaceToJSON(draft.Props),
 		model.StringInterfaceToJSON(draft.Priority),
+		draft.Version,
+	}
+}
+
+func draftRevisionColumns() []string {
+	return []string{
+		"CreateAt",
+		"UpdateAt",
+		"Message",
+		"RootId",
+		"ChannelId",
+		"UserId",
+		"FileIds",
+		"Props",
+		"Priority",
+		"Version",
+	}
+}
+
+func draftRevisionToSlice(draft *model.Draft) []any {
+	return []any{
+		draft.CreateAt,
+		draft.UpdateAt,
+		draft.Message,
+		draft.RootId,
+		draft.ChannelId,
+		draft.UserId,
+		model.ArrayToJSON(draft.FileIds),
+		model.StringInterfaceToJSON(draft.Props),
+		model.StringInterfaceToJSON(draft.Priority),
+		draft.Version,
 	}
 }
 
@@ -88,34 +121,141 @@ func (s *SqlDraftStore) Get(userId, channelId, rootId string, includeDeleted boo
 	return &dt, nil
 }
 
-func (s *SqlDraftStore) Upsert(draft *model.Draft) (*model.Draft, error) {
+// Upsert saves the draft and keeps its previous content as a revision. If the draft has a version, the save
+// fails with a store.ErrConflict unless it matches the version of the stored draft.
+func (s *SqlDraftStore) Upsert(draft *model.Draft) (_ *model.Draft, err error) {
 	draft.PreSave()
 	maxDraftSize := s.GetMaxDraftSize()
 	if err := draft.IsValid(maxDraftSize); err != nil {
 		return nil, err
 	}
 
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	current := model.Draft{}
+	selectQuery := s.getQueryBuilder().
+		Select(draftSliceColumns()...).
+		From("Drafts").
+		Where(sq.Eq{
+			"UserId":    draft.UserId,
+			"ChannelId": draft.ChannelId,
+			"RootId":    draft.RootId,
+		}).
+		Suffix("FOR UPDATE")
+	if err = transaction.GetBuilder(&current, selectQuery); err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrapf(err, "failed to find draft with channelid = %s", draft.ChannelId)
+	}
+	exists := err == nil
+
+	if draft.Version != 0 && (!exists || current.Version != draft.Version) {
+		return nil, store.NewErrConflict("Draft", nil, fmt.Sprintf("channelid=%s, rootid=%s, version=%d, current_version=%d", draft.ChannelId, draft.RootId, draft.Version, current.Version))
+	}
+
+	if exists {
+		if err = s.saveRevisionT(transaction, &current); err != nil {
+			return nil, err
+		}
+	} else if err = s.deleteRevisionsT(transaction, sq.Eq{"UserId": draft.UserId, "ChannelId": draft.ChannelId, "RootId": draft.RootId}); err != nil {
+		// Drop any revisions left behind by a draft deleted outside of the store, since versions restart at 1
+		return nil, err
+	}
+	draft.Version = current.Version + 1
+
 	builder := s.getQueryBuilder().Insert("Drafts").Columns(draftSliceColumns()...).Values(draftToSlice(draft)...)
 
 	if s.DriverName() == model.DatabaseDriverMysql {
-		builder = builder.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE  UpdateAt = ?, Message = ?, Props = ?, FileIds = ?, Priority = ?, DeleteAt = ?", draft.UpdateAt, draft.Message, draft.Props, draft.FileIds, draft.Priority, 0))
+		builder = builder.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE  UpdateAt = ?, Message = ?, Props = ?, FileIds = ?, Priority = ?, DeleteAt = ?, Version = ?", draft.UpdateAt, draft.Message, draft.Props, draft.FileIds, draft.Priority, 0, draft.Version))
 	} else {
-		builder = builder.SuffixExpr(sq.Expr("ON CONFLICT (UserId, ChannelId, RootId) DO UPDATE SET UpdateAt = ?, Message = ?, Props = ?, FileIds = ?, Priority = ?, DeleteAt = ?", draft.UpdateAt, draft.Message, draft.Props, draft.FileIds, draft.Priority, 0))
+		builder = builder.SuffixExpr(sq.Expr("ON CONFLICT (UserId, ChannelId, RootId) DO UPDATE SET UpdateAt = ?, Message = ?, Props = ?, FileIds = ?, Priority = ?, DeleteAt = ?, Version = ?", draft.UpdateAt, draft.Message, draft.Props, draft.FileIds, draft.Priority, 0, draft.Version))
 	}
 
 	query, args, err := builder.ToSql()
-
 	if err != nil {
 		return nil, errors.Wrap(err, "save_draft_tosql")
 	}
 
-	if _, err = s.GetMasterX().Exec(query, args...); err != nil {
+	if _, err = transaction.Exec(query, args...); err != nil {
 		return nil, errors.Wrap(err, "failed to upsert Draft")
 	}
 
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
 	return draft, nil
 }
 
+// saveRevisionT keeps the given content of a draft as a revision, and drops the revisions of the draft that are
+// too old to be kept.
+func (s *SqlDraftStore) saveRevisionT(transaction *sqlxTxWrapper, draft *model.Draft) error {
+	builder := s.getQueryBuilder().
+		Insert("DraftRevisions").
+		Columns(draftRevisionColumns()...).
+		Values(draftRevisionToSlice(draft)...)
+	if _, err := transaction.ExecBuilder(builder); err != nil {
+		return errors.Wrap(err, "failed to save DraftRevision")
+	}
+
+	return s.deleteRevisionsT(transaction, sq.And{
+		sq.Eq{"UserId": draft.UserId, "ChannelId": draft.ChannelId, "RootId": draft.RootId},
+		sq.LtOrEq{"Version": draft.Version - model.DraftRevisionsMax},
+	})
+}
+
+func (s *SqlDraftStore) deleteRevisionsT(transaction *sqlxTxWrapper, where sq.Sqlizer) error {
+	if _, err := transaction.ExecBuilder(s.getQueryBuilder().Delete("DraftRevisions").Where(where)); err != nil {
+		return errors.Wrap(err, "failed to delete DraftRevisions")
+	}
+
+	return nil
+}
+
+// GetRevisions returns the previous revisions of a draft, most recent first.
+func (s *SqlDraftStore) GetRevisions(userID, channelID, rootID string) ([]*model.Draft, error) {
+	query := s.getQueryBuilder().
+		Select(draftRevisionColumns()...).
+		From("DraftRevisions").
+		Where(sq.Eq{
+			"UserId":    userID,
+			"ChannelId": channelID,
+			"RootId":    rootID,
+		}).
+		OrderBy("Version DESC")
+
+	revisions := []*model.Draft{}
+	if err := s.GetReplicaX().SelectBuilder(&revisions, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get revisions of draft with channelid = %s", channelID)
+	}
+
+	return revisions, nil
+}
+
+func (s *SqlDraftStore) GetRevision(userID, channelID, rootID string, version int64) (*model.Draft, error) {
+	query := s.getQueryBuilder().
+		Select(draftRevisionColumns()...).
+		From("DraftRevisions").
+		Where(sq.Eq{
+			"UserId":    userID,
+			"ChannelId": channelID,
+			"RootId":    rootID,
+			"Version":   version,
+		})
+
+	revision := model.Draft{}
+	if err := s.GetReplicaX().GetBuilder(&revision, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("DraftRevision", fmt.Sprintf("channelid=%s, rootid=%s, version=%d", channelID, rootID, version))
+		}
+		return nil, errors.Wrapf(err, "failed to get revision of draft with channelid = %s", channelID)
+	}
+
+	return &revision, nil
+}
+
 func (s *SqlDraftStore) GetDraftsForUser(userID, teamID string) ([]*model.Draft, error) {
 	var drafts []*model.Draft
 
@@ -130,6 +270,7 @@ func (s *SqlDraftStore) GetDraftsForUser(userID, teamID string) ([]*model.Draft,
 			"Drafts.FileIds",
 			"Drafts.Props",
 			"Drafts.Priority",
+			"Drafts.Version",
 		).
 		From("Drafts").
 		InnerJoin("ChannelMembers ON ChannelMembers.ChannelId = Drafts.ChannelId").
@@ -159,48 +300,40 @@ func (s *SqlDraftStore) GetDraftsForUser(userID, teamID string) ([]*model.Draft,
 }
 
 func (s *SqlDraftStore) Delete(userID, channelID, rootID string) error {
-	query := s.getQueryBuilder().
-		Delete("Drafts").
-		Where(sq.Eq{
-			"UserId":    userID,
-			"ChannelId": channelID,
-			"RootId":    rootID,
-		})
-
-	sql, args, err := query.ToSql()
-	if err != nil {
-		return errors.Wrapf(err, "failed to convert to sql")
-	}
-
-	_, err = s.GetMasterX().Exec(sql, args...)
-
-	if err != nil {
-		return errors.Wrap(err, "failed to delete Draft")
-	}
-
-	return nil
+	return s.deleteWithRevisions(sq.Eq{
+		"UserId":    userID,
+		"ChannelId": channelID,
+		"RootId":    rootID,
+	})
 }
 
 // DeleteDraftsAssociatedWithPost deletes all drafts associated with a post.
 func (s *SqlDraftStore) DeleteDraftsAssociatedWithPost(channelID, rootID string) error {
-	query := s.getQueryBuilder().
-		Delete("Drafts").
-		Where(sq.Eq{
-			"ChannelId": channelID,
-			"RootId":    rootID,
-		})
+	return s.deleteWithRevisions(sq.Eq{
+		"ChannelId": channelID,
+		"RootId":    rootID,
+	})
+}
 
-	sql, args, err := query.ToSql()
+func (s *SqlDraftStore) deleteWithRevisions(where sq.Eq) (err error) {
+	transaction, err := s.GetMasterX().Beginx()
 	if err != nil {
-		return errors.Wrapf(err, "failed to convert to sql")
+		return errors.Wrap(err, "begin_transaction")
 	}
+	defer finalizeTransactionX(transaction, &err)
 
-	_, err = s.GetMasterX().Exec(sql, args...)
-
-	if err != nil {
+	if _, err = transaction.ExecBuilder(s.getQueryBuilder().Delete("Drafts").Where(where)); err != nil {
 		return errors.Wrap(err, "failed to delete Draft")
 	}
 
+	if err = s.deleteRevisionsT(transaction, where); err != nil {
+		return err
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
 	return nil
 }
 
@@ -289,7 +422,17 @@ func (s *SqlDraftStore) GetLastCreateAtAndUserIdValuesForEmptyDraftsMigration(cr
 	return lastElement.CreateAt, lastElement.UserId, nil
 }
 
-func (s *SqlDraftStore) DeleteEmptyDraftsByCreateAtAndUserId(createAt int64, userId string) error {
+func (s *SqlDraftStore) DeleteEmptyDraftsByCreateAtAndUserId(createAt int64, userId string) (err error) {
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	if err = s.deleteBatchRevisionsT(transaction, createAt, userId, "d.Message = ''"); err != nil {
+		return err
+	}
+
 	var builder Builder
 	if s.DriverName() == model.DatabaseDriverPostgres {
 		builder = s.getQueryBuilder().
@@ -337,14 +480,28 @@ func (s *SqlDraftStore) DeleteEmptyDraftsByCreateAtAndUserId(createAt int64, use
 			).Where(sq.Eq{"Message": ""})
 	}
 
-	if _, err := s.GetMasterX().ExecBuilder(builder); err != nil {
+	if _, err = transaction.ExecBuilder(builder); err != nil {
 		return errors.Wrapf(err, "failed to delete empty drafts")
 	}
 
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
 	return nil
 }
 
-func (s *SqlDraftStore) DeleteOrphanDraftsByCreateAtAndUserId(createAt int64, userId string) error {
+func (s *SqlDraftStore) DeleteOrphanDraftsByCreateAtAndUserId(createAt int64, userId string) (err error) {
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	if err = s.deleteBatchRevisionsT(transaction, createAt, userId, orphanDraftCondition); err != nil {
+		return err
+	}
+
 	var builder Builder
 	if s.DriverName() == model.DatabaseDriverPostgres {
 		builder = s.getQueryBuilder().
@@ -368,7 +525,7 @@ func (s *SqlDraftStore) DeleteOrphanDraftsByCreateAtAndUserId(createAt int64, us
 			Where("d.UserId = dd.UserId").
 			Where("d.ChannelId = dd.ChannelId").
 			Where("d.RootId = dd.RootId").
-			Suffix("AND (d.RootId IN (SELECT Id FROM Posts WHERE DeleteAt <> 0) OR NOT EXISTS (SELECT 1 FROM Posts WHERE Posts.Id = d.RootId))")
+			Suffix("AND " + orphanDraftCondition)
 	} else if s.DriverName() == model.DatabaseDriverMysql {
 		builder = s.getQueryBuilder().
 			Delete("Drafts d").
@@ -390,12 +547,64 @@ func (s *SqlDraftStore) DeleteOrphanDraftsByCreateAtAndUserId(createAt int64, us
 				Limit(100).
 				Suffix(") dj ON (d.UserId = dj.UserId AND d.ChannelId = dj.ChannelId AND d.RootId = dj.RootId)"),
 			).
-			Suffix("AND (d.RootId IN (SELECT Id FROM Posts WHERE DeleteAt <> 0) OR NOT EXISTS (SELECT 1 FROM Posts WHERE Posts.Id = d.RootId))")
+			Suffix("AND " + orphanDraftCondition)
 	}
 
-	if _, err := s.GetMasterX().ExecBuilder(builder); err != nil {
+	if _, err = transaction.ExecBuilder(builder); err != nil {
 		return errors.Wrapf(err, "failed to delete orphan drafts")
 	}
 
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+// orphanDraftCondition matches the drafts d replying to a thread that is deleted or doesn't exist.
+const orphanDraftCondition = "(d.RootId IN (SELECT Id FROM Posts WHERE DeleteAt <> 0) OR NOT EXISTS (SELECT 1 FROM Posts WHERE Posts.Id = d.RootId))"
+
+// deleteBatchRevisionsT deletes the revisions of the drafts d matching the condition among the batch of drafts
+// following the given CreateAt and UserId, before the drafts themselves are deleted.
+func (s *SqlDraftStore) deleteBatchRevisionsT(transaction *sqlxTxWrapper, createAt int64, userId string, condition string) error {
+	batch := s.getQueryBuilder().Select().
+		Columns("UserId", "ChannelId", "RootId").
+		From("Drafts").
+		Where(sq.Or{
+			sq.Gt{"CreateAt": createAt},
+			sq.And{
+				sq.Eq{"CreateAt": createAt},
+				sq.Gt{"UserId": userId},
+			},
+		}).
+		OrderBy("CreateAt", "UserId").
+		Limit(100)
+
+	var builder Builder
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		builder = s.getQueryBuilder().
+			Delete("DraftRevisions r").
+			PrefixExpr(batch.Prefix("WITH dd AS (").Suffix(")")).
+			Using("dd", "Drafts d").
+			Where("d.UserId = dd.UserId").
+			Where("d.ChannelId = dd.ChannelId").
+			Where("d.RootId = dd.RootId").
+			Where("r.UserId = d.UserId").
+			Where("r.ChannelId = d.ChannelId").
+			Where("r.RootId = d.RootId").
+			Where(condition)
+	} else if s.DriverName() == model.DatabaseDriverMysql {
+		builder = s.getQueryBuilder().
+			Delete("DraftRevisions r").
+			What("r.*").
+			JoinClause("INNER JOIN Drafts d ON (r.UserId = d.UserId AND r.ChannelId = d.ChannelId AND r.RootId = d.RootId)").
+			JoinClause(batch.Prefix("INNER JOIN (").Suffix(") dj ON (d.UserId = dj.UserId AND d.ChannelId = dj.ChannelId AND d.RootId = dj.RootId)")).
+			Where(condition)
+	}
+
+	if _, err := transaction.ExecBuilder(builder); err != nil {
+		return errors.Wrap(err, "failed to delete DraftRevisions")
+	}
+
 	return nil
 }
@@ -0,0 +1,175 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlOutOfOfficeStore struct {
+	*SqlStore
+}
+
+func newSqlOutOfOfficeStore(sqlStore *SqlStore) store.OutOfOfficeStore {
+	return &SqlOutOfOfficeStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func outOfOfficeColumns() []string {
+	return []string{
+		"UserId",
+		"StartAt",
+		"EndAt",
+		"DMMessage",
+		"MentionMessage",
+		"DelegateUserId",
+		"ExcludeBots",
+		"ExcludedUserIds",
+		"Active",
+		"CreateAt",
+		"UpdateAt",
+	}
+}
+
+func outOfOfficeToSlice(o *model.OutOfOffice) []any {
+	return []any{
+		o.UserId,
+		o.StartAt,
+		o.EndAt,
+		o.DMMessage,
+		o.MentionMessage,
+		o.DelegateUserId,
+		o.ExcludeBots,
+		o.ExcludedUserIds,
+		o.Active,
+		o.CreateAt,
+		o.UpdateAt,
+	}
+}
+
+// Save creates the out of office settings of a user, or replaces the existing ones.
+func (s *SqlOutOfOfficeStore) Save(outOfOffice *model.OutOfOffice) (*model.OutOfOffice, error) {
+	outOfOffice.PreSave()
+	if err := outOfOffice.IsValid(); err != nil {
+		return nil, err
+	}
+
+	builder := s.getQueryBuilder().
+		Insert("OutOfOffice").
+		Columns(outOfOfficeColumns()...).
+		Values(outOfOfficeToSlice(outOfOffice)...)
+
+	updateArgs := []any{
+		outOfOffice.StartAt,
+		outOfOffice.EndAt,
+		outOfOffice.DMMessage,
+		outOfOffice.MentionMessage,
+		outOfOffice.DelegateUserId,
+		outOfOffice.ExcludeBots,
+		outOfOffice.ExcludedUserIds,
+		outOfOffice.Active,
+		outOfOffice.UpdateAt,
+	}
+	if s.DriverName() == model.DatabaseDriverMysql {
+		builder = builder.SuffixExpr(sq.Expr("ON DUPLICATE KEY UPDATE StartAt = ?, EndAt = ?, DMMessage = ?, MentionMessage = ?, DelegateUserId = ?, ExcludeBots = ?, ExcludedUserIds = ?, Active = ?, UpdateAt = ?", updateArgs...))
+	} else {
+		builder = builder.SuffixExpr(sq.Expr("ON CONFLICT (UserId) DO UPDATE SET StartAt = ?, EndAt = ?, DMMessage = ?, MentionMessage = ?, DelegateUserId = ?, ExcludeBots = ?, ExcludedUserIds = ?, Active = ?, UpdateAt = ?", updateArgs...))
+	}
+
+	if _, err := s.GetMasterX().ExecBuilder(builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to save the out of office settings for user_id=%s", outOfOffice.UserId)
+	}
+
+	return outOfOffice, nil
+}
+
+func (s *SqlOutOfOfficeStore) Get(userID string) (*model.OutOfOffice, error) {
+	builder := s.getQueryBuilder().
+		Select(outOfOfficeColumns()...).
+		From("OutOfOffice").
+		Where(sq.Eq{"UserId": userID})
+
+	var outOfOffice model.OutOfOffice
+	if err := s.GetReplicaX().GetBuilder(&outOfOffice, builder); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("OutOfOffice", userID)
+		}
+		return nil, errors.Wrapf(err, "failed to get the out of office settings for user_id=%s", userID)
+	}
+
+	return &outOfOffice, nil
+}
+
+func (s *SqlOutOfOfficeStore) Delete(userID string) error {
+	builder := s.getQueryBuilder().
+		Delete("OutOfOffice").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMasterX().ExecBuilder(builder); err != nil {
+		return errors.Wrapf(err, "failed to delete the out of office settings for user_id=%s", userID)
+	}
+
+	return nil
+}
+
+func (s *SqlOutOfOfficeStore) SetActive(userID string, active bool) error {
+	builder := s.getQueryBuilder().
+		Update("OutOfOffice").
+		Set("Active", active).
+		Set("UpdateAt", model.GetMillis()).
+		Where(sq.Eq{"UserId": userID})
+
+	result, err := s.GetMasterX().ExecBuilder(builder)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update the out of office settings for user_id=%s", userID)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if count == 0 {
+		return store.NewErrNotFound("OutOfOffice", userID)
+	}
+
+	return nil
+}
+
+func (s *SqlOutOfOfficeStore) GetPendingTransitions(now int64, limit int) ([]*model.OutOfOffice, error) {
+	builder := s.getQueryBuilder().
+		Select(outOfOfficeColumns()...).
+		From("OutOfOffice").
+		Where(sq.Or{
+			sq.And{
+				sq.Eq{"Active": false},
+				sq.LtOrEq{"StartAt": now},
+				sq.Gt{"EndAt": now},
+			},
+			sq.And{
+				sq.Eq{"Active": true},
+				sq.Or{
+					sq.Gt{"StartAt": now},
+					sq.LtOrEq{"EndAt": now},
+				},
+			},
+		}).
+		OrderBy("StartAt", "UserId").
+		Limit(uint64(limit))
+
+	outOfOffices := []*model.OutOfOffice{}
+	// The job updates the rows it fetched before asking for the next batch,
+	// so read from the master to avoid seeing them again.
+	if err := s.GetMasterX().SelectBuilder(&outOfOffices, builder); err != nil {
+		return nil, errors.Wrap(err, "failed to get the pending out of office transitions")
+	}
+
+	return outOfOffices, nil
+}
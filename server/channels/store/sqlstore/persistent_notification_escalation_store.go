@@ -0,0 +1,161 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlPersistentNotificationEscalationStore struct {
+	*SqlStore
+}
+
+func newSqlPersistentNotificationEscalationStore(sqlStore *SqlStore) store.PersistentNotificationEscalationStore {
+	return &SqlPersistentNotificationEscalationStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func persistentNotificationEscalationColumns() []string {
+	return []string{
+		"Id",
+		"ChannelId",
+		"AfterCount",
+		"Type",
+		"COALESCE(GroupId, '') AS GroupId",
+		"COALESCE(FallbackChannelId, '') AS FallbackChannelId",
+		"CreatorId",
+		"CreateAt",
+		"UpdateAt",
+		"DeleteAt",
+	}
+}
+
+func (s *SqlPersistentNotificationEscalationStore) Save(escalation *model.PersistentNotificationEscalation) (*model.PersistentNotificationEscalation, error) {
+	escalation.PreSave()
+	if err := escalation.IsValid(); err != nil {
+		return nil, err
+	}
+
+	builder := s.getQueryBuilder().
+		Insert("PersistentNotificationEscalations").
+		Columns("Id", "ChannelId", "AfterCount", "Type", "GroupId", "FallbackChannelId", "CreatorId", "CreateAt", "UpdateAt", "DeleteAt").
+		Values(
+			escalation.Id,
+			escalation.ChannelId,
+			escalation.AfterCount,
+			escalation.Type,
+			escalation.GroupId,
+			escalation.FallbackChannelId,
+			escalation.CreatorId,
+			escalation.CreateAt,
+			escalation.UpdateAt,
+			escalation.DeleteAt,
+		)
+
+	if _, err := s.GetMasterX().ExecBuilder(builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to save persistent notification escalation for channel=%s", escalation.ChannelId)
+	}
+
+	return escalation, nil
+}
+
+func (s *SqlPersistentNotificationEscalationStore) Get(id string) (*model.PersistentNotificationEscalation, error) {
+	builder := s.getQueryBuilder().
+		Select(persistentNotificationEscalationColumns()...).
+		From("PersistentNotificationEscalations").
+		Where(sq.Eq{
+			"Id":       id,
+			"DeleteAt": 0,
+		})
+
+	var escalation model.PersistentNotificationEscalation
+	if err := s.GetReplicaX().GetBuilder(&escalation, builder); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("PersistentNotificationEscalation", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get persistent notification escalation with id=%s", id)
+	}
+
+	return &escalation, nil
+}
+
+func (s *SqlPersistentNotificationEscalationStore) GetForChannels(channelIds []string) ([]*model.PersistentNotificationEscalation, error) {
+	escalations := []*model.PersistentNotificationEscalation{}
+	if len(channelIds) == 0 {
+		return escalations, nil
+	}
+
+	builder := s.getQueryBuilder().
+		Select(persistentNotificationEscalationColumns()...).
+		From("PersistentNotificationEscalations").
+		Where(sq.Eq{
+			"ChannelId": channelIds,
+			"DeleteAt":  0,
+		}).
+		OrderBy("AfterCount ASC", "CreateAt ASC")
+
+	if err := s.GetReplicaX().SelectBuilder(&escalations, builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to get persistent notification escalations for channels %s", channelIds)
+	}
+
+	return escalations, nil
+}
+
+func (s *SqlPersistentNotificationEscalationStore) Delete(id string) error {
+	now := model.GetMillis()
+	builder := s.getQueryBuilder().
+		Update("PersistentNotificationEscalations").
+		Set("DeleteAt", now).
+		Set("UpdateAt", now).
+		Where(sq.Eq{
+			"Id":       id,
+			"DeleteAt": 0,
+		})
+
+	result, err := s.GetMasterX().ExecBuilder(builder)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete persistent notification escalation with id=%s", id)
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return errors.Wrap(err, "failed to get the number of deleted persistent notification escalations")
+	} else if rows == 0 {
+		return store.NewErrNotFound("PersistentNotificationEscalation", id)
+	}
+
+	return nil
+}
+
+// DeleteByChannel deletes the escalation steps of the given channels, and the
+// ones falling back to them.
+func (s *SqlPersistentNotificationEscalationStore) DeleteByChannel(channelIds []string) error {
+	if len(channelIds) == 0 {
+		return nil
+	}
+
+	now := model.GetMillis()
+	builder := s.getQueryBuilder().
+		Update("PersistentNotificationEscalations").
+		Set("DeleteAt", now).
+		Set("UpdateAt", now).
+		Where(sq.And{
+			sq.Eq{"DeleteAt": 0},
+			sq.Or{
+				sq.Eq{"ChannelId": channelIds},
+				sq.Eq{"FallbackChannelId": channelIds},
+			},
+		})
+
+	if _, err := s.GetMasterX().ExecBuilder(builder); err != nil {
+		return errors.Wrapf(err, "failed to delete persistent notification escalations for channels %s", channelIds)
+	}
+
+	return nil
+}
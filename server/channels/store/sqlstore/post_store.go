@@ -3282,6 +3282,47 @@ func (s *SqlPostStore) GetPostReminders(now int64) (_ []*model.PostReminder, err
 	return reminders, nil
 }
 
+// GetPostRemindersForUser returns the pending reminders of a user, the earliest first.
+func (s *SqlPostStore) GetPostRemindersForUser(userID string) ([]*model.PostReminder, error) {
+	reminders := []*model.PostReminder{}
+
+	query := s.getQueryBuilder().
+		Select("PostId", "UserId", "TargetTime").
+		From("PostReminders").
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("TargetTime ASC", "PostId ASC")
+
+	if err := s.GetReplicaX().SelectBuilder(&reminders, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get post reminders for userId=%s", userID)
+	}
+
+	return reminders, nil
+}
+
+func (s *SqlPostStore) DeletePostReminder(postID, userID string) error {
+	query := s.getQueryBuilder().
+		Delete("PostReminders").
+		Where(sq.Eq{
+			"PostId": postID,
+			"UserId": userID,
+		})
+
+	result, err := s.GetMasterX().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete post reminder with postId=%s", postID)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("PostReminder", postID)
+	}
+
+	return nil
+}
+
 func (s *SqlPostStore) GetPostReminderMetadata(postID string) (*store.PostReminderMetadata, error) {
 	meta := &store.PostReminderMetadata{}
 	err := s.GetReplicaX().Get(meta, `SELECT c.id as ChannelID,
@@ -877,6 +877,176 @@ func (s *SqlRetentionPolicyStore) GetIdsForDeletionByTableName(tableName string,
 	return idsForDeletion, nil
 }
 
+type retentionPolicyDryRunRow struct {
+	PolicyId    string
+	TeamId      string
+	ChannelId   string
+	RecordCount int64
+	OldestAt    int64
+	NewestAt    int64
+}
+
+// GetDryRun counts, per channel, the posts which the data retention job would
+// delete along with their files and reactions, without deleting anything.
+// If `policyID` is empty, all of the granular policies and the global policy
+// are included; otherwise only the given policy is. See
+// `RetentionPolicyBatchDeletionInfo` for the meaning of `now` and `globalPolicyEndTime`.
+// Like the deletion job, the global policy deletes files by their own creation
+// time, so they are counted against `globalFileEndTime` rather than through
+// their posts. A zero `globalFileEndTime` disables the deletion of files.
+func (s *SqlRetentionPolicyStore) GetDryRun(policyID string, now, globalPolicyEndTime, globalFileEndTime int64) ([]*model.RetentionPolicyDryRunChannel, error) {
+	if policyID != "" {
+		globalPolicyEndTime = 0
+		globalFileEndTime = 0
+	}
+
+	channels := []*model.RetentionPolicyDryRunChannel{}
+	channelsByKey := map[[2]string]*model.RetentionPolicyDryRunChannel{}
+
+	for _, table := range []string{"Posts", "FileInfo", "Reactions"} {
+		var rows []retentionPolicyDryRunRow
+		var err error
+		if table == "FileInfo" {
+			rows, err = s.getDryRunFileRows(policyID, now, globalFileEndTime)
+		} else {
+			baseBuilder := s.getQueryBuilder().Select().From("Posts")
+			if table != "Posts" {
+				baseBuilder = baseBuilder.InnerJoin(table + " ON " + table + ".PostId = Posts.Id")
+			}
+			rows, err = s.getDryRunRows(baseBuilder, policyID, now, globalPolicyEndTime)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to count %s to delete", table)
+		}
+
+		for _, row := range rows {
+			key := [2]string{row.PolicyId, row.ChannelId}
+			channel, ok := channelsByKey[key]
+			if !ok {
+				channel = &model.RetentionPolicyDryRunChannel{
+					PolicyID:  row.PolicyId,
+					TeamID:    row.TeamId,
+					ChannelID: row.ChannelId,
+				}
+				channelsByKey[key] = channel
+				channels = append(channels, channel)
+			}
+
+			switch table {
+			case "Posts":
+				channel.PostCount = row.RecordCount
+				channel.OldestPostAt = row.OldestAt
+				channel.NewestPostAt = row.NewestAt
+			case "FileInfo":
+				channel.FileCount = row.RecordCount
+			case "Reactions":
+				channel.ReactionCount = row.RecordCount
+			}
+		}
+	}
+
+	return channels, nil
+}
+
+// getDryRunFileRows counts the files of the posts deleted by the granular
+// policies, and the files which the global policy deletes by their creation
+// time in the channels which no granular policy applies to.
+func (s *SqlRetentionPolicyStore) getDryRunFileRows(policyID string, now, globalFileEndTime int64) ([]retentionPolicyDryRunRow, error) {
+	baseBuilder := s.getQueryBuilder().Select().
+		From("Posts").
+		InnerJoin("FileInfo ON FileInfo.PostId = Posts.Id")
+	rows, err := s.getDryRunRows(baseBuilder, policyID, now, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if globalFileEndTime > 0 {
+		builder := s.getQueryBuilder().
+			Select(
+				"'' AS PolicyId",
+				"COALESCE(Channels.TeamId, '') AS TeamId",
+				"FileInfo.ChannelId AS ChannelId",
+				"COUNT(*) AS RecordCount",
+			).
+			From("FileInfo").
+			LeftJoin("Channels ON Channels.Id = FileInfo.ChannelId").
+			// Granular policies override the global policy.
+			LeftJoin("RetentionPoliciesChannels ON FileInfo.ChannelId = RetentionPoliciesChannels.ChannelId").
+			LeftJoin("RetentionPoliciesTeams ON Channels.TeamId = RetentionPoliciesTeams.TeamId").
+			Where(sq.Eq{
+				"RetentionPoliciesChannels.PolicyId": nil,
+				"RetentionPoliciesTeams.PolicyId":    nil,
+			}).
+			Where(sq.Lt{"FileInfo.CreateAt": globalFileEndTime}).
+			Where(sq.NotEq{"FileInfo.CreatorId": model.BookmarkFileOwner}).
+			GroupBy("Channels.TeamId", "FileInfo.ChannelId").
+			OrderBy("Channels.TeamId", "FileInfo.ChannelId")
+
+		var globalRows []retentionPolicyDryRunRow
+		if err := s.GetReplicaX().SelectBuilder(&globalRows, builder); err != nil {
+			return nil, err
+		}
+		rows = append(rows, globalRows...)
+	}
+
+	return rows, nil
+}
+
+// getDryRunRows groups the records selected by `baseBuilder` which fall under
+// the scope of the retention policies by policy and channel, using the same
+// queries as `genericPermanentDeleteBatchForRetentionPolicies` for posts.
+func (s *SqlRetentionPolicyStore) getDryRunRows(baseBuilder sq.SelectBuilder, policyID string, now, globalPolicyEndTime int64) ([]retentionPolicyDryRunRow, error) {
+	channelPoliciesBuilder, teamPoliciesBuilder, globalPolicyBuilder := retentionPolicyScopeBuilders(RetentionPolicyBatchDeletionInfo{
+		BaseBuilder:         baseBuilder,
+		Table:               "Posts",
+		TimeColumn:          "CreateAt",
+		PrimaryKeys:         []string{"Id"},
+		ChannelIDTable:      "Posts",
+		NowMillis:           now,
+		GlobalPolicyEndTime: globalPolicyEndTime,
+	})
+
+	countColumns := []string{
+		"Channels.TeamId AS TeamId",
+		"Posts.ChannelId AS ChannelId",
+		"COUNT(*) AS RecordCount",
+		"MIN(Posts.CreateAt) AS OldestAt",
+		"MAX(Posts.CreateAt) AS NewestAt",
+	}
+
+	var builders []sq.SelectBuilder
+	if now > 0 {
+		for _, builder := range []sq.SelectBuilder{channelPoliciesBuilder, teamPoliciesBuilder} {
+			if policyID != "" {
+				builder = builder.Where(sq.Eq{"RetentionPolicies.Id": policyID})
+			}
+			builders = append(builders, builder.
+				Columns("RetentionPolicies.Id AS PolicyId").
+				Columns(countColumns...).
+				GroupBy("RetentionPolicies.Id", "Channels.TeamId", "Posts.ChannelId").
+				OrderBy("RetentionPolicies.Id", "Channels.TeamId", "Posts.ChannelId"))
+		}
+	}
+	if globalPolicyEndTime > 0 {
+		builders = append(builders, globalPolicyBuilder.
+			Columns("'' AS PolicyId").
+			Columns(countColumns...).
+			GroupBy("Channels.TeamId", "Posts.ChannelId").
+			OrderBy("Channels.TeamId", "Posts.ChannelId"))
+	}
+
+	rows := []retentionPolicyDryRunRow{}
+	for _, builder := range builders {
+		var scopeRows []retentionPolicyDryRunRow
+		if err := s.GetReplicaX().SelectBuilder(&scopeRows, builder); err != nil {
+			return nil, err
+		}
+		rows = append(rows, scopeRows...)
+	}
+
+	return rows, nil
+}
+
 func insertRetentionIdsForDeletion(txn *sqlxTxWrapper, row *model.RetentionIdsForDeletion, s *SqlStore) error {
 	row.PreSave()
 	insertBuilder := s.getQueryBuilder().
@@ -935,13 +1105,11 @@ type RetentionPolicyBatchDeletionInfo struct {
 	StoreDeletedIds     bool
 }
 
-// genericPermanentDeleteBatchForRetentionPolicies is a helper function for tables
-// which need to delete records for granular and global policies.
-func genericPermanentDeleteBatchForRetentionPolicies(
-	r RetentionPolicyBatchDeletionInfo,
-	s *SqlStore,
-	cursor model.RetentionPolicyCursor,
-) (int64, model.RetentionPolicyCursor, error) {
+// retentionPolicyScopeBuilders returns the queries selecting the records of
+// `r.BaseBuilder` which fall under the scope of the channel-specific policies,
+// the team-specific policies and the global policy, respectively. The queries
+// are shared by the deletion and the dry run so that both select the same records.
+func retentionPolicyScopeBuilders(r RetentionPolicyBatchDeletionInfo) (channelPoliciesBuilder, teamPoliciesBuilder, globalPolicyBuilder sq.SelectBuilder) {
 	baseBuilder := r.BaseBuilder.InnerJoin("Channels ON " + r.ChannelIDTable + ".ChannelId = Channels.Id")
 
 	scopedTimeColumn := r.Table + "." + r.TimeColumn
@@ -955,6 +1123,45 @@ func genericPermanentDeleteBatchForRetentionPolicies(
 		sq.Expr(nowStr + " - " + scopedTimeColumn + " > RetentionPolicies.PostDuration * " + strconv.FormatInt(millisecondsInADay, 10)),
 	}
 
+	channelPoliciesBuilder = baseBuilder.
+		InnerJoin("RetentionPoliciesChannels ON " + r.ChannelIDTable + ".ChannelId = RetentionPoliciesChannels.ChannelId").
+		InnerJoin("RetentionPolicies ON RetentionPoliciesChannels.PolicyId = RetentionPolicies.Id").
+		Where(fallsUnderGranularPolicy)
+
+	// Channel-specific policies override team-specific policies.
+	teamPoliciesBuilder = baseBuilder.
+		LeftJoin("RetentionPoliciesChannels ON " + r.ChannelIDTable + ".ChannelId = RetentionPoliciesChannels.ChannelId").
+		InnerJoin("RetentionPoliciesTeams ON Channels.TeamId = RetentionPoliciesTeams.TeamId").
+		InnerJoin("RetentionPolicies ON RetentionPoliciesTeams.PolicyId = RetentionPolicies.Id").
+		Where(sq.And{
+			sq.Eq{"RetentionPoliciesChannels.PolicyId": nil},
+			sq.Expr("RetentionPoliciesTeams.PolicyId = RetentionPolicies.Id"),
+		}).
+		Where(fallsUnderGranularPolicy)
+
+	// Granular policies override the global policy.
+	globalPolicyBuilder = baseBuilder.
+		LeftJoin("RetentionPoliciesChannels ON " + r.ChannelIDTable + ".ChannelId = RetentionPoliciesChannels.ChannelId").
+		LeftJoin("RetentionPoliciesTeams ON Channels.TeamId = RetentionPoliciesTeams.TeamId").
+		LeftJoin("RetentionPolicies ON RetentionPoliciesChannels.PolicyId = RetentionPolicies.Id").
+		Where(sq.And{
+			sq.Eq{"RetentionPoliciesChannels.PolicyId": nil},
+			sq.Eq{"RetentionPoliciesTeams.PolicyId": nil},
+		}).
+		Where(sq.Lt{scopedTimeColumn: r.GlobalPolicyEndTime})
+
+	return channelPoliciesBuilder, teamPoliciesBuilder, globalPolicyBuilder
+}
+
+// genericPermanentDeleteBatchForRetentionPolicies is a helper function for tables
+// which need to delete records for granular and global policies.
+func genericPermanentDeleteBatchForRetentionPolicies(
+	r RetentionPolicyBatchDeletionInfo,
+	s *SqlStore,
+	cursor model.RetentionPolicyCursor,
+) (int64, model.RetentionPolicyCursor, error) {
+	channelPoliciesBuilder, teamPoliciesBuilder, globalPolicyBuilder := retentionPolicyScopeBuilders(r)
+
 	// If the caller wants to disable the global policy from running
 	if r.GlobalPolicyEndTime <= 0 {
 		cursor.GlobalPoliciesDone = true
@@ -969,12 +1176,7 @@ func genericPermanentDeleteBatchForRetentionPolicies(
 
 	// First, delete all of the records which fall under the scope of a channel-specific policy
 	if !cursor.ChannelPoliciesDone {
-		channelPoliciesBuilder := baseBuilder.
-			InnerJoin("RetentionPoliciesChannels ON " + r.ChannelIDTable + ".ChannelId = RetentionPoliciesChannels.ChannelId").
-			InnerJoin("RetentionPolicies ON RetentionPoliciesChannels.PolicyId = RetentionPolicies.Id").
-			Where(fallsUnderGranularPolicy).
-			Limit(uint64(r.Limit))
-		rowsAffected, err := genericRetentionPoliciesDeletion(channelPoliciesBuilder, r, s)
+		rowsAffected, err := genericRetentionPoliciesDeletion(channelPoliciesBuilder.Limit(uint64(r.Limit)), r, s)
 		if err != nil {
 			return 0, cursor, err
 		}
@@ -987,18 +1189,7 @@ func genericPermanentDeleteBatchForRetentionPolicies(
 
 	// Next, delete all of the records which fall under the scope of a team-specific policy
 	if cursor.ChannelPoliciesDone && !cursor.TeamPoliciesDone {
-		// Channel-specific policies override team-specific policies.
-		teamPoliciesBuilder := baseBuilder.
-			LeftJoin("RetentionPoliciesChannels ON " + r.ChannelIDTable + ".ChannelId = RetentionPoliciesChannels.ChannelId").
-			InnerJoin("RetentionPoliciesTeams ON Channels.TeamId = RetentionPoliciesTeams.TeamId").
-			InnerJoin("RetentionPolicies ON RetentionPoliciesTeams.PolicyId = RetentionPolicies.Id").
-			Where(sq.And{
-				sq.Eq{"RetentionPoliciesChannels.PolicyId": nil},
-				sq.Expr("RetentionPoliciesTeams.PolicyId = RetentionPolicies.Id"),
-			}).
-			Where(fallsUnderGranularPolicy).
-			Limit(uint64(r.Limit))
-		rowsAffected, err := genericRetentionPoliciesDeletion(teamPoliciesBuilder, r, s)
+		rowsAffected, err := genericRetentionPoliciesDeletion(teamPoliciesBuilder.Limit(uint64(r.Limit)), r, s)
 		if err != nil {
 			return 0, cursor, err
 		}
@@ -1011,18 +1202,7 @@ func genericPermanentDeleteBatchForRetentionPolicies(
 
 	// Finally, delete all of the records which fall under the scope of the global policy
 	if cursor.ChannelPoliciesDone && cursor.TeamPoliciesDone && !cursor.GlobalPoliciesDone {
-		// Granular policies override the global policy.
-		globalPolicyBuilder := baseBuilder.
-			LeftJoin("RetentionPoliciesChannels ON " + r.ChannelIDTable + ".ChannelId = RetentionPoliciesChannels.ChannelId").
-			LeftJoin("RetentionPoliciesTeams ON Channels.TeamId = RetentionPoliciesTeams.TeamId").
-			LeftJoin("RetentionPolicies ON RetentionPoliciesChannels.PolicyId = RetentionPolicies.Id").
-			Where(sq.And{
-				sq.Eq{"RetentionPoliciesChannels.PolicyId": nil},
-				sq.Eq{"RetentionPoliciesTeams.PolicyId": nil},
-			}).
-			Where(sq.Lt{scopedTimeColumn: r.GlobalPolicyEndTime}).
-			Limit(uint64(r.Limit))
-		rowsAffected, err := genericRetentionPoliciesDeletion(globalPolicyBuilder, r, s)
+		rowsAffected, err := genericRetentionPoliciesDeletion(globalPolicyBuilder.Limit(uint64(r.Limit)), r, s)
 		if err != nil {
 			return 0, cursor, err
 		}
@@ -148,6 +148,10 @@ func (s *SqlRoleStore) createRole(role *model.Role, transaction *sqlxTxWrapper)
 		(Id, Name, DisplayName, Description, Permissions, CreateAt, UpdateAt, DeleteAt, SchemeManaged, BuiltIn)
 		VALUES
 		(:Id, :Name, :DisplayName, :Description, :Permissions, :CreateAt, :UpdateAt, :DeleteAt, :SchemeManaged, :BuiltIn)`, dbRole); err != nil {
+		// Deleted roles keep their name
+		if IsUniqueConstraintError(err, []string{"Name", "roles_name_key"}) {
+			return nil, store.NewErrConflict("Role", err, "name="+role.Name)
+		}
 		return nil, errors.Wrap(err, "failed to save Role")
 	}
 
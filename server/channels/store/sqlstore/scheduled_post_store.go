@@ -45,6 +45,8 @@ func (s *SqlScheduledPostStore) columns(prefix string) []string {
 		prefix + "ScheduledAt",
 		prefix + "ProcessedAt",
 		prefix + "ErrorCode",
+		prefix + "Recurrence",
+		prefix + "PausedAt",
 	}
 }
 
@@ -63,6 +65,8 @@ func (s *SqlScheduledPostStore) scheduledPostToSlice(scheduledPost *model.Schedu
 		scheduledPost.ScheduledAt,
 		scheduledPost.ProcessedAt,
 		scheduledPost.ErrorCode,
+		scheduledPost.Recurrence,
+		scheduledPost.PausedAt,
 	}
 }
 
@@ -122,6 +126,25 @@ func (s *SqlScheduledPostStore) GetScheduledPostsForUser(userId, teamId string)
 	return scheduledPosts, nil
 }
 
+func (s *SqlScheduledPostStore) GetScheduledPostSeriesForUser(userId string) ([]*model.ScheduledPost, error) {
+	query := s.getQueryBuilder().
+		Select(s.columns("")...).
+		From("ScheduledPosts").
+		Where(sq.Eq{"UserId": userId}).
+		Where(sq.NotEq{"Recurrence": nil}).
+		OrderBy("ScheduledAt, CreateAt")
+
+	var scheduledPosts []*model.ScheduledPost
+
+	if err := s.GetReplicaX().SelectBuilder(&scheduledPosts, query); err != nil {
+		mlog.Error("SqlScheduledPostStore.GetScheduledPostSeriesForUser: failed to fetch recurring scheduled posts for user", mlog.String("user_id", userId), mlog.Err(err))
+
+		return nil, errors.Wrapf(err, "SqlScheduledPostStore.GetScheduledPostSeriesForUser: failed to fetch recurring scheduled posts for user, userId: %s", userId)
+	}
+
+	return scheduledPosts, nil
+}
+
 func (s *SqlScheduledPostStore) GetMaxMessageSize() int {
 	s.maxMessageSizeOnce.Do(func() {
 		var err error
@@ -139,23 +162,27 @@ func (s *SqlScheduledPostStore) GetPendingScheduledPosts(beforeTime, afterTime i
 	query := s.getQueryBuilder().
 		Select(s.columns("")...).
 		From("ScheduledPosts").
-		Where(sq.Eq{"ErrorCode": ""}).
+		Where(sq.Eq{"ErrorCode": "", "PausedAt": 0}).
 		OrderBy("ScheduledAt DESC", "Id").
 		Limit(perPage)
 
-	if lastScheduledPostId == "" {
-		query = query.Where(sq.And{
-			sq.LtOrEq{"ScheduledAt": beforeTime},
+	// Recurring scheduled posts older than afterTime are still returned
+	// so that the job can move them to their next occurrence.
+	pendingCondition := sq.And{
+		sq.LtOrEq{"ScheduledAt": beforeTime},
+		sq.Or{
 			sq.GtOrEq{"ScheduledAt": afterTime},
-		})
+			sq.NotEq{"Recurrence": nil},
+		},
+	}
+
+	if lastScheduledPostId == "" {
+		query = query.Where(pendingCondition)
 	}
 	if lastScheduledPostId != "" {
 		query = query.
 			Where(sq.Or{
-				sq.And{
-					sq.LtOrEq{"ScheduledAt": beforeTime},
-					sq.GtOrEq{"ScheduledAt": afterTime},
-				},
+				pendingCondition,
 				sq.And{
 					sq.Eq{"ScheduledAt": beforeTime},
 					sq.Gt{"Id": lastScheduledPostId},
@@ -241,6 +268,8 @@ func (s *SqlScheduledPostStore) toUpdateMap(scheduledPost *model.ScheduledPost)
 		"ScheduledAt": scheduledPost.ScheduledAt,
 		"ProcessedAt": now,
 		"ErrorCode":   scheduledPost.ErrorCode,
+		"Recurrence":  scheduledPost.Recurrence,
+		"PausedAt":    scheduledPost.PausedAt,
 	}
 }
 
@@ -269,7 +298,7 @@ func (s *SqlScheduledPostStore) UpdateOldScheduledPosts(beforeTime int64) error
 		Set("ErrorCode", model.ScheduledPostErrorUnableToSend).
 		Set("ProcessedAt", model.GetMillis()).
 		Where(sq.And{
-			sq.Eq{"ErrorCode": ""},
+			sq.Eq{"ErrorCode": "", "PausedAt": 0, "Recurrence": nil},
 			sq.Lt{"ScheduledAt": beforeTime},
 		})
 
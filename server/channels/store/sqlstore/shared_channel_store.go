@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
@@ -681,6 +682,90 @@ func (s SqlSharedChannelStore) GetRemotesStatus(channelId string) ([]*model.Shar
 	return status, nil
 }
 
+// GetRemotesSyncStatus returns the synchronization status of each remote invited to the
+// specified shared channel.
+func (s SqlSharedChannelStore) GetRemotesSyncStatus(channelId string) ([]*model.SharedChannelRemoteSyncStatus, error) {
+	status := []*model.SharedChannelRemoteSyncStatus{}
+
+	// Files are pending when they were posted after the remote was invited, and have either
+	// never been sent to it or changed since.
+	pendingAttachments := `(SELECT COUNT(*) FROM FileInfo fi
+		LEFT JOIN SharedChannelAttachments sca ON sca.FileId = fi.Id AND sca.RemoteId = scr.RemoteId
+		WHERE fi.ChannelId = scr.ChannelId
+		AND fi.PostId != ''
+		AND fi.DeleteAt = 0
+		AND fi.CreateAt >= scr.CreateAt
+		AND COALESCE(fi.RemoteId, '') != scr.RemoteId
+		AND (sca.Id IS NULL OR sca.LastSyncAt < fi.UpdateAt)) AS PendingAttachments`
+
+	query := s.getQueryBuilder().
+		Select(
+			"scr.ChannelId",
+			"scr.RemoteId",
+			"rc.DisplayName",
+			"rc.SiteURL",
+			"scr.IsInviteAccepted",
+			"rc.LastPingAt",
+			"scr.LastPostUpdateAt AS LastSyncAt",
+			pendingAttachments,
+			"COALESCE(scr.LastSyncError, '') AS LastSyncError",
+			"COALESCE(scr.LastSyncErrorAt, 0) AS LastSyncErrorAt",
+		).
+		From("SharedChannelRemotes scr").
+		Join("RemoteClusters rc ON rc.RemoteId = scr.RemoteId").
+		Where(sq.Eq{
+			"scr.ChannelId": channelId,
+			"scr.DeleteAt":  0,
+		}).
+		OrderBy("rc.DisplayName", "scr.RemoteId")
+
+	squery, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrapf(err, "get_shared_channel_remotes_sync_status_tosql")
+	}
+
+	if err := s.GetReplicaX().Select(&status, squery, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get shared channel remote sync status for channel_id=%s", channelId)
+	}
+	return status, nil
+}
+
+// UpdateRemoteSyncError records the error of a failed synchronization of a shared channel with a
+// remote. An empty error clears the previous one after a successful synchronization.
+func (s SqlSharedChannelStore) UpdateRemoteSyncError(channelId string, remoteId string, syncErr string) error {
+	builder := s.getQueryBuilder().
+		Update("SharedChannelRemotes").
+		Where(sq.Eq{
+			"ChannelId": channelId,
+			"RemoteId":  remoteId,
+		})
+
+	if syncErr == "" {
+		// avoid writing on every successful synchronization
+		builder = builder.
+			Set("LastSyncError", "").
+			Set("LastSyncErrorAt", 0).
+			Where(sq.Gt{"LastSyncErrorAt": 0})
+	} else {
+		if utf8.RuneCountInString(syncErr) > model.SharedChannelRemoteSyncErrorMaxRunes {
+			syncErr = string([]rune(syncErr)[:model.SharedChannelRemoteSyncErrorMaxRunes])
+		}
+		builder = builder.
+			Set("LastSyncError", syncErr).
+			Set("LastSyncErrorAt", model.GetMillis())
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "update_shared_channel_remote_sync_error_tosql")
+	}
+
+	if _, err := s.GetMasterX().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to update sync error for SharedChannelRemote channel_id=%s, remote_id=%s", channelId, remoteId)
+	}
+	return nil
+}
+
 func sharedChannelUserFields(prefix string) []string {
 	if prefix != "" && !strings.HasSuffix(prefix, ".") {
 		prefix = prefix + "."
@@ -112,6 +112,8 @@ type SqlStoreStores struct {
 	desktopTokens              store.DesktopTokensStore
 	channelBookmarks           store.ChannelBookmarkStore
 	scheduledPost              store.ScheduledPostStore
+	outOfOffice                store.OutOfOfficeStore
+	notificationEscalation     store.PersistentNotificationEscalationStore
 }
 
 type SqlStore struct {
@@ -238,6 +240,8 @@ func New(settings model.SqlSettings, logger mlog.LoggerIFace, metrics einterface
 	store.stores.desktopTokens = newSqlDesktopTokensStore(store, metrics)
 	store.stores.channelBookmarks = newSqlChannelBookmarkStore(store)
 	store.stores.scheduledPost = newScheduledPostStore(store)
+	store.stores.outOfOffice = newSqlOutOfOfficeStore(store)
+	store.stores.notificationEscalation = newSqlPersistentNotificationEscalationStore(store)
 
 	store.stores.preference.(*SqlPreferenceStore).deleteUnusedFeatures()
 
@@ -1344,3 +1348,11 @@ func (ss *SqlStore) determineMaxColumnSize(tableName, columnName string) (int, e
 func (ss *SqlStore) ScheduledPost() store.ScheduledPostStore {
 	return ss.stores.scheduledPost
 }
+
+func (ss *SqlStore) OutOfOffice() store.OutOfOfficeStore {
+	return ss.stores.outOfOffice
+}
+
+func (ss *SqlStore) PersistentNotificationEscalation() store.PersistentNotificationEscalationStore {
+	return ss.stores.notificationEscalation
+}
@@ -43,9 +43,9 @@ func (s SqlWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.In
 	}
 
 	if _, err := s.GetMasterX().NamedExec(`INSERT INTO IncomingWebhooks
-		(Id, CreateAt, UpdateAt, DeleteAt, UserId, ChannelId, TeamId, DisplayName, Description, Username, IconURL, ChannelLocked)
+		(Id, CreateAt, UpdateAt, DeleteAt, UserId, ChannelId, TeamId, DisplayName, Description, Username, IconURL, ChannelLocked, PayloadFormat, Secret)
 		VALUES
-		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :UserId, :ChannelId, :TeamId, :DisplayName, :Description, :Username, :IconURL, :ChannelLocked)`, webhook); err != nil {
+		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :UserId, :ChannelId, :TeamId, :DisplayName, :Description, :Username, :IconURL, :ChannelLocked, :PayloadFormat, :Secret)`, webhook); err != nil {
 		return nil, errors.Wrapf(err, "failed to save IncomingWebhook with id=%s", webhook.Id)
 	}
 
@@ -57,7 +57,8 @@ func (s SqlWebhookStore) UpdateIncoming(hook *model.IncomingWebhook) (*model.Inc
 
 	_, err := s.GetMasterX().NamedExec(`UPDATE IncomingWebhooks SET
 			CreateAt=:CreateAt, UpdateAt=:UpdateAt, DeleteAt=:DeleteAt, ChannelId=:ChannelId, TeamId=:TeamId, DisplayName=:DisplayName,
-			Description=:Description, Username=:Username, IconURL=:IconURL, ChannelLocked=:ChannelLocked
+			Description=:Description, Username=:Username, IconURL=:IconURL, ChannelLocked=:ChannelLocked,
+			PayloadFormat=:PayloadFormat, Secret=:Secret
 			WHERE Id=:Id`, hook)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to update IncomingWebhook with id=%s", hook.Id)
@@ -185,11 +186,11 @@ func (s SqlWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*model.Ou
 	}
 
 	if _, err := s.GetMasterX().NamedExec(`INSERT INTO OutgoingWebhooks
-			(Id, Token, CreateAt, UpdateAt, DeleteAt, CreatorId, ChannelId, TeamId, TriggerWords, TriggerWhen,
-			CallbackURLs, DisplayName, Description, ContentType, Username, IconURL)
+			(Id, Token, SigningSecret, CreateAt, UpdateAt, DeleteAt, CreatorId, ChannelId, TeamId, TriggerWords, TriggerWhen,
+			CallbackURLs, DisplayName, Description, ContentType, Username, IconURL, PayloadTemplate, Headers)
 			VALUES
-			(:Id, :Token, :CreateAt, :UpdateAt, :DeleteAt, :CreatorId, :ChannelId, :TeamId, :TriggerWords, :TriggerWhen,
-			:CallbackURLs, :DisplayName, :Description, :ContentType, :Username, :IconURL)`, webhook); err != nil {
+			(:Id, :Token, :SigningSecret, :CreateAt, :UpdateAt, :DeleteAt, :CreatorId, :ChannelId, :TeamId, :TriggerWords, :TriggerWhen,
+			:CallbackURLs, :DisplayName, :Description, :ContentType, :Username, :IconURL, :PayloadTemplate, :Headers)`, webhook); err != nil {
 		return nil, errors.Wrapf(err, "failed to save OutgoingWebhook with id=%s", webhook.Id)
 	}
 
@@ -218,11 +219,14 @@ func (s SqlWebhookStore) GetOutgoingListByUser(userId string, offset, limit int)
 		From("OutgoingWebhooks").
 		Where(sq.And{
 			sq.Eq{"DeleteAt": int(0)},
-		}).Limit(uint64(limit)).Offset(uint64(offset))
+		})
 
 	if userId != "" {
 		query = query.Where(sq.Eq{"CreatorId": userId})
 	}
+	if limit >= 0 && offset >= 0 {
+		query = query.Limit(uint64(limit)).Offset(uint64(offset))
+	}
 
 	queryString, args, err := query.ToSql()
 	if err != nil {
@@ -341,10 +345,11 @@ func (s SqlWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.Out
 	hook.UpdateAt = model.GetMillis()
 
 	_, err := s.GetMasterX().NamedExec(`UPDATE OutgoingWebhooks SET
-			CreateAt = :CreateAt, UpdateAt = :UpdateAt, DeleteAt = :DeleteAt, Token = :Token, CreatorId = :CreatorId,
+			CreateAt = :CreateAt, UpdateAt = :UpdateAt, DeleteAt = :DeleteAt, Token = :Token, SigningSecret = :SigningSecret, CreatorId = :CreatorId,
 			ChannelId = :ChannelId, TeamId = :TeamId, TriggerWords = :TriggerWords, TriggerWhen = :TriggerWhen,
 			CallbackURLs = :CallbackURLs, DisplayName = :DisplayName, Description = :Description,
-			ContentType = :ContentType, Username = :Username, IconURL = :IconURL WHERE Id = :Id`, hook)
+			ContentType = :ContentType, Username = :Username, IconURL = :IconURL,
+			PayloadTemplate = :PayloadTemplate, Headers = :Headers WHERE Id = :Id`, hook)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to update OutgoingWebhook with id=%s", hook.Id)
 	}
@@ -352,6 +357,119 @@ func (s SqlWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.Out
 	return hook, nil
 }
 
+func (s SqlWebhookStore) SaveOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	if delivery.Id != "" {
+		return nil, store.NewErrInvalidInput("OutgoingWebhookDelivery", "id", delivery.Id)
+	}
+
+	delivery.PreSave()
+	if err := delivery.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetMasterX().NamedExec(`INSERT INTO OutgoingWebhookDeliveries
+			(Id, HookId, PostId, ChannelId, CallbackURL, ContentType, Payload, Status, Attempts, StatusCode,
+			Latency, Error, ResponseExcerpt, CreateAt, UpdateAt, NextAttemptAt)
+			VALUES
+			(:Id, :HookId, :PostId, :ChannelId, :CallbackURL, :ContentType, :Payload, :Status, :Attempts, :StatusCode,
+			:Latency, :Error, :ResponseExcerpt, :CreateAt, :UpdateAt, :NextAttemptAt)`, delivery); err != nil {
+		return nil, errors.Wrapf(err, "failed to save OutgoingWebhookDelivery with id=%s", delivery.Id)
+	}
+
+	return delivery, nil
+}
+
+func (s SqlWebhookStore) UpdateOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	delivery.PreUpdate()
+	if err := delivery.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetMasterX().NamedExec(`UPDATE OutgoingWebhookDeliveries SET
+			Status = :Status, Attempts = :Attempts, StatusCode = :StatusCode, Latency = :Latency, Error = :Error,
+			ResponseExcerpt = :ResponseExcerpt, UpdateAt = :UpdateAt, NextAttemptAt = :NextAttemptAt WHERE Id = :Id`, delivery); err != nil {
+		return nil, errors.Wrapf(err, "failed to update OutgoingWebhookDelivery with id=%s", delivery.Id)
+	}
+
+	return delivery, nil
+}
+
+func (s SqlWebhookStore) GetOutgoingDelivery(id string) (*model.OutgoingWebhookDelivery, error) {
+	var delivery model.OutgoingWebhookDelivery
+
+	if err := s.GetReplicaX().Get(&delivery, "SELECT * FROM OutgoingWebhookDeliveries WHERE Id = ?", id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("OutgoingWebhookDelivery", id)
+		}
+
+		return nil, errors.Wrapf(err, "failed to get OutgoingWebhookDelivery with id=%s", id)
+	}
+
+	return &delivery, nil
+}
+
+func (s SqlWebhookStore) GetOutgoingDeliveriesForHook(hookId string, offset, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	deliveries := []*model.OutgoingWebhookDelivery{}
+
+	query := s.getQueryBuilder().
+		Select("*").
+		From("OutgoingWebhookDeliveries").
+		Where(sq.Eq{"HookId": hookId}).
+		OrderBy("CreateAt DESC", "Id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "outgoing_webhook_delivery_tosql")
+	}
+
+	if err := s.GetReplicaX().Select(&deliveries, queryString, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to find OutgoingWebhookDeliveries with hookId=%s", hookId)
+	}
+
+	return deliveries, nil
+}
+
+func (s SqlWebhookStore) GetOutgoingDeliveriesToRetry(now int64, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	deliveries := []*model.OutgoingWebhookDelivery{}
+
+	query := s.getQueryBuilder().
+		Select("*").
+		From("OutgoingWebhookDeliveries").
+		Where(sq.And{
+			sq.Eq{"Status": model.OutgoingWebhookDeliveryStatusRetrying},
+			sq.LtOrEq{"NextAttemptAt": now},
+		}).
+		OrderBy("NextAttemptAt", "Id").
+		Limit(uint64(limit))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "outgoing_webhook_delivery_tosql")
+	}
+
+	if err := s.GetMasterX().Select(&deliveries, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find OutgoingWebhookDeliveries to retry")
+	}
+
+	return deliveries, nil
+}
+
+func (s SqlWebhookStore) PermanentDeleteOutgoingDeliveriesBefore(before int64) (int64, error) {
+	result, err := s.GetMasterX().Exec("DELETE FROM OutgoingWebhookDeliveries WHERE CreateAt < ?", before)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete OutgoingWebhookDeliveries")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to retrieve rows affected")
+	}
+
+	return rowsAffected, nil
+}
+
 func (s SqlWebhookStore) AnalyticsIncomingCount(teamID string, userID string) (int64, error) {
 	queryBuilder :=
 		s.getQueryBuilder().
@@ -92,6 +92,8 @@ type Store interface {
 	DesktopTokens() DesktopTokensStore
 	ChannelBookmark() ChannelBookmarkStore
 	ScheduledPost() ScheduledPostStore
+	OutOfOffice() OutOfOfficeStore
+	PersistentNotificationEscalation() PersistentNotificationEscalationStore
 }
 
 type RetentionPolicyStore interface {
@@ -115,6 +117,7 @@ type RetentionPolicyStore interface {
 	GetChannelPoliciesForUser(userID string, offset, limit int) ([]*model.RetentionPolicyForChannel, error)
 	GetChannelPoliciesCountForUser(userID string) (int64, error)
 	GetIdsForDeletionByTableName(tableName string, limit int) ([]*model.RetentionIdsForDeletion, error)
+	GetDryRun(policyID string, now, globalPolicyEndTime, globalFileEndTime int64) ([]*model.RetentionPolicyDryRunChannel, error)
 }
 
 type TeamStore interface {
@@ -405,6 +408,8 @@ type PostStore interface {
 	GetPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error)
 	SetPostReminder(reminder *model.PostReminder) error
 	GetPostReminders(now int64) ([]*model.PostReminder, error)
+	GetPostRemindersForUser(userID string) ([]*model.PostReminder, error)
+	DeletePostReminder(postID, userID string) error
 	GetPostReminderMetadata(postID string) (*PostReminderMetadata, error)
 	// GetNthRecentPostTime returns the CreateAt time of the nth most recent post.
 	GetNthRecentPostTime(n int64) (int64, error)
@@ -630,6 +635,13 @@ type WebhookStore interface {
 	PermanentDeleteOutgoingByUser(userID string) error
 	UpdateOutgoing(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, error)
 
+	SaveOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error)
+	UpdateOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error)
+	GetOutgoingDelivery(id string) (*model.OutgoingWebhookDelivery, error)
+	GetOutgoingDeliveriesForHook(hookID string, offset, limit int) ([]*model.OutgoingWebhookDelivery, error)
+	GetOutgoingDeliveriesToRetry(now int64, limit int) ([]*model.OutgoingWebhookDelivery, error)
+	PermanentDeleteOutgoingDeliveriesBefore(before int64) (int64, error)
+
 	AnalyticsIncomingCount(teamID string, userID string) (int64, error)
 	AnalyticsOutgoingCount(teamID string) (int64, error)
 	InvalidateWebhookCache(webhook string)
@@ -1000,6 +1012,8 @@ type SharedChannelStore interface {
 	UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error
 	DeleteRemote(remoteID string) (bool, error)
 	GetRemotesStatus(channelID string) ([]*model.SharedChannelRemoteStatus, error)
+	GetRemotesSyncStatus(channelID string) ([]*model.SharedChannelRemoteSyncStatus, error)
+	UpdateRemoteSyncError(channelID string, remoteID string, syncErr string) error
 
 	SaveUser(remote *model.SharedChannelUser) (*model.SharedChannelUser, error)
 	GetSingleUser(userID string, channelID string, remoteID string) (*model.SharedChannelUser, error)
@@ -1024,6 +1038,8 @@ type DraftStore interface {
 	Delete(userID, channelID, rootID string) error
 	DeleteDraftsAssociatedWithPost(channelID, rootID string) error
 	GetDraftsForUser(userID, teamID string) ([]*model.Draft, error)
+	GetRevisions(userID, channelID, rootID string) ([]*model.Draft, error)
+	GetRevision(userID, channelID, rootID string, version int64) (*model.Draft, error)
 	GetLastCreateAtAndUserIdValuesForEmptyDraftsMigration(createAt int64, userID string) (int64, string, error)
 	DeleteEmptyDraftsByCreateAtAndUserId(createAt int64, userID string) error
 	DeleteOrphanDraftsByCreateAtAndUserId(createAt int64, userID string) error
@@ -1046,6 +1062,17 @@ type PostPersistentNotificationStore interface {
 	DeleteByChannel(channelIds []string) error
 	DeleteByTeam(teamIds []string) error
 }
+
+type PersistentNotificationEscalationStore interface {
+	Save(escalation *model.PersistentNotificationEscalation) (*model.PersistentNotificationEscalation, error)
+	Get(id string) (*model.PersistentNotificationEscalation, error)
+	// GetForChannels returns the escalation steps of the given channels,
+	// ordered by the number of notifications after which they happen.
+	GetForChannels(channelIds []string) ([]*model.PersistentNotificationEscalation, error)
+	Delete(id string) error
+	DeleteByChannel(channelIds []string) error
+}
+
 type ChannelBookmarkStore interface {
 	ErrorIfBookmarkFileInfoAlreadyAttached(fileID string) error
 	Get(Id string, includeDeleted bool) (b *model.ChannelBookmarkWithFileInfo, err error)
@@ -1060,6 +1087,7 @@ type ScheduledPostStore interface {
 	GetMaxMessageSize() int
 	CreateScheduledPost(scheduledPost *model.ScheduledPost) (*model.ScheduledPost, error)
 	GetScheduledPostsForUser(userId, teamId string) ([]*model.ScheduledPost, error)
+	GetScheduledPostSeriesForUser(userId string) ([]*model.ScheduledPost, error)
 	GetPendingScheduledPosts(beforeTime, afterTime int64, lastScheduledPostId string, perPage uint64) ([]*model.ScheduledPost, error)
 	PermanentlyDeleteScheduledPosts(scheduledPostIDs []string) error
 	UpdatedScheduledPost(scheduledPost *model.ScheduledPost) error
@@ -1067,6 +1095,16 @@ type ScheduledPostStore interface {
 	UpdateOldScheduledPosts(beforeTime int64) error
 }
 
+type OutOfOfficeStore interface {
+	Save(outOfOffice *model.OutOfOffice) (*model.OutOfOffice, error)
+	Get(userID string) (*model.OutOfOffice, error)
+	Delete(userID string) error
+	SetActive(userID string, active bool) error
+	// GetPendingTransitions returns the out of office settings whose active
+	// state no longer matches their schedule at the given time.
+	GetPendingTransitions(now int64, limit int) ([]*model.OutOfOffice, error)
+}
+
 // ChannelSearchOpts contains options for searching channels.
 //
 // NotAssociatedToGroup will exclude channels that have associated, active GroupChannels records.
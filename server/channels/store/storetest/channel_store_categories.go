@@ -661,6 +661,33 @@ func testCreateSidebarCategory(t *testing.T, rctx request.CTX, ss store.Store) {
 		assert.Equal(t, model.SidebarCategorySortManual, res.Categories[1].Sorting)
 		assert.Equal(t, model.SidebarCategorySortManual, created.Sorting)
 	})
+
+	t.Run("should store the category rules", func(t *testing.T) {
+		userID, teamID := setupInitialSidebarCategories(t, rctx, ss)
+
+		rules := model.SidebarCategoryRules{
+			{Type: model.SidebarCategoryRuleNamePrefix, Value: "inc-"},
+			{Type: model.SidebarCategoryRuleShared},
+		}
+		created, err := ss.Channel().CreateSidebarCategory(userID, teamID, &model.SidebarCategoryWithChannels{
+			SidebarCategory: model.SidebarCategory{
+				DisplayName: model.NewId(),
+			},
+			Rules: rules,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, rules, created.Rules)
+
+		got, err := ss.Channel().GetSidebarCategory(created.Id)
+		require.NoError(t, err)
+		assert.Equal(t, rules, got.Rules)
+
+		res, err := ss.Channel().GetSidebarCategoriesForTeamForUser(userID, teamID)
+		require.NoError(t, err)
+		require.Len(t, res.Categories, 4)
+		assert.Equal(t, rules, res.Categories[1].Rules)
+		assert.Nil(t, res.Categories[0].Rules)
+	})
 }
 
 func testGetSidebarCategory(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
@@ -1972,6 +1999,54 @@ func testUpdateSidebarCategories(t *testing.T, rctx request.CTX, ss store.Store)
 		assert.Equal(t, []string{}, originalCategories[1].Channels)
 		assert.Equal(t, []string{channel.Id}, updatedCategories[1].Channels)
 	})
+
+	t.Run("should update the rules of custom categories only", func(t *testing.T) {
+		userID, teamID := setupInitialSidebarCategories(t, rctx, ss)
+
+		initialCategories, err := ss.Channel().GetSidebarCategoriesForTeamForUser(userID, teamID)
+		require.NoError(t, err)
+		channelsCategory := initialCategories.Categories[1]
+
+		customCategory, err := ss.Channel().CreateSidebarCategory(userID, teamID, &model.SidebarCategoryWithChannels{
+			SidebarCategory: model.SidebarCategory{
+				DisplayName: "incidents",
+			},
+		})
+		require.NoError(t, err)
+
+		rules := model.SidebarCategoryRules{{Type: model.SidebarCategoryRuleNamePrefix, Value: "inc-"}}
+		updatedCategories, _, err := ss.Channel().UpdateSidebarCategories(userID, teamID, []*model.SidebarCategoryWithChannels{
+			{SidebarCategory: customCategory.SidebarCategory, Rules: rules},
+			{SidebarCategory: channelsCategory.SidebarCategory, Channels: channelsCategory.Channels, Rules: rules},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, rules, updatedCategories[0].Rules)
+		assert.Nil(t, updatedCategories[1].Rules)
+
+		got, err := ss.Channel().GetSidebarCategory(channelsCategory.Id)
+		require.NoError(t, err)
+		assert.Nil(t, got.Rules)
+
+		// Omitting the rules keeps them
+		_, _, err = ss.Channel().UpdateSidebarCategories(userID, teamID, []*model.SidebarCategoryWithChannels{
+			{SidebarCategory: customCategory.SidebarCategory},
+		})
+		require.NoError(t, err)
+
+		got, err = ss.Channel().GetSidebarCategory(customCategory.Id)
+		require.NoError(t, err)
+		assert.Equal(t, rules, got.Rules)
+
+		// And an empty list clears them
+		_, _, err = ss.Channel().UpdateSidebarCategories(userID, teamID, []*model.SidebarCategoryWithChannels{
+			{SidebarCategory: customCategory.SidebarCategory, Rules: model.SidebarCategoryRules{}},
+		})
+		require.NoError(t, err)
+
+		got, err = ss.Channel().GetSidebarCategory(customCategory.Id)
+		require.NoError(t, err)
+		assert.Empty(t, got.Rules)
+	})
 }
 
 func setupInitialSidebarCategories(t *testing.T, rctx request.CTX, ss store.Store) (string, string) {
@@ -18,6 +18,8 @@ import (
 func TestDraftStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("SaveDraft", func(t *testing.T) { testSaveDraft(t, rctx, ss) })
 	t.Run("UpdateDraft", func(t *testing.T) { testUpdateDraft(t, rctx, ss) })
+	t.Run("UpdateDraftVersion", func(t *testing.T) { testUpdateDraftVersion(t, rctx, ss) })
+	t.Run("DraftRevisions", func(t *testing.T) { testDraftRevisions(t, rctx, ss) })
 	t.Run("DeleteDraft", func(t *testing.T) { testDeleteDraft(t, rctx, ss) })
 	t.Run("DeleteDraftsAssociatedWithPost", func(t *testing.T) { testDeleteDraftsAssociatedWithPost(t, rctx, ss) })
 	t.Run("GetDraft", func(t *testing.T) { testGetDraft(t, rctx, ss) })
@@ -149,6 +151,153 @@ func testUpdateDraft(t *testing.T, rctx request.CTX, ss store.Store) {
 	})
 }
 
+func testUpdateDraftVersion(t *testing.T, rctx request.CTX, ss store.Store) {
+	user := &model.User{
+		Id: model.NewId(),
+	}
+
+	channel := &model.Channel{
+		Id: model.NewId(),
+	}
+
+	member := &model.ChannelMember{
+		ChannelId:   channel.Id,
+		UserId:      user.Id,
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	}
+
+	_, err := ss.Channel().SaveMember(rctx, member)
+	require.NoError(t, err)
+
+	t.Run("should increase the version on every save", func(t *testing.T) {
+		draft, err := ss.Draft().Upsert(&model.Draft{
+			UserId:    user.Id,
+			ChannelId: channel.Id,
+			Message:   "draft",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), draft.Version)
+
+		draft, err = ss.Draft().Upsert(&model.Draft{
+			UserId:    user.Id,
+			ChannelId: channel.Id,
+			Message:   "draft",
+			Version:   1,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), draft.Version)
+
+		draft, err = ss.Draft().Get(user.Id, channel.Id, "", false)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), draft.Version)
+	})
+
+	t.Run("should return a conflict when the version is outdated", func(t *testing.T) {
+		_, err := ss.Draft().Upsert(&model.Draft{
+			UserId:    user.Id,
+			ChannelId: channel.Id,
+			Message:   "outdated",
+			Version:   1,
+		})
+		require.Error(t, err)
+		var cErr *store.ErrConflict
+		assert.ErrorAs(t, err, &cErr)
+
+		draft, err := ss.Draft().Get(user.Id, channel.Id, "", false)
+		require.NoError(t, err)
+		assert.Equal(t, "draft", draft.Message)
+		assert.Equal(t, int64(2), draft.Version)
+	})
+
+	t.Run("should return a conflict when the draft was deleted", func(t *testing.T) {
+		rootID := model.NewId()
+		_, err := ss.Draft().Upsert(&model.Draft{
+			UserId:    user.Id,
+			ChannelId: channel.Id,
+			RootId:    rootID,
+			Message:   "deleted",
+			Version:   1,
+		})
+		require.Error(t, err)
+		var cErr *store.ErrConflict
+		assert.ErrorAs(t, err, &cErr)
+	})
+}
+
+func testDraftRevisions(t *testing.T, rctx request.CTX, ss store.Store) {
+	user := &model.User{
+		Id: model.NewId(),
+	}
+
+	channel := &model.Channel{
+		Id: model.NewId(),
+	}
+
+	member := &model.ChannelMember{
+		ChannelId:   channel.Id,
+		UserId:      user.Id,
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	}
+
+	_, err := ss.Channel().SaveMember(rctx, member)
+	require.NoError(t, err)
+
+	rootID := model.NewId()
+
+	t.Run("should keep previous versions as revisions", func(t *testing.T) {
+		for _, message := range []string{"first", "second", "third"} {
+			_, err := ss.Draft().Upsert(&model.Draft{
+				UserId:    user.Id,
+				ChannelId: channel.Id,
+				RootId:    rootID,
+				Message:   message,
+			})
+			require.NoError(t, err)
+		}
+
+		revisions, err := ss.Draft().GetRevisions(user.Id, channel.Id, rootID)
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+		assert.Equal(t, "second", revisions[0].Message)
+		assert.Equal(t, int64(2), revisions[0].Version)
+		assert.Equal(t, "first", revisions[1].Message)
+		assert.Equal(t, int64(1), revisions[1].Version)
+
+		revision, err := ss.Draft().GetRevision(user.Id, channel.Id, rootID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "first", revision.Message)
+
+		_, err = ss.Draft().GetRevision(user.Id, channel.Id, rootID, 3)
+		var nfErr *store.ErrNotFound
+		assert.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("should keep a bounded number of revisions", func(t *testing.T) {
+		for i := 0; i < model.DraftRevisionsMax+5; i++ {
+			_, err := ss.Draft().Upsert(&model.Draft{
+				UserId:    user.Id,
+				ChannelId: channel.Id,
+				RootId:    rootID,
+				Message:   "message",
+			})
+			require.NoError(t, err)
+		}
+
+		revisions, err := ss.Draft().GetRevisions(user.Id, channel.Id, rootID)
+		require.NoError(t, err)
+		assert.Len(t, revisions, model.DraftRevisionsMax)
+	})
+
+	t.Run("should delete revisions along with the draft", func(t *testing.T) {
+		err := ss.Draft().Delete(user.Id, channel.Id, rootID)
+		require.NoError(t, err)
+
+		revisions, err := ss.Draft().GetRevisions(user.Id, channel.Id, rootID)
+		require.NoError(t, err)
+		assert.Empty(t, revisions)
+	})
+}
+
 func testDeleteDraft(t *testing.T, rctx request.CTX, ss store.Store) {
 	user := &model.User{
 		Id: model.NewId(),
@@ -518,6 +667,28 @@ func testDeleteEmptyDraftsByCreateAtAndUserID(t *testing.T, rctx request.CTX, ss
 		require.NoError(t, err)
 	})
 
+	t.Run("delete the revisions of empty drafts", func(t *testing.T) {
+		clearDrafts(t, rctx, ss)
+
+		draft := &model.Draft{
+			UserId:    model.NewId(),
+			ChannelId: model.NewId(),
+			RootId:    model.NewId(),
+		}
+		for _, message := range []string{"first", "second", ""} {
+			draft.Message = message
+			_, err := ss.Draft().Upsert(draft)
+			require.NoError(t, err)
+		}
+
+		err := ss.Draft().DeleteEmptyDraftsByCreateAtAndUserId(0, "")
+		require.NoError(t, err)
+
+		revisions, err := ss.Draft().GetRevisions(draft.UserId, draft.ChannelId, draft.RootId)
+		require.NoError(t, err)
+		assert.Empty(t, revisions)
+	})
+
 	t.Run("delete single page, all empty", func(t *testing.T) {
 		clearDrafts(t, rctx, ss)
 		makeDrafts(t, ss, 100, "")
@@ -652,6 +823,29 @@ func testDeleteOrphanDraftsByCreateAtAndUserID(t *testing.T, rctx request.CTX, s
 		require.NoError(t, err)
 	})
 
+	t.Run("delete the revisions of drafts with no post", func(t *testing.T) {
+		clearDrafts(t, rctx, ss)
+		clearPosts(t, rctx, ss)
+
+		draft := &model.Draft{
+			UserId:    model.NewId(),
+			ChannelId: model.NewId(),
+			RootId:    model.NewId(),
+		}
+		for _, message := range []string{"first", "second"} {
+			draft.Message = message
+			_, err := ss.Draft().Upsert(draft)
+			require.NoError(t, err)
+		}
+
+		err := ss.Draft().DeleteOrphanDraftsByCreateAtAndUserId(0, "")
+		require.NoError(t, err)
+
+		revisions, err := ss.Draft().GetRevisions(draft.UserId, draft.ChannelId, draft.RootId)
+		require.NoError(t, err)
+		assert.Empty(t, revisions)
+	})
+
 	t.Run("delete single page, drafts with no post", func(t *testing.T) {
 		clearDrafts(t, rctx, ss)
 		clearPosts(t, rctx, ss)
@@ -181,6 +181,66 @@ func (_m *DraftStore) GetLastCreateAtAndUserIdValuesForEmptyDraftsMigration(crea
 	return r0, r1, r2
 }
 
+// GetRevision provides a mock function with given fields: userID, channelID, rootID, version
+func (_m *DraftStore) GetRevision(userID string, channelID string, rootID string, version int64) (*model.Draft, error) {
+	ret := _m.Called(userID, channelID, rootID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevision")
+	}
+
+	var r0 *model.Draft
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string, int64) (*model.Draft, error)); ok {
+		return rf(userID, channelID, rootID, version)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string, int64) *model.Draft); ok {
+		r0 = rf(userID, channelID, rootID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Draft)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string, int64) error); ok {
+		r1 = rf(userID, channelID, rootID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisions provides a mock function with given fields: userID, channelID, rootID
+func (_m *DraftStore) GetRevisions(userID string, channelID string, rootID string) ([]*model.Draft, error) {
+	ret := _m.Called(userID, channelID, rootID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevisions")
+	}
+
+	var r0 []*model.Draft
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) ([]*model.Draft, error)); ok {
+		return rf(userID, channelID, rootID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) []*model.Draft); ok {
+		r0 = rf(userID, channelID, rootID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Draft)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(userID, channelID, rootID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Upsert provides a mock function with given fields: d
 func (_m *DraftStore) Upsert(d *model.Draft) (*model.Draft, error) {
 	ret := _m.Called(d)
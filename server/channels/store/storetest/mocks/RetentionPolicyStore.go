@@ -300,6 +300,36 @@ func (_m *RetentionPolicyStore) GetCount() (int64, error) {
 	return r0, r1
 }
 
+// GetDryRun provides a mock function with given fields: policyID, now, globalPolicyEndTime, globalFileEndTime
+func (_m *RetentionPolicyStore) GetDryRun(policyID string, now int64, globalPolicyEndTime int64, globalFileEndTime int64) ([]*model.RetentionPolicyDryRunChannel, error) {
+	ret := _m.Called(policyID, now, globalPolicyEndTime, globalFileEndTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDryRun")
+	}
+
+	var r0 []*model.RetentionPolicyDryRunChannel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64, int64, int64) ([]*model.RetentionPolicyDryRunChannel, error)); ok {
+		return rf(policyID, now, globalPolicyEndTime, globalFileEndTime)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64, int64, int64) []*model.RetentionPolicyDryRunChannel); ok {
+		r0 = rf(policyID, now, globalPolicyEndTime, globalFileEndTime)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.RetentionPolicyDryRunChannel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64, int64, int64) error); ok {
+		r1 = rf(policyID, now, globalPolicyEndTime, globalFileEndTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetIdsForDeletionByTableName provides a mock function with given fields: tableName, limit
 func (_m *RetentionPolicyStore) GetIdsForDeletionByTableName(tableName string, limit int) ([]*model.RetentionIdsForDeletion, error) {
 	ret := _m.Called(tableName, limit)
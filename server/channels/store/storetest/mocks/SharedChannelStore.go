@@ -338,6 +338,36 @@ func (_m *SharedChannelStore) GetRemotesStatus(channelID string) ([]*model.Share
 	return r0, r1
 }
 
+// GetRemotesSyncStatus provides a mock function with given fields: channelID
+func (_m *SharedChannelStore) GetRemotesSyncStatus(channelID string) ([]*model.SharedChannelRemoteSyncStatus, error) {
+	ret := _m.Called(channelID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRemotesSyncStatus")
+	}
+
+	var r0 []*model.SharedChannelRemoteSyncStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*model.SharedChannelRemoteSyncStatus, error)); ok {
+		return rf(channelID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*model.SharedChannelRemoteSyncStatus); ok {
+		r0 = rf(channelID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SharedChannelRemoteSyncStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSingleUser provides a mock function with given fields: userID, channelID, remoteID
 func (_m *SharedChannelStore) GetSingleUser(userID string, channelID string, remoteID string) (*model.SharedChannelUser, error) {
 	ret := _m.Called(userID, channelID, remoteID)
@@ -700,6 +730,24 @@ func (_m *SharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPost
 	return r0
 }
 
+// UpdateRemoteSyncError provides a mock function with given fields: channelID, remoteID, syncErr
+func (_m *SharedChannelStore) UpdateRemoteSyncError(channelID string, remoteID string, syncErr string) error {
+	ret := _m.Called(channelID, remoteID, syncErr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRemoteSyncError")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(channelID, remoteID, syncErr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUserLastSyncAt provides a mock function with given fields: userID, channelID, remoteID
 func (_m *SharedChannelStore) UpdateUserLastSyncAt(userID string, channelID string, remoteID string) error {
 	ret := _m.Called(userID, channelID, remoteID)
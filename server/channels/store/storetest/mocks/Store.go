@@ -658,6 +658,46 @@ func (_m *Store) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
 	return r0
 }
 
+// OutOfOffice provides a mock function with given fields:
+func (_m *Store) OutOfOffice() store.OutOfOfficeStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for OutOfOffice")
+	}
+
+	var r0 store.OutOfOfficeStore
+	if rf, ok := ret.Get(0).(func() store.OutOfOfficeStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.OutOfOfficeStore)
+		}
+	}
+
+	return r0
+}
+
+// PersistentNotificationEscalation provides a mock function with given fields:
+func (_m *Store) PersistentNotificationEscalation() store.PersistentNotificationEscalationStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PersistentNotificationEscalation")
+	}
+
+	var r0 store.PersistentNotificationEscalationStore
+	if rf, ok := ret.Get(0).(func() store.PersistentNotificationEscalationStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PersistentNotificationEscalationStore)
+		}
+	}
+
+	return r0
+}
+
 // Plugin provides a mock function with given fields:
 func (_m *Store) Plugin() store.PluginStore {
 	ret := _m.Called()
@@ -441,6 +441,96 @@ func (_m *WebhookStore) GetOutgoingByTeamByUser(teamID string, userID string, of
 	return r0, r1
 }
 
+// GetOutgoingDeliveriesForHook provides a mock function with given fields: hookID, offset, limit
+func (_m *WebhookStore) GetOutgoingDeliveriesForHook(hookID string, offset int, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	ret := _m.Called(hookID, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOutgoingDeliveriesForHook")
+	}
+
+	var r0 []*model.OutgoingWebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]*model.OutgoingWebhookDelivery, error)); ok {
+		return rf(hookID, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []*model.OutgoingWebhookDelivery); ok {
+		r0 = rf(hookID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.OutgoingWebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(hookID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOutgoingDeliveriesToRetry provides a mock function with given fields: now, limit
+func (_m *WebhookStore) GetOutgoingDeliveriesToRetry(now int64, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	ret := _m.Called(now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOutgoingDeliveriesToRetry")
+	}
+
+	var r0 []*model.OutgoingWebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int) ([]*model.OutgoingWebhookDelivery, error)); ok {
+		return rf(now, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int) []*model.OutgoingWebhookDelivery); ok {
+		r0 = rf(now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.OutgoingWebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOutgoingDelivery provides a mock function with given fields: id
+func (_m *WebhookStore) GetOutgoingDelivery(id string) (*model.OutgoingWebhookDelivery, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOutgoingDelivery")
+	}
+
+	var r0 *model.OutgoingWebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.OutgoingWebhookDelivery, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.OutgoingWebhookDelivery); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OutgoingWebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOutgoingList provides a mock function with given fields: offset, limit
 func (_m *WebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, error) {
 	ret := _m.Called(offset, limit)
@@ -578,6 +668,34 @@ func (_m *WebhookStore) PermanentDeleteOutgoingByUser(userID string) error {
 	return r0
 }
 
+// PermanentDeleteOutgoingDeliveriesBefore provides a mock function with given fields: before
+func (_m *WebhookStore) PermanentDeleteOutgoingDeliveriesBefore(before int64) (int64, error) {
+	ret := _m.Called(before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PermanentDeleteOutgoingDeliveriesBefore")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (int64, error)); ok {
+		return rf(before)
+	}
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SaveIncoming provides a mock function with given fields: webhook
 func (_m *WebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 	ret := _m.Called(webhook)
@@ -638,6 +756,36 @@ func (_m *WebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*model.Out
 	return r0, r1
 }
 
+// SaveOutgoingDelivery provides a mock function with given fields: delivery
+func (_m *WebhookStore) SaveOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	ret := _m.Called(delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveOutgoingDelivery")
+	}
+
+	var r0 *model.OutgoingWebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error)); ok {
+		return rf(delivery)
+	}
+	if rf, ok := ret.Get(0).(func(*model.OutgoingWebhookDelivery) *model.OutgoingWebhookDelivery); ok {
+		r0 = rf(delivery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OutgoingWebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.OutgoingWebhookDelivery) error); ok {
+		r1 = rf(delivery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateIncoming provides a mock function with given fields: webhook
 func (_m *WebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 	ret := _m.Called(webhook)
@@ -698,6 +846,36 @@ func (_m *WebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.Outg
 	return r0, r1
 }
 
+// UpdateOutgoingDelivery provides a mock function with given fields: delivery
+func (_m *WebhookStore) UpdateOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	ret := _m.Called(delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOutgoingDelivery")
+	}
+
+	var r0 *model.OutgoingWebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error)); ok {
+		return rf(delivery)
+	}
+	if rf, ok := ret.Get(0).(func(*model.OutgoingWebhookDelivery) *model.OutgoingWebhookDelivery); ok {
+		r0 = rf(delivery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OutgoingWebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.OutgoingWebhookDelivery) error); ok {
+		r1 = rf(delivery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewWebhookStore creates a new instance of WebhookStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewWebhookStore(t interface {
@@ -0,0 +1,143 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestOutOfOfficeStore(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testOutOfOfficeSaveAndGet(t, rctx, ss) })
+	t.Run("Delete", func(t *testing.T) { testOutOfOfficeDelete(t, rctx, ss) })
+	t.Run("SetActive", func(t *testing.T) { testOutOfOfficeSetActive(t, rctx, ss) })
+	t.Run("GetPendingTransitions", func(t *testing.T) { testOutOfOfficeGetPendingTransitions(t, rctx, ss) })
+}
+
+func newTestOutOfOffice(startAt, endAt int64) *model.OutOfOffice {
+	return &model.OutOfOffice{
+		UserId:    model.NewId(),
+		StartAt:   startAt,
+		EndAt:     endAt,
+		DMMessage: "I'm away",
+	}
+}
+
+func testOutOfOfficeSaveAndGet(t *testing.T, rctx request.CTX, ss store.Store) {
+	o := newTestOutOfOffice(1000, 2000)
+	o.MentionMessage = "I'm away, I'll reply when I'm back"
+	o.DelegateUserId = model.NewId()
+	o.ExcludeBots = true
+	o.ExcludedUserIds = model.StringArray{model.NewId()}
+	defer func() { require.NoError(t, ss.OutOfOffice().Delete(o.UserId)) }()
+
+	saved, err := ss.OutOfOffice().Save(o)
+	require.NoError(t, err)
+	assert.NotZero(t, saved.CreateAt)
+
+	got, err := ss.OutOfOffice().Get(o.UserId)
+	require.NoError(t, err)
+	assert.Equal(t, saved, got)
+
+	t.Run("save replaces the existing settings", func(t *testing.T) {
+		update := newTestOutOfOffice(3000, 4000)
+		update.UserId = o.UserId
+		_, err := ss.OutOfOffice().Save(update)
+		require.NoError(t, err)
+
+		got, err := ss.OutOfOffice().Get(o.UserId)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3000), got.StartAt)
+		assert.Equal(t, int64(4000), got.EndAt)
+		assert.Empty(t, got.MentionMessage)
+		assert.Empty(t, got.ExcludedUserIds)
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		_, err := ss.OutOfOffice().Save(newTestOutOfOffice(2000, 1000))
+		require.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := ss.OutOfOffice().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testOutOfOfficeDelete(t *testing.T, rctx request.CTX, ss store.Store) {
+	o, err := ss.OutOfOffice().Save(newTestOutOfOffice(1000, 2000))
+	require.NoError(t, err)
+
+	require.NoError(t, ss.OutOfOffice().Delete(o.UserId))
+
+	_, err = ss.OutOfOffice().Get(o.UserId)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testOutOfOfficeSetActive(t *testing.T, rctx request.CTX, ss store.Store) {
+	o, err := ss.OutOfOffice().Save(newTestOutOfOffice(1000, 2000))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.OutOfOffice().Delete(o.UserId)) }()
+
+	require.NoError(t, ss.OutOfOffice().SetActive(o.UserId, true))
+	got, err := ss.OutOfOffice().Get(o.UserId)
+	require.NoError(t, err)
+	assert.True(t, got.Active)
+
+	require.NoError(t, ss.OutOfOffice().SetActive(o.UserId, false))
+	got, err = ss.OutOfOffice().Get(o.UserId)
+	require.NoError(t, err)
+	assert.False(t, got.Active)
+
+	err = ss.OutOfOffice().SetActive(model.NewId(), true)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testOutOfOfficeGetPendingTransitions(t *testing.T, rctx request.CTX, ss store.Store) {
+	now := model.GetMillis()
+
+	future, err := ss.OutOfOffice().Save(newTestOutOfOffice(now+10000, now+20000))
+	require.NoError(t, err)
+	started, err := ss.OutOfOffice().Save(newTestOutOfOffice(now-10000, now+10000))
+	require.NoError(t, err)
+
+	alreadyActive := newTestOutOfOffice(now-10000, now+10000)
+	alreadyActive.Active = true
+	alreadyActive, err = ss.OutOfOffice().Save(alreadyActive)
+	require.NoError(t, err)
+
+	ended := newTestOutOfOffice(now-20000, now-10000)
+	ended.Active = true
+	ended, err = ss.OutOfOffice().Save(ended)
+	require.NoError(t, err)
+
+	defer func() {
+		for _, o := range []*model.OutOfOffice{future, started, alreadyActive, ended} {
+			require.NoError(t, ss.OutOfOffice().Delete(o.UserId))
+		}
+	}()
+
+	pending, err := ss.OutOfOffice().GetPendingTransitions(now, 100)
+	require.NoError(t, err)
+
+	var userIds []string
+	for _, o := range pending {
+		userIds = append(userIds, o.UserId)
+	}
+	assert.ElementsMatch(t, []string{started.UserId, ended.UserId}, userIds)
+
+	pending, err = ss.OutOfOffice().GetPendingTransitions(now, 1)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, ended.UserId, pending[0].UserId)
+}
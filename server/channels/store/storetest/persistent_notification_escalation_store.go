@@ -0,0 +1,140 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestPersistentNotificationEscalationStore(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("SaveGet", func(t *testing.T) { testPersistentNotificationEscalationSaveGet(t, rctx, ss) })
+	t.Run("GetForChannels", func(t *testing.T) { testPersistentNotificationEscalationGetForChannels(t, rctx, ss) })
+	t.Run("Delete", func(t *testing.T) { testPersistentNotificationEscalationDelete(t, rctx, ss) })
+	t.Run("DeleteByChannel", func(t *testing.T) { testPersistentNotificationEscalationDeleteByChannel(t, rctx, ss) })
+}
+
+func newTestGroupEscalation(channelID string, afterCount int) *model.PersistentNotificationEscalation {
+	return &model.PersistentNotificationEscalation{
+		ChannelId:  channelID,
+		AfterCount: afterCount,
+		Type:       model.PersistentNotificationEscalationTypeGroup,
+		GroupId:    model.NewId(),
+		CreatorId:  model.NewId(),
+	}
+}
+
+func newTestChannelEscalation(channelID string, afterCount int) *model.PersistentNotificationEscalation {
+	return &model.PersistentNotificationEscalation{
+		ChannelId:         channelID,
+		AfterCount:        afterCount,
+		Type:              model.PersistentNotificationEscalationTypeChannel,
+		FallbackChannelId: model.NewId(),
+		CreatorId:         model.NewId(),
+	}
+}
+
+func testPersistentNotificationEscalationSaveGet(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("group step", func(t *testing.T) {
+		escalation, err := ss.PersistentNotificationEscalation().Save(newTestGroupEscalation(model.NewId(), 2))
+		require.NoError(t, err)
+		require.NotEmpty(t, escalation.Id)
+
+		got, err := ss.PersistentNotificationEscalation().Get(escalation.Id)
+		require.NoError(t, err)
+		assert.Equal(t, escalation, got)
+	})
+
+	t.Run("channel step", func(t *testing.T) {
+		escalation, err := ss.PersistentNotificationEscalation().Save(newTestChannelEscalation(model.NewId(), 4))
+		require.NoError(t, err)
+
+		got, err := ss.PersistentNotificationEscalation().Get(escalation.Id)
+		require.NoError(t, err)
+		assert.Equal(t, escalation, got)
+		assert.Empty(t, got.GroupId)
+	})
+
+	t.Run("invalid step", func(t *testing.T) {
+		_, err := ss.PersistentNotificationEscalation().Save(newTestGroupEscalation(model.NewId(), 0))
+		require.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := ss.PersistentNotificationEscalation().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testPersistentNotificationEscalationGetForChannels(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID1 := model.NewId()
+	channelID2 := model.NewId()
+
+	third, err := ss.PersistentNotificationEscalation().Save(newTestChannelEscalation(channelID1, 5))
+	require.NoError(t, err)
+	first, err := ss.PersistentNotificationEscalation().Save(newTestGroupEscalation(channelID1, 1))
+	require.NoError(t, err)
+	second, err := ss.PersistentNotificationEscalation().Save(newTestGroupEscalation(channelID2, 3))
+	require.NoError(t, err)
+	_, err = ss.PersistentNotificationEscalation().Save(newTestGroupEscalation(model.NewId(), 1))
+	require.NoError(t, err)
+
+	escalations, err := ss.PersistentNotificationEscalation().GetForChannels([]string{channelID1, channelID2})
+	require.NoError(t, err)
+	require.Len(t, escalations, 3)
+	assert.Equal(t, first.Id, escalations[0].Id)
+	assert.Equal(t, second.Id, escalations[1].Id)
+	assert.Equal(t, third.Id, escalations[2].Id)
+
+	escalations, err = ss.PersistentNotificationEscalation().GetForChannels(nil)
+	require.NoError(t, err)
+	assert.Empty(t, escalations)
+}
+
+func testPersistentNotificationEscalationDelete(t *testing.T, rctx request.CTX, ss store.Store) {
+	escalation, err := ss.PersistentNotificationEscalation().Save(newTestGroupEscalation(model.NewId(), 2))
+	require.NoError(t, err)
+
+	require.NoError(t, ss.PersistentNotificationEscalation().Delete(escalation.Id))
+
+	_, err = ss.PersistentNotificationEscalation().Get(escalation.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	escalations, err := ss.PersistentNotificationEscalation().GetForChannels([]string{escalation.ChannelId})
+	require.NoError(t, err)
+	assert.Empty(t, escalations)
+
+	err = ss.PersistentNotificationEscalation().Delete(escalation.Id)
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testPersistentNotificationEscalationDeleteByChannel(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+
+	own, err := ss.PersistentNotificationEscalation().Save(newTestGroupEscalation(channelID, 2))
+	require.NoError(t, err)
+
+	fallingBack := newTestChannelEscalation(model.NewId(), 2)
+	fallingBack.FallbackChannelId = channelID
+	fallingBack, err = ss.PersistentNotificationEscalation().Save(fallingBack)
+	require.NoError(t, err)
+
+	other, err := ss.PersistentNotificationEscalation().Save(newTestGroupEscalation(model.NewId(), 2))
+	require.NoError(t, err)
+
+	require.NoError(t, ss.PersistentNotificationEscalation().DeleteByChannel([]string{channelID}))
+
+	escalations, err := ss.PersistentNotificationEscalation().GetForChannels([]string{own.ChannelId, fallingBack.ChannelId, other.ChannelId})
+	require.NoError(t, err)
+	require.Len(t, escalations, 1)
+	assert.Equal(t, other.Id, escalations[0].Id)
+}
@@ -62,6 +62,8 @@ func TestPostStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("GetPostsSinceCreateForSync", func(t *testing.T) { testGetPostsSinceCreateForSync(t, rctx, ss, s) })
 	t.Run("SetPostReminder", func(t *testing.T) { testSetPostReminder(t, rctx, ss, s) })
 	t.Run("GetPostReminders", func(t *testing.T) { testGetPostReminders(t, rctx, ss, s) })
+	t.Run("GetPostRemindersForUser", func(t *testing.T) { testGetPostRemindersForUser(t, rctx, ss) })
+	t.Run("DeletePostReminder", func(t *testing.T) { testDeletePostReminder(t, rctx, ss) })
 	t.Run("GetPostReminderMetadata", func(t *testing.T) { testGetPostReminderMetadata(t, rctx, ss, s) })
 	t.Run("GetNthRecentPostTime", func(t *testing.T) { testGetNthRecentPostTime(t, rctx, ss) })
 	t.Run("GetEditHistoryForPost", func(t *testing.T) { testGetEditHistoryForPost(t, rctx, ss) })
@@ -5036,6 +5038,76 @@ func testGetPostReminders(t *testing.T, rctx request.CTX, ss store.Store, s SqlS
 	require.Len(t, reminders, 0)
 }
 
+func testGetPostRemindersForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := NewTestID()
+	otherUserID := NewTestID()
+
+	var postIDs []string
+	for _, targetTime := range []int64{300, 100, 200} {
+		p, err := ss.Post().Save(rctx, &model.Post{
+			UserId:    otherUserID,
+			ChannelId: NewTestID(),
+			Message:   "hi there",
+			Type:      model.PostTypeDefault,
+		})
+		require.NoError(t, err)
+		postIDs = append(postIDs, p.Id)
+
+		require.NoError(t, ss.Post().SetPostReminder(&model.PostReminder{
+			TargetTime: targetTime,
+			PostId:     p.Id,
+			UserId:     userID,
+		}))
+	}
+
+	require.NoError(t, ss.Post().SetPostReminder(&model.PostReminder{
+		TargetTime: 50,
+		PostId:     postIDs[0],
+		UserId:     otherUserID,
+	}))
+
+	reminders, err := ss.Post().GetPostRemindersForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, reminders, 3)
+	assert.Equal(t, postIDs[1], reminders[0].PostId)
+	assert.Equal(t, int64(100), reminders[0].TargetTime)
+	assert.Equal(t, postIDs[2], reminders[1].PostId)
+	assert.Equal(t, postIDs[0], reminders[2].PostId)
+	assert.Equal(t, userID, reminders[2].UserId)
+
+	reminders, err = ss.Post().GetPostRemindersForUser(NewTestID())
+	require.NoError(t, err)
+	assert.Empty(t, reminders)
+}
+
+func testDeletePostReminder(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := NewTestID()
+
+	p, err := ss.Post().Save(rctx, &model.Post{
+		UserId:    userID,
+		ChannelId: NewTestID(),
+		Message:   "hi there",
+		Type:      model.PostTypeDefault,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.Post().SetPostReminder(&model.PostReminder{
+		TargetTime: 100,
+		PostId:     p.Id,
+		UserId:     userID,
+	}))
+
+	require.NoError(t, ss.Post().DeletePostReminder(p.Id, userID))
+
+	reminders, err := ss.Post().GetPostRemindersForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, reminders)
+
+	err = ss.Post().DeletePostReminder(p.Id, userID)
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(err, &nfErr))
+}
+
 func testGetPostReminderMetadata(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	team := &model.Team{
 		Name:        "teamname",
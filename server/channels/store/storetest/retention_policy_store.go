@@ -4,6 +4,7 @@
 package storetest
 
 import (
+	"context"
 	"sort"
 	"strconv"
 	"testing"
@@ -29,6 +30,7 @@ func TestRetentionPolicyStore(t *testing.T, rctx request.CTX, ss store.Store, s
 	t.Run("RemoveTeams", func(t *testing.T) { testRetentionPolicyStoreRemoveTeams(t, rctx, ss, s) })
 	t.Run("RemoveOrphanedRows", func(t *testing.T) { testRetentionPolicyStoreRemoveOrphanedRows(t, rctx, ss, s) })
 	t.Run("GetPoliciesForUser", func(t *testing.T) { testRetentionPolicyStoreGetPoliciesForUser(t, rctx, ss, s) })
+	t.Run("GetDryRun", func(t *testing.T) { testRetentionPolicyStoreGetDryRun(t, rctx, ss) })
 }
 
 func getRetentionPolicyWithTeamAndChannelIds(t *testing.T, ss store.Store, policyID string) *model.RetentionPolicyWithTeamAndChannelIDs {
@@ -680,3 +682,151 @@ func testRetentionPolicyStoreRemoveOrphanedRows(t *testing.T, rctx request.CTX,
 	policy.TeamIDs = make([]string, 0)
 	checkRetentionPolicyLikeThisExists(t, ss, policy)
 }
+
+func testRetentionPolicyStoreGetDryRun(t *testing.T, rctx request.CTX, ss store.Store) {
+	teamIDs := createTeamsForRetentionPolicy(t, ss, 2)
+	channelIDs := createChannelsForRetentionPolicy(rctx, t, ss, teamIDs[0], 2)
+	globalChannelID := createChannelsForRetentionPolicy(rctx, t, ss, teamIDs[1], 1)[0]
+	channelPolicy := saveRetentionPolicyWithTeamAndChannelIds(t, ss, "Channel policy", nil, []string{channelIDs[0]})
+	teamPolicy := saveRetentionPolicyWithTeamAndChannelIds(t, ss, "Team policy", []string{teamIDs[0]}, nil)
+
+	savePost := func(channelID string, createAt int64) *model.Post {
+		post, err := ss.Post().Save(rctx, &model.Post{
+			ChannelId: channelID,
+			UserId:    model.NewId(),
+			Message:   "message",
+			CreateAt:  createAt,
+		})
+		require.NoError(t, err)
+		return post
+	}
+
+	post := savePost(channelIDs[0], 1000)
+	savePost(channelIDs[0], 2000)
+	savePost(channelIDs[1], 1500)
+	savePost(globalChannelID, 1000)
+
+	_, err := ss.FileInfo().Save(rctx, &model.FileInfo{
+		CreatorId: model.NewId(),
+		PostId:    post.Id,
+		Path:      "file.txt",
+	})
+	require.NoError(t, err)
+	_, err = ss.Reaction().Save(&model.Reaction{
+		UserId:    model.NewId(),
+		PostId:    post.Id,
+		EmojiName: "smile",
+	})
+	require.NoError(t, err)
+
+	findChannel := func(channels []*model.RetentionPolicyDryRunChannel, channelID string) *model.RetentionPolicyDryRunChannel {
+		for _, channel := range channels {
+			if channel.ChannelID == channelID {
+				return channel
+			}
+		}
+		return nil
+	}
+
+	nowMillis := int64(2000) + *channelPolicy.PostDurationDays*model.DayInMilliseconds + 1
+
+	t.Run("granular policies", func(t *testing.T) {
+		channels, err := ss.RetentionPolicy().GetDryRun("", nowMillis, 0, 0)
+		require.NoError(t, err)
+
+		channel := findChannel(channels, channelIDs[0])
+		require.NotNil(t, channel)
+		require.Equal(t, &model.RetentionPolicyDryRunChannel{
+			PolicyID:  channelPolicy.ID,
+			TeamID:    teamIDs[0],
+			ChannelID: channelIDs[0],
+			RetentionPolicyDryRunCounts: model.RetentionPolicyDryRunCounts{
+				PostCount:     2,
+				FileCount:     1,
+				ReactionCount: 1,
+				OldestPostAt:  1000,
+				NewestPostAt:  2000,
+			},
+		}, channel)
+
+		channel = findChannel(channels, channelIDs[1])
+		require.NotNil(t, channel)
+		require.Equal(t, teamPolicy.ID, channel.PolicyID)
+		require.Equal(t, int64(1), channel.PostCount)
+
+		require.Nil(t, findChannel(channels, globalChannelID), "global policy should have been disabled")
+	})
+
+	t.Run("single policy", func(t *testing.T) {
+		channels, err := ss.RetentionPolicy().GetDryRun(teamPolicy.ID, nowMillis, 2000, 2000)
+		require.NoError(t, err)
+		require.Nil(t, findChannel(channels, channelIDs[0]))
+		require.NotNil(t, findChannel(channels, channelIDs[1]))
+		require.Nil(t, findChannel(channels, globalChannelID), "global policy should have been ignored")
+	})
+
+	t.Run("global policy", func(t *testing.T) {
+		channels, err := ss.RetentionPolicy().GetDryRun("", 0, 2000, 0)
+		require.NoError(t, err)
+		require.Nil(t, findChannel(channels, channelIDs[0]), "global policy should have been ignored due to granular policy")
+		require.Nil(t, findChannel(channels, channelIDs[1]), "global policy should have been ignored due to granular policy")
+
+		channel := findChannel(channels, globalChannelID)
+		require.NotNil(t, channel)
+		require.Equal(t, "", channel.PolicyID)
+		require.Equal(t, int64(1), channel.PostCount)
+	})
+
+	t.Run("global policy files", func(t *testing.T) {
+		for _, creatorID := range []string{model.NewId(), model.BookmarkFileOwner} {
+			_, err := ss.FileInfo().Save(rctx, &model.FileInfo{
+				CreatorId: creatorID,
+				ChannelId: globalChannelID,
+				Path:      "file.txt",
+				CreateAt:  500,
+			})
+			require.NoError(t, err)
+		}
+		for _, channelID := range channelIDs {
+			_, err := ss.FileInfo().Save(rctx, &model.FileInfo{
+				CreatorId: model.NewId(),
+				ChannelId: channelID,
+				Path:      "file.txt",
+				CreateAt:  500,
+			})
+			require.NoError(t, err)
+		}
+
+		channels, err := ss.RetentionPolicy().GetDryRun("", 0, 0, 1000)
+		require.NoError(t, err)
+
+		channel := findChannel(channels, globalChannelID)
+		require.NotNil(t, channel)
+		require.Equal(t, &model.RetentionPolicyDryRunChannel{
+			TeamID:    teamIDs[1],
+			ChannelID: globalChannelID,
+			RetentionPolicyDryRunCounts: model.RetentionPolicyDryRunCounts{
+				FileCount: 1,
+			},
+		}, channel, "files should be counted by their own creation time, without bookmark files")
+		require.Nil(t, findChannel(channels, channelIDs[0]), "global policy should have been ignored due to granular policy")
+		require.Nil(t, findChannel(channels, channelIDs[1]), "global policy should have been ignored due to granular policy")
+	})
+
+	t.Run("matches the deletion", func(t *testing.T) {
+		_, err := ss.Post().Get(context.Background(), post.Id, model.GetPostsOptions{}, "", map[string]bool{})
+		require.NoError(t, err, "dry run should not have deleted anything")
+
+		channels, err := ss.RetentionPolicy().GetDryRun(channelPolicy.ID, nowMillis, 0, 0)
+		require.NoError(t, err)
+		require.Len(t, channels, 1)
+
+		deleted, _, err := ss.Post().PermanentDeleteBatchForRetentionPolicies(nowMillis, 0, 1000, model.RetentionPolicyCursor{TeamPoliciesDone: true})
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, deleted, channels[0].PostCount)
+
+		channels, err = ss.RetentionPolicy().GetDryRun(channelPolicy.ID, nowMillis, 0, 0)
+		require.NoError(t, err)
+		require.Empty(t, channels)
+	})
+}
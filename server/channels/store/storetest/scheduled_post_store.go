@@ -20,6 +20,7 @@ func TestScheduledPostStore(t *testing.T, rctx request.CTX, ss store.Store, s Sq
 	t.Run("PermanentlyDeleteScheduledPosts", func(t *testing.T) { testPermanentlyDeleteScheduledPosts(t, rctx, ss, s) })
 	t.Run("UpdatedScheduledPost", func(t *testing.T) { testUpdatedScheduledPost(t, rctx, ss, s) })
 	t.Run("UpdateOldScheduledPosts", func(t *testing.T) { testUpdateOldScheduledPosts(t, rctx, ss, s) })
+	t.Run("RecurringScheduledPosts", func(t *testing.T) { testRecurringScheduledPosts(t, rctx, ss, s) })
 }
 
 func testCreateScheduledPost(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
@@ -470,3 +471,77 @@ func testUpdateOldScheduledPosts(t *testing.T, rctx request.CTX, ss store.Store,
 		assert.Equal(t, "", scheduledPosts[3].ErrorCode)
 	})
 }
+
+func testRecurringScheduledPosts(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	userId := model.NewId()
+	jan2100 := model.GetMillisForTime(time.Date(2100, time.January, 1, 9, 0, 0, 0, time.UTC))
+
+	newScheduledPost := func(scheduledAt int64, recurrence *model.ScheduledPostRecurrence) *model.ScheduledPost {
+		scheduledPost, err := ss.ScheduledPost().CreateScheduledPost(&model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    userId,
+				ChannelId: model.NewId(),
+				Message:   "this is a scheduled post",
+			},
+			ScheduledAt: scheduledAt,
+			Recurrence:  recurrence,
+		})
+		require.NoError(t, err)
+		return scheduledPost
+	}
+
+	daily := &model.ScheduledPostRecurrence{Frequency: model.ScheduledPostRecurrenceDaily, TimeZone: "Europe/Paris", SkipWeekends: true}
+	oneOff := newScheduledPost(jan2100-2*86400000, nil)
+	recurring := newScheduledPost(jan2100-2*86400000, daily)
+	paused := newScheduledPost(jan2100, &model.ScheduledPostRecurrence{Frequency: model.ScheduledPostRecurrenceWeekly})
+	defer func() {
+		_ = ss.ScheduledPost().PermanentlyDeleteScheduledPosts([]string{oneOff.Id, recurring.Id, paused.Id})
+	}()
+
+	paused.PausedAt = model.GetMillis()
+	require.NoError(t, ss.ScheduledPost().UpdatedScheduledPost(paused))
+
+	t.Run("should store the recurrence", func(t *testing.T) {
+		scheduledPost, err := ss.ScheduledPost().Get(recurring.Id)
+		require.NoError(t, err)
+		require.NotNil(t, scheduledPost.Recurrence)
+		assert.Equal(t, *daily, *scheduledPost.Recurrence)
+
+		scheduledPost, err = ss.ScheduledPost().Get(oneOff.Id)
+		require.NoError(t, err)
+		assert.Nil(t, scheduledPost.Recurrence)
+	})
+
+	t.Run("should list the series of a user", func(t *testing.T) {
+		series, err := ss.ScheduledPost().GetScheduledPostSeriesForUser(userId)
+		require.NoError(t, err)
+		require.Len(t, series, 2)
+		assert.Equal(t, recurring.Id, series[0].Id)
+		assert.Equal(t, paused.Id, series[1].Id)
+		assert.NotZero(t, series[1].PausedAt)
+	})
+
+	t.Run("should return old recurring scheduled posts but not paused ones", func(t *testing.T) {
+		scheduledPosts, err := ss.ScheduledPost().GetPendingScheduledPosts(jan2100, jan2100-86400000, "", 10)
+		require.NoError(t, err)
+		require.Len(t, scheduledPosts, 1)
+		assert.Equal(t, recurring.Id, scheduledPosts[0].Id)
+	})
+
+	t.Run("should not mark recurring scheduled posts as failed", func(t *testing.T) {
+		require.NoError(t, ss.ScheduledPost().UpdateOldScheduledPosts(jan2100+1))
+
+		scheduledPost, err := ss.ScheduledPost().Get(oneOff.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.ScheduledPostErrorUnableToSend, scheduledPost.ErrorCode)
+
+		scheduledPost, err = ss.ScheduledPost().Get(recurring.Id)
+		require.NoError(t, err)
+		assert.Empty(t, scheduledPost.ErrorCode)
+
+		scheduledPost, err = ss.ScheduledPost().Get(paused.Id)
+		require.NoError(t, err)
+		assert.Empty(t, scheduledPost.ErrorCode)
+	})
+}
@@ -5,6 +5,7 @@ package storetest
 
 import (
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -33,6 +34,8 @@ func TestSharedChannelStore(t *testing.T, rctx request.CTX, ss store.Store, s Sq
 	t.Run("GetRemoteForUser", func(t *testing.T) { testGetRemoteForUser(t, rctx, ss) })
 	t.Run("UpdateSharedChannelRemoteNextSyncAt", func(t *testing.T) { testUpdateSharedChannelRemoteCursor(t, rctx, ss) })
 	t.Run("DeleteSharedChannelRemote", func(t *testing.T) { testDeleteSharedChannelRemote(t, rctx, ss) })
+	t.Run("GetSharedChannelRemotesSyncStatus", func(t *testing.T) { testGetSharedChannelRemotesSyncStatus(t, rctx, ss) })
+	t.Run("UpdateSharedChannelRemoteSyncError", func(t *testing.T) { testUpdateSharedChannelRemoteSyncError(t, rctx, ss) })
 
 	t.Run("SaveSharedChannelUser", func(t *testing.T) { testSaveSharedChannelUser(t, rctx, ss) })
 	t.Run("GetSharedChannelSingleUser", func(t *testing.T) { testGetSingleSharedChannelUser(t, rctx, ss) })
@@ -1047,6 +1050,116 @@ func clearSharedChannels(ss store.Store) error {
 	return nil
 }
 
+func testGetSharedChannelRemotesSyncStatus(t *testing.T, rctx request.CTX, ss store.Store) {
+	channel, err := createSharedTestChannel(ss, rctx, "test_remotes_sync_status", true, nil)
+	require.NoError(t, err)
+
+	rc := &model.RemoteCluster{RemoteId: model.NewId(), SiteURL: model.NewId(), CreatorId: model.NewId(), Name: "test_remote", DisplayName: "Test Remote"}
+	_, err = ss.RemoteCluster().Save(rc)
+	require.NoError(t, err)
+
+	scr, err := ss.SharedChannel().SaveRemote(&model.SharedChannelRemote{
+		ChannelId:        channel.Id,
+		CreatorId:        model.NewId(),
+		RemoteId:         rc.RemoteId,
+		IsInviteAccepted: true,
+	})
+	require.NoError(t, err)
+
+	saveFile := func(info *model.FileInfo) *model.FileInfo {
+		info.CreatorId = model.NewId()
+		info.ChannelId = channel.Id
+		info.Path = "file.txt"
+		saved, err := ss.FileInfo().Save(rctx, info)
+		require.NoError(t, err)
+		return saved
+	}
+
+	// never sent to the remote
+	saveFile(&model.FileInfo{PostId: model.NewId(), CreateAt: scr.CreateAt + 1000})
+	// already sent to the remote
+	synced := saveFile(&model.FileInfo{PostId: model.NewId(), CreateAt: scr.CreateAt})
+	_, err = ss.SharedChannel().SaveAttachment(&model.SharedChannelAttachment{FileId: synced.Id, RemoteId: rc.RemoteId})
+	require.NoError(t, err)
+	// received from the remote
+	saveFile(&model.FileInfo{PostId: model.NewId(), CreateAt: scr.CreateAt + 1000, RemoteId: model.NewPointer(rc.RemoteId)})
+	// posted before the remote was invited
+	saveFile(&model.FileInfo{PostId: model.NewId(), CreateAt: scr.CreateAt - 1000})
+	// uploaded but never posted
+	saveFile(&model.FileInfo{CreateAt: scr.CreateAt + 1000})
+
+	t.Run("Get sync status of shared channel remotes", func(t *testing.T) {
+		status, err := ss.SharedChannel().GetRemotesSyncStatus(channel.Id)
+		require.NoError(t, err)
+		require.Len(t, status, 1)
+
+		assert.Equal(t, channel.Id, status[0].ChannelId)
+		assert.Equal(t, rc.RemoteId, status[0].RemoteId)
+		assert.Equal(t, "Test Remote", status[0].DisplayName)
+		assert.True(t, status[0].IsInviteAccepted)
+		assert.Equal(t, int64(1), status[0].PendingAttachments)
+		assert.Empty(t, status[0].LastSyncError)
+	})
+
+	t.Run("Deleted remotes are not included", func(t *testing.T) {
+		other, err := createSharedTestChannel(ss, rctx, "test_remotes_sync_status_deleted", true, nil)
+		require.NoError(t, err)
+
+		deleted, err := ss.SharedChannel().SaveRemote(&model.SharedChannelRemote{ChannelId: other.Id, CreatorId: model.NewId(), RemoteId: rc.RemoteId})
+		require.NoError(t, err)
+		_, err = ss.SharedChannel().DeleteRemote(deleted.Id)
+		require.NoError(t, err)
+
+		status, err := ss.SharedChannel().GetRemotesSyncStatus(other.Id)
+		require.NoError(t, err)
+		require.Empty(t, status)
+	})
+}
+
+func testUpdateSharedChannelRemoteSyncError(t *testing.T, rctx request.CTX, ss store.Store) {
+	channel, err := createSharedTestChannel(ss, rctx, "test_remote_sync_error", true, nil)
+	require.NoError(t, err)
+
+	rc := &model.RemoteCluster{RemoteId: model.NewId(), SiteURL: model.NewId(), CreatorId: model.NewId(), Name: "test_remote_sync_error"}
+	_, err = ss.RemoteCluster().Save(rc)
+	require.NoError(t, err)
+
+	_, err = ss.SharedChannel().SaveRemote(&model.SharedChannelRemote{ChannelId: channel.Id, CreatorId: model.NewId(), RemoteId: rc.RemoteId})
+	require.NoError(t, err)
+
+	t.Run("Record sync error", func(t *testing.T) {
+		err := ss.SharedChannel().UpdateRemoteSyncError(channel.Id, rc.RemoteId, "remote unreachable")
+		require.NoError(t, err)
+
+		status, err := ss.SharedChannel().GetRemotesSyncStatus(channel.Id)
+		require.NoError(t, err)
+		require.Len(t, status, 1)
+		assert.Equal(t, "remote unreachable", status[0].LastSyncError)
+		assert.NotZero(t, status[0].LastSyncErrorAt)
+	})
+
+	t.Run("Long sync errors are truncated", func(t *testing.T) {
+		err := ss.SharedChannel().UpdateRemoteSyncError(channel.Id, rc.RemoteId, strings.Repeat("e", model.SharedChannelRemoteSyncErrorMaxRunes+10))
+		require.NoError(t, err)
+
+		status, err := ss.SharedChannel().GetRemotesSyncStatus(channel.Id)
+		require.NoError(t, err)
+		require.Len(t, status, 1)
+		assert.Len(t, status[0].LastSyncError, model.SharedChannelRemoteSyncErrorMaxRunes)
+	})
+
+	t.Run("Clear sync error", func(t *testing.T) {
+		err := ss.SharedChannel().UpdateRemoteSyncError(channel.Id, rc.RemoteId, "")
+		require.NoError(t, err)
+
+		status, err := ss.SharedChannel().GetRemotesSyncStatus(channel.Id)
+		require.NoError(t, err)
+		require.Len(t, status, 1)
+		assert.Empty(t, status[0].LastSyncError)
+		assert.Zero(t, status[0].LastSyncErrorAt)
+	})
+}
+
 func testSaveSharedChannelUser(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("Save shared channel user", func(t *testing.T) {
 		scUser := &model.SharedChannelUser{
@@ -66,6 +66,8 @@ type Store struct {
 	DesktopTokensStore              mocks.DesktopTokensStore
 	ChannelBookmarkStore            mocks.ChannelBookmarkStore
 	ScheduledPostStore              mocks.ScheduledPostStore
+	OutOfOfficeStore                mocks.OutOfOfficeStore
+	NotificationEscalationStore     mocks.PersistentNotificationEscalationStore
 }
 
 func (s *Store) SetContext(context context.Context)            { s.context = context }
@@ -119,6 +121,10 @@ func (s *Store) LinkMetadata() store.LinkMetadataStore       { return &s.LinkMet
 func (s *Store) SharedChannel() store.SharedChannelStore     { return &s.SharedChannelStore }
 func (s *Store) PostPriority() store.PostPriorityStore       { return &s.PostPriorityStore }
 func (s *Store) ScheduledPost() store.ScheduledPostStore     { return &s.ScheduledPostStore }
+func (s *Store) OutOfOffice() store.OutOfOfficeStore         { return &s.OutOfOfficeStore }
+func (s *Store) PersistentNotificationEscalation() store.PersistentNotificationEscalationStore {
+	return &s.NotificationEscalationStore
+}
 func (s *Store) PostAcknowledgement() store.PostAcknowledgementStore {
 	return &s.PostAcknowledgementStore
 }
@@ -191,5 +197,7 @@ func (s *Store) AssertExpectations(t mock.TestingT) bool {
 		&s.DesktopTokensStore,
 		&s.ChannelBookmarkStore,
 		&s.ScheduledPostStore,
+		&s.OutOfOfficeStore,
+		&s.NotificationEscalationStore,
 	)
 }
@@ -41,6 +41,9 @@ func TestWebhookStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("UpdateOutgoing", func(t *testing.T) { testWebhookStoreUpdateOutgoing(t, rctx, ss) })
 	t.Run("CountIncoming", func(t *testing.T) { testWebhookStoreCountIncoming(t, rctx, ss) })
 	t.Run("CountOutgoing", func(t *testing.T) { testWebhookStoreCountOutgoing(t, rctx, ss) })
+	t.Run("OutgoingDeliveries", func(t *testing.T) { testWebhookStoreOutgoingDeliveries(t, rctx, ss) })
+	t.Run("GetOutgoingDeliveriesToRetry", func(t *testing.T) { testWebhookStoreGetOutgoingDeliveriesToRetry(t, rctx, ss) })
+	t.Run("PermanentDeleteOutgoingDeliveriesBefore", func(t *testing.T) { testWebhookStorePermanentDeleteOutgoingDeliveriesBefore(t, rctx, ss) })
 }
 
 func testWebhookStoreSaveIncoming(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -71,6 +74,16 @@ func testWebhookStoreUpdateIncoming(t *testing.T, rctx request.CTX, ss store.Sto
 	require.NotEqual(t, webhook.UpdateAt, previousUpdatedAt, "should have updated the UpdatedAt of the hook")
 
 	require.Equal(t, "TestHook", webhook.DisplayName, "display name is not updated")
+
+	o1.PayloadFormat = model.IncomingWebhookFormatGitHub
+	o1.Secret = "secret"
+	_, err = ss.Webhook().UpdateIncoming(o1)
+	require.NoError(t, err)
+
+	webhook, err = ss.Webhook().GetIncoming(o1.Id, false)
+	require.NoError(t, err)
+	require.Equal(t, model.IncomingWebhookFormatGitHub, webhook.PayloadFormat)
+	require.Equal(t, "secret", webhook.Secret)
 }
 
 func testWebhookStoreGetIncoming(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -373,6 +386,10 @@ func testWebhookStoreGetOutgoingList(t *testing.T, rctx request.CTX, ss store.St
 	result, err := ss.Webhook().GetOutgoingList(0, 2)
 	require.NoError(t, err)
 	require.Len(t, result, 2, "wrong number of hooks returned")
+
+	result, err = ss.Webhook().GetOutgoingList(0, -1)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(result), 2, "all of the hooks should have been returned")
 }
 
 func testWebhookStoreGetOutgoingByChannel(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -564,9 +581,16 @@ func testWebhookStoreUpdateOutgoing(t *testing.T, rctx request.CTX, ss store.Sto
 
 	o1.Token = model.NewId()
 	o1.Username = "another-test-user-name"
+	o1.PayloadTemplate = `{"text": {{json .Post.Message}}}`
+	o1.Headers = model.StringMap{"X-Api-Key": "secret"}
 
 	_, err := ss.Webhook().UpdateOutgoing(o1)
 	require.NoError(t, err)
+
+	webhook, err := ss.Webhook().GetOutgoing(o1.Id)
+	require.NoError(t, err)
+	require.Equal(t, o1.PayloadTemplate, webhook.PayloadTemplate)
+	require.Equal(t, o1.Headers, webhook.Headers)
 }
 
 func testWebhookStoreCountIncoming(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -615,3 +639,114 @@ func testWebhookStoreCountOutgoing(t *testing.T, rctx request.CTX, ss store.Stor
 	require.NoError(t, err)
 	require.NotEqual(t, 0, r, "should have at least 1 outgoing hook")
 }
+
+func buildOutgoingWebhookDelivery(hookId string) *model.OutgoingWebhookDelivery {
+	return &model.OutgoingWebhookDelivery{
+		HookId:      hookId,
+		PostId:      model.NewId(),
+		ChannelId:   model.NewId(),
+		CallbackURL: "http://nowhere.com/",
+		ContentType: "application/json",
+		Payload:     `{"text":"hello"}`,
+	}
+}
+
+func testWebhookStoreOutgoingDeliveries(t *testing.T, rctx request.CTX, ss store.Store) {
+	hookId := model.NewId()
+
+	d1, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(hookId))
+	require.NoError(t, err)
+	require.Equal(t, model.OutgoingWebhookDeliveryStatusPending, d1.Status)
+
+	_, err = ss.Webhook().SaveOutgoingDelivery(d1)
+	require.Error(t, err, "shouldn't be able to update from save")
+
+	time.Sleep(time.Millisecond)
+	d2, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(hookId))
+	require.NoError(t, err)
+
+	_, err = ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(model.NewId()))
+	require.NoError(t, err)
+
+	d1.Status = model.OutgoingWebhookDeliveryStatusSuccess
+	d1.Attempts = 1
+	d1.StatusCode = 200
+	d1.Latency = 42
+	d1.ResponseExcerpt = `{"text":"ok"}`
+	_, err = ss.Webhook().UpdateOutgoingDelivery(d1)
+	require.NoError(t, err)
+
+	delivery, err := ss.Webhook().GetOutgoingDelivery(d1.Id)
+	require.NoError(t, err)
+	require.Equal(t, d1, delivery)
+
+	_, err = ss.Webhook().GetOutgoingDelivery(model.NewId())
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+
+	deliveries, err := ss.Webhook().GetOutgoingDeliveriesForHook(hookId, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	require.Equal(t, d2.Id, deliveries[0].Id, "should return the most recent delivery first")
+	require.Equal(t, d1.Id, deliveries[1].Id)
+
+	deliveries, err = ss.Webhook().GetOutgoingDeliveriesForHook(hookId, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, d1.Id, deliveries[0].Id)
+}
+
+func testWebhookStoreGetOutgoingDeliveriesToRetry(t *testing.T, rctx request.CTX, ss store.Store) {
+	now := model.GetMillis()
+
+	save := func(status string, nextAttemptAt int64) *model.OutgoingWebhookDelivery {
+		delivery, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(model.NewId()))
+		require.NoError(t, err)
+
+		delivery.Status = status
+		delivery.NextAttemptAt = nextAttemptAt
+		delivery, err = ss.Webhook().UpdateOutgoingDelivery(delivery)
+		require.NoError(t, err)
+		return delivery
+	}
+
+	due := save(model.OutgoingWebhookDeliveryStatusRetrying, now-1000)
+	save(model.OutgoingWebhookDeliveryStatusRetrying, now+60000)
+	save(model.OutgoingWebhookDeliveryStatusFailed, now-1000)
+	save(model.OutgoingWebhookDeliveryStatusSuccess, 0)
+
+	deliveries, err := ss.Webhook().GetOutgoingDeliveriesToRetry(now, 100)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, d := range deliveries {
+		ids = append(ids, d.Id)
+		require.Equal(t, model.OutgoingWebhookDeliveryStatusRetrying, d.Status)
+		require.LessOrEqual(t, d.NextAttemptAt, now)
+	}
+	require.Contains(t, ids, due.Id)
+}
+
+func testWebhookStorePermanentDeleteOutgoingDeliveriesBefore(t *testing.T, rctx request.CTX, ss store.Store) {
+	hookId := model.NewId()
+
+	d1, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(hookId))
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+	cutoff := model.GetMillis()
+	time.Sleep(10 * time.Millisecond)
+
+	d2, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(hookId))
+	require.NoError(t, err)
+
+	deleted, err := ss.Webhook().PermanentDeleteOutgoingDeliveriesBefore(cutoff)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, deleted, int64(1))
+
+	_, err = ss.Webhook().GetOutgoingDelivery(d1.Id)
+	require.Error(t, err)
+
+	_, err = ss.Webhook().GetOutgoingDelivery(d2.Id)
+	require.NoError(t, err)
+}
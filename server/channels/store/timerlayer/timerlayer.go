@@ -18,53 +18,55 @@ import (
 
 type TimerLayer struct {
 	store.Store
-	Metrics                         einterfaces.MetricsInterface
-	AuditStore                      store.AuditStore
-	BotStore                        store.BotStore
-	ChannelStore                    store.ChannelStore
-	ChannelBookmarkStore            store.ChannelBookmarkStore
-	ChannelMemberHistoryStore       store.ChannelMemberHistoryStore
-	ClusterDiscoveryStore           store.ClusterDiscoveryStore
-	CommandStore                    store.CommandStore
-	CommandWebhookStore             store.CommandWebhookStore
-	ComplianceStore                 store.ComplianceStore
-	DesktopTokensStore              store.DesktopTokensStore
-	DraftStore                      store.DraftStore
-	EmojiStore                      store.EmojiStore
-	FileInfoStore                   store.FileInfoStore
-	GroupStore                      store.GroupStore
-	JobStore                        store.JobStore
-	LicenseStore                    store.LicenseStore
-	LinkMetadataStore               store.LinkMetadataStore
-	NotifyAdminStore                store.NotifyAdminStore
-	OAuthStore                      store.OAuthStore
-	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
-	PluginStore                     store.PluginStore
-	PostStore                       store.PostStore
-	PostAcknowledgementStore        store.PostAcknowledgementStore
-	PostPersistentNotificationStore store.PostPersistentNotificationStore
-	PostPriorityStore               store.PostPriorityStore
-	PreferenceStore                 store.PreferenceStore
-	ProductNoticesStore             store.ProductNoticesStore
-	ReactionStore                   store.ReactionStore
-	RemoteClusterStore              store.RemoteClusterStore
-	RetentionPolicyStore            store.RetentionPolicyStore
-	RoleStore                       store.RoleStore
-	ScheduledPostStore              store.ScheduledPostStore
-	SchemeStore                     store.SchemeStore
-	SessionStore                    store.SessionStore
-	SharedChannelStore              store.SharedChannelStore
-	StatusStore                     store.StatusStore
-	SystemStore                     store.SystemStore
-	TeamStore                       store.TeamStore
-	TermsOfServiceStore             store.TermsOfServiceStore
-	ThreadStore                     store.ThreadStore
-	TokenStore                      store.TokenStore
-	UploadSessionStore              store.UploadSessionStore
-	UserStore                       store.UserStore
-	UserAccessTokenStore            store.UserAccessTokenStore
-	UserTermsOfServiceStore         store.UserTermsOfServiceStore
-	WebhookStore                    store.WebhookStore
+	Metrics                               einterfaces.MetricsInterface
+	AuditStore                            store.AuditStore
+	BotStore                              store.BotStore
+	ChannelStore                          store.ChannelStore
+	ChannelBookmarkStore                  store.ChannelBookmarkStore
+	ChannelMemberHistoryStore             store.ChannelMemberHistoryStore
+	ClusterDiscoveryStore                 store.ClusterDiscoveryStore
+	CommandStore                          store.CommandStore
+	CommandWebhookStore                   store.CommandWebhookStore
+	ComplianceStore                       store.ComplianceStore
+	DesktopTokensStore                    store.DesktopTokensStore
+	DraftStore                            store.DraftStore
+	EmojiStore                            store.EmojiStore
+	FileInfoStore                         store.FileInfoStore
+	GroupStore                            store.GroupStore
+	JobStore                              store.JobStore
+	LicenseStore                          store.LicenseStore
+	LinkMetadataStore                     store.LinkMetadataStore
+	NotifyAdminStore                      store.NotifyAdminStore
+	OAuthStore                            store.OAuthStore
+	OutOfOfficeStore                      store.OutOfOfficeStore
+	OutgoingOAuthConnectionStore          store.OutgoingOAuthConnectionStore
+	PersistentNotificationEscalationStore store.PersistentNotificationEscalationStore
+	PluginStore                           store.PluginStore
+	PostStore                             store.PostStore
+	PostAcknowledgementStore              store.PostAcknowledgementStore
+	PostPersistentNotificationStore       store.PostPersistentNotificationStore
+	PostPriorityStore                     store.PostPriorityStore
+	PreferenceStore                       store.PreferenceStore
+	ProductNoticesStore                   store.ProductNoticesStore
+	ReactionStore                         store.ReactionStore
+	RemoteClusterStore                    store.RemoteClusterStore
+	RetentionPolicyStore                  store.RetentionPolicyStore
+	RoleStore                             store.RoleStore
+	ScheduledPostStore                    store.ScheduledPostStore
+	SchemeStore                           store.SchemeStore
+	SessionStore                          store.SessionStore
+	SharedChannelStore                    store.SharedChannelStore
+	StatusStore                           store.StatusStore
+	SystemStore                           store.SystemStore
+	TeamStore                             store.TeamStore
+	TermsOfServiceStore                   store.TermsOfServiceStore
+	ThreadStore                           store.ThreadStore
+	TokenStore                            store.TokenStore
+	UploadSessionStore                    store.UploadSessionStore
+	UserStore                             store.UserStore
+	UserAccessTokenStore                  store.UserAccessTokenStore
+	UserTermsOfServiceStore               store.UserTermsOfServiceStore
+	WebhookStore                          store.WebhookStore
 }
 
 func (s *TimerLayer) Audit() store.AuditStore {
@@ -143,10 +145,18 @@ func (s *TimerLayer) OAuth() store.OAuthStore {
 	return s.OAuthStore
 }
 
+func (s *TimerLayer) OutOfOffice() store.OutOfOfficeStore {
+	return s.OutOfOfficeStore
+}
+
 func (s *TimerLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
 	return s.OutgoingOAuthConnectionStore
 }
 
+func (s *TimerLayer) PersistentNotificationEscalation() store.PersistentNotificationEscalationStore {
+	return s.PersistentNotificationEscalationStore
+}
+
 func (s *TimerLayer) Plugin() store.PluginStore {
 	return s.PluginStore
 }
@@ -346,11 +356,21 @@ type TimerLayerOAuthStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerOutOfOfficeStore struct {
+	store.OutOfOfficeStore
+	Root *TimerLayer
+}
+
 type TimerLayerOutgoingOAuthConnectionStore struct {
 	store.OutgoingOAuthConnectionStore
 	Root *TimerLayer
 }
 
+type TimerLayerPersistentNotificationEscalationStore struct {
+	store.PersistentNotificationEscalationStore
+	Root *TimerLayer
+}
+
 type TimerLayerPluginStore struct {
 	store.PluginStore
 	Root *TimerLayer
@@ -3367,6 +3387,38 @@ func (s *TimerLayerDraftStore) GetLastCreateAtAndUserIdValuesForEmptyDraftsMigra
 	return result, resultVar1, err
 }
 
+func (s *TimerLayerDraftStore) GetRevision(userID string, channelID string, rootID string, version int64) (*model.Draft, error) {
+	start := time.Now()
+
+	result, err := s.DraftStore.GetRevision(userID, channelID, rootID, version)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("DraftStore.GetRevision", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerDraftStore) GetRevisions(userID string, channelID string, rootID string) ([]*model.Draft, error) {
+	start := time.Now()
+
+	result, err := s.DraftStore.GetRevisions(userID, channelID, rootID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("DraftStore.GetRevisions", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerDraftStore) Upsert(d *model.Draft) (*model.Draft, error) {
 	start := time.Now()
 
@@ -5493,6 +5545,86 @@ func (s *TimerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp,
 	return result, err
 }
 
+func (s *TimerLayerOutOfOfficeStore) Delete(userID string) error {
+	start := time.Now()
+
+	err := s.OutOfOfficeStore.Delete(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OutOfOfficeStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerOutOfOfficeStore) Get(userID string) (*model.OutOfOffice, error) {
+	start := time.Now()
+
+	result, err := s.OutOfOfficeStore.Get(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OutOfOfficeStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerOutOfOfficeStore) GetPendingTransitions(now int64, limit int) ([]*model.OutOfOffice, error) {
+	start := time.Now()
+
+	result, err := s.OutOfOfficeStore.GetPendingTransitions(now, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OutOfOfficeStore.GetPendingTransitions", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerOutOfOfficeStore) Save(outOfOffice *model.OutOfOffice) (*model.OutOfOffice, error) {
+	start := time.Now()
+
+	result, err := s.OutOfOfficeStore.Save(outOfOffice)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OutOfOfficeStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerOutOfOfficeStore) SetActive(userID string, active bool) error {
+	start := time.Now()
+
+	err := s.OutOfOfficeStore.SetActive(userID, active)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OutOfOfficeStore.SetActive", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerOutgoingOAuthConnectionStore) DeleteConnection(c request.CTX, id string) error {
 	start := time.Now()
 
@@ -5573,6 +5705,86 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) UpdateConnection(c request.CTX,
 	return result, err
 }
 
+func (s *TimerLayerPersistentNotificationEscalationStore) Delete(id string) error {
+	start := time.Now()
+
+	err := s.PersistentNotificationEscalationStore.Delete(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PersistentNotificationEscalationStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPersistentNotificationEscalationStore) DeleteByChannel(channelIds []string) error {
+	start := time.Now()
+
+	err := s.PersistentNotificationEscalationStore.DeleteByChannel(channelIds)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PersistentNotificationEscalationStore.DeleteByChannel", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPersistentNotificationEscalationStore) Get(id string) (*model.PersistentNotificationEscalation, error) {
+	start := time.Now()
+
+	result, err := s.PersistentNotificationEscalationStore.Get(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PersistentNotificationEscalationStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPersistentNotificationEscalationStore) GetForChannels(channelIds []string) ([]*model.PersistentNotificationEscalation, error) {
+	start := time.Now()
+
+	result, err := s.PersistentNotificationEscalationStore.GetForChannels(channelIds)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PersistentNotificationEscalationStore.GetForChannels", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPersistentNotificationEscalationStore) Save(escalation *model.PersistentNotificationEscalation) (*model.PersistentNotificationEscalation, error) {
+	start := time.Now()
+
+	result, err := s.PersistentNotificationEscalationStore.Save(escalation)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PersistentNotificationEscalationStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 	start := time.Now()
 
@@ -5796,6 +6008,22 @@ func (s *TimerLayerPostStore) Delete(rctx request.CTX, postID string, timestamp
 	return err
 }
 
+func (s *TimerLayerPostStore) DeletePostReminder(postID string, userID string) error {
+	start := time.Now()
+
+	err := s.PostStore.DeletePostReminder(postID, userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.DeletePostReminder", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerPostStore) Get(ctx context.Context, id string, opts model.GetPostsOptions, userID string, sanitizeOptions map[string]bool) (*model.PostList, error) {
 	start := time.Now()
 
@@ -6068,6 +6296,22 @@ func (s *TimerLayerPostStore) GetPostReminders(now int64) ([]*model.PostReminder
 	return result, err
 }
 
+func (s *TimerLayerPostStore) GetPostRemindersForUser(userID string) ([]*model.PostReminder, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetPostRemindersForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostRemindersForUser", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
 	start := time.Now()
 
@@ -7459,6 +7703,22 @@ func (s *TimerLayerRetentionPolicyStore) GetCount() (int64, error) {
 	return result, err
 }
 
+func (s *TimerLayerRetentionPolicyStore) GetDryRun(policyID string, now int64, globalPolicyEndTime int64, globalFileEndTime int64) ([]*model.RetentionPolicyDryRunChannel, error) {
+	start := time.Now()
+
+	result, err := s.RetentionPolicyStore.GetDryRun(policyID, now, globalPolicyEndTime, globalFileEndTime)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("RetentionPolicyStore.GetDryRun", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerRetentionPolicyStore) GetIdsForDeletionByTableName(tableName string, limit int) ([]*model.RetentionIdsForDeletion, error) {
 	start := time.Now()
 
@@ -7827,6 +8087,22 @@ func (s *TimerLayerScheduledPostStore) GetPendingScheduledPosts(beforeTime int64
 	return result, err
 }
 
+func (s *TimerLayerScheduledPostStore) GetScheduledPostSeriesForUser(userId string) ([]*model.ScheduledPost, error) {
+	start := time.Now()
+
+	result, err := s.ScheduledPostStore.GetScheduledPostSeriesForUser(userId)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledPostStore.GetScheduledPostSeriesForUser", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerScheduledPostStore) GetScheduledPostsForUser(userId string, teamId string) ([]*model.ScheduledPost, error) {
 	start := time.Now()
 
@@ -8483,6 +8759,22 @@ func (s *TimerLayerSharedChannelStore) GetRemotesStatus(channelID string) ([]*mo
 	return result, err
 }
 
+func (s *TimerLayerSharedChannelStore) GetRemotesSyncStatus(channelID string) ([]*model.SharedChannelRemoteSyncStatus, error) {
+	start := time.Now()
+
+	result, err := s.SharedChannelStore.GetRemotesSyncStatus(channelID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetRemotesSyncStatus", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerSharedChannelStore) GetSingleUser(userID string, channelID string, remoteID string) (*model.SharedChannelUser, error) {
 	start := time.Now()
 
@@ -8691,6 +8983,22 @@ func (s *TimerLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor mode
 	return err
 }
 
+func (s *TimerLayerSharedChannelStore) UpdateRemoteSyncError(channelID string, remoteID string, syncErr string) error {
+	start := time.Now()
+
+	err := s.SharedChannelStore.UpdateRemoteSyncError(channelID, remoteID, syncErr)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateRemoteSyncError", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerSharedChannelStore) UpdateUserLastSyncAt(userID string, channelID string, remoteID string) error {
 	start := time.Now()
 
@@ -12203,6 +12511,54 @@ func (s *TimerLayerWebhookStore) GetOutgoingByTeamByUser(teamID string, userID s
 	return result, err
 }
 
+func (s *TimerLayerWebhookStore) GetOutgoingDeliveriesForHook(hookID string, offset int, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.GetOutgoingDeliveriesForHook(hookID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingDeliveriesForHook", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebhookStore) GetOutgoingDeliveriesToRetry(now int64, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.GetOutgoingDeliveriesToRetry(now, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingDeliveriesToRetry", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebhookStore) GetOutgoingDelivery(id string) (*model.OutgoingWebhookDelivery, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.GetOutgoingDelivery(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingDelivery", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, error) {
 	start := time.Now()
 
@@ -12314,6 +12670,22 @@ func (s *TimerLayerWebhookStore) PermanentDeleteOutgoingByUser(userID string) er
 	return err
 }
 
+func (s *TimerLayerWebhookStore) PermanentDeleteOutgoingDeliveriesBefore(before int64) (int64, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.PermanentDeleteOutgoingDeliveriesBefore(before)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.PermanentDeleteOutgoingDeliveriesBefore", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 	start := time.Now()
 
@@ -12346,6 +12718,22 @@ func (s *TimerLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*
 	return result, err
 }
 
+func (s *TimerLayerWebhookStore) SaveOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.SaveOutgoingDelivery(delivery)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.SaveOutgoingDelivery", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 	start := time.Now()
 
@@ -12378,6 +12766,22 @@ func (s *TimerLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*m
 	return result, err
 }
 
+func (s *TimerLayerWebhookStore) UpdateOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.UpdateOutgoingDelivery(delivery)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.UpdateOutgoingDelivery", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayer) Close() {
 	s.Store.Close()
 }
@@ -12439,7 +12843,9 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.LinkMetadataStore = &TimerLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
 	newStore.NotifyAdminStore = &TimerLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &TimerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.OutOfOfficeStore = &TimerLayerOutOfOfficeStore{OutOfOfficeStore: childStore.OutOfOffice(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &TimerLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
+	newStore.PersistentNotificationEscalationStore = &TimerLayerPersistentNotificationEscalationStore{PersistentNotificationEscalationStore: childStore.PersistentNotificationEscalation(), Root: &newStore}
 	newStore.PluginStore = &TimerLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
 	newStore.PostStore = &TimerLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
 	newStore.PostAcknowledgementStore = &TimerLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
@@ -406,6 +406,17 @@ func (c *Context) RequireTimestamp() *Context {
 	return c
 }
 
+func (c *Context) RequireDraftVersion() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if c.Params.DraftVersion == 0 {
+		c.SetInvalidURLParam("draft_version")
+	}
+	return c
+}
+
 func (c *Context) RequireChannelId() *Context {
 	if c.Err != nil {
 		return c
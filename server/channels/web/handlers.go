@@ -307,9 +307,9 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			c.AppContext = c.AppContext.WithSession(session)
 		}
 
-		// Rate limit by UserID
+		// Rate limit by UserID, or by token for personal access tokens
 		if c.App.Srv().RateLimiter != nil {
-			rateLimitExceeded = c.App.Srv().RateLimiter.UserIdRateLimit(c.AppContext.Session().UserId, w)
+			rateLimitExceeded = c.App.Srv().RateLimiter.SessionRateLimit(c.AppContext.Session(), r, w)
 			if rateLimitExceeded {
 				return
 			}
@@ -109,6 +109,12 @@ type Params struct {
 	ChannelBookmarkId string
 	BookmarksSince    int64
 
+	// Persistent notifications
+	EscalationId string
+
+	// Drafts
+	DraftVersion int64
+
 	// Cloud
 	InvoiceId string
 }
@@ -178,6 +184,7 @@ func ParamsFromRequest(r *http.Request) *Params {
 	params.ExcludeHome, _ = strconv.ParseBool(query.Get("exclude_home"))
 	params.ExcludeRemote, _ = strconv.ParseBool(query.Get("exclude_remote"))
 	params.ChannelBookmarkId = props["bookmark_id"]
+	params.EscalationId = props["escalation_id"]
 	params.Scope = query.Get("scope")
 
 	if val, err := strconv.Atoi(query.Get("page")); err != nil || val < 0 {
@@ -286,5 +293,11 @@ func ParamsFromRequest(r *http.Request) *Params {
 		params.BookmarksSince = val
 	}
 
+	if val, err := strconv.ParseInt(props["draft_version"], 10, 64); err != nil || val < 0 {
+		params.DraftVersion = 0
+	} else {
+		params.DraftVersion = val
+	}
+
 	return params
 }
@@ -26,6 +26,12 @@ func incomingWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id := params["id"]
 
+	// Hooks with a payload format accept the native payloads of a service instead of Slack compatible requests
+	if hook, appErr := c.App.GetIncomingWebhook(id); appErr == nil && hook.PayloadFormat != "" {
+		handleIncomingWebhookPayload(c, w, r, hook)
+		return
+	}
+
 	r.ParseForm()
 
 	var err *model.AppError
@@ -104,6 +110,41 @@ func incomingWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("ok"))
 }
 
+func handleIncomingWebhookPayload(c *Context, w http.ResponseWriter, r *http.Request, hook *model.IncomingWebhook) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		c.Err = model.NewAppError("incomingWebhook", "web.incoming_webhook.parse.app_error", nil, "webhook_id="+hook.Id, http.StatusBadRequest).Wrap(err)
+		return
+	}
+
+	defer func() {
+		if *c.App.Config().LogSettings.EnableWebhookDebugging && c.Err != nil {
+			mlog.Debug("Incoming webhook received",
+				mlog.String("webhook_id", hook.Id),
+				mlog.String("request_id", c.AppContext.RequestId()),
+				mlog.String("payload_format", hook.PayloadFormat),
+				mlog.String("payload", string(body)),
+			)
+		}
+	}()
+
+	incomingWebhookPayload, appErr := c.App.ParseIncomingWebhookPayload(c.AppContext, hook, r.Header, body)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if incomingWebhookPayload != nil {
+		if appErr = c.App.HandleIncomingWebhook(c.AppContext, hook.Id, incomingWebhookPayload); appErr != nil {
+			c.Err = appErr
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte("ok"))
+}
+
 func commandWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	id := params["id"]
@@ -5,6 +5,9 @@ package web
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"strings"
@@ -229,6 +232,57 @@ func TestIncomingWebhook(t *testing.T) {
 		assert.True(t, resp.StatusCode == http.StatusForbidden)
 	})
 
+	t.Run("PayloadFormat", func(t *testing.T) {
+		hook, appErr := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{
+			ChannelId:     th.BasicChannel.Id,
+			PayloadFormat: model.IncomingWebhookFormatGitHub,
+			Secret:        "secret",
+		})
+		require.Nil(t, appErr)
+
+		apiHookURL := apiClient.URL + "/hooks/" + hook.Id
+		postGitHubEvent := func(t *testing.T, event string, body string, secret string) *http.Response {
+			t.Helper()
+
+			mac := hmac.New(sha256.New, []byte(secret))
+			mac.Write([]byte(body))
+
+			req, err := http.NewRequest(http.MethodPost, apiHookURL, strings.NewReader(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-GitHub-Event", event)
+			req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			return resp
+		}
+
+		body := `{
+			"action": "opened",
+			"repository": {"full_name": "octo/repo", "html_url": "https://github.com/octo/repo"},
+			"sender": {"login": "octocat"},
+			"issue": {"number": 1, "title": "Found a bug", "html_url": "https://github.com/octo/repo/issues/1"}
+		}`
+
+		resp := postGitHubEvent(t, "issues", body, "other")
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		resp = postGitHubEvent(t, "ping", `{"zen": "Speak like a human."}`, "secret")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp = postGitHubEvent(t, "issues", body, "secret")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		posts, appErr := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: th.BasicChannel.Id, PerPage: 1})
+		require.Nil(t, appErr)
+		require.Len(t, posts.Order, 1)
+		attachments := posts.Posts[posts.Order[0]].Attachments()
+		require.Len(t, attachments, 1)
+		assert.Equal(t, "#1 Found a bug", attachments[0].Title)
+	})
+
 	t.Run("DisableWebhooks", func(t *testing.T) {
 		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableIncomingWebhooks = false })
 		resp, err := http.Post(url, "application/json", strings.NewReader("{\"text\":\"this is a test\"}"))
@@ -49,6 +49,22 @@ var ActianceExportCmd = &cobra.Command{
 	RunE:    buildExportCmdF("actiance"),
 }
 
+var EmlExportCmd = &cobra.Command{
+	Use:     "eml",
+	Short:   "Export data from Mattermost in EML format",
+	Long:    "Export data from Mattermost into a zip file containing one RFC 5322 email per post",
+	Example: "export eml --exportFrom=12345",
+	RunE:    buildExportCmdF("eml"),
+}
+
+var MboxExportCmd = &cobra.Command{
+	Use:     "mbox",
+	Short:   "Export data from Mattermost in MBOX format",
+	Long:    "Export data from Mattermost into a zip file containing one MBOX archive per channel",
+	Example: "export mbox --exportFrom=12345",
+	RunE:    buildExportCmdF("mbox"),
+}
+
 var GlobalRelayZipExportCmd = &cobra.Command{
 	Use:     "global-relay-zip",
 	Short:   "Export data from Mattermost into a zip file containing emails to send to Global Relay for debug and testing purposes only.",
@@ -77,6 +93,12 @@ func init() {
 	ActianceExportCmd.Flags().Int64("exportFrom", -1, "The timestamp of the earliest post to export, expressed in seconds since the unix epoch.")
 	ActianceExportCmd.Flags().Int("limit", -1, "The number of posts to export. The default of -1 means no limit.")
 
+	EmlExportCmd.Flags().Int64("exportFrom", -1, "The timestamp of the earliest post to export, expressed in seconds since the unix epoch.")
+	EmlExportCmd.Flags().Int("limit", -1, "The number of posts to export. The default of -1 means no limit.")
+
+	MboxExportCmd.Flags().Int64("exportFrom", -1, "The timestamp of the earliest post to export, expressed in seconds since the unix epoch.")
+	MboxExportCmd.Flags().Int("limit", -1, "The number of posts to export. The default of -1 means no limit.")
+
 	GlobalRelayZipExportCmd.Flags().Int64("exportFrom", -1, "The timestamp of the earliest post to export, expressed in seconds since the unix epoch.")
 	GlobalRelayZipExportCmd.Flags().Int("limit", -1, "The number of posts to export. The default of -1 means no limit.")
 
@@ -89,6 +111,8 @@ func init() {
 	ExportCmd.AddCommand(ScheduleExportCmd)
 	ExportCmd.AddCommand(CsvExportCmd)
 	ExportCmd.AddCommand(ActianceExportCmd)
+	ExportCmd.AddCommand(EmlExportCmd)
+	ExportCmd.AddCommand(MboxExportCmd)
 	ExportCmd.AddCommand(GlobalRelayZipExportCmd)
 	ExportCmd.AddCommand(BulkExportCmd)
 
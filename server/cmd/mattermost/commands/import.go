@@ -57,7 +57,8 @@ func slackImportCmdF(command *cobra.Command, args []string) error {
 	}
 	defer a.Srv().Shutdown()
 
-	rctx := request.EmptyContext(a.Log())
+	// The command is run by the server operator, with the same privileges as local mode.
+	rctx := request.EmptyContext(a.Log()).WithSession(&model.Session{Local: true})
 
 	if len(args) != 2 {
 		return errors.New("Incorrect number of arguments.")
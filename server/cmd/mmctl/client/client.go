@@ -16,6 +16,8 @@ type Client interface {
 	RemoveUserFromChannel(ctx context.Context, channelID, userID string) (*model.Response, error)
 	GetChannelMembers(ctx context.Context, channelID string, page, perPage int, etag string) (model.ChannelMembers, *model.Response, error)
 	AddChannelMember(ctx context.Context, channelID, userID string) (*model.ChannelMember, *model.Response, error)
+	UpdateChannelMemberSchemeRoles(ctx context.Context, channelID string, userID string, schemeRoles *model.SchemeRoles) (*model.Response, error)
+	UpdateChannelScheme(ctx context.Context, channelID, schemeID string) (*model.Response, error)
 	DeleteChannel(ctx context.Context, channelID string) (*model.Response, error)
 	PermanentDeleteChannel(ctx context.Context, channelID string) (*model.Response, error)
 	MoveChannel(ctx context.Context, channelID, teamID string, force bool) (*model.Channel, *model.Response, error)
@@ -25,6 +27,12 @@ type Client interface {
 	GetChannelsForTeamForUser(ctx context.Context, teamID, userID string, includeDeleted bool, etag string) ([]*model.Channel, *model.Response, error)
 	RestoreChannel(ctx context.Context, channelID string) (*model.Channel, *model.Response, error)
 	PatchChannel(ctx context.Context, channelID string, patch *model.ChannelPatch) (*model.Channel, *model.Response, error)
+	ListChannelBookmarksForChannel(ctx context.Context, channelID string, since int64) ([]*model.ChannelBookmarkWithFileInfo, *model.Response, error)
+	CreateChannelBookmark(ctx context.Context, channelBookmark *model.ChannelBookmark) (*model.ChannelBookmarkWithFileInfo, *model.Response, error)
+	UpdateChannelBookmark(ctx context.Context, channelID, bookmarkID string, patch *model.ChannelBookmarkPatch) (*model.UpdateChannelBookmarkResponse, *model.Response, error)
+	UpdateChannelBookmarkSortOrder(ctx context.Context, channelID, bookmarkID string, sortOrder int64) ([]*model.ChannelBookmarkWithFileInfo, *model.Response, error)
+	DeleteChannelBookmark(ctx context.Context, channelID, bookmarkID string) (*model.ChannelBookmarkWithFileInfo, *model.Response, error)
+	UploadBookmarkFile(ctx context.Context, data []byte, channelID string, filename string) (*model.FileUploadResponse, *model.Response, error)
 	GetChannelByName(ctx context.Context, channelName, teamID string, etag string) (*model.Channel, *model.Response, error)
 	GetChannelByNameIncludeDeleted(ctx context.Context, channelName, teamID string, etag string) (*model.Channel, *model.Response, error)
 	GetChannel(ctx context.Context, channelID, etag string) (*model.Channel, *model.Response, error)
@@ -34,6 +42,9 @@ type Client interface {
 	CreateTeam(ctx context.Context, team *model.Team) (*model.Team, *model.Response, error)
 	PatchTeam(ctx context.Context, teamID string, patch *model.TeamPatch) (*model.Team, *model.Response, error)
 	AddTeamMember(ctx context.Context, teamID, userID string) (*model.TeamMember, *model.Response, error)
+	GetTeamMembers(ctx context.Context, teamID string, page int, perPage int, etag string) ([]*model.TeamMember, *model.Response, error)
+	UpdateTeamMemberSchemeRoles(ctx context.Context, teamID string, userID string, schemeRoles *model.SchemeRoles) (*model.Response, error)
+	UpdateTeamScheme(ctx context.Context, teamID, schemeID string) (*model.Response, error)
 	RemoveTeamMember(ctx context.Context, teamID, userID string) (*model.Response, error)
 	SoftDeleteTeam(ctx context.Context, teamID string) (*model.Response, error)
 	PermanentDeleteTeam(ctx context.Context, teamID string) (*model.Response, error)
@@ -44,6 +55,23 @@ type Client interface {
 	CreatePost(ctx context.Context, post *model.Post) (*model.Post, *model.Response, error)
 	GetPostsForChannel(ctx context.Context, channelID string, page, perPage int, etag string, collapsedThreads bool, includeDeleted bool) (*model.PostList, *model.Response, error)
 	GetPostsSince(ctx context.Context, channelID string, since int64, collapsedThreads bool) (*model.PostList, *model.Response, error)
+	CreateScheduledPost(ctx context.Context, scheduledPost *model.ScheduledPost) (*model.ScheduledPost, *model.Response, error)
+	GetUserScheduledPosts(ctx context.Context, teamID string, includeDirectChannels bool) (map[string][]*model.ScheduledPost, *model.Response, error)
+	DeleteScheduledPost(ctx context.Context, scheduledPostID string) (*model.ScheduledPost, *model.Response, error)
+	GetDrafts(ctx context.Context, userID, teamID string) ([]*model.Draft, *model.Response, error)
+	DeleteDraft(ctx context.Context, userID, channelID, rootID string) (*model.Draft, *model.Response, error)
+	GetRemoteClusters(ctx context.Context, page, perPage int, filter model.RemoteClusterQueryFilter) ([]*model.RemoteCluster, *model.Response, error)
+	GetRemoteCluster(ctx context.Context, remoteClusterID string) (*model.RemoteCluster, *model.Response, error)
+	CreateRemoteCluster(ctx context.Context, rcWithPassword *model.RemoteClusterWithPassword) (*model.RemoteClusterWithInvite, *model.Response, error)
+	RemoteClusterAcceptInvite(ctx context.Context, rcAcceptInvite *model.RemoteClusterAcceptInvite) (*model.RemoteCluster, *model.Response, error)
+	PingRemoteCluster(ctx context.Context, remoteClusterID string) (*model.RemoteCluster, *model.Response, error)
+	DeleteRemoteCluster(ctx context.Context, remoteClusterID string) (*model.Response, error)
+	GetAllSharedChannels(ctx context.Context, teamID string, page, perPage int) ([]*model.SharedChannel, *model.Response, error)
+	ShareChannel(ctx context.Context, sc *model.SharedChannel) (*model.SharedChannel, *model.Response, error)
+	UnshareChannel(ctx context.Context, channelID string) (*model.Response, error)
+	GetSharedChannelSyncStatus(ctx context.Context, channelID string) ([]*model.SharedChannelRemoteSyncStatus, *model.Response, error)
+	InviteRemoteClusterToChannel(ctx context.Context, remoteID, channelID string) (*model.Response, error)
+	UninviteRemoteClusterToChannel(ctx context.Context, remoteID, channelID string) (*model.Response, error)
 	DoAPIPost(ctx context.Context, url string, data string) (*http.Response, error)
 	GetLdapGroups(ctx context.Context) ([]*model.Group, *model.Response, error)
 	GetGroupsByChannel(ctx context.Context, channelID string, groupOpts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, int, *model.Response, error)
@@ -54,6 +82,15 @@ type Client interface {
 	GetLogs(ctx context.Context, page, perPage int) ([]string, *model.Response, error)
 	GetRoleByName(ctx context.Context, name string) (*model.Role, *model.Response, error)
 	PatchRole(ctx context.Context, roleID string, patch *model.RolePatch) (*model.Role, *model.Response, error)
+	GetSchemes(ctx context.Context, scope string, page int, perPage int) ([]*model.Scheme, *model.Response, error)
+	CreateScheme(ctx context.Context, scheme *model.Scheme) (*model.Scheme, *model.Response, error)
+	PatchScheme(ctx context.Context, schemeID string, patch *model.SchemePatch) (*model.Scheme, *model.Response, error)
+	GetScheme(ctx context.Context, schemeID string) (*model.Scheme, *model.Response, error)
+	DeleteScheme(ctx context.Context, schemeID string) (*model.Response, error)
+	GetTeamsForScheme(ctx context.Context, schemeID string, page int, perPage int) ([]*model.Team, *model.Response, error)
+	GetChannelsForScheme(ctx context.Context, schemeID string, page int, perPage int) (model.ChannelList, *model.Response, error)
+	CreateRole(ctx context.Context, role *model.Role) (*model.Role, *model.Response, error)
+	DeleteRole(ctx context.Context, roleID string) (*model.Response, error)
 	UploadPlugin(ctx context.Context, file io.Reader) (*model.Manifest, *model.Response, error)
 	UploadPluginForced(ctx context.Context, file io.Reader) (*model.Manifest, *model.Response, error)
 	RemovePlugin(ctx context.Context, id string) (*model.Response, error)
@@ -147,6 +184,8 @@ type Client interface {
 	GetOutgoingWebhooksForTeam(ctx context.Context, teamID string, page int, perPage int, etag string) ([]*model.OutgoingWebhook, *model.Response, error)
 	RegenOutgoingHookToken(ctx context.Context, hookID string) (*model.OutgoingWebhook, *model.Response, error)
 	DeleteOutgoingWebhook(ctx context.Context, hookID string) (*model.Response, error)
+	GetOutgoingWebhookDeliveries(ctx context.Context, hookID string, page int, perPage int) ([]*model.OutgoingWebhookDelivery, *model.Response, error)
+	RedeliverOutgoingWebhook(ctx context.Context, hookID string, deliveryID string) (*model.OutgoingWebhookDelivery, *model.Response, error)
 	ListExports(ctx context.Context) ([]string, *model.Response, error)
 	DeleteExport(ctx context.Context, name string) (*model.Response, error)
 	DownloadExport(ctx context.Context, name string, wr io.Writer, offset int64) (int64, *model.Response, error)
@@ -161,4 +200,11 @@ type Client interface {
 	DeletePreferences(ctx context.Context, userId string, preferences model.Preferences) (*model.Response, error)
 	PermanentDeletePost(ctx context.Context, postID string) (*model.Response, error)
 	DeletePost(ctx context.Context, postId string) (*model.Response, error)
+	GetDataRetentionDryRun(ctx context.Context) (*model.RetentionPolicyDryRun, *model.Response, error)
+	GetDataRetentionPolicyDryRun(ctx context.Context, policyID string) (*model.RetentionPolicyDryRun, *model.Response, error)
+	GetEmojiList(ctx context.Context, page, perPage int) ([]*model.Emoji, *model.Response, error)
+	GetEmojiByName(ctx context.Context, name string) (*model.Emoji, *model.Response, error)
+	GetEmojiImage(ctx context.Context, emojiID string) ([]byte, *model.Response, error)
+	CreateEmoji(ctx context.Context, emoji *model.Emoji, image []byte, filename string) (*model.Emoji, *model.Response, error)
+	DeleteEmoji(ctx context.Context, emojiID string) (*model.Response, error)
 }
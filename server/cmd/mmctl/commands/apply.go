@@ -0,0 +1,152 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+const applyChangeTemplate = `{{applySymbol .Action}} {{.Action}} {{.Kind}} {{.Name}}{{range .Details}}
+    {{.}}{{end}}`
+
+const applyStateDescription = `The desired-state document is written in YAML or JSON and may contain:
+  - roles: the permissions of existing roles
+  - schemes: team and channel schemes, with the permissions of their roles
+  - bots: bots with their display names and descriptions
+  - teams: teams with their members, channels, channel members and webhooks
+
+Only the objects listed in the document are managed, and only the fields that are set. Lists such as the members or
+channels of a team are only managed when they are present, and the objects missing from them are only removed with
+--prune. Teams, schemes and bots are never removed.
+
+Example document:
+
+  schemes:
+    - name: restricted
+      scope: channel
+      roles:
+        channel_user: [read_channel, create_post]
+  bots:
+    - username: ci
+      display_name: CI
+  teams:
+    - name: engineering
+      display_name: Engineering
+      type: invite
+      members:
+        - alice
+        - username: bob
+          admin: true
+        - ci
+      channels:
+        - name: town-square
+          header: Welcome to Engineering
+        - name: announcements
+          type: private
+          purpose: Company announcements
+          scheme: restricted
+          members: [alice, bob]
+      incoming_webhooks:
+        - display_name: Builds
+          channel: town-square
+          payload_format: github
+`
+
+var ApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a desired-state document to the server",
+	Long: `Compare a desired-state document with the server and make the changes needed to reach that state. Applying the same document again makes no further changes.
+
+` + applyStateDescription,
+	Example: `  apply -f state.yaml
+  apply -f state.yaml --dry-run
+  apply -f state.yaml --prune`,
+	Args: cobra.NoArgs,
+	RunE: withClient(applyCmdF),
+}
+
+var DiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show the changes needed to apply a desired-state document",
+	Long: `Compare a desired-state document with the server and show the changes that "apply" would make, without making them.
+
+` + applyStateDescription,
+	Example: `  diff -f state.yaml
+  diff -f state.yaml --prune`,
+	Args: cobra.NoArgs,
+	RunE: withClient(diffCmdF),
+}
+
+func init() {
+	ApplyCmd.Flags().StringP("file", "f", "", "Path of the desired-state document, or - to read it from the standard input")
+	ApplyCmd.Flags().Bool("dry-run", false, "Show the changes without making them")
+	ApplyCmd.Flags().Bool("prune", false, "Remove the members, channels and webhooks that are missing from the lists of the document")
+	_ = ApplyCmd.MarkFlagRequired("file")
+
+	DiffCmd.Flags().StringP("file", "f", "", "Path of the desired-state document, or - to read it from the standard input")
+	DiffCmd.Flags().Bool("prune", false, "Include the removal of the members, channels and webhooks that are missing from the lists of the document")
+	_ = DiffCmd.MarkFlagRequired("file")
+
+	RootCmd.AddCommand(
+		ApplyCmd,
+		DiffCmd,
+	)
+}
+
+func applyCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	return runApply(c, cmd, dryRun)
+}
+
+func diffCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	return runApply(c, cmd, true)
+}
+
+func runApply(c client.Client, cmd *cobra.Command, dryRun bool) error {
+	path, _ := cmd.Flags().GetString("file")
+	prune, _ := cmd.Flags().GetBool("prune")
+
+	state, err := readState(path)
+	if err != nil {
+		return err
+	}
+
+	changes, err := planState(c, state, prune)
+	if err != nil {
+		return errors.Wrap(err, "failed to compare the document with the server")
+	}
+
+	printer.SetTemplateFunc("applySymbol", applySymbol)
+
+	if len(changes) == 0 {
+		printer.Print("No changes")
+		return nil
+	}
+
+	for _, change := range changes {
+		if !dryRun {
+			if err := change.run(c); err != nil {
+				return errors.Wrapf(err, "failed to %s %s %q", change.Action, change.Kind, change.Name)
+			}
+		}
+		printer.PrintT(applyChangeTemplate, change)
+	}
+
+	return nil
+}
+
+func applySymbol(action string) string {
+	switch action {
+	case applyActionCreate, applyActionRestore, applyActionAdd:
+		return "+"
+	case applyActionRemove, applyActionArchive, applyActionDelete:
+		return "-"
+	default:
+		return "~"
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlE2ETestSuite) TestApplyCmd() {
+	s.SetupTestHelper().InitBasic()
+
+	newCmd := func(content string, prune bool) *cobra.Command {
+		path := filepath.Join(s.T().TempDir(), "state.yaml")
+		s.Require().NoError(os.WriteFile(path, []byte(content), 0600))
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("file", path, "")
+		cmd.Flags().Bool("dry-run", false, "")
+		cmd.Flags().Bool("prune", prune, "")
+		return cmd
+	}
+
+	s.RunForSystemAdminAndLocal("apply a document twice", func(c client.Client) {
+		printer.Clean()
+
+		teamName := "apply-" + model.NewId()[:8]
+		state := fmt.Sprintf(`
+teams:
+  - name: %s
+    display_name: Applied
+    members: [%s, %s]
+    channels:
+      - name: dev
+        header: Development
+        members: [%s]
+`, teamName, s.th.BasicUser.Username, s.th.BasicUser2.Username, s.th.BasicUser.Username)
+
+		err := applyCmdF(c, newCmd(state, false), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+		s.Require().NotEmpty(printer.GetLines())
+
+		team, _, err := s.th.SystemAdminClient.GetTeamByName(context.TODO(), teamName, "")
+		s.Require().NoError(err)
+		s.Require().Equal("Applied", team.DisplayName)
+
+		channel, _, err := s.th.SystemAdminClient.GetChannelByName(context.TODO(), "dev", team.Id, "")
+		s.Require().NoError(err)
+		s.Require().Equal("Development", channel.Header)
+
+		_, _, err = s.th.SystemAdminClient.GetChannelMember(context.TODO(), channel.Id, s.th.BasicUser.Id, "")
+		s.Require().NoError(err)
+
+		printer.Clean()
+		err = applyCmdF(c, newCmd(state, false), []string{})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{"No changes"}, printer.GetLines())
+
+		printer.Clean()
+		pruned := fmt.Sprintf(`
+teams:
+  - name: %s
+    members: [%s]
+`, teamName, s.th.BasicUser.Username)
+		err = applyCmdF(c, newCmd(pruned, true), []string{})
+		s.Require().NoError(err)
+
+		member, _, err := s.th.SystemAdminClient.GetTeamMember(context.TODO(), team.Id, s.th.BasicUser2.Id, "")
+		s.Require().NoError(err)
+		s.Require().NotZero(member.DeleteAt)
+	})
+
+	s.RunForSystemAdminAndLocal("prune a created team twice", func(c client.Client) {
+		printer.Clean()
+
+		state := fmt.Sprintf(`
+teams:
+  - name: apply-%s
+    members: [%s]
+`, model.NewId()[:8], s.th.BasicUser.Username)
+
+		err := applyCmdF(c, newCmd(state, true), []string{})
+		s.Require().NoError(err)
+
+		printer.Clean()
+		err = applyCmdF(c, newCmd(state, true), []string{})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{"No changes"}, printer.GetLines())
+	})
+}
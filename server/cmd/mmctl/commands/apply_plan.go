@@ -0,0 +1,1166 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+)
+
+const (
+	applyActionCreate  = "create"
+	applyActionUpdate  = "update"
+	applyActionRestore = "restore"
+	applyActionAssign  = "assign"
+	applyActionAdd     = "add"
+	applyActionRemove  = "remove"
+	applyActionArchive = "archive"
+	applyActionDelete  = "delete"
+)
+
+// offTopicChannelName is the name of the second channel created with every team.
+const offTopicChannelName = "off-topic"
+
+// applyChange is a step of the plan that brings the server to the desired state.
+type applyChange struct {
+	Action  string   `json:"action"`
+	Kind    string   `json:"kind"`
+	Name    string   `json:"name"`
+	Details []string `json:"details,omitempty"`
+
+	run func(c client.Client) error
+}
+
+// applyDetails describes the fields changed by a step of the plan.
+type applyDetails []string
+
+// compare records the change of a field and reports whether the field changed.
+func (d *applyDetails) compare(field string, current, wanted any) bool {
+	if current == wanted {
+		return false
+	}
+
+	*d = append(*d, fmt.Sprintf("%s: %s -> %s", field, formatApplyValue(current), formatApplyValue(wanted)))
+	return true
+}
+
+func (d *applyDetails) compareList(field string, current, wanted []string) bool {
+	if strings.Join(current, "\n") == strings.Join(wanted, "\n") {
+		return false
+	}
+
+	*d = append(*d, fmt.Sprintf("%s: [%s] -> [%s]", field, strings.Join(current, ", "), strings.Join(wanted, ", ")))
+	return true
+}
+
+func formatApplyValue(value any) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+
+	return fmt.Sprint(value)
+}
+
+// applyPlanner compares a desired-state document with the server. Objects that the plan creates are represented by
+// placeholders, which are filled in when the plan runs so that the later steps can refer to their ids.
+type applyPlanner struct {
+	c       client.Client
+	prune   bool
+	changes []*applyChange
+
+	users         map[string]*model.User
+	schemes       map[string]*model.Scheme
+	schemeNames   map[string]string
+	schemesLoaded bool
+	channelNames  map[string]string
+}
+
+func planState(c client.Client, state *State, prune bool) ([]*applyChange, error) {
+	p := &applyPlanner{
+		c:            c,
+		prune:        prune,
+		users:        map[string]*model.User{},
+		schemes:      map[string]*model.Scheme{},
+		schemeNames:  map[string]string{},
+		channelNames: map[string]string{},
+	}
+
+	for _, role := range state.Roles {
+		if err := p.planRole(role); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(state.Schemes) > 0 {
+		if err := p.loadSchemes(); err != nil {
+			return nil, err
+		}
+	}
+	for _, scheme := range state.Schemes {
+		if err := p.planScheme(scheme); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(state.Bots) > 0 {
+		if err := p.planBots(state.Bots); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, team := range state.Teams {
+		if err := p.planTeam(team); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.changes, nil
+}
+
+func (p *applyPlanner) add(change *applyChange) {
+	p.changes = append(p.changes, change)
+}
+
+// user returns the user with the given username, which may be a bot created by the plan.
+func (p *applyPlanner) user(username string) (*model.User, error) {
+	if user, ok := p.users[username]; ok {
+		return user, nil
+	}
+
+	user, resp, err := p.c.GetUserByUsername(context.TODO(), username, "")
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, ErrEntityNotFound{Type: "user", ID: username}
+		}
+		return nil, errors.Wrapf(err, "failed to get user %q", username)
+	}
+
+	p.users[username] = user
+	return user, nil
+}
+
+func (p *applyPlanner) loadSchemes() error {
+	if p.schemesLoaded {
+		return nil
+	}
+
+	schemes, err := getPages(func(page, numPerPage int, etag string) ([]*model.Scheme, *model.Response, error) {
+		return p.c.GetSchemes(context.TODO(), "", page, numPerPage)
+	}, DefaultPageSize)
+	if err != nil {
+		return errors.Wrap(err, "failed to get schemes")
+	}
+
+	for _, scheme := range schemes {
+		p.schemes[scheme.Name] = scheme
+		p.schemeNames[scheme.Id] = scheme.Name
+	}
+	p.schemesLoaded = true
+
+	return nil
+}
+
+// planSchemeAssignment plans setting the scheme of a team or channel, where an empty name resets it to the default.
+func (p *applyPlanner) planSchemeAssignment(kind, name string, currentID *string, wanted string, assign func(c client.Client, schemeID string) error) error {
+	if err := p.loadSchemes(); err != nil {
+		return err
+	}
+
+	var scheme *model.Scheme
+	if wanted != "" {
+		if scheme = p.schemes[wanted]; scheme == nil {
+			return ErrEntityNotFound{Type: "scheme", ID: wanted}
+		}
+	}
+
+	current := ""
+	if currentID != nil {
+		current = p.schemeNames[*currentID]
+	}
+
+	details := applyDetails{}
+	if !details.compare("scheme", current, wanted) {
+		return nil
+	}
+
+	p.add(&applyChange{Action: applyActionAssign, Kind: kind, Name: name, Details: details, run: func(c client.Client) error {
+		if scheme == nil {
+			return assign(c, "")
+		}
+		return assign(c, scheme.Id)
+	}})
+
+	return nil
+}
+
+func (p *applyPlanner) planRole(state *RoleState) error {
+	if state.Permissions == nil {
+		return nil
+	}
+
+	role, _, err := p.c.GetRoleByName(context.TODO(), state.Name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get role %q", state.Name)
+	}
+
+	p.planPermissions("role", state.Name, role.Permissions, *state.Permissions, func() string { return role.Name })
+
+	return nil
+}
+
+// planPermissions plans setting the permissions of a role. The name of the role is resolved when the plan runs, as
+// the roles of the schemes created by the plan don't exist yet.
+func (p *applyPlanner) planPermissions(kind, name string, current, wanted []string, roleName func() string) {
+	currentSet := make(map[string]bool, len(current))
+	for _, permission := range current {
+		currentSet[permission] = true
+	}
+	wantedSet := make(map[string]bool, len(wanted))
+	for _, permission := range wanted {
+		wantedSet[permission] = true
+	}
+
+	details := applyDetails{}
+	for _, permission := range wanted {
+		if !currentSet[permission] {
+			details = append(details, "+ "+permission)
+		}
+	}
+	for _, permission := range current {
+		if !wantedSet[permission] {
+			details = append(details, "- "+permission)
+		}
+	}
+	if len(details) == 0 {
+		return
+	}
+	sort.SliceStable(details, func(i, j int) bool { return details[i][2:] < details[j][2:] })
+
+	permissions := append([]string{}, wanted...)
+	p.add(&applyChange{Action: applyActionUpdate, Kind: kind, Name: name, Details: details, run: func(c client.Client) error {
+		role, _, err := c.GetRoleByName(context.TODO(), roleName())
+		if err != nil {
+			return err
+		}
+
+		_, _, err = c.PatchRole(context.TODO(), role.Id, &model.RolePatch{Permissions: &permissions})
+		return err
+	}})
+}
+
+func (p *applyPlanner) planScheme(state *SchemeState) error {
+	scheme := p.schemes[state.Name]
+	if scheme == nil {
+		scheme = &model.Scheme{
+			Name:        state.Name,
+			DisplayName: state.DisplayName,
+			Scope:       state.Scope,
+		}
+		if scheme.DisplayName == "" {
+			scheme.DisplayName = state.Name
+		}
+		if state.Description != nil {
+			scheme.Description = *state.Description
+		}
+		p.schemes[state.Name] = scheme
+
+		p.add(&applyChange{Action: applyActionCreate, Kind: "scheme", Name: state.Name, run: func(c client.Client) error {
+			created, _, err := c.CreateScheme(context.TODO(), &model.Scheme{
+				Name:        scheme.Name,
+				DisplayName: scheme.DisplayName,
+				Description: scheme.Description,
+				Scope:       scheme.Scope,
+			})
+			if err != nil {
+				return err
+			}
+			*scheme = *created
+			return nil
+		}})
+
+		for _, key := range applySchemeRoles {
+			if permissions, ok := state.Roles[key]; ok {
+				p.planPermissions("scheme role", state.Name+"/"+key, nil, permissions, func() string { return schemeRoleName(scheme, key) })
+			}
+		}
+
+		return nil
+	}
+
+	if scheme.Scope != state.Scope {
+		return errors.Errorf("scheme %q already exists with the %q scope", state.Name, scheme.Scope)
+	}
+
+	details := applyDetails{}
+	patch := &model.SchemePatch{}
+	if state.DisplayName != "" && details.compare("display_name", scheme.DisplayName, state.DisplayName) {
+		patch.DisplayName = &state.DisplayName
+	}
+	if state.Description != nil && details.compare("description", scheme.Description, *state.Description) {
+		patch.Description = state.Description
+	}
+	if len(details) > 0 {
+		p.add(&applyChange{Action: applyActionUpdate, Kind: "scheme", Name: state.Name, Details: details, run: func(c client.Client) error {
+			_, _, err := c.PatchScheme(context.TODO(), scheme.Id, patch)
+			return err
+		}})
+	}
+
+	for _, key := range applySchemeRoles {
+		permissions, ok := state.Roles[key]
+		if !ok {
+			continue
+		}
+
+		roleName := schemeRoleName(scheme, key)
+		role, _, err := p.c.GetRoleByName(context.TODO(), roleName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get role %q of scheme %q", roleName, state.Name)
+		}
+		p.planPermissions("scheme role", state.Name+"/"+key, role.Permissions, permissions, func() string { return roleName })
+	}
+
+	return nil
+}
+
+func (p *applyPlanner) planBots(states []*BotState) error {
+	bots, err := getPages(func(page, numPerPage int, etag string) ([]*model.Bot, *model.Response, error) {
+		return p.c.GetBotsIncludeDeleted(context.TODO(), page, numPerPage, etag)
+	}, DefaultPageSize)
+	if err != nil {
+		return errors.Wrap(err, "failed to get bots")
+	}
+
+	existing := make(map[string]*model.Bot, len(bots))
+	for _, bot := range bots {
+		existing[bot.Username] = bot
+	}
+
+	for _, state := range states {
+		bot := existing[state.Username]
+		if bot == nil {
+			user := &model.User{Username: state.Username}
+			p.users[state.Username] = user
+
+			newBot := &model.Bot{Username: state.Username, DisplayName: state.DisplayName}
+			if state.Description != nil {
+				newBot.Description = *state.Description
+			}
+			p.add(&applyChange{Action: applyActionCreate, Kind: "bot", Name: state.Username, run: func(c client.Client) error {
+				created, _, err := c.CreateBot(context.TODO(), newBot)
+				if err != nil {
+					return err
+				}
+				user.Id = created.UserId
+				return nil
+			}})
+			continue
+		}
+
+		p.users[state.Username] = &model.User{Id: bot.UserId, Username: bot.Username}
+
+		if bot.DeleteAt != 0 {
+			p.add(&applyChange{Action: applyActionRestore, Kind: "bot", Name: state.Username, run: func(c client.Client) error {
+				_, _, err := c.EnableBot(context.TODO(), bot.UserId)
+				return err
+			}})
+		}
+
+		details := applyDetails{}
+		patch := &model.BotPatch{}
+		if state.DisplayName != "" && details.compare("display_name", bot.DisplayName, state.DisplayName) {
+			patch.DisplayName = &state.DisplayName
+		}
+		if state.Description != nil && details.compare("description", bot.Description, *state.Description) {
+			patch.Description = state.Description
+		}
+		if len(details) > 0 {
+			p.add(&applyChange{Action: applyActionUpdate, Kind: "bot", Name: state.Username, Details: details, run: func(c client.Client) error {
+				_, _, err := c.PatchBot(context.TODO(), bot.UserId, patch)
+				return err
+			}})
+		}
+	}
+
+	return nil
+}
+
+func applyTeamType(teamType string) string {
+	if teamType == applyTeamTypeInvite {
+		return model.TeamInvite
+	}
+
+	return model.TeamOpen
+}
+
+func applyChannelType(channelType string) model.ChannelType {
+	if channelType == applyChannelTypePrivate {
+		return model.ChannelTypePrivate
+	}
+
+	return model.ChannelTypeOpen
+}
+
+func (p *applyPlanner) planTeam(state *TeamState) error {
+	team, resp, err := p.c.GetTeamByName(context.TODO(), state.Name, "")
+	if err != nil {
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return errors.Wrapf(err, "failed to get team %q", state.Name)
+		}
+		team = nil
+	}
+
+	// wantedMembers is set when pruning the members of a team created by the plan
+	var wantedMembers map[string]bool
+	isNew := team == nil
+	if isNew {
+		team = &model.Team{
+			Name:        state.Name,
+			DisplayName: state.DisplayName,
+			Type:        applyTeamType(state.Type),
+		}
+		if team.DisplayName == "" {
+			team.DisplayName = state.Name
+		}
+		if state.Description != nil {
+			team.Description = *state.Description
+		}
+
+		newTeam := *team
+		p.add(&applyChange{Action: applyActionCreate, Kind: "team", Name: state.Name, run: func(c client.Client) error {
+			created, _, err := c.CreateTeam(context.TODO(), &newTeam)
+			if err != nil {
+				return err
+			}
+			*team = *created
+			if wantedMembers != nil {
+				return pruneCreatedTeamMembers(c, team, wantedMembers)
+			}
+			return nil
+		}})
+	} else {
+		if team.DeleteAt != 0 {
+			p.add(&applyChange{Action: applyActionRestore, Kind: "team", Name: state.Name, run: func(c client.Client) error {
+				_, _, err := c.RestoreTeam(context.TODO(), team.Id)
+				return err
+			}})
+		}
+
+		details := applyDetails{}
+		patch := &model.TeamPatch{}
+		if state.DisplayName != "" && details.compare("display_name", team.DisplayName, state.DisplayName) {
+			patch.DisplayName = &state.DisplayName
+		}
+		if state.Description != nil && details.compare("description", team.Description, *state.Description) {
+			patch.Description = state.Description
+		}
+		if len(details) > 0 {
+			p.add(&applyChange{Action: applyActionUpdate, Kind: "team", Name: state.Name, Details: details, run: func(c client.Client) error {
+				_, _, err := c.PatchTeam(context.TODO(), team.Id, patch)
+				return err
+			}})
+		}
+
+		typeDetails := applyDetails{}
+		if wanted := applyTeamType(state.Type); state.Type != "" && typeDetails.compare("type", team.Type, wanted) {
+			p.add(&applyChange{Action: applyActionUpdate, Kind: "team", Name: state.Name, Details: typeDetails, run: func(c client.Client) error {
+				_, _, err := c.UpdateTeamPrivacy(context.TODO(), team.Id, wanted)
+				return err
+			}})
+		}
+	}
+
+	if state.Scheme != nil {
+		err := p.planSchemeAssignment("team", state.Name, team.SchemeId, *state.Scheme, func(c client.Client, schemeID string) error {
+			_, err := c.UpdateTeamScheme(context.TODO(), team.Id, schemeID)
+			return err
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to plan the scheme of team %q", state.Name)
+		}
+	}
+
+	if state.Members != nil {
+		wanted, err := p.planTeamMembers(team, isNew, state.Members)
+		if err != nil {
+			return err
+		}
+		if isNew && p.prune {
+			wantedMembers = wanted
+		}
+	}
+
+	channels := map[string]*model.Channel{}
+	for _, channelState := range state.Channels {
+		channel, err := p.planChannel(team, isNew, channelState)
+		if err != nil {
+			return err
+		}
+		channels[channelState.Name] = channel
+	}
+	if state.Channels != nil && p.prune && !isNew {
+		if err := p.pruneChannels(team, channels); err != nil {
+			return err
+		}
+	}
+
+	if state.IncomingWebhooks != nil {
+		if err := p.planIncomingWebhooks(team, isNew, channels, state.IncomingWebhooks); err != nil {
+			return err
+		}
+	}
+	if state.OutgoingWebhooks != nil {
+		if err := p.planOutgoingWebhooks(team, isNew, channels, state.OutgoingWebhooks); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// planTeamMembers plans the changes to the members of a team, and returns the ids of the users listed in the
+// document.
+func (p *applyPlanner) planTeamMembers(team *model.Team, teamIsNew bool, states []*MemberState) (map[string]bool, error) {
+	current := map[string]*model.TeamMember{}
+	if !teamIsNew {
+		members, err := getPages(func(page, numPerPage int, etag string) ([]*model.TeamMember, *model.Response, error) {
+			return p.c.GetTeamMembers(context.TODO(), team.Id, page, numPerPage, etag)
+		}, DefaultPageSize)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the members of team %q", team.Name)
+		}
+		for _, member := range members {
+			if member.DeleteAt == 0 {
+				current[member.UserId] = member
+			}
+		}
+	}
+
+	wanted := map[string]bool{}
+	for _, state := range states {
+		user, err := p.user(state.Username)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to plan the members of team %q", team.Name)
+		}
+		wanted[user.Id] = true
+
+		name := team.Name + "/" + state.Username
+		admin := state.Admin
+		member := current[user.Id]
+		if member == nil {
+			var details []string
+			if admin {
+				details = []string{"admin: true"}
+			}
+			p.add(&applyChange{Action: applyActionAdd, Kind: "team member", Name: name, Details: details, run: func(c client.Client) error {
+				// The user may already be a member, as the server adds the user creating a team as an admin
+				added, _, err := c.AddTeamMember(context.TODO(), team.Id, user.Id)
+				if err != nil || added.SchemeAdmin == admin {
+					return err
+				}
+				_, err = c.UpdateTeamMemberSchemeRoles(context.TODO(), team.Id, user.Id, &model.SchemeRoles{SchemeAdmin: admin, SchemeUser: added.SchemeUser, SchemeGuest: added.SchemeGuest})
+				return err
+			}})
+			continue
+		}
+
+		details := applyDetails{}
+		if details.compare("admin", member.SchemeAdmin, admin) {
+			p.add(&applyChange{Action: applyActionUpdate, Kind: "team member", Name: name, Details: details, run: func(c client.Client) error {
+				_, err := c.UpdateTeamMemberSchemeRoles(context.TODO(), team.Id, user.Id, &model.SchemeRoles{SchemeAdmin: admin, SchemeUser: member.SchemeUser, SchemeGuest: member.SchemeGuest})
+				return err
+			}})
+		}
+	}
+
+	if !p.prune {
+		return wanted, nil
+	}
+
+	err := pruneMembers(p, current, wanted, func(username string) string { return team.Name + "/" + username }, "team member", func(c client.Client, userID string) error {
+		_, err := c.RemoveTeamMember(context.TODO(), team.Id, userID)
+		return err
+	})
+	return wanted, err
+}
+
+// pruneCreatedTeamMembers removes the members that the server added to a team created by the plan, such as the user
+// creating it, which aren't listed in the document.
+func pruneCreatedTeamMembers(c client.Client, team *model.Team, wanted map[string]bool) error {
+	members, err := getPages(func(page, numPerPage int, etag string) ([]*model.TeamMember, *model.Response, error) {
+		return c.GetTeamMembers(context.TODO(), team.Id, page, numPerPage, etag)
+	}, DefaultPageSize)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the members of the created team")
+	}
+
+	for _, member := range members {
+		if member.DeleteAt != 0 || wanted[member.UserId] {
+			continue
+		}
+		if _, err := c.RemoveTeamMember(context.TODO(), team.Id, member.UserId); err != nil {
+			return errors.Wrapf(err, "failed to remove user %q from the created team", member.UserId)
+		}
+	}
+
+	return nil
+}
+
+// pruneMembers plans removing the members that aren't listed in the document.
+func pruneMembers[T any](p *applyPlanner, current map[string]T, wanted map[string]bool, name func(username string) string, kind string, remove func(c client.Client, userID string) error) error {
+	userIDs := []string{}
+	for userID := range current {
+		if !wanted[userID] {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	users, _, err := p.c.GetUsersByIds(context.TODO(), userIDs)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the members to remove")
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+
+	for _, user := range users {
+		userID := user.Id
+		p.add(&applyChange{Action: applyActionRemove, Kind: kind, Name: name(user.Username), run: func(c client.Client) error {
+			return remove(c, userID)
+		}})
+	}
+
+	return nil
+}
+
+// ensureChannel fetches a channel that wasn't created by the plan itself, such as the default channels of a team
+// created by the plan.
+func ensureChannel(c client.Client, team *model.Team, channel *model.Channel) error {
+	if channel.Id != "" {
+		return nil
+	}
+
+	fetched, _, err := c.GetChannelByName(context.TODO(), channel.Name, team.Id, "")
+	if err != nil {
+		return err
+	}
+	*channel = *fetched
+
+	return nil
+}
+
+func (p *applyPlanner) planChannel(team *model.Team, teamIsNew bool, state *ChannelState) (*model.Channel, error) {
+	name := team.Name + "/" + state.Name
+
+	var channel *model.Channel
+	switch {
+	case teamIsNew && (state.Name == model.DefaultChannelName || state.Name == offTopicChannelName):
+		// The default channels are created along with the team
+		channel = &model.Channel{Name: state.Name, DisplayName: "Town Square", Type: model.ChannelTypeOpen}
+		if state.Name == offTopicChannelName {
+			channel.DisplayName = "Off-Topic"
+		}
+	case !teamIsNew:
+		var resp *model.Response
+		var err error
+		channel, resp, err = p.c.GetChannelByNameIncludeDeleted(context.TODO(), state.Name, team.Id, "")
+		if err != nil {
+			if resp == nil || resp.StatusCode != http.StatusNotFound {
+				return nil, errors.Wrapf(err, "failed to get channel %q", name)
+			}
+			channel = nil
+		}
+	}
+
+	isNew := channel == nil
+	if isNew {
+		channel = &model.Channel{
+			Name:        state.Name,
+			DisplayName: state.DisplayName,
+			Type:        applyChannelType(state.Type),
+		}
+		if channel.DisplayName == "" {
+			channel.DisplayName = state.Name
+		}
+		if state.Header != nil {
+			channel.Header = *state.Header
+		}
+		if state.Purpose != nil {
+			channel.Purpose = *state.Purpose
+		}
+
+		newChannel := *channel
+		p.add(&applyChange{Action: applyActionCreate, Kind: "channel", Name: name, run: func(c client.Client) error {
+			newChannel.TeamId = team.Id
+			created, _, err := c.CreateChannel(context.TODO(), &newChannel)
+			if err != nil {
+				return err
+			}
+			*channel = *created
+			return nil
+		}})
+	} else {
+		if channel.DeleteAt != 0 {
+			p.add(&applyChange{Action: applyActionRestore, Kind: "channel", Name: name, run: func(c client.Client) error {
+				_, _, err := c.RestoreChannel(context.TODO(), channel.Id)
+				return err
+			}})
+		}
+
+		details := applyDetails{}
+		patch := &model.ChannelPatch{}
+		if state.DisplayName != "" && details.compare("display_name", channel.DisplayName, state.DisplayName) {
+			patch.DisplayName = &state.DisplayName
+		}
+		if state.Header != nil && details.compare("header", channel.Header, *state.Header) {
+			patch.Header = state.Header
+		}
+		if state.Purpose != nil && details.compare("purpose", channel.Purpose, *state.Purpose) {
+			patch.Purpose = state.Purpose
+		}
+		if len(details) > 0 {
+			p.add(&applyChange{Action: applyActionUpdate, Kind: "channel", Name: name, Details: details, run: func(c client.Client) error {
+				if err := ensureChannel(c, team, channel); err != nil {
+					return err
+				}
+				_, _, err := c.PatchChannel(context.TODO(), channel.Id, patch)
+				return err
+			}})
+		}
+
+		typeDetails := applyDetails{}
+		if wanted := applyChannelType(state.Type); state.Type != "" && typeDetails.compare("type", string(channel.Type), string(wanted)) {
+			p.add(&applyChange{Action: applyActionUpdate, Kind: "channel", Name: name, Details: typeDetails, run: func(c client.Client) error {
+				if err := ensureChannel(c, team, channel); err != nil {
+					return err
+				}
+				_, _, err := c.UpdateChannelPrivacy(context.TODO(), channel.Id, wanted)
+				return err
+			}})
+		}
+	}
+
+	if state.Scheme != nil {
+		err := p.planSchemeAssignment("channel", name, channel.SchemeId, *state.Scheme, func(c client.Client, schemeID string) error {
+			if err := ensureChannel(c, team, channel); err != nil {
+				return err
+			}
+			_, err := c.UpdateChannelScheme(context.TODO(), channel.Id, schemeID)
+			return err
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to plan the scheme of channel %q", name)
+		}
+	}
+
+	if state.Members != nil {
+		if err := p.planChannelMembers(team, channel, name, state.Members); err != nil {
+			return nil, err
+		}
+	}
+
+	return channel, nil
+}
+
+func (p *applyPlanner) planChannelMembers(team *model.Team, channel *model.Channel, channelName string, states []*MemberState) error {
+	current := map[string]model.ChannelMember{}
+	if channel.Id != "" {
+		members, err := getPages(func(page, numPerPage int, etag string) ([]model.ChannelMember, *model.Response, error) {
+			return p.c.GetChannelMembers(context.TODO(), channel.Id, page, numPerPage, etag)
+		}, DefaultPageSize)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the members of channel %q", channelName)
+		}
+		for _, member := range members {
+			current[member.UserId] = member
+		}
+	}
+
+	wanted := map[string]bool{}
+	for _, state := range states {
+		user, err := p.user(state.Username)
+		if err != nil {
+			return errors.Wrapf(err, "failed to plan the members of channel %q", channelName)
+		}
+		wanted[user.Id] = true
+
+		name := channelName + "/" + state.Username
+		admin := state.Admin
+		member, ok := current[user.Id]
+		if !ok {
+			var details []string
+			if admin {
+				details = []string{"admin: true"}
+			}
+			p.add(&applyChange{Action: applyActionAdd, Kind: "channel member", Name: name, Details: details, run: func(c client.Client) error {
+				if err := ensureChannel(c, team, channel); err != nil {
+					return err
+				}
+				added, _, err := c.AddChannelMember(context.TODO(), channel.Id, user.Id)
+				if err != nil || !admin {
+					return err
+				}
+				_, err = c.UpdateChannelMemberSchemeRoles(context.TODO(), channel.Id, user.Id, &model.SchemeRoles{SchemeAdmin: true, SchemeUser: added.SchemeUser, SchemeGuest: added.SchemeGuest})
+				return err
+			}})
+			continue
+		}
+
+		details := applyDetails{}
+		if details.compare("admin", member.SchemeAdmin, admin) {
+			p.add(&applyChange{Action: applyActionUpdate, Kind: "channel member", Name: name, Details: details, run: func(c client.Client) error {
+				_, err := c.UpdateChannelMemberSchemeRoles(context.TODO(), channel.Id, user.Id, &model.SchemeRoles{SchemeAdmin: admin, SchemeUser: member.SchemeUser, SchemeGuest: member.SchemeGuest})
+				return err
+			}})
+		}
+	}
+
+	if !p.prune {
+		return nil
+	}
+
+	return pruneMembers(p, current, wanted, func(username string) string { return channelName + "/" + username }, "channel member", func(c client.Client, userID string) error {
+		_, err := c.RemoveUserFromChannel(context.TODO(), channel.Id, userID)
+		return err
+	})
+}
+
+// pruneChannels plans archiving the channels of a team that aren't listed in the document, except its default channel.
+func (p *applyPlanner) pruneChannels(team *model.Team, listed map[string]*model.Channel) error {
+	public, err := getPages(func(page, numPerPage int, etag string) ([]*model.Channel, *model.Response, error) {
+		return p.c.GetPublicChannelsForTeam(context.TODO(), team.Id, page, numPerPage, etag)
+	}, DefaultPageSize)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the public channels of team %q", team.Name)
+	}
+	private, err := getPages(func(page, numPerPage int, etag string) ([]*model.Channel, *model.Response, error) {
+		return p.c.GetPrivateChannelsForTeam(context.TODO(), team.Id, page, numPerPage, etag)
+	}, DefaultPageSize)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the private channels of team %q", team.Name)
+	}
+
+	channels := append(public, private...)
+	sort.Slice(channels, func(i, j int) bool { return channels[i].Name < channels[j].Name })
+	for _, channel := range channels {
+		if _, ok := listed[channel.Name]; ok || channel.Name == model.DefaultChannelName || channel.DeleteAt != 0 {
+			continue
+		}
+
+		channelID := channel.Id
+		p.add(&applyChange{Action: applyActionArchive, Kind: "channel", Name: team.Name + "/" + channel.Name, run: func(c client.Client) error {
+			_, err := c.DeleteChannel(context.TODO(), channelID)
+			return err
+		}})
+	}
+
+	return nil
+}
+
+// webhookChannel returns the channel of a webhook, which is either listed in the document or already exists.
+func (p *applyPlanner) webhookChannel(team *model.Team, teamIsNew bool, channels map[string]*model.Channel, name string) (*model.Channel, error) {
+	if channel, ok := channels[name]; ok {
+		return channel, nil
+	}
+
+	if !teamIsNew {
+		channel, _, err := p.c.GetChannelByName(context.TODO(), name, team.Id, "")
+		if err == nil {
+			return channel, nil
+		}
+	}
+
+	return nil, ErrEntityNotFound{Type: "channel", ID: team.Name + "/" + name}
+}
+
+// channelName returns the name of an existing channel, to describe the webhooks that are moved to another channel.
+func (p *applyPlanner) channelName(channelID string) string {
+	if channelID == "" {
+		return ""
+	}
+	if name, ok := p.channelNames[channelID]; ok {
+		return name
+	}
+
+	name := channelID
+	if channel, _, err := p.c.GetChannel(context.TODO(), channelID, ""); err == nil {
+		name = channel.Name
+	}
+	p.channelNames[channelID] = name
+
+	return name
+}
+
+func (p *applyPlanner) planIncomingWebhooks(team *model.Team, teamIsNew bool, channels map[string]*model.Channel, states []*IncomingWebhookState) error {
+	existing := map[string]*model.IncomingWebhook{}
+	var hooks []*model.IncomingWebhook
+	if !teamIsNew {
+		var err error
+		hooks, err = getPages(func(page, numPerPage int, etag string) ([]*model.IncomingWebhook, *model.Response, error) {
+			return p.c.GetIncomingWebhooksForTeam(context.TODO(), team.Id, page, numPerPage, etag)
+		}, DefaultPageSize)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the incoming webhooks of team %q", team.Name)
+		}
+		for _, hook := range hooks {
+			if _, ok := existing[hook.DisplayName]; !ok {
+				existing[hook.DisplayName] = hook
+			}
+		}
+	}
+
+	for _, state := range states {
+		name := team.Name + "/" + state.DisplayName
+		channel, err := p.webhookChannel(team, teamIsNew, channels, state.Channel)
+		if err != nil {
+			return errors.Wrapf(err, "failed to plan incoming webhook %q", name)
+		}
+		var user *model.User
+		if state.User != "" {
+			if user, err = p.user(state.User); err != nil {
+				return errors.Wrapf(err, "failed to plan incoming webhook %q", name)
+			}
+		}
+
+		hook := existing[state.DisplayName]
+		if hook == nil {
+			p.add(&applyChange{Action: applyActionCreate, Kind: "incoming webhook", Name: name, run: func(c client.Client) error {
+				if err := ensureChannel(c, team, channel); err != nil {
+					return err
+				}
+				newHook := &model.IncomingWebhook{
+					ChannelId:     channel.Id,
+					DisplayName:   state.DisplayName,
+					Description:   state.Description,
+					Username:      state.Username,
+					IconURL:       state.IconURL,
+					ChannelLocked: state.ChannelLocked,
+					PayloadFormat: state.PayloadFormat,
+				}
+				if user != nil {
+					newHook.UserId = user.Id
+				}
+				if state.Secret != nil {
+					newHook.Secret = *state.Secret
+				}
+				_, _, err := c.CreateIncomingWebhook(context.TODO(), newHook)
+				return err
+			}})
+			continue
+		}
+
+		details := applyDetails{}
+		channelChanged := channel.Id != hook.ChannelId
+		if channelChanged {
+			details.compare("channel", p.channelName(hook.ChannelId), state.Channel)
+		}
+		details.compare("description", hook.Description, state.Description)
+		details.compare("username", hook.Username, state.Username)
+		details.compare("icon_url", hook.IconURL, state.IconURL)
+		details.compare("channel_locked", hook.ChannelLocked, state.ChannelLocked)
+		details.compare("payload_format", hook.PayloadFormat, state.PayloadFormat)
+		if state.Secret != nil && hook.Secret != *state.Secret {
+			// The secrets themselves aren't shown
+			details = append(details, "secret: changed")
+		}
+		if len(details) == 0 {
+			continue
+		}
+
+		p.add(&applyChange{Action: applyActionUpdate, Kind: "incoming webhook", Name: name, Details: details, run: func(c client.Client) error {
+			if err := ensureChannel(c, team, channel); err != nil {
+				return err
+			}
+			updated := *hook
+			updated.ChannelId = channel.Id
+			updated.Description = state.Description
+			updated.Username = state.Username
+			updated.IconURL = state.IconURL
+			updated.ChannelLocked = state.ChannelLocked
+			updated.PayloadFormat = state.PayloadFormat
+			if state.Secret != nil {
+				updated.Secret = *state.Secret
+			}
+			_, _, err := c.UpdateIncomingWebhook(context.TODO(), &updated)
+			return err
+		}})
+	}
+
+	if !p.prune {
+		return nil
+	}
+
+	listed := make(map[string]bool, len(states))
+	for _, state := range states {
+		listed[state.DisplayName] = true
+	}
+	for _, hook := range hooks {
+		if existing[hook.DisplayName] == hook && listed[hook.DisplayName] {
+			continue
+		}
+
+		hookID := hook.Id
+		p.add(&applyChange{Action: applyActionDelete, Kind: "incoming webhook", Name: team.Name + "/" + hook.DisplayName, run: func(c client.Client) error {
+			_, err := c.DeleteIncomingWebhook(context.TODO(), hookID)
+			return err
+		}})
+	}
+
+	return nil
+}
+
+func applyTriggerWhen(triggerWhen string) int {
+	if triggerWhen == applyTriggerWhenStart {
+		return 1
+	}
+
+	return 0
+}
+
+func (p *applyPlanner) planOutgoingWebhooks(team *model.Team, teamIsNew bool, channels map[string]*model.Channel, states []*OutgoingWebhookState) error {
+	existing := map[string]*model.OutgoingWebhook{}
+	var hooks []*model.OutgoingWebhook
+	if !teamIsNew {
+		var err error
+		hooks, err = getPages(func(page, numPerPage int, etag string) ([]*model.OutgoingWebhook, *model.Response, error) {
+			return p.c.GetOutgoingWebhooksForTeam(context.TODO(), team.Id, page, numPerPage, etag)
+		}, DefaultPageSize)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the outgoing webhooks of team %q", team.Name)
+		}
+		for _, hook := range hooks {
+			if _, ok := existing[hook.DisplayName]; !ok {
+				existing[hook.DisplayName] = hook
+			}
+		}
+	}
+
+	for _, state := range states {
+		name := team.Name + "/" + state.DisplayName
+		var channel *model.Channel
+		var err error
+		if state.Channel != "" {
+			if channel, err = p.webhookChannel(team, teamIsNew, channels, state.Channel); err != nil {
+				return errors.Wrapf(err, "failed to plan outgoing webhook %q", name)
+			}
+		}
+		var user *model.User
+		if state.User != "" {
+			if user, err = p.user(state.User); err != nil {
+				return errors.Wrapf(err, "failed to plan outgoing webhook %q", name)
+			}
+		}
+		contentType := state.ContentType
+		if contentType == "" {
+			contentType = "application/x-www-form-urlencoded"
+		}
+
+		// channelID is resolved when the plan runs, as the channel may be created by the plan
+		channelID := func(c client.Client) (string, error) {
+			if channel == nil {
+				return "", nil
+			}
+			if err := ensureChannel(c, team, channel); err != nil {
+				return "", err
+			}
+			return channel.Id, nil
+		}
+
+		hook := existing[state.DisplayName]
+		if hook == nil {
+			p.add(&applyChange{Action: applyActionCreate, Kind: "outgoing webhook", Name: name, run: func(c client.Client) error {
+				id, err := channelID(c)
+				if err != nil {
+					return err
+				}
+				newHook := &model.OutgoingWebhook{
+					TeamId:       team.Id,
+					ChannelId:    id,
+					DisplayName:  state.DisplayName,
+					Description:  state.Description,
+					TriggerWords: state.TriggerWords,
+					TriggerWhen:  applyTriggerWhen(state.TriggerWhen),
+					CallbackURLs: state.CallbackURLs,
+					ContentType:  contentType,
+					Username:     state.Username,
+					IconURL:      state.IconURL,
+				}
+				if user != nil {
+					newHook.CreatorId = user.Id
+				}
+				_, _, err = c.CreateOutgoingWebhook(context.TODO(), newHook)
+				return err
+			}})
+			continue
+		}
+
+		details := applyDetails{}
+		wantedChannelID := ""
+		if channel != nil {
+			wantedChannelID = channel.Id
+		}
+		if wantedChannelID != hook.ChannelId {
+			details.compare("channel", p.channelName(hook.ChannelId), state.Channel)
+		}
+		details.compare("description", hook.Description, state.Description)
+		details.compareList("trigger_words", hook.TriggerWords, state.TriggerWords)
+		details.compare("trigger_when", hook.TriggerWhen, applyTriggerWhen(state.TriggerWhen))
+		details.compareList("callback_urls", hook.CallbackURLs, state.CallbackURLs)
+		details.compare("content_type", hook.ContentType, contentType)
+		details.compare("username", hook.Username, state.Username)
+		details.compare("icon_url", hook.IconURL, state.IconURL)
+		if len(details) == 0 {
+			continue
+		}
+
+		p.add(&applyChange{Action: applyActionUpdate, Kind: "outgoing webhook", Name: name, Details: details, run: func(c client.Client) error {
+			id, err := channelID(c)
+			if err != nil {
+				return err
+			}
+			updated := *hook
+			updated.ChannelId = id
+			updated.Description = state.Description
+			updated.TriggerWords = state.TriggerWords
+			updated.TriggerWhen = applyTriggerWhen(state.TriggerWhen)
+			updated.CallbackURLs = state.CallbackURLs
+			updated.ContentType = contentType
+			updated.Username = state.Username
+			updated.IconURL = state.IconURL
+			_, _, err = c.UpdateOutgoingWebhook(context.TODO(), &updated)
+			return err
+		}})
+	}
+
+	if !p.prune {
+		return nil
+	}
+
+	listed := make(map[string]bool, len(states))
+	for _, state := range states {
+		listed[state.DisplayName] = true
+	}
+	for _, hook := range hooks {
+		if existing[hook.DisplayName] == hook && listed[hook.DisplayName] {
+			continue
+		}
+
+		hookID := hook.Id
+		p.add(&applyChange{Action: applyActionDelete, Kind: "outgoing webhook", Name: team.Name + "/" + hook.DisplayName, run: func(c client.Client) error {
+			_, err := c.DeleteOutgoingWebhook(context.TODO(), hookID)
+			return err
+		}})
+	}
+
+	return nil
+}
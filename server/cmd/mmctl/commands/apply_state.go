@@ -0,0 +1,331 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	applyTeamTypeOpen       = "open"
+	applyTeamTypeInvite     = "invite"
+	applyChannelTypePublic  = "public"
+	applyChannelTypePrivate = "private"
+
+	applyTriggerWhenExact = "exact"
+	applyTriggerWhenStart = "start"
+)
+
+// applySchemeRoles are the keys of the roles of a scheme in a desired-state document.
+var applySchemeRoles = []string{"team_admin", "team_user", "team_guest", "channel_admin", "channel_user", "channel_guest"}
+
+// State is the desired state of a server, as described by the document read by the apply and diff commands. Only the
+// objects listed in the document are managed, and only the fields that are set.
+type State struct {
+	Roles   []*RoleState   `yaml:"roles"`
+	Schemes []*SchemeState `yaml:"schemes"`
+	Bots    []*BotState    `yaml:"bots"`
+	Teams   []*TeamState   `yaml:"teams"`
+}
+
+// RoleState sets the permissions of an existing role, such as system_user or a role of a scheme.
+type RoleState struct {
+	Name string `yaml:"name"`
+
+	// Permissions are only managed when the list is present in the document, even if it's empty.
+	Permissions *[]string `yaml:"permissions"`
+}
+
+type SchemeState struct {
+	Name        string  `yaml:"name"`
+	DisplayName string  `yaml:"display_name"`
+	Description *string `yaml:"description"`
+	Scope       string  `yaml:"scope"`
+
+	// Roles sets the permissions of the roles of the scheme, keyed by team_admin, team_user, team_guest,
+	// channel_admin, channel_user or channel_guest.
+	Roles map[string][]string `yaml:"roles"`
+}
+
+type BotState struct {
+	Username    string  `yaml:"username"`
+	DisplayName string  `yaml:"display_name"`
+	Description *string `yaml:"description"`
+}
+
+type TeamState struct {
+	Name        string  `yaml:"name"`
+	DisplayName string  `yaml:"display_name"`
+	Description *string `yaml:"description"`
+	Type        string  `yaml:"type"`
+
+	// Scheme is the name of the team scheme of the team, or empty for the default permissions.
+	Scheme *string `yaml:"scheme"`
+
+	// Members are only managed when the list is present in the document, even if it's empty.
+	Members          []*MemberState          `yaml:"members"`
+	Channels         []*ChannelState         `yaml:"channels"`
+	IncomingWebhooks []*IncomingWebhookState `yaml:"incoming_webhooks"`
+	OutgoingWebhooks []*OutgoingWebhookState `yaml:"outgoing_webhooks"`
+}
+
+type ChannelState struct {
+	Name        string  `yaml:"name"`
+	DisplayName string  `yaml:"display_name"`
+	Type        string  `yaml:"type"`
+	Header      *string `yaml:"header"`
+	Purpose     *string `yaml:"purpose"`
+
+	// Scheme is the name of the channel scheme of the channel, or empty for the permissions of the team.
+	Scheme *string `yaml:"scheme"`
+
+	Members []*MemberState `yaml:"members"`
+}
+
+// MemberState is a member of a team or channel. It can be written as a plain username in the document.
+type MemberState struct {
+	Username string `yaml:"username"`
+	Admin    bool   `yaml:"admin"`
+}
+
+func (m *MemberState) UnmarshalYAML(unmarshal func(any) error) error {
+	var username string
+	if err := unmarshal(&username); err == nil {
+		m.Username = username
+		return nil
+	}
+
+	type plain MemberState
+	return unmarshal((*plain)(m))
+}
+
+// IncomingWebhookState is an incoming webhook of a team, identified by its display name.
+type IncomingWebhookState struct {
+	DisplayName   string  `yaml:"display_name"`
+	Description   string  `yaml:"description"`
+	Channel       string  `yaml:"channel"`
+	User          string  `yaml:"user"`
+	Username      string  `yaml:"username"`
+	IconURL       string  `yaml:"icon_url"`
+	ChannelLocked bool    `yaml:"channel_locked"`
+	PayloadFormat string  `yaml:"payload_format"`
+	Secret        *string `yaml:"secret"`
+}
+
+// OutgoingWebhookState is an outgoing webhook of a team, identified by its display name.
+type OutgoingWebhookState struct {
+	DisplayName  string   `yaml:"display_name"`
+	Description  string   `yaml:"description"`
+	Channel      string   `yaml:"channel"`
+	User         string   `yaml:"user"`
+	TriggerWords []string `yaml:"trigger_words"`
+	TriggerWhen  string   `yaml:"trigger_when"`
+	CallbackURLs []string `yaml:"callback_urls"`
+	ContentType  string   `yaml:"content_type"`
+	Username     string   `yaml:"username"`
+	IconURL      string   `yaml:"icon_url"`
+}
+
+// readState reads a desired-state document in YAML or JSON from a file, or from the standard input if path is "-".
+func readState(path string) (*State, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the state document")
+	}
+
+	return parseState(data)
+}
+
+func parseState(data []byte) (*State, error) {
+	var state State
+	if err := yaml.UnmarshalStrict(data, &state); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the state document")
+	}
+
+	if err := state.validate(); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+// validate checks the document before anything is read from the server, so that mistakes such as duplicated names
+// are reported all at once.
+func (s *State) validate() error {
+	var result *multierror.Error
+	fail := func(format string, args ...any) {
+		result = multierror.Append(result, fmt.Errorf(format, args...))
+	}
+
+	roles := map[string]bool{}
+	for _, role := range s.Roles {
+		if role.Name == "" {
+			fail("role without a name")
+		} else if roles[role.Name] {
+			fail("role %q is listed more than once", role.Name)
+		}
+		roles[role.Name] = true
+	}
+
+	schemes := map[string]string{}
+	for _, scheme := range s.Schemes {
+		switch {
+		case !model.IsValidSchemeName(scheme.Name):
+			fail("scheme %q has an invalid name", scheme.Name)
+		case schemes[scheme.Name] != "":
+			fail("scheme %q is listed more than once", scheme.Name)
+		}
+		if scheme.Scope != model.SchemeScopeTeam && scheme.Scope != model.SchemeScopeChannel {
+			fail("scheme %q must have the %q or %q scope", scheme.Name, model.SchemeScopeTeam, model.SchemeScopeChannel)
+		}
+		for key := range scheme.Roles {
+			if !isValidSchemeRole(key, scheme.Scope) {
+				fail("scheme %q has no %q role", scheme.Name, key)
+			}
+		}
+		schemes[scheme.Name] = scheme.Scope
+	}
+
+	bots := map[string]bool{}
+	for _, bot := range s.Bots {
+		if !model.IsValidUsername(bot.Username) {
+			fail("bot %q has an invalid username", bot.Username)
+		} else if bots[bot.Username] {
+			fail("bot %q is listed more than once", bot.Username)
+		}
+		bots[bot.Username] = true
+	}
+
+	teams := map[string]bool{}
+	for _, team := range s.Teams {
+		if team.Name == "" {
+			fail("team without a name")
+			continue
+		}
+		if teams[team.Name] {
+			fail("team %q is listed more than once", team.Name)
+		}
+		teams[team.Name] = true
+
+		if team.Type != "" && team.Type != applyTeamTypeOpen && team.Type != applyTeamTypeInvite {
+			fail("team %q must have the %q or %q type", team.Name, applyTeamTypeOpen, applyTeamTypeInvite)
+		}
+		if team.Scheme != nil && *team.Scheme != "" && schemes[*team.Scheme] == model.SchemeScopeChannel {
+			fail("team %q can't use the channel scheme %q", team.Name, *team.Scheme)
+		}
+		validateMembers(fail, "team "+team.Name, team.Members)
+
+		channels := map[string]bool{}
+		for _, channel := range team.Channels {
+			name := team.Name + "/" + channel.Name
+			if channel.Name == "" {
+				fail("channel without a name in team %q", team.Name)
+				continue
+			}
+			if channels[channel.Name] {
+				fail("channel %q is listed more than once", name)
+			}
+			channels[channel.Name] = true
+
+			if channel.Type != "" && channel.Type != applyChannelTypePublic && channel.Type != applyChannelTypePrivate {
+				fail("channel %q must have the %q or %q type", name, applyChannelTypePublic, applyChannelTypePrivate)
+			}
+			if channel.Scheme != nil && *channel.Scheme != "" && schemes[*channel.Scheme] == model.SchemeScopeTeam {
+				fail("channel %q can't use the team scheme %q", name, *channel.Scheme)
+			}
+			validateMembers(fail, "channel "+name, channel.Members)
+		}
+
+		incoming := map[string]bool{}
+		for _, hook := range team.IncomingWebhooks {
+			if hook.DisplayName == "" {
+				fail("incoming webhook without a display name in team %q", team.Name)
+			} else if incoming[hook.DisplayName] {
+				fail("incoming webhook %q is listed more than once in team %q", hook.DisplayName, team.Name)
+			}
+			incoming[hook.DisplayName] = true
+
+			if hook.Channel == "" {
+				fail("incoming webhook %q of team %q has no channel", hook.DisplayName, team.Name)
+			}
+		}
+
+		outgoing := map[string]bool{}
+		for _, hook := range team.OutgoingWebhooks {
+			if hook.DisplayName == "" {
+				fail("outgoing webhook without a display name in team %q", team.Name)
+			} else if outgoing[hook.DisplayName] {
+				fail("outgoing webhook %q is listed more than once in team %q", hook.DisplayName, team.Name)
+			}
+			outgoing[hook.DisplayName] = true
+
+			if hook.TriggerWhen != "" && hook.TriggerWhen != applyTriggerWhenExact && hook.TriggerWhen != applyTriggerWhenStart {
+				fail("outgoing webhook %q of team %q must trigger when %q or %q", hook.DisplayName, team.Name, applyTriggerWhenExact, applyTriggerWhenStart)
+			}
+			if len(hook.CallbackURLs) == 0 {
+				fail("outgoing webhook %q of team %q has no callback URL", hook.DisplayName, team.Name)
+			}
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func validateMembers(fail func(format string, args ...any), owner string, members []*MemberState) {
+	usernames := map[string]bool{}
+	for _, member := range members {
+		if member.Username == "" {
+			fail("member without a username in %s", owner)
+		} else if usernames[member.Username] {
+			fail("member %q is listed more than once in %s", member.Username, owner)
+		}
+		usernames[member.Username] = true
+	}
+}
+
+func isValidSchemeRole(key, scope string) bool {
+	for _, role := range applySchemeRoles {
+		if role == key {
+			// Channel schemes don't have team roles
+			return scope == model.SchemeScopeTeam || !strings.HasPrefix(key, "team_")
+		}
+	}
+
+	return false
+}
+
+// schemeRoleName returns the name of the role of a scheme for its key in the document.
+func schemeRoleName(scheme *model.Scheme, key string) string {
+	switch key {
+	case "team_admin":
+		return scheme.DefaultTeamAdminRole
+	case "team_user":
+		return scheme.DefaultTeamUserRole
+	case "team_guest":
+		return scheme.DefaultTeamGuestRole
+	case "channel_admin":
+		return scheme.DefaultChannelAdminRole
+	case "channel_user":
+		return scheme.DefaultChannelUserRole
+	case "channel_guest":
+		return scheme.DefaultChannelGuestRole
+	}
+
+	return ""
+}
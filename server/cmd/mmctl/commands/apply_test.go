@@ -0,0 +1,371 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestParseState() {
+	s.Run("members can be written as usernames", func() {
+		state, err := parseState([]byte(`
+teams:
+  - name: eng
+    members:
+      - alice
+      - username: bob
+        admin: true
+    channels:
+      - name: dev
+        members: []
+      - name: ops
+`))
+		s.Require().NoError(err)
+		s.Require().Len(state.Teams, 1)
+
+		team := state.Teams[0]
+		s.Require().Equal([]*MemberState{{Username: "alice"}, {Username: "bob", Admin: true}}, team.Members)
+		s.Require().NotNil(team.Channels[0].Members, "an empty list manages the members")
+		s.Require().Nil(team.Channels[1].Members, "a missing list doesn't manage the members")
+		s.Require().Nil(team.IncomingWebhooks)
+	})
+
+	s.Run("JSON documents", func() {
+		state, err := parseState([]byte(`{"teams": [{"name": "eng", "type": "invite"}]}`))
+		s.Require().NoError(err)
+		s.Require().Equal("invite", state.Teams[0].Type)
+	})
+
+	s.Run("unknown fields", func() {
+		_, err := parseState([]byte(`
+teams:
+  - name: eng
+    colour: blue
+`))
+		s.Require().Error(err)
+	})
+
+	s.Run("invalid documents report every error", func() {
+		_, err := parseState([]byte(`
+schemes:
+  - name: restricted
+    scope: channel
+    roles:
+      team_admin: [manage_team]
+teams:
+  - name: eng
+    type: secret
+    scheme: restricted
+    members: [alice, alice]
+    channels:
+      - name: dev
+      - name: dev
+    outgoing_webhooks:
+      - display_name: Build
+        trigger_when: sometimes
+`))
+		s.Require().Error(err)
+		s.Require().Contains(err.Error(), `scheme "restricted" has no "team_admin" role`)
+		s.Require().Contains(err.Error(), `team "eng" must have the "open" or "invite" type`)
+		s.Require().Contains(err.Error(), `team "eng" can't use the channel scheme "restricted"`)
+		s.Require().Contains(err.Error(), `member "alice" is listed more than once in team eng`)
+		s.Require().Contains(err.Error(), `channel "eng/dev" is listed more than once`)
+		s.Require().Contains(err.Error(), `outgoing webhook "Build" of team "eng" must trigger when "exact" or "start"`)
+		s.Require().Contains(err.Error(), `outgoing webhook "Build" of team "eng" has no callback URL`)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestApplyCmd() {
+	team := &model.Team{Id: model.NewId(), Name: "eng", DisplayName: "Eng", Type: model.TeamOpen}
+	alice := &model.User{Id: model.NewId(), Username: "alice"}
+	carol := &model.User{Id: model.NewId(), Username: "carol"}
+
+	writeState := func(content string) string {
+		path := filepath.Join(s.T().TempDir(), "state.yaml")
+		s.Require().NoError(os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+	newCmd := func(path string, dryRun, prune bool) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("file", path, "")
+		cmd.Flags().Bool("dry-run", dryRun, "")
+		cmd.Flags().Bool("prune", prune, "")
+		return cmd
+	}
+
+	path := writeState(`
+teams:
+  - name: eng
+    display_name: Engineering
+    members: [alice]
+    channels:
+      - name: dev
+        header: Development
+`)
+
+	expectPlan := func(members []*model.TeamMember) {
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), "eng", "").
+			Return(team, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetTeamMembers(context.TODO(), team.Id, 0, DefaultPageSize, "").
+			Return(members, &model.Response{}, nil).
+			Times(1)
+		if len(members) > 0 {
+			s.client.
+				EXPECT().
+				GetTeamMembers(context.TODO(), team.Id, 1, DefaultPageSize, "").
+				Return([]*model.TeamMember{}, &model.Response{}, nil).
+				Times(1)
+		}
+		s.client.
+			EXPECT().
+			GetUserByUsername(context.TODO(), "alice", "").
+			Return(alice, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetChannelByNameIncludeDeleted(context.TODO(), "dev", team.Id, "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+	}
+
+	s.Run("dry run", func() {
+		printer.Clean()
+		expectPlan(nil)
+
+		err := applyCmdF(s.client, newCmd(path, true, false), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+		s.Require().Len(printer.GetLines(), 3)
+		s.Require().Equal(&applyChange{Action: applyActionUpdate, Kind: "team", Name: "eng", Details: []string{`display_name: "Eng" -> "Engineering"`}}, withoutRun(printer.GetLines()[0]))
+		s.Require().Equal(&applyChange{Action: applyActionAdd, Kind: "team member", Name: "eng/alice"}, withoutRun(printer.GetLines()[1]))
+		s.Require().Equal(&applyChange{Action: applyActionCreate, Kind: "channel", Name: "eng/dev"}, withoutRun(printer.GetLines()[2]))
+	})
+
+	s.Run("apply", func() {
+		printer.Clean()
+		expectPlan(nil)
+
+		displayName := "Engineering"
+		s.client.
+			EXPECT().
+			PatchTeam(context.TODO(), team.Id, &model.TeamPatch{DisplayName: &displayName}).
+			Return(team, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			AddTeamMember(context.TODO(), team.Id, alice.Id).
+			Return(&model.TeamMember{TeamId: team.Id, UserId: alice.Id, SchemeUser: true}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateChannel(context.TODO(), &model.Channel{TeamId: team.Id, Name: "dev", DisplayName: "dev", Type: model.ChannelTypeOpen, Header: "Development"}).
+			Return(&model.Channel{Id: model.NewId(), TeamId: team.Id, Name: "dev"}, &model.Response{}, nil).
+			Times(1)
+
+		err := applyCmdF(s.client, newCmd(path, false, false), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+		s.Require().Len(printer.GetLines(), 3)
+	})
+
+	s.Run("stop at the first failure", func() {
+		printer.Clean()
+		expectPlan(nil)
+
+		s.client.
+			EXPECT().
+			PatchTeam(context.TODO(), team.Id, gomock.Any()).
+			Return(nil, &model.Response{StatusCode: http.StatusForbidden}, errors.New("forbidden")).
+			Times(1)
+
+		err := applyCmdF(s.client, newCmd(path, false, false), []string{})
+		s.Require().EqualError(err, `failed to update team "eng": forbidden`)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+
+	s.Run("prune", func() {
+		printer.Clean()
+		expectPlan([]*model.TeamMember{
+			{TeamId: team.Id, UserId: alice.Id, SchemeUser: true},
+			{TeamId: team.Id, UserId: carol.Id, SchemeUser: true},
+		})
+
+		s.client.
+			EXPECT().
+			GetUsersByIds(context.TODO(), []string{carol.Id}).
+			Return([]*model.User{carol}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetPublicChannelsForTeam(context.TODO(), team.Id, 0, DefaultPageSize, "").
+			Return([]*model.Channel{{Id: model.NewId(), Name: model.DefaultChannelName}, {Id: model.NewId(), Name: "old"}}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetPublicChannelsForTeam(context.TODO(), team.Id, 1, DefaultPageSize, "").
+			Return([]*model.Channel{}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetPrivateChannelsForTeam(context.TODO(), team.Id, 0, DefaultPageSize, "").
+			Return([]*model.Channel{}, &model.Response{}, nil).
+			Times(1)
+
+		err := diffCmdF(s.client, newCmd(path, false, true), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 4)
+		s.Require().Equal(&applyChange{Action: applyActionRemove, Kind: "team member", Name: "eng/carol"}, withoutRun(printer.GetLines()[1]))
+		s.Require().Equal(&applyChange{Action: applyActionArchive, Kind: "channel", Name: "eng/old"}, withoutRun(printer.GetLines()[3]))
+	})
+
+	s.Run("prune the members added to a created team", func() {
+		printer.Clean()
+
+		creator := &model.User{Id: model.NewId(), Username: "admin"}
+		created := &model.Team{Id: model.NewId(), Name: "new", DisplayName: "new", Type: model.TeamOpen}
+
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), "new", "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetUserByUsername(context.TODO(), "alice", "").
+			Return(alice, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateTeam(context.TODO(), &model.Team{Name: "new", DisplayName: "new", Type: model.TeamOpen}).
+			Return(created, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetTeamMembers(context.TODO(), created.Id, 0, DefaultPageSize, "").
+			Return([]*model.TeamMember{{TeamId: created.Id, UserId: creator.Id, SchemeUser: true, SchemeAdmin: true}}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetTeamMembers(context.TODO(), created.Id, 1, DefaultPageSize, "").
+			Return([]*model.TeamMember{}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			RemoveTeamMember(context.TODO(), created.Id, creator.Id).
+			Return(&model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			AddTeamMember(context.TODO(), created.Id, alice.Id).
+			Return(&model.TeamMember{TeamId: created.Id, UserId: alice.Id, SchemeUser: true}, &model.Response{}, nil).
+			Times(1)
+
+		err := applyCmdF(s.client, newCmd(writeState("teams: [{name: new, members: [alice]}]"), false, true), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+		s.Require().Len(printer.GetLines(), 2)
+	})
+
+	s.Run("demote a creator listed as a member", func() {
+		printer.Clean()
+
+		created := &model.Team{Id: model.NewId(), Name: "new", DisplayName: "new", Type: model.TeamOpen}
+
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), "new", "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetUserByUsername(context.TODO(), "alice", "").
+			Return(alice, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateTeam(context.TODO(), gomock.Any()).
+			Return(created, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			AddTeamMember(context.TODO(), created.Id, alice.Id).
+			Return(&model.TeamMember{TeamId: created.Id, UserId: alice.Id, SchemeUser: true, SchemeAdmin: true}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UpdateTeamMemberSchemeRoles(context.TODO(), created.Id, alice.Id, &model.SchemeRoles{SchemeUser: true}).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := applyCmdF(s.client, newCmd(writeState("teams: [{name: new, members: [alice]}]"), false, false), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("no changes", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), "eng", "").
+			Return(team, &model.Response{}, nil).
+			Times(1)
+
+		err := applyCmdF(s.client, newCmd(writeState("teams: [{name: eng}]"), false, true), []string{})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{"No changes"}, printer.GetLines())
+	})
+
+	s.Run("roles without permissions are left as they are", func() {
+		printer.Clean()
+
+		err := applyCmdF(s.client, newCmd(writeState("roles: [{name: system_user}]"), false, false), []string{})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{"No changes"}, printer.GetLines())
+	})
+
+	s.Run("roles with an empty list of permissions lose them", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetRoleByName(context.TODO(), model.SystemUserRoleId).
+			Return(&model.Role{Name: model.SystemUserRoleId, Permissions: []string{"create_team"}}, &model.Response{}, nil).
+			Times(1)
+
+		err := applyCmdF(s.client, newCmd(writeState("roles: [{name: system_user, permissions: []}]"), true, false), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(&applyChange{Action: applyActionUpdate, Kind: "role", Name: model.SystemUserRoleId, Details: []string{"- create_team"}}, withoutRun(printer.GetLines()[0]))
+	})
+
+	s.Run("invalid document", func() {
+		printer.Clean()
+
+		err := applyCmdF(s.client, newCmd(writeState("teams: [{display_name: Engineering}]"), false, false), []string{})
+		s.Require().ErrorContains(err, "team without a name")
+	})
+}
+
+// withoutRun returns a printed change without its function, so that it can be compared.
+func withoutRun(line any) *applyChange {
+	change := *line.(*applyChange)
+	change.run = nil
+	return &change
+}
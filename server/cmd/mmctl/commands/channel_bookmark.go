@@ -0,0 +1,248 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+const channelBookmarkTemplate = `{{.Id}}: {{if .Emoji}}{{.Emoji}} {{end}}{{.DisplayName}} ({{if eq .Type "file"}}{{if .FileInfo}}{{.FileInfo.Name}}{{else}}{{.FileId}}{{end}}{{else}}{{.LinkUrl}}{{end}})`
+
+var ChannelBookmarkCmd = &cobra.Command{
+	Use:   "bookmark",
+	Short: "Management of channel bookmarks",
+}
+
+var ChannelBookmarkListCmd = &cobra.Command{
+	Use:     "list [channel]",
+	Short:   "List the bookmarks of a channel",
+	Long:    "List the bookmarks of a channel in the order they are shown in the channel header.",
+	Example: "  channel bookmark list myteam:mychannel",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(channelBookmarkListCmdF),
+}
+
+var ChannelBookmarkAddCmd = &cobra.Command{
+	Use:   "add [channel]",
+	Short: "Add a bookmark to a channel",
+	Long:  "Add a link bookmark, or a file bookmark that uploads a local file, to a channel.",
+	Example: `  channel bookmark add myteam:mychannel --display-name "Handbook" --link https://example.com/handbook
+  channel bookmark add myteam:mychannel --display-name "Roadmap" --file ./roadmap.pdf --emoji ":map:"`,
+	Args: cobra.ExactArgs(1),
+	RunE: withClient(channelBookmarkAddCmdF),
+}
+
+var ChannelBookmarkUpdateCmd = &cobra.Command{
+	Use:   "update [channel] [bookmark]",
+	Short: "Update a bookmark of a channel",
+	Long:  "Update the display name, link, image or emoji of a bookmark. Only the fields of the flags that are set are updated.",
+	Example: `  channel bookmark update myteam:mychannel 7jgcjt7tyjyyu83qz81wo84w6o --display-name "Handbook"
+  channel bookmark update myteam:mychannel 7jgcjt7tyjyyu83qz81wo84w6o --emoji ""`,
+	Args: cobra.ExactArgs(2),
+	RunE: withClient(channelBookmarkUpdateCmdF),
+}
+
+var ChannelBookmarkDeleteCmd = &cobra.Command{
+	Use:     "delete [channel] [bookmarks]",
+	Aliases: []string{"rm"},
+	Short:   "Delete bookmarks of a channel",
+	Example: "  channel bookmark delete myteam:mychannel 7jgcjt7tyjyyu83qz81wo84w6o udjmt396tjghi8wnsk3a1qs1sw",
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    withClient(channelBookmarkDeleteCmdF),
+}
+
+var ChannelBookmarkReorderCmd = &cobra.Command{
+	Use:     "reorder [channel] [bookmarks]",
+	Short:   "Reorder the bookmarks of a channel",
+	Long:    "Move the bookmarks to the start of the channel header, in the order they are given. The bookmarks that aren't given keep their relative order after them.",
+	Example: "  channel bookmark reorder myteam:mychannel udjmt396tjghi8wnsk3a1qs1sw 7jgcjt7tyjyyu83qz81wo84w6o",
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    withClient(channelBookmarkReorderCmdF),
+}
+
+func init() {
+	ChannelBookmarkAddCmd.Flags().String("display-name", "", "Display name of the bookmark")
+	ChannelBookmarkAddCmd.Flags().String("link", "", "URL of a link bookmark")
+	ChannelBookmarkAddCmd.Flags().String("file", "", "Path of the local file of a file bookmark")
+	ChannelBookmarkAddCmd.Flags().String("image", "", "URL of the image of a link bookmark")
+	ChannelBookmarkAddCmd.Flags().String("emoji", "", "Emoji of the bookmark, such as :smile:")
+	_ = ChannelBookmarkAddCmd.MarkFlagRequired("display-name")
+	ChannelBookmarkAddCmd.MarkFlagsMutuallyExclusive("link", "file")
+	ChannelBookmarkAddCmd.MarkFlagsOneRequired("link", "file")
+
+	ChannelBookmarkUpdateCmd.Flags().String("display-name", "", "Display name of the bookmark")
+	ChannelBookmarkUpdateCmd.Flags().String("link", "", "URL of a link bookmark")
+	ChannelBookmarkUpdateCmd.Flags().String("image", "", "URL of the image of a link bookmark")
+	ChannelBookmarkUpdateCmd.Flags().String("emoji", "", "Emoji of the bookmark, such as :smile:")
+
+	ChannelBookmarkCmd.AddCommand(
+		ChannelBookmarkListCmd,
+		ChannelBookmarkAddCmd,
+		ChannelBookmarkUpdateCmd,
+		ChannelBookmarkDeleteCmd,
+		ChannelBookmarkReorderCmd,
+	)
+
+	ChannelCmd.AddCommand(ChannelBookmarkCmd)
+}
+
+func channelBookmarkListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	bookmarks, _, err := c.ListChannelBookmarksForChannel(context.TODO(), channel.Id, 0)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the bookmarks of channel %q", args[0])
+	}
+
+	printBookmarks(bookmarks)
+	return nil
+}
+
+func channelBookmarkAddCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	displayName, _ := cmd.Flags().GetString("display-name")
+	link, _ := cmd.Flags().GetString("link")
+	path, _ := cmd.Flags().GetString("file")
+	image, _ := cmd.Flags().GetString("image")
+	emoji, _ := cmd.Flags().GetString("emoji")
+
+	bookmark := &model.ChannelBookmark{
+		ChannelId:   channel.Id,
+		DisplayName: displayName,
+		LinkUrl:     link,
+		ImageUrl:    image,
+		Emoji:       emoji,
+		Type:        model.ChannelBookmarkLink,
+	}
+
+	if path != "" {
+		if image != "" {
+			return errors.New("only link bookmarks can have an image")
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read file %q", path)
+		}
+
+		uploadResponse, _, err := c.UploadBookmarkFile(context.TODO(), data, channel.Id, filepath.Base(path))
+		if err != nil {
+			return errors.Wrapf(err, "failed to upload file %q", path)
+		}
+		if len(uploadResponse.FileInfos) != 1 {
+			return errors.Errorf("failed to upload file %q", path)
+		}
+
+		bookmark.FileId = uploadResponse.FileInfos[0].Id
+		bookmark.Type = model.ChannelBookmarkFile
+	}
+
+	created, _, err := c.CreateChannelBookmark(context.TODO(), bookmark)
+	if err != nil {
+		return errors.Wrapf(err, "failed to add the bookmark to channel %q", args[0])
+	}
+
+	printer.PrintT(channelBookmarkTemplate, created)
+	return nil
+}
+
+func channelBookmarkUpdateCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	patch := &model.ChannelBookmarkPatch{}
+	for flag, field := range map[string]**string{
+		"display-name": &patch.DisplayName,
+		"link":         &patch.LinkUrl,
+		"image":        &patch.ImageUrl,
+		"emoji":        &patch.Emoji,
+	} {
+		if cmd.Flags().Changed(flag) {
+			value, _ := cmd.Flags().GetString(flag)
+			*field = &value
+		}
+	}
+
+	if *patch == (model.ChannelBookmarkPatch{}) {
+		return errors.New("at least one field must be updated")
+	}
+
+	response, _, err := c.UpdateChannelBookmark(context.TODO(), channel.Id, args[1], patch)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update bookmark %q", args[1])
+	}
+
+	// Bookmarks owned by other users are replaced by an updated copy with a new ID
+	printer.PrintT(channelBookmarkTemplate, response.Updated)
+	return nil
+}
+
+func channelBookmarkDeleteCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	var result *multierror.Error
+	for _, bookmarkID := range args[1:] {
+		if _, _, err := c.DeleteChannelBookmark(context.TODO(), channel.Id, bookmarkID); err != nil {
+			printer.PrintError(fmt.Sprintf("Unable to delete bookmark %q. Error: %s", bookmarkID, err))
+			result = multierror.Append(result, err)
+			continue
+		}
+		printer.Print(fmt.Sprintf("Bookmark %s successfully deleted", bookmarkID))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func channelBookmarkReorderCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	var bookmarks []*model.ChannelBookmarkWithFileInfo
+	for i, bookmarkID := range args[1:] {
+		var err error
+		bookmarks, _, err = c.UpdateChannelBookmarkSortOrder(context.TODO(), channel.Id, bookmarkID, int64(i))
+		if err != nil {
+			return errors.Wrapf(err, "failed to move bookmark %q", bookmarkID)
+		}
+	}
+
+	printBookmarks(bookmarks)
+	return nil
+}
+
+func printBookmarks(bookmarks []*model.ChannelBookmarkWithFileInfo) {
+	sort.SliceStable(bookmarks, func(i, j int) bool {
+		return bookmarks[i].SortOrder < bookmarks[j].SortOrder
+	})
+
+	for _, bookmark := range bookmarks {
+		printer.PrintT(channelBookmarkTemplate, bookmark)
+	}
+}
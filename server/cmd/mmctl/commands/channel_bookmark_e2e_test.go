@@ -0,0 +1,129 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlE2ETestSuite) TestChannelBookmarkCmds() {
+	s.SetupTestHelper().InitBasic()
+	s.th.App.Srv().SetLicense(model.NewTestLicense())
+
+	channelArg := s.th.BasicTeam.Name + ":" + s.th.BasicChannel.Name
+
+	s.Run("add a link bookmark", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "Handbook", "")
+		cmd.Flags().String("link", "https://example.com/handbook", "")
+		cmd.Flags().String("emoji", ":book:", "")
+
+		err := channelBookmarkAddCmdF(s.th.Client, cmd, []string{channelArg})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Len(printer.GetErrorLines(), 0)
+
+		bookmark := printer.GetLines()[0].(*model.ChannelBookmarkWithFileInfo)
+		s.Require().Equal(s.th.BasicChannel.Id, bookmark.ChannelId)
+		s.Require().Equal(model.ChannelBookmarkLink, bookmark.Type)
+		s.Require().Equal("https://example.com/handbook", bookmark.LinkUrl)
+		s.Require().Equal(":book:", bookmark.Emoji)
+	})
+
+	s.Run("add a file bookmark", func() {
+		printer.Clean()
+
+		path := filepath.Join(s.T().TempDir(), "roadmap.txt")
+		s.Require().NoError(os.WriteFile(path, []byte("roadmap"), 0600))
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "Roadmap", "")
+		cmd.Flags().String("file", path, "")
+
+		err := channelBookmarkAddCmdF(s.th.Client, cmd, []string{channelArg})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+
+		bookmark := printer.GetLines()[0].(*model.ChannelBookmarkWithFileInfo)
+		s.Require().Equal(model.ChannelBookmarkFile, bookmark.Type)
+		s.Require().NotNil(bookmark.FileInfo)
+		s.Require().Equal("roadmap.txt", bookmark.FileInfo.Name)
+	})
+
+	s.Run("list, update, reorder and delete bookmarks", func() {
+		channel, _, err := s.th.Client.CreateChannel(context.Background(), &model.Channel{
+			TeamId:      s.th.BasicTeam.Id,
+			Name:        "bookmarks-" + model.NewId()[:8],
+			DisplayName: "Bookmarks",
+			Type:        model.ChannelTypeOpen,
+		})
+		s.Require().NoError(err)
+		channelArg := s.th.BasicTeam.Name + ":" + channel.Name
+
+		first, _, err := s.th.Client.CreateChannelBookmark(context.Background(), &model.ChannelBookmark{ChannelId: channel.Id, DisplayName: "First", LinkUrl: "https://example.com/1", Type: model.ChannelBookmarkLink})
+		s.Require().NoError(err)
+		second, _, err := s.th.Client.CreateChannelBookmark(context.Background(), &model.ChannelBookmark{ChannelId: channel.Id, DisplayName: "Second", LinkUrl: "https://example.com/2", Type: model.ChannelBookmarkLink})
+		s.Require().NoError(err)
+
+		printer.Clean()
+		err = channelBookmarkListCmdF(s.th.Client, &cobra.Command{}, []string{channelArg})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(first.Id, printer.GetLines()[0].(*model.ChannelBookmarkWithFileInfo).Id)
+		s.Require().Equal(second.Id, printer.GetLines()[1].(*model.ChannelBookmarkWithFileInfo).Id)
+
+		printer.Clean()
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "", "")
+		s.Require().NoError(cmd.Flags().Set("display-name", "Renamed"))
+		err = channelBookmarkUpdateCmdF(s.th.Client, cmd, []string{channelArg, first.Id})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		updated := printer.GetLines()[0].(*model.ChannelBookmarkWithFileInfo)
+		s.Require().Equal(first.Id, updated.Id)
+		s.Require().Equal("Renamed", updated.DisplayName)
+		s.Require().Equal("https://example.com/1", updated.LinkUrl)
+
+		printer.Clean()
+		err = channelBookmarkReorderCmdF(s.th.Client, &cobra.Command{}, []string{channelArg, second.Id, first.Id})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(second.Id, printer.GetLines()[0].(*model.ChannelBookmarkWithFileInfo).Id)
+		s.Require().Equal(first.Id, printer.GetLines()[1].(*model.ChannelBookmarkWithFileInfo).Id)
+
+		printer.Clean()
+		err = channelBookmarkDeleteCmdF(s.th.Client, &cobra.Command{}, []string{channelArg, first.Id, second.Id})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+
+		bookmarks, _, err := s.th.Client.ListChannelBookmarksForChannel(context.Background(), channel.Id, 0)
+		s.Require().NoError(err)
+		s.Require().Empty(bookmarks)
+	})
+
+	s.Run("delete a bookmark that doesn't exist", func() {
+		printer.Clean()
+		bookmark, _, err := s.th.Client.CreateChannelBookmark(context.Background(), &model.ChannelBookmark{
+			ChannelId:   s.th.BasicChannel.Id,
+			DisplayName: "Existing",
+			LinkUrl:     "https://example.com/existing",
+			Type:        model.ChannelBookmarkLink,
+		})
+		s.Require().NoError(err)
+
+		err = channelBookmarkDeleteCmdF(s.th.Client, &cobra.Command{}, []string{channelArg, model.NewId(), bookmark.Id})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetErrorLines(), 1)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestChannelBookmarkListCmd() {
+	channel := &model.Channel{Id: model.NewId(), Name: "town-square"}
+
+	s.Run("bookmarks are printed in their sort order", func() {
+		printer.Clean()
+
+		first := &model.ChannelBookmarkWithFileInfo{ChannelBookmark: &model.ChannelBookmark{Id: model.NewId(), DisplayName: "First", SortOrder: 0}}
+		second := &model.ChannelBookmarkWithFileInfo{ChannelBookmark: &model.ChannelBookmark{Id: model.NewId(), DisplayName: "Second", SortOrder: 1}}
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			ListChannelBookmarksForChannel(context.TODO(), channel.Id, int64(0)).
+			Return([]*model.ChannelBookmarkWithFileInfo{second, first}, &model.Response{}, nil).
+			Times(1)
+
+		err := channelBookmarkListCmdF(s.client, &cobra.Command{}, []string{channel.Id})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{first, second}, printer.GetLines())
+	})
+
+	s.Run("channel not found", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+
+		err := channelBookmarkListCmdF(s.client, &cobra.Command{}, []string{channel.Id})
+		s.Require().EqualError(err, `unable to find channel "`+channel.Id+`"`)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestChannelBookmarkAddCmd() {
+	channel := &model.Channel{Id: model.NewId(), Name: "town-square"}
+
+	s.Run("add a file bookmark", func() {
+		printer.Clean()
+
+		path := filepath.Join(s.T().TempDir(), "roadmap.txt")
+		s.Require().NoError(os.WriteFile(path, []byte("roadmap"), 0600))
+		fileInfo := &model.FileInfo{Id: model.NewId(), Name: "roadmap.txt"}
+		created := &model.ChannelBookmarkWithFileInfo{ChannelBookmark: &model.ChannelBookmark{Id: model.NewId()}, FileInfo: fileInfo}
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UploadBookmarkFile(context.TODO(), []byte("roadmap"), channel.Id, "roadmap.txt").
+			Return(&model.FileUploadResponse{FileInfos: []*model.FileInfo{fileInfo}}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateChannelBookmark(context.TODO(), &model.ChannelBookmark{
+				ChannelId:   channel.Id,
+				DisplayName: "Roadmap",
+				FileId:      fileInfo.Id,
+				Type:        model.ChannelBookmarkFile,
+			}).
+			Return(created, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "Roadmap", "")
+		cmd.Flags().String("file", path, "")
+
+		err := channelBookmarkAddCmdF(s.client, cmd, []string{channel.Id})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{created}, printer.GetLines())
+	})
+
+	s.Run("file bookmarks can't have an image", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "Roadmap", "")
+		cmd.Flags().String("file", "roadmap.txt", "")
+		cmd.Flags().String("image", "https://example.com/image.png", "")
+
+		err := channelBookmarkAddCmdF(s.client, cmd, []string{channel.Id})
+		s.Require().EqualError(err, "only link bookmarks can have an image")
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestChannelBookmarkUpdateCmd() {
+	channel := &model.Channel{Id: model.NewId(), Name: "town-square"}
+	bookmarkID := model.NewId()
+
+	s.Run("only the flags that are set are updated", func() {
+		printer.Clean()
+
+		updated := &model.ChannelBookmarkWithFileInfo{ChannelBookmark: &model.ChannelBookmark{Id: bookmarkID, Emoji: ""}}
+		emoji := ""
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UpdateChannelBookmark(context.TODO(), channel.Id, bookmarkID, &model.ChannelBookmarkPatch{Emoji: &emoji}).
+			Return(&model.UpdateChannelBookmarkResponse{Updated: updated}, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "", "")
+		cmd.Flags().String("emoji", ":smile:", "")
+		s.Require().NoError(cmd.Flags().Set("emoji", ""))
+
+		err := channelBookmarkUpdateCmdF(s.client, cmd, []string{channel.Id, bookmarkID})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{updated}, printer.GetLines())
+	})
+
+	s.Run("nothing to update", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "", "")
+
+		err := channelBookmarkUpdateCmdF(s.client, cmd, []string{channel.Id, bookmarkID})
+		s.Require().EqualError(err, "at least one field must be updated")
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestChannelBookmarkReorderCmd() {
+	channel := &model.Channel{Id: model.NewId(), Name: "town-square"}
+	first := &model.ChannelBookmarkWithFileInfo{ChannelBookmark: &model.ChannelBookmark{Id: model.NewId(), SortOrder: 1}}
+	second := &model.ChannelBookmarkWithFileInfo{ChannelBookmark: &model.ChannelBookmark{Id: model.NewId(), SortOrder: 0}}
+
+	s.Run("bookmarks are moved in the given order", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		firstMove := s.client.
+			EXPECT().
+			UpdateChannelBookmarkSortOrder(context.TODO(), channel.Id, second.Id, int64(0)).
+			Return([]*model.ChannelBookmarkWithFileInfo{second}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UpdateChannelBookmarkSortOrder(context.TODO(), channel.Id, first.Id, int64(1)).
+			Return([]*model.ChannelBookmarkWithFileInfo{first, second}, &model.Response{}, nil).
+			Times(1).
+			After(firstMove)
+
+		err := channelBookmarkReorderCmdF(s.client, &cobra.Command{}, []string{channel.Id, second.Id, first.Id})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{second, first}, printer.GetLines())
+	})
+
+	s.Run("stop at the first failure", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UpdateChannelBookmarkSortOrder(context.TODO(), channel.Id, second.Id, int64(0)).
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+
+		err := channelBookmarkReorderCmdF(s.client, &cobra.Command{}, []string{channel.Id, second.Id, first.Id})
+		s.Require().EqualError(err, `failed to move bookmark "`+second.Id+`": not found`)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+const dataRetentionDryRunTemplate = `{{define "counts"}}{{.PostCount}} posts, {{.FileCount}} files, {{.ReactionCount}} reactions{{if .PostCount}}, created from {{retentionDate .OldestPostAt}} to {{retentionDate .NewestPostAt}}{{end}}{{end}}` +
+	`{{range .Policies}}Policy {{retentionPolicy .PolicyID}}: {{template "counts" .}}
+{{end}}` +
+	`{{range .Teams}}Policy {{retentionPolicy .PolicyID}}, team {{retentionTeam .TeamID}}: {{template "counts" .}}
+{{end}}` +
+	`{{if showChannels}}{{range .Channels}}Policy {{retentionPolicy .PolicyID}}, channel {{.ChannelID}}: {{template "counts" .}}
+{{end}}{{end}}` +
+	`Total: {{template "counts" .Total}}`
+
+var DataRetentionCmd = &cobra.Command{
+	Use:   "data-retention",
+	Short: "Management of data retention policies",
+}
+
+var DataRetentionDryRunCmd = &cobra.Command{
+	Use:   "dry-run [policyID]",
+	Short: "Preview what data retention would delete",
+	Long: `Show how many posts, files and reactions the data retention job would delete if it ran now, per policy and team, without deleting anything.
+If [policyID] is specified, only that granular policy is considered; otherwise all of the granular policies and the global policy are.`,
+	Example: `  data-retention dry-run
+  data-retention dry-run 3k5e8ftr7tb8pjcoe6ss8ao8ca --channels`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: withClient(dataRetentionDryRunCmdF),
+}
+
+func init() {
+	DataRetentionDryRunCmd.Flags().Bool("channels", false, "Also show the counts for each channel")
+
+	DataRetentionCmd.AddCommand(
+		DataRetentionDryRunCmd,
+	)
+
+	RootCmd.AddCommand(DataRetentionCmd)
+}
+
+func dataRetentionDryRunCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	printer.SetSingle(true)
+
+	var dryRun *model.RetentionPolicyDryRun
+	var err error
+	if len(args) == 1 {
+		dryRun, _, err = c.GetDataRetentionPolicyDryRun(context.TODO(), args[0])
+		if err != nil {
+			return errors.Wrapf(err, "unable to compute the dry run of policy '%s'", args[0])
+		}
+	} else {
+		dryRun, _, err = c.GetDataRetentionDryRun(context.TODO())
+		if err != nil {
+			return errors.Wrap(err, "unable to compute the data retention dry run")
+		}
+	}
+
+	showChannels, _ := cmd.Flags().GetBool("channels")
+	printer.SetTemplateFunc("showChannels", func() bool { return showChannels })
+	printer.SetTemplateFunc("retentionPolicy", func(policyID string) string {
+		if policyID == "" {
+			return "global"
+		}
+		return policyID
+	})
+	printer.SetTemplateFunc("retentionTeam", func(teamID string) string {
+		if teamID == "" {
+			return "(direct messages)"
+		}
+		return teamID
+	})
+	printer.SetTemplateFunc("retentionDate", func(millis int64) string {
+		return time.UnixMilli(millis).UTC().Format(time.RFC3339)
+	})
+
+	printer.PrintT(dataRetentionDryRunTemplate, dryRun)
+
+	return nil
+}
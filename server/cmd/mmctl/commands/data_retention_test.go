@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/spf13/cobra"
+)
+
+func (s *MmctlUnitTestSuite) TestDataRetentionDryRunCmd() {
+	policyID := model.NewId()
+	teamID := model.NewId()
+	channelID := model.NewId()
+
+	dryRun := model.NewRetentionPolicyDryRun([]*model.RetentionPolicyDryRunChannel{
+		{
+			PolicyID:  policyID,
+			TeamID:    teamID,
+			ChannelID: channelID,
+			RetentionPolicyDryRunCounts: model.RetentionPolicyDryRunCounts{
+				PostCount:     2,
+				FileCount:     1,
+				ReactionCount: 3,
+				OldestPostAt:  1000,
+				NewestPostAt:  2000,
+			},
+		},
+		{
+			ChannelID: model.NewId(),
+		},
+	})
+
+	s.Run("All policies", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetDataRetentionDryRun(context.TODO()).
+			Return(dryRun, &model.Response{}, nil).
+			Times(1)
+
+		err := dataRetentionDryRunCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(dryRun, printer.GetLines()[0])
+	})
+
+	s.Run("Single policy in plain format", func() {
+		printer.Clean()
+		printer.SetFormat(printer.FormatPlain)
+		defer printer.SetFormat(printer.FormatJSON)
+
+		s.client.
+			EXPECT().
+			GetDataRetentionPolicyDryRun(context.TODO(), policyID).
+			Return(dryRun, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("channels", true, "")
+
+		err := dataRetentionDryRunCmdF(s.client, cmd, []string{policyID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+
+		counts := "2 posts, 1 files, 3 reactions, created from 1970-01-01T00:00:01Z to 1970-01-01T00:00:02Z"
+		s.Require().Equal("Policy "+policyID+": "+counts+"\n"+
+			"Policy global: 0 posts, 0 files, 0 reactions\n"+
+			"Policy "+policyID+", team "+teamID+": "+counts+"\n"+
+			"Policy global, team (direct messages): 0 posts, 0 files, 0 reactions\n"+
+			"Policy "+policyID+", channel "+channelID+": "+counts+"\n"+
+			"Policy global, channel "+dryRun.Channels[1].ChannelID+": 0 posts, 0 files, 0 reactions\n"+
+			"Total: "+counts, printer.GetLines()[0])
+	})
+
+	s.Run("Fail to compute the dry run", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetDataRetentionPolicyDryRun(context.TODO(), policyID).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := dataRetentionDryRunCmdF(s.client, &cobra.Command{}, []string{policyID})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
@@ -0,0 +1,674 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+const (
+	// emojiMaxFileSize matches the size limit the server applies to emoji images.
+	emojiMaxFileSize = 1 << 19 // 512 KiB
+
+	emojiPackFileName = "emoji.yaml"
+
+	// emojiHashCacheFileName is the file of the user's cache directory
+	// keeping the hashes of the images of the existing emoji, by emoji id.
+	emojiHashCacheFileName = "emoji_hashes.json"
+
+	emojiStatusCreated  = "created"
+	emojiStatusExported = "exported"
+	emojiStatusSkipped  = "skipped"
+	emojiStatusConflict = "conflict"
+	emojiStatusFailed   = "failed"
+)
+
+const emojiTemplate = `{{.Id}}: {{.Name}}`
+
+const emojiResultTemplate = `{{.Name}}: {{.Status}}{{if .Message}} ({{.Message}}){{end}}`
+
+var emojiImageExtensions = map[string]bool{
+	".png":  true,
+	".gif":  true,
+	".jpg":  true,
+	".jpeg": true,
+}
+
+var emojiDownloadClient = &http.Client{Timeout: 30 * time.Second}
+
+// EmojiPack is a Slack-style emoji pack. The source of each emoji is either
+// an http(s) URL or a path relative to the pack file.
+type EmojiPack struct {
+	Title  string            `yaml:"title"`
+	Emojis []*EmojiPackEmoji `yaml:"emojis"`
+}
+
+type EmojiPackEmoji struct {
+	Name    string   `yaml:"name"`
+	Src     string   `yaml:"src"`
+	Aliases []string `yaml:"aliases,omitempty"`
+}
+
+// EmojiResult is the outcome of exporting or importing a single emoji.
+type EmojiResult struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+var EmojiCmd = &cobra.Command{
+	Use:   "emoji",
+	Short: "Management of custom emoji",
+}
+
+var EmojiListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List custom emoji",
+	Example: "  emoji list",
+	Args:    cobra.NoArgs,
+	RunE:    withClient(emojiListCmdF),
+}
+
+var EmojiCreateCmd = &cobra.Command{
+	Use:     "create [name] [image]",
+	Short:   "Create a custom emoji",
+	Long:    "Create a custom emoji from a PNG, GIF or JPEG image. The emoji is owned by the user running the command.",
+	Example: "  emoji create partyparrot ./partyparrot.gif",
+	Args:    cobra.ExactArgs(2),
+	RunE:    withClient(emojiCreateCmdF),
+}
+
+var EmojiDeleteCmd = &cobra.Command{
+	Use:     "delete [names]",
+	Aliases: []string{"rm"},
+	Short:   "Delete custom emoji",
+	Example: "  emoji delete partyparrot thisisfine",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(emojiDeleteCmdF),
+}
+
+var EmojiExportCmd = &cobra.Command{
+	Use:   "export [directory]",
+	Short: "Export all custom emoji to a directory",
+	Long: `Download the image of every custom emoji to a directory, along with an ` + emojiPackFileName + ` emoji pack
+that lists them. The directory can be imported into another server with "emoji import".`,
+	Example: "  emoji export ./emoji",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(emojiExportCmdF),
+}
+
+var EmojiImportCmd = &cobra.Command{
+	Use:   "import [directory|zip|pack]",
+	Short: "Import custom emoji",
+	Long: `Import custom emoji from a directory or zip file of images named after the emoji, or from a Slack-style
+emoji pack, a YAML file listing the name and source URL or path of each emoji. A directory or zip file that contains
+an ` + emojiPackFileName + ` pack is imported from the pack.
+
+Emoji whose image is already used by an existing emoji are skipped, and emoji whose name is taken by a different
+image are reported as conflicts. The result of every emoji is printed. The images of the existing emoji are only
+downloaded when needed, and their hashes are cached in the user's cache directory.`,
+	Example: `  emoji import ./emoji
+  emoji import slackmojis.zip
+  emoji import ./packs/parrots.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: withClient(emojiImportCmdF),
+}
+
+func init() {
+	EmojiCmd.AddCommand(
+		EmojiListCmd,
+		EmojiCreateCmd,
+		EmojiDeleteCmd,
+		EmojiExportCmd,
+		EmojiImportCmd,
+	)
+
+	RootCmd.AddCommand(EmojiCmd)
+}
+
+func getAllEmoji(c client.Client) ([]*model.Emoji, error) {
+	return getPages(func(page, numPerPage int, etag string) ([]*model.Emoji, *model.Response, error) {
+		return c.GetEmojiList(context.TODO(), page, numPerPage)
+	}, DefaultPageSize)
+}
+
+func emojiListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	emojis, err := getAllEmoji(c)
+	if err != nil {
+		return errors.Wrap(err, "failed to list emoji")
+	}
+
+	for _, emoji := range emojis {
+		printer.PrintT(emojiTemplate, emoji)
+	}
+
+	return nil
+}
+
+func emojiCreateCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[1])
+	if err != nil {
+		return errors.Wrapf(err, "failed to read image %q", args[1])
+	}
+
+	me, _, err := c.GetUser(context.TODO(), "me", "")
+	if err != nil {
+		return errors.Wrap(err, "failed to get the current user")
+	}
+
+	emoji, _, err := c.CreateEmoji(context.TODO(), &model.Emoji{Name: args[0], CreatorId: me.Id}, data, filepath.Base(args[1]))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create emoji %q", args[0])
+	}
+
+	printer.PrintT(emojiTemplate, emoji)
+	return nil
+}
+
+func emojiDeleteCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	var result *multierror.Error
+	for _, name := range args {
+		emoji, _, err := c.GetEmojiByName(context.TODO(), name)
+		if err != nil {
+			printer.PrintError(fmt.Sprintf("Unable to find emoji %s. Error: %s", name, err.Error()))
+			result = multierror.Append(result, err)
+			continue
+		}
+
+		if _, err := c.DeleteEmoji(context.TODO(), emoji.Id); err != nil {
+			printer.PrintError(fmt.Sprintf("Error deleting emoji %s. Error: %s", name, err.Error()))
+			result = multierror.Append(result, err)
+			continue
+		}
+		printer.Print(fmt.Sprintf("Emoji %s successfully deleted", name))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func emojiExportCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	dir := args[0]
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "failed to create directory %q", dir)
+	}
+
+	emojis, err := getAllEmoji(c)
+	if err != nil {
+		return errors.Wrap(err, "failed to list emoji")
+	}
+
+	pack := &EmojiPack{}
+	var result *multierror.Error
+	for _, emoji := range emojis {
+		fileName, err := exportEmoji(c, emoji, dir)
+		if err != nil {
+			printer.PrintT(emojiResultTemplate, &EmojiResult{Name: emoji.Name, Status: emojiStatusFailed, Message: err.Error()})
+			result = multierror.Append(result, errors.Wrapf(err, "failed to export emoji %q", emoji.Name))
+			continue
+		}
+
+		pack.Emojis = append(pack.Emojis, &EmojiPackEmoji{Name: emoji.Name, Src: fileName})
+		printer.PrintT(emojiResultTemplate, &EmojiResult{Name: emoji.Name, Status: emojiStatusExported, Message: fileName})
+	}
+
+	data, err := yaml.Marshal(pack)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the emoji pack")
+	}
+	if err := os.WriteFile(filepath.Join(dir, emojiPackFileName), data, 0600); err != nil {
+		return errors.Wrap(err, "failed to write the emoji pack")
+	}
+
+	return result.ErrorOrNil()
+}
+
+// exportEmoji downloads the image of an emoji to dir, and returns the name of
+// the file it was written to.
+func exportEmoji(c client.Client, emoji *model.Emoji, dir string) (string, error) {
+	data, _, err := c.GetEmojiImage(context.TODO(), emoji.Id)
+	if err != nil {
+		return "", err
+	}
+
+	fileName := emoji.Name + emojiImageExtension(data)
+	if err := os.WriteFile(filepath.Join(dir, fileName), data, 0600); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+func emojiImageExtension(data []byte) string {
+	switch http.DetectContentType(data) {
+	case "image/gif":
+		return ".gif"
+	case "image/jpeg":
+		return ".jpg"
+	default:
+		return ".png"
+	}
+}
+
+// emojiImportItem is an emoji to import, whose image is only read when needed.
+type emojiImportItem struct {
+	name string
+	load func() ([]byte, error)
+}
+
+func emojiImportCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	items, closeFn, err := readEmojiImportItems(args[0])
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	me, _, err := c.GetUser(context.TODO(), "me", "")
+	if err != nil {
+		return errors.Wrap(err, "failed to get the current user")
+	}
+
+	existing, err := getAllEmoji(c)
+	if err != nil {
+		return errors.Wrap(err, "failed to list the existing emoji")
+	}
+
+	hashes := newEmojiHashes(c, existing)
+	defer hashes.save()
+
+	var result *multierror.Error
+	for _, item := range items {
+		res := importEmoji(c, me.Id, item, hashes)
+		printer.PrintT(emojiResultTemplate, res)
+		if res.Status == emojiStatusFailed || res.Status == emojiStatusConflict {
+			result = multierror.Append(result, errors.Errorf("%s: %s", res.Name, res.Message))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// importEmoji creates a single emoji unless its image or name is already in
+// use, and records it in hashes once created.
+func importEmoji(c client.Client, creatorID string, item *emojiImportItem, hashes *emojiHashes) *EmojiResult {
+	res := &EmojiResult{Name: item.name}
+
+	if model.IsSystemEmojiName(item.name) {
+		res.Status = emojiStatusConflict
+		res.Message = "the name is used by a system emoji"
+		return res
+	}
+	if appErr := model.IsValidEmojiName(item.name); appErr != nil {
+		res.Status = emojiStatusFailed
+		res.Message = "invalid name"
+		return res
+	}
+
+	data, err := item.load()
+	if err != nil {
+		res.Status = emojiStatusFailed
+		res.Message = err.Error()
+		return res
+	}
+	sourceHash := emojiHash(data)
+	hash := hashes.stored(sourceHash)
+
+	existingHash, ok, err := hashes.byName(item.name)
+	if err != nil {
+		res.Status = emojiStatusFailed
+		res.Message = err.Error()
+		return res
+	}
+	if ok {
+		if existingHash == hash {
+			res.Status = emojiStatusSkipped
+			res.Message = "already exists"
+		} else {
+			res.Status = emojiStatusConflict
+			res.Message = "the name is used by a different emoji"
+		}
+		return res
+	}
+
+	duplicate, err := hashes.duplicate(hash)
+	if err != nil {
+		res.Status = emojiStatusFailed
+		res.Message = err.Error()
+		return res
+	}
+	if duplicate != "" {
+		res.Status = emojiStatusSkipped
+		res.Message = fmt.Sprintf("duplicate of :%s:", duplicate)
+		return res
+	}
+
+	emoji, _, err := c.CreateEmoji(context.TODO(), &model.Emoji{Name: item.name, CreatorId: creatorID}, data, item.name+emojiImageExtension(data))
+	if err != nil {
+		res.Status = emojiStatusFailed
+		res.Message = err.Error()
+		return res
+	}
+
+	hashes.add(emoji, sourceHash)
+	res.Status = emojiStatusCreated
+	return res
+}
+
+// emojiHashes finds the existing emoji by name or by image. The images are
+// hashed as stored by the server, which shrinks the large ones, so that the
+// emoji created by anyone can be compared. They are only downloaded when
+// needed, and their hashes are kept in the user's cache directory by emoji
+// id, as the image of an existing emoji can't be replaced.
+type emojiHashes struct {
+	c         client.Client
+	emoji     []*model.Emoji
+	names     map[string]*model.Emoji
+	cache     emojiHashCache
+	images    map[string]string // names by hash, loaded by the first duplicate check
+	cachePath string
+	changed   bool
+}
+
+// emojiHashCache is the content of the cache of the hashes of the images.
+type emojiHashCache struct {
+	// Emoji are the hashes of the stored images, by emoji id.
+	Emoji map[string]string `json:"emoji"`
+	// Sources are the hashes of the stored images, by hash of the imported
+	// images that the server shrank.
+	Sources map[string]string `json:"sources"`
+}
+
+func newEmojiHashes(c client.Client, existing []*model.Emoji) *emojiHashes {
+	h := &emojiHashes{
+		c:     c,
+		emoji: existing,
+		names: map[string]*model.Emoji{},
+	}
+	for _, emoji := range existing {
+		h.names[emoji.Name] = emoji
+	}
+
+	if cacheDir, err := os.UserCacheDir(); err == nil {
+		h.cachePath = filepath.Join(cacheDir, "mmctl", emojiHashCacheFileName)
+		if data, err := os.ReadFile(h.cachePath); err == nil {
+			// a broken cache is rebuilt
+			_ = json.Unmarshal(data, &h.cache)
+		}
+	}
+	if h.cache.Emoji == nil {
+		h.cache.Emoji = map[string]string{}
+	}
+	if h.cache.Sources == nil {
+		h.cache.Sources = map[string]string{}
+	}
+
+	return h
+}
+
+// hash returns the hash of the stored image of an existing emoji.
+func (h *emojiHashes) hash(emoji *model.Emoji) (string, error) {
+	if hash, ok := h.cache.Emoji[emoji.Id]; ok {
+		return hash, nil
+	}
+
+	data, _, err := h.c.GetEmojiImage(context.TODO(), emoji.Id)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get the image of emoji %q", emoji.Name)
+	}
+	hash := emojiHash(data)
+	h.cache.Emoji[emoji.Id] = hash
+	h.changed = true
+
+	return hash, nil
+}
+
+// stored returns the hash of the image the server stored when the image with
+// the given hash was imported, if it's known to have been shrunk, or the
+// given hash otherwise.
+func (h *emojiHashes) stored(sourceHash string) string {
+	if hash, ok := h.cache.Sources[sourceHash]; ok {
+		return hash
+	}
+	return sourceHash
+}
+
+// byName returns the hash of the image of the emoji with the given name, if it exists.
+func (h *emojiHashes) byName(name string) (string, bool, error) {
+	emoji, ok := h.names[name]
+	if !ok {
+		return "", false, nil
+	}
+
+	hash, err := h.hash(emoji)
+	return hash, err == nil, err
+}
+
+// duplicate returns the name of an emoji using the image with the given hash,
+// or an empty string if there is none.
+func (h *emojiHashes) duplicate(hash string) (string, error) {
+	if h.images == nil {
+		images := map[string]string{}
+		for _, emoji := range h.emoji {
+			emojiHash, err := h.hash(emoji)
+			if err != nil {
+				return "", err
+			}
+			if _, ok := images[emojiHash]; !ok {
+				images[emojiHash] = emoji.Name
+			}
+		}
+		h.images = images
+	}
+
+	return h.images[hash], nil
+}
+
+// add records a created emoji, downloading its image as stored by the server.
+func (h *emojiHashes) add(emoji *model.Emoji, sourceHash string) {
+	h.emoji = append(h.emoji, emoji)
+	h.names[emoji.Name] = emoji
+
+	hash, err := h.hash(emoji)
+	if err != nil {
+		// the image is downloaded again when needed
+		h.images = nil
+		return
+	}
+	if hash != sourceHash {
+		h.cache.Sources[sourceHash] = hash
+	}
+	if h.images != nil {
+		if _, ok := h.images[hash]; !ok {
+			h.images[hash] = emoji.Name
+		}
+	}
+}
+
+// save stores the known hashes in the cache, so that the next imports don't download the images again.
+func (h *emojiHashes) save() {
+	if !h.changed || h.cachePath == "" {
+		return
+	}
+
+	data, err := json.Marshal(h.cache)
+	if err == nil {
+		if err = os.MkdirAll(filepath.Dir(h.cachePath), 0700); err == nil {
+			err = os.WriteFile(h.cachePath, data, 0600)
+		}
+	}
+	if err != nil {
+		printer.PrintWarning(fmt.Sprintf("Failed to cache the hashes of the emoji images: %s", err))
+	}
+}
+
+func emojiHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// readEmojiImportItems lists the emoji of a directory, zip file or emoji pack,
+// sorted by name. The returned function releases the opened files.
+func readEmojiImportItems(source string) ([]*emojiImportItem, func(), error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to open %q", source)
+	}
+
+	var (
+		fsys     fs.FS
+		packFile string
+		closeFn  = func() {}
+	)
+	switch ext := strings.ToLower(filepath.Ext(source)); {
+	case info.IsDir():
+		fsys = os.DirFS(source)
+	case ext == ".zip":
+		zipReader, zErr := zip.OpenReader(source)
+		if zErr != nil {
+			return nil, nil, errors.Wrapf(zErr, "failed to open zip file %q", source)
+		}
+		fsys = zipReader
+		closeFn = func() { zipReader.Close() }
+	case ext == ".yaml" || ext == ".yml":
+		fsys = os.DirFS(filepath.Dir(source))
+		packFile = filepath.Base(source)
+	default:
+		return nil, nil, errors.Errorf("%q is not a directory, a zip file or a YAML emoji pack", source)
+	}
+
+	if packFile == "" {
+		if _, err := fs.Stat(fsys, emojiPackFileName); err == nil {
+			packFile = emojiPackFileName
+		}
+	}
+
+	var items []*emojiImportItem
+	if packFile != "" {
+		items, err = readEmojiPack(fsys, packFile)
+	} else {
+		items, err = readEmojiImages(fsys)
+	}
+	if err != nil {
+		closeFn()
+		return nil, nil, err
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].name < items[j].name })
+	return items, closeFn, nil
+}
+
+func readEmojiPack(fsys fs.FS, packFile string) ([]*emojiImportItem, error) {
+	data, err := fs.ReadFile(fsys, packFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read emoji pack %q", packFile)
+	}
+
+	var pack EmojiPack
+	if err := yaml.Unmarshal(data, &pack); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse emoji pack %q", packFile)
+	}
+
+	items := make([]*emojiImportItem, 0, len(pack.Emojis))
+	for _, emoji := range pack.Emojis {
+		src := emoji.Src
+		load := func() ([]byte, error) {
+			if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") {
+				return downloadEmojiImage(src)
+			}
+			return readEmojiImage(fsys, path.Join(path.Dir(packFile), src))
+		}
+		if src == "" {
+			load = func() ([]byte, error) { return nil, errors.New("missing source") }
+		}
+		items = append(items, &emojiImportItem{name: emoji.Name, load: load})
+	}
+
+	return items, nil
+}
+
+func readEmojiImages(fsys fs.FS) ([]*emojiImportItem, error) {
+	var items []*emojiImportItem
+	err := fs.WalkDir(fsys, ".", func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && strings.HasPrefix(d.Name(), "__MACOSX") {
+			return fs.SkipDir
+		}
+
+		ext := path.Ext(d.Name())
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".") || !emojiImageExtensions[strings.ToLower(ext)] {
+			return nil
+		}
+
+		items = append(items, &emojiImportItem{
+			name: strings.ToLower(strings.TrimSuffix(d.Name(), ext)),
+			load: func() ([]byte, error) { return readEmojiImage(fsys, filePath) },
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the emoji images")
+	}
+
+	return items, nil
+}
+
+func readEmojiImage(fsys fs.FS, filePath string) ([]byte, error) {
+	file, err := fsys.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return readEmojiData(file)
+}
+
+func downloadEmojiImage(url string) ([]byte, error) {
+	resp, err := emojiDownloadClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+
+	return readEmojiData(resp.Body)
+}
+
+func readEmojiData(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, emojiMaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > emojiMaxFileSize {
+		return nil, errors.Errorf("the image is larger than %d KiB", emojiMaxFileSize/1024)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlE2ETestSuite) TestEmojiCmds() {
+	s.SetupTestHelper().InitBasic()
+	s.th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCustomEmoji = true })
+
+	dir := s.T().TempDir()
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "mmctl_gif.gif"), utils.CreateTestGif(s.T(), 10, 10), 0600))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "mmctl_png.png"), utils.CreateTestPng(s.T(), 10, 10), 0600))
+
+	s.Run("create an emoji", func() {
+		printer.Clean()
+
+		err := emojiCreateCmdF(s.th.Client, &cobra.Command{}, []string{"mmctl_created", filepath.Join(dir, "mmctl_gif.gif")})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(s.th.BasicUser.Id, printer.GetLines()[0].(*model.Emoji).CreatorId)
+	})
+
+	s.Run("import skips the images that already exist", func() {
+		printer.Clean()
+
+		err := emojiImportCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{dir})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{
+			&EmojiResult{Name: "mmctl_gif", Status: emojiStatusSkipped, Message: "duplicate of :mmctl_created:"},
+			&EmojiResult{Name: "mmctl_png", Status: emojiStatusCreated},
+		}, printer.GetLines())
+	})
+
+	s.Run("export and import into an empty server", func() {
+		printer.Clean()
+
+		exportDir := filepath.Join(s.T().TempDir(), "export")
+		err := emojiExportCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{exportDir})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().FileExists(filepath.Join(exportDir, emojiPackFileName))
+
+		printer.Clean()
+		err = emojiDeleteCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{"mmctl_created", "mmctl_png"})
+		s.Require().NoError(err)
+
+		printer.Clean()
+		err = emojiImportCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{exportDir})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{
+			&EmojiResult{Name: "mmctl_created", Status: emojiStatusCreated},
+			&EmojiResult{Name: "mmctl_png", Status: emojiStatusCreated},
+		}, printer.GetLines())
+
+		printer.Clean()
+		err = emojiListCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+	})
+}
@@ -0,0 +1,379 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"archive/zip"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+var (
+	testEmojiPNG = []byte("\x89PNG\r\n\x1a\nparrot")
+	testEmojiGIF = []byte("GIF89afine")
+)
+
+func (s *MmctlUnitTestSuite) expectEmojiList(emojis []*model.Emoji) {
+	s.client.
+		EXPECT().
+		GetEmojiList(context.TODO(), 0, DefaultPageSize).
+		Return(emojis, &model.Response{}, nil).
+		Times(1)
+	if len(emojis) > 0 {
+		s.client.
+			EXPECT().
+			GetEmojiList(context.TODO(), 1, DefaultPageSize).
+			Return([]*model.Emoji{}, &model.Response{}, nil).
+			Times(1)
+	}
+}
+
+func (s *MmctlUnitTestSuite) TestEmojiListCmd() {
+	s.Run("list all custom emoji", func() {
+		printer.Clean()
+
+		emoji := &model.Emoji{Id: model.NewId(), Name: "partyparrot"}
+		s.expectEmojiList([]*model.Emoji{emoji})
+
+		err := emojiListCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{emoji}, printer.GetLines())
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestEmojiCreateCmd() {
+	me := &model.User{Id: model.NewId()}
+
+	s.Run("create an emoji owned by the current user", func() {
+		printer.Clean()
+
+		path := filepath.Join(s.T().TempDir(), "parrot.png")
+		s.Require().NoError(os.WriteFile(path, testEmojiPNG, 0600))
+		created := &model.Emoji{Id: model.NewId(), Name: "partyparrot", CreatorId: me.Id}
+
+		s.client.
+			EXPECT().
+			GetUser(context.TODO(), "me", "").
+			Return(me, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateEmoji(context.TODO(), &model.Emoji{Name: "partyparrot", CreatorId: me.Id}, testEmojiPNG, "parrot.png").
+			Return(created, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiCreateCmdF(s.client, &cobra.Command{}, []string{"partyparrot", path})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{created}, printer.GetLines())
+	})
+
+	s.Run("image not found", func() {
+		printer.Clean()
+
+		err := emojiCreateCmdF(s.client, &cobra.Command{}, []string{"partyparrot", filepath.Join(s.T().TempDir(), "missing.png")})
+		s.Require().Error(err)
+		s.Require().Contains(err.Error(), "failed to read image")
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestEmojiDeleteCmd() {
+	s.Run("keep deleting after a failure", func() {
+		printer.Clean()
+
+		emoji := &model.Emoji{Id: model.NewId(), Name: "partyparrot"}
+
+		s.client.
+			EXPECT().
+			GetEmojiByName(context.TODO(), "missing").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetEmojiByName(context.TODO(), emoji.Name).
+			Return(emoji, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DeleteEmoji(context.TODO(), emoji.Id).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := emojiDeleteCmdF(s.client, &cobra.Command{}, []string{"missing", emoji.Name})
+		s.Require().Error(err)
+		s.Require().Equal([]any{"Emoji partyparrot successfully deleted"}, printer.GetLines())
+		s.Require().Equal([]string{"Unable to find emoji missing. Error: not found"}, printer.GetErrorLines())
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestEmojiExportCmd() {
+	s.Run("export the images and the emoji pack", func() {
+		printer.Clean()
+
+		dir := filepath.Join(s.T().TempDir(), "emoji")
+		parrot := &model.Emoji{Id: model.NewId(), Name: "partyparrot"}
+		fine := &model.Emoji{Id: model.NewId(), Name: "thisisfine"}
+		s.expectEmojiList([]*model.Emoji{parrot, fine})
+		s.client.
+			EXPECT().
+			GetEmojiImage(context.TODO(), parrot.Id).
+			Return(testEmojiPNG, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetEmojiImage(context.TODO(), fine.Id).
+			Return(testEmojiGIF, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiExportCmdF(s.client, &cobra.Command{}, []string{dir})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{
+			&EmojiResult{Name: "partyparrot", Status: emojiStatusExported, Message: "partyparrot.png"},
+			&EmojiResult{Name: "thisisfine", Status: emojiStatusExported, Message: "thisisfine.gif"},
+		}, printer.GetLines())
+
+		data, err := os.ReadFile(filepath.Join(dir, "thisisfine.gif"))
+		s.Require().NoError(err)
+		s.Require().Equal(testEmojiGIF, data)
+
+		items, closeFn, err := readEmojiImportItems(dir)
+		s.Require().NoError(err)
+		defer closeFn()
+		s.Require().Len(items, 2)
+		s.Require().Equal("partyparrot", items[0].name)
+		data, err = items[0].load()
+		s.Require().NoError(err)
+		s.Require().Equal(testEmojiPNG, data)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestEmojiImportCmd() {
+	me := &model.User{Id: model.NewId()}
+	existing := &model.Emoji{Id: model.NewId(), Name: "partyparrot"}
+
+	s.Run("import a directory of images", func() {
+		printer.Clean()
+		s.T().Setenv("XDG_CACHE_HOME", s.T().TempDir())
+
+		dir := s.T().TempDir()
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "partyparrot.png"), testEmojiPNG, 0600))
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "parrot_copy.png"), testEmojiPNG, 0600))
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "thisisfine.gif"), testEmojiGIF, 0600))
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "README.md"), []byte("not an emoji"), 0600))
+
+		s.client.
+			EXPECT().
+			GetUser(context.TODO(), "me", "").
+			Return(me, &model.Response{}, nil).
+			Times(1)
+		s.expectEmojiList([]*model.Emoji{existing})
+		s.client.
+			EXPECT().
+			GetEmojiImage(context.TODO(), existing.Id).
+			Return(testEmojiPNG, &model.Response{}, nil).
+			Times(1)
+		created := &model.Emoji{Id: model.NewId(), Name: "thisisfine"}
+		s.client.
+			EXPECT().
+			CreateEmoji(context.TODO(), &model.Emoji{Name: "thisisfine", CreatorId: me.Id}, testEmojiGIF, "thisisfine.gif").
+			Return(created, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetEmojiImage(context.TODO(), created.Id).
+			Return(testEmojiGIF, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiImportCmdF(s.client, &cobra.Command{}, []string{dir})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{
+			&EmojiResult{Name: "parrot_copy", Status: emojiStatusSkipped, Message: "duplicate of :partyparrot:"},
+			&EmojiResult{Name: "partyparrot", Status: emojiStatusSkipped, Message: "already exists"},
+			&EmojiResult{Name: "thisisfine", Status: emojiStatusCreated},
+		}, printer.GetLines())
+	})
+
+	s.Run("import a zip file with an emoji pack", func() {
+		printer.Clean()
+		s.T().Setenv("XDG_CACHE_HOME", s.T().TempDir())
+
+		zipPath := filepath.Join(s.T().TempDir(), "pack.zip")
+		zipFile, err := os.Create(zipPath)
+		s.Require().NoError(err)
+		zipWriter := zip.NewWriter(zipFile)
+		for name, data := range map[string][]byte{
+			emojiPackFileName:    []byte("title: test\nemojis:\n  - name: partyparrot\n    src: images/fine.gif\n  - name: bad name\n    src: images/fine.gif\n  - name: smile\n    src: images/fine.gif\n  - name: failing\n    src: images/failing.png\n  - name: missing\n    src: images/missing.png\n"),
+			"images/fine.gif":    testEmojiGIF,
+			"images/failing.png": []byte("\x89PNG\r\n\x1a\nfailing"),
+		} {
+			w, wErr := zipWriter.Create(name)
+			s.Require().NoError(wErr)
+			_, wErr = w.Write(data)
+			s.Require().NoError(wErr)
+		}
+		s.Require().NoError(zipWriter.Close())
+		s.Require().NoError(zipFile.Close())
+
+		s.client.
+			EXPECT().
+			GetUser(context.TODO(), "me", "").
+			Return(me, &model.Response{}, nil).
+			Times(1)
+		s.expectEmojiList([]*model.Emoji{existing})
+		s.client.
+			EXPECT().
+			GetEmojiImage(context.TODO(), existing.Id).
+			Return(testEmojiPNG, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateEmoji(context.TODO(), &model.Emoji{Name: "failing", CreatorId: me.Id}, gomock.Any(), "failing.png").
+			Return(nil, &model.Response{StatusCode: http.StatusBadRequest}, errors.New("invalid image")).
+			Times(1)
+
+		err = emojiImportCmdF(s.client, &cobra.Command{}, []string{zipPath})
+		s.Require().Error(err)
+		lines := printer.GetLines()
+		s.Require().Len(lines, 5)
+		s.Require().Equal(&EmojiResult{Name: "bad name", Status: emojiStatusFailed, Message: "invalid name"}, lines[0])
+		s.Require().Equal(&EmojiResult{Name: "failing", Status: emojiStatusFailed, Message: "invalid image"}, lines[1])
+		s.Require().Equal(emojiStatusFailed, lines[2].(*EmojiResult).Status)
+		s.Require().Equal("missing", lines[2].(*EmojiResult).Name)
+		s.Require().Equal(&EmojiResult{Name: "partyparrot", Status: emojiStatusConflict, Message: "the name is used by a different emoji"}, lines[3])
+		s.Require().Equal(&EmojiResult{Name: "smile", Status: emojiStatusConflict, Message: "the name is used by a system emoji"}, lines[4])
+	})
+
+	s.Run("only download the image of a name in use", func() {
+		printer.Clean()
+		s.T().Setenv("XDG_CACHE_HOME", s.T().TempDir())
+
+		dir := s.T().TempDir()
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "partyparrot.png"), testEmojiPNG, 0600))
+
+		s.client.
+			EXPECT().
+			GetUser(context.TODO(), "me", "").
+			Return(me, &model.Response{}, nil).
+			Times(1)
+		s.expectEmojiList([]*model.Emoji{existing, {Id: model.NewId(), Name: "thisisfine"}})
+		s.client.
+			EXPECT().
+			GetEmojiImage(context.TODO(), existing.Id).
+			Return(testEmojiPNG, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiImportCmdF(s.client, &cobra.Command{}, []string{dir})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{
+			&EmojiResult{Name: "partyparrot", Status: emojiStatusSkipped, Message: "already exists"},
+		}, printer.GetLines())
+	})
+
+	s.Run("reuse the cached hashes of the images", func() {
+		printer.Clean()
+		cacheDir := s.T().TempDir()
+		s.T().Setenv("XDG_CACHE_HOME", cacheDir)
+
+		cachePath := filepath.Join(cacheDir, "mmctl", emojiHashCacheFileName)
+		s.Require().NoError(os.MkdirAll(filepath.Dir(cachePath), 0700))
+		s.Require().NoError(os.WriteFile(cachePath, []byte(`{"emoji":{"`+existing.Id+`":"`+emojiHash(testEmojiPNG)+`"}}`), 0600))
+
+		dir := s.T().TempDir()
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "thisisfine.gif"), testEmojiGIF, 0600))
+		created := &model.Emoji{Id: model.NewId(), Name: "thisisfine"}
+
+		s.client.
+			EXPECT().
+			GetUser(context.TODO(), "me", "").
+			Return(me, &model.Response{}, nil).
+			Times(1)
+		s.expectEmojiList([]*model.Emoji{existing})
+		s.client.
+			EXPECT().
+			CreateEmoji(context.TODO(), &model.Emoji{Name: "thisisfine", CreatorId: me.Id}, testEmojiGIF, "thisisfine.gif").
+			Return(created, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetEmojiImage(context.TODO(), created.Id).
+			Return(testEmojiGIF, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiImportCmdF(s.client, &cobra.Command{}, []string{dir})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{&EmojiResult{Name: "thisisfine", Status: emojiStatusCreated}}, printer.GetLines())
+
+		data, err := os.ReadFile(cachePath)
+		s.Require().NoError(err)
+		s.Require().JSONEq(`{"emoji":{"`+existing.Id+`":"`+emojiHash(testEmojiPNG)+`","`+created.Id+`":"`+emojiHash(testEmojiGIF)+`"},"sources":{}}`, string(data))
+	})
+
+	s.Run("compare the images as stored by the server", func() {
+		s.T().Setenv("XDG_CACHE_HOME", s.T().TempDir())
+
+		// The server shrinks the large images.
+		large := []byte("\x89PNG\r\n\x1a\nlarge parrot")
+		dir := s.T().TempDir()
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "bigparrot.png"), large, 0600))
+		created := &model.Emoji{Id: model.NewId(), Name: "bigparrot"}
+
+		printer.Clean()
+		s.client.
+			EXPECT().
+			GetUser(context.TODO(), "me", "").
+			Return(me, &model.Response{}, nil).
+			Times(2)
+		s.expectEmojiList([]*model.Emoji{existing})
+		s.client.
+			EXPECT().
+			GetEmojiImage(context.TODO(), existing.Id).
+			Return(testEmojiGIF, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateEmoji(context.TODO(), &model.Emoji{Name: "bigparrot", CreatorId: me.Id}, large, "bigparrot.png").
+			Return(created, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetEmojiImage(context.TODO(), created.Id).
+			Return(testEmojiPNG, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiImportCmdF(s.client, &cobra.Command{}, []string{dir})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{&EmojiResult{Name: "bigparrot", Status: emojiStatusCreated}}, printer.GetLines())
+
+		// Importing the same image again matches the stored one, under the
+		// same name or another one, without downloading the images again.
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "bigparrot_copy.png"), large, 0600))
+		printer.Clean()
+		s.expectEmojiList([]*model.Emoji{existing, created})
+
+		err = emojiImportCmdF(s.client, &cobra.Command{}, []string{dir})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{
+			&EmojiResult{Name: "bigparrot", Status: emojiStatusSkipped, Message: "already exists"},
+			&EmojiResult{Name: "bigparrot_copy", Status: emojiStatusSkipped, Message: "duplicate of :bigparrot:"},
+		}, printer.GetLines())
+	})
+
+	s.Run("unsupported source", func() {
+		printer.Clean()
+
+		path := filepath.Join(s.T().TempDir(), "emoji.txt")
+		s.Require().NoError(os.WriteFile(path, []byte{}, 0600))
+
+		err := emojiImportCmdF(s.client, &cobra.Command{}, []string{path})
+		s.Require().EqualError(err, `"`+path+`" is not a directory, a zip file or a YAML emoji pack`)
+	})
+}
@@ -466,3 +466,143 @@ Permissions   edit_brand
 		s.Require().Len(printer.GetErrorLines(), 0)
 	})
 }
+
+func (s *MmctlUnitTestSuite) TestCreateRoleCmd() {
+	s.Run("Create a role with ancillary permissions", func() {
+		printer.Clean()
+
+		expectedRole := &model.Role{
+			Name:        "support_agent",
+			DisplayName: "Support agent",
+			Permissions: []string{"sysconsole_read_user_management_channels", "read_public_channel", "read_channel", "read_public_channel_groups", "read_private_channel_groups"},
+		}
+		createdRole := &model.Role{Id: model.NewId(), Name: expectedRole.Name}
+
+		s.client.
+			EXPECT().
+			CreateRole(context.TODO(), expectedRole).
+			Return(createdRole, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "Support agent", "")
+		cmd.Flags().String("description", "", "")
+		cmd.Flags().StringSlice("permissions", []string{"sysconsole_read_user_management_channels", "read_channel"}, "")
+
+		err := createRoleCmdF(s.client, cmd, []string{expectedRole.Name})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Fail to create a role", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			CreateRole(context.TODO(), &model.Role{Name: "support_agent", DisplayName: "Support agent", Permissions: []string{}}).
+			Return(nil, &model.Response{StatusCode: http.StatusBadRequest}, errors.New("name taken")).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "Support agent", "")
+		cmd.Flags().String("description", "", "")
+		cmd.Flags().StringSlice("permissions", nil, "")
+
+		err := createRoleCmdF(s.client, cmd, []string{"support_agent"})
+		s.Require().EqualError(err, `failed to create role "support_agent": name taken`)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+	s.Run("Available at the top level and under permissions", func() {
+		for _, args := range [][]string{{"role", "create"}, {"permissions", "role", "create"}} {
+			cmd, _, err := RootCmd.Find(args)
+			s.Require().NoError(err)
+			s.Require().Equal("create", cmd.Name())
+			s.Require().Equal(args[len(args)-2], cmd.Parent().Name())
+			s.Require().NotNil(cmd.Flags().Lookup("display-name"))
+		}
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestPatchRoleCmd() {
+	s.Run("Replace the permissions of a role", func() {
+		printer.Clean()
+
+		mockRole := &model.Role{Id: model.NewId(), Name: "support_agent", Permissions: []string{"read_channel"}}
+		expectedPermissions := []string{"list_public_teams", "join_public_teams"}
+
+		s.client.
+			EXPECT().
+			GetRoleByName(context.TODO(), mockRole.Name).
+			Return(mockRole, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			PatchRole(context.TODO(), mockRole.Id, &model.RolePatch{Permissions: &expectedPermissions}).
+			Return(&model.Role{Id: mockRole.Id, Name: mockRole.Name, Permissions: expectedPermissions}, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().StringSlice("permissions", []string{"list_public_teams", "join_public_teams", "list_public_teams"}, "")
+
+		err := patchRoleCmdF(s.client, cmd, []string{mockRole.Name})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestDeleteRoleCmd() {
+	s.Run("Delete custom roles", func() {
+		printer.Clean()
+
+		mockRole := &model.Role{Id: model.NewId(), Name: "support_agent"}
+
+		s.client.
+			EXPECT().
+			GetRoleByName(context.TODO(), mockRole.Name).
+			Return(mockRole, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DeleteRole(context.TODO(), mockRole.Id).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := deleteRoleCmdF(s.client, &cobra.Command{}, []string{mockRole.Name})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{"Role 'support_agent' successfully deleted."}, printer.GetLines())
+	})
+
+	s.Run("Keep deleting after a failure", func() {
+		printer.Clean()
+
+		builtIn := &model.Role{Id: model.NewId(), Name: model.SystemUserRoleId, BuiltIn: true}
+		custom := &model.Role{Id: model.NewId(), Name: "support_agent"}
+
+		s.client.
+			EXPECT().
+			GetRoleByName(context.TODO(), builtIn.Name).
+			Return(builtIn, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DeleteRole(context.TODO(), builtIn.Id).
+			Return(&model.Response{StatusCode: http.StatusBadRequest}, errors.New("not a custom role")).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetRoleByName(context.TODO(), custom.Name).
+			Return(custom, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DeleteRole(context.TODO(), custom.Id).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := deleteRoleCmdF(s.client, &cobra.Command{}, []string{builtIn.Name, custom.Name})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal([]string{"Couldn't delete role 'system_user'."}, printer.GetErrorLines())
+	})
+}
@@ -6,6 +6,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/mattermost/mattermost/server/public/model"
 
@@ -60,12 +61,39 @@ var ResetCmd = &cobra.Command{
 	RunE: withClient(resetPermissionsCmdF),
 }
 
+var DiffPermissionsCmd = &cobra.Command{
+	Use:   "diff <role_a> <role_b>",
+	Short: "Compare the permissions of two roles",
+	Long:  "Show the permissions that only one of two roles has.",
+	Example: `  # Show what a system manager can do that a system user can't, and the other way around.
+  $ mmctl permissions diff system_user system_manager`,
+	Args: cobra.ExactArgs(2),
+	RunE: withClient(diffPermissionsCmdF),
+}
+
+// RolePermissionsDiff holds the permissions that only one of two roles has.
+type RolePermissionsDiff struct {
+	RoleA     string   `json:"role_a"`
+	RoleB     string   `json:"role_b"`
+	OnlyInA   []string `json:"only_in_a"`
+	OnlyInB   []string `json:"only_in_b"`
+	Identical bool     `json:"identical"`
+}
+
+const rolePermissionsDiffTemplate = `{{if .Identical}}Roles {{.RoleA}} and {{.RoleB}} have the same permissions{{else}}Only in {{.RoleA}}:{{range .OnlyInA}}
+  + {{.}}{{else}}
+  (none){{end}}
+Only in {{.RoleB}}:{{range .OnlyInB}}
+  + {{.}}{{else}}
+  (none){{end}}{{end}}`
+
 func init() {
 	PermissionsCmd.AddCommand(
 		AddPermissionsCmd,
 		RemovePermissionsCmd,
 		ShowRoleCmd,
 		ResetCmd,
+		DiffPermissionsCmd,
 	)
 
 	RootCmd.AddCommand(PermissionsCmd)
@@ -164,6 +192,49 @@ func resetPermissionsCmdF(c client.Client, cmd *cobra.Command, args []string) er
 	return nil
 }
 
+func diffPermissionsCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	roleA, _, err := c.GetRoleByName(context.TODO(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get role %q: %w", args[0], err)
+	}
+
+	roleB, _, err := c.GetRoleByName(context.TODO(), args[1])
+	if err != nil {
+		return fmt.Errorf("failed to get role %q: %w", args[1], err)
+	}
+
+	diff := &RolePermissionsDiff{
+		RoleA:   roleA.Name,
+		RoleB:   roleB.Name,
+		OnlyInA: permissionsDifference(roleA.Permissions, roleB.Permissions),
+		OnlyInB: permissionsDifference(roleB.Permissions, roleA.Permissions),
+	}
+	diff.Identical = len(diff.OnlyInA) == 0 && len(diff.OnlyInB) == 0
+
+	printer.PrintT(rolePermissionsDiffTemplate, diff)
+
+	return nil
+}
+
+// permissionsDifference returns the sorted permissions of a that aren't in b.
+func permissionsDifference(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, permission := range b {
+		inB[permission] = true
+	}
+
+	difference := []string{}
+	for _, permission := range a {
+		if !inB[permission] {
+			inB[permission] = true
+			difference = append(difference, permission)
+		}
+	}
+	sort.Strings(difference)
+
+	return difference
+}
+
 func removeFromStringSlice(items []string, item string) []string {
 	newPermissions := []string{}
 	for _, x := range items {
@@ -108,3 +108,19 @@ func (s *MmctlE2ETestSuite) TestRemovePermissionsCmd() {
 		s.Require().NotContains(updatedRole.Permissions, model.PermissionCreateDirectChannel.Id)
 	})
 }
+
+func (s *MmctlE2ETestSuite) TestDiffPermissionsCmd() {
+	s.SetupTestHelper().InitBasic()
+
+	s.RunForAllClients("Compare two roles", func(c client.Client) {
+		printer.Clean()
+
+		err := diffPermissionsCmdF(c, &cobra.Command{}, []string{model.ChannelUserRoleId, model.ChannelAdminRoleId})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+
+		diff := printer.GetLines()[0].(*RolePermissionsDiff)
+		s.Require().False(diff.Identical)
+		s.Require().NotEmpty(diff.OnlyInB)
+	})
+}
@@ -63,16 +63,93 @@ var UnassignCmd = &cobra.Command{
 	RunE: withClient(unassignUsersCmdF),
 }
 
+// CustomRoleCmd manages custom roles. Its commands are also available under "permissions role".
+var CustomRoleCmd = &cobra.Command{
+	Use:   "role",
+	Short: "Management of custom roles",
+}
+
+var (
+	RoleCreateCmd = newRoleCreateCmd("permissions role")
+	RolePatchCmd  = newRolePatchCmd("permissions role")
+	RoleDeleteCmd = newRoleDeleteCmd("permissions role")
+
+	CustomRoleCreateCmd = newRoleCreateCmd("role")
+	CustomRolePatchCmd  = newRolePatchCmd("role")
+	CustomRoleDeleteCmd = newRoleDeleteCmd("role")
+)
+
+// newRoleCreateCmd creates the command creating custom roles, for the parent command with the given path.
+func newRoleCreateCmd(parentPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create <role_name>",
+		Short: "Create a custom role (EE Only)",
+		Long:  "Create a custom role with the given permissions (Only works in Enterprise Edition).",
+		Example: fmt.Sprintf(`  %[1]s create support_agent --display-name "Support agent" --permissions list_public_teams,manage_public_channel_members
+  %[1]s create console_reader --display-name "Console reader" --permissions sysconsole_read_user_management_users`, parentPath),
+		Args: cobra.ExactArgs(1),
+		RunE: withClient(createRoleCmdF),
+	}
+
+	cmd.Flags().String("display-name", "", "Display name of the role")
+	cmd.Flags().String("description", "", "Description of the role")
+	cmd.Flags().StringSlice("permissions", nil, "Permissions of the role")
+	_ = cmd.MarkFlagRequired("display-name")
+
+	return cmd
+}
+
+// newRolePatchCmd creates the command replacing the permissions of roles, for the parent command with the given path.
+func newRolePatchCmd(parentPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "patch <role_name>",
+		Short:   "Replace the permissions of a role (EE Only)",
+		Long:    "Replace the whole permission set of a role (Only works in Enterprise Edition). Use \"permissions add\" and \"permissions remove\" to change single permissions.",
+		Example: fmt.Sprintf("  %s patch support_agent --permissions list_public_teams,join_public_teams", parentPath),
+		Args:    cobra.ExactArgs(1),
+		RunE:    withClient(patchRoleCmdF),
+	}
+
+	cmd.Flags().StringSlice("permissions", nil, "New permissions of the role")
+	_ = cmd.MarkFlagRequired("permissions")
+
+	return cmd
+}
+
+// newRoleDeleteCmd creates the command deleting custom roles, for the parent command with the given path.
+func newRoleDeleteCmd(parentPath string) *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete <role_name...>",
+		Aliases: []string{"rm"},
+		Short:   "Delete custom roles (EE Only)",
+		Long:    "Delete custom roles (Only works in Enterprise Edition). Built-in and scheme managed roles can't be deleted.",
+		Example: fmt.Sprintf("  %s delete support_agent", parentPath),
+		Args:    cobra.MinimumNArgs(1),
+		RunE:    withClient(deleteRoleCmdF),
+	}
+}
+
 func init() {
 	RoleCmd.AddCommand(
 		AssignCmd,
 		UnassignCmd,
 		ShowCmd,
+		RoleCreateCmd,
+		RolePatchCmd,
+		RoleDeleteCmd,
 	)
 
 	PermissionsCmd.AddCommand(
 		RoleCmd,
 	)
+
+	CustomRoleCmd.AddCommand(
+		CustomRoleCreateCmd,
+		CustomRolePatchCmd,
+		CustomRoleDeleteCmd,
+	)
+
+	RootCmd.AddCommand(CustomRoleCmd)
 }
 
 func prettyRole(role *model.Role) string {
@@ -225,3 +302,85 @@ func unassignUsersCmdF(c client.Client, cmd *cobra.Command, args []string) error
 
 	return nil
 }
+
+// withAncillaryPermissions returns the given permissions with the ancillary
+// permissions of the sysconsole ones, without duplicates.
+func withAncillaryPermissions(permissions []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	add := func(permissionID string) {
+		if !seen[permissionID] {
+			seen[permissionID] = true
+			result = append(result, permissionID)
+		}
+	}
+
+	for _, permissionID := range permissions {
+		add(permissionID)
+		for _, ancillaryPermission := range model.SysconsoleAncillaryPermissions[permissionID] {
+			add(ancillaryPermission.Id)
+		}
+	}
+
+	return result
+}
+
+func createRoleCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	displayName, _ := cmd.Flags().GetString("display-name")
+	description, _ := cmd.Flags().GetString("description")
+	permissions, _ := cmd.Flags().GetStringSlice("permissions")
+
+	role, _, err := c.CreateRole(context.TODO(), &model.Role{
+		Name:        args[0],
+		DisplayName: displayName,
+		Description: description,
+		Permissions: withAncillaryPermissions(permissions),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create role %q: %w", args[0], err)
+	}
+
+	printer.PrintT(prettyRole(role), nil)
+
+	return nil
+}
+
+func patchRoleCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	role, _, err := c.GetRoleByName(context.TODO(), args[0])
+	if err != nil {
+		return err
+	}
+
+	permissions, _ := cmd.Flags().GetStringSlice("permissions")
+	newPermissions := withAncillaryPermissions(permissions)
+
+	role, _, err = c.PatchRole(context.TODO(), role.Id, &model.RolePatch{Permissions: &newPermissions})
+	if err != nil {
+		return fmt.Errorf("failed to patch role %q: %w", args[0], err)
+	}
+
+	printer.PrintT(prettyRole(role), nil)
+
+	return nil
+}
+
+func deleteRoleCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	var errs *multierror.Error
+	for _, roleName := range args {
+		role, _, err := c.GetRoleByName(context.TODO(), roleName)
+		if err != nil {
+			printer.PrintError("Couldn't find role '" + roleName + "'.")
+			errs = multierror.Append(errs, fmt.Errorf("couldn't find role '%s': %w", roleName, err))
+			continue
+		}
+
+		if _, err := c.DeleteRole(context.TODO(), role.Id); err != nil {
+			printer.PrintError("Couldn't delete role '" + roleName + "'.")
+			errs = multierror.Append(errs, fmt.Errorf("couldn't delete role '%s': %w", roleName, err))
+			continue
+		}
+		printer.Print("Role '" + roleName + "' successfully deleted.")
+	}
+
+	return errs.ErrorOrNil()
+}
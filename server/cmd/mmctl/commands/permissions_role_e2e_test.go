@@ -4,6 +4,8 @@
 package commands
 
 import (
+	"context"
+
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
 
@@ -102,3 +104,63 @@ func (s *MmctlE2ETestSuite) TestUnassignUsersCmd() {
 		s.Require().False(u.IsInRole(model.SystemManagerRoleId))
 	})
 }
+
+func (s *MmctlE2ETestSuite) TestCustomRoleCmds() {
+	s.SetupEnterpriseTestHelper().InitBasic()
+
+	s.Run("Should not allow normal user to create a role", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "Forbidden", "")
+		cmd.Flags().String("description", "", "")
+		cmd.Flags().StringSlice("permissions", nil, "")
+
+		err := createRoleCmdF(s.th.Client, cmd, []string{"forbidden_role"})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+
+	s.RunForSystemAdminAndLocal("Create, patch and delete a custom role", func(c client.Client) {
+		printer.Clean()
+
+		roleName := "custom_" + model.NewId()[:10]
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "Custom role", "")
+		cmd.Flags().String("description", "", "")
+		cmd.Flags().StringSlice("permissions", []string{model.PermissionListPublicTeams.Id}, "")
+
+		err := createRoleCmdF(c, cmd, []string{roleName})
+		s.Require().NoError(err)
+
+		role, appErr := s.th.App.GetRoleByName(context.Background(), roleName)
+		s.Require().Nil(appErr)
+		s.Require().Equal([]string{model.PermissionListPublicTeams.Id}, role.Permissions)
+
+		patchCmd := &cobra.Command{}
+		patchCmd.Flags().StringSlice("permissions", []string{model.PermissionJoinPublicTeams.Id}, "")
+
+		err = patchRoleCmdF(c, patchCmd, []string{roleName})
+		s.Require().NoError(err)
+
+		role, appErr = s.th.App.GetRoleByName(context.Background(), roleName)
+		s.Require().Nil(appErr)
+		s.Require().Equal([]string{model.PermissionJoinPublicTeams.Id}, role.Permissions)
+
+		printer.Clean()
+		err = deleteRoleCmdF(c, &cobra.Command{}, []string{roleName})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+
+		_, appErr = s.th.App.GetRoleByName(context.Background(), roleName)
+		s.Require().NotNil(appErr)
+	})
+
+	s.RunForSystemAdminAndLocal("Built-in roles can't be deleted", func(c client.Client) {
+		printer.Clean()
+
+		err := deleteRoleCmdF(c, &cobra.Command{}, []string{model.SystemUserRoleId})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetErrorLines(), 1)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
 
 	"github.com/spf13/cobra"
 )
@@ -268,3 +269,69 @@ func (s *MmctlUnitTestSuite) TestResetPermissionsCmd() {
 		s.Require().Nil(err)
 	})
 }
+
+func (s *MmctlUnitTestSuite) TestDiffPermissionsCmd() {
+	s.Run("Show the permissions only one role has", func() {
+		printer.Clean()
+
+		roleA := &model.Role{Id: model.NewId(), Name: "role_a", Permissions: []string{"view", "edit", "delete"}}
+		roleB := &model.Role{Id: model.NewId(), Name: "role_b", Permissions: []string{"view", "create", "archive"}}
+
+		s.client.
+			EXPECT().
+			GetRoleByName(context.TODO(), roleA.Name).
+			Return(roleA, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetRoleByName(context.TODO(), roleB.Name).
+			Return(roleB, &model.Response{}, nil).
+			Times(1)
+
+		err := diffPermissionsCmdF(s.client, &cobra.Command{}, []string{roleA.Name, roleB.Name})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{&RolePermissionsDiff{
+			RoleA:   roleA.Name,
+			RoleB:   roleB.Name,
+			OnlyInA: []string{"delete", "edit"},
+			OnlyInB: []string{"archive", "create"},
+		}}, printer.GetLines())
+	})
+
+	s.Run("Roles with the same permissions", func() {
+		printer.Clean()
+		printer.SetFormat(printer.FormatPlain)
+		defer printer.SetFormat(printer.FormatJSON)
+
+		roleA := &model.Role{Id: model.NewId(), Name: "role_a", Permissions: []string{"view", "edit"}}
+		roleB := &model.Role{Id: model.NewId(), Name: "role_b", Permissions: []string{"edit", "view"}}
+
+		s.client.
+			EXPECT().
+			GetRoleByName(context.TODO(), roleA.Name).
+			Return(roleA, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetRoleByName(context.TODO(), roleB.Name).
+			Return(roleB, &model.Response{}, nil).
+			Times(1)
+
+		err := diffPermissionsCmdF(s.client, &cobra.Command{}, []string{roleA.Name, roleB.Name})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{"Roles role_a and role_b have the same permissions"}, printer.GetLines())
+	})
+
+	s.Run("Role not found", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetRoleByName(context.TODO(), "role_a").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("role_not_found")).
+			Times(1)
+
+		err := diffPermissionsCmdF(s.client, &cobra.Command{}, []string{"role_a", "role_b"})
+		s.Require().EqualError(err, `failed to get role "role_a": role_not_found`)
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+const draftTemplate = `{{postTime .UpdateAt}} in {{.ChannelId}}{{if .RootId}} (reply to {{.RootId}}){{end}}
+  {{.Message}}`
+
+var PostDraftCmd = &cobra.Command{
+	Use:   "draft",
+	Short: "Management of drafts",
+	Long:  "Management of the synced drafts of the user running the command.",
+}
+
+var PostDraftListCmd = &cobra.Command{
+	Use:     "list [team]",
+	Short:   "List drafts",
+	Long:    "List the drafts of the user running the command in the channels of a team, and in direct and group messages.",
+	Example: "  post draft list myteam",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(postDraftListCmdF),
+}
+
+var PostDraftDeleteCmd = &cobra.Command{
+	Use:   "delete [channel]",
+	Short: "Delete a draft",
+	Example: `  post draft delete myteam:mychannel
+  post draft delete myteam:mychannel --reply-to udjmt396tjghi8wnsk3a1qs1sw`,
+	Args: cobra.ExactArgs(1),
+	RunE: withClient(postDraftDeleteCmdF),
+}
+
+func init() {
+	PostDraftDeleteCmd.Flags().StringP("reply-to", "r", "", "Id of the thread of the draft")
+
+	PostDraftCmd.AddCommand(
+		PostDraftListCmd,
+		PostDraftDeleteCmd,
+	)
+
+	PostCmd.AddCommand(PostDraftCmd)
+}
+
+func postDraftListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	team := getTeamFromTeamArg(c, args[0])
+	if team == nil {
+		return errors.Errorf("unable to find team %q", args[0])
+	}
+
+	drafts, _, err := c.GetDrafts(context.TODO(), "me", team.Id)
+	if err != nil {
+		return errors.Wrap(err, "failed to list drafts")
+	}
+
+	setPostTimeTemplateFunc()
+	for _, draft := range drafts {
+		printer.PrintT(draftTemplate, draft)
+	}
+
+	return nil
+}
+
+func postDraftDeleteCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	replyTo, _ := cmd.Flags().GetString("reply-to")
+	if _, _, err := c.DeleteDraft(context.TODO(), "me", channel.Id, replyTo); err != nil {
+		return errors.Wrap(err, "failed to delete the draft")
+	}
+
+	printer.Print(fmt.Sprintf("Draft of channel %s successfully deleted", args[0]))
+	return nil
+}
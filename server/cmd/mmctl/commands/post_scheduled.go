@@ -0,0 +1,173 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+const scheduledPostTemplate = `{{.Id}}: {{postTime .ScheduledAt}} in {{.ChannelId}}{{if .RootId}} (reply to {{.RootId}}){{end}}{{if .ErrorCode}} [error: {{.ErrorCode}}]{{end}}
+  {{.Message}}`
+
+var PostScheduledCmd = &cobra.Command{
+	Use:   "scheduled",
+	Short: "Management of scheduled posts",
+	Long:  "Management of the scheduled posts of the user running the command.",
+}
+
+var PostScheduledListCmd = &cobra.Command{
+	Use:   "list [team]",
+	Short: "List scheduled posts",
+	Long:  "List the scheduled posts of the user running the command in the channels of a team, ordered by the time they will be posted.",
+	Example: `  post scheduled list myteam
+  post scheduled list myteam --include-direct-channels`,
+	Args: cobra.ExactArgs(1),
+	RunE: withClient(postScheduledListCmdF),
+}
+
+var PostScheduledCreateCmd = &cobra.Command{
+	Use:   "create [channel]",
+	Short: "Schedule a post",
+	Example: `  post scheduled create myteam:mychannel --message "some text for the post" --at 2024-01-31T09:00:00+01:00
+  post scheduled create myteam:mychannel --message "a reply" --reply-to udjmt396tjghi8wnsk3a1qs1sw --at 2024-01-31T09:00:00Z`,
+	Args: cobra.ExactArgs(1),
+	RunE: withClient(postScheduledCreateCmdF),
+}
+
+var PostScheduledDeleteCmd = &cobra.Command{
+	Use:     "delete [scheduled posts]",
+	Short:   "Delete scheduled posts",
+	Example: "  post scheduled delete udjmt396tjghi8wnsk3a1qs1sw 7jgcjt7tyjyyu83qz81wo84w6o",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(postScheduledDeleteCmdF),
+}
+
+func init() {
+	PostScheduledListCmd.Flags().Bool("include-direct-channels", false, "Also list the scheduled posts of direct and group messages")
+
+	PostScheduledCreateCmd.Flags().StringP("message", "m", "", "Message for the post")
+	PostScheduledCreateCmd.Flags().StringP("reply-to", "r", "", "Post id to reply to")
+	PostScheduledCreateCmd.Flags().String("at", "", "Time the post will be posted at (ISO 8601)")
+	_ = PostScheduledCreateCmd.MarkFlagRequired("at")
+
+	PostScheduledCmd.AddCommand(
+		PostScheduledListCmd,
+		PostScheduledCreateCmd,
+		PostScheduledDeleteCmd,
+	)
+
+	PostCmd.AddCommand(PostScheduledCmd)
+}
+
+func postScheduledListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	team := getTeamFromTeamArg(c, args[0])
+	if team == nil {
+		return errors.Errorf("unable to find team %q", args[0])
+	}
+
+	includeDirectChannels, _ := cmd.Flags().GetBool("include-direct-channels")
+	scheduledPostsByTeam, _, err := c.GetUserScheduledPosts(context.TODO(), team.Id, includeDirectChannels)
+	if err != nil {
+		return errors.Wrap(err, "failed to list scheduled posts")
+	}
+
+	var scheduledPosts []*model.ScheduledPost
+	for _, teamScheduledPosts := range scheduledPostsByTeam {
+		scheduledPosts = append(scheduledPosts, teamScheduledPosts...)
+	}
+	sort.SliceStable(scheduledPosts, func(i, j int) bool {
+		return scheduledPosts[i].ScheduledAt < scheduledPosts[j].ScheduledAt
+	})
+
+	setPostTimeTemplateFunc()
+	for _, scheduledPost := range scheduledPosts {
+		printer.PrintT(scheduledPostTemplate, scheduledPost)
+	}
+
+	return nil
+}
+
+func postScheduledCreateCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	message, _ := cmd.Flags().GetString("message")
+	if message == "" {
+		return errors.New("message cannot be empty")
+	}
+
+	at, _ := cmd.Flags().GetString("at")
+	scheduledAt, err := time.Parse(ISO8601Layout, at)
+	if err != nil {
+		return fmt.Errorf("invalid time '%s'", at)
+	}
+
+	replyTo, _ := cmd.Flags().GetString("reply-to")
+	if replyTo != "" {
+		replyToPost, _, err := c.GetPost(context.TODO(), replyTo, "")
+		if err != nil {
+			return err
+		}
+		if replyToPost.RootId != "" {
+			replyTo = replyToPost.RootId
+		}
+	}
+
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	scheduledPost := &model.ScheduledPost{
+		Draft: model.Draft{
+			ChannelId: channel.Id,
+			RootId:    replyTo,
+			Message:   message,
+		},
+		ScheduledAt: model.GetMillisForTime(scheduledAt),
+	}
+
+	created, _, err := c.CreateScheduledPost(context.TODO(), scheduledPost)
+	if err != nil {
+		return errors.Wrap(err, "failed to schedule the post")
+	}
+
+	setPostTimeTemplateFunc()
+	printer.PrintT(scheduledPostTemplate, created)
+	return nil
+}
+
+func postScheduledDeleteCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	var result *multierror.Error
+	for _, scheduledPostID := range args {
+		if !model.IsValidId(scheduledPostID) {
+			err := errors.Errorf("invalid scheduled post ID %q", scheduledPostID)
+			printer.PrintError(err.Error())
+			result = multierror.Append(result, err)
+			continue
+		}
+		if _, _, err := c.DeleteScheduledPost(context.TODO(), scheduledPostID); err != nil {
+			printer.PrintError(fmt.Sprintf("Error deleting scheduled post: %s. Error: %s", scheduledPostID, err.Error()))
+			result = multierror.Append(result, err)
+			continue
+		}
+		printer.Print(fmt.Sprintf("%s successfully deleted", scheduledPostID))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func setPostTimeTemplateFunc() {
+	printer.SetTemplateFunc("postTime", func(millis int64) string {
+		return model.GetTimeForMillis(millis).Format(PostTimeFormat)
+	})
+}
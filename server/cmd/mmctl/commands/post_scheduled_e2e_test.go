@@ -0,0 +1,134 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlE2ETestSuite) TestPostScheduledCmds() {
+	s.SetupTestHelper().InitBasic()
+	s.th.App.Srv().SetLicense(model.NewTestLicense())
+
+	channelArg := s.th.BasicTeam.Name + ":" + s.th.BasicChannel.Name
+
+	s.Run("create, list and delete scheduled posts", func() {
+		printer.Clean()
+
+		later := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+		sooner := time.Now().Add(time.Hour).Truncate(time.Second)
+
+		var created []*model.ScheduledPost
+		for _, at := range []time.Time{later, sooner} {
+			printer.Clean()
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("message", "scheduled at "+at.Format(ISO8601Layout), "")
+			cmd.Flags().String("reply-to", "", "")
+			cmd.Flags().String("at", at.Format(ISO8601Layout), "")
+
+			err := postScheduledCreateCmdF(s.th.Client, cmd, []string{channelArg})
+			s.Require().NoError(err)
+			s.Require().Len(printer.GetLines(), 1)
+
+			scheduledPost := printer.GetLines()[0].(*model.ScheduledPost)
+			s.Require().Equal(s.th.BasicChannel.Id, scheduledPost.ChannelId)
+			s.Require().Equal(s.th.BasicUser.Id, scheduledPost.UserId)
+			s.Require().Equal(model.GetMillisForTime(at), scheduledPost.ScheduledAt)
+			created = append(created, scheduledPost)
+		}
+
+		printer.Clean()
+		listCmd := &cobra.Command{}
+		listCmd.Flags().Bool("include-direct-channels", false, "")
+		err := postScheduledListCmdF(s.th.Client, listCmd, []string{s.th.BasicTeam.Name})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(created[1].Id, printer.GetLines()[0].(*model.ScheduledPost).Id, "the post scheduled sooner is listed first")
+		s.Require().Equal(created[0].Id, printer.GetLines()[1].(*model.ScheduledPost).Id)
+
+		printer.Clean()
+		err = postScheduledDeleteCmdF(s.th.Client, &cobra.Command{}, []string{created[0].Id, created[1].Id})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Len(printer.GetErrorLines(), 0)
+
+		scheduledPosts, _, err := s.th.Client.GetUserScheduledPosts(context.Background(), s.th.BasicTeam.Id, false)
+		s.Require().NoError(err)
+		s.Require().Empty(scheduledPosts[s.th.BasicTeam.Id])
+	})
+
+	s.Run("create with an invalid time", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("message", "some message", "")
+		cmd.Flags().String("reply-to", "", "")
+		cmd.Flags().String("at", "tomorrow", "")
+
+		err := postScheduledCreateCmdF(s.th.Client, cmd, []string{channelArg})
+		s.Require().EqualError(err, "invalid time 'tomorrow'")
+		s.Require().Len(printer.GetLines(), 0)
+	})
+
+	s.Run("delete scheduled posts of another user", func() {
+		printer.Clean()
+
+		scheduledPost, _, err := s.th.Client.CreateScheduledPost(context.Background(), &model.ScheduledPost{
+			Draft:       model.Draft{ChannelId: s.th.BasicChannel.Id, Message: "not yours"},
+			ScheduledAt: model.GetMillis() + time.Hour.Milliseconds(),
+		})
+		s.Require().NoError(err)
+
+		err = postScheduledDeleteCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{scheduledPost.Id})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetErrorLines(), 1)
+	})
+}
+
+func (s *MmctlE2ETestSuite) TestPostDraftCmds() {
+	s.SetupTestHelper().InitBasic()
+	s.th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.AllowSyncedDrafts = true })
+
+	channelArg := s.th.BasicTeam.Name + ":" + s.th.BasicChannel.Name
+	rootID := model.NewId()
+
+	for _, draft := range []*model.Draft{
+		{ChannelId: s.th.BasicChannel.Id, Message: "channel draft"},
+		{ChannelId: s.th.BasicChannel.Id, RootId: rootID, Message: "thread draft"},
+	} {
+		_, _, err := s.th.Client.UpsertDraft(context.Background(), draft)
+		s.Require().NoError(err)
+	}
+
+	s.Run("list drafts", func() {
+		printer.Clean()
+
+		err := postDraftListCmdF(s.th.Client, &cobra.Command{}, []string{s.th.BasicTeam.Name})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+	})
+
+	s.Run("delete the draft of a thread", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("reply-to", rootID, "")
+
+		err := postDraftDeleteCmdF(s.th.Client, cmd, []string{channelArg})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+
+		drafts, _, err := s.th.Client.GetDrafts(context.Background(), s.th.BasicUser.Id, s.th.BasicTeam.Id)
+		s.Require().NoError(err)
+		s.Require().Len(drafts, 1)
+		s.Require().Equal("channel draft", drafts[0].Message)
+	})
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+const remoteClusterTemplate = `{{.RemoteId}}: {{.DisplayName}} ({{.Name}}) {{if .SiteURL}}{{.SiteURL}} {{end}}[{{remoteStatus .}}]{{if .DeleteAt}} [deleted]{{end}}`
+
+var RemoteCmd = &cobra.Command{
+	Use:   "remote",
+	Short: "Management of secure connections",
+	Long:  "Management of the secure connections with remote Mattermost servers used to share channels.",
+}
+
+var RemoteListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List secure connections",
+	Example: "  remote list",
+	Args:    cobra.NoArgs,
+	RunE:    withClient(remoteListCmdF),
+}
+
+var RemoteCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a secure connection",
+	Long:  "Create a secure connection and print the invitation code and password to send to the administrator of the remote server.",
+	Example: `  remote create --name acme --display-name "ACME Inc."
+  remote create --name acme --default-team myteam --password "s3cr3t password"`,
+	Args: cobra.NoArgs,
+	RunE: withClient(remoteCreateCmdF),
+}
+
+var RemoteAcceptInviteCmd = &cobra.Command{
+	Use:     "accept-invite",
+	Short:   "Accept a secure connection invitation",
+	Long:    "Accept the invitation to a secure connection created by the administrator of a remote server.",
+	Example: `  remote accept-invite --name acme --invite "eyJyZW1vdGVfaWQiOi..." --password "s3cr3t password"`,
+	Args:    cobra.NoArgs,
+	RunE:    withClient(remoteAcceptInviteCmdF),
+}
+
+var RemotePingCmd = &cobra.Command{
+	Use:     "ping [remotes]",
+	Short:   "Ping secure connections",
+	Long:    "Ping secure connections right away and print whether they are online.",
+	Example: "  remote ping udjmt396tjghi8wnsk3a1qs1sw 7jgcjt7tyjyyu83qz81wo84w6o",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(remotePingCmdF),
+}
+
+var RemoteDeleteCmd = &cobra.Command{
+	Use:     "delete [remotes]",
+	Aliases: []string{"rm"},
+	Short:   "Delete secure connections",
+	Long:    "Delete secure connections. The channels shared with them stop being synchronized.",
+	Example: "  remote delete udjmt396tjghi8wnsk3a1qs1sw",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(remoteDeleteCmdF),
+}
+
+func init() {
+	RemoteListCmd.Flags().Bool("include-deleted", false, "Also list the deleted secure connections")
+
+	RemoteCreateCmd.Flags().String("name", "", "Name of the secure connection")
+	RemoteCreateCmd.Flags().String("display-name", "", "Display name of the secure connection. Defaults to the name")
+	RemoteCreateCmd.Flags().String("default-team", "", "Team where the channels shared by the remote are created")
+	RemoteCreateCmd.Flags().String("password", "", "Password of the invitation. A random password is generated if empty")
+	_ = RemoteCreateCmd.MarkFlagRequired("name")
+
+	RemoteAcceptInviteCmd.Flags().String("name", "", "Name of the secure connection")
+	RemoteAcceptInviteCmd.Flags().String("display-name", "", "Display name of the secure connection. Defaults to the name")
+	RemoteAcceptInviteCmd.Flags().String("default-team", "", "Team where the channels shared by the remote are created")
+	RemoteAcceptInviteCmd.Flags().String("invite", "", "Invitation code")
+	RemoteAcceptInviteCmd.Flags().String("password", "", "Password of the invitation")
+	_ = RemoteAcceptInviteCmd.MarkFlagRequired("name")
+	_ = RemoteAcceptInviteCmd.MarkFlagRequired("invite")
+	_ = RemoteAcceptInviteCmd.MarkFlagRequired("password")
+
+	RemoteCmd.AddCommand(
+		RemoteListCmd,
+		RemoteCreateCmd,
+		RemoteAcceptInviteCmd,
+		RemotePingCmd,
+		RemoteDeleteCmd,
+	)
+
+	RootCmd.AddCommand(RemoteCmd)
+}
+
+func remoteListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	includeDeleted, _ := cmd.Flags().GetBool("include-deleted")
+
+	remotes, err := getPages(func(page, numPerPage int, etag string) ([]*model.RemoteCluster, *model.Response, error) {
+		return c.GetRemoteClusters(context.TODO(), page, numPerPage, model.RemoteClusterQueryFilter{IncludeDeleted: includeDeleted})
+	}, DefaultPageSize)
+	if err != nil {
+		return errors.Wrap(err, "failed to list secure connections")
+	}
+
+	setRemoteStatusTemplateFunc()
+	for _, rc := range remotes {
+		printer.PrintT(remoteClusterTemplate, rc)
+	}
+
+	return nil
+}
+
+func remoteCreateCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	name, _ := cmd.Flags().GetString("name")
+	displayName, _ := cmd.Flags().GetString("display-name")
+	password, _ := cmd.Flags().GetString("password")
+
+	defaultTeamID, err := getRemoteDefaultTeamID(c, cmd)
+	if err != nil {
+		return err
+	}
+
+	rcWithInvite, _, err := c.CreateRemoteCluster(context.TODO(), &model.RemoteClusterWithPassword{
+		RemoteCluster: &model.RemoteCluster{
+			Name:          name,
+			DisplayName:   displayName,
+			DefaultTeamId: defaultTeamID,
+		},
+		Password: password,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create the secure connection")
+	}
+
+	printer.PrintT(`Secure connection {{.RemoteCluster.DisplayName}} ({{.RemoteCluster.RemoteId}}) successfully created
+Invitation code: {{.Invite}}{{if .Password}}
+Password: {{.Password}}{{end}}`, rcWithInvite)
+	return nil
+}
+
+func remoteAcceptInviteCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	name, _ := cmd.Flags().GetString("name")
+	displayName, _ := cmd.Flags().GetString("display-name")
+	invite, _ := cmd.Flags().GetString("invite")
+	password, _ := cmd.Flags().GetString("password")
+
+	defaultTeamID, err := getRemoteDefaultTeamID(c, cmd)
+	if err != nil {
+		return err
+	}
+
+	rc, _, err := c.RemoteClusterAcceptInvite(context.TODO(), &model.RemoteClusterAcceptInvite{
+		Name:          name,
+		DisplayName:   displayName,
+		DefaultTeamId: defaultTeamID,
+		Invite:        invite,
+		Password:      password,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to accept the invitation")
+	}
+
+	setRemoteStatusTemplateFunc()
+	printer.PrintT(remoteClusterTemplate, rc)
+	return nil
+}
+
+func remotePingCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	setRemoteStatusTemplateFunc()
+
+	var result *multierror.Error
+	for _, remoteID := range args {
+		rc, _, err := c.PingRemoteCluster(context.TODO(), remoteID)
+		if err != nil {
+			printer.PrintError(fmt.Sprintf("Error pinging secure connection: %s. Error: %s", remoteID, err.Error()))
+			result = multierror.Append(result, err)
+			continue
+		}
+		printer.PrintT(remoteClusterTemplate, rc)
+	}
+
+	return result.ErrorOrNil()
+}
+
+func remoteDeleteCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	var result *multierror.Error
+	for _, remoteID := range args {
+		if _, err := c.DeleteRemoteCluster(context.TODO(), remoteID); err != nil {
+			printer.PrintError(fmt.Sprintf("Error deleting secure connection: %s. Error: %s", remoteID, err.Error()))
+			result = multierror.Append(result, err)
+			continue
+		}
+		printer.Print(fmt.Sprintf("Secure connection %s successfully deleted", remoteID))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func getRemoteDefaultTeamID(c client.Client, cmd *cobra.Command) (string, error) {
+	teamArg, _ := cmd.Flags().GetString("default-team")
+	if teamArg == "" {
+		return "", nil
+	}
+
+	team := getTeamFromTeamArg(c, teamArg)
+	if team == nil {
+		return "", errors.Errorf("unable to find team %q", teamArg)
+	}
+	return team.Id, nil
+}
+
+func setRemoteStatusTemplateFunc() {
+	printer.SetTemplateFunc("remoteStatus", func(rc *model.RemoteCluster) string {
+		switch {
+		case !rc.IsConfirmed():
+			return "invitation pending"
+		case rc.IsOnline():
+			return "online"
+		default:
+			return "offline"
+		}
+	})
+}
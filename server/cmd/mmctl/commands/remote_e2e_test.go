@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlE2ETestSuite) TestRemoteCmds() {
+	s.setupSharedChannelsTestHelper()
+
+	s.Run("create, list and delete secure connections", func() {
+		printer.Clean()
+		c := s.th.SystemAdminClient
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", "acme"+model.NewId()[:8], "")
+		cmd.Flags().String("display-name", "ACME", "")
+		cmd.Flags().String("default-team", s.th.BasicTeam.Name, "")
+		cmd.Flags().String("password", "", "")
+
+		err := remoteCreateCmdF(c, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+
+		created := printer.GetLines()[0].(*model.RemoteClusterWithInvite)
+		s.Require().NotEmpty(created.Invite)
+		s.Require().NotEmpty(created.Password, "a password is generated when none is given")
+		s.Require().Equal(s.th.BasicTeam.Id, created.RemoteCluster.DefaultTeamId)
+
+		printer.Clean()
+		listCmd := &cobra.Command{}
+		listCmd.Flags().Bool("include-deleted", false, "")
+		err = remoteListCmdF(c, listCmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Contains(remoteIDs(printer.GetLines()), created.RemoteCluster.RemoteId)
+
+		printer.Clean()
+		err = remotePingCmdF(c, &cobra.Command{}, []string{created.RemoteCluster.RemoteId})
+		s.Require().Error(err, "secure connections can't be pinged before accepting the invitation")
+		s.Require().Len(printer.GetErrorLines(), 1)
+
+		printer.Clean()
+		err = remoteDeleteCmdF(c, &cobra.Command{}, []string{created.RemoteCluster.RemoteId})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+
+		printer.Clean()
+		err = remoteListCmdF(c, listCmd, []string{})
+		s.Require().NoError(err)
+		s.Require().NotContains(remoteIDs(printer.GetLines()), created.RemoteCluster.RemoteId)
+	})
+
+	s.Run("accept an invalid invitation", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", "acme", "")
+		cmd.Flags().String("invite", "not an invitation", "")
+		cmd.Flags().String("password", "password", "")
+
+		err := remoteAcceptInviteCmdF(s.th.SystemAdminClient, cmd, []string{})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+
+	s.Run("list without permissions", func() {
+		printer.Clean()
+
+		listCmd := &cobra.Command{}
+		listCmd.Flags().Bool("include-deleted", false, "")
+		err := remoteListCmdF(s.th.Client, listCmd, []string{})
+		s.Require().Error(err)
+	})
+}
+
+func remoteIDs(lines []any) []string {
+	ids := make([]string, 0, len(lines))
+	for _, line := range lines {
+		ids = append(ids, line.(*model.RemoteCluster).RemoteId)
+	}
+	return ids
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestRemoteListCmd() {
+	s.Run("list all the pages of secure connections", func() {
+		printer.Clean()
+
+		rc := &model.RemoteCluster{RemoteId: model.NewId(), Name: "acme"}
+		filter := model.RemoteClusterQueryFilter{IncludeDeleted: true}
+
+		s.client.
+			EXPECT().
+			GetRemoteClusters(context.TODO(), 0, DefaultPageSize, filter).
+			Return([]*model.RemoteCluster{rc}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetRemoteClusters(context.TODO(), 1, DefaultPageSize, filter).
+			Return([]*model.RemoteCluster{}, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("include-deleted", true, "")
+
+		err := remoteListCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{rc}, printer.GetLines())
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestRemoteCreateCmd() {
+	s.Run("create with a default team", func() {
+		printer.Clean()
+
+		team := &model.Team{Id: model.NewId(), Name: "myteam"}
+		created := &model.RemoteClusterWithInvite{
+			RemoteCluster: &model.RemoteCluster{RemoteId: model.NewId(), Name: "acme"},
+			Invite:        "invite",
+			Password:      "generated",
+		}
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), team.Name, "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), team.Name, "").
+			Return(team, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateRemoteCluster(context.TODO(), &model.RemoteClusterWithPassword{
+				RemoteCluster: &model.RemoteCluster{Name: "acme", DefaultTeamId: team.Id},
+			}).
+			Return(created, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", "acme", "")
+		cmd.Flags().String("display-name", "", "")
+		cmd.Flags().String("default-team", team.Name, "")
+		cmd.Flags().String("password", "", "")
+
+		err := remoteCreateCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{created}, printer.GetLines())
+	})
+
+	s.Run("default team not found", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), "unknown", "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), "unknown", "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", "acme", "")
+		cmd.Flags().String("default-team", "unknown", "")
+
+		err := remoteCreateCmdF(s.client, cmd, []string{})
+		s.Require().EqualError(err, `unable to find team "unknown"`)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestRemotePingCmd() {
+	s.Run("ping every remote and report failures", func() {
+		printer.Clean()
+
+		rc := &model.RemoteCluster{RemoteId: model.NewId(), Name: "acme", LastPingAt: model.GetMillis()}
+		unknownID := model.NewId()
+
+		s.client.
+			EXPECT().
+			PingRemoteCluster(context.TODO(), rc.RemoteId).
+			Return(rc, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			PingRemoteCluster(context.TODO(), unknownID).
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+
+		err := remotePingCmdF(s.client, &cobra.Command{}, []string{rc.RemoteId, unknownID})
+		s.Require().Error(err)
+		s.Require().Equal([]any{rc}, printer.GetLines())
+		s.Require().Len(printer.GetErrorLines(), 1)
+	})
+}
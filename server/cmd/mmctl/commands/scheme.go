@@ -0,0 +1,302 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+const schemeTemplate = `{{.Id}}: {{.DisplayName}} ({{.Name}}) [{{.Scope}}]`
+
+const schemeDetailTemplate = `Id: {{.Id}}
+Name: {{.Name}}
+Display name: {{.DisplayName}}
+Description: {{.Description}}
+Scope: {{.Scope}}{{if eq .Scope "team"}}
+Team admin role: {{.DefaultTeamAdminRole}}
+Team user role: {{.DefaultTeamUserRole}}
+Team guest role: {{.DefaultTeamGuestRole}}{{end}}
+Channel admin role: {{.DefaultChannelAdminRole}}
+Channel user role: {{.DefaultChannelUserRole}}
+Channel guest role: {{.DefaultChannelGuestRole}}`
+
+var SchemeCmd = &cobra.Command{
+	Use:   "scheme",
+	Short: "Management of permission schemes",
+	Long:  "Management of the permission schemes that override the system permissions in teams and channels.",
+}
+
+var SchemeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List permission schemes",
+	Example: `  scheme list
+  scheme list --scope channel`,
+	Args: cobra.NoArgs,
+	RunE: withClient(schemeListCmdF),
+}
+
+var SchemeShowCmd = &cobra.Command{
+	Use:     "show [scheme]",
+	Short:   "Show a permission scheme",
+	Long:    "Show the roles of a permission scheme, and the teams or channels it is assigned to.",
+	Example: "  scheme show engineering",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(schemeShowCmdF),
+}
+
+var SchemeCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a permission scheme",
+	Long:  "Create a permission scheme. Its roles start with the permissions of the system scheme.",
+	Example: `  scheme create --name engineering --display-name "Engineering"
+  scheme create --name announcements --display-name "Announcements" --scope channel`,
+	Args: cobra.NoArgs,
+	RunE: withClient(schemeCreateCmdF),
+}
+
+var SchemeDeleteCmd = &cobra.Command{
+	Use:     "delete [schemes]",
+	Aliases: []string{"rm"},
+	Short:   "Delete permission schemes",
+	Long:    "Delete permission schemes. The teams and channels they are assigned to go back to the permissions of the higher scope.",
+	Example: "  scheme delete engineering",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(schemeDeleteCmdF),
+}
+
+var SchemeAssignTeamCmd = &cobra.Command{
+	Use:     "assign-team [scheme] [teams]",
+	Short:   "Assign a team scheme to teams",
+	Example: "  scheme assign-team engineering myteam otherteam",
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    withClient(schemeAssignTeamCmdF),
+}
+
+var SchemeAssignChannelCmd = &cobra.Command{
+	Use:     "assign-channel [scheme] [channels]",
+	Short:   "Assign a channel scheme to channels",
+	Example: "  scheme assign-channel announcements myteam:town-square myteam:news",
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    withClient(schemeAssignChannelCmdF),
+}
+
+func init() {
+	SchemeListCmd.Flags().String("scope", "", "Only list the schemes of a scope, team or channel")
+
+	SchemeCreateCmd.Flags().String("name", "", "Name of the scheme")
+	SchemeCreateCmd.Flags().String("display-name", "", "Display name of the scheme")
+	SchemeCreateCmd.Flags().String("description", "", "Description of the scheme")
+	SchemeCreateCmd.Flags().String("scope", model.SchemeScopeTeam, "Scope of the scheme, team or channel")
+	_ = SchemeCreateCmd.MarkFlagRequired("name")
+	_ = SchemeCreateCmd.MarkFlagRequired("display-name")
+
+	SchemeCmd.AddCommand(
+		SchemeListCmd,
+		SchemeShowCmd,
+		SchemeCreateCmd,
+		SchemeDeleteCmd,
+		SchemeAssignTeamCmd,
+		SchemeAssignChannelCmd,
+	)
+
+	RootCmd.AddCommand(SchemeCmd)
+}
+
+func schemeListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	scope, _ := cmd.Flags().GetString("scope")
+	if err := validateSchemeScope(scope); scope != "" && err != nil {
+		return err
+	}
+
+	schemes, err := getPages(func(page, numPerPage int, etag string) ([]*model.Scheme, *model.Response, error) {
+		return c.GetSchemes(context.TODO(), scope, page, numPerPage)
+	}, DefaultPageSize)
+	if err != nil {
+		return errors.Wrap(err, "failed to list schemes")
+	}
+
+	for _, scheme := range schemes {
+		printer.PrintT(schemeTemplate, scheme)
+	}
+
+	return nil
+}
+
+func schemeShowCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	scheme, err := getSchemeFromArg(c, args[0])
+	if err != nil {
+		return err
+	}
+
+	printer.PrintT(schemeDetailTemplate, scheme)
+
+	switch scheme.Scope {
+	case model.SchemeScopeTeam:
+		teams, err := getPages(func(page, numPerPage int, etag string) ([]*model.Team, *model.Response, error) {
+			return c.GetTeamsForScheme(context.TODO(), scheme.Id, page, numPerPage)
+		}, DefaultPageSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the teams of the scheme")
+		}
+		for _, team := range teams {
+			printer.PrintT("Team: {{.Name}} ({{.Id}})", team)
+		}
+	case model.SchemeScopeChannel:
+		channels, err := getPages(func(page, numPerPage int, etag string) ([]*model.Channel, *model.Response, error) {
+			return c.GetChannelsForScheme(context.TODO(), scheme.Id, page, numPerPage)
+		}, DefaultPageSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the channels of the scheme")
+		}
+		for _, channel := range channels {
+			printer.PrintT("Channel: {{.Name}} ({{.Id}})", channel)
+		}
+	}
+
+	return nil
+}
+
+func schemeCreateCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	name, _ := cmd.Flags().GetString("name")
+	displayName, _ := cmd.Flags().GetString("display-name")
+	description, _ := cmd.Flags().GetString("description")
+	scope, _ := cmd.Flags().GetString("scope")
+
+	if err := validateSchemeScope(scope); err != nil {
+		return err
+	}
+
+	scheme, _, err := c.CreateScheme(context.TODO(), &model.Scheme{
+		Name:        name,
+		DisplayName: displayName,
+		Description: description,
+		Scope:       scope,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create the scheme")
+	}
+
+	printer.PrintT(schemeTemplate, scheme)
+	return nil
+}
+
+func schemeDeleteCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	var result *multierror.Error
+	for _, arg := range args {
+		scheme, err := getSchemeFromArg(c, arg)
+		if err != nil {
+			printer.PrintError(err.Error())
+			result = multierror.Append(result, err)
+			continue
+		}
+
+		if _, err := c.DeleteScheme(context.TODO(), scheme.Id); err != nil {
+			printer.PrintError(fmt.Sprintf("Error deleting scheme: %s. Error: %s", arg, err.Error()))
+			result = multierror.Append(result, err)
+			continue
+		}
+		printer.Print(fmt.Sprintf("Scheme %s successfully deleted", arg))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func schemeAssignTeamCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	scheme, err := getSchemeFromArg(c, args[0])
+	if err != nil {
+		return err
+	}
+	if scheme.Scope != model.SchemeScopeTeam {
+		return errors.Errorf("scheme %q is not a team scheme", args[0])
+	}
+
+	var result *multierror.Error
+	teams := getTeamsFromTeamArgs(c, args[1:])
+	for i, team := range teams {
+		if team == nil {
+			err := errors.Errorf("unable to find team %q", args[i+1])
+			printer.PrintError(err.Error())
+			result = multierror.Append(result, err)
+			continue
+		}
+
+		if _, err := c.UpdateTeamScheme(context.TODO(), team.Id, scheme.Id); err != nil {
+			printer.PrintError(fmt.Sprintf("Error assigning the scheme to team %s. Error: %s", args[i+1], err.Error()))
+			result = multierror.Append(result, err)
+			continue
+		}
+		printer.Print(fmt.Sprintf("Scheme %s successfully assigned to team %s", args[0], args[i+1]))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func schemeAssignChannelCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	scheme, err := getSchemeFromArg(c, args[0])
+	if err != nil {
+		return err
+	}
+	if scheme.Scope != model.SchemeScopeChannel {
+		return errors.Errorf("scheme %q is not a channel scheme", args[0])
+	}
+
+	var result *multierror.Error
+	channels := getChannelsFromChannelArgs(c, args[1:])
+	for i, channel := range channels {
+		if channel == nil {
+			err := errors.Errorf("unable to find channel %q", args[i+1])
+			printer.PrintError(err.Error())
+			result = multierror.Append(result, err)
+			continue
+		}
+
+		if _, err := c.UpdateChannelScheme(context.TODO(), channel.Id, scheme.Id); err != nil {
+			printer.PrintError(fmt.Sprintf("Error assigning the scheme to channel %s. Error: %s", args[i+1], err.Error()))
+			result = multierror.Append(result, err)
+			continue
+		}
+		printer.Print(fmt.Sprintf("Scheme %s successfully assigned to channel %s", args[0], args[i+1]))
+	}
+
+	return result.ErrorOrNil()
+}
+
+// getSchemeFromArg finds a scheme by its id, or else by its name.
+func getSchemeFromArg(c client.Client, schemeArg string) (*model.Scheme, error) {
+	if model.IsValidId(schemeArg) {
+		if scheme, _, err := c.GetScheme(context.TODO(), schemeArg); err == nil {
+			return scheme, nil
+		}
+	}
+
+	schemes, err := getPages(func(page, numPerPage int, etag string) ([]*model.Scheme, *model.Response, error) {
+		return c.GetSchemes(context.TODO(), "", page, numPerPage)
+	}, DefaultPageSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get schemes")
+	}
+
+	for _, scheme := range schemes {
+		if scheme.Name == schemeArg {
+			return scheme, nil
+		}
+	}
+	return nil, errors.Errorf("unable to find scheme %q", schemeArg)
+}
+
+func validateSchemeScope(scope string) error {
+	if scope != model.SchemeScopeTeam && scope != model.SchemeScopeChannel {
+		return errors.Errorf("invalid scope %q, must be team or channel", scope)
+	}
+	return nil
+}
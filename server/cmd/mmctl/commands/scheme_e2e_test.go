@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlE2ETestSuite) TestSchemeCmds() {
+	s.SetupTestHelper().InitBasic()
+	s.th.App.Srv().SetLicense(model.NewTestLicense("custom_permissions_schemes"))
+
+	createCmd := func(name, scope string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", name, "")
+		cmd.Flags().String("display-name", name, "")
+		cmd.Flags().String("description", "", "")
+		cmd.Flags().String("scope", scope, "")
+		return cmd
+	}
+
+	s.Run("should not allow a normal user to create a scheme", func() {
+		printer.Clean()
+
+		err := schemeCreateCmdF(s.th.Client, createCmd("forbidden", model.SchemeScopeTeam), []string{})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+
+	s.Run("create, assign and delete a team scheme", func() {
+		printer.Clean()
+
+		err := schemeCreateCmdF(s.th.SystemAdminClient, createCmd("engineering", model.SchemeScopeTeam), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		scheme := printer.GetLines()[0].(*model.Scheme)
+
+		printer.Clean()
+		err = schemeAssignTeamCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{scheme.Name, s.th.BasicTeam.Name})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+
+		team, appErr := s.th.App.GetTeam(s.th.BasicTeam.Id)
+		s.Require().Nil(appErr)
+		s.Require().NotNil(team.SchemeId)
+		s.Require().Equal(scheme.Id, *team.SchemeId)
+
+		printer.Clean()
+		err = schemeShowCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{scheme.Name})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(s.th.BasicTeam.Id, printer.GetLines()[1].(*model.Team).Id)
+
+		printer.Clean()
+		err = schemeDeleteCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{scheme.Name})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+
+		_, appErr = s.th.App.GetScheme(scheme.Id)
+		s.Require().NotNil(appErr)
+	})
+
+	s.Run("assign a channel scheme to a channel", func() {
+		printer.Clean()
+
+		err := schemeCreateCmdF(s.th.SystemAdminClient, createCmd("announcements", model.SchemeScopeChannel), []string{})
+		s.Require().NoError(err)
+		scheme := printer.GetLines()[0].(*model.Scheme)
+
+		printer.Clean()
+		err = schemeAssignChannelCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{scheme.Id, s.th.BasicChannel.Id})
+		s.Require().NoError(err)
+
+		channel, appErr := s.th.App.GetChannel(s.th.Context, s.th.BasicChannel.Id)
+		s.Require().Nil(appErr)
+		s.Require().NotNil(channel.SchemeId)
+		s.Require().Equal(scheme.Id, *channel.SchemeId)
+
+		printer.Clean()
+		err = schemeAssignTeamCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{scheme.Id, s.th.BasicTeam.Id})
+		s.Require().Error(err)
+	})
+}
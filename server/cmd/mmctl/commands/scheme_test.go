@@ -0,0 +1,278 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestSchemeListCmd() {
+	s.Run("list the schemes of a scope", func() {
+		printer.Clean()
+
+		scheme := &model.Scheme{Id: model.NewId(), Name: "announcements", Scope: model.SchemeScopeChannel}
+
+		s.client.
+			EXPECT().
+			GetSchemes(context.TODO(), model.SchemeScopeChannel, 0, DefaultPageSize).
+			Return([]*model.Scheme{scheme}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetSchemes(context.TODO(), model.SchemeScopeChannel, 1, DefaultPageSize).
+			Return([]*model.Scheme{}, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("scope", model.SchemeScopeChannel, "")
+
+		err := schemeListCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{scheme}, printer.GetLines())
+	})
+
+	s.Run("invalid scope", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("scope", "system", "")
+
+		err := schemeListCmdF(s.client, cmd, []string{})
+		s.Require().EqualError(err, `invalid scope "system", must be team or channel`)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestSchemeShowCmd() {
+	s.Run("show a team scheme and its teams", func() {
+		printer.Clean()
+
+		scheme := &model.Scheme{Id: model.NewId(), Name: "engineering", Scope: model.SchemeScopeTeam}
+		team := &model.Team{Id: model.NewId(), Name: "myteam"}
+
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), scheme.Id).
+			Return(scheme, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetTeamsForScheme(context.TODO(), scheme.Id, 0, DefaultPageSize).
+			Return([]*model.Team{team}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetTeamsForScheme(context.TODO(), scheme.Id, 1, DefaultPageSize).
+			Return([]*model.Team{}, &model.Response{}, nil).
+			Times(1)
+
+		err := schemeShowCmdF(s.client, &cobra.Command{}, []string{scheme.Id})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{scheme, team}, printer.GetLines())
+	})
+
+	s.Run("find a channel scheme by name", func() {
+		printer.Clean()
+
+		other := &model.Scheme{Id: model.NewId(), Name: "engineering", Scope: model.SchemeScopeTeam}
+		scheme := &model.Scheme{Id: model.NewId(), Name: "announcements", Scope: model.SchemeScopeChannel}
+		channel := &model.Channel{Id: model.NewId(), Name: "news"}
+
+		s.client.
+			EXPECT().
+			GetSchemes(context.TODO(), "", 0, DefaultPageSize).
+			Return([]*model.Scheme{other, scheme}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetSchemes(context.TODO(), "", 1, DefaultPageSize).
+			Return([]*model.Scheme{}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetChannelsForScheme(context.TODO(), scheme.Id, 0, DefaultPageSize).
+			Return(model.ChannelList{channel}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetChannelsForScheme(context.TODO(), scheme.Id, 1, DefaultPageSize).
+			Return(model.ChannelList{}, &model.Response{}, nil).
+			Times(1)
+
+		err := schemeShowCmdF(s.client, &cobra.Command{}, []string{scheme.Name})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{scheme, channel}, printer.GetLines())
+	})
+
+	s.Run("scheme not found", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetSchemes(context.TODO(), "", 0, DefaultPageSize).
+			Return([]*model.Scheme{}, &model.Response{}, nil).
+			Times(1)
+
+		err := schemeShowCmdF(s.client, &cobra.Command{}, []string{"missing"})
+		s.Require().EqualError(err, `unable to find scheme "missing"`)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestSchemeCreateCmd() {
+	s.Run("create a team scheme", func() {
+		printer.Clean()
+
+		expected := &model.Scheme{Name: "engineering", DisplayName: "Engineering", Scope: model.SchemeScopeTeam}
+		created := &model.Scheme{Id: model.NewId(), Name: expected.Name, DisplayName: expected.DisplayName, Scope: expected.Scope}
+
+		s.client.
+			EXPECT().
+			CreateScheme(context.TODO(), expected).
+			Return(created, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", "engineering", "")
+		cmd.Flags().String("display-name", "Engineering", "")
+		cmd.Flags().String("description", "", "")
+		cmd.Flags().String("scope", model.SchemeScopeTeam, "")
+
+		err := schemeCreateCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{created}, printer.GetLines())
+	})
+
+	s.Run("server error", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			CreateScheme(context.TODO(), &model.Scheme{Name: "engineering", DisplayName: "Engineering", Scope: model.SchemeScopeChannel}).
+			Return(nil, &model.Response{StatusCode: http.StatusNotImplemented}, errors.New("license required")).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", "engineering", "")
+		cmd.Flags().String("display-name", "Engineering", "")
+		cmd.Flags().String("description", "", "")
+		cmd.Flags().String("scope", model.SchemeScopeChannel, "")
+
+		err := schemeCreateCmdF(s.client, cmd, []string{})
+		s.Require().EqualError(err, "failed to create the scheme: license required")
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestSchemeDeleteCmd() {
+	s.Run("keep deleting after a failure", func() {
+		printer.Clean()
+
+		failing := &model.Scheme{Id: model.NewId(), Name: "failing"}
+		scheme := &model.Scheme{Id: model.NewId(), Name: "engineering"}
+
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), failing.Id).
+			Return(failing, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DeleteScheme(context.TODO(), failing.Id).
+			Return(&model.Response{StatusCode: http.StatusInternalServerError}, errors.New("internal error")).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), scheme.Id).
+			Return(scheme, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DeleteScheme(context.TODO(), scheme.Id).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := schemeDeleteCmdF(s.client, &cobra.Command{}, []string{failing.Id, scheme.Id})
+		s.Require().Error(err)
+		s.Require().Equal([]any{"Scheme " + scheme.Id + " successfully deleted"}, printer.GetLines())
+		s.Require().Equal([]string{"Error deleting scheme: " + failing.Id + ". Error: internal error"}, printer.GetErrorLines())
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestSchemeAssignTeamCmd() {
+	scheme := &model.Scheme{Id: model.NewId(), Name: "engineering", Scope: model.SchemeScopeTeam}
+	team := &model.Team{Id: model.NewId(), Name: "myteam"}
+
+	s.Run("assign the scheme to a team", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), scheme.Id).
+			Return(scheme, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), team.Id, "").
+			Return(team, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UpdateTeamScheme(context.TODO(), team.Id, scheme.Id).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := schemeAssignTeamCmdF(s.client, &cobra.Command{}, []string{scheme.Id, team.Id})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{"Scheme " + scheme.Id + " successfully assigned to team " + team.Id}, printer.GetLines())
+	})
+
+	s.Run("channel schemes can't be assigned to teams", func() {
+		printer.Clean()
+
+		channelScheme := &model.Scheme{Id: model.NewId(), Name: "announcements", Scope: model.SchemeScopeChannel}
+
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), channelScheme.Id).
+			Return(channelScheme, &model.Response{}, nil).
+			Times(1)
+
+		err := schemeAssignTeamCmdF(s.client, &cobra.Command{}, []string{channelScheme.Id, team.Id})
+		s.Require().EqualError(err, `scheme "`+channelScheme.Id+`" is not a team scheme`)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestSchemeAssignChannelCmd() {
+	scheme := &model.Scheme{Id: model.NewId(), Name: "announcements", Scope: model.SchemeScopeChannel}
+	channel := &model.Channel{Id: model.NewId(), Name: "news"}
+
+	s.Run("assign the scheme to a channel", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), scheme.Id).
+			Return(scheme, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UpdateChannelScheme(context.TODO(), channel.Id, scheme.Id).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := schemeAssignChannelCmdF(s.client, &cobra.Command{}, []string{scheme.Id, channel.Id})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{"Scheme " + scheme.Id + " successfully assigned to channel " + channel.Id}, printer.GetLines())
+	})
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+const sharedChannelTemplate = `{{.ChannelId}}: {{.ShareDisplayName}} ({{.ShareName}}){{if .ReadOnly}} [read-only]{{end}}{{if not .Home}} [from remote {{.RemoteId}}]{{end}}`
+
+const sharedChannelSyncStatusTemplate = `{{.RemoteId}}: {{.DisplayName}} [{{if .IsOnline}}online{{else}}offline{{end}}{{if not .IsInviteAccepted}}, invitation pending{{end}}]
+  Last sync: {{syncTime .LastSyncAt}}
+  Pending attachments: {{.PendingAttachments}}{{if .LastSyncError}}
+  Last error: {{syncTime .LastSyncErrorAt}}: {{.LastSyncError}}{{end}}`
+
+var SharedChannelCmd = &cobra.Command{
+	Use:   "sharedchannel",
+	Short: "Management of shared channels",
+	Long:  "Management of the channels shared with remote Mattermost servers through secure connections.",
+}
+
+var SharedChannelShareCmd = &cobra.Command{
+	Use:   "share [channel]",
+	Short: "Share a channel",
+	Long:  "Share a channel, and optionally invite secure connections to it. The share name, display name, purpose and header default to the ones of the channel.",
+	Example: `  sharedchannel share myteam:mychannel
+  sharedchannel share myteam:mychannel --readonly --remote udjmt396tjghi8wnsk3a1qs1sw`,
+	Args: cobra.ExactArgs(1),
+	RunE: withClient(sharedChannelShareCmdF),
+}
+
+var SharedChannelUnshareCmd = &cobra.Command{
+	Use:   "unshare [channel]",
+	Short: "Unshare a channel",
+	Long:  "Stop sharing a channel with all secure connections, or only with the given ones.",
+	Example: `  sharedchannel unshare myteam:mychannel
+  sharedchannel unshare myteam:mychannel --remote udjmt396tjghi8wnsk3a1qs1sw`,
+	Args: cobra.ExactArgs(1),
+	RunE: withClient(sharedChannelUnshareCmdF),
+}
+
+var SharedChannelListCmd = &cobra.Command{
+	Use:     "list [team]",
+	Short:   "List the shared channels of a team",
+	Example: "  sharedchannel list myteam",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(sharedChannelListCmdF),
+}
+
+var SharedChannelStatusCmd = &cobra.Command{
+	Use:   "status [channel]",
+	Short: "Show the synchronization status of a shared channel",
+	Long: `Show, for each secure connection a channel is shared with, the time of the last synchronized post, the number of
+attachments that haven't been sent yet and the error of the last failed synchronization.`,
+	Example: "  sharedchannel status myteam:mychannel",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(sharedChannelStatusCmdF),
+}
+
+func init() {
+	SharedChannelShareCmd.Flags().Bool("readonly", false, "Share the channel as read-only for the remotes")
+	SharedChannelShareCmd.Flags().String("name", "", "Share name of the channel")
+	SharedChannelShareCmd.Flags().String("display-name", "", "Share display name of the channel")
+	SharedChannelShareCmd.Flags().String("purpose", "", "Share purpose of the channel")
+	SharedChannelShareCmd.Flags().String("header", "", "Share header of the channel")
+	SharedChannelShareCmd.Flags().StringSlice("remote", nil, "Ids of the secure connections to invite to the channel")
+
+	SharedChannelUnshareCmd.Flags().StringSlice("remote", nil, "Ids of the secure connections to stop sharing the channel with")
+
+	SharedChannelCmd.AddCommand(
+		SharedChannelShareCmd,
+		SharedChannelUnshareCmd,
+		SharedChannelListCmd,
+		SharedChannelStatusCmd,
+	)
+
+	RootCmd.AddCommand(SharedChannelCmd)
+}
+
+func sharedChannelShareCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	readOnly, _ := cmd.Flags().GetBool("readonly")
+	name, _ := cmd.Flags().GetString("name")
+	displayName, _ := cmd.Flags().GetString("display-name")
+	purpose, _ := cmd.Flags().GetString("purpose")
+	header, _ := cmd.Flags().GetString("header")
+	remoteIDs, _ := cmd.Flags().GetStringSlice("remote")
+
+	sc, _, err := c.ShareChannel(context.TODO(), &model.SharedChannel{
+		ChannelId:        channel.Id,
+		ReadOnly:         readOnly,
+		ShareName:        name,
+		ShareDisplayName: displayName,
+		SharePurpose:     purpose,
+		ShareHeader:      header,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to share channel %q", args[0])
+	}
+	printer.PrintT(sharedChannelTemplate, sc)
+
+	var result *multierror.Error
+	for _, remoteID := range remoteIDs {
+		if _, err := c.InviteRemoteClusterToChannel(context.TODO(), remoteID, channel.Id); err != nil {
+			printer.PrintError(fmt.Sprintf("Error inviting secure connection %s to the channel. Error: %s", remoteID, err.Error()))
+			result = multierror.Append(result, err)
+			continue
+		}
+		printer.Print(fmt.Sprintf("Secure connection %s successfully invited", remoteID))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func sharedChannelUnshareCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	remoteIDs, _ := cmd.Flags().GetStringSlice("remote")
+	if len(remoteIDs) == 0 {
+		if _, err := c.UnshareChannel(context.TODO(), channel.Id); err != nil {
+			return errors.Wrapf(err, "failed to unshare channel %q", args[0])
+		}
+		printer.Print(fmt.Sprintf("Channel %s successfully unshared", args[0]))
+		return nil
+	}
+
+	var result *multierror.Error
+	for _, remoteID := range remoteIDs {
+		if _, err := c.UninviteRemoteClusterToChannel(context.TODO(), remoteID, channel.Id); err != nil {
+			printer.PrintError(fmt.Sprintf("Error uninviting secure connection %s from the channel. Error: %s", remoteID, err.Error()))
+			result = multierror.Append(result, err)
+			continue
+		}
+		printer.Print(fmt.Sprintf("Secure connection %s successfully uninvited", remoteID))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func sharedChannelListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	team := getTeamFromTeamArg(c, args[0])
+	if team == nil {
+		return errors.Errorf("unable to find team %q", args[0])
+	}
+
+	sharedChannels, err := getPages(func(page, numPerPage int, etag string) ([]*model.SharedChannel, *model.Response, error) {
+		return c.GetAllSharedChannels(context.TODO(), team.Id, page, numPerPage)
+	}, DefaultPageSize)
+	if err != nil {
+		return errors.Wrap(err, "failed to list shared channels")
+	}
+
+	for _, sc := range sharedChannels {
+		printer.PrintT(sharedChannelTemplate, sc)
+	}
+
+	return nil
+}
+
+func sharedChannelStatusCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	status, _, err := c.GetSharedChannelSyncStatus(context.TODO(), channel.Id)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the synchronization status of channel %q", args[0])
+	}
+
+	printer.SetTemplateFunc("syncTime", func(millis int64) string {
+		if millis == 0 {
+			return "never"
+		}
+		return model.GetTimeForMillis(millis).Format(PostTimeFormat)
+	})
+	for _, remoteStatus := range status {
+		printer.PrintT(sharedChannelSyncStatusTemplate, remoteStatus)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/api4"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlE2ETestSuite) setupSharedChannelsTestHelper() *api4.TestHelper {
+	s.th = api4.SetupConfig(s.T(), func(cfg *model.Config) {
+		*cfg.ConnectedWorkspacesSettings.EnableRemoteClusterService = true
+		*cfg.ConnectedWorkspacesSettings.EnableSharedChannels = true
+	}).InitBasic()
+	s.th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.SiteURL = fmt.Sprintf("http://localhost:%d", s.th.Server.ListenAddr.Port)
+	})
+	return s.th
+}
+
+func (s *MmctlE2ETestSuite) TestSharedChannelCmds() {
+	s.setupSharedChannelsTestHelper()
+
+	channelArg := s.th.BasicTeam.Name + ":" + s.th.BasicChannel.Name
+
+	s.Run("share, list, show the status of and unshare a channel", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("readonly", true, "")
+		cmd.Flags().String("display-name", "Shared Channel", "")
+		cmd.Flags().StringSlice("remote", nil, "")
+
+		err := sharedChannelShareCmdF(s.th.SystemAdminClient, cmd, []string{channelArg})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+
+		sc := printer.GetLines()[0].(*model.SharedChannel)
+		s.Require().Equal(s.th.BasicChannel.Id, sc.ChannelId)
+		s.Require().Equal(s.th.BasicChannel.Name, sc.ShareName)
+		s.Require().Equal("Shared Channel", sc.ShareDisplayName)
+		s.Require().True(sc.ReadOnly)
+
+		printer.Clean()
+		err = sharedChannelListCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{s.th.BasicTeam.Name})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(s.th.BasicChannel.Id, printer.GetLines()[0].(*model.SharedChannel).ChannelId)
+
+		rc, appErr := s.th.App.AddRemoteCluster(&model.RemoteCluster{Name: "globex", DisplayName: "Globex", SiteURL: "http://example.com", CreatorId: s.th.SystemAdminUser.Id})
+		s.Require().Nil(appErr)
+		_, err = s.th.App.SaveSharedChannelRemote(&model.SharedChannelRemote{
+			ChannelId:        s.th.BasicChannel.Id,
+			CreatorId:        s.th.SystemAdminUser.Id,
+			RemoteId:         rc.RemoteId,
+			IsInviteAccepted: true,
+		})
+		s.Require().NoError(err)
+		s.Require().NoError(s.th.App.Srv().Store().SharedChannel().UpdateRemoteSyncError(s.th.BasicChannel.Id, rc.RemoteId, "remote unreachable"))
+
+		printer.Clean()
+		err = sharedChannelStatusCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{channelArg})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+
+		status := printer.GetLines()[0].(*model.SharedChannelRemoteSyncStatus)
+		s.Require().Equal(rc.RemoteId, status.RemoteId)
+		s.Require().Equal("Globex", status.DisplayName)
+		s.Require().Equal("remote unreachable", status.LastSyncError)
+		s.Require().NotZero(status.LastSyncErrorAt)
+
+		printer.Clean()
+		unshareCmd := &cobra.Command{}
+		unshareCmd.Flags().StringSlice("remote", nil, "")
+		err = sharedChannelUnshareCmdF(s.th.SystemAdminClient, unshareCmd, []string{channelArg})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+
+		shared, err := s.th.App.HasSharedChannel(s.th.BasicChannel.Id)
+		s.Require().NoError(err)
+		s.Require().False(shared)
+	})
+
+	s.Run("status of a channel that isn't shared", func() {
+		printer.Clean()
+
+		err := sharedChannelStatusCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{channelArg})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+
+	s.Run("share without permissions", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().StringSlice("remote", nil, "")
+
+		err := sharedChannelShareCmdF(s.th.Client, cmd, []string{channelArg})
+		s.Require().Error(err)
+
+		_, appErr := s.th.App.GetSharedChannel(s.th.BasicChannel.Id)
+		s.Require().Error(appErr)
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestSharedChannelShareCmd() {
+	channel := &model.Channel{Id: model.NewId(), Name: "town-square"}
+
+	s.Run("share the channel and invite remotes", func() {
+		printer.Clean()
+
+		remoteID := model.NewId()
+		sc := &model.SharedChannel{ChannelId: channel.Id, ShareName: channel.Name, Home: true, ReadOnly: true}
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			ShareChannel(context.TODO(), &model.SharedChannel{ChannelId: channel.Id, ReadOnly: true}).
+			Return(sc, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			InviteRemoteClusterToChannel(context.TODO(), remoteID, channel.Id).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("readonly", true, "")
+		cmd.Flags().StringSlice("remote", []string{remoteID}, "")
+
+		err := sharedChannelShareCmdF(s.client, cmd, []string{channel.Id})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(sc, printer.GetLines()[0])
+	})
+
+	s.Run("channel not found", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+
+		err := sharedChannelShareCmdF(s.client, &cobra.Command{}, []string{channel.Id})
+		s.Require().EqualError(err, `unable to find channel "`+channel.Id+`"`)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestSharedChannelUnshareCmd() {
+	channel := &model.Channel{Id: model.NewId(), Name: "town-square"}
+
+	s.Run("unshare the channel with every remote", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UnshareChannel(context.TODO(), channel.Id).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().StringSlice("remote", nil, "")
+
+		err := sharedChannelUnshareCmdF(s.client, cmd, []string{channel.Id})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+
+	s.Run("only uninvite the given remotes", func() {
+		printer.Clean()
+
+		remoteID := model.NewId()
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UninviteRemoteClusterToChannel(context.TODO(), remoteID, channel.Id).
+			Return(&model.Response{StatusCode: http.StatusBadRequest}, errors.New("invalid remote")).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().StringSlice("remote", []string{remoteID}, "")
+
+		err := sharedChannelUnshareCmdF(s.client, cmd, []string{channel.Id})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+		s.Require().Len(printer.GetErrorLines(), 1)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestSharedChannelStatusCmd() {
+	channel := &model.Channel{Id: model.NewId(), Name: "town-square"}
+
+	s.Run("print the status of every remote", func() {
+		printer.Clean()
+
+		status := []*model.SharedChannelRemoteSyncStatus{
+			{ChannelId: channel.Id, RemoteId: model.NewId(), DisplayName: "ACME", PendingAttachments: 2},
+			{ChannelId: channel.Id, RemoteId: model.NewId(), DisplayName: "Globex", LastSyncError: "remote unreachable", LastSyncErrorAt: model.GetMillis()},
+		}
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetSharedChannelSyncStatus(context.TODO(), channel.Id).
+			Return(status, &model.Response{}, nil).
+			Times(1)
+
+		err := sharedChannelStatusCmdF(s.client, &cobra.Command{}, []string{channel.Id})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{status[0], status[1]}, printer.GetLines())
+	})
+
+	s.Run("channel not shared", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetSharedChannelSyncStatus(context.TODO(), channel.Id).
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("channel is not shared")).
+			Times(1)
+
+		err := sharedChannelStatusCmdF(s.client, &cobra.Command{}, []string{channel.Id})
+		s.Require().EqualError(err, `failed to get the synchronization status of channel "`+channel.Id+`": channel is not shared`)
+	})
+}
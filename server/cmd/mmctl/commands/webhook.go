@@ -86,6 +86,24 @@ var DeleteWebhookCmd = &cobra.Command{
 	RunE:    withClient(deleteWebhookCmdF),
 }
 
+var ListWebhookDeliveriesCmd = &cobra.Command{
+	Use:     "deliveries [webhookID]",
+	Short:   "List outgoing webhook deliveries",
+	Long:    "List the most recent deliveries of the outgoing webhook specified by [webhookID], with their status, response code and latency",
+	Args:    cobra.ExactArgs(1),
+	Example: "  webhook deliveries w16zb5tu3n1zkqo18goqry1je --per-page 20",
+	RunE:    withClient(listWebhookDeliveriesCmdF),
+}
+
+var RedeliverWebhookCmd = &cobra.Command{
+	Use:     "redeliver [webhookID] [deliveryID]",
+	Short:   "Redeliver an outgoing webhook payload",
+	Long:    "Send the payload of the delivery specified by [deliveryID] to the outgoing webhook's callback URL again",
+	Args:    cobra.ExactArgs(2),
+	Example: "  webhook redeliver w16zb5tu3n1zkqo18goqry1je 8e7z9w6nsbdm5r1b3qj7pn1wxa",
+	RunE:    withClient(redeliverWebhookCmdF),
+}
+
 func listWebhookCmdF(c client.Client, command *cobra.Command, args []string) error {
 	var teams []*model.Team
 
@@ -426,6 +444,34 @@ func showWebhookCmdF(c client.Client, command *cobra.Command, args []string) err
 	return errors.New("Webhook with id '" + webhookID + "' not found")
 }
 
+func listWebhookDeliveriesCmdF(c client.Client, command *cobra.Command, args []string) error {
+	page, _ := command.Flags().GetInt("page")
+	perPage, _ := command.Flags().GetInt("per-page")
+
+	deliveries, _, err := c.GetOutgoingWebhookDeliveries(context.TODO(), args[0], page, perPage)
+	if err != nil {
+		return errors.Wrapf(err, "unable to list deliveries of webhook '%s'", args[0])
+	}
+
+	for _, delivery := range deliveries {
+		printer.PrintT("{{.Id}}\t{{.Status}}\t{{.StatusCode}}\t{{.Attempts}} attempt(s)\t{{.Latency}}ms\t{{.CallbackURL}}", delivery)
+	}
+
+	return nil
+}
+
+func redeliverWebhookCmdF(c client.Client, command *cobra.Command, args []string) error {
+	printer.SetSingle(true)
+
+	delivery, _, err := c.RedeliverOutgoingWebhook(context.TODO(), args[0], args[1])
+	if err != nil {
+		return errors.Wrapf(err, "unable to redeliver '%s' of webhook '%s'", args[1], args[0])
+	}
+
+	printer.PrintT("Delivery {{.Id}} sent with status {{.Status}}", delivery)
+	return nil
+}
+
 func init() {
 	CreateIncomingWebhookCmd.Flags().String("channel", "", "Channel ID (required)")
 	_ = CreateIncomingWebhookCmd.MarkFlagRequired("channel")
@@ -467,6 +513,9 @@ func init() {
 	ModifyOutgoingWebhookCmd.Flags().StringArray("url", []string{}, "Callback URL")
 	ModifyOutgoingWebhookCmd.Flags().String("content-type", "", "Content-type")
 
+	ListWebhookDeliveriesCmd.Flags().Int("page", 0, "Page number to fetch for the list of deliveries")
+	ListWebhookDeliveriesCmd.Flags().Int("per-page", DefaultPageSize, "Number of deliveries to be fetched")
+
 	WebhookCmd.AddCommand(
 		ListWebhookCmd,
 		CreateIncomingWebhookCmd,
@@ -475,6 +524,8 @@ func init() {
 		ModifyOutgoingWebhookCmd,
 		DeleteWebhookCmd,
 		ShowWebhookCmd,
+		ListWebhookDeliveriesCmd,
+		RedeliverWebhookCmd,
 	)
 
 	RootCmd.AddCommand(WebhookCmd)
@@ -788,3 +788,87 @@ func (s *MmctlUnitTestSuite) TestShowWebhookCmd() {
 		s.Require().Equal("Webhook with id '"+nonExistentID+"' not found", err.Error())
 	})
 }
+
+func (s *MmctlUnitTestSuite) TestListWebhookDeliveriesCmd() {
+	webhookID := model.NewId()
+
+	s.Run("Successfully list deliveries", func() {
+		printer.Clean()
+
+		deliveries := []*model.OutgoingWebhookDelivery{
+			{Id: model.NewId(), HookId: webhookID, Status: model.OutgoingWebhookDeliveryStatusSuccess, StatusCode: 200},
+			{Id: model.NewId(), HookId: webhookID, Status: model.OutgoingWebhookDeliveryStatusRetrying, StatusCode: 503},
+		}
+
+		s.client.
+			EXPECT().
+			GetOutgoingWebhookDeliveries(context.TODO(), webhookID, 1, 20).
+			Return(deliveries, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 1, "")
+		cmd.Flags().Int("per-page", 20, "")
+
+		err := listWebhookDeliveriesCmdF(s.client, cmd, []string{webhookID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(deliveries[0], printer.GetLines()[0])
+		s.Require().Equal(deliveries[1], printer.GetLines()[1])
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Fail to list deliveries", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetOutgoingWebhookDeliveries(context.TODO(), webhookID, 0, DefaultPageSize).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", DefaultPageSize, "")
+
+		err := listWebhookDeliveriesCmdF(s.client, cmd, []string{webhookID})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestRedeliverWebhookCmd() {
+	webhookID := model.NewId()
+	deliveryID := model.NewId()
+
+	s.Run("Successfully redeliver", func() {
+		printer.Clean()
+
+		delivery := &model.OutgoingWebhookDelivery{Id: model.NewId(), HookId: webhookID, Status: model.OutgoingWebhookDeliveryStatusSuccess}
+
+		s.client.
+			EXPECT().
+			RedeliverOutgoingWebhook(context.TODO(), webhookID, deliveryID).
+			Return(delivery, &model.Response{}, nil).
+			Times(1)
+
+		err := redeliverWebhookCmdF(s.client, &cobra.Command{}, []string{webhookID, deliveryID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(delivery, printer.GetLines()[0])
+	})
+
+	s.Run("Fail to redeliver", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			RedeliverOutgoingWebhook(context.TODO(), webhookID, deliveryID).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := redeliverWebhookCmdF(s.client, &cobra.Command{}, []string{webhookID, deliveryID})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
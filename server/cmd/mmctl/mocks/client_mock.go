@@ -198,6 +198,22 @@ func (mr *MockClientMockRecorder) CreateChannel(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChannel", reflect.TypeOf((*MockClient)(nil).CreateChannel), arg0, arg1)
 }
 
+// CreateChannelBookmark mocks base method.
+func (m *MockClient) CreateChannelBookmark(arg0 context.Context, arg1 *model.ChannelBookmark) (*model.ChannelBookmarkWithFileInfo, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChannelBookmark", arg0, arg1)
+	ret0, _ := ret[0].(*model.ChannelBookmarkWithFileInfo)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateChannelBookmark indicates an expected call of CreateChannelBookmark.
+func (mr *MockClientMockRecorder) CreateChannelBookmark(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChannelBookmark", reflect.TypeOf((*MockClient)(nil).CreateChannelBookmark), arg0, arg1)
+}
+
 // CreateCommand mocks base method.
 func (m *MockClient) CreateCommand(arg0 context.Context, arg1 *model.Command) (*model.Command, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -214,6 +230,22 @@ func (mr *MockClientMockRecorder) CreateCommand(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCommand", reflect.TypeOf((*MockClient)(nil).CreateCommand), arg0, arg1)
 }
 
+// CreateEmoji mocks base method.
+func (m *MockClient) CreateEmoji(arg0 context.Context, arg1 *model.Emoji, arg2 []byte, arg3 string) (*model.Emoji, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmoji", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Emoji)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateEmoji indicates an expected call of CreateEmoji.
+func (mr *MockClientMockRecorder) CreateEmoji(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmoji", reflect.TypeOf((*MockClient)(nil).CreateEmoji), arg0, arg1, arg2, arg3)
+}
+
 // CreateIncomingWebhook mocks base method.
 func (m *MockClient) CreateIncomingWebhook(arg0 context.Context, arg1 *model.IncomingWebhook) (*model.IncomingWebhook, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -278,6 +310,70 @@ func (mr *MockClientMockRecorder) CreatePost(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePost", reflect.TypeOf((*MockClient)(nil).CreatePost), arg0, arg1)
 }
 
+// CreateRemoteCluster mocks base method.
+func (m *MockClient) CreateRemoteCluster(arg0 context.Context, arg1 *model.RemoteClusterWithPassword) (*model.RemoteClusterWithInvite, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRemoteCluster", arg0, arg1)
+	ret0, _ := ret[0].(*model.RemoteClusterWithInvite)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateRemoteCluster indicates an expected call of CreateRemoteCluster.
+func (mr *MockClientMockRecorder) CreateRemoteCluster(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRemoteCluster", reflect.TypeOf((*MockClient)(nil).CreateRemoteCluster), arg0, arg1)
+}
+
+// CreateRole mocks base method.
+func (m *MockClient) CreateRole(arg0 context.Context, arg1 *model.Role) (*model.Role, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", arg0, arg1)
+	ret0, _ := ret[0].(*model.Role)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockClientMockRecorder) CreateRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockClient)(nil).CreateRole), arg0, arg1)
+}
+
+// CreateScheduledPost mocks base method.
+func (m *MockClient) CreateScheduledPost(arg0 context.Context, arg1 *model.ScheduledPost) (*model.ScheduledPost, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledPost", arg0, arg1)
+	ret0, _ := ret[0].(*model.ScheduledPost)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateScheduledPost indicates an expected call of CreateScheduledPost.
+func (mr *MockClientMockRecorder) CreateScheduledPost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledPost", reflect.TypeOf((*MockClient)(nil).CreateScheduledPost), arg0, arg1)
+}
+
+// CreateScheme mocks base method.
+func (m *MockClient) CreateScheme(arg0 context.Context, arg1 *model.Scheme) (*model.Scheme, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheme", arg0, arg1)
+	ret0, _ := ret[0].(*model.Scheme)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateScheme indicates an expected call of CreateScheme.
+func (mr *MockClientMockRecorder) CreateScheme(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheme", reflect.TypeOf((*MockClient)(nil).CreateScheme), arg0, arg1)
+}
+
 // CreateTeam mocks base method.
 func (m *MockClient) CreateTeam(arg0 context.Context, arg1 *model.Team) (*model.Team, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -357,6 +453,22 @@ func (mr *MockClientMockRecorder) DeleteChannel(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannel", reflect.TypeOf((*MockClient)(nil).DeleteChannel), arg0, arg1)
 }
 
+// DeleteChannelBookmark mocks base method.
+func (m *MockClient) DeleteChannelBookmark(arg0 context.Context, arg1, arg2 string) (*model.ChannelBookmarkWithFileInfo, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannelBookmark", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.ChannelBookmarkWithFileInfo)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteChannelBookmark indicates an expected call of DeleteChannelBookmark.
+func (mr *MockClientMockRecorder) DeleteChannelBookmark(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannelBookmark", reflect.TypeOf((*MockClient)(nil).DeleteChannelBookmark), arg0, arg1, arg2)
+}
+
 // DeleteCommand mocks base method.
 func (m *MockClient) DeleteCommand(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -372,6 +484,37 @@ func (mr *MockClientMockRecorder) DeleteCommand(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCommand", reflect.TypeOf((*MockClient)(nil).DeleteCommand), arg0, arg1)
 }
 
+// DeleteDraft mocks base method.
+func (m *MockClient) DeleteDraft(arg0 context.Context, arg1, arg2, arg3 string) (*model.Draft, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDraft", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Draft)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteDraft indicates an expected call of DeleteDraft.
+func (mr *MockClientMockRecorder) DeleteDraft(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDraft", reflect.TypeOf((*MockClient)(nil).DeleteDraft), arg0, arg1, arg2, arg3)
+}
+
+// DeleteEmoji mocks base method.
+func (m *MockClient) DeleteEmoji(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmoji", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEmoji indicates an expected call of DeleteEmoji.
+func (mr *MockClientMockRecorder) DeleteEmoji(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmoji", reflect.TypeOf((*MockClient)(nil).DeleteEmoji), arg0, arg1)
+}
+
 // DeleteExport mocks base method.
 func (m *MockClient) DeleteExport(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -447,6 +590,67 @@ func (mr *MockClientMockRecorder) DeletePreferences(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePreferences", reflect.TypeOf((*MockClient)(nil).DeletePreferences), arg0, arg1, arg2)
 }
 
+// DeleteRemoteCluster mocks base method.
+func (m *MockClient) DeleteRemoteCluster(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRemoteCluster", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRemoteCluster indicates an expected call of DeleteRemoteCluster.
+func (mr *MockClientMockRecorder) DeleteRemoteCluster(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRemoteCluster", reflect.TypeOf((*MockClient)(nil).DeleteRemoteCluster), arg0, arg1)
+}
+
+// DeleteRole mocks base method.
+func (m *MockClient) DeleteRole(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockClientMockRecorder) DeleteRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockClient)(nil).DeleteRole), arg0, arg1)
+}
+
+// DeleteScheduledPost mocks base method.
+func (m *MockClient) DeleteScheduledPost(arg0 context.Context, arg1 string) (*model.ScheduledPost, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduledPost", arg0, arg1)
+	ret0, _ := ret[0].(*model.ScheduledPost)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteScheduledPost indicates an expected call of DeleteScheduledPost.
+func (mr *MockClientMockRecorder) DeleteScheduledPost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduledPost", reflect.TypeOf((*MockClient)(nil).DeleteScheduledPost), arg0, arg1)
+}
+
+// DeleteScheme mocks base method.
+func (m *MockClient) DeleteScheme(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheme", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteScheme indicates an expected call of DeleteScheme.
+func (mr *MockClientMockRecorder) DeleteScheme(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheme", reflect.TypeOf((*MockClient)(nil).DeleteScheme), arg0, arg1)
+}
+
 // DemoteUserToGuest mocks base method.
 func (m *MockClient) DemoteUserToGuest(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -587,6 +791,22 @@ func (mr *MockClientMockRecorder) GenerateSupportPacket(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSupportPacket", reflect.TypeOf((*MockClient)(nil).GenerateSupportPacket), arg0)
 }
 
+// GetAllSharedChannels mocks base method.
+func (m *MockClient) GetAllSharedChannels(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*model.SharedChannel, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllSharedChannels", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.SharedChannel)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllSharedChannels indicates an expected call of GetAllSharedChannels.
+func (mr *MockClientMockRecorder) GetAllSharedChannels(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSharedChannels", reflect.TypeOf((*MockClient)(nil).GetAllSharedChannels), arg0, arg1, arg2, arg3)
+}
+
 // GetAllTeams mocks base method.
 func (m *MockClient) GetAllTeams(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*model.Team, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -715,6 +935,22 @@ func (mr *MockClientMockRecorder) GetChannelMembers(arg0, arg1, arg2, arg3, arg4
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelMembers", reflect.TypeOf((*MockClient)(nil).GetChannelMembers), arg0, arg1, arg2, arg3, arg4)
 }
 
+// GetChannelsForScheme mocks base method.
+func (m *MockClient) GetChannelsForScheme(arg0 context.Context, arg1 string, arg2, arg3 int) (model.ChannelList, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelsForScheme", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(model.ChannelList)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetChannelsForScheme indicates an expected call of GetChannelsForScheme.
+func (mr *MockClientMockRecorder) GetChannelsForScheme(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelsForScheme", reflect.TypeOf((*MockClient)(nil).GetChannelsForScheme), arg0, arg1, arg2, arg3)
+}
+
 // GetChannelsForTeamForUser mocks base method.
 func (m *MockClient) GetChannelsForTeamForUser(arg0 context.Context, arg1, arg2 string, arg3 bool, arg4 string) ([]*model.Channel, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -763,6 +999,38 @@ func (mr *MockClientMockRecorder) GetConfig(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfig", reflect.TypeOf((*MockClient)(nil).GetConfig), arg0)
 }
 
+// GetDataRetentionDryRun mocks base method.
+func (m *MockClient) GetDataRetentionDryRun(arg0 context.Context) (*model.RetentionPolicyDryRun, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataRetentionDryRun", arg0)
+	ret0, _ := ret[0].(*model.RetentionPolicyDryRun)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDataRetentionDryRun indicates an expected call of GetDataRetentionDryRun.
+func (mr *MockClientMockRecorder) GetDataRetentionDryRun(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataRetentionDryRun", reflect.TypeOf((*MockClient)(nil).GetDataRetentionDryRun), arg0)
+}
+
+// GetDataRetentionPolicyDryRun mocks base method.
+func (m *MockClient) GetDataRetentionPolicyDryRun(arg0 context.Context, arg1 string) (*model.RetentionPolicyDryRun, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataRetentionPolicyDryRun", arg0, arg1)
+	ret0, _ := ret[0].(*model.RetentionPolicyDryRun)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDataRetentionPolicyDryRun indicates an expected call of GetDataRetentionPolicyDryRun.
+func (mr *MockClientMockRecorder) GetDataRetentionPolicyDryRun(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataRetentionPolicyDryRun", reflect.TypeOf((*MockClient)(nil).GetDataRetentionPolicyDryRun), arg0, arg1)
+}
+
 // GetDeletedChannelsForTeam mocks base method.
 func (m *MockClient) GetDeletedChannelsForTeam(arg0 context.Context, arg1 string, arg2, arg3 int, arg4 string) ([]*model.Channel, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -779,6 +1047,70 @@ func (mr *MockClientMockRecorder) GetDeletedChannelsForTeam(arg0, arg1, arg2, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedChannelsForTeam", reflect.TypeOf((*MockClient)(nil).GetDeletedChannelsForTeam), arg0, arg1, arg2, arg3, arg4)
 }
 
+// GetDrafts mocks base method.
+func (m *MockClient) GetDrafts(arg0 context.Context, arg1, arg2 string) ([]*model.Draft, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDrafts", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Draft)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDrafts indicates an expected call of GetDrafts.
+func (mr *MockClientMockRecorder) GetDrafts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDrafts", reflect.TypeOf((*MockClient)(nil).GetDrafts), arg0, arg1, arg2)
+}
+
+// GetEmojiByName mocks base method.
+func (m *MockClient) GetEmojiByName(arg0 context.Context, arg1 string) (*model.Emoji, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmojiByName", arg0, arg1)
+	ret0, _ := ret[0].(*model.Emoji)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEmojiByName indicates an expected call of GetEmojiByName.
+func (mr *MockClientMockRecorder) GetEmojiByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmojiByName", reflect.TypeOf((*MockClient)(nil).GetEmojiByName), arg0, arg1)
+}
+
+// GetEmojiImage mocks base method.
+func (m *MockClient) GetEmojiImage(arg0 context.Context, arg1 string) ([]byte, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmojiImage", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEmojiImage indicates an expected call of GetEmojiImage.
+func (mr *MockClientMockRecorder) GetEmojiImage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmojiImage", reflect.TypeOf((*MockClient)(nil).GetEmojiImage), arg0, arg1)
+}
+
+// GetEmojiList mocks base method.
+func (m *MockClient) GetEmojiList(arg0 context.Context, arg1, arg2 int) ([]*model.Emoji, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmojiList", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Emoji)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEmojiList indicates an expected call of GetEmojiList.
+func (mr *MockClientMockRecorder) GetEmojiList(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmojiList", reflect.TypeOf((*MockClient)(nil).GetEmojiList), arg0, arg1, arg2)
+}
+
 // GetGroupsByChannel mocks base method.
 func (m *MockClient) GetGroupsByChannel(arg0 context.Context, arg1 string, arg2 model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, int, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1005,6 +1337,22 @@ func (mr *MockClientMockRecorder) GetOutgoingWebhook(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhook", reflect.TypeOf((*MockClient)(nil).GetOutgoingWebhook), arg0, arg1)
 }
 
+// GetOutgoingWebhookDeliveries mocks base method.
+func (m *MockClient) GetOutgoingWebhookDeliveries(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*model.OutgoingWebhookDelivery, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingWebhookDeliveries", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.OutgoingWebhookDelivery)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOutgoingWebhookDeliveries indicates an expected call of GetOutgoingWebhookDeliveries.
+func (mr *MockClientMockRecorder) GetOutgoingWebhookDeliveries(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhookDeliveries", reflect.TypeOf((*MockClient)(nil).GetOutgoingWebhookDeliveries), arg0, arg1, arg2, arg3)
+}
+
 // GetOutgoingWebhooks mocks base method.
 func (m *MockClient) GetOutgoingWebhooks(arg0 context.Context, arg1, arg2 int, arg3 string) ([]*model.OutgoingWebhook, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1245,6 +1593,38 @@ func (mr *MockClientMockRecorder) GetPublicChannelsForTeam(arg0, arg1, arg2, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicChannelsForTeam", reflect.TypeOf((*MockClient)(nil).GetPublicChannelsForTeam), arg0, arg1, arg2, arg3, arg4)
 }
 
+// GetRemoteCluster mocks base method.
+func (m *MockClient) GetRemoteCluster(arg0 context.Context, arg1 string) (*model.RemoteCluster, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemoteCluster", arg0, arg1)
+	ret0, _ := ret[0].(*model.RemoteCluster)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRemoteCluster indicates an expected call of GetRemoteCluster.
+func (mr *MockClientMockRecorder) GetRemoteCluster(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteCluster", reflect.TypeOf((*MockClient)(nil).GetRemoteCluster), arg0, arg1)
+}
+
+// GetRemoteClusters mocks base method.
+func (m *MockClient) GetRemoteClusters(arg0 context.Context, arg1, arg2 int, arg3 model.RemoteClusterQueryFilter) ([]*model.RemoteCluster, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemoteClusters", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.RemoteCluster)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRemoteClusters indicates an expected call of GetRemoteClusters.
+func (mr *MockClientMockRecorder) GetRemoteClusters(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteClusters", reflect.TypeOf((*MockClient)(nil).GetRemoteClusters), arg0, arg1, arg2, arg3)
+}
+
 // GetRoleByName mocks base method.
 func (m *MockClient) GetRoleByName(arg0 context.Context, arg1 string) (*model.Role, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1261,6 +1641,38 @@ func (mr *MockClientMockRecorder) GetRoleByName(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleByName", reflect.TypeOf((*MockClient)(nil).GetRoleByName), arg0, arg1)
 }
 
+// GetScheme mocks base method.
+func (m *MockClient) GetScheme(arg0 context.Context, arg1 string) (*model.Scheme, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheme", arg0, arg1)
+	ret0, _ := ret[0].(*model.Scheme)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetScheme indicates an expected call of GetScheme.
+func (mr *MockClientMockRecorder) GetScheme(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheme", reflect.TypeOf((*MockClient)(nil).GetScheme), arg0, arg1)
+}
+
+// GetSchemes mocks base method.
+func (m *MockClient) GetSchemes(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*model.Scheme, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchemes", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Scheme)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSchemes indicates an expected call of GetSchemes.
+func (mr *MockClientMockRecorder) GetSchemes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemes", reflect.TypeOf((*MockClient)(nil).GetSchemes), arg0, arg1, arg2, arg3)
+}
+
 // GetServerBusy mocks base method.
 func (m *MockClient) GetServerBusy(arg0 context.Context) (*model.ServerBusyState, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1277,6 +1689,22 @@ func (mr *MockClientMockRecorder) GetServerBusy(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServerBusy", reflect.TypeOf((*MockClient)(nil).GetServerBusy), arg0)
 }
 
+// GetSharedChannelSyncStatus mocks base method.
+func (m *MockClient) GetSharedChannelSyncStatus(arg0 context.Context, arg1 string) ([]*model.SharedChannelRemoteSyncStatus, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedChannelSyncStatus", arg0, arg1)
+	ret0, _ := ret[0].([]*model.SharedChannelRemoteSyncStatus)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSharedChannelSyncStatus indicates an expected call of GetSharedChannelSyncStatus.
+func (mr *MockClientMockRecorder) GetSharedChannelSyncStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedChannelSyncStatus", reflect.TypeOf((*MockClient)(nil).GetSharedChannelSyncStatus), arg0, arg1)
+}
+
 // GetTeam mocks base method.
 func (m *MockClient) GetTeam(arg0 context.Context, arg1, arg2 string) (*model.Team, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1309,6 +1737,38 @@ func (mr *MockClientMockRecorder) GetTeamByName(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamByName", reflect.TypeOf((*MockClient)(nil).GetTeamByName), arg0, arg1, arg2)
 }
 
+// GetTeamMembers mocks base method.
+func (m *MockClient) GetTeamMembers(arg0 context.Context, arg1 string, arg2, arg3 int, arg4 string) ([]*model.TeamMember, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamMembers", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*model.TeamMember)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTeamMembers indicates an expected call of GetTeamMembers.
+func (mr *MockClientMockRecorder) GetTeamMembers(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamMembers", reflect.TypeOf((*MockClient)(nil).GetTeamMembers), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetTeamsForScheme mocks base method.
+func (m *MockClient) GetTeamsForScheme(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*model.Team, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamsForScheme", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Team)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTeamsForScheme indicates an expected call of GetTeamsForScheme.
+func (mr *MockClientMockRecorder) GetTeamsForScheme(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamsForScheme", reflect.TypeOf((*MockClient)(nil).GetTeamsForScheme), arg0, arg1, arg2, arg3)
+}
+
 // GetUpload mocks base method.
 func (m *MockClient) GetUpload(arg0 context.Context, arg1 string) (*model.UploadSession, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1405,6 +1865,22 @@ func (mr *MockClientMockRecorder) GetUserByUsername(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockClient)(nil).GetUserByUsername), arg0, arg1, arg2)
 }
 
+// GetUserScheduledPosts mocks base method.
+func (m *MockClient) GetUserScheduledPosts(arg0 context.Context, arg1 string, arg2 bool) (map[string][]*model.ScheduledPost, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserScheduledPosts", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string][]*model.ScheduledPost)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserScheduledPosts indicates an expected call of GetUserScheduledPosts.
+func (mr *MockClientMockRecorder) GetUserScheduledPosts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserScheduledPosts", reflect.TypeOf((*MockClient)(nil).GetUserScheduledPosts), arg0, arg1, arg2)
+}
+
 // GetUsers mocks base method.
 func (m *MockClient) GetUsers(arg0 context.Context, arg1, arg2 int, arg3 string) ([]*model.User, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1501,6 +1977,21 @@ func (mr *MockClientMockRecorder) InstallPluginFromURL(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallPluginFromURL", reflect.TypeOf((*MockClient)(nil).InstallPluginFromURL), arg0, arg1, arg2)
 }
 
+// InviteRemoteClusterToChannel mocks base method.
+func (m *MockClient) InviteRemoteClusterToChannel(arg0 context.Context, arg1, arg2 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteRemoteClusterToChannel", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteRemoteClusterToChannel indicates an expected call of InviteRemoteClusterToChannel.
+func (mr *MockClientMockRecorder) InviteRemoteClusterToChannel(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteRemoteClusterToChannel", reflect.TypeOf((*MockClient)(nil).InviteRemoteClusterToChannel), arg0, arg1, arg2)
+}
+
 // InviteUsersToTeam mocks base method.
 func (m *MockClient) InviteUsersToTeam(arg0 context.Context, arg1 string, arg2 []string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1516,6 +2007,22 @@ func (mr *MockClientMockRecorder) InviteUsersToTeam(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteUsersToTeam", reflect.TypeOf((*MockClient)(nil).InviteUsersToTeam), arg0, arg1, arg2)
 }
 
+// ListChannelBookmarksForChannel mocks base method.
+func (m *MockClient) ListChannelBookmarksForChannel(arg0 context.Context, arg1 string, arg2 int64) ([]*model.ChannelBookmarkWithFileInfo, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChannelBookmarksForChannel", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.ChannelBookmarkWithFileInfo)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListChannelBookmarksForChannel indicates an expected call of ListChannelBookmarksForChannel.
+func (mr *MockClientMockRecorder) ListChannelBookmarksForChannel(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChannelBookmarksForChannel", reflect.TypeOf((*MockClient)(nil).ListChannelBookmarksForChannel), arg0, arg1, arg2)
+}
+
 // ListCommands mocks base method.
 func (m *MockClient) ListCommands(arg0 context.Context, arg1 string, arg2 bool) ([]*model.Command, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1719,6 +2226,22 @@ func (mr *MockClientMockRecorder) PatchRole(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchRole", reflect.TypeOf((*MockClient)(nil).PatchRole), arg0, arg1, arg2)
 }
 
+// PatchScheme mocks base method.
+func (m *MockClient) PatchScheme(arg0 context.Context, arg1 string, arg2 *model.SchemePatch) (*model.Scheme, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchScheme", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Scheme)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PatchScheme indicates an expected call of PatchScheme.
+func (mr *MockClientMockRecorder) PatchScheme(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchScheme", reflect.TypeOf((*MockClient)(nil).PatchScheme), arg0, arg1, arg2)
+}
+
 // PatchTeam mocks base method.
 func (m *MockClient) PatchTeam(arg0 context.Context, arg1 string, arg2 *model.TeamPatch) (*model.Team, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1810,6 +2333,22 @@ func (mr *MockClientMockRecorder) PermanentDeleteUser(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PermanentDeleteUser", reflect.TypeOf((*MockClient)(nil).PermanentDeleteUser), arg0, arg1)
 }
 
+// PingRemoteCluster mocks base method.
+func (m *MockClient) PingRemoteCluster(arg0 context.Context, arg1 string) (*model.RemoteCluster, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingRemoteCluster", arg0, arg1)
+	ret0, _ := ret[0].(*model.RemoteCluster)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PingRemoteCluster indicates an expected call of PingRemoteCluster.
+func (mr *MockClientMockRecorder) PingRemoteCluster(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingRemoteCluster", reflect.TypeOf((*MockClient)(nil).PingRemoteCluster), arg0, arg1)
+}
+
 // PromoteGuestToUser mocks base method.
 func (m *MockClient) PromoteGuestToUser(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1825,6 +2364,22 @@ func (mr *MockClientMockRecorder) PromoteGuestToUser(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteGuestToUser", reflect.TypeOf((*MockClient)(nil).PromoteGuestToUser), arg0, arg1)
 }
 
+// RedeliverOutgoingWebhook mocks base method.
+func (m *MockClient) RedeliverOutgoingWebhook(arg0 context.Context, arg1, arg2 string) (*model.OutgoingWebhookDelivery, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeliverOutgoingWebhook", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.OutgoingWebhookDelivery)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RedeliverOutgoingWebhook indicates an expected call of RedeliverOutgoingWebhook.
+func (mr *MockClientMockRecorder) RedeliverOutgoingWebhook(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeliverOutgoingWebhook", reflect.TypeOf((*MockClient)(nil).RedeliverOutgoingWebhook), arg0, arg1, arg2)
+}
+
 // RegenOutgoingHookToken mocks base method.
 func (m *MockClient) RegenOutgoingHookToken(arg0 context.Context, arg1 string) (*model.OutgoingWebhook, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1856,6 +2411,22 @@ func (mr *MockClientMockRecorder) ReloadConfig(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadConfig", reflect.TypeOf((*MockClient)(nil).ReloadConfig), arg0)
 }
 
+// RemoteClusterAcceptInvite mocks base method.
+func (m *MockClient) RemoteClusterAcceptInvite(arg0 context.Context, arg1 *model.RemoteClusterAcceptInvite) (*model.RemoteCluster, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoteClusterAcceptInvite", arg0, arg1)
+	ret0, _ := ret[0].(*model.RemoteCluster)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RemoteClusterAcceptInvite indicates an expected call of RemoteClusterAcceptInvite.
+func (mr *MockClientMockRecorder) RemoteClusterAcceptInvite(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteClusterAcceptInvite", reflect.TypeOf((*MockClient)(nil).RemoteClusterAcceptInvite), arg0, arg1)
+}
+
 // RemoveLicenseFile mocks base method.
 func (m *MockClient) RemoveLicenseFile(arg0 context.Context) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2041,6 +2612,22 @@ func (mr *MockClientMockRecorder) SetServerBusy(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetServerBusy", reflect.TypeOf((*MockClient)(nil).SetServerBusy), arg0, arg1)
 }
 
+// ShareChannel mocks base method.
+func (m *MockClient) ShareChannel(arg0 context.Context, arg1 *model.SharedChannel) (*model.SharedChannel, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareChannel", arg0, arg1)
+	ret0, _ := ret[0].(*model.SharedChannel)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ShareChannel indicates an expected call of ShareChannel.
+func (mr *MockClientMockRecorder) ShareChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareChannel", reflect.TypeOf((*MockClient)(nil).ShareChannel), arg0, arg1)
+}
+
 // SoftDeleteTeam mocks base method.
 func (m *MockClient) SoftDeleteTeam(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2071,6 +2658,83 @@ func (mr *MockClientMockRecorder) SyncLdap(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncLdap", reflect.TypeOf((*MockClient)(nil).SyncLdap), arg0, arg1)
 }
 
+// UninviteRemoteClusterToChannel mocks base method.
+func (m *MockClient) UninviteRemoteClusterToChannel(arg0 context.Context, arg1, arg2 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UninviteRemoteClusterToChannel", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UninviteRemoteClusterToChannel indicates an expected call of UninviteRemoteClusterToChannel.
+func (mr *MockClientMockRecorder) UninviteRemoteClusterToChannel(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninviteRemoteClusterToChannel", reflect.TypeOf((*MockClient)(nil).UninviteRemoteClusterToChannel), arg0, arg1, arg2)
+}
+
+// UnshareChannel mocks base method.
+func (m *MockClient) UnshareChannel(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnshareChannel", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnshareChannel indicates an expected call of UnshareChannel.
+func (mr *MockClientMockRecorder) UnshareChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnshareChannel", reflect.TypeOf((*MockClient)(nil).UnshareChannel), arg0, arg1)
+}
+
+// UpdateChannelBookmark mocks base method.
+func (m *MockClient) UpdateChannelBookmark(arg0 context.Context, arg1, arg2 string, arg3 *model.ChannelBookmarkPatch) (*model.UpdateChannelBookmarkResponse, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChannelBookmark", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.UpdateChannelBookmarkResponse)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateChannelBookmark indicates an expected call of UpdateChannelBookmark.
+func (mr *MockClientMockRecorder) UpdateChannelBookmark(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannelBookmark", reflect.TypeOf((*MockClient)(nil).UpdateChannelBookmark), arg0, arg1, arg2, arg3)
+}
+
+// UpdateChannelBookmarkSortOrder mocks base method.
+func (m *MockClient) UpdateChannelBookmarkSortOrder(arg0 context.Context, arg1, arg2 string, arg3 int64) ([]*model.ChannelBookmarkWithFileInfo, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChannelBookmarkSortOrder", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.ChannelBookmarkWithFileInfo)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateChannelBookmarkSortOrder indicates an expected call of UpdateChannelBookmarkSortOrder.
+func (mr *MockClientMockRecorder) UpdateChannelBookmarkSortOrder(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannelBookmarkSortOrder", reflect.TypeOf((*MockClient)(nil).UpdateChannelBookmarkSortOrder), arg0, arg1, arg2, arg3)
+}
+
+// UpdateChannelMemberSchemeRoles mocks base method.
+func (m *MockClient) UpdateChannelMemberSchemeRoles(arg0 context.Context, arg1, arg2 string, arg3 *model.SchemeRoles) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChannelMemberSchemeRoles", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateChannelMemberSchemeRoles indicates an expected call of UpdateChannelMemberSchemeRoles.
+func (mr *MockClientMockRecorder) UpdateChannelMemberSchemeRoles(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannelMemberSchemeRoles", reflect.TypeOf((*MockClient)(nil).UpdateChannelMemberSchemeRoles), arg0, arg1, arg2, arg3)
+}
+
 // UpdateChannelPrivacy mocks base method.
 func (m *MockClient) UpdateChannelPrivacy(arg0 context.Context, arg1 string, arg2 model.ChannelType) (*model.Channel, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2087,6 +2751,21 @@ func (mr *MockClientMockRecorder) UpdateChannelPrivacy(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannelPrivacy", reflect.TypeOf((*MockClient)(nil).UpdateChannelPrivacy), arg0, arg1, arg2)
 }
 
+// UpdateChannelScheme mocks base method.
+func (m *MockClient) UpdateChannelScheme(arg0 context.Context, arg1, arg2 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChannelScheme", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateChannelScheme indicates an expected call of UpdateChannelScheme.
+func (mr *MockClientMockRecorder) UpdateChannelScheme(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannelScheme", reflect.TypeOf((*MockClient)(nil).UpdateChannelScheme), arg0, arg1, arg2)
+}
+
 // UpdateCommand mocks base method.
 func (m *MockClient) UpdateCommand(arg0 context.Context, arg1 *model.Command) (*model.Command, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2197,6 +2876,21 @@ func (mr *MockClientMockRecorder) UpdateTeam(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeam", reflect.TypeOf((*MockClient)(nil).UpdateTeam), arg0, arg1)
 }
 
+// UpdateTeamMemberSchemeRoles mocks base method.
+func (m *MockClient) UpdateTeamMemberSchemeRoles(arg0 context.Context, arg1, arg2 string, arg3 *model.SchemeRoles) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTeamMemberSchemeRoles", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTeamMemberSchemeRoles indicates an expected call of UpdateTeamMemberSchemeRoles.
+func (mr *MockClientMockRecorder) UpdateTeamMemberSchemeRoles(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeamMemberSchemeRoles", reflect.TypeOf((*MockClient)(nil).UpdateTeamMemberSchemeRoles), arg0, arg1, arg2, arg3)
+}
+
 // UpdateTeamPrivacy mocks base method.
 func (m *MockClient) UpdateTeamPrivacy(arg0 context.Context, arg1, arg2 string) (*model.Team, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2213,6 +2907,21 @@ func (mr *MockClientMockRecorder) UpdateTeamPrivacy(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeamPrivacy", reflect.TypeOf((*MockClient)(nil).UpdateTeamPrivacy), arg0, arg1, arg2)
 }
 
+// UpdateTeamScheme mocks base method.
+func (m *MockClient) UpdateTeamScheme(arg0 context.Context, arg1, arg2 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTeamScheme", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTeamScheme indicates an expected call of UpdateTeamScheme.
+func (mr *MockClientMockRecorder) UpdateTeamScheme(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeamScheme", reflect.TypeOf((*MockClient)(nil).UpdateTeamScheme), arg0, arg1, arg2)
+}
+
 // UpdateUser mocks base method.
 func (m *MockClient) UpdateUser(arg0 context.Context, arg1 *model.User) (*model.User, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2304,6 +3013,22 @@ func (mr *MockClientMockRecorder) UpdateUserRoles(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRoles", reflect.TypeOf((*MockClient)(nil).UpdateUserRoles), arg0, arg1, arg2)
 }
 
+// UploadBookmarkFile mocks base method.
+func (m *MockClient) UploadBookmarkFile(arg0 context.Context, arg1 []byte, arg2, arg3 string) (*model.FileUploadResponse, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadBookmarkFile", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.FileUploadResponse)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UploadBookmarkFile indicates an expected call of UploadBookmarkFile.
+func (mr *MockClientMockRecorder) UploadBookmarkFile(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadBookmarkFile", reflect.TypeOf((*MockClient)(nil).UploadBookmarkFile), arg0, arg1, arg2, arg3)
+}
+
 // UploadData mocks base method.
 func (m *MockClient) UploadData(arg0 context.Context, arg1 string, arg2 io.Reader) (*model.FileInfo, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -24,6 +24,7 @@ type MetricsInterface interface {
 
 	IncrementHTTPRequest()
 	IncrementHTTPError()
+	IncrementRateLimitRejection(profile string)
 
 	IncrementClusterRequest()
 	ObserveClusterRequestDuration(elapsed float64)
@@ -42,6 +43,9 @@ type MetricsInterface interface {
 	IncrementMemCacheHitCounterSession()
 	IncrementMemCacheInvalidationCounterSession()
 
+	IncrementFileStoreCacheHitCounter()
+	IncrementFileStoreCacheMissCounter()
+
 	IncrementWebsocketEvent(eventType model.WebsocketEventType)
 	IncrementWebSocketBroadcast(eventType model.WebsocketEventType)
 	IncrementWebSocketBroadcastBufferSize(hub string, amount float64)
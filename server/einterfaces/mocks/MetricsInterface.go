@@ -108,6 +108,16 @@ func (_m *MetricsInterface) IncrementFileIndexCounter() {
 	_m.Called()
 }
 
+// IncrementFileStoreCacheHitCounter provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementFileStoreCacheHitCounter() {
+	_m.Called()
+}
+
+// IncrementFileStoreCacheMissCounter provides a mock function with given fields:
+func (_m *MetricsInterface) IncrementFileStoreCacheMissCounter() {
+	_m.Called()
+}
+
 // IncrementFilesSearchCounter provides a mock function with given fields:
 func (_m *MetricsInterface) IncrementFilesSearchCounter() {
 	_m.Called()
@@ -238,6 +248,11 @@ func (_m *MetricsInterface) IncrementPostsSearchCounter() {
 	_m.Called()
 }
 
+// IncrementRateLimitRejection provides a mock function with given fields: profile
+func (_m *MetricsInterface) IncrementRateLimitRejection(profile string) {
+	_m.Called(profile)
+}
+
 // IncrementRemoteClusterConnStateChangeCounter provides a mock function with given fields: remoteID, online
 func (_m *MetricsInterface) IncrementRemoteClusterConnStateChangeCounter(remoteID string, online bool) {
 	_m.Called(remoteID, online)
@@ -0,0 +1,15 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package einterfaces
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+type TranslationInterface interface {
+	// Translate translates the message of the given posts to the given language. It returns the translated messages
+	// keyed by post id, omitting the posts it could not or did not need to translate.
+	Translate(rctx request.CTX, posts []*model.Post, language string) (map[string]string, *model.AppError)
+}
@@ -0,0 +1,484 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package eml_export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/http"
+	"net/mail"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/common_export"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const (
+	EmlExportFilename  = "eml_export.zip"
+	MboxExportFilename = "mbox_export.zip"
+	EmlWarningFilename = "warning.txt"
+
+	// DefaultMessageIDDomain is used to build the Message-ID headers when no site URL is configured.
+	DefaultMessageIDDomain = "mattermost"
+
+	base64LineLength = 76
+)
+
+var headerValueReplacer = strings.NewReplacer("\r", " ", "\n", " ")
+
+type exportedPost struct {
+	post        *model.MessageExport
+	attachments []*model.FileInfo
+}
+
+// EmlExport writes every post as an RFC 5322 message into a zip archive, with one
+// directory per channel and one .eml file per post.
+func EmlExport(rctx request.CTX, posts []*model.MessageExport, db store.Store, exportBackend filestore.FileBackend, fileAttachmentBackend filestore.FileBackend, exportDirectory string, siteURL string) (warningCount int64, appErr *model.AppError) {
+	return emlExport(rctx, model.ComplianceExportTypeEml, posts, db, exportBackend, fileAttachmentBackend, exportDirectory, siteURL)
+}
+
+// MboxExport writes every post as an RFC 5322 message into a zip archive, with one
+// mboxrd file per channel.
+func MboxExport(rctx request.CTX, posts []*model.MessageExport, db store.Store, exportBackend filestore.FileBackend, fileAttachmentBackend filestore.FileBackend, exportDirectory string, siteURL string) (warningCount int64, appErr *model.AppError) {
+	return emlExport(rctx, model.ComplianceExportTypeMbox, posts, db, exportBackend, fileAttachmentBackend, exportDirectory, siteURL)
+}
+
+func emlExport(rctx request.CTX, exportType string, posts []*model.MessageExport, db store.Store, exportBackend filestore.FileBackend, fileAttachmentBackend filestore.FileBackend, exportDirectory string, siteURL string) (warningCount int64, appErr *model.AppError) {
+	exportFilename := EmlExportFilename
+	if exportType == model.ComplianceExportTypeMbox {
+		exportFilename = MboxExportFilename
+	}
+	domain := messageIDDomain(siteURL)
+
+	metadata := common_export.Metadata{
+		Channels:         map[string]common_export.MetadataChannel{},
+		MessagesCount:    0,
+		AttachmentsCount: 0,
+		StartTime:        0,
+		EndTime:          0,
+	}
+	membersByChannel := common_export.MembersByChannel{}
+	postsByChannel := map[string][]exportedPost{}
+	channelIds := []string{}
+
+	for _, post := range posts {
+		if post == nil {
+			rctx.Logger().Warn("ignored a nil post reference in the list")
+			continue
+		}
+
+		attachments, appErr := getPostAttachments(db, post)
+		if appErr != nil {
+			return warningCount, appErr
+		}
+
+		if _, ok := membersByChannel[*post.ChannelId]; !ok {
+			membersByChannel[*post.ChannelId] = common_export.ChannelMembers{}
+			channelIds = append(channelIds, *post.ChannelId)
+		}
+		membersByChannel[*post.ChannelId][*post.UserId] = common_export.ChannelMember{
+			UserId:   *post.UserId,
+			Username: *post.Username,
+			IsBot:    post.IsBot,
+			Email:    *post.UserEmail,
+		}
+
+		postsByChannel[*post.ChannelId] = append(postsByChannel[*post.ChannelId], exportedPost{post: post, attachments: attachments})
+		metadata.Update(post, len(attachments))
+	}
+
+	participantsByChannel, appErr := getParticipantsByChannel(metadata.Channels, membersByChannel, db, domain)
+	if appErr != nil {
+		return warningCount, appErr
+	}
+
+	dest, err := os.CreateTemp("", exportFilename)
+	if err != nil {
+		return warningCount, model.NewAppError("EmlExport", "ent.compliance.eml.file.creation.appError", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	defer os.Remove(dest.Name())
+	defer dest.Close()
+
+	zipFile := zip.NewWriter(dest)
+
+	var missingFiles []string
+	usedFilenames := map[string]bool{}
+	for _, channelId := range channelIds {
+		var mboxFile io.Writer
+		if exportType == model.ComplianceExportTypeMbox {
+			mboxFile, err = zipFile.Create(channelId + ".mbox")
+			if err != nil {
+				return warningCount, model.NewAppError("EmlExport", "ent.compliance.eml.zip.creation.appError", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+		}
+
+		for _, exported := range postsByChannel[channelId] {
+			post := exported.post
+
+			var message bytes.Buffer
+			missing, err := writePostMessage(&message, post, exported.attachments, participantsByChannel[channelId], fileAttachmentBackend, domain)
+			if err != nil {
+				return warningCount, model.NewAppError("EmlExport", "ent.compliance.eml.post.export.appError", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			for _, attachment := range missing {
+				missingFiles = append(missingFiles, "Warning:"+common_export.MissingFileMessage+" - Post: "+*post.PostId+" - "+attachment.Path)
+				rctx.Logger().Warn(common_export.MissingFileMessage, mlog.String("PostId", *post.PostId), mlog.String("FileName", attachment.Path))
+			}
+
+			if mboxFile != nil {
+				err = writeMboxMessage(mboxFile, *post.UserEmail, *post.PostCreateAt, message.Bytes())
+			} else {
+				var emlFile io.Writer
+				emlFile, err = zipFile.Create(uniqueFilename(usedFilenames, path.Join(channelId, *post.PostId), ".eml"))
+				if err == nil {
+					_, err = emlFile.Write(message.Bytes())
+				}
+			}
+			if err != nil {
+				return warningCount, model.NewAppError("EmlExport", "ent.compliance.eml.post.export.appError", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+		}
+	}
+
+	warningCount = int64(len(missingFiles))
+	if warningCount > 0 {
+		warningFile, err := zipFile.Create(EmlWarningFilename)
+		if err != nil {
+			return warningCount, model.NewAppError("EmlExport", "ent.compliance.eml.warning.appError", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		for _, value := range missingFiles {
+			if _, err = warningFile.Write([]byte(value + "\n")); err != nil {
+				return warningCount, model.NewAppError("EmlExport", "ent.compliance.eml.warning.appError", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+		}
+	}
+
+	metadataFile, err := zipFile.Create("metadata.json")
+	if err != nil {
+		return warningCount, model.NewAppError("EmlExport", "ent.compliance.eml.metadata.export.appError", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return warningCount, model.NewAppError("EmlExport", "ent.compliance.eml.metadata.export.appError", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if _, err = metadataFile.Write(data); err != nil {
+		return warningCount, model.NewAppError("EmlExport", "ent.compliance.eml.metadata.export.appError", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if err = zipFile.Close(); err != nil {
+		return warningCount, model.NewAppError("EmlExport", "ent.compliance.eml.zip.close.appError", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if _, err = dest.Seek(0, 0); err != nil {
+		return warningCount, model.NewAppError("EmlExport", "ent.compliance.eml.seek.appError", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	// Try to write the file without a timeout due to the potential size of the file.
+	_, err = filestore.TryWriteFileContext(rctx.Context(), exportBackend, dest, path.Join(exportDirectory, exportFilename))
+	if err != nil {
+		return warningCount, model.NewAppError("EmlExport", "ent.compliance.eml.write_file.appError", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return warningCount, nil
+}
+
+func getPostAttachments(db store.Store, post *model.MessageExport) ([]*model.FileInfo, *model.AppError) {
+	// if the post included any files, we need to add them as MIME parts to the message.
+	if len(post.PostFileIds) == 0 {
+		return []*model.FileInfo{}, nil
+	}
+
+	attachments, err := db.FileInfo().GetForPost(*post.PostId, true, true, false)
+	if err != nil {
+		return nil, model.NewAppError("getPostAttachments", "ent.message_export.eml_export.get_attachment_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return attachments, nil
+}
+
+// getParticipantsByChannel returns, for every exported channel, the addresses of the users that
+// were members of the channel at some point during the export period.
+func getParticipantsByChannel(channels map[string]common_export.MetadataChannel, membersByChannel common_export.MembersByChannel, db store.Store, domain string) (map[string][]*mail.Address, *model.AppError) {
+	participantsByChannel := map[string][]*mail.Address{}
+	for _, channel := range channels {
+		channelMembersHistory, err := db.ChannelMemberHistory().GetUsersInChannelDuring(channel.StartTime, channel.EndTime, channel.ChannelId)
+		if err != nil {
+			return nil, model.NewAppError("getParticipantsByChannel", "ent.get_users_in_channel_during", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		joins, _ := common_export.GetJoinsAndLeavesForChannel(channel.StartTime, channel.EndTime, channelMembersHistory, membersByChannel[channel.ChannelId])
+		seen := map[string]bool{}
+		participants := []*mail.Address{}
+		for _, join := range joins {
+			if seen[join.UserId] {
+				continue
+			}
+			seen[join.UserId] = true
+			participants = append(participants, userAddress(join.Username, join.Email, domain))
+		}
+		sort.Slice(participants, func(i, j int) bool {
+			return participants[i].Address < participants[j].Address
+		})
+		participantsByChannel[channel.ChannelId] = participants
+	}
+	return participantsByChannel, nil
+}
+
+func writePostMessage(w io.Writer, post *model.MessageExport, attachments []*model.FileInfo, participants []*mail.Address, fileAttachmentBackend filestore.FileBackend, domain string) (missing []*model.FileInfo, err error) {
+	header := &messageHeader{}
+	header.add("From", userAddress(*post.Username, *post.UserEmail, domain).String())
+	if len(participants) > 0 {
+		recipients := make([]string, 0, len(participants))
+		for _, participant := range participants {
+			recipients = append(recipients, participant.String())
+		}
+		header.add("To", strings.Join(recipients, ", "))
+	} else {
+		header.add("To", "undisclosed-recipients:;")
+	}
+	header.add("Subject", encodeHeaderValue(channelDisplayName(post)))
+	header.add("Date", time.UnixMilli(*post.PostCreateAt).UTC().Format(time.RFC1123Z))
+	header.add("Message-ID", messageID(*post.PostId, domain))
+	if post.PostRootId != nil && *post.PostRootId != "" {
+		header.add("In-Reply-To", messageID(*post.PostRootId, domain))
+		header.add("References", messageID(*post.PostRootId, domain))
+	}
+	header.add("MIME-Version", "1.0")
+
+	if post.TeamId != nil && *post.TeamId != "" {
+		header.add("X-Mattermost-Team-Id", *post.TeamId)
+		header.add("X-Mattermost-Team-Name", encodeHeaderValue(*post.TeamName))
+		header.add("X-Mattermost-Team-Display-Name", encodeHeaderValue(*post.TeamDisplayName))
+	}
+	header.add("X-Mattermost-Channel-Id", *post.ChannelId)
+	header.add("X-Mattermost-Channel-Name", encodeHeaderValue(*post.ChannelName))
+	header.add("X-Mattermost-Channel-Display-Name", encodeHeaderValue(*post.ChannelDisplayName))
+	header.add("X-Mattermost-Channel-Type", common_export.ChannelTypeDisplayName(*post.ChannelType))
+	header.add("X-Mattermost-Post-Id", *post.PostId)
+	if post.PostOriginalId != nil && *post.PostOriginalId != "" {
+		header.add("X-Mattermost-Edited-By-Post-Id", *post.PostOriginalId)
+	}
+	if previewID := post.PreviewID(); previewID != "" {
+		header.add("X-Mattermost-Previews-Post-Id", previewID)
+	}
+	postType := "message"
+	if *post.PostType != "" {
+		postType = *post.PostType
+	}
+	header.add("X-Mattermost-Post-Type", postType)
+	userType := "user"
+	if post.IsBot {
+		userType = "bot"
+	}
+	header.add("X-Mattermost-User-Type", userType)
+	if post.PostDeleteAt != nil && *post.PostDeleteAt > 0 {
+		header.add("X-Mattermost-Deleted-At", time.UnixMilli(*post.PostDeleteAt).UTC().Format(time.RFC1123Z))
+	}
+
+	if len(attachments) == 0 {
+		header.add("Content-Type", "text/plain; charset=UTF-8")
+		header.add("Content-Transfer-Encoding", "quoted-printable")
+		if err = header.write(w); err != nil {
+			return nil, err
+		}
+		return nil, writeQuotedPrintable(w, *post.PostMessage)
+	}
+
+	mw := multipart.NewWriter(w)
+	header.add("Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": mw.Boundary()}))
+	if err = header.write(w); err != nil {
+		return nil, err
+	}
+
+	textPart, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=UTF-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = writeQuotedPrintable(textPart, *post.PostMessage); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range attachments {
+		attachmentSrc, nErr := fileAttachmentBackend.Reader(attachment.Path)
+		if nErr != nil {
+			missing = append(missing, attachment)
+			continue
+		}
+
+		err = writeAttachmentPart(mw, attachment, attachmentSrc)
+		attachmentSrc.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return missing, mw.Close()
+}
+
+func writeAttachmentPart(mw *multipart.Writer, attachment *model.FileInfo, src io.Reader) error {
+	contentType := attachment.MimeType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	partHeader := textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name})},
+		"X-Mattermost-File-Id":      {attachment.Id},
+	}
+	if attachment.DeleteAt > 0 {
+		partHeader.Set("X-Mattermost-Deleted-At", time.UnixMilli(attachment.DeleteAt).UTC().Format(time.RFC1123Z))
+	}
+
+	part, err := mw.CreatePart(partHeader)
+	if err != nil {
+		return err
+	}
+	encoder := base64.NewEncoder(base64.StdEncoding, &lineWrapper{w: part, lineLength: base64LineLength})
+	if _, err = io.Copy(encoder, src); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// writeMboxMessage appends a message to an mbox file using the mboxrd conventions: a "From "
+// separator line, and every line of the message starting with ">*From " quoted with ">".
+func writeMboxMessage(w io.Writer, sender string, createAt int64, message []byte) error {
+	if sender == "" {
+		sender = "MAILER-DAEMON"
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From %s %s\n", sender, time.UnixMilli(createAt).UTC().Format(time.ANSIC))
+	for _, line := range bytes.Split(bytes.TrimRight(message, "\r\n"), []byte("\n")) {
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if bytes.HasPrefix(bytes.TrimLeft(line, ">"), []byte("From ")) {
+			buf.WriteByte('>')
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+type messageHeader struct {
+	fields [][2]string
+}
+
+func (h *messageHeader) add(key, value string) {
+	h.fields = append(h.fields, [2]string{key, headerValueReplacer.Replace(value)})
+}
+
+func (h *messageHeader) write(w io.Writer) error {
+	var buf bytes.Buffer
+	for _, field := range h.fields {
+		buf.WriteString(field[0])
+		buf.WriteString(": ")
+		buf.WriteString(field[1])
+		buf.WriteString("\r\n")
+	}
+	buf.WriteString("\r\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// lineWrapper breaks the written data into lines of at most lineLength bytes, as
+// required for base64 encoded MIME parts.
+type lineWrapper struct {
+	w          io.Writer
+	lineLength int
+	written    int
+}
+
+func (lw *lineWrapper) Write(p []byte) (int, error) {
+	total := 0
+	for len(p) > 0 {
+		if lw.written == lw.lineLength {
+			if _, err := lw.w.Write([]byte("\r\n")); err != nil {
+				return total, err
+			}
+			lw.written = 0
+		}
+		n := min(len(p), lw.lineLength-lw.written)
+		if _, err := lw.w.Write(p[:n]); err != nil {
+			return total, err
+		}
+		lw.written += n
+		total += n
+		p = p[n:]
+	}
+	return total, nil
+}
+
+func writeQuotedPrintable(w io.Writer, text string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(text)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+func userAddress(username, email, domain string) *mail.Address {
+	if email == "" {
+		email = username + "@" + domain
+	}
+	return &mail.Address{Name: username, Address: email}
+}
+
+func messageID(postId, domain string) string {
+	return "<" + postId + "@" + domain + ">"
+}
+
+func messageIDDomain(siteURL string) string {
+	if siteURL == "" {
+		return DefaultMessageIDDomain
+	}
+	u, err := url.Parse(siteURL)
+	if err != nil || u.Hostname() == "" {
+		return DefaultMessageIDDomain
+	}
+	return u.Hostname()
+}
+
+func channelDisplayName(post *model.MessageExport) string {
+	if *post.ChannelDisplayName != "" {
+		return *post.ChannelDisplayName
+	}
+	return *post.ChannelName
+}
+
+func encodeHeaderValue(value string) string {
+	return mime.QEncoding.Encode("UTF-8", value)
+}
+
+func uniqueFilename(used map[string]bool, name string, extension string) string {
+	filename := name + extension
+	for i := 1; used[filename]; i++ {
+		filename = name + "-" + strconv.Itoa(i) + extension
+	}
+	used[filename] = true
+	return filename
+}
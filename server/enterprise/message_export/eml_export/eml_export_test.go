@@ -0,0 +1,299 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package eml_export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+func newTestPost(id string, createAt int64, message string) *model.MessageExport {
+	chanTypeOpen := model.ChannelTypeOpen
+	return &model.MessageExport{
+		PostId:             model.NewPointer(id),
+		PostOriginalId:     model.NewPointer(""),
+		PostRootId:         model.NewPointer(""),
+		TeamId:             model.NewPointer("team-id"),
+		TeamName:           model.NewPointer("team-name"),
+		TeamDisplayName:    model.NewPointer("Team Display Name"),
+		ChannelId:          model.NewPointer("channel-id"),
+		ChannelName:        model.NewPointer("channel-name"),
+		ChannelDisplayName: model.NewPointer("Chännel Display Name"),
+		ChannelType:        &chanTypeOpen,
+		PostCreateAt:       model.NewPointer(createAt),
+		PostMessage:        model.NewPointer(message),
+		PostType:           model.NewPointer(""),
+		UserEmail:          model.NewPointer("user@test.com"),
+		UserId:             model.NewPointer("user-id"),
+		Username:           model.NewPointer("username"),
+		PostFileIds:        []string{},
+	}
+}
+
+func newTestFileBackend(t *testing.T) filestore.FileBackend {
+	tempDir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err = os.RemoveAll(tempDir)
+		assert.NoError(t, err)
+	})
+
+	fileBackend, err := filestore.NewFileBackend(filestore.FileBackendSettings{
+		DriverName: model.ImageDriverLocal,
+		Directory:  tempDir,
+	})
+	require.NoError(t, err)
+	return fileBackend
+}
+
+func readZipFiles(t *testing.T, backend filestore.FileBackend, filename string) map[string]string {
+	zipBytes, err := backend.ReadFile(filename)
+	require.NoError(t, err)
+
+	zipReader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	for _, file := range zipReader.File {
+		r, err := file.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+		files[file.Name] = string(data)
+	}
+	return files
+}
+
+func TestWritePostMessage(t *testing.T) {
+	participants := []*mail.Address{
+		{Name: "other", Address: "other@test.com"},
+		{Name: "username", Address: "user@test.com"},
+	}
+
+	t.Run("plain text reply", func(t *testing.T) {
+		post := newTestPost("post-id", 1700000000000, "Héllo\nworld")
+		post.PostRootId = model.NewPointer("root-id")
+
+		var buf bytes.Buffer
+		missing, err := writePostMessage(&buf, post, nil, participants, nil, "example.com")
+		require.NoError(t, err)
+		require.Empty(t, missing)
+
+		msg, err := mail.ReadMessage(&buf)
+		require.NoError(t, err)
+
+		assert.Equal(t, `"username" <user@test.com>`, msg.Header.Get("From"))
+		to, err := msg.Header.AddressList("To")
+		require.NoError(t, err)
+		require.Len(t, to, 2)
+		assert.Equal(t, "other@test.com", to[0].Address)
+		assert.Equal(t, "<post-id@example.com>", msg.Header.Get("Message-ID"))
+		assert.Equal(t, "<root-id@example.com>", msg.Header.Get("In-Reply-To"))
+		assert.Equal(t, "<root-id@example.com>", msg.Header.Get("References"))
+		assert.Equal(t, "channel-id", msg.Header.Get("X-Mattermost-Channel-Id"))
+		assert.Equal(t, "public", msg.Header.Get("X-Mattermost-Channel-Type"))
+		assert.Equal(t, "message", msg.Header.Get("X-Mattermost-Post-Type"))
+		assert.Equal(t, "user", msg.Header.Get("X-Mattermost-User-Type"))
+
+		subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, "Chännel Display Name", subject)
+
+		date, err := msg.Header.Date()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1700000000000), date.UnixMilli())
+
+		body, err := io.ReadAll(msg.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "H=C3=A9llo\r\nworld", string(body))
+	})
+
+	t.Run("root post without participants", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := writePostMessage(&buf, newTestPost("post-id", 1, "message"), nil, nil, nil, "example.com")
+		require.NoError(t, err)
+
+		msg, err := mail.ReadMessage(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, "undisclosed-recipients:;", msg.Header.Get("To"))
+		assert.Empty(t, msg.Header.Get("In-Reply-To"))
+		assert.Empty(t, msg.Header.Get("References"))
+	})
+
+	t.Run("header injection", func(t *testing.T) {
+		post := newTestPost("post-id", 1, "message")
+		post.ChannelName = model.NewPointer("channel\r\nBcc: evil@test.com")
+
+		var buf bytes.Buffer
+		_, err := writePostMessage(&buf, post, nil, nil, nil, "example.com")
+		require.NoError(t, err)
+
+		msg, err := mail.ReadMessage(&buf)
+		require.NoError(t, err)
+		assert.Empty(t, msg.Header.Get("Bcc"))
+	})
+
+	t.Run("attachments", func(t *testing.T) {
+		fileBackend := newTestFileBackend(t)
+		content := bytes.Repeat([]byte("attachment content "), 20)
+		_, err := fileBackend.WriteFile(bytes.NewReader(content), "path/file.txt")
+		require.NoError(t, err)
+
+		attachments := []*model.FileInfo{
+			{Id: "file-id", Name: "file.txt", Path: "path/file.txt", MimeType: "text/plain"},
+			{Id: "missing-id", Name: "missing.txt", Path: "path/missing.txt"},
+		}
+
+		var buf bytes.Buffer
+		missing, err := writePostMessage(&buf, newTestPost("post-id", 1, "message"), attachments, participants, fileBackend, "example.com")
+		require.NoError(t, err)
+		require.Len(t, missing, 1)
+		assert.Equal(t, "missing-id", missing[0].Id)
+
+		msg, err := mail.ReadMessage(&buf)
+		require.NoError(t, err)
+		mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		require.Equal(t, "multipart/mixed", mediaType)
+
+		mr := multipart.NewReader(msg.Body, params["boundary"])
+		textPart, err := mr.NextPart()
+		require.NoError(t, err)
+		text, err := io.ReadAll(textPart)
+		require.NoError(t, err)
+		assert.Equal(t, "message", string(text))
+
+		filePart, err := mr.NextRawPart()
+		require.NoError(t, err)
+		assert.Equal(t, "file.txt", filePart.FileName())
+		assert.Equal(t, "file-id", filePart.Header.Get("X-Mattermost-File-Id"))
+		raw, err := io.ReadAll(filePart)
+		require.NoError(t, err)
+		for _, line := range strings.Split(strings.TrimSpace(string(raw)), "\r\n") {
+			assert.LessOrEqual(t, len(line), base64LineLength)
+		}
+
+		_, err = mr.NextPart()
+		require.Equal(t, io.EOF, err)
+	})
+}
+
+func TestWriteMboxMessage(t *testing.T) {
+	var buf bytes.Buffer
+	message := []byte("Subject: test\r\n\r\nFrom the start\r\n>From quoted\r\nnot From\r\n")
+	err := writeMboxMessage(&buf, "user@test.com", 0, message)
+	require.NoError(t, err)
+
+	assert.Equal(t, strings.Join([]string{
+		"From user@test.com Thu Jan  1 00:00:00 1970",
+		"Subject: test",
+		"",
+		">From the start",
+		">>From quoted",
+		"not From",
+		"",
+		"",
+	}, "\n"), buf.String())
+}
+
+func TestMessageIDDomain(t *testing.T) {
+	assert.Equal(t, DefaultMessageIDDomain, messageIDDomain(""))
+	assert.Equal(t, DefaultMessageIDDomain, messageIDDomain("not a url"))
+	assert.Equal(t, "chat.example.com", messageIDDomain("https://chat.example.com:8065/subpath"))
+}
+
+func TestEmlExport(t *testing.T) {
+	rctx := request.TestContext(t)
+
+	cmhs := []*model.ChannelMemberHistoryResult{
+		{JoinTime: 0, UserId: "other-id", UserEmail: "other@test.com", Username: "other"},
+	}
+
+	posts := []*model.MessageExport{
+		newTestPost("post-id-1", 1, "first"),
+		newTestPost("post-id-2", 2, "second"),
+		newTestPost("post-id-2", 2, "second, edited"),
+	}
+	posts[1].PostRootId = model.NewPointer("post-id-1")
+	posts[1].PostFileIds = []string{"file-id"}
+
+	attachments := []*model.FileInfo{
+		{Id: "file-id", Name: "file.txt", Path: "path/file.txt"},
+	}
+
+	setupStore := func() *storetest.Store {
+		mockStore := &storetest.Store{}
+		mockStore.FileInfoStore.On("GetForPost", "post-id-2", true, true, false).Return(attachments, nil).Once()
+		mockStore.ChannelMemberHistoryStore.On("GetUsersInChannelDuring", int64(1), int64(2), "channel-id").Return(cmhs, nil).Once()
+		return mockStore
+	}
+
+	t.Run("eml", func(t *testing.T) {
+		mockStore := setupStore()
+		defer mockStore.AssertExpectations(t)
+		exportBackend := newTestFileBackend(t)
+		attachmentBackend := newTestFileBackend(t)
+
+		warningCount, appErr := EmlExport(rctx, posts, mockStore, exportBackend, attachmentBackend, "test", "https://chat.example.com")
+		require.Nil(t, appErr)
+		assert.Equal(t, int64(1), warningCount)
+
+		files := readZipFiles(t, exportBackend, "test/"+EmlExportFilename)
+		require.Len(t, files, 5)
+		require.Contains(t, files, "channel-id/post-id-1.eml")
+		require.Contains(t, files, "channel-id/post-id-2.eml")
+		require.Contains(t, files, "channel-id/post-id-2-1.eml")
+		require.Contains(t, files, EmlWarningFilename)
+		require.Contains(t, files, "metadata.json")
+		assert.Contains(t, files[EmlWarningFilename], "post-id-2 - path/file.txt")
+		assert.Contains(t, files["metadata.json"], `"MessagesCount": 3`)
+
+		msg, err := mail.ReadMessage(strings.NewReader(files["channel-id/post-id-2.eml"]))
+		require.NoError(t, err)
+		assert.Equal(t, "<post-id-1@chat.example.com>", msg.Header.Get("In-Reply-To"))
+		to, err := msg.Header.AddressList("To")
+		require.NoError(t, err)
+		require.Len(t, to, 2)
+		assert.Equal(t, "other@test.com", to[0].Address)
+		assert.Equal(t, "user@test.com", to[1].Address)
+	})
+
+	t.Run("mbox", func(t *testing.T) {
+		mockStore := setupStore()
+		defer mockStore.AssertExpectations(t)
+		exportBackend := newTestFileBackend(t)
+		attachmentBackend := newTestFileBackend(t)
+		_, err := attachmentBackend.WriteFile(bytes.NewReader([]byte("content")), "path/file.txt")
+		require.NoError(t, err)
+
+		warningCount, appErr := MboxExport(rctx, posts, mockStore, exportBackend, attachmentBackend, "test", "")
+		require.Nil(t, appErr)
+		assert.Equal(t, int64(0), warningCount)
+
+		files := readZipFiles(t, exportBackend, "test/"+MboxExportFilename)
+		require.Len(t, files, 2)
+		require.Contains(t, files, "metadata.json")
+		mbox := files["channel-id.mbox"]
+		assert.True(t, strings.HasPrefix(mbox, "From user@test.com "))
+		assert.Equal(t, 3, strings.Count("\n"+mbox, "\nFrom user@test.com "))
+		assert.Contains(t, mbox, "Message-ID: <post-id-1@mattermost>")
+		assert.Contains(t, mbox, "Content-Disposition: attachment; filename=file.txt")
+	})
+}
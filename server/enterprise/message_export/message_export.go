@@ -28,6 +28,7 @@ import (
 
 	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/actiance_export"
 	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/csv_export"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/eml_export"
 	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/global_relay_export"
 )
 
@@ -188,6 +189,14 @@ func runExportByType(rctx request.CTX, exportType string, postsToExport []*model
 		rctx.Logger().Debug("Exporting Actiance")
 		return actiance_export.ActianceExport(rctx, postsToExport, db, exportBackend, fileAttachmentBackend, exportDirectory)
 
+	case model.ComplianceExportTypeEml:
+		rctx.Logger().Debug("Exporting EML")
+		return eml_export.EmlExport(rctx, postsToExport, db, exportBackend, fileAttachmentBackend, exportDirectory, siteURL(config))
+
+	case model.ComplianceExportTypeMbox:
+		rctx.Logger().Debug("Exporting MBOX")
+		return eml_export.MboxExport(rctx, postsToExport, db, exportBackend, fileAttachmentBackend, exportDirectory, siteURL(config))
+
 	case model.ComplianceExportTypeGlobalrelay, model.ComplianceExportTypeGlobalrelayZip:
 		rctx.Logger().Debug("Exporting GlobalRelay")
 		f, err := os.CreateTemp("", "")
@@ -232,3 +241,10 @@ func runExportByType(rctx request.CTX, exportType string, postsToExport []*model
 	}
 	return warningCount, nil
 }
+
+func siteURL(config *model.Config) string {
+	if config == nil || config.ServiceSettings.SiteURL == nil {
+		return ""
+	}
+	return *config.ServiceSettings.SiteURL
+}
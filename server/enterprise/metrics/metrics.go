@@ -72,6 +72,8 @@ type MetricsInterfaceImpl struct {
 	HTTPErrorsCounter   prometheus.Counter
 	HTTPWebsocketsGauge *prometheus.GaugeVec
 
+	RateLimitRejectionsCounter *prometheus.CounterVec
+
 	ClusterRequestsDuration prometheus.Histogram
 	ClusterRequestsCounter  prometheus.Counter
 
@@ -94,6 +96,9 @@ type MetricsInterfaceImpl struct {
 	MemCacheMissCounterSession         prometheus.Counter
 	MemCacheInvalidationCounterSession prometheus.Counter
 
+	FileStoreCacheHitCounter  prometheus.Counter
+	FileStoreCacheMissCounter prometheus.Counter
+
 	WebsocketEventCounters *prometheus.CounterVec
 
 	WebSocketBroadcastCounters                    *prometheus.CounterVec
@@ -414,6 +419,15 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	})
 	m.Registry.MustRegister(m.HTTPErrorsCounter)
 
+	m.RateLimitRejectionsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemHTTP,
+		Name:        "rate_limit_rejections_total",
+		Help:        "The total number of http API requests rejected by the rate limiter.",
+		ConstLabels: additionalLabels,
+	}, []string{"profile"})
+	m.Registry.MustRegister(m.RateLimitRejectionsCounter)
+
 	// Cluster Subsystem
 
 	m.ClusterHealthGauge = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
@@ -588,6 +602,24 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	m.Registry.MustRegister(m.MemCacheInvalidationCounters)
 	m.MemCacheInvalidationCounterSession = m.MemCacheInvalidationCounters.With(prometheus.Labels{"name": "Session"})
 
+	m.FileStoreCacheHitCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemCaching,
+		Name:        "filestore_hit_total",
+		Help:        "Total number of file store local cache hits",
+		ConstLabels: additionalLabels,
+	})
+	m.Registry.MustRegister(m.FileStoreCacheHitCounter)
+
+	m.FileStoreCacheMissCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemCaching,
+		Name:        "filestore_miss_total",
+		Help:        "Total number of file store local cache misses",
+		ConstLabels: additionalLabels,
+	})
+	m.Registry.MustRegister(m.FileStoreCacheMissCounter)
+
 	// Websocket Subsystem
 
 	m.WebSocketBroadcastCounters = prometheus.NewCounterVec(
@@ -1536,6 +1568,10 @@ func (mi *MetricsInterfaceImpl) IncrementHTTPError() {
 	mi.HTTPErrorsCounter.Inc()
 }
 
+func (mi *MetricsInterfaceImpl) IncrementRateLimitRejection(profile string) {
+	mi.RateLimitRejectionsCounter.With(prometheus.Labels{"profile": profile}).Inc()
+}
+
 func (mi *MetricsInterfaceImpl) IncrementClusterRequest() {
 	mi.ClusterRequestsCounter.Inc()
 }
@@ -1603,6 +1639,14 @@ func (mi *MetricsInterfaceImpl) IncrementMemCacheHitCounterSession() {
 	mi.MemCacheHitCounterSession.Inc()
 }
 
+func (mi *MetricsInterfaceImpl) IncrementFileStoreCacheHitCounter() {
+	mi.FileStoreCacheHitCounter.Inc()
+}
+
+func (mi *MetricsInterfaceImpl) IncrementFileStoreCacheMissCounter() {
+	mi.FileStoreCacheMissCounter.Inc()
+}
+
 func (mi *MetricsInterfaceImpl) IncrementMemCacheInvalidationCounterSession() {
 	mi.MemCacheInvalidationCounterSession.Inc()
 }
@@ -5,6 +5,7 @@ package docextractor
 
 import (
 	"io"
+	"time"
 
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
@@ -14,6 +15,14 @@ type ExtractSettings struct {
 	ArchiveRecursion bool
 	MMPreviewURL     string
 	MMPreviewSecret  string
+
+	// OCREngine enables the text recognition of images and scanned PDF
+	// files when set. Files larger than the size limits are skipped, and a
+	// zero limit or timeout means no limit.
+	OCREngine       OCREngine
+	OCRMaxImageSize int64
+	OCRMaxPDFSize   int64
+	OCRTimeout      time.Duration
 }
 
 // Extract extract the text from a document using the system default extractors
@@ -29,6 +38,9 @@ func ExtractWithExtraExtractors(logger mlog.LoggerIFace, filename string, r io.R
 	for _, extraExtractor := range extraExtractors {
 		enabledExtractors.Add(extraExtractor)
 	}
+	if settings.OCREngine != nil {
+		enabledExtractors.Add(newOCRExtractor(settings))
+	}
 	enabledExtractors.Add(&documentExtractor{})
 	enabledExtractors.Add(&pdfExtractor{})
 
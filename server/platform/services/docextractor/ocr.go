@@ -0,0 +1,152 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+// The OCR extractor recognizes the text contained in images and in scanned
+// PDF files without a text layer. The recognition itself is delegated to an
+// OCREngine, so different OCR backends can be plugged in through the
+// ExtractSettings.
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// OCREngine recognizes the text contained in an image or in a scanned document.
+type OCREngine interface {
+	Name() string
+	Recognize(ctx context.Context, filename string, r io.ReadSeeker) (string, error)
+}
+
+var ocrImageExtensions = map[string]bool{
+	"png":  true,
+	"jpg":  true,
+	"jpeg": true,
+	"gif":  true,
+	"bmp":  true,
+	"tif":  true,
+	"tiff": true,
+	"webp": true,
+}
+
+// IsOCRSupportedExtension returns true if the OCR extractor is able to
+// process files with the given extension.
+func IsOCRSupportedExtension(extension string) bool {
+	extension = strings.ToLower(extension)
+	return ocrImageExtensions[extension] || extension == "pdf"
+}
+
+type ocrExtractor struct {
+	engine       OCREngine
+	maxImageSize int64
+	maxPDFSize   int64
+	timeout      time.Duration
+	pdfExtractor pdfExtractor
+}
+
+func newOCRExtractor(settings ExtractSettings) *ocrExtractor {
+	return &ocrExtractor{
+		engine:       settings.OCREngine,
+		maxImageSize: settings.OCRMaxImageSize,
+		maxPDFSize:   settings.OCRMaxPDFSize,
+		timeout:      settings.OCRTimeout,
+	}
+}
+
+func (oe *ocrExtractor) Name() string {
+	return "ocrExtractor:" + oe.engine.Name()
+}
+
+func (oe *ocrExtractor) Match(filename string) bool {
+	return IsOCRSupportedExtension(strings.TrimPrefix(path.Ext(filename), "."))
+}
+
+func (oe *ocrExtractor) Extract(filename string, r io.ReadSeeker) (string, error) {
+	maxSize := oe.maxImageSize
+	if strings.EqualFold(path.Ext(filename), ".pdf") {
+		// Only scanned documents go through the OCR engine, so we try
+		// the text layer first.
+		text, err := oe.pdfExtractor.Extract(filename, r)
+		if err == nil && strings.TrimSpace(text) != "" {
+			return text, nil
+		}
+		maxSize = oe.maxPDFSize
+	}
+
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to read the file size")
+	}
+	if maxSize > 0 && size > maxSize {
+		return "", errors.Errorf("file too large for OCR: %d bytes exceeds the limit of %d bytes", size, maxSize)
+	}
+	if _, err = r.Seek(0, io.SeekStart); err != nil {
+		return "", errors.Wrap(err, "unable to rewind the file")
+	}
+
+	ctx := context.Background()
+	if oe.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, oe.timeout)
+		defer cancel()
+	}
+
+	return oe.engine.Recognize(ctx, filename, r)
+}
+
+// remoteOCREngine sends the files to an OCR micro-service, which answers
+// with the recognized plain text. The service is responsible for
+// rasterizing the PDF pages.
+type remoteOCREngine struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewRemoteOCREngine creates an OCREngine backed by the OCR service listening on url.
+func NewRemoteOCREngine(url string, secret string) OCREngine {
+	return &remoteOCREngine{
+		url:    strings.TrimSuffix(url, "/"),
+		secret: secret,
+		client: http.DefaultClient,
+	}
+}
+
+func (roe *remoteOCREngine) Name() string {
+	return "remoteOCREngine"
+}
+
+func (roe *remoteOCREngine) Recognize(ctx context.Context, filename string, r io.ReadSeeker) (string, error) {
+	b, w, err := createMultipartFormData("file", filename, r)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to prepare the OCR request")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, roe.url+"/ocr", &b)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to prepare the OCR request")
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	if roe.secret != "" {
+		req.Header.Add("Authentication", roe.secret)
+	}
+	resp, err := roe.client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to reach the OCR service")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("the OCR service has replied with status code %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to read the response from the OCR service")
+	}
+	return string(data), nil
+}
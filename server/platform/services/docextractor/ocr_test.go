@@ -0,0 +1,157 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/utils/testutils"
+)
+
+type testOCREngine struct {
+	calls int
+	delay time.Duration
+}
+
+func (te *testOCREngine) Name() string {
+	return "testOCREngine"
+}
+
+func (te *testOCREngine) Recognize(ctx context.Context, filename string, r io.ReadSeeker) (string, error) {
+	te.calls++
+	select {
+	case <-time.After(te.delay):
+		return "recognized text from " + filename, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func TestOCRExtractor(t *testing.T) {
+	t.Run("image file", func(t *testing.T) {
+		engine := &testOCREngine{}
+		extractor := newOCRExtractor(ExtractSettings{OCREngine: engine})
+		content, err := testutils.ReadTestFile("testjpg.jpg")
+		require.NoError(t, err)
+
+		require.True(t, extractor.Match("testjpg.jpg"))
+		text, err := extractor.Extract("testjpg.jpg", bytes.NewReader(content))
+		require.NoError(t, err)
+		assert.Equal(t, "recognized text from testjpg.jpg", text)
+		assert.Equal(t, 1, engine.calls)
+	})
+
+	t.Run("pdf file with a text layer", func(t *testing.T) {
+		engine := &testOCREngine{}
+		extractor := newOCRExtractor(ExtractSettings{OCREngine: engine})
+		content, err := testutils.ReadTestFile("sample-doc.pdf")
+		require.NoError(t, err)
+
+		text, err := extractor.Extract("sample-doc.pdf", bytes.NewReader(content))
+		require.NoError(t, err)
+		assert.Equal(t, "This is a simple document that contains some text.", text)
+		assert.Equal(t, 0, engine.calls)
+	})
+
+	t.Run("pdf file without a text layer", func(t *testing.T) {
+		engine := &testOCREngine{}
+		extractor := newOCRExtractor(ExtractSettings{OCREngine: engine})
+
+		text, err := extractor.Extract("scanned.pdf", bytes.NewReader([]byte("not a real pdf")))
+		require.NoError(t, err)
+		assert.Equal(t, "recognized text from scanned.pdf", text)
+		assert.Equal(t, 1, engine.calls)
+	})
+
+	t.Run("unsupported file", func(t *testing.T) {
+		extractor := newOCRExtractor(ExtractSettings{OCREngine: &testOCREngine{}})
+		require.False(t, extractor.Match("sample-doc.docx"))
+		require.False(t, extractor.Match("archive.zip"))
+	})
+
+	t.Run("size limits", func(t *testing.T) {
+		engine := &testOCREngine{}
+		extractor := newOCRExtractor(ExtractSettings{
+			OCREngine:       engine,
+			OCRMaxImageSize: 10,
+			OCRMaxPDFSize:   100,
+		})
+
+		_, err := extractor.Extract("image.png", bytes.NewReader(make([]byte, 11)))
+		require.Error(t, err)
+
+		_, err = extractor.Extract("scanned.pdf", bytes.NewReader(make([]byte, 50)))
+		require.NoError(t, err)
+
+		_, err = extractor.Extract("scanned.pdf", bytes.NewReader(make([]byte, 101)))
+		require.Error(t, err)
+		assert.Equal(t, 1, engine.calls)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		extractor := newOCRExtractor(ExtractSettings{
+			OCREngine:  &testOCREngine{delay: time.Second},
+			OCRTimeout: 10 * time.Millisecond,
+		})
+
+		_, err := extractor.Extract("image.png", bytes.NewReader([]byte("image")))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestRemoteOCREngine(t *testing.T) {
+	t.Run("successful recognition", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/ocr", r.URL.Path)
+			require.Equal(t, "secret", r.Header.Get("Authentication"))
+			file, header, err := r.FormFile("file")
+			require.NoError(t, err)
+			defer file.Close()
+			require.Equal(t, "image.png", header.Filename)
+			w.Write([]byte("hello from the image"))
+		}))
+		defer server.Close()
+
+		engine := NewRemoteOCREngine(server.URL+"/", "secret")
+		text, err := engine.Recognize(context.Background(), "image.png", bytes.NewReader([]byte("image")))
+		require.NoError(t, err)
+		assert.Equal(t, "hello from the image", text)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		engine := NewRemoteOCREngine(server.URL, "")
+		_, err := engine.Recognize(context.Background(), "image.png", bytes.NewReader([]byte("image")))
+		require.Error(t, err)
+	})
+}
+
+func TestExtractWithOCR(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(t)
+
+	data, err := testutils.ReadTestFile("testjpg.jpg")
+	require.NoError(t, err)
+
+	text, err := Extract(logger, "testjpg.jpg", bytes.NewReader(data), ExtractSettings{OCREngine: &testOCREngine{}})
+	require.NoError(t, err)
+	assert.Equal(t, "recognized text from testjpg.jpg", text)
+
+	text, err = Extract(logger, "testjpg.jpg", bytes.NewReader(data), ExtractSettings{})
+	require.NoError(t, err)
+	assert.Equal(t, "", text)
+}
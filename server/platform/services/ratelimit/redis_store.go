@@ -0,0 +1,133 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/rueidis"
+)
+
+// redisCASScript swaps the value of a key if it matches the expected one.
+// It returns -1 when the key does not exist, so that a missing key can be
+// told apart from a failed comparison.
+const redisCASScript = `
+local v = redis.call('get', KEYS[1])
+if v == false then
+  return -1
+end
+if v ~= ARGV[1] then
+  return 0
+end
+redis.call('set', KEYS[1], ARGV[2], 'px', ARGV[3])
+return 1
+`
+
+// RedisStore is a throttled.GCRAStore keeping the rate limiting state in
+// Redis, so that every node of a cluster shares the same counters.
+type RedisStore struct {
+	client    rueidis.Client
+	prefix    string
+	casScript *rueidis.Lua
+	timeout   time.Duration
+}
+
+// NewRedisStore creates a store using the given client. Keys are prefixed
+// with keyPrefix.
+func NewRedisStore(client rueidis.Client, keyPrefix string) *RedisStore {
+	return &RedisStore{
+		client:    client,
+		prefix:    keyPrefix,
+		casScript: rueidis.NewLuaScript(redisCASScript),
+		timeout:   5 * time.Second,
+	}
+}
+
+func (r *RedisStore) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), r.timeout)
+}
+
+// ttlMilliseconds returns the ttl in milliseconds, with a minimum of one
+// millisecond as a zero expiration is rejected by Redis.
+func ttlMilliseconds(ttl time.Duration) int64 {
+	if ms := ttl.Milliseconds(); ms > 0 {
+		return ms
+	}
+	return 1
+}
+
+// GetWithTime returns the value of the key, or -1 if it does not exist,
+// along with the current time of the Redis server.
+func (r *RedisStore) GetWithTime(key string) (int64, time.Time, error) {
+	ctx, cancel := r.context()
+	defer cancel()
+
+	results := r.client.DoMulti(ctx,
+		r.client.B().Time().Build(),
+		r.client.B().Get().Key(r.prefix+key).Build(),
+	)
+
+	serverTime, err := results[0].AsStrSlice()
+	if err != nil {
+		return 0, time.Time{}, errors.Wrap(err, "failed to get the time of the Redis server")
+	}
+	if len(serverTime) != 2 {
+		return 0, time.Time{}, errors.New("unexpected response to the Redis TIME command")
+	}
+	seconds, err := strconv.ParseInt(serverTime[0], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, errors.Wrap(err, "failed to parse the time of the Redis server")
+	}
+	microseconds, err := strconv.ParseInt(serverTime[1], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, errors.Wrap(err, "failed to parse the time of the Redis server")
+	}
+	now := time.Unix(seconds, microseconds*int64(time.Microsecond))
+
+	value, err := results[1].AsInt64()
+	if rueidis.IsRedisNil(err) {
+		return -1, now, nil
+	} else if err != nil {
+		return 0, now, errors.Wrapf(err, "failed to get the rate limit key %q", key)
+	}
+
+	return value, now, nil
+}
+
+// SetIfNotExistsWithTTL sets the value of the key only if it does not exist
+// yet, and reports whether it was set.
+func (r *RedisStore) SetIfNotExistsWithTTL(key string, value int64, ttl time.Duration) (bool, error) {
+	ctx, cancel := r.context()
+	defer cancel()
+
+	err := r.client.Do(ctx, r.client.B().Set().Key(r.prefix+key).Value(strconv.FormatInt(value, 10)).Nx().PxMilliseconds(ttlMilliseconds(ttl)).Build()).Error()
+	if rueidis.IsRedisNil(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Wrapf(err, "failed to set the rate limit key %q", key)
+	}
+
+	return true, nil
+}
+
+// CompareAndSwapWithTTL atomically sets the key to the new value if it holds
+// the old one. It returns false with no error if the key does not exist.
+func (r *RedisStore) CompareAndSwapWithTTL(key string, old, new int64, ttl time.Duration) (bool, error) {
+	ctx, cancel := r.context()
+	defer cancel()
+
+	result, err := r.casScript.Exec(ctx, r.client, []string{r.prefix + key}, []string{
+		strconv.FormatInt(old, 10),
+		strconv.FormatInt(new, 10),
+		strconv.FormatInt(ttlMilliseconds(ttl), 10),
+	}).AsInt64()
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to swap the rate limit key %q", key)
+	}
+
+	return result == 1, nil
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/throttled/throttled"
+)
+
+func newTestStore(t *testing.T) (*RedisStore, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+
+	client, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{server.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	return NewRedisStore(client, "ratelimit:"), server
+}
+
+func TestRedisStore(t *testing.T) {
+	t.Run("missing key", func(t *testing.T) {
+		store, server := newTestStore(t)
+		now := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)
+		server.SetTime(now)
+
+		value, serverTime, err := store.GetWithTime("key")
+		require.NoError(t, err)
+		assert.Equal(t, int64(-1), value)
+		assert.True(t, now.Equal(serverTime))
+
+		swapped, err := store.CompareAndSwapWithTTL("key", 1, 2, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, swapped)
+	})
+
+	t.Run("set if not exists", func(t *testing.T) {
+		store, server := newTestStore(t)
+
+		set, err := store.SetIfNotExistsWithTTL("key", 10, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, set)
+
+		set, err = store.SetIfNotExistsWithTTL("key", 20, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, set)
+
+		value, _, err := store.GetWithTime("key")
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), value)
+		assert.True(t, server.Exists("ratelimit:key"))
+		assert.Equal(t, time.Minute, server.TTL("ratelimit:key"))
+	})
+
+	t.Run("compare and swap", func(t *testing.T) {
+		store, server := newTestStore(t)
+
+		_, err := store.SetIfNotExistsWithTTL("key", 10, time.Minute)
+		require.NoError(t, err)
+
+		swapped, err := store.CompareAndSwapWithTTL("key", 5, 20, time.Hour)
+		require.NoError(t, err)
+		assert.False(t, swapped)
+
+		swapped, err = store.CompareAndSwapWithTTL("key", 10, 20, time.Hour)
+		require.NoError(t, err)
+		assert.True(t, swapped)
+
+		value, _, err := store.GetWithTime("key")
+		require.NoError(t, err)
+		assert.Equal(t, int64(20), value)
+		assert.Equal(t, time.Hour, server.TTL("ratelimit:key"))
+	})
+
+	t.Run("rate limiter shares state across stores", func(t *testing.T) {
+		store, server := newTestStore(t)
+		server.SetTime(time.Now())
+
+		client, err := rueidis.NewClient(rueidis.ClientOption{
+			InitAddress:  []string{server.Addr()},
+			DisableCache: true,
+		})
+		require.NoError(t, err)
+		defer client.Close()
+		otherStore := NewRedisStore(client, "ratelimit:")
+
+		quota := throttled.RateQuota{MaxRate: throttled.PerMin(1), MaxBurst: 1}
+		limiter, err := throttled.NewGCRARateLimiter(store, quota)
+		require.NoError(t, err)
+		otherLimiter, err := throttled.NewGCRARateLimiter(otherStore, quota)
+		require.NoError(t, err)
+
+		limited, _, err := limiter.RateLimit("user", 1)
+		require.NoError(t, err)
+		assert.False(t, limited)
+
+		limited, _, err = otherLimiter.RateLimit("user", 1)
+		require.NoError(t, err)
+		assert.False(t, limited)
+
+		limited, result, err := otherLimiter.RateLimit("user", 1)
+		require.NoError(t, err)
+		assert.True(t, limited)
+		assert.Greater(t, result.RetryAfter, time.Duration(0))
+	})
+}
@@ -0,0 +1,516 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package rediscluster implements the cluster interface over Redis pub/sub,
+// so that a Mattermost cluster can run without the gossip mesh.
+//
+// Every node subscribes to a channel shared by the whole cluster and to a
+// channel of its own. The nodes register themselves in a Redis hash which is
+// refreshed on every heartbeat, and the leader is elected through a key
+// holding the ID of the leading node, which expires if the leader stops
+// renewing it.
+package rediscluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/rueidis"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+)
+
+const (
+	DefaultHeartbeatInterval = 5 * time.Second
+	DefaultRequestTimeout    = 10 * time.Second
+
+	// A node which missed this many heartbeats is considered gone.
+	missedHeartbeatsBeforeExpiry = 3
+
+	sendQueueSize      = 4096
+	publishTimeout     = 5 * time.Second
+	resubscribeBackoff = time.Second
+)
+
+// renewLeaderScript extends the leadership of a node, only if the node is
+// still the leader.
+var renewLeaderScript = rueidis.NewLuaScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// Node gives the cluster access to the server it is running in, to describe
+// the node and to answer the requests of the other nodes.
+type Node interface {
+	Config() *model.Config
+	// ClusterInfo describes the node. The ID is set by the cluster.
+	ClusterInfo() *model.ClusterInfo
+	// ClusterStats returns the statistics of the node. The ID is set by the cluster.
+	ClusterStats() *model.ClusterStats
+	GetLogs(rctx request.CTX, page, perPage int) ([]string, *model.AppError)
+	GetPluginStatuses() (model.PluginStatuses, *model.AppError)
+	WebConnCountForUser(userID string) int
+	SupportPacketFiles(rctx request.CTX, options *model.SupportPacketOptions) []model.FileData
+	// ApplyConfig is called when the configuration was changed by another node.
+	ApplyConfig(cfg *model.Config) error
+	// LeaderChanged is called when the node gains or loses the leadership.
+	LeaderChanged()
+}
+
+type Options struct {
+	// Client is owned by the cluster, which closes it when it stops.
+	Client rueidis.Client
+	// ClusterName isolates clusters sharing the same Redis server.
+	ClusterName       string
+	HeartbeatInterval time.Duration
+	RequestTimeout    time.Duration
+}
+
+// envelope is the unit sent over the Redis channels.
+type envelope struct {
+	NodeId string `json:"node_id"`
+	// RequestId is set for the requests sent to the other nodes and for
+	// their responses.
+	RequestId string                `json:"request_id,omitempty"`
+	Message   *model.ClusterMessage `json:"message"`
+}
+
+type nodeRecord struct {
+	Info     *model.ClusterInfo `json:"info"`
+	LastPing int64              `json:"last_ping"`
+}
+
+type Cluster struct {
+	node   Node
+	logger mlog.LoggerIFace
+	client rueidis.Client
+
+	id                string
+	prefix            string
+	heartbeatInterval time.Duration
+	requestTimeout    time.Duration
+
+	handlersMut sync.RWMutex
+	handlers    map[model.ClusterEvent]einterfaces.ClusterMessageHandler
+
+	pendingMut sync.Mutex
+	pending    map[string]chan *envelope
+
+	sendQueue  chan *envelope
+	isLeader   atomic.Bool
+	healthy    atomic.Bool
+	subscribed chan struct{}
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stop      context.CancelFunc
+	ctx       context.Context
+	wg        sync.WaitGroup
+}
+
+var _ einterfaces.ClusterInterface = (*Cluster)(nil)
+
+// New creates a cluster node communicating through the Redis server the client
+// is connected to. The node joins the cluster once StartInterNodeCommunication is called.
+func New(opts Options, node Node, logger mlog.LoggerIFace) (*Cluster, error) {
+	if opts.Client == nil {
+		return nil, errors.New("a redis client is required")
+	}
+	if opts.HeartbeatInterval <= 0 {
+		opts.HeartbeatInterval = DefaultHeartbeatInterval
+	}
+	if opts.RequestTimeout <= 0 {
+		opts.RequestTimeout = DefaultRequestTimeout
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Cluster{
+		node:              node,
+		logger:            logger.With(mlog.String("component", "redis_cluster")),
+		client:            opts.Client,
+		id:                model.NewId(),
+		prefix:            "mattermost:cluster:" + opts.ClusterName,
+		heartbeatInterval: opts.HeartbeatInterval,
+		requestTimeout:    opts.RequestTimeout,
+		handlers:          make(map[model.ClusterEvent]einterfaces.ClusterMessageHandler),
+		pending:           make(map[string]chan *envelope),
+		sendQueue:         make(chan *envelope, sendQueueSize),
+		subscribed:        make(chan struct{}),
+		ctx:               ctx,
+		stop:              cancel,
+	}, nil
+}
+
+func (c *Cluster) broadcastChannel() string {
+	return c.prefix + ":broadcast"
+}
+
+func (c *Cluster) nodeChannel(nodeID string) string {
+	return c.prefix + ":node:" + nodeID
+}
+
+func (c *Cluster) nodesKey() string {
+	return c.prefix + ":nodes"
+}
+
+func (c *Cluster) leaderKey() string {
+	return c.prefix + ":leader"
+}
+
+func (c *Cluster) StartInterNodeCommunication() {
+	c.startOnce.Do(func() {
+		c.logger.Info("Starting the redis cluster", mlog.String("node_id", c.id))
+
+		c.wg.Add(3)
+		go c.subscribe()
+		go c.sendLoop()
+		go c.heartbeatLoop()
+
+		// Waiting for the subscription avoids missing the messages sent right after startup.
+		select {
+		case <-c.subscribed:
+		case <-time.After(c.requestTimeout):
+			c.logger.Warn("Timed out waiting for the subscription to the cluster channels")
+		}
+	})
+}
+
+func (c *Cluster) StopInterNodeCommunication() {
+	c.stopOnce.Do(func() {
+		c.logger.Info("Stopping the redis cluster", mlog.String("node_id", c.id))
+		c.stop()
+		c.wg.Wait()
+
+		ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+		defer cancel()
+		if err := c.client.Do(ctx, c.client.B().Hdel().Key(c.nodesKey()).Field(c.id).Build()).Error(); err != nil {
+			c.logger.Warn("Failed to unregister the node from the cluster", mlog.Err(err))
+		}
+		if c.isLeader.Load() {
+			if err := c.client.Do(ctx, c.client.B().Del().Key(c.leaderKey()).Build()).Error(); err != nil {
+				c.logger.Warn("Failed to give up the cluster leadership", mlog.Err(err))
+			}
+		}
+
+		c.client.Close()
+	})
+}
+
+func (c *Cluster) RegisterClusterMessageHandler(event model.ClusterEvent, crm einterfaces.ClusterMessageHandler) {
+	c.handlersMut.Lock()
+	defer c.handlersMut.Unlock()
+	c.handlers[event] = crm
+}
+
+func (c *Cluster) GetClusterId() string {
+	return c.id
+}
+
+func (c *Cluster) IsLeader() bool {
+	return c.isLeader.Load()
+}
+
+// HealthScore is zero when the node is subscribed to the cluster channels and
+// its last heartbeat succeeded.
+func (c *Cluster) HealthScore() int {
+	if c.healthy.Load() {
+		return 0
+	}
+	return 1
+}
+
+func (c *Cluster) GetMyClusterInfo() *model.ClusterInfo {
+	info := c.node.ClusterInfo()
+	info.Id = c.id
+	return info
+}
+
+func (c *Cluster) GetClusterInfos() []*model.ClusterInfo {
+	records, err := c.liveNodes(c.ctx)
+	if err != nil {
+		c.logger.Warn("Failed to get the nodes of the cluster", mlog.Err(err))
+		return []*model.ClusterInfo{c.GetMyClusterInfo()}
+	}
+
+	infos := make([]*model.ClusterInfo, 0, len(records))
+	for _, record := range records {
+		infos = append(infos, record.Info)
+	}
+	return infos
+}
+
+// SendClusterMessage sends the message to all the other nodes. Reliable
+// messages and the ones waiting for all to send are published synchronously,
+// the others are queued.
+func (c *Cluster) SendClusterMessage(msg *model.ClusterMessage) {
+	env := &envelope{NodeId: c.id, Message: msg}
+
+	if msg.SendType == model.ClusterSendReliable || msg.WaitForAllToSend {
+		if err := c.publish(c.broadcastChannel(), env); err != nil {
+			c.logger.Error("Failed to send the cluster message", mlog.String("event", string(msg.Event)), mlog.Err(err))
+		}
+		return
+	}
+
+	select {
+	case c.sendQueue <- env:
+	default:
+		c.logger.Warn("The cluster send queue is full, dropping the message", mlog.String("event", string(msg.Event)))
+	}
+}
+
+func (c *Cluster) SendClusterMessageToNode(nodeID string, msg *model.ClusterMessage) error {
+	return c.publish(c.nodeChannel(nodeID), &envelope{NodeId: c.id, Message: msg})
+}
+
+// NotifyMsg handles an envelope received outside of the Redis subscription.
+func (c *Cluster) NotifyMsg(buf []byte) {
+	c.handleMessage(buf)
+}
+
+func (c *Cluster) publish(channel string, env *envelope) error {
+	buf, err := json.Marshal(env)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the cluster message")
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, publishTimeout)
+	defer cancel()
+
+	return c.client.Do(ctx, c.client.B().Publish().Channel(channel).Message(rueidis.BinaryString(buf)).Build()).Error()
+}
+
+func (c *Cluster) sendLoop() {
+	defer c.wg.Done()
+	for {
+		select {
+		case env := <-c.sendQueue:
+			if err := c.publish(c.broadcastChannel(), env); err != nil {
+				c.logger.Warn("Failed to send the cluster message", mlog.String("event", string(env.Message.Event)), mlog.Err(err))
+			}
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Cluster) subscribe() {
+	defer c.wg.Done()
+
+	var once sync.Once
+	for {
+		err := c.receive(func() {
+			c.healthy.Store(true)
+			once.Do(func() { close(c.subscribed) })
+		})
+		if c.ctx.Err() != nil {
+			return
+		}
+
+		c.healthy.Store(false)
+		c.logger.Warn("The subscription to the cluster channels was interrupted, resubscribing", mlog.Err(err))
+		select {
+		case <-time.After(resubscribeBackoff):
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// receive handles the messages sent to the node until the subscription is
+// interrupted. onSubscribed is called once the node listens to all its channels.
+func (c *Cluster) receive(onSubscribed func()) error {
+	client, release := c.client.Dedicate()
+	defer release()
+
+	channels := []string{c.broadcastChannel(), c.nodeChannel(c.id)}
+	wait := client.SetPubSubHooks(rueidis.PubSubHooks{
+		OnMessage: func(msg rueidis.PubSubMessage) {
+			c.handleMessage([]byte(msg.Message))
+		},
+		OnSubscription: func(s rueidis.PubSubSubscription) {
+			if s.Kind == "subscribe" && s.Count == int64(len(channels)) {
+				onSubscribed()
+			}
+		},
+	})
+
+	if err := client.Do(c.ctx, client.B().Subscribe().Channel(channels...).Build()).Error(); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-wait:
+		return err
+	case <-c.ctx.Done():
+		return nil
+	}
+}
+
+func (c *Cluster) handleMessage(buf []byte) {
+	var env envelope
+	if err := json.Unmarshal(buf, &env); err != nil {
+		c.logger.Warn("Failed to decode the cluster message", mlog.Err(err))
+		return
+	}
+
+	if env.NodeId == c.id || env.Message == nil {
+		return
+	}
+
+	if env.RequestId != "" {
+		c.handleRequestMessage(&env)
+		return
+	}
+
+	c.handlersMut.RLock()
+	handler, ok := c.handlers[env.Message.Event]
+	c.handlersMut.RUnlock()
+
+	if !ok {
+		c.logger.Debug("No handler registered for the cluster message", mlog.String("event", string(env.Message.Event)))
+		return
+	}
+
+	handler(env.Message)
+}
+
+func (c *Cluster) heartbeatLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		c.heartbeat()
+
+		select {
+		case <-ticker.C:
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// heartbeat registers the node in the cluster and takes part in the leader election.
+func (c *Cluster) heartbeat() {
+	ctx, cancel := context.WithTimeout(c.ctx, c.heartbeatInterval)
+	defer cancel()
+
+	record, err := json.Marshal(&nodeRecord{Info: c.GetMyClusterInfo(), LastPing: model.GetMillis()})
+	if err != nil {
+		c.logger.Error("Failed to encode the cluster node", mlog.Err(err))
+		return
+	}
+
+	if err = c.client.Do(ctx, c.client.B().Hset().Key(c.nodesKey()).FieldValue().FieldValue(c.id, string(record)).Build()).Error(); err != nil {
+		c.healthy.Store(false)
+		c.logger.Warn("Failed to register the node in the cluster", mlog.Err(err))
+		return
+	}
+
+	// Dropping the nodes which stopped without unregistering themselves.
+	if _, err = c.liveNodes(ctx); err != nil {
+		c.logger.Warn("Failed to clean up the nodes of the cluster", mlog.Err(err))
+	}
+
+	leader, err := c.electLeader(ctx)
+	if err != nil {
+		c.healthy.Store(false)
+		c.logger.Warn("Failed to take part in the cluster leader election", mlog.Err(err))
+		return
+	}
+
+	select {
+	case <-c.subscribed:
+		c.healthy.Store(true)
+	default:
+	}
+
+	if c.isLeader.Swap(leader) != leader {
+		c.logger.Info("The cluster leadership changed", mlog.Bool("is_leader", leader))
+		c.node.LeaderChanged()
+	}
+}
+
+func (c *Cluster) expiry() time.Duration {
+	return c.heartbeatInterval * missedHeartbeatsBeforeExpiry
+}
+
+func (c *Cluster) electLeader(ctx context.Context) (bool, error) {
+	expiry := strconv.FormatInt(c.expiry().Milliseconds(), 10)
+
+	renewed, err := renewLeaderScript.Exec(ctx, c.client, []string{c.leaderKey()}, []string{c.id, expiry}).AsInt64()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to renew the leadership")
+	}
+	if renewed == 1 {
+		return true, nil
+	}
+
+	err = c.client.Do(ctx, c.client.B().Set().Key(c.leaderKey()).Value(c.id).Nx().PxMilliseconds(c.expiry().Milliseconds()).Build()).Error()
+	if rueidis.IsRedisNil(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Wrap(err, "failed to acquire the leadership")
+	}
+
+	return true, nil
+}
+
+// liveNodes returns the nodes of the cluster, removing the ones which missed
+// too many heartbeats.
+func (c *Cluster) liveNodes(ctx context.Context) ([]*nodeRecord, error) {
+	entries, err := c.client.Do(ctx, c.client.B().Hgetall().Key(c.nodesKey()).Build()).AsStrMap()
+	if err != nil {
+		return nil, err
+	}
+
+	oldest := model.GetMillis() - c.expiry().Milliseconds()
+	records := make([]*nodeRecord, 0, len(entries))
+	var expired []string
+	for nodeID, entry := range entries {
+		var record nodeRecord
+		if err := json.Unmarshal([]byte(entry), &record); err != nil || record.Info == nil || record.LastPing < oldest {
+			expired = append(expired, nodeID)
+			continue
+		}
+		records = append(records, &record)
+	}
+
+	if len(expired) > 0 {
+		if err := c.client.Do(ctx, c.client.B().Hdel().Key(c.nodesKey()).Field(expired...).Build()).Error(); err != nil {
+			return nil, err
+		}
+	}
+
+	return records, nil
+}
+
+// otherNodes returns the IDs of the live nodes of the cluster, except this one.
+func (c *Cluster) otherNodes(ctx context.Context) ([]string, error) {
+	records, err := c.liveNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the nodes of the cluster: %w", err)
+	}
+
+	nodeIDs := make([]string, 0, len(records))
+	for _, record := range records {
+		if record.Info.Id != c.id {
+			nodeIDs = append(nodeIDs, record.Info.Id)
+		}
+	}
+	return nodeIDs, nil
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package rediscluster
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+type testNode struct {
+	hostname       string
+	webConnCount   int
+	leaderChanges  atomic.Int32
+	appliedConfigs chan *model.Config
+}
+
+func newTestNode(hostname string) *testNode {
+	return &testNode{hostname: hostname, appliedConfigs: make(chan *model.Config, 1)}
+}
+
+func (n *testNode) Config() *model.Config {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	return cfg
+}
+
+func (n *testNode) ClusterInfo() *model.ClusterInfo {
+	return &model.ClusterInfo{Hostname: n.hostname, Version: model.CurrentVersion}
+}
+
+func (n *testNode) ClusterStats() *model.ClusterStats {
+	return &model.ClusterStats{TotalWebsocketConnections: n.webConnCount}
+}
+
+func (n *testNode) GetLogs(rctx request.CTX, page, perPage int) ([]string, *model.AppError) {
+	return []string{n.hostname + " log line"}, nil
+}
+
+func (n *testNode) GetPluginStatuses() (model.PluginStatuses, *model.AppError) {
+	return model.PluginStatuses{{PluginId: "plugin", ClusterId: n.hostname}}, nil
+}
+
+func (n *testNode) WebConnCountForUser(userID string) int {
+	return n.webConnCount
+}
+
+func (n *testNode) SupportPacketFiles(rctx request.CTX, options *model.SupportPacketOptions) []model.FileData {
+	return []model.FileData{{Filename: n.hostname + "/mattermost.log", Body: []byte("log")}}
+}
+
+func (n *testNode) ApplyConfig(cfg *model.Config) error {
+	n.appliedConfigs <- cfg
+	return nil
+}
+
+func (n *testNode) LeaderChanged() {
+	n.leaderChanges.Add(1)
+}
+
+// testClient records whether the cluster closed its client.
+type testClient struct {
+	rueidis.Client
+	closed atomic.Bool
+}
+
+func (c *testClient) Close() {
+	c.closed.Store(true)
+	c.Client.Close()
+}
+
+func newTestCluster(t *testing.T, server *miniredis.Miniredis, node Node) *Cluster {
+	t.Helper()
+
+	client, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{server.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+
+	cluster, err := New(Options{
+		Client:            &testClient{Client: client},
+		ClusterName:       "test",
+		HeartbeatInterval: 100 * time.Millisecond,
+		RequestTimeout:    2 * time.Second,
+	}, node, mlog.CreateConsoleTestLogger(t))
+	require.NoError(t, err)
+
+	cluster.StartInterNodeCommunication()
+	t.Cleanup(cluster.StopInterNodeCommunication)
+
+	return cluster
+}
+
+func waitForNodes(t *testing.T, clusters ...*Cluster) {
+	t.Helper()
+	for _, c := range clusters {
+		require.Eventually(t, func() bool {
+			return len(c.GetClusterInfos()) == len(clusters)
+		}, 5*time.Second, 50*time.Millisecond)
+	}
+}
+
+func TestClusterMessages(t *testing.T) {
+	server := miniredis.RunT(t)
+	c1 := newTestCluster(t, server, newTestNode("node1"))
+	c2 := newTestCluster(t, server, newTestNode("node2"))
+	c3 := newTestCluster(t, server, newTestNode("node3"))
+	waitForNodes(t, c1, c2, c3)
+
+	received := func(c *Cluster) chan *model.ClusterMessage {
+		ch := make(chan *model.ClusterMessage, 10)
+		c.RegisterClusterMessageHandler(model.ClusterEventPublish, func(msg *model.ClusterMessage) {
+			ch <- msg
+		})
+		return ch
+	}
+	received1, received2, received3 := received(c1), received(c2), received(c3)
+
+	t.Run("broadcast", func(t *testing.T) {
+		for _, sendType := range []string{model.ClusterSendBestEffort, model.ClusterSendReliable} {
+			c1.SendClusterMessage(&model.ClusterMessage{Event: model.ClusterEventPublish, SendType: sendType, Data: []byte(sendType)})
+
+			for _, ch := range []chan *model.ClusterMessage{received2, received3} {
+				select {
+				case msg := <-ch:
+					assert.Equal(t, []byte(sendType), msg.Data)
+				case <-time.After(5 * time.Second):
+					require.Fail(t, "message not received")
+				}
+			}
+		}
+
+		// The sender doesn't receive its own messages.
+		select {
+		case <-received1:
+			require.Fail(t, "message received by the sender")
+		case <-time.After(200 * time.Millisecond):
+		}
+	})
+
+	t.Run("to a single node", func(t *testing.T) {
+		err := c1.SendClusterMessageToNode(c2.GetClusterId(), &model.ClusterMessage{Event: model.ClusterEventPublish, Data: []byte("direct")})
+		require.NoError(t, err)
+
+		select {
+		case msg := <-received2:
+			assert.Equal(t, []byte("direct"), msg.Data)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "message not received")
+		}
+
+		select {
+		case <-received3:
+			require.Fail(t, "message received by another node")
+		case <-time.After(200 * time.Millisecond):
+		}
+	})
+}
+
+func TestClusterLeader(t *testing.T) {
+	server := miniredis.RunT(t)
+	node1, node2 := newTestNode("node1"), newTestNode("node2")
+	c1 := newTestCluster(t, server, node1)
+	c2 := newTestCluster(t, server, node2)
+	waitForNodes(t, c1, c2)
+
+	require.Eventually(t, func() bool {
+		return c1.IsLeader() != c2.IsLeader()
+	}, 5*time.Second, 50*time.Millisecond)
+
+	leader, follower, followerNode := c1, c2, node2
+	if c2.IsLeader() {
+		leader, follower, followerNode = c2, c1, node1
+	}
+	assert.Zero(t, followerNode.leaderChanges.Load())
+
+	leader.StopInterNodeCommunication()
+	assert.True(t, leader.client.(*testClient).closed.Load(), "the client should have been closed")
+
+	require.Eventually(t, func() bool {
+		return follower.IsLeader()
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Equal(t, int32(1), followerNode.leaderChanges.Load())
+	assert.Len(t, follower.GetClusterInfos(), 1)
+}
+
+func TestClusterRequests(t *testing.T) {
+	server := miniredis.RunT(t)
+	node1, node2, node3 := newTestNode("node1"), newTestNode("node2"), newTestNode("node3")
+	node1.webConnCount, node2.webConnCount, node3.webConnCount = 1, 2, 3
+	c1 := newTestCluster(t, server, node1)
+	c2 := newTestCluster(t, server, node2)
+	c3 := newTestCluster(t, server, node3)
+	waitForNodes(t, c1, c2, c3)
+
+	rctx := request.TestContext(t)
+
+	t.Run("cluster stats", func(t *testing.T) {
+		stats, appErr := c1.GetClusterStats(rctx)
+		require.Nil(t, appErr)
+		require.Len(t, stats, 2)
+
+		byID := map[string]int{}
+		for _, s := range stats {
+			byID[s.Id] = s.TotalWebsocketConnections
+		}
+		assert.Equal(t, map[string]int{c2.GetClusterId(): 2, c3.GetClusterId(): 3}, byID)
+	})
+
+	t.Run("web connection count", func(t *testing.T) {
+		count, appErr := c1.WebConnCountForUser(model.NewId())
+		require.Nil(t, appErr)
+		assert.Equal(t, 6, count)
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		logs, appErr := c1.QueryLogs(rctx, 0, 10)
+		require.Nil(t, appErr)
+		assert.Equal(t, map[string][]string{
+			"node2": {"node2 log line"},
+			"node3": {"node3 log line"},
+		}, logs)
+	})
+
+	t.Run("plugin statuses", func(t *testing.T) {
+		statuses, appErr := c2.GetPluginStatuses()
+		require.Nil(t, appErr)
+		require.Len(t, statuses, 2)
+		assert.ElementsMatch(t, []string{"node1", "node3"}, []string{statuses[0].ClusterId, statuses[1].ClusterId})
+	})
+
+	t.Run("support packet", func(t *testing.T) {
+		files, err := c1.GenerateSupportPacket(rctx, &model.SupportPacketOptions{IncludeLogs: true})
+		require.NoError(t, err)
+		assert.Len(t, files, 2)
+		assert.Equal(t, "node2/mattermost.log", files["node2"][0].Filename)
+	})
+
+	t.Run("config changed", func(t *testing.T) {
+		cfg := &model.Config{}
+		cfg.SetDefaults()
+		cfg.ServiceSettings.SiteURL = model.NewPointer("http://example.com")
+
+		require.Nil(t, c1.ConfigChanged(cfg, cfg, false))
+		require.Nil(t, c1.ConfigChanged(cfg, cfg, true))
+
+		var wg sync.WaitGroup
+		for _, node := range []*testNode{node2, node3} {
+			wg.Add(1)
+			go func(node *testNode) {
+				defer wg.Done()
+				select {
+				case applied := <-node.appliedConfigs:
+					assert.Equal(t, "http://example.com", *applied.ServiceSettings.SiteURL)
+				case <-time.After(5 * time.Second):
+					assert.Fail(t, "config not applied", node.hostname)
+				}
+			}(node)
+		}
+		wg.Wait()
+
+		// Only the configuration sent to the other servers is applied.
+		assert.Empty(t, node1.appliedConfigs)
+	})
+}
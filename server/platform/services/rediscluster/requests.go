@@ -0,0 +1,339 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package rediscluster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// The requests are broadcast to the cluster, and every other live node
+// answers on the channel of the requesting node.
+
+var responseEvents = map[model.ClusterEvent]model.ClusterEvent{
+	model.ClusterGossipEventRequestGetLogs:               model.ClusterGossipEventResponseGetLogs,
+	model.ClusterGossipEventRequestGenerateSupportPacket: model.ClusterGossipEventResponseGenerateSupportPacket,
+	model.ClusterGossipEventRequestGetClusterStats:       model.ClusterGossipEventResponseGetClusterStats,
+	model.ClusterGossipEventRequestGetPluginStatuses:     model.ClusterGossipEventResponseGetPluginStatuses,
+	model.ClusterGossipEventRequestSaveConfig:            model.ClusterGossipEventResponseSaveConfig,
+	model.ClusterGossipEventRequestWebConnCount:          model.ClusterGossipEventResponseWebConnCount,
+}
+
+type logsRequest struct {
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+}
+
+type logsResponse struct {
+	Hostname string   `json:"hostname"`
+	Lines    []string `json:"lines"`
+}
+
+type supportPacketResponse struct {
+	Hostname string           `json:"hostname"`
+	Files    []model.FileData `json:"files"`
+}
+
+// errorResponse is sent back when a node fails to answer a request.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// request sends the request to all the other nodes and returns their
+// responses by node ID. The nodes which didn't answer in time are ignored.
+func (c *Cluster) request(ctx context.Context, event model.ClusterEvent, data any) (map[string][]byte, error) {
+	nodeIDs, err := c.otherNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodeIDs) == 0 {
+		return map[string][]byte{}, nil
+	}
+
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode the cluster request")
+	}
+
+	requestID := model.NewId()
+	responses := make(chan *envelope, len(nodeIDs))
+	c.pendingMut.Lock()
+	c.pending[requestID] = responses
+	c.pendingMut.Unlock()
+	defer func() {
+		c.pendingMut.Lock()
+		delete(c.pending, requestID)
+		c.pendingMut.Unlock()
+	}()
+
+	env := &envelope{
+		NodeId:    c.id,
+		RequestId: requestID,
+		Message:   &model.ClusterMessage{Event: event, SendType: model.ClusterSendReliable, Data: buf},
+	}
+	if err := c.publish(c.broadcastChannel(), env); err != nil {
+		return nil, errors.Wrap(err, "failed to send the cluster request")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+
+	results := make(map[string][]byte, len(nodeIDs))
+	for len(results) < len(nodeIDs) {
+		select {
+		case response := <-responses:
+			results[response.NodeId] = response.Message.Data
+		case <-ctx.Done():
+			c.logger.Warn("Timed out waiting for the cluster nodes to answer", mlog.String("event", string(event)), mlog.Int("expected", len(nodeIDs)), mlog.Int("received", len(results)))
+			return results, nil
+		}
+	}
+
+	return results, nil
+}
+
+func (c *Cluster) handleRequestMessage(env *envelope) {
+	responseEvent, isRequest := responseEvents[env.Message.Event]
+	if !isRequest {
+		c.pendingMut.Lock()
+		responses, ok := c.pending[env.RequestId]
+		c.pendingMut.Unlock()
+		if !ok {
+			return
+		}
+		select {
+		case responses <- env:
+		default:
+		}
+		return
+	}
+
+	// Answering may take a while, so the requests are handled outside of
+	// the subscription loop.
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		data, err := c.answer(env.Message)
+		if err != nil {
+			c.logger.Warn("Failed to answer the cluster request", mlog.String("event", string(env.Message.Event)), mlog.Err(err))
+			data, _ = json.Marshal(&errorResponse{Error: err.Error()})
+		}
+
+		response := &envelope{
+			NodeId:    c.id,
+			RequestId: env.RequestId,
+			Message:   &model.ClusterMessage{Event: responseEvent, Data: data},
+		}
+		if err := c.publish(c.nodeChannel(env.NodeId), response); err != nil {
+			c.logger.Warn("Failed to send the response to the cluster request", mlog.String("event", string(env.Message.Event)), mlog.Err(err))
+		}
+	}()
+}
+
+func (c *Cluster) answer(msg *model.ClusterMessage) ([]byte, error) {
+	rctx := request.EmptyContext(c.logger)
+
+	switch msg.Event {
+	case model.ClusterGossipEventRequestGetLogs:
+		var req logsRequest
+		if err := json.Unmarshal(msg.Data, &req); err != nil {
+			return nil, err
+		}
+		lines, appErr := c.node.GetLogs(rctx, req.Page, req.PerPage)
+		if appErr != nil {
+			return nil, appErr
+		}
+		return json.Marshal(&logsResponse{Hostname: c.node.ClusterInfo().Hostname, Lines: lines})
+
+	case model.ClusterGossipEventRequestGenerateSupportPacket:
+		var options model.SupportPacketOptions
+		if err := json.Unmarshal(msg.Data, &options); err != nil {
+			return nil, err
+		}
+		return json.Marshal(&supportPacketResponse{Hostname: c.node.ClusterInfo().Hostname, Files: c.node.SupportPacketFiles(rctx, &options)})
+
+	case model.ClusterGossipEventRequestGetClusterStats:
+		stats := c.node.ClusterStats()
+		stats.Id = c.id
+		return json.Marshal(stats)
+
+	case model.ClusterGossipEventRequestGetPluginStatuses:
+		statuses, appErr := c.node.GetPluginStatuses()
+		if appErr != nil {
+			return nil, appErr
+		}
+		return json.Marshal(statuses)
+
+	case model.ClusterGossipEventRequestSaveConfig:
+		var cfg model.Config
+		if err := json.Unmarshal(msg.Data, &cfg); err != nil {
+			return nil, err
+		}
+		if err := c.node.ApplyConfig(&cfg); err != nil {
+			return nil, err
+		}
+		return json.Marshal(map[string]string{})
+
+	case model.ClusterGossipEventRequestWebConnCount:
+		var userID string
+		if err := json.Unmarshal(msg.Data, &userID); err != nil {
+			return nil, err
+		}
+		return []byte(strconv.Itoa(c.node.WebConnCountForUser(userID))), nil
+	}
+
+	return nil, errors.Errorf("unknown cluster request %q", msg.Event)
+}
+
+// decodeResponse decodes the response of a node, returning the error the node
+// failed with, if any.
+func decodeResponse(data []byte, v any) error {
+	var errResponse errorResponse
+	if err := json.Unmarshal(data, &errResponse); err == nil && errResponse.Error != "" {
+		return errors.New(errResponse.Error)
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (c *Cluster) GetClusterStats(rctx request.CTX) ([]*model.ClusterStats, *model.AppError) {
+	responses, err := c.request(rctx.Context(), model.ClusterGossipEventRequestGetClusterStats, struct{}{})
+	if err != nil {
+		return nil, model.NewAppError("GetClusterStats", "ent.cluster.request_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	stats := make([]*model.ClusterStats, 0, len(responses))
+	for nodeID, data := range responses {
+		var nodeStats model.ClusterStats
+		if err := decodeResponse(data, &nodeStats); err != nil {
+			rctx.Logger().Warn("Failed to get the stats of a cluster node", mlog.String("node_id", nodeID), mlog.Err(err))
+			continue
+		}
+		stats = append(stats, &nodeStats)
+	}
+
+	return stats, nil
+}
+
+func (c *Cluster) GetLogs(rctx request.CTX, page, perPage int) ([]string, *model.AppError) {
+	logsByHostname, appErr := c.QueryLogs(rctx, page, perPage)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var lines []string
+	for hostname, nodeLines := range logsByHostname {
+		lines = append(lines, "-----------------------------------------------------------------------------------------------------------")
+		lines = append(lines, hostname)
+		lines = append(lines, "-----------------------------------------------------------------------------------------------------------")
+		lines = append(lines, nodeLines...)
+	}
+
+	return lines, nil
+}
+
+func (c *Cluster) QueryLogs(rctx request.CTX, page, perPage int) (map[string][]string, *model.AppError) {
+	responses, err := c.request(rctx.Context(), model.ClusterGossipEventRequestGetLogs, &logsRequest{Page: page, PerPage: perPage})
+	if err != nil {
+		return nil, model.NewAppError("QueryLogs", "ent.cluster.request_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	logs := make(map[string][]string, len(responses))
+	for nodeID, data := range responses {
+		var response logsResponse
+		if err := decodeResponse(data, &response); err != nil {
+			rctx.Logger().Warn("Failed to get the logs of a cluster node", mlog.String("node_id", nodeID), mlog.Err(err))
+			continue
+		}
+		logs[response.Hostname] = response.Lines
+	}
+
+	return logs, nil
+}
+
+func (c *Cluster) GenerateSupportPacket(rctx request.CTX, options *model.SupportPacketOptions) (map[string][]model.FileData, error) {
+	responses, err := c.request(rctx.Context(), model.ClusterGossipEventRequestGenerateSupportPacket, options)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]model.FileData, len(responses))
+	for nodeID, data := range responses {
+		var response supportPacketResponse
+		if err := decodeResponse(data, &response); err != nil {
+			rctx.Logger().Warn("Failed to get the support packet of a cluster node", mlog.String("node_id", nodeID), mlog.Err(err))
+			continue
+		}
+		files[response.Hostname] = response.Files
+	}
+
+	return files, nil
+}
+
+func (c *Cluster) GetPluginStatuses() (model.PluginStatuses, *model.AppError) {
+	responses, err := c.request(c.ctx, model.ClusterGossipEventRequestGetPluginStatuses, struct{}{})
+	if err != nil {
+		return nil, model.NewAppError("GetPluginStatuses", "ent.cluster.request_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	var statuses model.PluginStatuses
+	for nodeID, data := range responses {
+		var nodeStatuses model.PluginStatuses
+		if err := decodeResponse(data, &nodeStatuses); err != nil {
+			c.logger.Warn("Failed to get the plugin statuses of a cluster node", mlog.String("node_id", nodeID), mlog.Err(err))
+			continue
+		}
+		statuses = append(statuses, nodeStatuses...)
+	}
+
+	return statuses, nil
+}
+
+// ConfigChanged asks the other nodes to apply the new configuration.
+func (c *Cluster) ConfigChanged(previousConfig *model.Config, newConfig *model.Config, sendToOtherServer bool) *model.AppError {
+	if !sendToOtherServer {
+		return nil
+	}
+
+	responses, err := c.request(c.ctx, model.ClusterGossipEventRequestSaveConfig, newConfig)
+	if err != nil {
+		return model.NewAppError("ConfigChanged", "ent.cluster.request_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for nodeID, data := range responses {
+		var response map[string]string
+		if err := decodeResponse(data, &response); err != nil {
+			return model.NewAppError("ConfigChanged", "ent.cluster.request_error", nil, "node_id="+nodeID, http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Cluster) WebConnCountForUser(userID string) (int, *model.AppError) {
+	responses, err := c.request(c.ctx, model.ClusterGossipEventRequestWebConnCount, userID)
+	if err != nil {
+		return 0, model.NewAppError("WebConnCountForUser", "ent.cluster.request_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	count := c.node.WebConnCountForUser(userID)
+	for nodeID, data := range responses {
+		var nodeCount int
+		if err := decodeResponse(data, &nodeCount); err != nil {
+			c.logger.Warn("Failed to get the websocket connection count of a cluster node", mlog.String("node_id", nodeID), mlog.Err(err))
+			continue
+		}
+		count += nodeCount
+	}
+
+	return count, nil
+}
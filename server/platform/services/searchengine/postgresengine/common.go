@@ -0,0 +1,245 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package postgresengine
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
+)
+
+const (
+	headlineStartSel = "[[mm-match]]"
+	headlineStopSel  = "[[/mm-match]]"
+)
+
+var (
+	searchTermsRegexp = regexp.MustCompile(`"[^"]*"|\S+`)
+	headlineRegexp    = regexp.MustCompile(regexp.QuoteMeta(headlineStartSel) + `(.*?)` + regexp.QuoteMeta(headlineStopSel))
+	likeEscaper       = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+)
+
+type PGChannel struct {
+	Id            string
+	Type          model.ChannelType
+	TeamId        string
+	UserIDs       []string
+	TeamMemberIDs []string
+	NameSuggest   []string
+}
+
+type PGUser struct {
+	Id                         string
+	SuggestionsWithFullname    []string
+	SuggestionsWithoutFullname []string
+	TeamsIds                   []string
+	ChannelsIds                []string
+}
+
+type PGPost struct {
+	Id        string
+	TeamId    string
+	ChannelId string
+	UserId    string
+	CreateAt  int64
+	Message   string
+	Type      string
+	Hashtags  string
+}
+
+type PGFile struct {
+	Id        string
+	CreatorId string
+	ChannelId string
+	PostId    string
+	CreateAt  int64
+	Name      string
+	Content   string
+	Extension string
+}
+
+func PGChannelFromChannel(channel *model.Channel, userIDs, teamMemberIDs []string) *PGChannel {
+	displayNameInputs := searchengine.GetSuggestionInputsSplitBy(channel.DisplayName, " ")
+	nameInputs := searchengine.GetSuggestionInputsSplitByMultiple(channel.Name, []string{"-", "_"})
+
+	return &PGChannel{
+		Id:            channel.Id,
+		Type:          channel.Type,
+		TeamId:        channel.TeamId,
+		NameSuggest:   append(displayNameInputs, nameInputs...),
+		UserIDs:       userIDs,
+		TeamMemberIDs: teamMemberIDs,
+	}
+}
+
+func PGUserFromUserAndTeams(user *model.User, teamsIds, channelsIds []string) *PGUser {
+	usernameSuggestions := searchengine.GetSuggestionInputsSplitByMultiple(user.Username, []string{".", "-", "_"})
+
+	fullnameStrings := []string{}
+	if user.FirstName != "" {
+		fullnameStrings = append(fullnameStrings, user.FirstName)
+	}
+	if user.LastName != "" {
+		fullnameStrings = append(fullnameStrings, user.LastName)
+	}
+
+	fullnameSuggestions := []string{}
+	if len(fullnameStrings) > 0 {
+		fullname := strings.Join(fullnameStrings, " ")
+		fullnameSuggestions = searchengine.GetSuggestionInputsSplitBy(fullname, " ")
+	}
+
+	nicknameSuggestions := []string{}
+	if user.Nickname != "" {
+		nicknameSuggestions = searchengine.GetSuggestionInputsSplitBy(user.Nickname, " ")
+	}
+
+	usernameAndNicknameSuggestions := append(usernameSuggestions, nicknameSuggestions...)
+
+	return &PGUser{
+		Id:                         user.Id,
+		SuggestionsWithFullname:    append(usernameAndNicknameSuggestions, fullnameSuggestions...),
+		SuggestionsWithoutFullname: usernameAndNicknameSuggestions,
+		TeamsIds:                   teamsIds,
+		ChannelsIds:                channelsIds,
+	}
+}
+
+func PGUserFromUserForIndexing(userForIndexing *model.UserForIndexing) *PGUser {
+	user := &model.User{
+		Id:        userForIndexing.Id,
+		Username:  userForIndexing.Username,
+		Nickname:  userForIndexing.Nickname,
+		FirstName: userForIndexing.FirstName,
+		LastName:  userForIndexing.LastName,
+		CreateAt:  userForIndexing.CreateAt,
+		DeleteAt:  userForIndexing.DeleteAt,
+	}
+
+	return PGUserFromUserAndTeams(user, userForIndexing.TeamsIds, userForIndexing.ChannelsIds)
+}
+
+func PGPostFromPost(post *model.Post, teamId string) *PGPost {
+	p := &model.PostForIndexing{
+		TeamId: teamId,
+	}
+	post.ShallowCopy(&p.Post)
+	return PGPostFromPostForIndexing(p)
+}
+
+func PGPostFromPostForIndexing(post *model.PostForIndexing) *PGPost {
+	return &PGPost{
+		Id:        post.Id,
+		TeamId:    post.TeamId,
+		ChannelId: post.ChannelId,
+		UserId:    post.UserId,
+		CreateAt:  post.CreateAt,
+		Message:   post.Message,
+		Type:      post.Type,
+		Hashtags:  post.Hashtags,
+	}
+}
+
+func splitFilenameWords(name string) string {
+	result := name
+	result = strings.ReplaceAll(result, "-", " ")
+	result = strings.ReplaceAll(result, ".", " ")
+	return result
+}
+
+func PGFileFromFileInfo(fileInfo *model.FileInfo, channelId string) *PGFile {
+	return &PGFile{
+		Id:        fileInfo.Id,
+		ChannelId: channelId,
+		CreatorId: fileInfo.CreatorId,
+		PostId:    fileInfo.PostId,
+		CreateAt:  fileInfo.CreateAt,
+		Content:   fileInfo.Content,
+		Extension: fileInfo.Extension,
+		Name:      fileInfo.Name + " " + splitFilenameWords(fileInfo.Name),
+	}
+}
+
+func PGFileFromFileForIndexing(file *model.FileForIndexing) *PGFile {
+	return &PGFile{
+		Id:        file.Id,
+		ChannelId: file.ChannelId,
+		CreatorId: file.CreatorId,
+		PostId:    file.PostId,
+		CreateAt:  file.CreateAt,
+		Content:   file.Content,
+		Extension: file.Extension,
+		Name:      file.Name + " " + splitFilenameWords(file.Name),
+	}
+}
+
+// quoteLexeme quotes a single term so that it can be safely embedded in a
+// tsquery expression, regardless of any operators it may contain.
+func quoteLexeme(term string) string {
+	term = strings.ReplaceAll(term, `\`, `\\`)
+	term = strings.ReplaceAll(term, `'`, `''`)
+	return "'" + term + "'"
+}
+
+// toTSQueryTerms converts the terms of a search into tsquery operands.
+// Quoted phrases become phrase queries and terms ending in an asterisk
+// become prefix queries.
+func toTSQueryTerms(terms string) []string {
+	operands := []string{}
+	for _, term := range searchTermsRegexp.FindAllString(terms, -1) {
+		if strings.HasPrefix(term, `"`) && strings.HasSuffix(term, `"`) && len(term) > 1 {
+			words := strings.Fields(strings.Trim(term, `"`))
+			if len(words) == 0 {
+				continue
+			}
+			quoted := make([]string, len(words))
+			for i, word := range words {
+				quoted[i] = quoteLexeme(word)
+			}
+			operands = append(operands, "("+strings.Join(quoted, " <-> ")+")")
+			continue
+		}
+
+		if strings.HasSuffix(term, "*") {
+			term = strings.TrimRight(term, "*")
+			if term == "" {
+				continue
+			}
+			operands = append(operands, quoteLexeme(term)+":*")
+			continue
+		}
+
+		operands = append(operands, quoteLexeme(term))
+	}
+	return operands
+}
+
+// joinTSQuery joins tsquery operands with AND or OR.
+func joinTSQuery(operands []string, orTerms bool) string {
+	if orTerms {
+		return strings.Join(operands, " | ")
+	}
+	return strings.Join(operands, " & ")
+}
+
+// matchesFromHeadline extracts the words highlighted by ts_headline.
+func matchesFromHeadline(headline string) []string {
+	matches := []string{}
+	seen := map[string]bool{}
+	for _, match := range headlineRegexp.FindAllStringSubmatch(headline, -1) {
+		if match[1] == "" || seen[match[1]] {
+			continue
+		}
+		seen[match[1]] = true
+		matches = append(matches, match[1])
+	}
+	return matches
+}
+
+// prefixPattern returns a LIKE pattern matching values starting with term.
+func prefixPattern(term string) string {
+	return likeEscaper.Replace(strings.ToLower(term)) + "%"
+}
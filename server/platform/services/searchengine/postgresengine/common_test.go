@@ -0,0 +1,95 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package postgresengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestToTSQueryTerms(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Terms    string
+		Expected []string
+	}{
+		{
+			Name:     "single term",
+			Terms:    "hello",
+			Expected: []string{"'hello'"},
+		},
+		{
+			Name:     "multiple terms",
+			Terms:    "hello  world",
+			Expected: []string{"'hello'", "'world'"},
+		},
+		{
+			Name:     "prefix term",
+			Terms:    "hel*",
+			Expected: []string{"'hel':*"},
+		},
+		{
+			Name:     "lone asterisk is ignored",
+			Terms:    "* hello",
+			Expected: []string{"'hello'"},
+		},
+		{
+			Name:     "phrase",
+			Terms:    `"hello world" again`,
+			Expected: []string{"('hello' <-> 'world')", "'again'"},
+		},
+		{
+			Name:     "empty phrase is ignored",
+			Terms:    `"" hello`,
+			Expected: []string{"'hello'"},
+		},
+		{
+			Name:     "operators are quoted",
+			Terms:    `it's a|b !c \d`,
+			Expected: []string{"'it''s'", "'a|b'", "'!c'", `'\\d'`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, toTSQueryTerms(tc.Terms))
+		})
+	}
+}
+
+func TestJoinTSQuery(t *testing.T) {
+	operands := []string{"'hello'", "'world':*"}
+	assert.Equal(t, "'hello' & 'world':*", joinTSQuery(operands, false))
+	assert.Equal(t, "'hello' | 'world':*", joinTSQuery(operands, true))
+}
+
+func TestMatchesFromHeadline(t *testing.T) {
+	headline := "the [[mm-match]]quick[[/mm-match]] brown fox is [[mm-match]]quick[[/mm-match]] and [[mm-match]]Quickly[[/mm-match]] gone"
+	assert.Equal(t, []string{"quick", "Quickly"}, matchesFromHeadline(headline))
+	assert.Empty(t, matchesFromHeadline("no highlights here"))
+}
+
+func TestPrefixPattern(t *testing.T) {
+	assert.Equal(t, "john%", prefixPattern("John"))
+	assert.Equal(t, `100\%\_off\\%`, prefixPattern(`100%_off\`))
+}
+
+func TestPGFileFromFileInfo(t *testing.T) {
+	fileInfo := &model.FileInfo{
+		Id:        model.NewId(),
+		CreatorId: model.NewId(),
+		PostId:    model.NewId(),
+		Name:      "my-file.name.pdf",
+		Extension: "pdf",
+		Content:   "file content",
+	}
+
+	file := PGFileFromFileInfo(fileInfo, "channelid")
+	assert.Equal(t, "channelid", file.ChannelId)
+	assert.Equal(t, fileInfo.PostId, file.PostId)
+	assert.Equal(t, "my-file.name.pdf my file name pdf", file.Name)
+}
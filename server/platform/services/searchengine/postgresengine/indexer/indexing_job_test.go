@@ -0,0 +1,80 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package indexer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+	"github.com/mattermost/mattermost/server/v8/channels/utils/testutils"
+	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine/postgresengine"
+)
+
+func TestPostgresIndexer(t *testing.T) {
+	mockStore := &storetest.Store{}
+	defer mockStore.AssertExpectations(t)
+
+	t.Run("Fail the job when the engine is not active", func(t *testing.T) {
+		job := &model.Job{
+			Id:       model.NewId(),
+			CreateAt: model.GetMillis(),
+			Status:   model.JobStatusPending,
+			Type:     model.JobTypePostgresSearchIndexing,
+		}
+
+		mockStore.JobStore.On("UpdateStatusOptimistically", job.Id, model.JobStatusPending, model.JobStatusInProgress).Return(true, nil)
+		mockStore.JobStore.On("UpdateOptimistically", job, model.JobStatusInProgress).Return(true, nil)
+
+		cfg := &model.Config{}
+		cfg.SetDefaults()
+
+		jobServer := &jobs.JobServer{
+			Store: mockStore,
+			ConfigService: &testutils.StaticConfigService{
+				Cfg: cfg,
+			},
+		}
+
+		postgresEngine := postgresengine.NewPostgresEngine(cfg)
+		require.Nil(t, postgresEngine.Start())
+		require.False(t, postgresEngine.IsActive())
+
+		worker := &PostgresIndexerWorker{
+			jobServer: jobServer,
+			engine:    postgresEngine,
+			logger:    mlog.CreateConsoleTestLogger(t),
+		}
+
+		worker.DoJob(job)
+
+		assert.Equal(t, model.JobStatusError, job.Status)
+		assert.Contains(t, job.Data["error"], "postgresengine.indexer.do_job.engine_inactive")
+	})
+}
+
+func TestIndexingProgress(t *testing.T) {
+	progress := IndexingProgress{
+		TotalPostsCount:    50,
+		DonePostsCount:     50,
+		DonePosts:          true,
+		TotalFilesCount:    10,
+		TotalChannelsCount: 20,
+		DoneChannelsCount:  10,
+		TotalUsersCount:    20,
+	}
+
+	assert.Equal(t, int64(60), progress.CurrentProgress())
+	assert.False(t, progress.IsDone())
+
+	progress.DoneFiles = true
+	progress.DoneChannels = true
+	progress.DoneUsers = true
+	assert.True(t, progress.IsDone())
+}
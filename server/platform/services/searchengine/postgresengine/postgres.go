@@ -0,0 +1,282 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package postgresengine
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	sq "github.com/mattermost/squirrel"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+const (
+	EngineName   = "postgres"
+	PostIndex    = "posts"
+	FileIndex    = "files"
+	UserIndex    = "users"
+	ChannelIndex = "channels"
+
+	PostTable    = "searchposts"
+	FileTable    = "searchfiles"
+	UserTable    = "searchusers"
+	ChannelTable = "searchchannels"
+)
+
+var indexTables = map[string]string{
+	PostIndex:    PostTable,
+	FileIndex:    FileTable,
+	UserIndex:    UserTable,
+	ChannelIndex: ChannelTable,
+}
+
+// PostgresEngine indexes and searches the content in dedicated tables of the
+// server database, using the connections of the store.
+type PostgresEngine struct {
+	db        *sqlx.DB
+	builder   sq.StatementBuilderType
+	Mutex     sync.RWMutex
+	ready     int32
+	cfg       *model.Config
+	indexSync bool
+}
+
+func NewPostgresEngine(cfg *model.Config) *PostgresEngine {
+	return &PostgresEngine{
+		cfg:     cfg,
+		builder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+func (p *PostgresEngine) isConfigured(cfg *model.Config) bool {
+	return *cfg.PostgresSearchSettings.EnableIndexing && *cfg.SqlSettings.DriverName == model.DatabaseDriverPostgres
+}
+
+// SetDB sets the database the engine runs its queries against, which is
+// shared with the store so that the engine doesn't open connections of its
+// own. It must be called before the engine is started.
+func (p *PostgresEngine) SetDB(db *sql.DB) {
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+
+	p.db = sqlx.NewDb(db, model.DatabaseDriverPostgres)
+}
+
+func (p *PostgresEngine) openConnection() *model.AppError {
+	if atomic.LoadInt32(&p.ready) != 0 {
+		return model.NewAppError("Postgresengine.Start", "postgresengine.already_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	if p.db == nil {
+		return model.NewAppError("Postgresengine.Start", "postgresengine.open_connection.error", nil, "no database was set", http.StatusInternalServerError)
+	}
+
+	ctx, cancel := p.queryContext()
+	defer cancel()
+	if err := p.db.PingContext(ctx); err != nil {
+		return model.NewAppError("Postgresengine.Start", "postgresengine.open_connection.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	atomic.StoreInt32(&p.ready, 1)
+	return nil
+}
+
+// closeConnection stops using the database, whose connections are left to the
+// store owning them.
+func (p *PostgresEngine) closeConnection() {
+	atomic.StoreInt32(&p.ready, 0)
+}
+
+// queryContext returns a context bounded by the configured SQL query timeout.
+func (p *PostgresEngine) queryContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), time.Duration(*p.cfg.SqlSettings.QueryTimeout)*time.Second)
+}
+
+func (p *PostgresEngine) textSearchConfig() string {
+	return *p.cfg.PostgresSearchSettings.TextSearchConfig
+}
+
+func (p *PostgresEngine) Start() *model.AppError {
+	if !p.isConfigured(p.cfg) {
+		return nil
+	}
+
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+
+	mlog.Info("EXPERIMENTAL: Starting Postgres search engine")
+
+	return p.openConnection()
+}
+
+func (p *PostgresEngine) Stop() *model.AppError {
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+
+	mlog.Info("Stopping Postgres search engine")
+
+	p.closeConnection()
+	return nil
+}
+
+func (p *PostgresEngine) IsEnabled() bool {
+	return p.IsIndexingEnabled()
+}
+
+func (p *PostgresEngine) IsActive() bool {
+	return atomic.LoadInt32(&p.ready) == 1
+}
+
+func (p *PostgresEngine) IsIndexingSync() bool {
+	return p.indexSync
+}
+
+func (p *PostgresEngine) RefreshIndexes(_ request.CTX) *model.AppError {
+	return nil
+}
+
+func (p *PostgresEngine) GetVersion() int {
+	return 0
+}
+
+func (p *PostgresEngine) GetFullVersion() string {
+	return "0"
+}
+
+func (p *PostgresEngine) GetPlugins() []string {
+	return []string{}
+}
+
+func (p *PostgresEngine) GetName() string {
+	return EngineName
+}
+
+func (p *PostgresEngine) TestConfig(rctx request.CTX, cfg *model.Config) *model.AppError {
+	if *cfg.SqlSettings.DriverName != model.DatabaseDriverPostgres {
+		return model.NewAppError("Postgresengine.TestConfig", "postgresengine.test_config.driver.error", nil, "", http.StatusBadRequest)
+	}
+	return nil
+}
+
+func (p *PostgresEngine) truncateTables(tables ...string) *model.AppError {
+	ctx, cancel := p.queryContext()
+	defer cancel()
+
+	for _, table := range tables {
+		if _, err := p.db.ExecContext(ctx, "TRUNCATE TABLE "+table); err != nil {
+			return model.NewAppError("Postgresengine.PurgeIndexes", "postgresengine.purge_index.error", map[string]any{"Index": table}, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+	return nil
+}
+
+func (p *PostgresEngine) PurgeIndexes(rctx request.CTX) *model.AppError {
+	if !p.IsActive() {
+		return nil
+	}
+
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+
+	rctx.Logger().Info("PurgeIndexes Postgres search engine")
+
+	return p.truncateTables(PostTable, FileTable, UserTable, ChannelTable)
+}
+
+func (p *PostgresEngine) PurgeIndexList(rctx request.CTX, indexes []string) *model.AppError {
+	if !p.IsActive() {
+		return nil
+	}
+
+	tables := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		table, ok := indexTables[index]
+		if !ok {
+			return model.NewAppError("Postgresengine.PurgeIndexList", "postgresengine.purge_list.unknown_index.error", map[string]any{"Index": index}, "", http.StatusBadRequest)
+		}
+		tables = append(tables, table)
+	}
+
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+
+	rctx.Logger().Info("PurgeIndexList Postgres search engine", mlog.Array("indexes", indexes))
+
+	return p.truncateTables(tables...)
+}
+
+func (p *PostgresEngine) DataRetentionDeleteIndexes(rctx request.CTX, cutoff time.Time) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.DataRetentionDeleteIndexes", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	ctx, cancel := p.queryContext()
+	defer cancel()
+
+	cutoffMillis := model.GetMillisForTime(cutoff)
+	for _, table := range []string{PostTable, FileTable} {
+		query, args, err := p.builder.Delete(table).Where(sq.Lt{"CreateAt": cutoffMillis}).ToSql()
+		if err != nil {
+			return model.NewAppError("Postgresengine.DataRetentionDeleteIndexes", "postgresengine.data_retention_delete_indexes.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		if _, err := p.db.ExecContext(ctx, query, args...); err != nil {
+			return model.NewAppError("Postgresengine.DataRetentionDeleteIndexes", "postgresengine.data_retention_delete_indexes.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+func (p *PostgresEngine) IsAutocompletionEnabled() bool {
+	return *p.cfg.PostgresSearchSettings.EnableAutocomplete
+}
+
+func (p *PostgresEngine) IsIndexingEnabled() bool {
+	return *p.cfg.PostgresSearchSettings.EnableIndexing
+}
+
+func (p *PostgresEngine) IsSearchEnabled() bool {
+	return *p.cfg.PostgresSearchSettings.EnableSearching
+}
+
+func (p *PostgresEngine) UpdateConfig(cfg *model.Config) {
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+
+	if reflect.DeepEqual(cfg.PostgresSearchSettings, p.cfg.PostgresSearchSettings) {
+		return
+	}
+
+	mlog.Info("UpdateConf Postgres search engine")
+
+	wasConfigured := p.isConfigured(p.cfg)
+	p.cfg = cfg
+	if p.isConfigured(cfg) == wasConfigured {
+		return
+	}
+	if !p.isConfigured(cfg) {
+		p.closeConnection()
+		return
+	}
+	if err := p.openConnection(); err != nil {
+		mlog.Error("Error starting the Postgres search engine after updating the config", mlog.Err(err))
+	}
+}
+
+func (p *PostgresEngine) IsChannelsIndexVerified() bool {
+	return true
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package postgresengine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+func TestInactiveEngine(t *testing.T) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	*cfg.SqlSettings.DriverName = model.DatabaseDriverPostgres
+	*cfg.PostgresSearchSettings.EnableIndexing = true
+
+	p := NewPostgresEngine(cfg)
+
+	t.Run("the engine can't start without a database", func(t *testing.T) {
+		appErr := p.Start()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "postgresengine.open_connection.error", appErr.Id)
+		assert.False(t, p.IsActive())
+	})
+
+	t.Run("the entry points fail instead of using the database", func(t *testing.T) {
+		rctx := request.EmptyContext(mlog.CreateConsoleTestLogger(t))
+
+		appErr := p.DataRetentionDeleteIndexes(rctx, time.Now())
+		require.NotNil(t, appErr)
+		assert.Equal(t, "postgresengine.not_started.error", appErr.Id)
+
+		appErr = p.IndexPost(&model.Post{Id: model.NewId()}, model.NewId())
+		require.NotNil(t, appErr)
+		assert.Equal(t, "postgresengine.not_started.error", appErr.Id)
+
+		_, _, appErr = p.SearchPosts(model.ChannelList{}, []*model.SearchParams{{Terms: "test"}}, 0, 20)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "postgresengine.not_started.error", appErr.Id)
+
+		_, appErr = p.SearchChannels("", "", "test", false)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "postgresengine.not_started.error", appErr.Id)
+	})
+}
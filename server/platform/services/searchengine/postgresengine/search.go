@@ -0,0 +1,837 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package postgresengine
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/lib/pq"
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// UpsertBatchSize bounds the number of rows written per statement, keeping
+// bulk indexing below the Postgres limit on bind parameters.
+const UpsertBatchSize = 1000
+
+const headlineOptions = `StartSel="` + headlineStartSel + `", StopSel="` + headlineStopSel + `", HighlightAll=true`
+
+type searchResult struct {
+	Id       string
+	Headline string
+}
+
+func (p *PostgresEngine) exec(query sq.Sqlizer) (int64, error) {
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to build query")
+	}
+
+	ctx, cancel := p.queryContext()
+	defer cancel()
+
+	result, err := p.db.ExecContext(ctx, queryString, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (p *PostgresEngine) selectIds(query sq.SelectBuilder) ([]string, error) {
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build query")
+	}
+
+	ctx, cancel := p.queryContext()
+	defer cancel()
+
+	ids := []string{}
+	if err := p.db.SelectContext(ctx, &ids, queryString, args...); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (p *PostgresEngine) deleteByIds(table string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := p.exec(p.builder.Delete(table).Where("Id = ANY(?)", pq.Array(ids)))
+	return err
+}
+
+func (p *PostgresEngine) upsertPosts(posts []*PGPost) error {
+	cfg := p.textSearchConfig()
+	for start := 0; start < len(posts); start += UpsertBatchSize {
+		end := min(start+UpsertBatchSize, len(posts))
+
+		query := p.builder.Insert(PostTable).
+			Columns("Id", "TeamId", "ChannelId", "UserId", "CreateAt", "Type", "Message", "Hashtags", "MessageVector", "HashtagsVector")
+		for _, post := range posts[start:end] {
+			query = query.Values(
+				post.Id, post.TeamId, post.ChannelId, post.UserId, post.CreateAt, post.Type, post.Message, post.Hashtags,
+				sq.Expr("to_tsvector(?::regconfig, ?)", cfg, post.Message),
+				sq.Expr("to_tsvector('simple', ?)", post.Hashtags),
+			)
+		}
+		query = query.Suffix(`ON CONFLICT (Id) DO UPDATE SET
+			TeamId = EXCLUDED.TeamId, ChannelId = EXCLUDED.ChannelId, UserId = EXCLUDED.UserId,
+			CreateAt = EXCLUDED.CreateAt, Type = EXCLUDED.Type, Message = EXCLUDED.Message, Hashtags = EXCLUDED.Hashtags,
+			MessageVector = EXCLUDED.MessageVector, HashtagsVector = EXCLUDED.HashtagsVector`)
+
+		if _, err := p.exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PostgresEngine) upsertFiles(files []*PGFile) error {
+	cfg := p.textSearchConfig()
+	for start := 0; start < len(files); start += UpsertBatchSize {
+		end := min(start+UpsertBatchSize, len(files))
+
+		query := p.builder.Insert(FileTable).
+			Columns("Id", "ChannelId", "CreatorId", "PostId", "CreateAt", "Name", "Content", "Extension", "SearchVector")
+		for _, file := range files[start:end] {
+			query = query.Values(
+				file.Id, file.ChannelId, file.CreatorId, file.PostId, file.CreateAt, file.Name, file.Content, file.Extension,
+				sq.Expr("setweight(to_tsvector(?::regconfig, ?), 'A') || setweight(to_tsvector(?::regconfig, ?), 'B')", cfg, file.Name, cfg, file.Content),
+			)
+		}
+		query = query.Suffix(`ON CONFLICT (Id) DO UPDATE SET
+			ChannelId = EXCLUDED.ChannelId, CreatorId = EXCLUDED.CreatorId, PostId = EXCLUDED.PostId,
+			CreateAt = EXCLUDED.CreateAt, Name = EXCLUDED.Name, Content = EXCLUDED.Content,
+			Extension = EXCLUDED.Extension, SearchVector = EXCLUDED.SearchVector`)
+
+		if _, err := p.exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PostgresEngine) upsertUsers(users []*PGUser) error {
+	for start := 0; start < len(users); start += UpsertBatchSize {
+		end := min(start+UpsertBatchSize, len(users))
+
+		query := p.builder.Insert(UserTable).
+			Columns("Id", "TeamsIds", "ChannelsIds", "SuggestionsWithFullname", "SuggestionsWithoutFullname")
+		for _, user := range users[start:end] {
+			query = query.Values(
+				user.Id,
+				pq.Array(user.TeamsIds),
+				pq.Array(user.ChannelsIds),
+				pq.Array(lowerAll(user.SuggestionsWithFullname)),
+				pq.Array(lowerAll(user.SuggestionsWithoutFullname)),
+			)
+		}
+		query = query.Suffix(`ON CONFLICT (Id) DO UPDATE SET
+			TeamsIds = EXCLUDED.TeamsIds, ChannelsIds = EXCLUDED.ChannelsIds,
+			SuggestionsWithFullname = EXCLUDED.SuggestionsWithFullname,
+			SuggestionsWithoutFullname = EXCLUDED.SuggestionsWithoutFullname`)
+
+		if _, err := p.exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PostgresEngine) upsertChannels(channels []*PGChannel) error {
+	for start := 0; start < len(channels); start += UpsertBatchSize {
+		end := min(start+UpsertBatchSize, len(channels))
+
+		query := p.builder.Insert(ChannelTable).
+			Columns("Id", "TeamId", "Type", "UserIds", "TeamMemberIds", "NameSuggest")
+		for _, channel := range channels[start:end] {
+			query = query.Values(
+				channel.Id,
+				channel.TeamId,
+				channel.Type,
+				pq.Array(channel.UserIDs),
+				pq.Array(channel.TeamMemberIDs),
+				pq.Array(lowerAll(channel.NameSuggest)),
+			)
+		}
+		query = query.Suffix(`ON CONFLICT (Id) DO UPDATE SET
+			TeamId = EXCLUDED.TeamId, Type = EXCLUDED.Type, UserIds = EXCLUDED.UserIds,
+			TeamMemberIds = EXCLUDED.TeamMemberIds, NameSuggest = EXCLUDED.NameSuggest`)
+
+		if _, err := p.exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func lowerAll(values []string) []string {
+	lowered := make([]string, len(values))
+	for i, value := range values {
+		lowered[i] = strings.ToLower(value)
+	}
+	return lowered
+}
+
+// BulkIndexPosts indexes the given posts and removes the deleted ones from
+// the index in a single pass. It is used by the indexer job.
+func (p *PostgresEngine) BulkIndexPosts(posts []*PGPost, deletedIds []string) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.BulkIndexPosts", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	if err := p.upsertPosts(posts); err != nil {
+		return model.NewAppError("Postgresengine.BulkIndexPosts", "postgresengine.bulk_index_posts.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if err := p.deleteByIds(PostTable, deletedIds); err != nil {
+		return model.NewAppError("Postgresengine.BulkIndexPosts", "postgresengine.bulk_index_posts.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+// BulkIndexFiles indexes the given files and removes the deleted ones from
+// the index in a single pass. It is used by the indexer job.
+func (p *PostgresEngine) BulkIndexFiles(files []*PGFile, deletedIds []string) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.BulkIndexFiles", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	if err := p.upsertFiles(files); err != nil {
+		return model.NewAppError("Postgresengine.BulkIndexFiles", "postgresengine.bulk_index_files.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if err := p.deleteByIds(FileTable, deletedIds); err != nil {
+		return model.NewAppError("Postgresengine.BulkIndexFiles", "postgresengine.bulk_index_files.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+// BulkIndexUsers indexes the given users and removes the deleted ones from
+// the index in a single pass. It is used by the indexer job.
+func (p *PostgresEngine) BulkIndexUsers(users []*PGUser, deletedIds []string) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.BulkIndexUsers", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	if err := p.upsertUsers(users); err != nil {
+		return model.NewAppError("Postgresengine.BulkIndexUsers", "postgresengine.bulk_index_users.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if err := p.deleteByIds(UserTable, deletedIds); err != nil {
+		return model.NewAppError("Postgresengine.BulkIndexUsers", "postgresengine.bulk_index_users.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+// BulkIndexChannels indexes the given channels and removes the deleted ones
+// from the index in a single pass. It is used by the indexer job.
+func (p *PostgresEngine) BulkIndexChannels(channels []*PGChannel, deletedIds []string) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.BulkIndexChannels", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	if err := p.upsertChannels(channels); err != nil {
+		return model.NewAppError("Postgresengine.BulkIndexChannels", "postgresengine.bulk_index_channels.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if err := p.deleteByIds(ChannelTable, deletedIds); err != nil {
+		return model.NewAppError("Postgresengine.BulkIndexChannels", "postgresengine.bulk_index_channels.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+func (p *PostgresEngine) IndexPost(post *model.Post, teamId string) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.IndexPost", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	if err := p.upsertPosts([]*PGPost{PGPostFromPost(post, teamId)}); err != nil {
+		return model.NewAppError("Postgresengine.IndexPost", "postgresengine.index_post.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+// applyCommonFilters adds the channel, user and date filters shared by post
+// and file searches. userColumn names the column holding the author.
+func applyCommonFilters(query sq.SelectBuilder, params *model.SearchParams, userColumn string) sq.SelectBuilder {
+	if len(params.InChannels) > 0 {
+		query = query.Where(sq.Eq{"ChannelId": params.InChannels})
+	}
+
+	if len(params.ExcludedChannels) > 0 {
+		query = query.Where(sq.NotEq{"ChannelId": params.ExcludedChannels})
+	}
+
+	if len(params.FromUsers) > 0 {
+		query = query.Where(sq.Eq{userColumn: params.FromUsers})
+	}
+
+	if len(params.ExcludedUsers) > 0 {
+		query = query.Where(sq.NotEq{userColumn: params.ExcludedUsers})
+	}
+
+	if params.OnDate != "" {
+		onDateStart, onDateEnd := params.GetOnDateMillis()
+		return query.Where("CreateAt BETWEEN ? AND ?", onDateStart, onDateEnd)
+	}
+
+	if params.ExcludedDate != "" {
+		excludedDateStart, excludedDateEnd := params.GetExcludedDateMillis()
+		query = query.Where("CreateAt NOT BETWEEN ? AND ?", excludedDateStart, excludedDateEnd)
+	}
+
+	if params.AfterDate != "" {
+		query = query.Where("CreateAt >= ?", params.GetAfterDateMillis())
+	}
+
+	if params.BeforeDate != "" {
+		query = query.Where("CreateAt <= ?", params.GetBeforeDateMillis())
+	}
+
+	if params.ExcludedAfterDate != "" {
+		query = query.Where("CreateAt < ?", params.GetExcludedAfterDateMillis())
+	}
+
+	if params.ExcludedBeforeDate != "" {
+		query = query.Where("CreateAt > ?", params.GetExcludedBeforeDateMillis())
+	}
+
+	return query
+}
+
+func whereTerms(query sq.SelectBuilder, conditions []sq.Sqlizer, orTerms bool) sq.SelectBuilder {
+	if len(conditions) == 0 {
+		return query
+	}
+	if orTerms {
+		return query.Where(sq.Or(conditions))
+	}
+	return query.Where(sq.And(conditions))
+}
+
+func (p *PostgresEngine) SearchPosts(channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, *model.AppError) {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return nil, nil, model.NewAppError("Postgresengine.SearchPosts", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	cfg := p.textSearchConfig()
+	orTerms := searchParams[0].OrTerms
+
+	query := p.builder.Select("Id").
+		From(PostTable).
+		Where("ChannelId = ANY(?)", pq.Array(channelIdsFromList(channels))).
+		Where(sq.Eq{"Type": ""})
+
+	var messageOperands, hashtagOperands, excludedMessageOperands, excludedHashtagOperands []string
+	for i, params := range searchParams {
+		// Date, channels and FromUsers filters come in all
+		// searchParams iteration, and as they are global to the
+		// query, we only need to process them once
+		if i == 0 {
+			query = applyCommonFilters(query, params, "UserId")
+		}
+
+		if params.IsHashtag {
+			if params.Terms != "" {
+				hashtagOperands = append(hashtagOperands, toTSQueryTerms(params.Terms)...)
+			} else if params.ExcludedTerms != "" {
+				excludedHashtagOperands = append(excludedHashtagOperands, toTSQueryTerms(params.ExcludedTerms)...)
+			}
+		} else {
+			if params.Terms != "" {
+				messageOperands = append(messageOperands, toTSQueryTerms(params.Terms)...)
+			}
+			if params.ExcludedTerms != "" {
+				excludedMessageOperands = append(excludedMessageOperands, toTSQueryTerms(params.ExcludedTerms)...)
+			}
+		}
+	}
+
+	messageQuery := joinTSQuery(messageOperands, orTerms)
+
+	var termConditions []sq.Sqlizer
+	if len(messageOperands) > 0 {
+		termConditions = append(termConditions, sq.Expr("MessageVector @@ to_tsquery(?::regconfig, ?)", cfg, messageQuery))
+	}
+	if len(hashtagOperands) > 0 {
+		termConditions = append(termConditions, sq.Expr("HashtagsVector @@ to_tsquery('simple', ?)", joinTSQuery(hashtagOperands, orTerms)))
+	}
+	query = whereTerms(query, termConditions, orTerms)
+
+	if len(excludedMessageOperands) > 0 {
+		query = query.Where("NOT (MessageVector @@ to_tsquery(?::regconfig, ?))", cfg, joinTSQuery(excludedMessageOperands, true))
+	}
+	if len(excludedHashtagOperands) > 0 {
+		query = query.Where("NOT (HashtagsVector @@ to_tsquery('simple', ?))", joinTSQuery(excludedHashtagOperands, true))
+	}
+
+	if len(messageOperands) > 0 {
+		query = query.
+			Column(sq.Expr("ts_headline(?::regconfig, Message, to_tsquery(?::regconfig, ?), ?) AS Headline", cfg, cfg, messageQuery, headlineOptions)).
+			OrderByClause("ts_rank(MessageVector, to_tsquery(?::regconfig, ?)) DESC", cfg, messageQuery)
+	} else {
+		query = query.Column("'' AS Headline")
+	}
+	query = query.OrderBy("CreateAt DESC").
+		Limit(uint64(perPage)).
+		Offset(uint64(page * perPage))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, nil, model.NewAppError("Postgresengine.SearchPosts", "postgresengine.search_posts.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	ctx, cancel := p.queryContext()
+	defer cancel()
+
+	results := []searchResult{}
+	if err := p.db.SelectContext(ctx, &results, queryString, args...); err != nil {
+		return nil, nil, model.NewAppError("Postgresengine.SearchPosts", "postgresengine.search_posts.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	postIds := []string{}
+	matches := model.PostSearchMatches{}
+
+	for _, r := range results {
+		postIds = append(postIds, r.Id)
+		if postMatches := matchesFromHeadline(r.Headline); len(postMatches) > 0 {
+			matches[r.Id] = postMatches
+		}
+	}
+
+	return postIds, matches, nil
+}
+
+func channelIdsFromList(channels model.ChannelList) []string {
+	channelIds := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		channelIds = append(channelIds, channel.Id)
+	}
+	return channelIds
+}
+
+func (p *PostgresEngine) DeleteChannelPosts(rctx request.CTX, channelID string) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.DeleteChannelPosts", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	deleted, err := p.exec(p.builder.Delete(PostTable).Where(sq.Eq{"ChannelId": channelID}))
+	if err != nil {
+		return model.NewAppError("Postgresengine.DeleteChannelPosts", "postgresengine.delete_channel_posts.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	rctx.Logger().Info("Posts for channel deleted", mlog.String("channel_id", channelID), mlog.Int("deleted", deleted))
+
+	return nil
+}
+
+func (p *PostgresEngine) DeleteUserPosts(rctx request.CTX, userID string) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.DeleteUserPosts", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	deleted, err := p.exec(p.builder.Delete(PostTable).Where(sq.Eq{"UserId": userID}))
+	if err != nil {
+		return model.NewAppError("Postgresengine.DeleteUserPosts", "postgresengine.delete_user_posts.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	rctx.Logger().Info("Posts for user deleted", mlog.String("user_id", userID), mlog.Int("deleted", deleted))
+
+	return nil
+}
+
+func (p *PostgresEngine) DeletePost(post *model.Post) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.DeletePost", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	if err := p.deleteByIds(PostTable, []string{post.Id}); err != nil {
+		return model.NewAppError("Postgresengine.DeletePost", "postgresengine.delete_post.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+func (p *PostgresEngine) IndexChannel(_ request.CTX, channel *model.Channel, userIDs, teamMemberIDs []string) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.IndexChannel", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	if err := p.upsertChannels([]*PGChannel{PGChannelFromChannel(channel, userIDs, teamMemberIDs)}); err != nil {
+		return model.NewAppError("Postgresengine.IndexChannel", "postgresengine.index_channel.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+// suggestionMatch matches rows having at least one suggestion starting with
+// term in the given array column.
+func suggestionMatch(column, term string) sq.Sqlizer {
+	return sq.Expr("EXISTS (SELECT 1 FROM unnest("+column+") AS Suggestion WHERE Suggestion LIKE ?)", prefixPattern(term))
+}
+
+// orderBySuggestion ranks exact suggestion matches ahead of prefix matches.
+func orderBySuggestion(query sq.SelectBuilder, column, term string) sq.SelectBuilder {
+	if term != "" {
+		query = query.OrderByClause("? = ANY("+column+") DESC", strings.ToLower(term))
+	}
+	return query.OrderBy("Id")
+}
+
+func (p *PostgresEngine) SearchChannels(teamId, userID, term string, isGuest bool) ([]string, *model.AppError) {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return nil, model.NewAppError("Postgresengine.SearchChannels", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	query := p.builder.Select("Id").From(ChannelTable)
+
+	if teamId != "" {
+		query = query.Where(sq.Eq{"TeamId": teamId})
+	} else {
+		query = query.Where("? = ANY(TeamMemberIds)", userID)
+	}
+
+	if isGuest {
+		query = query.Where("? = ANY(UserIds)", userID)
+	} else {
+		query = query.Where(sq.Or{
+			sq.NotEq{"Type": model.ChannelTypePrivate},
+			sq.Expr("? = ANY(UserIds)", userID),
+		})
+	}
+
+	if term != "" {
+		query = query.Where(suggestionMatch("NameSuggest", term))
+	}
+
+	query = orderBySuggestion(query, "NameSuggest", term).Limit(model.ChannelSearchDefaultLimit)
+
+	channelIds, err := p.selectIds(query)
+	if err != nil {
+		return nil, model.NewAppError("Postgresengine.SearchChannels", "postgresengine.search_channels.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return channelIds, nil
+}
+
+func (p *PostgresEngine) DeleteChannel(channel *model.Channel) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.DeleteChannel", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	if err := p.deleteByIds(ChannelTable, []string{channel.Id}); err != nil {
+		return model.NewAppError("Postgresengine.DeleteChannel", "postgresengine.delete_channel.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+func (p *PostgresEngine) IndexUser(_ request.CTX, user *model.User, teamsIds, channelsIds []string) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.IndexUser", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	if err := p.upsertUsers([]*PGUser{PGUserFromUserAndTeams(user, teamsIds, channelsIds)}); err != nil {
+		return model.NewAppError("Postgresengine.IndexUser", "postgresengine.index_user.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+func suggestionsColumn(options *model.UserSearchOptions) string {
+	if options.AllowFullNames {
+		return "SuggestionsWithFullname"
+	}
+	return "SuggestionsWithoutFullname"
+}
+
+func (p *PostgresEngine) SearchUsersInChannel(teamId, channelId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, []string, *model.AppError) {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return nil, nil, model.NewAppError("Postgresengine.SearchUsersInChannel", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	if restrictedToChannels != nil && len(restrictedToChannels) == 0 {
+		return []string{}, []string{}, nil
+	}
+
+	column := suggestionsColumn(options)
+
+	// users in channel
+	uchanQuery := p.builder.Select("Id").From(UserTable).Where("? = ANY(ChannelsIds)", channelId)
+	if term != "" {
+		uchanQuery = uchanQuery.Where(suggestionMatch(column, term))
+	}
+	uchanQuery = orderBySuggestion(uchanQuery, column, term).Limit(uint64(options.Limit))
+
+	uchanIds, err := p.selectIds(uchanQuery)
+	if err != nil {
+		return nil, nil, model.NewAppError("Postgresengine.SearchUsersInChannel", "postgresengine.search_users_in_channel.uchan.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	// users not in channel
+	nuchanQuery := p.builder.Select("Id").
+		From(UserTable).
+		Where("? = ANY(TeamsIds)", teamId).
+		Where("NOT (? = ANY(ChannelsIds))", channelId)
+	if term != "" {
+		nuchanQuery = nuchanQuery.Where(suggestionMatch(column, term))
+	}
+	if len(restrictedToChannels) > 0 {
+		nuchanQuery = nuchanQuery.Where("ChannelsIds && ?", pq.Array(restrictedToChannels))
+	}
+	nuchanQuery = orderBySuggestion(nuchanQuery, column, term).Limit(uint64(options.Limit))
+
+	nuchanIds, err := p.selectIds(nuchanQuery)
+	if err != nil {
+		return nil, nil, model.NewAppError("Postgresengine.SearchUsersInChannel", "postgresengine.search_users_in_channel.nuchan.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return uchanIds, nuchanIds, nil
+}
+
+func (p *PostgresEngine) SearchUsersInTeam(teamId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, *model.AppError) {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return nil, model.NewAppError("Postgresengine.SearchUsersInTeam", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	if restrictedToChannels != nil && len(restrictedToChannels) == 0 {
+		return []string{}, nil
+	}
+
+	column := suggestionsColumn(options)
+
+	query := p.builder.Select("Id").From(UserTable)
+	if term != "" {
+		query = query.Where(suggestionMatch(column, term))
+	}
+
+	if len(restrictedToChannels) > 0 {
+		// restricted channels are already filtered by team, so we
+		// can search only those matches
+		query = query.Where("ChannelsIds && ?", pq.Array(restrictedToChannels))
+	} else if teamId != "" {
+		// this means that we only need to restrict by team
+		query = query.Where("? = ANY(TeamsIds)", teamId)
+	}
+
+	query = orderBySuggestion(query, column, term).Limit(uint64(options.Limit))
+
+	usersIds, err := p.selectIds(query)
+	if err != nil {
+		return nil, model.NewAppError("Postgresengine.SearchUsersInTeam", "postgresengine.search_users_in_team.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return usersIds, nil
+}
+
+func (p *PostgresEngine) DeleteUser(user *model.User) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.DeleteUser", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	if err := p.deleteByIds(UserTable, []string{user.Id}); err != nil {
+		return model.NewAppError("Postgresengine.DeleteUser", "postgresengine.delete_user.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+func (p *PostgresEngine) IndexFile(file *model.FileInfo, channelId string) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.IndexFile", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	if err := p.upsertFiles([]*PGFile{PGFileFromFileInfo(file, channelId)}); err != nil {
+		return model.NewAppError("Postgresengine.IndexFile", "postgresengine.index_file.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+func (p *PostgresEngine) SearchFiles(channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, *model.AppError) {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return nil, model.NewAppError("Postgresengine.SearchFiles", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	cfg := p.textSearchConfig()
+	orTerms := searchParams[0].OrTerms
+
+	query := p.builder.Select("Id").
+		From(FileTable).
+		Where("ChannelId = ANY(?)", pq.Array(channelIdsFromList(channels)))
+
+	var termOperands, excludedOperands []string
+	for i, params := range searchParams {
+		// Date, channels and FromUsers filters come in all
+		// searchParams iteration, and as they are global to the
+		// query, we only need to process them once
+		if i == 0 {
+			query = applyCommonFilters(query, params, "CreatorId")
+
+			if len(params.Extensions) > 0 {
+				query = query.Where(sq.Eq{"Extension": params.Extensions})
+			}
+
+			if len(params.ExcludedExtensions) > 0 {
+				query = query.Where(sq.NotEq{"Extension": params.ExcludedExtensions})
+			}
+		}
+
+		if params.Terms != "" {
+			termOperands = append(termOperands, toTSQueryTerms(params.Terms)...)
+		}
+
+		if params.ExcludedTerms != "" {
+			excludedOperands = append(excludedOperands, toTSQueryTerms(params.ExcludedTerms)...)
+		}
+	}
+
+	if len(excludedOperands) > 0 {
+		query = query.Where("NOT (SearchVector @@ to_tsquery(?::regconfig, ?))", cfg, joinTSQuery(excludedOperands, true))
+	}
+
+	if len(termOperands) > 0 {
+		termsQuery := joinTSQuery(termOperands, orTerms)
+		query = query.
+			Where("SearchVector @@ to_tsquery(?::regconfig, ?)", cfg, termsQuery).
+			OrderByClause("ts_rank(SearchVector, to_tsquery(?::regconfig, ?)) DESC", cfg, termsQuery)
+	}
+	query = query.OrderBy("CreateAt DESC").
+		Limit(uint64(perPage)).
+		Offset(uint64(page * perPage))
+
+	fileIds, err := p.selectIds(query)
+	if err != nil {
+		return nil, model.NewAppError("Postgresengine.SearchFiles", "postgresengine.search_files.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return fileIds, nil
+}
+
+func (p *PostgresEngine) DeleteFile(fileID string) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.DeleteFile", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	if err := p.deleteByIds(FileTable, []string{fileID}); err != nil {
+		return model.NewAppError("Postgresengine.DeleteFile", "postgresengine.delete_file.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+func (p *PostgresEngine) DeleteUserFiles(rctx request.CTX, userID string) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.DeleteUserFiles", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	deleted, err := p.exec(p.builder.Delete(FileTable).Where(sq.Eq{"CreatorId": userID}))
+	if err != nil {
+		return model.NewAppError("Postgresengine.DeleteUserFiles", "postgresengine.delete_user_files.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	rctx.Logger().Info("Files for user deleted", mlog.String("user_id", userID), mlog.Int("deleted", deleted))
+
+	return nil
+}
+
+func (p *PostgresEngine) DeletePostFiles(rctx request.CTX, postID string) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.DeletePostFiles", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	deleted, err := p.exec(p.builder.Delete(FileTable).Where(sq.Eq{"PostId": postID}))
+	if err != nil {
+		return model.NewAppError("Postgresengine.DeletePostFiles", "postgresengine.delete_post_files.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	rctx.Logger().Info("Files for post deleted", mlog.String("post_id", postID), mlog.Int("deleted", deleted))
+
+	return nil
+}
+
+func (p *PostgresEngine) DeleteFilesBatch(rctx request.CTX, endTime, limit int64) *model.AppError {
+	p.Mutex.RLock()
+	defer p.Mutex.RUnlock()
+
+	if !p.IsActive() {
+		return model.NewAppError("Postgresengine.DeleteFilesBatch", "postgresengine.not_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	// The subquery keeps the default placeholders, which the outer
+	// statement rewrites to Postgres ones.
+	batch := sq.Select("Id").
+		From(FileTable).
+		Where(sq.LtOrEq{"CreateAt": endTime}).
+		OrderBy("CreateAt DESC").
+		Limit(uint64(limit))
+
+	deleted, err := p.exec(p.builder.Delete(FileTable).Where(sq.Expr("Id IN (?)", batch)))
+	if err != nil {
+		return model.NewAppError("Postgresengine.DeleteFilesBatch", "postgresengine.delete_files_batch.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	rctx.Logger().Info("Files in batch deleted", mlog.Int("endTime", endTime), mlog.Int("limit", limit), mlog.Int("deleted", deleted))
+
+	return nil
+}
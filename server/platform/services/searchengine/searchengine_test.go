@@ -26,6 +26,11 @@ func TestActiveEngine(t *testing.T) {
 	bleveMock.On("IsIndexingEnabled").Return(true)
 	bleveMock.On("GetName").Return("bleve")
 
+	postgresMock := &mocks.SearchEngineInterface{}
+	postgresMock.On("IsActive").Return(true)
+	postgresMock.On("IsIndexingEnabled").Return(true)
+	postgresMock.On("GetName").Return("postgres")
+
 	assert.Equal(t, "database", b.ActiveEngine())
 
 	b.ElasticsearchEngine = esMock
@@ -36,6 +41,14 @@ func TestActiveEngine(t *testing.T) {
 	assert.Equal(t, "bleve", b.ActiveEngine())
 
 	b.BleveEngine = nil
+	b.PostgresEngine = postgresMock
+	assert.Equal(t, "postgres", b.ActiveEngine())
+
+	b.BleveEngine = bleveMock
+	assert.Equal(t, "bleve", b.ActiveEngine())
+
+	b.BleveEngine = nil
+	b.PostgresEngine = nil
 	*b.cfg.SqlSettings.DisableDatabaseSearch = true
 
 	assert.Equal(t, "none", b.ActiveEngine())
@@ -408,7 +408,9 @@ func (scs *Service) processTask(task syncTask) error {
 	for _, rc := range remotesMap {
 		rtask := task
 		rtask.remoteID = rc.RemoteId
-		if err := scs.syncForRemote(rtask, rc); err != nil {
+		err := scs.syncForRemote(rtask, rc)
+		scs.recordSyncError(rtask.channelID, rc, err)
+		if err != nil {
 			// retry...
 			if rtask.incRetry() {
 				scs.addTask(rtask)
@@ -424,6 +426,27 @@ func (scs *Service) processTask(task syncTask) error {
 	return nil
 }
 
+// recordSyncError keeps the outcome of the latest synchronization of a channel with a remote so it can
+// be reported by the sync status. A nil error clears any previous failure.
+func (scs *Service) recordSyncError(channelID string, rc *model.RemoteCluster, syncErr error) {
+	if channelID == "" {
+		return
+	}
+
+	var msg string
+	if syncErr != nil {
+		msg = syncErr.Error()
+	}
+
+	if err := scs.server.GetStore().SharedChannel().UpdateRemoteSyncError(channelID, rc.RemoteId, msg); err != nil {
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceWarn, "Failed to record sync error for shared channel remote",
+			mlog.String("channel_id", channelID),
+			mlog.String("remote", rc.DisplayName),
+			mlog.Err(err),
+		)
+	}
+}
+
 func (scs *Service) handlePostError(postId string, task syncTask, rc *model.RemoteCluster) {
 	if task.retryMsg != nil && len(task.retryMsg.Posts) == 1 && task.retryMsg.Posts[0].Id == postId {
 		// this was a retry for specific post that failed previously. Try again if within MaxRetries.
@@ -0,0 +1,219 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slackimport
+
+import (
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+type slackCanvas struct {
+	Id        string   `json:"id"`
+	Title     string   `json:"title"`
+	User      string   `json:"user"`
+	Created   int64    `json:"created"`
+	Channels  []string `json:"channels"`
+	Permalink string   `json:"permalink"`
+	Markdown  string   `json:"markdown"`
+}
+
+type slackListColumn struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type slackListItem struct {
+	Fields map[string]string `json:"fields"`
+}
+
+type slackList struct {
+	Id        string            `json:"id"`
+	Title     string            `json:"title"`
+	User      string            `json:"user"`
+	Created   int64             `json:"created"`
+	Channels  []string          `json:"channels"`
+	Permalink string            `json:"permalink"`
+	Columns   []slackListColumn `json:"columns"`
+	Items     []slackListItem   `json:"items"`
+}
+
+var slackListCellEscaper = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ")
+
+// slackConvertListToMarkdown renders the items of a Slack list as a Markdown table.
+func slackConvertListToMarkdown(list slackList) string {
+	if len(list.Columns) == 0 || len(list.Items) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	header := make([]string, len(list.Columns))
+	separator := make([]string, len(list.Columns))
+	for i, column := range list.Columns {
+		header[i] = slackListCellEscaper.Replace(column.Name)
+		separator[i] = "---"
+	}
+	sb.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	sb.WriteString("| " + strings.Join(separator, " | ") + " |\n")
+
+	for _, item := range list.Items {
+		row := make([]string, len(list.Columns))
+		for i, column := range list.Columns {
+			row[i] = slackListCellEscaper.Replace(item.Fields[column.Id])
+		}
+		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+
+	return sb.String()
+}
+
+func slackDocumentMessage(title, body string) string {
+	if title == "" {
+		return body
+	}
+	return "#### " + title + "\n\n" + body
+}
+
+// slackDocumentChannels returns the imported channels a canvas or a list was shared in.
+func slackDocumentChannels(channelIds []string, channels map[string]*model.Channel) []*model.Channel {
+	var result []*model.Channel
+	for _, channelId := range channelIds {
+		if channel, ok := channels[channelId]; ok {
+			result = append(result, channel)
+		}
+	}
+	return result
+}
+
+// slackDocumentOwner returns the user a canvas or a list is imported as,
+// falling back to the bot user when its creator wasn't imported.
+func slackDocumentOwner(userId string, users map[string]*model.User, botUser *model.User) *model.User {
+	if user, ok := users[userId]; ok {
+		return user
+	}
+	return botUser
+}
+
+func slackDocumentCreateAt(created int64) int64 {
+	if created <= 0 {
+		return model.GetMillis()
+	}
+	return created * 1000
+}
+
+func (si *SlackImporter) slackAddDocumentPost(rctx request.CTX, channel *model.Channel, owner *model.User, createAt int64, message string) {
+	post := &model.Post{
+		UserId:    owner.Id,
+		ChannelId: channel.Id,
+		Message:   message,
+		CreateAt:  createAt,
+	}
+	si.oldImportPost(rctx, post)
+}
+
+func (si *SlackImporter) slackAddDocumentBookmark(rctx request.CTX, channel *model.Channel, owner *model.User, title, link string) bool {
+	if title == "" {
+		title = link
+	}
+
+	bookmark := &model.ChannelBookmark{
+		ChannelId:   channel.Id,
+		OwnerId:     owner.Id,
+		DisplayName: truncateRunes(title, model.DisplayNameMaxRunes),
+		LinkUrl:     link,
+		Type:        model.ChannelBookmarkLink,
+	}
+	if _, err := si.store.ChannelBookmark().Save(bookmark, true); err != nil {
+		rctx.Logger().Warn("Slack Import: Unable to add the channel bookmark.", mlog.String("channel_id", channel.Id), mlog.String("link", link), mlog.Err(err))
+		return false
+	}
+	return true
+}
+
+// slackAddCanvases imports canvases as posts in the channels they were shared
+// in, or as channel bookmarks linking back to Slack when their content is not
+// part of the export.
+func (si *SlackImporter) slackAddCanvases(rctx request.CTX, canvases []slackCanvas, channels map[string]*model.Channel, users map[string]*model.User, botUser *model.User) {
+	for _, canvas := range canvases {
+		targets := slackDocumentChannels(canvas.Channels, channels)
+		if len(targets) == 0 {
+			si.summary.addUnconverted(SlackImportItemCanvas, canvas.Id, canvas.Title, "not shared in any imported channel")
+			continue
+		}
+
+		owner := slackDocumentOwner(canvas.User, users, botUser)
+		if owner == nil {
+			si.summary.addUnconverted(SlackImportItemCanvas, canvas.Id, canvas.Title, "no user to import it as")
+			continue
+		}
+
+		switch {
+		case strings.TrimSpace(canvas.Markdown) != "":
+			message := slackDocumentMessage(canvas.Title, canvas.Markdown)
+			for _, channel := range targets {
+				si.slackAddDocumentPost(rctx, channel, owner, slackDocumentCreateAt(canvas.Created), message)
+			}
+		case model.IsValidHTTPURL(canvas.Permalink):
+			added := false
+			for _, channel := range targets {
+				if si.slackAddDocumentBookmark(rctx, channel, owner, canvas.Title, canvas.Permalink) {
+					added = true
+				}
+			}
+			if !added {
+				si.summary.addUnconverted(SlackImportItemCanvas, canvas.Id, canvas.Title, "unable to add the channel bookmark")
+				continue
+			}
+		default:
+			si.summary.addUnconverted(SlackImportItemCanvas, canvas.Id, canvas.Title, "no content or link in the export")
+			continue
+		}
+		si.summary.Canvases++
+	}
+}
+
+// slackAddLists imports lists as Markdown tables in the channels they were
+// shared in, or as channel bookmarks linking back to Slack when their items
+// are not part of the export.
+func (si *SlackImporter) slackAddLists(rctx request.CTX, lists []slackList, channels map[string]*model.Channel, users map[string]*model.User, botUser *model.User) {
+	for _, list := range lists {
+		targets := slackDocumentChannels(list.Channels, channels)
+		if len(targets) == 0 {
+			si.summary.addUnconverted(SlackImportItemList, list.Id, list.Title, "not shared in any imported channel")
+			continue
+		}
+
+		owner := slackDocumentOwner(list.User, users, botUser)
+		if owner == nil {
+			si.summary.addUnconverted(SlackImportItemList, list.Id, list.Title, "no user to import it as")
+			continue
+		}
+
+		table := slackConvertListToMarkdown(list)
+		switch {
+		case table != "":
+			message := slackDocumentMessage(list.Title, table)
+			for _, channel := range targets {
+				si.slackAddDocumentPost(rctx, channel, owner, slackDocumentCreateAt(list.Created), message)
+			}
+		case model.IsValidHTTPURL(list.Permalink):
+			added := false
+			for _, channel := range targets {
+				if si.slackAddDocumentBookmark(rctx, channel, owner, list.Title, list.Permalink) {
+					added = true
+				}
+			}
+			if !added {
+				si.summary.addUnconverted(SlackImportItemList, list.Id, list.Title, "unable to add the channel bookmark")
+				continue
+			}
+		default:
+			si.summary.addUnconverted(SlackImportItemList, list.Id, list.Title, "no items or link in the export")
+			continue
+		}
+		si.summary.Lists++
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slackimport
+
+import (
+	"bytes"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+type slackWorkspace struct {
+	Id     string `json:"id"`
+	Name   string `json:"name"`
+	Domain string `json:"domain"`
+	// dir is the directory holding the workspace data in the export.
+	dir string
+}
+
+// slackGridChannels holds the channels owned by a workspace once shared
+// channels have been merged, along with their messages.
+type slackGridChannels struct {
+	channels []slackChannel
+	posts    map[string][]slackPost
+	shared   map[string]bool
+}
+
+// gridWorkspaces returns the workspaces of an Enterprise Grid export that have
+// data in the archive, in the order they are listed in workspaces.json.
+// Workspace directories missing from workspaces.json are named after the directory.
+func (e *slackExport) gridWorkspaces() []slackWorkspace {
+	var workspaces []slackWorkspace
+	found := make(map[string]bool)
+	for _, workspace := range e.workspaces {
+		for _, dir := range []string{workspace.Domain, workspace.Id, workspace.Name} {
+			if _, ok := e.workspaceExports[dir]; ok && dir != "" && !found[dir] {
+				workspace.dir = dir
+				found[dir] = true
+				workspaces = append(workspaces, workspace)
+				break
+			}
+		}
+	}
+
+	var remaining []string
+	for dir := range e.workspaceExports {
+		if !found[dir] {
+			remaining = append(remaining, dir)
+		}
+	}
+	sort.Strings(remaining)
+	for _, dir := range remaining {
+		workspaces = append(workspaces, slackWorkspace{Id: dir, Name: dir, Domain: dir, dir: dir})
+	}
+
+	return workspaces
+}
+
+// slackWorkspaceUsers returns the users that are members of the given workspace.
+func slackWorkspaceUsers(users []slackUser, workspaceId string) []slackUser {
+	var result []slackUser
+	for _, user := range users {
+		if user.EnterpriseUser != nil && slices.Contains(user.EnterpriseUser.Teams, workspaceId) {
+			result = append(result, user)
+		}
+	}
+	return result
+}
+
+// slackUnassignedUsers returns the users that are not members of any of the imported workspaces.
+func slackUnassignedUsers(users []slackUser, teams map[string]*model.Team) []slackUser {
+	var result []slackUser
+	for _, user := range users {
+		if user.EnterpriseUser == nil || !slices.ContainsFunc(user.EnterpriseUser.Teams, func(id string) bool { return teams[id] != nil }) {
+			result = append(result, user)
+		}
+	}
+	return result
+}
+
+// slackChannelOwner returns the workspace a channel is imported into. Shared
+// channels are imported once, into the workspace that owns them.
+func slackChannelOwner(channel slackChannel, currentWorkspaceId string, workspaceIds map[string]bool) string {
+	if workspaceIds[channel.ContextTeamId] {
+		return channel.ContextTeamId
+	}
+	for _, id := range channel.SharedTeamIds {
+		if workspaceIds[id] {
+			return id
+		}
+	}
+	return currentWorkspaceId
+}
+
+// slackMergePosts appends posts to existing ones, skipping the messages already
+// present because the channel was exported by several workspaces.
+func slackMergePosts(existing []slackPost, posts []slackPost) []slackPost {
+	seen := make(map[string]bool, len(existing))
+	for _, post := range existing {
+		seen[post.TimeStamp+"/"+post.User] = true
+	}
+	for _, post := range posts {
+		key := post.TimeStamp + "/" + post.User
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		existing = append(existing, post)
+	}
+	return existing
+}
+
+// slackUniqueByID removes the items whose id was already seen, preserving the order of the others.
+func slackUniqueByID[T any](items []T, id func(T) string) []T {
+	seen := make(map[string]bool, len(items))
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		if seen[id(item)] {
+			continue
+		}
+		seen[id(item)] = true
+		result = append(result, item)
+	}
+	return result
+}
+
+// slackGroupGridChannels assigns each channel of an Enterprise Grid export to
+// the workspace that owns it, merging the members and messages of channels
+// shared across several workspaces.
+func slackGroupGridChannels(workspaces []slackWorkspace, exports map[string]*slackWorkspaceExport) map[string]*slackGridChannels {
+	workspaceIds := make(map[string]bool, len(workspaces))
+	grouped := make(map[string]*slackGridChannels, len(workspaces))
+	for _, workspace := range workspaces {
+		workspaceIds[workspace.Id] = true
+		grouped[workspace.Id] = &slackGridChannels{
+			posts:  make(map[string][]slackPost),
+			shared: make(map[string]bool),
+		}
+	}
+
+	// Where each channel ended up, as the owning workspace and its index in the channel list.
+	type location struct {
+		owner string
+		index int
+	}
+	locations := make(map[string]location)
+
+	for _, workspace := range workspaces {
+		export := exports[workspace.dir]
+		for _, channel := range export.channels {
+			if loc, ok := locations[channel.Id]; ok {
+				owned := grouped[loc.owner]
+				merged := &owned.channels[loc.index]
+				for _, member := range channel.Members {
+					if !slices.Contains(merged.Members, member) {
+						merged.Members = append(merged.Members, member)
+					}
+				}
+				owned.posts[merged.Name] = slackMergePosts(owned.posts[merged.Name], export.posts[channel.Name])
+				owned.shared[channel.Id] = true
+				continue
+			}
+
+			owner := slackChannelOwner(channel, workspace.Id, workspaceIds)
+			owned := grouped[owner]
+			locations[channel.Id] = location{owner: owner, index: len(owned.channels)}
+			owned.channels = append(owned.channels, channel)
+			owned.posts[channel.Name] = slackMergePosts(owned.posts[channel.Name], export.posts[channel.Name])
+			if len(channel.SharedTeamIds) > 1 || owner != workspace.Id {
+				owned.shared[channel.Id] = true
+			}
+		}
+	}
+
+	return grouped
+}
+
+// slackGridTeam returns the team a workspace is imported into, creating it if
+// no team with a matching name exists yet.
+func (si *SlackImporter) slackGridTeam(rctx request.CTX, workspace slackWorkspace, log *bytes.Buffer) *model.Team {
+	name := workspace.Domain
+	if name == "" {
+		name = workspace.Name
+	}
+	name = model.CleanTeamName(name)
+
+	if team, err := si.store.Team().GetByName(name); err == nil {
+		log.WriteString(i18n.T("api.slackimport.slack_import.workspace_merge", map[string]any{"Workspace": workspace.Name, "TeamName": team.Name}))
+		return team
+	}
+
+	displayName := workspace.Name
+	if displayName == "" {
+		displayName = name
+	}
+	team, appErr := si.actions.CreateTeam(rctx, &model.Team{
+		Name:        name,
+		DisplayName: truncateRunes(displayName, model.TeamDisplayNameMaxRunes),
+		Type:        model.TeamInvite,
+	})
+	if appErr != nil {
+		rctx.Logger().Warn("Slack Import: Unable to create the team for the Slack workspace.", mlog.String("workspace", workspace.Name), mlog.Err(appErr))
+		log.WriteString(i18n.T("api.slackimport.slack_import.workspace_failed", map[string]any{"Workspace": workspace.Name}))
+		return nil
+	}
+
+	log.WriteString(i18n.T("api.slackimport.slack_import.workspace_created", map[string]any{"Workspace": workspace.Name, "TeamName": team.Name}))
+	return team
+}
+
+// slackJoinSharedChannelMembers adds the members of shared channels coming
+// from other workspaces to the team the channel is imported into.
+func (si *SlackImporter) slackJoinSharedChannelMembers(rctx request.CTX, team *model.Team, grid *slackGridChannels, users map[string]*model.User) {
+	joined := make(map[string]bool)
+	for _, channel := range grid.channels {
+		if !grid.shared[channel.Id] {
+			continue
+		}
+		for _, member := range channel.Members {
+			user, ok := users[member]
+			if !ok || joined[user.Id] {
+				continue
+			}
+			joined[user.Id] = true
+			if _, err := si.actions.JoinUserToTeam(team, user, ""); err != nil {
+				rctx.Logger().Warn("Slack Import: Unable to add the shared channel member to the team.", mlog.String("user_id", user.Id), mlog.String("team_id", team.Id), mlog.Err(err))
+			}
+		}
+	}
+}
+
+// slackImportEnterpriseGrid imports an Enterprise Grid export, mapping each
+// workspace to a team. Direct and group messages are organization wide and
+// are imported alongside the team the import was started from.
+func (si *SlackImporter) slackImportEnterpriseGrid(rctx request.CTX, teamID string, export *slackExport, log *bytes.Buffer) {
+	users := export.orgUsers
+	if len(users) == 0 {
+		users = export.users
+	}
+
+	workspaces := export.gridWorkspaces()
+	teams := make(map[string]*model.Team, len(workspaces))
+	for _, workspace := range workspaces {
+		team := si.slackGridTeam(rctx, workspace, log)
+		if team == nil {
+			si.summary.addUnconverted(SlackImportItemWorkspace, workspace.Id, workspace.Name, "unable to create the team")
+			continue
+		}
+		teams[workspace.Id] = team
+		si.summary.Teams++
+	}
+
+	addedUsers := make(map[string]*model.User, len(users))
+	for _, workspace := range workspaces {
+		if team, ok := teams[workspace.Id]; ok {
+			maps.Copy(addedUsers, si.slackAddUsers(rctx, team.Id, slackWorkspaceUsers(users, workspace.Id), log))
+		}
+	}
+	maps.Copy(addedUsers, si.slackAddUsers(rctx, teamID, slackUnassignedUsers(users, teams), log))
+
+	botUser := si.slackAddBotUser(rctx, teamID, log)
+
+	var allChannels []slackChannel
+	grouped := slackGroupGridChannels(workspaces, export.workspaceExports)
+	for _, grid := range grouped {
+		allChannels = append(allChannels, grid.channels...)
+	}
+	allChannels = append(allChannels, export.root.channels...)
+
+	addedChannels := make(map[string]*model.Channel)
+	for _, workspace := range workspaces {
+		team, ok := teams[workspace.Id]
+		grid := grouped[workspace.Id]
+		if !ok {
+			for _, channel := range grid.channels {
+				si.summary.addUnconverted(SlackImportItemChannel, channel.Id, channel.Name, "the workspace could not be imported")
+			}
+			continue
+		}
+
+		posts := slackConvertUserMentions(users, grid.posts)
+		posts = slackConvertChannelMentions(allChannels, posts)
+		posts = slackConvertPostsMarkup(posts)
+
+		si.slackJoinSharedChannelMembers(rctx, team, grid, addedUsers)
+		maps.Copy(addedChannels, si.slackAddChannels(rctx, team.Id, grid.channels, posts, addedUsers, export.uploads, botUser, log))
+	}
+
+	posts := slackConvertUserMentions(users, export.root.posts)
+	posts = slackConvertChannelMentions(allChannels, posts)
+	posts = slackConvertPostsMarkup(posts)
+	maps.Copy(addedChannels, si.slackAddChannels(rctx, teamID, export.root.channels, posts, addedUsers, export.uploads, botUser, log))
+
+	// Canvases and lists shared across workspaces are exported by each of them.
+	canvases := export.root.canvases
+	lists := export.root.lists
+	for _, workspace := range workspaces {
+		canvases = append(canvases, export.workspaceExports[workspace.dir].canvases...)
+		lists = append(lists, export.workspaceExports[workspace.dir].lists...)
+	}
+	canvases = slackUniqueByID(canvases, func(canvas slackCanvas) string { return canvas.Id })
+	lists = slackUniqueByID(lists, func(list slackList) string { return list.Id })
+	si.slackAddCanvases(rctx, canvases, addedChannels, addedUsers, botUser)
+	si.slackAddLists(rctx, lists, addedChannels, addedUsers, botUser)
+
+	if botUser != nil {
+		si.deactivateSlackBotUser(rctx, botUser)
+	}
+}
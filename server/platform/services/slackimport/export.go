@@ -0,0 +1,132 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slackimport
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
+)
+
+// slackGridWorkspacesDir is the directory of an Enterprise Grid export holding
+// one sub directory per workspace.
+const slackGridWorkspacesDir = "teams"
+
+// slackWorkspaceExport holds the channels, messages and documents of a single
+// workspace. Standard exports only have one, at the root of the archive.
+type slackWorkspaceExport struct {
+	channels []slackChannel
+	posts    map[string][]slackPost
+	canvases []slackCanvas
+	lists    []slackList
+}
+
+func newSlackWorkspaceExport() *slackWorkspaceExport {
+	return &slackWorkspaceExport{
+		posts: make(map[string][]slackPost),
+	}
+}
+
+// slackExport holds the parsed content of a Slack export archive.
+type slackExport struct {
+	users      []slackUser
+	orgUsers   []slackUser
+	isGrid     bool
+	workspaces []slackWorkspace
+	root       *slackWorkspaceExport
+	// workspaceExports are keyed by the name of the workspace directory.
+	workspaceExports map[string]*slackWorkspaceExport
+	uploads          map[string]*zip.File
+}
+
+func newSlackExport() *slackExport {
+	return &slackExport{
+		root:             newSlackWorkspaceExport(),
+		workspaceExports: make(map[string]*slackWorkspaceExport),
+		uploads:          make(map[string]*zip.File),
+	}
+}
+
+// isEnterpriseGrid reports whether the export comes from an Enterprise Grid
+// organization rather than from a single workspace.
+func (e *slackExport) isEnterpriseGrid() bool {
+	return e.isGrid || len(e.workspaceExports) > 0
+}
+
+// addFile parses a file of the export archive. Parsing errors other than the
+// file exceeding the size limit are ignored, as partial data may still be imported.
+func (e *slackExport) addFile(file *zip.File, reader io.Reader) error {
+	var err error
+	spl := strings.Split(file.Name, "/")
+	switch {
+	case file.Name == "users.json":
+		e.users, err = slackParseUsers(reader)
+	case file.Name == "org_users.json":
+		e.isGrid = true
+		e.orgUsers, err = slackParseUsers(reader)
+	case file.Name == "workspaces.json":
+		e.isGrid = true
+		e.workspaces, err = slackParseWorkspaces(reader)
+	case len(spl) == 3 && spl[0] == "__uploads":
+		e.uploads[spl[1]] = file
+	case len(spl) > 2 && spl[0] == slackGridWorkspacesDir && spl[1] != "":
+		workspace, ok := e.workspaceExports[spl[1]]
+		if !ok {
+			workspace = newSlackWorkspaceExport()
+			e.workspaceExports[spl[1]] = workspace
+		}
+		err = workspace.addFile(strings.Join(spl[2:], "/"), reader)
+	default:
+		err = e.root.addFile(file.Name, reader)
+	}
+	return err
+}
+
+func (w *slackWorkspaceExport) addFile(name string, reader io.Reader) error {
+	switch name {
+	case "channels.json":
+		return w.addChannels(reader, model.ChannelTypeOpen)
+	case "dms.json":
+		return w.addChannels(reader, model.ChannelTypeDirect)
+	case "groups.json":
+		return w.addChannels(reader, model.ChannelTypePrivate)
+	case "mpims.json":
+		return w.addChannels(reader, model.ChannelTypeGroup)
+	case "canvases.json":
+		canvases, err := slackParseCanvases(reader)
+		if errors.Is(err, utils.ErrSizeLimitExceeded) {
+			return err
+		}
+		w.canvases = append(w.canvases, canvases...)
+	case "lists.json":
+		lists, err := slackParseLists(reader)
+		if errors.Is(err, utils.ErrSizeLimitExceeded) {
+			return err
+		}
+		w.lists = append(w.lists, lists...)
+	default:
+		spl := strings.Split(name, "/")
+		if len(spl) == 2 && strings.HasSuffix(spl[1], ".json") {
+			newposts, err := slackParsePosts(reader)
+			if errors.Is(err, utils.ErrSizeLimitExceeded) {
+				return err
+			}
+			w.posts[spl[0]] = append(w.posts[spl[0]], newposts...)
+		}
+	}
+	return nil
+}
+
+func (w *slackWorkspaceExport) addChannels(reader io.Reader, channelType model.ChannelType) error {
+	channels, err := slackParseChannels(reader, channelType)
+	if errors.Is(err, utils.ErrSizeLimitExceeded) {
+		return err
+	}
+	w.channels = append(w.channels, channels...)
+	return nil
+}
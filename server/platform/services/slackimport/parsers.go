@@ -48,3 +48,36 @@ func slackParsePosts(data io.Reader) ([]slackPost, error) {
 	}
 	return posts, nil
 }
+
+func slackParseWorkspaces(data io.Reader) ([]slackWorkspace, error) {
+	decoder := json.NewDecoder(data)
+
+	var workspaces []slackWorkspace
+	if err := decoder.Decode(&workspaces); err != nil {
+		mlog.Warn("Slack Import: Error occurred when parsing the Slack workspaces. Import may work anyway.", mlog.Err(err))
+		return workspaces, err
+	}
+	return workspaces, nil
+}
+
+func slackParseCanvases(data io.Reader) ([]slackCanvas, error) {
+	decoder := json.NewDecoder(data)
+
+	var canvases []slackCanvas
+	if err := decoder.Decode(&canvases); err != nil {
+		mlog.Warn("Slack Import: Error occurred when parsing some Slack canvases. Import may work anyway.", mlog.Err(err))
+		return canvases, err
+	}
+	return canvases, nil
+}
+
+func slackParseLists(data io.Reader) ([]slackList, error) {
+	decoder := json.NewDecoder(data)
+
+	var lists []slackList
+	if err := decoder.Decode(&lists); err != nil {
+		mlog.Warn("Slack Import: Error occurred when parsing some Slack lists. Import may work anyway.", mlog.Err(err))
+		return lists, err
+	}
+	return lists, nil
+}
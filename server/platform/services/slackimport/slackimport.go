@@ -27,13 +27,15 @@ import (
 )
 
 type slackChannel struct {
-	Id      string          `json:"id"`
-	Name    string          `json:"name"`
-	Creator string          `json:"creator"`
-	Members []string        `json:"members"`
-	Purpose slackChannelSub `json:"purpose"`
-	Topic   slackChannelSub `json:"topic"`
-	Type    model.ChannelType
+	Id            string          `json:"id"`
+	Name          string          `json:"name"`
+	Creator       string          `json:"creator"`
+	Members       []string        `json:"members"`
+	Purpose       slackChannelSub `json:"purpose"`
+	Topic         slackChannelSub `json:"topic"`
+	ContextTeamId string          `json:"context_team_id"`
+	SharedTeamIds []string        `json:"shared_team_ids"`
+	Type          model.ChannelType
 }
 
 type slackChannelSub struct {
@@ -46,10 +48,15 @@ type slackProfile struct {
 	Email     string `json:"email"`
 }
 
+type slackEnterpriseUser struct {
+	Teams []string `json:"teams"`
+}
+
 type slackUser struct {
-	Id       string       `json:"id"`
-	Username string       `json:"name"`
-	Profile  slackProfile `json:"profile"`
+	Id             string               `json:"id"`
+	Username       string               `json:"name"`
+	Profile        slackProfile         `json:"profile"`
+	EnterpriseUser *slackEnterpriseUser `json:"enterprise_user"`
 }
 
 type slackFile struct {
@@ -90,6 +97,8 @@ type Actions struct {
 	CreateDirectChannel    func(request.CTX, string, string, ...model.ChannelOption) (*model.Channel, *model.AppError)
 	CreateGroupChannel     func(request.CTX, []string) (*model.Channel, *model.AppError)
 	CreateChannel          func(*model.Channel, bool) (*model.Channel, *model.AppError)
+	CreateTeam             func(request.CTX, *model.Team) (*model.Team, *model.AppError)
+	HasPermissionTo        func(*model.Permission) bool
 	DoUploadFile           func(time.Time, string, string, string, string, []byte) (*model.FileInfo, *model.AppError)
 	GenerateThumbnailImage func(request.CTX, image.Image, string, string)
 	GeneratePreviewImage   func(request.CTX, image.Image, string, string)
@@ -103,6 +112,7 @@ type SlackImporter struct {
 	store   store.Store
 	actions Actions
 	config  *model.Config
+	summary *ImportSummary
 }
 
 // New creates a new SlackImporter service instance. It receive a store, a set of actions and the current config.
@@ -112,6 +122,7 @@ func New(store store.Store, actions Actions, config *model.Config) *SlackImporte
 		store:   store,
 		actions: actions,
 		config:  config,
+		summary: &ImportSummary{},
 	}
 }
 
@@ -125,15 +136,7 @@ func (si *SlackImporter) SlackImport(rctx request.CTX, fileData multipart.File,
 		return model.NewAppError("SlackImport", "api.slackimport.slack_import.zip.app_error", nil, "", http.StatusBadRequest).Wrap(err), log
 	}
 
-	var channels []slackChannel
-	var publicChannels []slackChannel
-	var privateChannels []slackChannel
-	var groupChannels []slackChannel
-	var directChannels []slackChannel
-
-	var users []slackUser
-	posts := make(map[string][]slackPost)
-	uploads := make(map[string]*zip.File)
+	export := newSlackExport()
 	for _, file := range zipreader.File {
 		fileReader, err := file.Open()
 		if err != nil {
@@ -143,75 +146,27 @@ func (si *SlackImporter) SlackImport(rctx request.CTX, fileData multipart.File,
 		defer fileReader.Close()
 
 		reader := utils.NewLimitedReaderWithError(fileReader, slackImportMaxFileSize)
-		if file.Name == "channels.json" {
-			publicChannels, err = slackParseChannels(reader, model.ChannelTypeOpen)
-			if errors.Is(err, utils.ErrSizeLimitExceeded) {
-				log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-				continue
-			}
-			channels = append(channels, publicChannels...)
-		} else if file.Name == "dms.json" {
-			directChannels, err = slackParseChannels(reader, model.ChannelTypeDirect)
-			if errors.Is(err, utils.ErrSizeLimitExceeded) {
-				log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-				continue
-			}
-			channels = append(channels, directChannels...)
-		} else if file.Name == "groups.json" {
-			privateChannels, err = slackParseChannels(reader, model.ChannelTypePrivate)
-			if errors.Is(err, utils.ErrSizeLimitExceeded) {
-				log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-				continue
-			}
-			channels = append(channels, privateChannels...)
-		} else if file.Name == "mpims.json" {
-			groupChannels, err = slackParseChannels(reader, model.ChannelTypeGroup)
-			if errors.Is(err, utils.ErrSizeLimitExceeded) {
-				log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-				continue
-			}
-			channels = append(channels, groupChannels...)
-		} else if file.Name == "users.json" {
-			users, err = slackParseUsers(reader)
-			if errors.Is(err, utils.ErrSizeLimitExceeded) {
-				log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-				continue
-			}
-		} else {
-			spl := strings.Split(file.Name, "/")
-			if len(spl) == 2 && strings.HasSuffix(spl[1], ".json") {
-				newposts, err := slackParsePosts(reader)
-				if errors.Is(err, utils.ErrSizeLimitExceeded) {
-					log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-					continue
-				}
-				channel := spl[0]
-				if _, ok := posts[channel]; !ok {
-					posts[channel] = newposts
-				} else {
-					posts[channel] = append(posts[channel], newposts...)
-				}
-			} else if len(spl) == 3 && spl[0] == "__uploads" {
-				uploads[spl[1]] = file
-			}
+		if err := export.addFile(file, reader); errors.Is(err, utils.ErrSizeLimitExceeded) {
+			log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
 		}
 	}
 
-	posts = slackConvertUserMentions(users, posts)
-	posts = slackConvertChannelMentions(channels, posts)
-	posts = slackConvertPostsMarkup(posts)
-
-	addedUsers := si.slackAddUsers(rctx, teamID, users, log)
-	botUser := si.slackAddBotUser(rctx, teamID, log)
-
-	si.slackAddChannels(rctx, teamID, channels, posts, addedUsers, uploads, botUser, log)
-
-	if botUser != nil {
-		si.deactivateSlackBotUser(rctx, botUser)
+	if export.isEnterpriseGrid() {
+		// Enterprise Grid imports create teams and merge into existing ones
+		// matched by name, so they aren't limited to the team being imported.
+		if !si.actions.HasPermissionTo(model.PermissionManageSystem) {
+			log.WriteString(i18n.T("api.slackimport.slack_import.enterprise_grid_permissions.app_error"))
+			return model.NewAppError("SlackImport", "api.slackimport.slack_import.enterprise_grid_permissions.app_error", nil, "", http.StatusForbidden), log
+		}
+		si.slackImportEnterpriseGrid(rctx, teamID, export, log)
+	} else {
+		si.slackImportWorkspace(rctx, teamID, export, log)
 	}
 
 	si.actions.InvalidateAllCaches()
 
+	si.summary.write(log)
+
 	log.WriteString(i18n.T("api.slackimport.slack_import.notes"))
 	log.WriteString("=======\r\n\r\n")
 
@@ -222,6 +177,30 @@ func (si *SlackImporter) SlackImport(rctx request.CTX, fileData multipart.File,
 	return nil, log
 }
 
+// Summary returns the structured summary of the last import run by this importer.
+func (si *SlackImporter) Summary() *ImportSummary {
+	return si.summary
+}
+
+// slackImportWorkspace imports a standard, single workspace Slack export into the given team.
+func (si *SlackImporter) slackImportWorkspace(rctx request.CTX, teamID string, export *slackExport, log *bytes.Buffer) {
+	root := export.root
+	posts := slackConvertUserMentions(export.users, root.posts)
+	posts = slackConvertChannelMentions(root.channels, posts)
+	posts = slackConvertPostsMarkup(posts)
+
+	addedUsers := si.slackAddUsers(rctx, teamID, export.users, log)
+	botUser := si.slackAddBotUser(rctx, teamID, log)
+
+	addedChannels := si.slackAddChannels(rctx, teamID, root.channels, posts, addedUsers, export.uploads, botUser, log)
+	si.slackAddCanvases(rctx, root.canvases, addedChannels, addedUsers, botUser)
+	si.slackAddLists(rctx, root.lists, addedChannels, addedUsers, botUser)
+
+	if botUser != nil {
+		si.deactivateSlackBotUser(rctx, botUser)
+	}
+}
+
 func truncateRunes(s string, i int) string {
 	runes := []rune(s)
 	if len(runes) > i {
@@ -264,6 +243,7 @@ func (si *SlackImporter) slackAddUsers(rctx request.CTX, teamId string, slackuse
 			} else {
 				importerLog.WriteString(i18n.T("api.slackimport.slack_add_users.merge_existing", map[string]any{"Email": existingUser.Email, "Username": existingUser.Username}))
 			}
+			si.summary.Users++
 			continue
 		}
 
@@ -279,9 +259,11 @@ func (si *SlackImporter) slackAddUsers(rctx request.CTX, teamId string, slackuse
 		mUser := si.oldImportUser(rctx, team, &newUser)
 		if mUser == nil {
 			importerLog.WriteString(i18n.T("api.slackimport.slack_add_users.unable_import", map[string]any{"Username": sUser.Username}))
+			si.summary.addUnconverted(SlackImportItemUser, sUser.Id, sUser.Username, "unable to create the user")
 			continue
 		}
 		addedUsers[sUser.Id] = mUser
+		si.summary.Users++
 		importerLog.WriteString(i18n.T("api.slackimport.slack_add_users.email_pwd", map[string]any{"Email": newUser.Email, "Password": password}))
 	}
 
@@ -515,6 +497,7 @@ func (si *SlackImporter) slackAddPosts(rctx request.CTX, teamId string, channel
 				mlog.String("post_type", sPost.Type),
 				mlog.String("post_subtype", sPost.SubType),
 			)
+			si.summary.addUnconverted(SlackImportItemMessage, sPost.TimeStamp, channel.Name, "unsupported message type "+strings.Trim(sPost.Type+"/"+sPost.SubType, "/"))
 		}
 	}
 }
@@ -631,6 +614,7 @@ func (si *SlackImporter) slackAddChannels(rctx request.CTX, teamId string, slack
 			if mChannel == nil {
 				rctx.Logger().Warn("Slack Import: Unable to import Slack channel.", mlog.String("channel_display_name", newChannel.DisplayName))
 				importerLog.WriteString(i18n.T("api.slackimport.slack_add_channels.import_failed", map[string]any{"DisplayName": newChannel.DisplayName}))
+				si.summary.addUnconverted(SlackImportItemChannel, sChannel.Id, newChannel.DisplayName, "unable to create the channel")
 				continue
 			}
 		}
@@ -641,6 +625,7 @@ func (si *SlackImporter) slackAddChannels(rctx request.CTX, teamId string, slack
 		}
 		importerLog.WriteString(newChannel.DisplayName + "\r\n")
 		addedChannels[sChannel.Id] = mChannel
+		si.summary.Channels++
 		si.slackAddPosts(rctx, teamId, mChannel, posts[sChannel.Name], users, uploads, botUser)
 	}
 
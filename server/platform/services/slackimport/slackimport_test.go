@@ -6,6 +6,7 @@ package slackimport
 import (
 	"archive/zip"
 	"bytes"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,11 +14,13 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
 )
 
 func TestSlackConvertTimeStamp(t *testing.T) {
@@ -459,3 +462,224 @@ func TestSlackUploadFile(t *testing.T) {
 		require.False(t, ok)
 	})
 }
+
+func TestSlackExportAddFile(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buf)
+	files := map[string]string{
+		"org_users.json":                        `[{"id": "U1", "name": "user1", "enterprise_user": {"teams": ["T1"]}}]`,
+		"workspaces.json":                       `[{"id": "T1", "name": "Engineering", "domain": "eng"}]`,
+		"mpims.json":                            `[{"id": "G1", "name": "mpdm-user1--user2-1", "members": ["U1", "U2"]}]`,
+		"teams/eng/channels.json":               `[{"id": "C1", "name": "general", "members": ["U1"]}]`,
+		"teams/eng/general/2024-01-01.json":     `[{"type": "message", "user": "U1", "text": "hello", "ts": "1704100000.000100"}]`,
+		"teams/eng/canvases.json":               `[{"id": "F1", "title": "Plan", "channels": ["C1"], "markdown": "# Plan"}]`,
+		"teams/eng/lists.json":                  `[{"id": "F2", "title": "Tasks", "channels": ["C1"]}]`,
+		"mpdm-user1--user2-1/2024-01-01.json":   `[{"type": "message", "user": "U1", "text": "hi", "ts": "1704100000.000200"}]`,
+		"__uploads/F3/file.txt":                 "content",
+		"teams/unlisted/groups.json":            `[{"id": "P1", "name": "private"}]`,
+		"teams/unlisted/private/2024-01-1.json": `[]`,
+	}
+	for name, content := range files {
+		writer, err := zipWriter.Create(name)
+		require.NoError(t, err)
+		_, err = writer.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	export := newSlackExport()
+	for _, file := range zipReader.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+		require.NoError(t, export.addFile(file, reader))
+		reader.Close()
+	}
+
+	require.True(t, export.isEnterpriseGrid())
+	require.Len(t, export.orgUsers, 1)
+	assert.Equal(t, []string{"T1"}, export.orgUsers[0].EnterpriseUser.Teams)
+	require.Len(t, export.root.channels, 1)
+	assert.Equal(t, model.ChannelTypeGroup, export.root.channels[0].Type)
+	assert.Len(t, export.root.posts["mpdm-user1--user2-1"], 1)
+	assert.Contains(t, export.uploads, "F3")
+
+	eng := export.workspaceExports["eng"]
+	require.NotNil(t, eng)
+	require.Len(t, eng.channels, 1)
+	assert.Len(t, eng.posts["general"], 1)
+	assert.Len(t, eng.canvases, 1)
+	assert.Len(t, eng.lists, 1)
+
+	workspaces := export.gridWorkspaces()
+	require.Len(t, workspaces, 2)
+	assert.Equal(t, "T1", workspaces[0].Id)
+	assert.Equal(t, "eng", workspaces[0].dir)
+	assert.Equal(t, "unlisted", workspaces[1].Id)
+	assert.Equal(t, "unlisted", workspaces[1].dir)
+}
+
+func TestSlackExportIsEnterpriseGrid(t *testing.T) {
+	export := newSlackExport()
+	require.False(t, export.isEnterpriseGrid())
+
+	require.NoError(t, export.root.addFile("channels.json", strings.NewReader(`[{"id": "C1", "name": "general"}]`)))
+	require.False(t, export.isEnterpriseGrid())
+
+	export.workspaceExports["eng"] = newSlackWorkspaceExport()
+	require.True(t, export.isEnterpriseGrid())
+}
+
+func TestSlackImportEnterpriseGridPermissions(t *testing.T) {
+	require.NoError(t, utils.TranslationsPreInit())
+
+	path := filepath.Join(t.TempDir(), "grid.zip")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	zipWriter := zip.NewWriter(file)
+	writer, err := zipWriter.Create("workspaces.json")
+	require.NoError(t, err)
+	_, err = writer.Write([]byte(`[{"id": "T1", "name": "Engineering", "domain": "eng"}]`))
+	require.NoError(t, err)
+	require.NoError(t, zipWriter.Close())
+	require.NoError(t, file.Close())
+
+	fileData, err := os.Open(path)
+	require.NoError(t, err)
+	defer fileData.Close()
+	info, err := fileData.Stat()
+	require.NoError(t, err)
+
+	// The store mock fails the test if the import touches any team.
+	store := &mocks.Store{}
+	importer := New(store, Actions{
+		HasPermissionTo: func(permission *model.Permission) bool {
+			return permission.Id != model.PermissionManageSystem.Id
+		},
+	}, &model.Config{})
+
+	appErr, _ := importer.SlackImport(request.TestContext(t), fileData, info.Size(), model.NewId())
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	store.AssertExpectations(t)
+}
+
+func TestSlackGroupGridChannels(t *testing.T) {
+	workspaces := []slackWorkspace{
+		{Id: "T1", dir: "eng"},
+		{Id: "T2", dir: "sales"},
+	}
+	exports := map[string]*slackWorkspaceExport{
+		"eng": {
+			channels: []slackChannel{
+				{Id: "C1", Name: "eng-only", Members: []string{"U1"}},
+				{Id: "C2", Name: "shared", Members: []string{"U1"}, ContextTeamId: "T2", SharedTeamIds: []string{"T2", "T1"}},
+			},
+			posts: map[string][]slackPost{
+				"eng-only": {{User: "U1", TimeStamp: "1.0"}},
+				"shared":   {{User: "U1", TimeStamp: "2.0"}, {User: "U2", TimeStamp: "3.0"}},
+			},
+		},
+		"sales": {
+			channels: []slackChannel{
+				{Id: "C2", Name: "shared", Members: []string{"U2"}, ContextTeamId: "T2", SharedTeamIds: []string{"T2", "T1"}},
+			},
+			posts: map[string][]slackPost{
+				"shared": {{User: "U2", TimeStamp: "3.0"}, {User: "U2", TimeStamp: "4.0"}},
+			},
+		},
+	}
+
+	grouped := slackGroupGridChannels(workspaces, exports)
+
+	eng := grouped["T1"]
+	require.Len(t, eng.channels, 1)
+	assert.Equal(t, "C1", eng.channels[0].Id)
+	assert.False(t, eng.shared["C1"])
+
+	sales := grouped["T2"]
+	require.Len(t, sales.channels, 1)
+	assert.Equal(t, "C2", sales.channels[0].Id)
+	assert.ElementsMatch(t, []string{"U1", "U2"}, sales.channels[0].Members)
+	assert.True(t, sales.shared["C2"])
+	assert.Len(t, sales.posts["shared"], 3)
+}
+
+func TestSlackWorkspaceUsers(t *testing.T) {
+	users := []slackUser{
+		{Id: "U1", EnterpriseUser: &slackEnterpriseUser{Teams: []string{"T1"}}},
+		{Id: "U2", EnterpriseUser: &slackEnterpriseUser{Teams: []string{"T1", "T2"}}},
+		{Id: "U3", EnterpriseUser: &slackEnterpriseUser{Teams: []string{"T3"}}},
+		{Id: "U4"},
+	}
+
+	assert.Len(t, slackWorkspaceUsers(users, "T1"), 2)
+	assert.Len(t, slackWorkspaceUsers(users, "T2"), 1)
+
+	unassigned := slackUnassignedUsers(users, map[string]*model.Team{"T1": {}, "T2": {}})
+	require.Len(t, unassigned, 2)
+	assert.Equal(t, "U3", unassigned[0].Id)
+	assert.Equal(t, "U4", unassigned[1].Id)
+}
+
+func TestSlackConvertListToMarkdown(t *testing.T) {
+	list := slackList{
+		Columns: []slackListColumn{{Id: "c1", Name: "Task"}, {Id: "c2", Name: "Owner"}},
+		Items: []slackListItem{
+			{Fields: map[string]string{"c1": "Ship it", "c2": "alice"}},
+			{Fields: map[string]string{"c1": "a|b\nc"}},
+		},
+	}
+
+	expected := "| Task | Owner |\n| --- | --- |\n| Ship it | alice |\n| a\\|b c |  |\n"
+	assert.Equal(t, expected, slackConvertListToMarkdown(list))
+	assert.Empty(t, slackConvertListToMarkdown(slackList{Columns: list.Columns}))
+}
+
+func TestSlackAddCanvasesAndLists(t *testing.T) {
+	config := &model.Config{}
+	config.SetDefaults()
+	rctx := request.TestContext(t)
+
+	user := &model.User{Id: model.NewId(), Username: "user1"}
+	channel := &model.Channel{Id: model.NewId(), Name: "general"}
+	users := map[string]*model.User{"U1": user}
+	channels := map[string]*model.Channel{"C1": channel}
+
+	store := &mocks.Store{}
+	bookmarkStore := &mocks.ChannelBookmarkStore{}
+	bookmarkStore.On("Save", mock.MatchedBy(func(bookmark *model.ChannelBookmark) bool {
+		return bookmark.ChannelId == channel.Id && bookmark.OwnerId == user.Id && bookmark.LinkUrl == "https://example.slack.com/docs/F2"
+	}), true).Return(&model.ChannelBookmarkWithFileInfo{}, nil).Once()
+	store.On("ChannelBookmark").Return(bookmarkStore)
+	postStore := &mocks.PostStore{}
+	postStore.On("Save", rctx, mock.MatchedBy(func(post *model.Post) bool {
+		return post.ChannelId == channel.Id && post.Message == "#### Plan\n\n# Goals"
+	})).Return(&model.Post{}, nil).Once()
+	store.On("Post").Return(postStore)
+
+	importer := New(store, Actions{MaxPostSize: func() int { return model.PostMessageMaxRunesV2 }}, config)
+
+	importer.slackAddCanvases(rctx, []slackCanvas{
+		{Id: "F1", Title: "Plan", User: "U1", Channels: []string{"C1"}, Markdown: "# Goals"},
+		{Id: "F3", Title: "Orphan", User: "U1", Channels: []string{"C9"}, Markdown: "text"},
+		{Id: "F4", Title: "Empty", User: "U1", Channels: []string{"C1"}},
+	}, channels, users, nil)
+	importer.slackAddLists(rctx, []slackList{
+		{Id: "F2", Title: "Tasks", User: "U1", Channels: []string{"C1"}, Permalink: "https://example.slack.com/docs/F2"},
+		{Id: "F5", Title: "No owner", User: "U2", Channels: []string{"C1"}, Permalink: "https://example.slack.com/docs/F5"},
+	}, channels, users, nil)
+
+	bookmarkStore.AssertExpectations(t)
+	postStore.AssertExpectations(t)
+
+	summary := importer.Summary()
+	assert.Equal(t, 1, summary.Canvases)
+	assert.Equal(t, 1, summary.Lists)
+	require.Len(t, summary.Unconverted, 3)
+	assert.Equal(t, UnconvertedItem{Type: SlackImportItemCanvas, Id: "F3", Name: "Orphan", Reason: "not shared in any imported channel"}, summary.Unconverted[0])
+	assert.Equal(t, UnconvertedItem{Type: SlackImportItemCanvas, Id: "F4", Name: "Empty", Reason: "no content or link in the export"}, summary.Unconverted[1])
+	assert.Equal(t, UnconvertedItem{Type: SlackImportItemList, Id: "F5", Name: "No owner", Reason: "no user to import it as"}, summary.Unconverted[2])
+}
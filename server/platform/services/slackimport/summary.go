@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slackimport
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	SlackImportItemWorkspace = "workspace"
+	SlackImportItemUser      = "user"
+	SlackImportItemChannel   = "channel"
+	SlackImportItemMessage   = "message"
+	SlackImportItemCanvas    = "canvas"
+	SlackImportItemList      = "list"
+)
+
+// UnconvertedItem describes an item of a Slack export that could not be
+// converted into its Mattermost equivalent.
+type UnconvertedItem struct {
+	Type   string `json:"type"`
+	Id     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// ImportSummary is a structured report of what a Slack import created and
+// which items it was unable to convert.
+type ImportSummary struct {
+	Teams       int               `json:"teams"`
+	Users       int               `json:"users"`
+	Channels    int               `json:"channels"`
+	Canvases    int               `json:"canvases"`
+	Lists       int               `json:"lists"`
+	Unconverted []UnconvertedItem `json:"unconverted"`
+}
+
+func (s *ImportSummary) addUnconverted(itemType, id, name, reason string) {
+	s.Unconverted = append(s.Unconverted, UnconvertedItem{
+		Type:   itemType,
+		Id:     id,
+		Name:   name,
+		Reason: reason,
+	})
+}
+
+// write appends the summary, encoded as JSON, to the import log.
+func (s *ImportSummary) write(log *bytes.Buffer) {
+	if s.Unconverted == nil {
+		s.Unconverted = []UnconvertedItem{}
+	}
+
+	summary, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		mlog.Warn("Slack Import: Unable to encode the import summary.", mlog.Err(err))
+		return
+	}
+
+	log.WriteString(i18n.T("api.slackimport.slack_import.summary"))
+	log.WriteString("===============\r\n\r\n")
+	log.Write(summary)
+	log.WriteString("\r\n")
+}
@@ -75,6 +75,7 @@ const (
 	TrackConfigGuestAccounts       = "config_guest_accounts"
 	TrackConfigImageProxy          = "config_image_proxy"
 	TrackConfigBleve               = "config_bleve"
+	TrackConfigPostgresSearch      = "config_postgres_search"
 	TrackConfigExport              = "config_export"
 	TrackConfigWrangler            = "config_wrangler"
 	TrackConfigConnectedWorkspaces = "config_connected_workspaces"
@@ -480,6 +481,7 @@ func (ts *TelemetryService) trackConfig() {
 		"enable_outgoing_oauth_connections":                       cfg.ServiceSettings.EnableOutgoingOAuthConnections,
 		"enable_commands":                                         *cfg.ServiceSettings.EnableCommands,
 		"outgoing_integrations_requests_timeout":                  cfg.ServiceSettings.OutgoingIntegrationRequestsTimeout,
+		"outgoing_webhook_max_retries":                            *cfg.ServiceSettings.OutgoingWebhookMaxRetries,
 		"enable_post_username_override":                           cfg.ServiceSettings.EnablePostUsernameOverride,
 		"enable_post_icon_override":                               cfg.ServiceSettings.EnablePostIconOverride,
 		"enable_user_access_tokens":                               *cfg.ServiceSettings.EnableUserAccessTokens,
@@ -708,6 +710,11 @@ func (ts *TelemetryService) trackConfig() {
 		"max_burst":                *cfg.RateLimitSettings.MaxBurst,
 		"memory_store_size":        *cfg.RateLimitSettings.MemoryStoreSize,
 		"isdefault_vary_by_header": isDefault(cfg.RateLimitSettings.VaryByHeader, ""),
+		"store_type":               *cfg.RateLimitSettings.StoreType,
+		"posts_per_sec":            *cfg.RateLimitSettings.PostsPerSec,
+		"search_per_sec":           *cfg.RateLimitSettings.SearchPerSec,
+		"file_upload_per_sec":      *cfg.RateLimitSettings.FileUploadPerSec,
+		"access_token_per_sec":     *cfg.RateLimitSettings.AccessTokenPerSec,
 	})
 
 	ts.SendTelemetry(TrackConfigPrivacy, map[string]any{
@@ -946,6 +953,14 @@ func (ts *TelemetryService) trackConfig() {
 		"bulk_indexing_batch_size": *cfg.BleveSettings.BatchSize,
 	})
 
+	ts.SendTelemetry(TrackConfigPostgresSearch, map[string]any{
+		"enable_indexing":          *cfg.PostgresSearchSettings.EnableIndexing,
+		"enable_searching":         *cfg.PostgresSearchSettings.EnableSearching,
+		"enable_autocomplete":      *cfg.PostgresSearchSettings.EnableAutocomplete,
+		"text_search_config":       *cfg.PostgresSearchSettings.TextSearchConfig,
+		"bulk_indexing_batch_size": *cfg.PostgresSearchSettings.BatchSize,
+	})
+
 	ts.SendTelemetry(TrackConfigExport, map[string]any{
 		"retention_days": *cfg.ExportSettings.RetentionDays,
 	})
@@ -0,0 +1,112 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package translation provides translation services for post messages that don't depend on an external provider.
+package translation
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+)
+
+// DictionaryProvider translates messages word by word using a fixed dictionary per language. It is meant for
+// development and testing, where translations must be predictable and must not require network access.
+type DictionaryProvider struct {
+	dictionaries map[string]map[string]string
+}
+
+var _ einterfaces.TranslationInterface = (*DictionaryProvider)(nil)
+
+// NewDictionaryProvider creates a provider from dictionaries keyed by language code, each mapping a word to its
+// translation. Words are matched case-insensitively.
+func NewDictionaryProvider(dictionaries map[string]map[string]string) *DictionaryProvider {
+	provider := &DictionaryProvider{
+		dictionaries: make(map[string]map[string]string, len(dictionaries)),
+	}
+
+	for language, dictionary := range dictionaries {
+		words := make(map[string]string, len(dictionary))
+		for word, translation := range dictionary {
+			words[strings.ToLower(word)] = translation
+		}
+		provider.dictionaries[strings.ToLower(language)] = words
+	}
+
+	return provider
+}
+
+// Translate returns the translated message of each post that contains at least one word of the dictionary of the
+// given language. Posts for which no word is known are omitted.
+func (p *DictionaryProvider) Translate(rctx request.CTX, posts []*model.Post, language string) (map[string]string, *model.AppError) {
+	translations := map[string]string{}
+
+	dictionary, ok := p.dictionaries[strings.ToLower(language)]
+	if !ok {
+		return translations, nil
+	}
+
+	for _, post := range posts {
+		if translated, ok := translateMessage(post.Message, dictionary); ok {
+			translations[post.Id] = translated
+		}
+	}
+
+	return translations, nil
+}
+
+// translateMessage replaces each word of the message found in the dictionary, leaving everything else, including
+// punctuation and whitespace, untouched. It reports whether any word was replaced.
+func translateMessage(message string, dictionary map[string]string) (string, bool) {
+	var sb strings.Builder
+	translated := false
+
+	for message != "" {
+		i := strings.IndexFunc(message, isWordRune)
+		if i < 0 {
+			sb.WriteString(message)
+			break
+		}
+		sb.WriteString(message[:i])
+		message = message[i:]
+
+		end := strings.IndexFunc(message, func(r rune) bool { return !isWordRune(r) })
+		if end < 0 {
+			end = len(message)
+		}
+		word := message[:end]
+		message = message[end:]
+
+		if translation, ok := dictionary[strings.ToLower(word)]; ok {
+			sb.WriteString(matchCase(word, translation))
+			translated = true
+		} else {
+			sb.WriteString(word)
+		}
+	}
+
+	return sb.String(), translated
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || r == '\''
+}
+
+// matchCase applies the capitalization of word to its translation: all caps or a capitalized first letter.
+func matchCase(word, translation string) string {
+	first, _ := utf8.DecodeRuneInString(word)
+	if !unicode.IsUpper(first) {
+		return translation
+	}
+
+	if utf8.RuneCountInString(word) > 1 && strings.ToUpper(word) == word {
+		return strings.ToUpper(translation)
+	}
+
+	translationFirst, translationSize := utf8.DecodeRuneInString(translation)
+	return string(unicode.ToUpper(translationFirst)) + translation[translationSize:]
+}
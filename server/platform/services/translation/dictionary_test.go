@@ -0,0 +1,53 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package translation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+func TestDictionaryProvider(t *testing.T) {
+	provider := NewDictionaryProvider(map[string]map[string]string{
+		"FR": {
+			"hello":    "bonjour",
+			"World":    "monde",
+			"the":      "le",
+			"cat":      "chat",
+			"what's":   "qu'est-ce",
+			"éléphant": "elephant",
+		},
+	})
+	rctx := request.TestContext(t)
+
+	posts := []*model.Post{
+		{Id: model.NewId(), Message: "Hello, world!"},
+		{Id: model.NewId(), Message: "THE CAT sat on the mat"},
+		{Id: model.NewId(), Message: "nothing to see here"},
+		{Id: model.NewId(), Message: "What's up? Éléphant"},
+		{Id: model.NewId(), Message: ""},
+	}
+
+	t.Run("translates known words", func(t *testing.T) {
+		translations, appErr := provider.Translate(rctx, posts, "fr")
+		require.Nil(t, appErr)
+
+		assert.Equal(t, map[string]string{
+			posts[0].Id: "Bonjour, monde!",
+			posts[1].Id: "LE CHAT sat on le mat",
+			posts[3].Id: "Qu'est-ce up? Elephant",
+		}, translations)
+	})
+
+	t.Run("unknown language", func(t *testing.T) {
+		translations, appErr := provider.Translate(rctx, posts, "de")
+		require.Nil(t, appErr)
+		assert.Empty(t, translations)
+	})
+}
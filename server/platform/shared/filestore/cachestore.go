@@ -0,0 +1,661 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// cacheTempDirectory holds the files being downloaded, so that partially
+// written files are never served.
+const cacheTempDirectory = ".tmp"
+
+// CacheMetrics is the subset of einterfaces.MetricsInterface used by
+// the CachedFileBackend.
+type CacheMetrics interface {
+	IncrementFileStoreCacheHitCounter()
+	IncrementFileStoreCacheMissCounter()
+}
+
+// CachedFileBackend is a FileBackend keeping a copy of the recently read and
+// written files on the local disk. The files are stored in the cache under
+// their own path, and the least recently used ones are evicted once the cache
+// grows over its maximum size. Files larger than the maximum file size are
+// never cached, so that a single file can't evict the whole cache.
+//
+// The cache is invalidated by the changes made through this node only, so
+// only the uploaded files, which are stored under a path unique to each of
+// them and never modified in place, are cached. Files such as the profile
+// images, which are overwritten under the same path, are always read from the
+// backend so that the other nodes of a cluster never serve a stale copy.
+type CachedFileBackend struct {
+	backend          FileBackend
+	cache            *diskCache
+	maxFileSizeBytes int64
+	metrics          CacheMetrics
+}
+
+// NewCachedFileBackend wraps the backend with a cache stored in directory.
+// The backends using the same directory share the same cache. A maximum
+// file size that isn't positive defaults to the size of the cache.
+func NewCachedFileBackend(backend FileBackend, directory string, maxSizeBytes, maxFileSizeBytes int64, metrics CacheMetrics) (*CachedFileBackend, error) {
+	if maxSizeBytes <= 0 {
+		return nil, errors.New("the maximum size of the file cache must be positive")
+	}
+	if maxFileSizeBytes <= 0 || maxFileSizeBytes > maxSizeBytes {
+		maxFileSizeBytes = maxSizeBytes
+	}
+
+	cache, err := getDiskCache(directory, maxSizeBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CachedFileBackend{
+		backend:          backend,
+		cache:            cache,
+		maxFileSizeBytes: maxFileSizeBytes,
+		metrics:          metrics,
+	}, nil
+}
+
+// Unwrap returns the backend the files are read from and written to.
+func (b *CachedFileBackend) Unwrap() FileBackend {
+	return b.backend
+}
+
+func (b *CachedFileBackend) hit() {
+	if b.metrics != nil {
+		b.metrics.IncrementFileStoreCacheHitCounter()
+	}
+}
+
+func (b *CachedFileBackend) miss() {
+	if b.metrics != nil {
+		b.metrics.IncrementFileStoreCacheMissCounter()
+	}
+}
+
+func (b *CachedFileBackend) DriverName() string {
+	return b.backend.DriverName()
+}
+
+func (b *CachedFileBackend) TestConnection() error {
+	return b.backend.TestConnection()
+}
+
+func (b *CachedFileBackend) Reader(path string) (ReadCloseSeeker, error) {
+	if f := b.cache.open(path); f != nil {
+		b.hit()
+		return f, nil
+	}
+	b.miss()
+
+	r, err := b.backend.Reader(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := cacheKey(path); !ok {
+		return r, nil
+	}
+	tmp, err := b.cache.createTemp()
+	if err != nil {
+		mlog.Warn("Failed to create a file in the cache", mlog.Err(err))
+		return r, nil
+	}
+
+	// The file is cached as the caller reads it, rather than downloaded
+	// to the cache before being read again.
+	return &cachingReader{
+		r:     r,
+		cache: b.cache,
+		path:  path,
+		tmp:   tmp,
+		limit: b.maxFileSizeBytes,
+		size:  -1,
+	}, nil
+}
+
+func (b *CachedFileBackend) ReadFile(path string) ([]byte, error) {
+	if f := b.cache.open(path); f != nil {
+		defer f.Close()
+		data, err := io.ReadAll(f)
+		if err == nil {
+			b.hit()
+			return data, nil
+		}
+		mlog.Warn("Failed to read the file from the cache", mlog.String("path", path), mlog.Err(err))
+	}
+	b.miss()
+
+	data, err := b.backend.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b.cache.store(bytes.NewReader(data), path, b.maxFileSizeBytes)
+	return data, nil
+}
+
+func (b *CachedFileBackend) FileExists(path string) (bool, error) {
+	return b.backend.FileExists(path)
+}
+
+func (b *CachedFileBackend) FileSize(path string) (int64, error) {
+	return b.backend.FileSize(path)
+}
+
+func (b *CachedFileBackend) FileModTime(path string) (time.Time, error) {
+	return b.backend.FileModTime(path)
+}
+
+func (b *CachedFileBackend) CopyFile(oldPath, newPath string) error {
+	b.cache.remove(newPath)
+	return b.backend.CopyFile(oldPath, newPath)
+}
+
+func (b *CachedFileBackend) MoveFile(oldPath, newPath string) error {
+	b.cache.remove(oldPath)
+	b.cache.remove(newPath)
+	return b.backend.MoveFile(oldPath, newPath)
+}
+
+// WriteFile writes the file to the backend, keeping a copy in the cache.
+func (b *CachedFileBackend) WriteFile(fr io.Reader, path string) (int64, error) {
+	return b.writeFile(fr, path, b.backend.WriteFile)
+}
+
+// WriteFileContext is like WriteFile, stopping the write once the context is
+// done, even if the wrapped backend doesn't support contexts.
+func (b *CachedFileBackend) WriteFileContext(ctx context.Context, fr io.Reader, path string) (int64, error) {
+	return b.writeFile(&contextReader{ctx: ctx, r: fr}, path, func(fr io.Reader, path string) (int64, error) {
+		return TryWriteFileContext(ctx, b.backend, fr, path)
+	})
+}
+
+func (b *CachedFileBackend) writeFile(fr io.Reader, path string, write func(io.Reader, string) (int64, error)) (int64, error) {
+	b.cache.remove(path)
+
+	tmp, err := b.cache.createTemp()
+	if err != nil {
+		mlog.Warn("Failed to create a file in the cache", mlog.Err(err))
+		return write(fr, path)
+	}
+	defer os.Remove(tmp.Name())
+
+	// The copy is limited to the maximum file size, the larger files being
+	// written without caching them.
+	limited := &limitedWriter{w: tmp, limit: b.maxFileSizeBytes}
+	written, err := write(io.TeeReader(fr, limited), path)
+	closeErr := tmp.Close()
+	if err != nil {
+		return written, err
+	}
+
+	if closeErr == nil && limited.err == nil && !limited.exceeded {
+		b.cache.commit(tmp.Name(), path, limited.written)
+	}
+
+	return written, nil
+}
+
+func (b *CachedFileBackend) AppendFile(fr io.Reader, path string) (int64, error) {
+	b.cache.remove(path)
+	return b.backend.AppendFile(fr, path)
+}
+
+func (b *CachedFileBackend) RemoveFile(path string) error {
+	b.cache.remove(path)
+	return b.backend.RemoveFile(path)
+}
+
+func (b *CachedFileBackend) ListDirectory(path string) ([]string, error) {
+	return b.backend.ListDirectory(path)
+}
+
+func (b *CachedFileBackend) ListDirectoryRecursively(path string) ([]string, error) {
+	return b.backend.ListDirectoryRecursively(path)
+}
+
+func (b *CachedFileBackend) RemoveDirectory(path string) error {
+	b.cache.removeDirectory(path)
+	return b.backend.RemoveDirectory(path)
+}
+
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := r.r.Read(p)
+	if ctxErr := r.ctx.Err(); ctxErr != nil {
+		return n, ctxErr
+	}
+	return n, err
+}
+
+// cachingReader copies the file to the cache as it's read. The copy is
+// committed to the cache once the whole file has been read in order, and
+// dropped if the reader seeks elsewhere, or the file exceeds the limit.
+type cachingReader struct {
+	r     ReadCloseSeeker
+	cache *diskCache
+	path  string
+	limit int64
+
+	// tmp is the partial copy of the file, nil once committed or dropped.
+	tmp     *os.File
+	written int64
+	offset  int64
+	// size is the size of the file if known, which tells that it has been
+	// read entirely by callers that don't read until EOF.
+	size int64
+}
+
+func (c *cachingReader) Read(p []byte) (int, error) {
+	if c.tmp != nil && c.offset != c.written {
+		c.drop()
+	}
+
+	n, err := c.r.Read(p)
+	c.offset += int64(n)
+	if c.tmp == nil {
+		return n, err
+	}
+
+	if n > 0 {
+		if c.written+int64(n) > c.limit {
+			c.drop()
+			return n, err
+		}
+		if _, writeErr := c.tmp.Write(p[:n]); writeErr != nil {
+			mlog.Warn("Failed to write the file to the cache", mlog.String("path", c.path), mlog.Err(writeErr))
+			c.drop()
+			return n, err
+		}
+		c.written += int64(n)
+	}
+
+	if err == io.EOF || c.written == c.size {
+		c.commit()
+	}
+	return n, err
+}
+
+func (c *cachingReader) Seek(offset int64, whence int) (int64, error) {
+	pos, err := c.r.Seek(offset, whence)
+	if err != nil {
+		c.drop()
+		return pos, err
+	}
+	c.offset = pos
+
+	// Seeking to the end tells the size of the file, before reading it.
+	if whence == io.SeekEnd {
+		c.size = pos - offset
+		if c.size > c.limit {
+			c.drop()
+		}
+	}
+	return pos, nil
+}
+
+func (c *cachingReader) Close() error {
+	c.drop()
+	return c.r.Close()
+}
+
+func (c *cachingReader) commit() {
+	tmp := c.tmp
+	c.tmp = nil
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Close(); err != nil {
+		mlog.Warn("Failed to write the file to the cache", mlog.String("path", c.path), mlog.Err(err))
+		return
+	}
+	c.cache.commit(tmp.Name(), c.path, c.written)
+}
+
+func (c *cachingReader) drop() {
+	if c.tmp == nil {
+		return
+	}
+	c.tmp.Close()
+	os.Remove(c.tmp.Name())
+	c.tmp = nil
+}
+
+// limitedWriter writes up to limit bytes, silently dropping the rest so that
+// the write to the backend is never interrupted by the cache.
+type limitedWriter struct {
+	w        io.Writer
+	limit    int64
+	written  int64
+	exceeded bool
+	err      error
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.exceeded || l.err != nil {
+		return len(p), nil
+	}
+
+	if l.written+int64(len(p)) > l.limit {
+		l.exceeded = true
+		return len(p), nil
+	}
+
+	n, err := l.w.Write(p)
+	l.written += int64(n)
+	l.err = err
+	return len(p), nil
+}
+
+var (
+	diskCachesMut sync.Mutex
+	diskCaches    = map[string]*diskCache{}
+)
+
+type diskCacheEntry struct {
+	key  string
+	size int64
+}
+
+// diskCache is a size bounded LRU of files stored on the local disk.
+type diskCache struct {
+	directory string
+
+	mut     sync.Mutex
+	maxSize int64
+	size    int64
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+func getDiskCache(directory string, maxSize int64) (*diskCache, error) {
+	directory, err := filepath.Abs(directory)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to resolve the file cache directory")
+	}
+
+	diskCachesMut.Lock()
+	defer diskCachesMut.Unlock()
+
+	if c, ok := diskCaches[directory]; ok {
+		c.mut.Lock()
+		c.maxSize = maxSize
+		c.evict()
+		c.mut.Unlock()
+		return c, nil
+	}
+
+	c := &diskCache{
+		directory: directory,
+		maxSize:   maxSize,
+		lru:       list.New(),
+		entries:   map[string]*list.Element{},
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	diskCaches[directory] = c
+
+	return c, nil
+}
+
+// load indexes the files cached before a restart, the most recently modified
+// ones being the most recently used.
+func (c *diskCache) load() error {
+	if err := os.RemoveAll(filepath.Join(c.directory, cacheTempDirectory)); err != nil {
+		return errors.Wrap(err, "unable to clean the file cache directory")
+	}
+	if err := os.MkdirAll(filepath.Join(c.directory, cacheTempDirectory), 0750); err != nil {
+		return errors.Wrap(err, "unable to create the file cache directory")
+	}
+
+	type cachedFile struct {
+		key     string
+		size    int64
+		modTime time.Time
+	}
+	var files []cachedFile
+	err := filepath.WalkDir(c.directory, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == cacheTempDirectory {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(c.directory, p)
+		if err != nil {
+			return err
+		}
+		if _, ok := cacheKey(rel); !ok {
+			return os.Remove(p)
+		}
+		files = append(files, cachedFile{key: filepath.ToSlash(rel), size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to read the file cache directory")
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.After(files[j].modTime)
+	})
+	for _, f := range files {
+		c.entries[f.key] = c.lru.PushBack(&diskCacheEntry{key: f.key, size: f.size})
+		c.size += f.size
+	}
+	c.evict()
+
+	return nil
+}
+
+// cleanCachePath returns the path relative to the cache directory.
+func cleanCachePath(filePath string) string {
+	return strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(filePath)), "/")
+}
+
+// cacheKey returns the path of the file relative to the cache directory, or
+// false if the file can't be cached. Only the uploaded files, stored under
+// YYYYMMDD/teams/, are cached, as they are never modified in place.
+func cacheKey(filePath string) (string, bool) {
+	key := cleanCachePath(filePath)
+	segments := strings.SplitN(key, "/", 3)
+	if len(segments) < 3 || segments[1] != "teams" || !isUploadDate(segments[0]) {
+		return "", false
+	}
+	return key, true
+}
+
+func isUploadDate(s string) bool {
+	if len(s) != 8 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *diskCache) localPath(key string) string {
+	return filepath.Join(c.directory, filepath.FromSlash(key))
+}
+
+func (c *diskCache) open(filePath string) *os.File {
+	key, ok := cacheKey(filePath)
+	if !ok {
+		return nil
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+
+	// Opening the file under the lock ensures it isn't evicted in
+	// between. Once opened, it remains readable even if evicted.
+	f, err := os.Open(c.localPath(key))
+	if err != nil {
+		c.removeElement(elem)
+		return nil
+	}
+	c.lru.MoveToFront(elem)
+
+	return f
+}
+
+func (c *diskCache) createTemp() (*os.File, error) {
+	return os.CreateTemp(filepath.Join(c.directory, cacheTempDirectory), "file")
+}
+
+// store copies the content of r to the cache, unless it's larger than
+// maxFileSize, returning whether it succeeded.
+func (c *diskCache) store(r io.Reader, filePath string, maxFileSize int64) bool {
+	if _, ok := cacheKey(filePath); !ok {
+		return false
+	}
+
+	tmp, err := c.createTemp()
+	if err != nil {
+		mlog.Warn("Failed to create a file in the cache", mlog.Err(err))
+		return false
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, io.LimitReader(r, maxFileSize+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		mlog.Warn("Failed to write the file to the cache", mlog.String("path", filePath), mlog.Err(err))
+		return false
+	}
+	if written > maxFileSize {
+		return false
+	}
+
+	return c.commit(tmp.Name(), filePath, written)
+}
+
+// commit moves the downloaded file to its place in the cache.
+func (c *diskCache) commit(tmpPath, filePath string, size int64) bool {
+	key, ok := cacheKey(filePath)
+	if !ok {
+		return false
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if size > c.maxSize {
+		return false
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		c.removeElement(elem)
+	}
+
+	dst := c.localPath(key)
+	if err := os.MkdirAll(filepath.Dir(dst), 0750); err != nil {
+		mlog.Warn("Failed to create the directory in the cache", mlog.String("path", filePath), mlog.Err(err))
+		return false
+	}
+	if err := os.Rename(tmpPath, dst); err != nil {
+		mlog.Warn("Failed to move the file to the cache", mlog.String("path", filePath), mlog.Err(err))
+		return false
+	}
+
+	c.entries[key] = c.lru.PushFront(&diskCacheEntry{key: key, size: size})
+	c.size += size
+	c.evict()
+
+	return true
+}
+
+func (c *diskCache) remove(filePath string) {
+	key, ok := cacheKey(filePath)
+	if !ok {
+		return
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.removeElement(elem)
+	}
+}
+
+func (c *diskCache) removeDirectory(dirPath string) {
+	key := cleanCachePath(dirPath)
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	for entryKey, elem := range c.entries {
+		if key == "" || strings.HasPrefix(entryKey, key+"/") {
+			c.removeElement(elem)
+		}
+	}
+}
+
+// evict removes the least recently used files until the cache fits its
+// maximum size. It must be called with the lock held.
+func (c *diskCache) evict() {
+	for c.size > c.maxSize {
+		elem := c.lru.Back()
+		if elem == nil {
+			return
+		}
+		c.removeElement(elem)
+	}
+}
+
+// removeElement must be called with the lock held.
+func (c *diskCache) removeElement(elem *list.Element) {
+	entry := elem.Value.(*diskCacheEntry)
+	c.lru.Remove(elem)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+
+	if err := os.Remove(c.localPath(entry.key)); err != nil && !os.IsNotExist(err) {
+		mlog.Warn("Failed to remove the file from the cache", mlog.String("key", entry.key), mlog.Err(err))
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type testCacheMetrics struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+func (m *testCacheMetrics) IncrementFileStoreCacheHitCounter() {
+	m.hits.Add(1)
+}
+
+func (m *testCacheMetrics) IncrementFileStoreCacheMissCounter() {
+	m.misses.Add(1)
+}
+
+func TestCachedFileBackendTestSuite(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(t)
+	mlog.InitGlobalLogger(logger)
+
+	suite.Run(t, &FileBackendTestSuite{
+		settings: FileBackendSettings{
+			DriverName:             driverLocal,
+			Directory:              t.TempDir(),
+			LocalCacheDirectory:    t.TempDir(),
+			LocalCacheMaxSizeBytes: 10 * 1024 * 1024,
+		},
+	})
+}
+
+// testUploadDir is where the server stores an uploaded file, the only files
+// that are cached.
+const testUploadDir = "20240101/teams/team/channels/channel/users/user/file"
+
+func newTestCachedFileBackend(t *testing.T, maxSize int64) (*CachedFileBackend, *testCacheMetrics, string) {
+	t.Helper()
+
+	cacheDir := t.TempDir()
+	metrics := &testCacheMetrics{}
+	backend, err := NewCachedFileBackend(&LocalFileBackend{directory: t.TempDir()}, cacheDir, maxSize, 0, metrics)
+	require.NoError(t, err)
+
+	return backend, metrics, cacheDir
+}
+
+func TestCachedFileBackend(t *testing.T) {
+	t.Run("read through", func(t *testing.T) {
+		backend, metrics, cacheDir := newTestCachedFileBackend(t, 1024)
+
+		// Writing to the inner backend directly leaves the cache empty.
+		_, err := backend.Unwrap().WriteFile(strings.NewReader("content"), testUploadDir+"/dir/file.txt")
+		require.NoError(t, err)
+
+		data, err := backend.ReadFile(testUploadDir + "/dir/file.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "content", string(data))
+		assert.Equal(t, int64(0), metrics.hits.Load())
+		assert.Equal(t, int64(1), metrics.misses.Load())
+		assert.FileExists(t, filepath.Join(cacheDir, testUploadDir, "dir", "file.txt"))
+
+		r, err := backend.Reader(testUploadDir + "/dir/file.txt")
+		require.NoError(t, err)
+		data, err = io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		assert.Equal(t, "content", string(data))
+		assert.Equal(t, int64(1), metrics.hits.Load())
+		assert.Equal(t, int64(1), metrics.misses.Load())
+	})
+
+	t.Run("write through", func(t *testing.T) {
+		backend, metrics, cacheDir := newTestCachedFileBackend(t, 1024)
+
+		written, err := backend.WriteFile(strings.NewReader("content"), testUploadDir+"/file.txt")
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), written)
+		assert.FileExists(t, filepath.Join(cacheDir, testUploadDir, "file.txt"))
+
+		data, err := backend.ReadFile(testUploadDir + "/file.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "content", string(data))
+		assert.Equal(t, int64(1), metrics.hits.Load())
+		assert.Equal(t, int64(0), metrics.misses.Load())
+	})
+
+	t.Run("files larger than the cache are not cached", func(t *testing.T) {
+		backend, metrics, cacheDir := newTestCachedFileBackend(t, 4)
+
+		_, err := backend.WriteFile(strings.NewReader("content"), testUploadDir+"/file.txt")
+		require.NoError(t, err)
+		assert.NoFileExists(t, filepath.Join(cacheDir, testUploadDir, "file.txt"))
+
+		r, err := backend.Reader(testUploadDir + "/file.txt")
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		assert.Equal(t, "content", string(data))
+		assert.Equal(t, int64(1), metrics.misses.Load())
+		assert.NoFileExists(t, filepath.Join(cacheDir, testUploadDir, "file.txt"))
+	})
+
+	t.Run("files larger than the maximum file size are not cached", func(t *testing.T) {
+		backend, err := NewCachedFileBackend(&LocalFileBackend{directory: t.TempDir()}, t.TempDir(), 1024, 4, nil)
+		require.NoError(t, err)
+		cacheDir := backend.cache.directory
+
+		_, err = backend.WriteFile(strings.NewReader("content"), testUploadDir+"/file.txt")
+		require.NoError(t, err)
+		assert.NoFileExists(t, filepath.Join(cacheDir, testUploadDir, "file.txt"))
+
+		data, err := backend.ReadFile(testUploadDir + "/file.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "content", string(data))
+		assert.NoFileExists(t, filepath.Join(cacheDir, testUploadDir, "file.txt"))
+
+		_, err = backend.WriteFile(strings.NewReader("tiny"), testUploadDir+"/tiny.txt")
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(cacheDir, testUploadDir, "tiny.txt"))
+	})
+
+	t.Run("files are cached as they are read", func(t *testing.T) {
+		for name, read := range map[string]func(r ReadCloseSeeker) ([]byte, error){
+			"until EOF": func(r ReadCloseSeeker) ([]byte, error) {
+				return io.ReadAll(r)
+			},
+			"like http.ServeContent": func(r ReadCloseSeeker) ([]byte, error) {
+				size, err := r.Seek(0, io.SeekEnd)
+				if err != nil {
+					return nil, err
+				}
+				if _, err = r.Seek(0, io.SeekStart); err != nil {
+					return nil, err
+				}
+				var buf bytes.Buffer
+				_, err = io.CopyN(&buf, r, size)
+				return buf.Bytes(), err
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				backend, metrics, cacheDir := newTestCachedFileBackend(t, 1024)
+				_, err := backend.Unwrap().WriteFile(strings.NewReader("content"), testUploadDir+"/file.txt")
+				require.NoError(t, err)
+
+				r, err := backend.Reader(testUploadDir + "/file.txt")
+				require.NoError(t, err)
+				data, err := read(r)
+				require.NoError(t, err)
+				assert.Equal(t, "content", string(data))
+				assert.FileExists(t, filepath.Join(cacheDir, testUploadDir, "file.txt"))
+				require.NoError(t, r.Close())
+
+				data, err = backend.ReadFile(testUploadDir + "/file.txt")
+				require.NoError(t, err)
+				assert.Equal(t, "content", string(data))
+				assert.Equal(t, int64(1), metrics.hits.Load())
+			})
+		}
+	})
+
+	t.Run("partially read files are not cached", func(t *testing.T) {
+		backend, _, cacheDir := newTestCachedFileBackend(t, 1024)
+		_, err := backend.Unwrap().WriteFile(strings.NewReader("content"), testUploadDir+"/file.txt")
+		require.NoError(t, err)
+
+		r, err := backend.Reader(testUploadDir + "/file.txt")
+		require.NoError(t, err)
+		_, err = r.Read(make([]byte, 3))
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		assert.NoFileExists(t, filepath.Join(cacheDir, testUploadDir, "file.txt"))
+
+		r, err = backend.Reader(testUploadDir + "/file.txt")
+		require.NoError(t, err)
+		_, err = r.Seek(3, io.SeekStart)
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, "tent", string(data))
+		require.NoError(t, r.Close())
+		assert.NoFileExists(t, filepath.Join(cacheDir, testUploadDir, "file.txt"))
+	})
+
+	t.Run("invalidation", func(t *testing.T) {
+		backend, _, cacheDir := newTestCachedFileBackend(t, 1024)
+
+		for _, name := range []string{"a.txt", "b.txt", "dir/c.txt", "dir/d.txt"} {
+			_, err := backend.WriteFile(strings.NewReader(name), testUploadDir+"/"+name)
+			require.NoError(t, err)
+			require.FileExists(t, filepath.Join(cacheDir, testUploadDir, name))
+		}
+
+		require.NoError(t, backend.RemoveFile(testUploadDir+"/a.txt"))
+		assert.NoFileExists(t, filepath.Join(cacheDir, testUploadDir, "a.txt"))
+		_, err := backend.ReadFile(testUploadDir + "/a.txt")
+		require.Error(t, err)
+
+		require.NoError(t, backend.MoveFile(testUploadDir+"/b.txt", testUploadDir+"/dir/c.txt"))
+		assert.NoFileExists(t, filepath.Join(cacheDir, "b.txt"))
+		data, err := backend.ReadFile(testUploadDir + "/dir/c.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "b.txt", string(data))
+
+		require.NoError(t, backend.RemoveDirectory(testUploadDir+"/dir"))
+		assert.NoFileExists(t, filepath.Join(cacheDir, testUploadDir, "dir", "c.txt"))
+		assert.NoFileExists(t, filepath.Join(cacheDir, testUploadDir, "dir", "d.txt"))
+	})
+
+	t.Run("the least recently used files are evicted", func(t *testing.T) {
+		backend, _, cacheDir := newTestCachedFileBackend(t, 10)
+
+		for _, name := range []string{"a", "b"} {
+			_, err := backend.WriteFile(bytes.NewReader(make([]byte, 4)), testUploadDir+"/"+name)
+			require.NoError(t, err)
+		}
+
+		_, err := backend.ReadFile(testUploadDir + "/a")
+		require.NoError(t, err)
+
+		_, err = backend.WriteFile(bytes.NewReader(make([]byte, 4)), testUploadDir+"/c")
+		require.NoError(t, err)
+
+		assert.FileExists(t, filepath.Join(cacheDir, testUploadDir, "a"))
+		assert.NoFileExists(t, filepath.Join(cacheDir, testUploadDir, "b"))
+		assert.FileExists(t, filepath.Join(cacheDir, testUploadDir, "c"))
+	})
+
+	t.Run("files modified in place are not cached", func(t *testing.T) {
+		backend, metrics, cacheDir := newTestCachedFileBackend(t, 1024)
+
+		_, err := backend.WriteFile(strings.NewReader("old"), "users/user/profile.png")
+		require.NoError(t, err)
+		assert.NoFileExists(t, filepath.Join(cacheDir, "users", "user", "profile.png"))
+
+		// Another node of the cluster overwrites the file.
+		_, err = backend.Unwrap().WriteFile(strings.NewReader("new"), "users/user/profile.png")
+		require.NoError(t, err)
+
+		data, err := backend.ReadFile("users/user/profile.png")
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(data))
+		assert.NoFileExists(t, filepath.Join(cacheDir, "users", "user", "profile.png"))
+		assert.Equal(t, int64(0), metrics.hits.Load())
+	})
+
+	t.Run("the cache is restored", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(cacheDir, testUploadDir, "dir"), 0750))
+		require.NoError(t, os.WriteFile(filepath.Join(cacheDir, testUploadDir, "dir", "file.txt"), []byte("cached"), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(cacheDir, "file.txt"), []byte("cached"), 0600))
+
+		metrics := &testCacheMetrics{}
+		backend, err := NewCachedFileBackend(&LocalFileBackend{directory: t.TempDir()}, cacheDir, 1024, 0, metrics)
+		require.NoError(t, err)
+
+		data, err := backend.ReadFile(testUploadDir + "/dir/file.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "cached", string(data))
+		assert.Equal(t, int64(1), metrics.hits.Load())
+		assert.NoFileExists(t, filepath.Join(cacheDir, "file.txt"))
+	})
+}
+
+func TestCacheKey(t *testing.T) {
+	for path, expected := range map[string]string{
+		"20240101/teams/t/f/file.txt":       "20240101/teams/t/f/file.txt",
+		"/20240101/teams/t/f/file.txt":      "20240101/teams/t/f/file.txt",
+		"20240101/teams/t/x/../f/file.txt":  "20240101/teams/t/f/file.txt",
+		"../../20240101/teams/t/f/file.txt": "20240101/teams/t/f/file.txt",
+		"20240101//teams/t/f/file.txt":      "20240101/teams/t/f/file.txt",
+	} {
+		key, ok := cacheKey(path)
+		assert.True(t, ok, path)
+		assert.Equal(t, expected, key, path)
+	}
+
+	for _, path := range []string{
+		"",
+		"/",
+		".tmp",
+		".tmp/file",
+		"file.txt",
+		"20240101/teams",
+		"20240101/users/u/file.txt",
+		"2024010/teams/t/file.txt",
+		"2024010a/teams/t/file.txt",
+		"users/u/profile.png",
+		"teams/t/teamIcon.png",
+		"brand/image.png",
+	} {
+		_, ok := cacheKey(path)
+		assert.False(t, ok, path)
+	}
+}
@@ -63,6 +63,12 @@ type FileBackendSettings struct {
 	AmazonS3RequestTimeoutMilliseconds int64
 	AmazonS3PresignExpiresSeconds      int64
 	AmazonS3UploadPartSizeBytes        int64
+	// LocalCacheDirectory enables the local disk cache of the files when set.
+	LocalCacheDirectory    string
+	LocalCacheMaxSizeBytes int64
+	// LocalCacheMaxFileSizeBytes is the size above which files bypass the cache.
+	LocalCacheMaxFileSizeBytes int64
+	LocalCacheMetrics          CacheMetrics
 }
 
 func NewFileBackendSettingsFromConfig(fileSettings *model.FileSettings, enableComplianceFeature bool, skipVerify bool) FileBackendSettings {
@@ -72,7 +78,7 @@ func NewFileBackendSettingsFromConfig(fileSettings *model.FileSettings, enableCo
 			Directory:  *fileSettings.Directory,
 		}
 	}
-	return FileBackendSettings{
+	settings := FileBackendSettings{
 		DriverName:                         *fileSettings.DriverName,
 		AmazonS3AccessKeyId:                *fileSettings.AmazonS3AccessKeyId,
 		AmazonS3SecretAccessKey:            *fileSettings.AmazonS3SecretAccessKey,
@@ -88,6 +94,12 @@ func NewFileBackendSettingsFromConfig(fileSettings *model.FileSettings, enableCo
 		SkipVerify:                         skipVerify,
 		AmazonS3UploadPartSizeBytes:        *fileSettings.AmazonS3UploadPartSizeBytes,
 	}
+	if fileSettings.EnableLocalCache != nil && *fileSettings.EnableLocalCache {
+		settings.LocalCacheDirectory = *fileSettings.LocalCacheDirectory
+		settings.LocalCacheMaxSizeBytes = *fileSettings.LocalCacheMaxSizeBytes
+		settings.LocalCacheMaxFileSizeBytes = *fileSettings.LocalCacheMaxFileSizeBytes
+	}
+	return settings
 }
 
 func NewExportFileBackendSettingsFromConfig(fileSettings *model.FileSettings, enableComplianceFeature bool, skipVerify bool) FileBackendSettings {
@@ -140,6 +152,19 @@ func NewExportFileBackend(settings FileBackendSettings) (FileBackend, error) {
 }
 
 func newFileBackend(settings FileBackendSettings, canBeCloud bool) (FileBackend, error) {
+	backend, err := newDriverFileBackend(settings, canBeCloud)
+	if err != nil || settings.LocalCacheDirectory == "" {
+		return backend, err
+	}
+
+	cachedBackend, err := NewCachedFileBackend(backend, settings.LocalCacheDirectory, settings.LocalCacheMaxSizeBytes, settings.LocalCacheMaxFileSizeBytes, settings.LocalCacheMetrics)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the local file cache")
+	}
+	return cachedBackend, nil
+}
+
+func newDriverFileBackend(settings FileBackendSettings, canBeCloud bool) (FileBackend, error) {
 	switch settings.DriverName {
 	case driverS3:
 		newBackendFn := NewS3FileBackend
@@ -28,6 +28,8 @@ var MediaContentTypes = [...]string{
 	"image/gif",
 	"image/tiff",
 	"image/webp",
+	"image/avif",
+	"image/apng",
 	"video/avi",
 	"video/mpeg",
 	"video/mp4",
@@ -4,8 +4,13 @@
 package model
 
 import (
+	"database/sql/driver"
 	"encoding/json"
+	"errors"
+	"net/http"
 	"regexp"
+	"strings"
+	"unicode/utf8"
 )
 
 type SidebarCategoryType string
@@ -35,6 +40,21 @@ const (
 	SidebarCategorySortAlphabetical SidebarCategorySorting = "alpha"
 )
 
+type SidebarCategoryRuleType string
+
+const (
+	// Rules place the channels a user joins into custom categories automatically.
+	// The value of a name prefix rule is matched against the name of public and private channels
+	SidebarCategoryRuleNamePrefix SidebarCategoryRuleType = "name_prefix"
+	// matches direct messages with bots
+	SidebarCategoryRuleBotDirectMessages SidebarCategoryRuleType = "bot_direct_messages"
+	// matches channels shared with remote clusters
+	SidebarCategoryRuleShared SidebarCategoryRuleType = "shared"
+
+	SidebarCategoryRulesMax          = 10
+	SidebarCategoryRuleValueMaxRunes = ChannelNameMaxLength
+)
+
 // SidebarCategory represents the corresponding DB table
 type SidebarCategory struct {
 	Id          string                 `json:"id"`
@@ -51,13 +71,115 @@ type SidebarCategory struct {
 // SidebarCategoryWithChannels combines data from SidebarCategory table with the Channel IDs that belong to that category
 type SidebarCategoryWithChannels struct {
 	SidebarCategory
-	Channels []string `json:"channel_ids"`
+	Channels []string             `json:"channel_ids"`
+	Rules    SidebarCategoryRules `json:"rules,omitempty"`
 }
 
 func (sc SidebarCategoryWithChannels) ChannelIds() []string {
 	return sc.Channels
 }
 
+// SidebarCategoryRule describes channels that should be placed into a custom category when the user joins them
+type SidebarCategoryRule struct {
+	Type  SidebarCategoryRuleType `json:"type"`
+	Value string                  `json:"value,omitempty"`
+}
+
+// Matches returns true if the channel is matched by the rule. isBotDM tells whether the channel is a direct message
+// with a bot, since that can't be known from the channel alone.
+func (r *SidebarCategoryRule) Matches(channel *Channel, isBotDM bool) bool {
+	switch r.Type {
+	case SidebarCategoryRuleNamePrefix:
+		if channel.Type != ChannelTypeOpen && channel.Type != ChannelTypePrivate {
+			return false
+		}
+		return strings.HasPrefix(channel.Name, strings.ToLower(r.Value))
+	case SidebarCategoryRuleBotDirectMessages:
+		return channel.Type == ChannelTypeDirect && isBotDM
+	case SidebarCategoryRuleShared:
+		return channel.IsShared()
+	}
+
+	return false
+}
+
+// SidebarCategoryRules are the rules of a custom category. A channel belongs to the category if any of them matches.
+type SidebarCategoryRules []*SidebarCategoryRule
+
+func (rules SidebarCategoryRules) Matches(channel *Channel, isBotDM bool) bool {
+	for _, rule := range rules {
+		if rule.Matches(channel, isBotDM) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (rules SidebarCategoryRules) IsValid() *AppError {
+	if len(rules) > SidebarCategoryRulesMax {
+		return NewAppError("SidebarCategoryRules.IsValid", "model.sidebar_category_rules.is_valid.too_many.app_error", map[string]any{"Max": SidebarCategoryRulesMax}, "", http.StatusBadRequest)
+	}
+
+	for _, rule := range rules {
+		if rule == nil {
+			return NewAppError("SidebarCategoryRules.IsValid", "model.sidebar_category_rules.is_valid.type.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		switch rule.Type {
+		case SidebarCategoryRuleNamePrefix:
+			if rule.Value == "" || utf8.RuneCountInString(rule.Value) > SidebarCategoryRuleValueMaxRunes {
+				return NewAppError("SidebarCategoryRules.IsValid", "model.sidebar_category_rules.is_valid.value.app_error", map[string]any{"Max": SidebarCategoryRuleValueMaxRunes}, "type="+string(rule.Type), http.StatusBadRequest)
+			}
+		case SidebarCategoryRuleBotDirectMessages, SidebarCategoryRuleShared:
+			if rule.Value != "" {
+				return NewAppError("SidebarCategoryRules.IsValid", "model.sidebar_category_rules.is_valid.no_value.app_error", nil, "type="+string(rule.Type), http.StatusBadRequest)
+			}
+		default:
+			return NewAppError("SidebarCategoryRules.IsValid", "model.sidebar_category_rules.is_valid.type.app_error", nil, "type="+string(rule.Type), http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// Scan converts the database column value to SidebarCategoryRules
+func (rules *SidebarCategoryRules) Scan(value any) error {
+	if value == nil {
+		return nil
+	}
+
+	var buf []byte
+	switch v := value.(type) {
+	case []byte:
+		buf = v
+	case string:
+		buf = []byte(v)
+	default:
+		return errors.New("received value is neither a byte slice nor string")
+	}
+
+	if len(buf) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(buf, rules)
+}
+
+// Value converts SidebarCategoryRules to a database value. Categories without rules are stored as NULL.
+func (rules SidebarCategoryRules) Value() (driver.Value, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	j, err := json.Marshal(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(j), nil
+}
+
 type SidebarCategoryOrder []string
 
 // OrderedSidebarCategories combines categories, their channel IDs and an array of Category IDs, sorted
@@ -5,9 +5,11 @@ package model
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsValidCategoryId(t *testing.T) {
@@ -47,3 +49,116 @@ func TestIsValidCategoryId(t *testing.T) {
 		})
 	}
 }
+
+func TestSidebarCategoryRulesMatches(t *testing.T) {
+	incident := &Channel{Name: "inc-database", Type: ChannelTypeOpen}
+	private := &Channel{Name: "inc-private", Type: ChannelTypePrivate}
+	other := &Channel{Name: "town-square", Type: ChannelTypeOpen}
+	shared := &Channel{Name: "partners", Type: ChannelTypeOpen, Shared: NewPointer(true)}
+	dm := &Channel{Name: NewId() + "__" + NewId(), Type: ChannelTypeDirect}
+
+	t.Run("name prefix", func(t *testing.T) {
+		rule := &SidebarCategoryRule{Type: SidebarCategoryRuleNamePrefix, Value: "Inc-"}
+		assert.True(t, rule.Matches(incident, false))
+		assert.True(t, rule.Matches(private, false))
+		assert.False(t, rule.Matches(other, false))
+		assert.False(t, rule.Matches(&Channel{Name: "inc-" + NewId(), Type: ChannelTypeGroup}, false))
+	})
+
+	t.Run("bot direct messages", func(t *testing.T) {
+		rule := &SidebarCategoryRule{Type: SidebarCategoryRuleBotDirectMessages}
+		assert.True(t, rule.Matches(dm, true))
+		assert.False(t, rule.Matches(dm, false))
+		assert.False(t, rule.Matches(incident, true))
+	})
+
+	t.Run("shared", func(t *testing.T) {
+		rule := &SidebarCategoryRule{Type: SidebarCategoryRuleShared}
+		assert.True(t, rule.Matches(shared, false))
+		assert.False(t, rule.Matches(other, false))
+	})
+
+	t.Run("any rule matches", func(t *testing.T) {
+		rules := SidebarCategoryRules{
+			{Type: SidebarCategoryRuleNamePrefix, Value: "inc-"},
+			{Type: SidebarCategoryRuleShared},
+		}
+		assert.True(t, rules.Matches(incident, false))
+		assert.True(t, rules.Matches(shared, false))
+		assert.False(t, rules.Matches(other, false))
+		assert.False(t, SidebarCategoryRules(nil).Matches(incident, false))
+	})
+}
+
+func TestSidebarCategoryRulesIsValid(t *testing.T) {
+	for _, test := range []struct {
+		Name  string
+		Rules SidebarCategoryRules
+		Valid bool
+	}{
+		{
+			Name:  "no rules",
+			Rules: nil,
+			Valid: true,
+		},
+		{
+			Name: "valid rules",
+			Rules: SidebarCategoryRules{
+				{Type: SidebarCategoryRuleNamePrefix, Value: "inc-"},
+				{Type: SidebarCategoryRuleBotDirectMessages},
+				{Type: SidebarCategoryRuleShared},
+			},
+			Valid: true,
+		},
+		{
+			Name:  "unknown type",
+			Rules: SidebarCategoryRules{{Type: "unknown"}},
+		},
+		{
+			Name:  "nil rule",
+			Rules: SidebarCategoryRules{nil},
+		},
+		{
+			Name:  "name prefix without value",
+			Rules: SidebarCategoryRules{{Type: SidebarCategoryRuleNamePrefix}},
+		},
+		{
+			Name:  "name prefix too long",
+			Rules: SidebarCategoryRules{{Type: SidebarCategoryRuleNamePrefix, Value: strings.Repeat("a", SidebarCategoryRuleValueMaxRunes+1)}},
+		},
+		{
+			Name:  "value on a rule without value",
+			Rules: SidebarCategoryRules{{Type: SidebarCategoryRuleShared, Value: "inc-"}},
+		},
+		{
+			Name:  "too many rules",
+			Rules: make(SidebarCategoryRules, SidebarCategoryRulesMax+1),
+		},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			if test.Valid {
+				assert.Nil(t, test.Rules.IsValid())
+			} else {
+				assert.NotNil(t, test.Rules.IsValid())
+			}
+		})
+	}
+}
+
+func TestSidebarCategoryRulesScanValue(t *testing.T) {
+	value, err := SidebarCategoryRules(nil).Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	rules := SidebarCategoryRules{{Type: SidebarCategoryRuleNamePrefix, Value: "inc-"}}
+	value, err = rules.Value()
+	require.NoError(t, err)
+
+	var scanned SidebarCategoryRules
+	require.NoError(t, scanned.Scan([]byte(value.(string))))
+	assert.Equal(t, rules, scanned)
+
+	scanned = nil
+	require.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+}
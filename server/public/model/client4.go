@@ -263,6 +263,10 @@ func (c *Client4) channelRoute(channelId string) string {
 	return fmt.Sprintf(c.channelsRoute()+"/%v", channelId)
 }
 
+func (c *Client4) channelEscalationsRoute(channelId string) string {
+	return c.channelRoute(channelId) + "/persistent_notification_escalations"
+}
+
 func (c *Client4) channelByNameRoute(channelName, teamId string) string {
 	return fmt.Sprintf(c.teamRoute(teamId)+"/channels/name/%v", channelName)
 }
@@ -536,6 +540,10 @@ func (c *Client4) userTermsOfServiceRoute(userId string) string {
 	return c.userRoute(userId) + "/terms_of_service"
 }
 
+func (c *Client4) userOutOfOfficeRoute(userId string) string {
+	return c.userRoute(userId) + "/out_of_office"
+}
+
 func (c *Client4) termsOfServiceRoute() string {
 	return "/terms_of_service"
 }
@@ -683,6 +691,45 @@ func (c *Client4) DeleteScheduledPost(ctx context.Context, scheduledPostId strin
 	return &deletedScheduledPost, BuildResponse(r), nil
 }
 
+func (c *Client4) GetScheduledPostSeries(ctx context.Context) ([]*ScheduledPost, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.postsRoute()+"/scheduled/series", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var scheduledPosts []*ScheduledPost
+	if err := json.NewDecoder(r.Body).Decode(&scheduledPosts); err != nil {
+		return nil, nil, NewAppError("GetScheduledPostSeries", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return scheduledPosts, BuildResponse(r), nil
+}
+
+func (c *Client4) PauseScheduledPost(ctx context.Context, scheduledPostId string) (*ScheduledPost, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.postsRoute()+"/schedule/"+scheduledPostId+"/pause", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var scheduledPost ScheduledPost
+	if err := json.NewDecoder(r.Body).Decode(&scheduledPost); err != nil {
+		return nil, nil, NewAppError("PauseScheduledPost", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &scheduledPost, BuildResponse(r), nil
+}
+
+func (c *Client4) ResumeScheduledPost(ctx context.Context, scheduledPostId string) (*ScheduledPost, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.postsRoute()+"/schedule/"+scheduledPostId+"/resume", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var scheduledPost ScheduledPost
+	if err := json.NewDecoder(r.Body).Decode(&scheduledPost); err != nil {
+		return nil, nil, NewAppError("ResumeScheduledPost", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &scheduledPost, BuildResponse(r), nil
+}
+
 func (c *Client4) bookmarksRoute(channelId string) string {
 	return c.channelRoute(channelId) + "/bookmarks"
 }
@@ -4026,6 +4073,31 @@ func (c *Client4) SetPostReminder(ctx context.Context, reminder *PostReminder) (
 	return BuildResponse(r), nil
 }
 
+// DeletePostReminder cancels the reminder of a user about a post.
+func (c *Client4) DeletePostReminder(ctx context.Context, userId, postId string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.userRoute(userId)+c.postRoute(postId)+"/reminder")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// GetPostReminders returns the pending post reminders of a user, the earliest first.
+func (c *Client4) GetPostReminders(ctx context.Context, userId string) ([]*PostReminder, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/posts/reminders", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var reminders []*PostReminder
+	if err := json.NewDecoder(r.Body).Decode(&reminders); err != nil {
+		return nil, nil, NewAppError("GetPostReminders", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return reminders, BuildResponse(r), nil
+}
+
 // PinPost pin a post based on provided post id string.
 func (c *Client4) PinPost(ctx context.Context, postId string) (*Response, error) {
 	r, err := c.DoAPIPost(ctx, c.postRoute(postId)+"/pin", "")
@@ -4590,6 +4662,12 @@ func (c *Client4) UploadFileAsRequestBody(ctx context.Context, data []byte, chan
 	return c.DoUploadFile(ctx, c.filesRoute()+fmt.Sprintf("?channel_id=%v&filename=%v", url.QueryEscape(channelId), url.QueryEscape(filename)), data, http.DetectContentType(data))
 }
 
+// UploadBookmarkFile will upload a file to a channel as the body of a request, to be later used as the file of a
+// channel bookmark. Files uploaded with Client4.UploadFile can't be bookmarked.
+func (c *Client4) UploadBookmarkFile(ctx context.Context, data []byte, channelId string, filename string) (*FileUploadResponse, *Response, error) {
+	return c.DoUploadFile(ctx, c.filesRoute()+fmt.Sprintf("?channel_id=%v&filename=%v&%v=true", url.QueryEscape(channelId), url.QueryEscape(filename), BookmarkFileOwner), data, http.DetectContentType(data))
+}
+
 // GetFile gets the bytes for a file by id.
 func (c *Client4) GetFile(ctx context.Context, fileId string) ([]byte, *Response, error) {
 	r, err := c.DoAPIGet(ctx, c.fileRoute(fileId), "")
@@ -5180,6 +5258,25 @@ func (c *Client4) CreateOutgoingWebhook(ctx context.Context, hook *OutgoingWebho
 	return &ow, BuildResponse(r), nil
 }
 
+// RenderOutgoingWebhookPayload renders the payload template of an outgoing webhook, which doesn't need to be saved,
+// as it would be sent for a sample post.
+func (c *Client4) RenderOutgoingWebhookPayload(ctx context.Context, hook *OutgoingWebhook) (*OutgoingWebhookRenderedPayload, *Response, error) {
+	buf, err := json.Marshal(hook)
+	if err != nil {
+		return nil, nil, NewAppError("RenderOutgoingWebhookPayload", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, c.outgoingWebhooksRoute()+"/render", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var rendered OutgoingWebhookRenderedPayload
+	if err := json.NewDecoder(r.Body).Decode(&rendered); err != nil {
+		return nil, nil, NewAppError("RenderOutgoingWebhookPayload", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &rendered, BuildResponse(r), nil
+}
+
 // UpdateOutgoingWebhook creates an outgoing webhook for a team or channel.
 func (c *Client4) UpdateOutgoingWebhook(ctx context.Context, hook *OutgoingWebhook) (*OutgoingWebhook, *Response, error) {
 	buf, err := json.Marshal(hook)
@@ -5280,6 +5377,35 @@ func (c *Client4) RegenOutgoingHookToken(ctx context.Context, hookId string) (*O
 	return &ow, BuildResponse(r), nil
 }
 
+// GetOutgoingWebhookDeliveries returns a page of the deliveries of an outgoing webhook, most recent first. Page counting starts at 0.
+func (c *Client4) GetOutgoingWebhookDeliveries(ctx context.Context, hookId string, page int, perPage int) ([]*OutgoingWebhookDelivery, *Response, error) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoAPIGet(ctx, c.outgoingWebhookRoute(hookId)+"/deliveries"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var deliveries []*OutgoingWebhookDelivery
+	if err := json.NewDecoder(r.Body).Decode(&deliveries); err != nil {
+		return nil, nil, NewAppError("GetOutgoingWebhookDeliveries", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return deliveries, BuildResponse(r), nil
+}
+
+// RedeliverOutgoingWebhook sends the payload of a past delivery of an outgoing webhook again and returns the new delivery.
+func (c *Client4) RedeliverOutgoingWebhook(ctx context.Context, hookId string, deliveryId string) (*OutgoingWebhookDelivery, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.outgoingWebhookRoute(hookId)+"/deliveries/"+deliveryId+"/redeliver", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var delivery OutgoingWebhookDelivery
+	if err := json.NewDecoder(r.Body).Decode(&delivery); err != nil {
+		return nil, nil, NewAppError("RedeliverOutgoingWebhook", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &delivery, BuildResponse(r), nil
+}
+
 // DeleteOutgoingWebhook delete the outgoing webhook on the system requested by Hook Id.
 func (c *Client4) DeleteOutgoingWebhook(ctx context.Context, hookId string) (*Response, error) {
 	r, err := c.DoAPIDelete(ctx, c.outgoingWebhookRoute(hookId))
@@ -6364,6 +6490,38 @@ func (c *Client4) GetDataRetentionPolicyByID(ctx context.Context, policyID strin
 	return &p, BuildResponse(r), nil
 }
 
+// GetDataRetentionDryRun will get what the data retention job would delete if it ran now,
+// under the granular policies and the global policy, without deleting anything.
+func (c *Client4) GetDataRetentionDryRun(ctx context.Context) (*RetentionPolicyDryRun, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.dataRetentionRoute()+"/dry_run", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var d RetentionPolicyDryRun
+	if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
+		return nil, nil, NewAppError("GetDataRetentionDryRun", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &d, BuildResponse(r), nil
+}
+
+// GetDataRetentionPolicyDryRun will get what the data retention job would delete if it ran now,
+// under the granular data retention policy with the specified ID, without deleting anything.
+func (c *Client4) GetDataRetentionPolicyDryRun(ctx context.Context, policyID string) (*RetentionPolicyDryRun, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.dataRetentionPolicyRoute(policyID)+"/dry_run", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var d RetentionPolicyDryRun
+	if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
+		return nil, nil, NewAppError("GetDataRetentionPolicyDryRun", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &d, BuildResponse(r), nil
+}
+
 // GetDataRetentionPoliciesCount will get the total number of granular data retention policies.
 func (c *Client4) GetDataRetentionPoliciesCount(ctx context.Context) (int64, *Response, error) {
 	type CountBody struct {
@@ -6638,7 +6796,7 @@ func (c *Client4) GetDrafts(ctx context.Context, userId, teamId string) ([]*Draf
 }
 
 func (c *Client4) DeleteDraft(ctx context.Context, userId, channelId, rootId string) (*Draft, *Response, error) {
-	r, err := c.DoAPIDelete(ctx, c.userRoute(userId)+c.channelRoute(channelId)+"/drafts")
+	r, err := c.DoAPIDelete(ctx, c.draftRoute(userId, channelId, rootId))
 	if err != nil {
 		return nil, BuildResponse(r), err
 	}
@@ -6652,6 +6810,50 @@ func (c *Client4) DeleteDraft(ctx context.Context, userId, channelId, rootId str
 	return df, BuildResponse(r), nil
 }
 
+func (c *Client4) draftRoute(userId, channelId, rootId string) string {
+	route := c.userRoute(userId) + c.channelRoute(channelId) + "/drafts"
+	if rootId != "" {
+		route += "/" + rootId
+	}
+	return route
+}
+
+func (c *Client4) draftRevisionsRoute(userId, channelId, rootId string) string {
+	return c.draftRoute(userId, channelId, rootId) + "/revisions"
+}
+
+// GetDraftHistory will get a draft along with its previous revisions
+func (c *Client4) GetDraftHistory(ctx context.Context, userId, channelId, rootId string) (*DraftHistory, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.draftRevisionsRoute(userId, channelId, rootId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var history DraftHistory
+	err = json.NewDecoder(r.Body).Decode(&history)
+	if err != nil {
+		return nil, nil, NewAppError("GetDraftHistory", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &history, BuildResponse(r), nil
+}
+
+// RestoreDraftRevision will save a previous revision of a draft as its latest version
+func (c *Client4) RestoreDraftRevision(ctx context.Context, userId, channelId, rootId string, version int64) (*Draft, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.draftRevisionsRoute(userId, channelId, rootId)+"/"+strconv.FormatInt(version, 10)+"/restore", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var df Draft
+	err = json.NewDecoder(r.Body).Decode(&df)
+	if err != nil {
+		return nil, nil, NewAppError("RestoreDraftRevision", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &df, BuildResponse(r), nil
+}
+
 // Commands Section
 
 // CreateCommand will create a new command if the user have the right permissions.
@@ -7350,6 +7552,34 @@ func (c *Client4) PatchRole(ctx context.Context, roleId string, patch *RolePatch
 	return &role, BuildResponse(r), nil
 }
 
+// CreateRole creates a custom role.
+func (c *Client4) CreateRole(ctx context.Context, role *Role) (*Role, *Response, error) {
+	buf, err := json.Marshal(role)
+	if err != nil {
+		return nil, nil, NewAppError("CreateRole", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, c.rolesRoute(), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var created Role
+	if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+		return nil, nil, NewAppError("CreateRole", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &created, BuildResponse(r), nil
+}
+
+// DeleteRole deletes a custom role.
+func (c *Client4) DeleteRole(ctx context.Context, roleId string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.rolesRoute()+fmt.Sprintf("/%v", roleId))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // Schemes Section
 
 // CreateScheme creates a new Scheme.
@@ -7803,6 +8033,48 @@ func (c *Client4) GetUserTermsOfService(ctx context.Context, userId, etag string
 	return &u, BuildResponse(r), nil
 }
 
+// GetOutOfOffice returns the out of office settings of a user.
+func (c *Client4) GetOutOfOffice(ctx context.Context, userId string) (*OutOfOffice, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userOutOfOfficeRoute(userId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var o OutOfOffice
+	if err := json.NewDecoder(r.Body).Decode(&o); err != nil {
+		return nil, nil, NewAppError("GetOutOfOffice", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &o, BuildResponse(r), nil
+}
+
+// SetOutOfOffice creates or replaces the out of office settings of a user.
+func (c *Client4) SetOutOfOffice(ctx context.Context, userId string, outOfOffice *OutOfOffice) (*OutOfOffice, *Response, error) {
+	buf, err := json.Marshal(outOfOffice)
+	if err != nil {
+		return nil, nil, NewAppError("SetOutOfOffice", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPutBytes(ctx, c.userOutOfOfficeRoute(userId), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var o OutOfOffice
+	if err := json.NewDecoder(r.Body).Decode(&o); err != nil {
+		return nil, nil, NewAppError("SetOutOfOffice", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &o, BuildResponse(r), nil
+}
+
+// DeleteOutOfOffice removes the out of office settings of a user.
+func (c *Client4) DeleteOutOfOffice(ctx context.Context, userId string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.userOutOfOfficeRoute(userId))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // CreateTermsOfService creates new terms of service.
 func (c *Client4) CreateTermsOfService(ctx context.Context, text, userId string) (*TermsOfService, *Response, error) {
 	url := c.termsOfServiceRoute()
@@ -8256,6 +8528,22 @@ func (c *Client4) UpdateSidebarCategoryOrderForTeamForUser(ctx context.Context,
 	return c.ArrayFromJSON(r.Body), BuildResponse(r), nil
 }
 
+// PreviewSidebarCategoryRulesForTeamForUser returns the IDs of the user's channels on the team that would be
+// matched by the given sidebar category rules.
+func (c *Client4) PreviewSidebarCategoryRulesForTeamForUser(ctx context.Context, userID, teamID string, rules SidebarCategoryRules) ([]string, *Response, error) {
+	payload, err := json.Marshal(rules)
+	if err != nil {
+		return nil, nil, NewAppError("PreviewSidebarCategoryRulesForTeamForUser", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	route := c.userCategoryRoute(userID, teamID) + "/rules/preview"
+	r, err := c.DoAPIPostBytes(ctx, route, payload)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return c.ArrayFromJSON(r.Body), BuildResponse(r), nil
+}
+
 func (c *Client4) GetSidebarCategoryForTeamForUser(ctx context.Context, userID, teamID, categoryID, etag string) (*SidebarCategoryWithChannels, *Response, error) {
 	route := c.userCategoryRoute(userID, teamID) + "/" + categoryID
 	r, err := c.DoAPIGet(ctx, route, etag)
@@ -8822,6 +9110,53 @@ func (c *Client4) GetAllSharedChannels(ctx context.Context, teamID string, page,
 	return channels, BuildResponse(r), nil
 }
 
+// ShareChannel shares a channel with remote clusters. The share name, display name, purpose and header
+// default to the ones of the channel when empty.
+func (c *Client4) ShareChannel(ctx context.Context, sc *SharedChannel) (*SharedChannel, *Response, error) {
+	buf, err := json.Marshal(sc)
+	if err != nil {
+		return nil, nil, NewAppError("ShareChannel", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPostBytes(ctx, fmt.Sprintf("%s/channels/%s", c.sharedChannelsRoute(), sc.ChannelId), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var shared SharedChannel
+	if err := json.NewDecoder(r.Body).Decode(&shared); err != nil {
+		return nil, nil, NewAppError("ShareChannel", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &shared, BuildResponse(r), nil
+}
+
+// UnshareChannel stops sharing a channel with all remote clusters.
+func (c *Client4) UnshareChannel(ctx context.Context, channelID string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, fmt.Sprintf("%s/channels/%s", c.sharedChannelsRoute(), channelID))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// GetSharedChannelSyncStatus returns the synchronization status of each remote cluster a channel is
+// shared with.
+func (c *Client4) GetSharedChannelSyncStatus(ctx context.Context, channelID string) ([]*SharedChannelRemoteSyncStatus, *Response, error) {
+	r, err := c.DoAPIGet(ctx, fmt.Sprintf("%s/channels/%s/status", c.sharedChannelsRoute(), channelID), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var status []*SharedChannelRemoteSyncStatus
+	if err := json.NewDecoder(r.Body).Decode(&status); err != nil {
+		return nil, nil, NewAppError("GetSharedChannelSyncStatus", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return status, BuildResponse(r), nil
+}
+
 func (c *Client4) GetRemoteClusterInfo(ctx context.Context, remoteID string) (RemoteClusterInfo, *Response, error) {
 	url := fmt.Sprintf("%s/remote_info/%s", c.sharedChannelsRoute(), remoteID)
 	r, err := c.DoAPIGet(ctx, url, "")
@@ -8961,6 +9296,21 @@ func (c *Client4) GetRemoteCluster(ctx context.Context, remoteClusterId string)
 	return rc, BuildResponse(r), nil
 }
 
+// PingRemoteCluster pings a remote cluster immediately and returns it with its updated status.
+func (c *Client4) PingRemoteCluster(ctx context.Context, remoteClusterId string) (*RemoteCluster, *Response, error) {
+	r, err := c.DoAPIPost(ctx, fmt.Sprintf("%s/%s/ping", c.remoteClusterRoute(), remoteClusterId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var rc RemoteCluster
+	if err := json.NewDecoder(r.Body).Decode(&rc); err != nil {
+		return nil, nil, NewAppError("PingRemoteCluster", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &rc, BuildResponse(r), nil
+}
+
 func (c *Client4) PatchRemoteCluster(ctx context.Context, remoteClusterId string, patch *RemoteClusterPatch) (*RemoteCluster, *Response, error) {
 	patchJSON, err := json.Marshal(patch)
 	if err != nil {
@@ -9149,6 +9499,69 @@ func (c *Client4) GetPostInfo(ctx context.Context, postId string) (*PostInfo, *R
 	return info, BuildResponse(r), nil
 }
 
+// GetPersistentNotificationEscalations returns the escalation chain of the
+// persistent notifications posted in a channel.
+func (c *Client4) GetPersistentNotificationEscalations(ctx context.Context, channelId string) ([]*PersistentNotificationEscalation, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.channelEscalationsRoute(channelId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var escalations []*PersistentNotificationEscalation
+	if err = json.NewDecoder(r.Body).Decode(&escalations); err != nil {
+		return nil, nil, NewAppError("GetPersistentNotificationEscalations", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return escalations, BuildResponse(r), nil
+}
+
+// CreatePersistentNotificationEscalation adds a step to the escalation chain
+// of a channel.
+func (c *Client4) CreatePersistentNotificationEscalation(ctx context.Context, escalation *PersistentNotificationEscalation) (*PersistentNotificationEscalation, *Response, error) {
+	buf, err := json.Marshal(escalation)
+	if err != nil {
+		return nil, nil, NewAppError("CreatePersistentNotificationEscalation", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, c.channelEscalationsRoute(escalation.ChannelId), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var saved *PersistentNotificationEscalation
+	if err = json.NewDecoder(r.Body).Decode(&saved); err != nil {
+		return nil, nil, NewAppError("CreatePersistentNotificationEscalation", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return saved, BuildResponse(r), nil
+}
+
+// DeletePersistentNotificationEscalation removes a step from the escalation
+// chain of a channel.
+func (c *Client4) DeletePersistentNotificationEscalation(ctx context.Context, channelId, escalationId string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.channelEscalationsRoute(channelId)+"/"+escalationId)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// GetPostPersistentNotificationEscalations returns the escalation chain of a
+// persistent notification post, telling which steps were already reached.
+func (c *Client4) GetPostPersistentNotificationEscalations(ctx context.Context, postId string) (*PostPersistentNotificationEscalations, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.postRoute(postId)+"/persistent_notification_escalations", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var escalations *PostPersistentNotificationEscalations
+	if err = json.NewDecoder(r.Body).Decode(&escalations); err != nil {
+		return nil, nil, NewAppError("GetPostPersistentNotificationEscalations", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return escalations, BuildResponse(r), nil
+}
+
 func (c *Client4) AcknowledgePost(ctx context.Context, postId, userId string) (*PostAcknowledgement, *Response, error) {
 	r, err := c.DoAPIPost(ctx, c.userRoute(userId)+c.postRoute(postId)+"/ack", "")
 	if err != nil {
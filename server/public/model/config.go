@@ -101,6 +101,12 @@ const (
 	CacheTypeLRU   = "lru"
 	CacheTypeRedis = "redis"
 
+	ClusterTransportGossip = "gossip"
+	ClusterTransportRedis  = "redis"
+
+	RateLimitStoreTypeMemory = "memory"
+	RateLimitStoreTypeRedis  = "redis"
+
 	SitenameMaxLength = 30
 
 	ServiceSettingsDefaultSiteURL                = "http://localhost:8065"
@@ -129,6 +135,12 @@ const (
 	FileSettingsDefaultDirectory                   = "./data/"
 	FileSettingsDefaultS3UploadPartSizeBytes       = 5 * 1024 * 1024   // 5MB
 	FileSettingsDefaultS3ExportUploadPartSizeBytes = 100 * 1024 * 1024 // 100MB
+	FileSettingsDefaultOCRMaxImageSize             = 20 * 1024 * 1024  // 20MB
+	FileSettingsDefaultOCRMaxPDFSize               = 50 * 1024 * 1024  // 50MB
+	FileSettingsDefaultOCRTimeoutMilliseconds      = 60000
+	FileSettingsDefaultLocalCacheDirectory         = "./data-cache/"
+	FileSettingsDefaultLocalCacheMaxSizeBytes      = 1024 * 1024 * 1024 // 1GB
+	FileSettingsDefaultLocalCacheMaxFileSizeBytes  = 100 * 1024 * 1024  // 100MB
 
 	ImportSettingsDefaultDirectory     = "./import"
 	ImportSettingsDefaultRetentionDays = 30
@@ -215,6 +227,9 @@ const (
 	BleveSettingsDefaultIndexDir  = ""
 	BleveSettingsDefaultBatchSize = 10000
 
+	PostgresSearchSettingsDefaultTextSearchConfig = "english"
+	PostgresSearchSettingsDefaultBatchSize        = 10000
+
 	DataRetentionSettingsDefaultMessageRetentionDays           = 365
 	DataRetentionSettingsDefaultMessageRetentionHours          = 0
 	DataRetentionSettingsDefaultFileRetentionDays              = 365
@@ -226,6 +241,7 @@ const (
 	DataRetentionSettingsDefaultRetentionIdsBatchSize          = 100
 
 	OutgoingIntegrationRequestsDefaultTimeout = 30
+	OutgoingWebhookDefaultMaxRetries          = 3
 
 	PluginSettingsDefaultDirectory         = "./plugins"
 	PluginSettingsDefaultClientDirectory   = "./client/plugins"
@@ -237,6 +253,8 @@ const (
 	ComplianceExportTypeActiance       = "actiance"
 	ComplianceExportTypeGlobalrelay    = "globalrelay"
 	ComplianceExportTypeGlobalrelayZip = "globalrelay-zip"
+	ComplianceExportTypeEml            = "eml"
+	ComplianceExportTypeMbox           = "mbox"
 	GlobalrelayCustomerTypeA9          = "A9"
 	GlobalrelayCustomerTypeA10         = "A10"
 	GlobalrelayCustomerTypeCustom      = "CUSTOM"
@@ -326,6 +344,7 @@ type ServiceSettings struct {
 	EnableOutgoingOAuthConnections      *bool    `access:"integrations_integration_management"`
 	EnableCommands                      *bool    `access:"integrations_integration_management"`
 	OutgoingIntegrationRequestsTimeout  *int64   `access:"integrations_integration_management"` // In seconds.
+	OutgoingWebhookMaxRetries           *int     `access:"integrations_integration_management"`
 	EnablePostUsernameOverride          *bool    `access:"integrations_integration_management"`
 	EnablePostIconOverride              *bool    `access:"integrations_integration_management"`
 	GoogleDeveloperKey                  *string  `access:"site_posts,write_restrictable,cloud_restrictable"`
@@ -538,6 +557,10 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.OutgoingIntegrationRequestsTimeout = NewPointer(int64(OutgoingIntegrationRequestsDefaultTimeout))
 	}
 
+	if s.OutgoingWebhookMaxRetries == nil {
+		s.OutgoingWebhookMaxRetries = NewPointer(OutgoingWebhookDefaultMaxRetries)
+	}
+
 	if s.ConnectionSecurity == nil {
 		s.ConnectionSecurity = NewPointer("")
 	}
@@ -1007,6 +1030,9 @@ type ClusterSettings struct {
 	EnableExperimentalGossipEncryption *bool   `access:"environment_high_availability,write_restrictable,cloud_restrictable"`
 	ReadOnlyConfig                     *bool   `access:"environment_high_availability,write_restrictable,cloud_restrictable"`
 	GossipPort                         *int    `access:"environment_high_availability,write_restrictable,cloud_restrictable"` // telemetry: none
+	// Transport selects how the nodes communicate. The redis transport uses
+	// the Redis server configured in CacheSettings instead of the gossip mesh.
+	Transport *string `access:"environment_high_availability,write_restrictable,cloud_restrictable"`
 }
 
 func (s *ClusterSettings) SetDefaults() {
@@ -1053,6 +1079,22 @@ func (s *ClusterSettings) SetDefaults() {
 	if s.GossipPort == nil {
 		s.GossipPort = NewPointer(8074)
 	}
+
+	if s.Transport == nil {
+		s.Transport = NewPointer(ClusterTransportGossip)
+	}
+}
+
+func (s *ClusterSettings) isValid(cacheSettings *CacheSettings) *AppError {
+	if *s.Transport != ClusterTransportGossip && *s.Transport != ClusterTransportRedis {
+		return NewAppError("Config.IsValid", "model.config.is_valid.cluster_transport.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.Enable && *s.Transport == ClusterTransportRedis && *cacheSettings.RedisAddress == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.cluster_redis_address.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
 }
 
 type MetricsSettings struct {
@@ -1635,6 +1677,16 @@ type FileSettings struct {
 	EnablePublicLink                   *bool   `access:"site_public_links,cloud_restrictable"`
 	ExtractContent                     *bool   `access:"environment_file_storage,write_restrictable"`
 	ArchiveRecursion                   *bool   `access:"environment_file_storage,write_restrictable"`
+	OCRServiceURL                      *string `access:"environment_file_storage,write_restrictable"` // telemetry: none
+	OCRServiceSecret                   *string `access:"environment_file_storage,write_restrictable"` // telemetry: none
+	OCRMaxImageSize                    *int64  `access:"environment_file_storage,write_restrictable"`
+	OCRMaxPDFSize                      *int64  `access:"environment_file_storage,write_restrictable"`
+	OCRTimeoutMilliseconds             *int64  `access:"environment_file_storage,write_restrictable"`
+	EnableLocalCache                   *bool   `access:"environment_file_storage,write_restrictable,cloud_restrictable"`
+	LocalCacheDirectory                *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	LocalCacheMaxSizeBytes             *int64  `access:"environment_file_storage,write_restrictable,cloud_restrictable"`
+	LocalCacheMaxFileSizeBytes         *int64  `access:"environment_file_storage,write_restrictable,cloud_restrictable"`
+	EnableImageVariants                *bool   `access:"environment_file_storage,write_restrictable,cloud_restrictable"`
 	PublicLinkSalt                     *string `access:"site_public_links,cloud_restrictable"`                           // telemetry: none
 	InitialFont                        *string `access:"environment_file_storage,cloud_restrictable"`                    // telemetry: none
 	AmazonS3AccessKeyId                *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
@@ -1713,6 +1765,46 @@ func (s *FileSettings) SetDefaults(isUpdate bool) {
 		s.ArchiveRecursion = NewPointer(false)
 	}
 
+	if s.OCRServiceURL == nil {
+		s.OCRServiceURL = NewPointer("")
+	}
+
+	if s.OCRServiceSecret == nil {
+		s.OCRServiceSecret = NewPointer("")
+	}
+
+	if s.OCRMaxImageSize == nil {
+		s.OCRMaxImageSize = NewPointer(int64(FileSettingsDefaultOCRMaxImageSize))
+	}
+
+	if s.OCRMaxPDFSize == nil {
+		s.OCRMaxPDFSize = NewPointer(int64(FileSettingsDefaultOCRMaxPDFSize))
+	}
+
+	if s.OCRTimeoutMilliseconds == nil {
+		s.OCRTimeoutMilliseconds = NewPointer(int64(FileSettingsDefaultOCRTimeoutMilliseconds))
+	}
+
+	if s.EnableLocalCache == nil {
+		s.EnableLocalCache = NewPointer(false)
+	}
+
+	if s.LocalCacheDirectory == nil || *s.LocalCacheDirectory == "" {
+		s.LocalCacheDirectory = NewPointer(FileSettingsDefaultLocalCacheDirectory)
+	}
+
+	if s.LocalCacheMaxSizeBytes == nil {
+		s.LocalCacheMaxSizeBytes = NewPointer(int64(FileSettingsDefaultLocalCacheMaxSizeBytes))
+	}
+
+	if s.LocalCacheMaxFileSizeBytes == nil {
+		s.LocalCacheMaxFileSizeBytes = NewPointer(int64(FileSettingsDefaultLocalCacheMaxFileSizeBytes))
+	}
+
+	if s.EnableImageVariants == nil {
+		s.EnableImageVariants = NewPointer(false)
+	}
+
 	if isUpdate {
 		// When updating an existing configuration, ensure link salt has been specified.
 		if s.PublicLinkSalt == nil || *s.PublicLinkSalt == "" {
@@ -2028,6 +2120,20 @@ type RateLimitSettings struct {
 	VaryByRemoteAddr *bool  `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
 	VaryByUser       *bool  `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
 	VaryByHeader     string `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	// StoreType selects where the counters are kept. The redis store uses the
+	// Redis server configured in CacheSettings so that limits hold cluster-wide.
+	StoreType *string `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	// The following profiles apply to specific route groups and to requests
+	// authenticated with a personal access token. A rate of 0 disables the
+	// profile and the default limits apply instead.
+	PostsPerSec         *int `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	PostsMaxBurst       *int `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	SearchPerSec        *int `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	SearchMaxBurst      *int `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	FileUploadPerSec    *int `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	FileUploadMaxBurst  *int `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	AccessTokenPerSec   *int `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	AccessTokenMaxBurst *int `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
 }
 
 func (s *RateLimitSettings) SetDefaults() {
@@ -2054,6 +2160,42 @@ func (s *RateLimitSettings) SetDefaults() {
 	if s.VaryByUser == nil {
 		s.VaryByUser = NewPointer(false)
 	}
+
+	if s.StoreType == nil {
+		s.StoreType = NewPointer(RateLimitStoreTypeMemory)
+	}
+
+	if s.PostsPerSec == nil {
+		s.PostsPerSec = NewPointer(0)
+	}
+
+	if s.PostsMaxBurst == nil {
+		s.PostsMaxBurst = NewPointer(0)
+	}
+
+	if s.SearchPerSec == nil {
+		s.SearchPerSec = NewPointer(0)
+	}
+
+	if s.SearchMaxBurst == nil {
+		s.SearchMaxBurst = NewPointer(0)
+	}
+
+	if s.FileUploadPerSec == nil {
+		s.FileUploadPerSec = NewPointer(0)
+	}
+
+	if s.FileUploadMaxBurst == nil {
+		s.FileUploadMaxBurst = NewPointer(0)
+	}
+
+	if s.AccessTokenPerSec == nil {
+		s.AccessTokenPerSec = NewPointer(0)
+	}
+
+	if s.AccessTokenMaxBurst == nil {
+		s.AccessTokenMaxBurst = NewPointer(0)
+	}
 }
 
 type PrivacySettings struct {
@@ -2976,6 +3118,36 @@ func (bs *BleveSettings) SetDefaults() {
 	}
 }
 
+type PostgresSearchSettings struct {
+	EnableIndexing     *bool   `access:"experimental_features"`
+	EnableSearching    *bool   `access:"experimental_features"`
+	EnableAutocomplete *bool   `access:"experimental_features"`
+	TextSearchConfig   *string `access:"experimental_features"`
+	BatchSize          *int    `access:"experimental_features"`
+}
+
+func (s *PostgresSearchSettings) SetDefaults() {
+	if s.EnableIndexing == nil {
+		s.EnableIndexing = NewPointer(false)
+	}
+
+	if s.EnableSearching == nil {
+		s.EnableSearching = NewPointer(false)
+	}
+
+	if s.EnableAutocomplete == nil {
+		s.EnableAutocomplete = NewPointer(false)
+	}
+
+	if s.TextSearchConfig == nil {
+		s.TextSearchConfig = NewPointer(PostgresSearchSettingsDefaultTextSearchConfig)
+	}
+
+	if s.BatchSize == nil {
+		s.BatchSize = NewPointer(PostgresSearchSettingsDefaultBatchSize)
+	}
+}
+
 type DataRetentionSettings struct {
 	EnableMessageDeletion          *bool   `access:"compliance_data_retention_policy"`
 	EnableFileDeletion             *bool   `access:"compliance_data_retention_policy"`
@@ -3625,6 +3797,7 @@ type Config struct {
 	AnalyticsSettings           AnalyticsSettings
 	ElasticsearchSettings       ElasticsearchSettings
 	BleveSettings               BleveSettings
+	PostgresSearchSettings      PostgresSearchSettings
 	DataRetentionSettings       DataRetentionSettings
 	MessageExportSettings       MessageExportSettings
 	JobSettings                 JobSettings
@@ -3735,6 +3908,7 @@ func (o *Config) SetDefaults() {
 	o.LocalizationSettings.SetDefaults()
 	o.ElasticsearchSettings.SetDefaults()
 	o.BleveSettings.SetDefaults()
+	o.PostgresSearchSettings.SetDefaults()
 	o.NativeAppSettings.SetDefaults()
 	o.DataRetentionSettings.SetDefaults()
 	o.RateLimitSettings.SetDefaults()
@@ -3770,6 +3944,10 @@ func (o *Config) IsValid() *AppError {
 		return appErr
 	}
 
+	if appErr := o.ClusterSettings.isValid(&o.CacheSettings); appErr != nil {
+		return appErr
+	}
+
 	if *o.ServiceSettings.SiteURL == "" && *o.ServiceSettings.AllowCookiesForSubdomains {
 		return NewAppError("Config.IsValid", "model.config.is_valid.allow_cookies_for_subdomains.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -3806,7 +3984,7 @@ func (o *Config) IsValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.password_length.app_error", map[string]any{"MinLength": PasswordMinimumLength, "MaxLength": PasswordMaximumLength}, "", http.StatusBadRequest)
 	}
 
-	if appErr := o.RateLimitSettings.isValid(); appErr != nil {
+	if appErr := o.RateLimitSettings.isValid(&o.CacheSettings); appErr != nil {
 		return appErr
 	}
 
@@ -3822,6 +4000,10 @@ func (o *Config) IsValid() *AppError {
 		return appErr
 	}
 
+	if appErr := o.PostgresSearchSettings.isValid(*o.SqlSettings.DriverName); appErr != nil {
+		return appErr
+	}
+
 	if appErr := o.DataRetentionSettings.isValid(); appErr != nil {
 		return appErr
 	}
@@ -3958,6 +4140,26 @@ func (s *FileSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.amazons3_timeout.app_error", map[string]any{"Value": *s.MaxImageDecoderConcurrency}, "", http.StatusBadRequest)
 	}
 
+	if *s.OCRServiceURL != "" && !IsValidHTTPURL(*s.OCRServiceURL) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.ocr_service_url.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.OCRMaxImageSize < 0 || *s.OCRMaxPDFSize < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.ocr_max_size.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.OCRTimeoutMilliseconds < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.ocr_timeout.app_error", map[string]any{"Value": *s.OCRTimeoutMilliseconds}, "", http.StatusBadRequest)
+	}
+
+	if *s.EnableLocalCache && *s.LocalCacheMaxSizeBytes <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.file_local_cache_size.app_error", map[string]any{"Value": *s.LocalCacheMaxSizeBytes}, "", http.StatusBadRequest)
+	}
+
+	if *s.EnableLocalCache && *s.LocalCacheMaxFileSizeBytes <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.file_local_cache_file_size.app_error", map[string]any{"Value": *s.LocalCacheMaxFileSizeBytes}, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -3981,7 +4183,7 @@ func (s *EmailSettings) isValid() *AppError {
 	return nil
 }
 
-func (s *RateLimitSettings) isValid() *AppError {
+func (s *RateLimitSettings) isValid(cacheSettings *CacheSettings) *AppError {
 	if *s.MemoryStoreSize <= 0 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.rate_mem.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -3994,6 +4196,30 @@ func (s *RateLimitSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.max_burst.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.StoreType != RateLimitStoreTypeMemory && *s.StoreType != RateLimitStoreTypeRedis {
+		return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_store_type.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.StoreType == RateLimitStoreTypeRedis && *cacheSettings.RedisAddress == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_redis_address.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	profiles := []struct {
+		name     string
+		perSec   int
+		maxBurst int
+	}{
+		{"Posts", *s.PostsPerSec, *s.PostsMaxBurst},
+		{"Search", *s.SearchPerSec, *s.SearchMaxBurst},
+		{"FileUpload", *s.FileUploadPerSec, *s.FileUploadMaxBurst},
+		{"AccessToken", *s.AccessTokenPerSec, *s.AccessTokenMaxBurst},
+	}
+	for _, profile := range profiles {
+		if profile.perSec < 0 || profile.maxBurst < 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_profile.app_error", map[string]any{"Profile": profile.name}, "", http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -4215,6 +4441,10 @@ func (s *ServiceSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.outgoing_integrations_request_timeout.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.OutgoingWebhookMaxRetries < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.outgoing_webhook_max_retries.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if *s.ExperimentalGroupUnreadChannels != GroupUnreadChannelsDisabled &&
 		*s.ExperimentalGroupUnreadChannels != GroupUnreadChannelsDefaultOn &&
 		*s.ExperimentalGroupUnreadChannels != GroupUnreadChannelsDefaultOff {
@@ -4328,6 +4558,30 @@ func (bs *BleveSettings) isValid() *AppError {
 	return nil
 }
 
+func (s *PostgresSearchSettings) isValid(driverName string) *AppError {
+	if *s.EnableIndexing {
+		if driverName != DatabaseDriverPostgres {
+			return NewAppError("Config.IsValid", "model.config.is_valid.postgres_search.driver.app_error", nil, "", http.StatusBadRequest)
+		}
+	} else {
+		if *s.EnableSearching {
+			return NewAppError("Config.IsValid", "model.config.is_valid.postgres_search.enable_searching.app_error", nil, "", http.StatusBadRequest)
+		}
+		if *s.EnableAutocomplete {
+			return NewAppError("Config.IsValid", "model.config.is_valid.postgres_search.enable_autocomplete.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+	if *s.TextSearchConfig == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.postgres_search.text_search_config.app_error", nil, "", http.StatusBadRequest)
+	}
+	minBatchSize := 1
+	if *s.BatchSize < minBatchSize {
+		return NewAppError("Config.IsValid", "model.config.is_valid.postgres_search.batch_size.app_error", map[string]any{"BatchSize": minBatchSize}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 func (s *DataRetentionSettings) isValid() *AppError {
 	if s.MessageRetentionDays == nil || *s.MessageRetentionDays < 0 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.message_retention_days_too_low.app_error", nil, "", http.StatusBadRequest)
@@ -4391,7 +4645,7 @@ func (s *MessageExportSettings) isValid() *AppError {
 			return NewAppError("Config.IsValid", "model.config.is_valid.message_export.daily_runtime.app_error", nil, "", http.StatusBadRequest).Wrap(err)
 		} else if s.BatchSize == nil || *s.BatchSize < 0 {
 			return NewAppError("Config.IsValid", "model.config.is_valid.message_export.batch_size.app_error", nil, "", http.StatusBadRequest)
-		} else if s.ExportFormat == nil || (*s.ExportFormat != ComplianceExportTypeActiance && *s.ExportFormat != ComplianceExportTypeGlobalrelay && *s.ExportFormat != ComplianceExportTypeCsv) {
+		} else if s.ExportFormat == nil || (*s.ExportFormat != ComplianceExportTypeActiance && *s.ExportFormat != ComplianceExportTypeGlobalrelay && *s.ExportFormat != ComplianceExportTypeCsv && *s.ExportFormat != ComplianceExportTypeEml && *s.ExportFormat != ComplianceExportTypeMbox) {
 			return NewAppError("Config.IsValid", "model.config.is_valid.message_export.export_type.app_error", nil, "", http.StatusBadRequest)
 		}
 
@@ -4478,6 +4732,10 @@ func (o *Config) Sanitize(pluginManifests []*Manifest) {
 		*o.FileSettings.AmazonS3SecretAccessKey = FakeSetting
 	}
 
+	if o.FileSettings.OCRServiceSecret != nil && *o.FileSettings.OCRServiceSecret != "" {
+		*o.FileSettings.OCRServiceSecret = FakeSetting
+	}
+
 	if o.EmailSettings.SMTPPassword != nil && *o.EmailSettings.SMTPPassword != "" {
 		*o.EmailSettings.SMTPPassword = FakeSetting
 	}
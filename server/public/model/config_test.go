@@ -500,6 +500,140 @@ func TestMessageExportSettingsIsValidActiance(t *testing.T) {
 	require.Nil(t, mes.isValid())
 }
 
+func TestMessageExportSettingsIsValidEmlAndMbox(t *testing.T) {
+	for _, format := range []string{ComplianceExportTypeEml, ComplianceExportTypeMbox} {
+		mes := &MessageExportSettings{
+			EnableExport:        NewPointer(true),
+			ExportFormat:        NewPointer(format),
+			ExportFromTimestamp: NewPointer(int64(0)),
+			DailyRunTime:        NewPointer("15:04"),
+			BatchSize:           NewPointer(100),
+		}
+
+		// should pass because everything is valid
+		require.Nil(t, mes.isValid(), format)
+	}
+}
+
+func TestRateLimitSettingsIsValid(t *testing.T) {
+	newSettings := func() *RateLimitSettings {
+		s := &RateLimitSettings{}
+		s.SetDefaults()
+		return s
+	}
+	cacheSettings := &CacheSettings{}
+	cacheSettings.SetDefaults()
+
+	t.Run("defaults are valid", func(t *testing.T) {
+		require.Nil(t, newSettings().isValid(cacheSettings))
+	})
+
+	t.Run("invalid store type", func(t *testing.T) {
+		s := newSettings()
+		s.StoreType = NewPointer("disk")
+		appErr := s.isValid(cacheSettings)
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.rate_limit_store_type.app_error", appErr.Id)
+	})
+
+	t.Run("redis store requires a redis address", func(t *testing.T) {
+		s := newSettings()
+		s.StoreType = NewPointer(RateLimitStoreTypeRedis)
+		appErr := s.isValid(&CacheSettings{RedisAddress: NewPointer("")})
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.rate_limit_redis_address.app_error", appErr.Id)
+
+		require.Nil(t, s.isValid(&CacheSettings{RedisAddress: NewPointer("localhost:6379")}))
+	})
+
+	t.Run("profiles can't be negative", func(t *testing.T) {
+		s := newSettings()
+		s.SearchMaxBurst = NewPointer(-1)
+		appErr := s.isValid(cacheSettings)
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.rate_limit_profile.app_error", appErr.Id)
+		require.Equal(t, "Search", appErr.params["Profile"])
+	})
+}
+
+func TestClusterSettingsIsValid(t *testing.T) {
+	newSettings := func() *ClusterSettings {
+		s := &ClusterSettings{}
+		s.SetDefaults()
+		return s
+	}
+	cacheSettings := &CacheSettings{}
+	cacheSettings.SetDefaults()
+
+	t.Run("defaults are valid", func(t *testing.T) {
+		require.Nil(t, newSettings().isValid(cacheSettings))
+	})
+
+	t.Run("invalid transport", func(t *testing.T) {
+		s := newSettings()
+		s.Transport = NewPointer("carrier-pigeon")
+		appErr := s.isValid(cacheSettings)
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.cluster_transport.app_error", appErr.Id)
+	})
+
+	t.Run("redis transport requires a redis address when clustering is enabled", func(t *testing.T) {
+		s := newSettings()
+		s.Transport = NewPointer(ClusterTransportRedis)
+		require.Nil(t, s.isValid(&CacheSettings{RedisAddress: NewPointer("")}), "disabled clustering should not require a redis address")
+
+		s.Enable = NewPointer(true)
+		appErr := s.isValid(&CacheSettings{RedisAddress: NewPointer("")})
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.cluster_redis_address.app_error", appErr.Id)
+
+		require.Nil(t, s.isValid(&CacheSettings{RedisAddress: NewPointer("localhost:6379")}))
+	})
+}
+
+func TestPostgresSearchSettingsIsValid(t *testing.T) {
+	newSettings := func() *PostgresSearchSettings {
+		s := &PostgresSearchSettings{}
+		s.SetDefaults()
+		return s
+	}
+
+	t.Run("defaults are valid", func(t *testing.T) {
+		require.Nil(t, newSettings().isValid(DatabaseDriverMysql))
+	})
+
+	t.Run("indexing requires postgres", func(t *testing.T) {
+		s := newSettings()
+		s.EnableIndexing = NewPointer(true)
+		require.NotNil(t, s.isValid(DatabaseDriverMysql))
+		require.Nil(t, s.isValid(DatabaseDriverPostgres))
+	})
+
+	t.Run("searching requires indexing", func(t *testing.T) {
+		s := newSettings()
+		s.EnableSearching = NewPointer(true)
+		require.NotNil(t, s.isValid(DatabaseDriverPostgres))
+	})
+
+	t.Run("autocomplete requires indexing", func(t *testing.T) {
+		s := newSettings()
+		s.EnableAutocomplete = NewPointer(true)
+		require.NotNil(t, s.isValid(DatabaseDriverPostgres))
+	})
+
+	t.Run("text search config must be set", func(t *testing.T) {
+		s := newSettings()
+		s.TextSearchConfig = NewPointer("")
+		require.NotNil(t, s.isValid(DatabaseDriverPostgres))
+	})
+
+	t.Run("batch size must be positive", func(t *testing.T) {
+		s := newSettings()
+		s.BatchSize = NewPointer(0)
+		require.NotNil(t, s.isValid(DatabaseDriverPostgres))
+	})
+}
+
 func TestMessageExportSettingsIsValidGlobalRelaySettingsMissing(t *testing.T) {
 	mes := &MessageExportSettings{
 		EnableExport:        NewPointer(true),
@@ -96,3 +96,94 @@ func (r *RetentionIdsForDeletion) PreSave() {
 		r.Id = NewId()
 	}
 }
+
+// RetentionPolicyDryRunCounts holds the number of records which would be
+// deleted by the data retention job, along with the creation times of the
+// oldest and newest affected posts.
+type RetentionPolicyDryRunCounts struct {
+	PostCount     int64 `json:"post_count"`
+	FileCount     int64 `json:"file_count"`
+	ReactionCount int64 `json:"reaction_count"`
+	OldestPostAt  int64 `json:"oldest_post_at"`
+	NewestPostAt  int64 `json:"newest_post_at"`
+}
+
+func (c *RetentionPolicyDryRunCounts) Add(other RetentionPolicyDryRunCounts) {
+	c.PostCount += other.PostCount
+	c.FileCount += other.FileCount
+	c.ReactionCount += other.ReactionCount
+	if other.OldestPostAt > 0 && (c.OldestPostAt == 0 || other.OldestPostAt < c.OldestPostAt) {
+		c.OldestPostAt = other.OldestPostAt
+	}
+	if other.NewestPostAt > c.NewestPostAt {
+		c.NewestPostAt = other.NewestPostAt
+	}
+}
+
+// RetentionPolicyDryRunChannel holds the counts for a channel. An empty
+// PolicyID denotes the global policy, and an empty TeamID a direct or group
+// message channel.
+type RetentionPolicyDryRunChannel struct {
+	PolicyID  string `json:"policy_id"`
+	TeamID    string `json:"team_id"`
+	ChannelID string `json:"channel_id"`
+	RetentionPolicyDryRunCounts
+}
+
+type RetentionPolicyDryRunTeam struct {
+	PolicyID string `json:"policy_id"`
+	TeamID   string `json:"team_id"`
+	RetentionPolicyDryRunCounts
+}
+
+type RetentionPolicyDryRunPolicy struct {
+	PolicyID string `json:"policy_id"`
+	RetentionPolicyDryRunCounts
+}
+
+// RetentionPolicyDryRun describes what the next run of the data retention job
+// would delete, per policy, team and channel.
+type RetentionPolicyDryRun struct {
+	Policies []*RetentionPolicyDryRunPolicy  `json:"policies"`
+	Teams    []*RetentionPolicyDryRunTeam    `json:"teams"`
+	Channels []*RetentionPolicyDryRunChannel `json:"channels"`
+	Total    RetentionPolicyDryRunCounts     `json:"total"`
+}
+
+// NewRetentionPolicyDryRun aggregates the per channel counts by team and by
+// policy, preserving the order in which the channels are given.
+func NewRetentionPolicyDryRun(channels []*RetentionPolicyDryRunChannel) *RetentionPolicyDryRun {
+	dryRun := &RetentionPolicyDryRun{
+		Policies: []*RetentionPolicyDryRunPolicy{},
+		Teams:    []*RetentionPolicyDryRunTeam{},
+		Channels: channels,
+	}
+	if dryRun.Channels == nil {
+		dryRun.Channels = []*RetentionPolicyDryRunChannel{}
+	}
+
+	policies := map[string]*RetentionPolicyDryRunPolicy{}
+	teams := map[[2]string]*RetentionPolicyDryRunTeam{}
+	for _, channel := range dryRun.Channels {
+		policy, ok := policies[channel.PolicyID]
+		if !ok {
+			policy = &RetentionPolicyDryRunPolicy{PolicyID: channel.PolicyID}
+			policies[channel.PolicyID] = policy
+			dryRun.Policies = append(dryRun.Policies, policy)
+		}
+		policy.Add(channel.RetentionPolicyDryRunCounts)
+
+		teamKey := [2]string{channel.PolicyID, channel.TeamID}
+		team, ok := teams[teamKey]
+		if !ok {
+			team = &RetentionPolicyDryRunTeam{PolicyID: channel.PolicyID, TeamID: channel.TeamID}
+			teams[teamKey] = team
+			dryRun.Teams = append(dryRun.Teams, team)
+		}
+		team.Add(channel.RetentionPolicyDryRunCounts)
+
+		dryRun.Total.Add(channel.RetentionPolicyDryRunCounts)
+	}
+
+	return dryRun
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRetentionPolicyDryRun(t *testing.T) {
+	t.Run("no channels", func(t *testing.T) {
+		dryRun := NewRetentionPolicyDryRun(nil)
+		assert.Empty(t, dryRun.Policies)
+		assert.Empty(t, dryRun.Teams)
+		assert.NotNil(t, dryRun.Channels)
+		assert.Equal(t, RetentionPolicyDryRunCounts{}, dryRun.Total)
+	})
+
+	t.Run("aggregates by team and policy", func(t *testing.T) {
+		policyID, teamID := NewId(), NewId()
+		dryRun := NewRetentionPolicyDryRun([]*RetentionPolicyDryRunChannel{
+			{
+				PolicyID:                    policyID,
+				TeamID:                      teamID,
+				ChannelID:                   NewId(),
+				RetentionPolicyDryRunCounts: RetentionPolicyDryRunCounts{PostCount: 3, FileCount: 1, OldestPostAt: 20, NewestPostAt: 30},
+			},
+			{
+				PolicyID:                    policyID,
+				TeamID:                      teamID,
+				ChannelID:                   NewId(),
+				RetentionPolicyDryRunCounts: RetentionPolicyDryRunCounts{PostCount: 2, ReactionCount: 4, OldestPostAt: 10, NewestPostAt: 25},
+			},
+			{
+				ChannelID:                   NewId(),
+				RetentionPolicyDryRunCounts: RetentionPolicyDryRunCounts{PostCount: 1, OldestPostAt: 5, NewestPostAt: 5},
+			},
+		})
+
+		require.Len(t, dryRun.Policies, 2)
+		assert.Equal(t, policyID, dryRun.Policies[0].PolicyID)
+		assert.Equal(t, RetentionPolicyDryRunCounts{PostCount: 5, FileCount: 1, ReactionCount: 4, OldestPostAt: 10, NewestPostAt: 30}, dryRun.Policies[0].RetentionPolicyDryRunCounts)
+		assert.Equal(t, "", dryRun.Policies[1].PolicyID)
+
+		require.Len(t, dryRun.Teams, 2)
+		assert.Equal(t, teamID, dryRun.Teams[0].TeamID)
+		assert.Equal(t, int64(5), dryRun.Teams[0].PostCount)
+		assert.Equal(t, "", dryRun.Teams[1].TeamID)
+
+		assert.Len(t, dryRun.Channels, 3)
+		assert.Equal(t, RetentionPolicyDryRunCounts{PostCount: 6, FileCount: 1, ReactionCount: 4, OldestPostAt: 5, NewestPostAt: 30}, dryRun.Total)
+	})
+}
@@ -9,6 +9,9 @@ import (
 	"unicode/utf8"
 )
 
+// DraftRevisionsMax is the number of previous revisions kept for each draft.
+const DraftRevisionsMax = 10
+
 type Draft struct {
 	CreateAt  int64  `json:"create_at"`
 	UpdateAt  int64  `json:"update_at"`
@@ -24,6 +27,17 @@ type Draft struct {
 	FileIds  StringArray     `json:"file_ids,omitempty"`
 	Metadata *PostMetadata   `json:"metadata,omitempty"`
 	Priority StringInterface `json:"priority,omitempty"`
+
+	// Version is incremented every time the draft is saved. Saving a draft with the version last seen by the
+	// client fails with a conflict if the draft was changed since, e.g. from another device. Saving a draft
+	// with version 0 overwrites it unconditionally.
+	Version int64 `json:"version"`
+}
+
+// DraftHistory is a draft along with its previous revisions, most recent first.
+type DraftHistory struct {
+	Draft     *Draft   `json:"draft"`
+	Revisions []*Draft `json:"revisions"`
 }
 
 func (o *Draft) IsValid(maxDraftSize int) *AppError {
@@ -67,6 +81,10 @@ func (o *Draft) BaseIsValid() *AppError {
 		return NewAppError("Drafts.IsValid", "model.draft.is_valid.priority.app_error", nil, "channelid="+o.ChannelId, http.StatusBadRequest)
 	}
 
+	if o.Version < 0 {
+		return NewAppError("Drafts.IsValid", "model.draft.is_valid.version.app_error", nil, "channelid="+o.ChannelId, http.StatusBadRequest)
+	}
+
 	return nil
 }
 
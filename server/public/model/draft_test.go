@@ -51,6 +51,11 @@ func TestDraftIsValid(t *testing.T) {
 	o.FileIds = StringArray{strings.Repeat("0", maxDraftSize+1)}
 	err = o.IsValid(maxDraftSize)
 	assert.NotNil(t, err)
+
+	o.FileIds = nil
+	o.Version = -1
+	err = o.IsValid(maxDraftSize)
+	assert.NotNil(t, err)
 }
 
 func TestDraftPreSave(t *testing.T) {
@@ -124,6 +124,10 @@ func (fi *FileInfo) IsImage() bool {
 	return strings.HasPrefix(fi.MimeType, "image")
 }
 
+func (fi *FileInfo) IsVideo() bool {
+	return strings.HasPrefix(fi.MimeType, "video")
+}
+
 func (fi *FileInfo) IsSvg() bool {
 	return fi.MimeType == "image/svg+xml"
 }
@@ -74,3 +74,16 @@ func TestFileInfoIsImage(t *testing.T) {
 		assert.False(t, info.IsImage(), "Text file should not be considered as an image")
 	})
 }
+
+func TestFileInfoIsVideo(t *testing.T) {
+	info := &FileInfo{}
+	t.Run("MimeType set to video/mp4 is considered a video", func(t *testing.T) {
+		info.MimeType = "video/mp4"
+		assert.True(t, info.IsVideo(), "MP4 file should be considered as a video")
+	})
+
+	t.Run("MimeType set to image/gif is not considered a video", func(t *testing.T) {
+		info.MimeType = "image/gif"
+		assert.False(t, info.IsVideo(), "GIF file should not be considered as a video")
+	})
+}
@@ -13,6 +13,14 @@ import (
 
 const (
 	DefaultWebhookUsername = "webhook"
+
+	// Formats of the payloads accepted by an incoming webhook, besides the default Mattermost and Slack compatible one
+	IncomingWebhookFormatGitHub       = "github"
+	IncomingWebhookFormatGitLab       = "gitlab"
+	IncomingWebhookFormatAlertmanager = "alertmanager"
+	IncomingWebhookFormatCloudEvents  = "cloudevents"
+
+	IncomingWebhookSecretMaxLength = 128
 )
 
 type IncomingWebhook struct {
@@ -28,6 +36,14 @@ type IncomingWebhook struct {
 	Username      string `json:"username"`
 	IconURL       string `json:"icon_url"`
 	ChannelLocked bool   `json:"channel_locked"`
+
+	// PayloadFormat is the format of the payloads the webhook accepts, such as IncomingWebhookFormatGitHub. The
+	// default Mattermost and Slack compatible format is used when it is empty.
+	PayloadFormat string `json:"payload_format"`
+
+	// Secret is used to verify that payloads come from the service of the payload format, such as the secret
+	// GitHub signs payloads with. Payloads aren't verified when it is empty.
+	Secret string `json:"secret"`
 }
 
 func (o *IncomingWebhook) Auditable() map[string]interface{} {
@@ -44,6 +60,7 @@ func (o *IncomingWebhook) Auditable() map[string]interface{} {
 		"username":       o.Username,
 		"icon_url:":      o.IconURL,
 		"channel_locked": o.ChannelLocked,
+		"payload_format": o.PayloadFormat,
 	}
 }
 
@@ -105,6 +122,16 @@ func (o *IncomingWebhook) IsValid() *AppError {
 		return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.icon_url.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	switch o.PayloadFormat {
+	case "", IncomingWebhookFormatGitHub, IncomingWebhookFormatGitLab, IncomingWebhookFormatAlertmanager, IncomingWebhookFormatCloudEvents:
+	default:
+		return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.payload_format.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.Secret) > IncomingWebhookSecretMaxLength {
+		return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.secret.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -65,6 +65,18 @@ func TestIncomingWebhookIsValid(t *testing.T) {
 
 	o.IconURL = strings.Repeat("1", 1024)
 	require.Nil(t, o.IsValid())
+
+	o.PayloadFormat = "slack"
+	require.NotNil(t, o.IsValid())
+
+	o.PayloadFormat = IncomingWebhookFormatGitHub
+	require.Nil(t, o.IsValid())
+
+	o.Secret = strings.Repeat("1", 129)
+	require.NotNil(t, o.IsValid())
+
+	o.Secret = strings.Repeat("1", 128)
+	require.Nil(t, o.IsValid())
 }
 
 func TestIncomingWebhookPreSave(t *testing.T) {
@@ -41,6 +41,9 @@ const (
 	JobTypeExportUsersToCSV              = "export_users_to_csv"
 	JobTypeDeleteDmsPreferencesMigration = "delete_dms_preferences_migration"
 	JobTypeMobileSessionMetadata         = "mobile_session_metadata"
+	JobTypeOutgoingWebhookRetry          = "outgoing_webhook_retry"
+	JobTypePostgresSearchIndexing        = "postgres_search_indexing"
+	JobTypeOutOfOffice                   = "out_of_office"
 
 	JobStatusPending         = "pending"
 	JobStatusInProgress      = "in_progress"
@@ -74,6 +77,9 @@ var AllJobTypes = [...]string{
 	JobTypeCleanupDesktopTokens,
 	JobTypeRefreshPostStats,
 	JobTypeMobileSessionMetadata,
+	JobTypeOutgoingWebhookRetry,
+	JobTypePostgresSearchIndexing,
+	JobTypeOutOfOffice,
 }
 
 type Job struct {
@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	OutOfOfficeMessageMaxRunes     = 4000
+	OutOfOfficeExcludedUsersMax    = 256
+	OutOfOfficeResponseTypeDM      = "dm"
+	OutOfOfficeResponseTypeMention = "mention"
+)
+
+// OutOfOffice is a scheduled auto-responder. It is turned on at StartAt and
+// off at EndAt by the out of office job, and replies to direct messages and
+// channel mentions with their own message.
+type OutOfOffice struct {
+	UserId          string      `json:"user_id"`
+	StartAt         int64       `json:"start_at"`
+	EndAt           int64       `json:"end_at"`
+	DMMessage       string      `json:"dm_message"`
+	MentionMessage  string      `json:"mention_message"`
+	DelegateUserId  string      `json:"delegate_user_id"`
+	ExcludeBots     bool        `json:"exclude_bots"`
+	ExcludedUserIds StringArray `json:"excluded_user_ids"`
+	Active          bool        `json:"active"`
+	CreateAt        int64       `json:"create_at"`
+	UpdateAt        int64       `json:"update_at"`
+}
+
+func (o *OutOfOffice) Auditable() map[string]any {
+	return map[string]any{
+		"user_id":           o.UserId,
+		"start_at":          o.StartAt,
+		"end_at":            o.EndAt,
+		"delegate_user_id":  o.DelegateUserId,
+		"exclude_bots":      o.ExcludeBots,
+		"excluded_user_ids": o.ExcludedUserIds,
+		"active":            o.Active,
+	}
+}
+
+func (o *OutOfOffice) PreSave() {
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+	o.UpdateAt = GetMillis()
+
+	if o.ExcludedUserIds == nil {
+		o.ExcludedUserIds = StringArray{}
+	}
+}
+
+func (o *OutOfOffice) IsValid() *AppError {
+	if !IsValidId(o.UserId) {
+		return NewAppError("OutOfOffice.IsValid", "model.out_of_office.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.StartAt <= 0 || o.EndAt <= o.StartAt {
+		return NewAppError("OutOfOffice.IsValid", "model.out_of_office.is_valid.schedule.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if o.DMMessage == "" && o.MentionMessage == "" {
+		return NewAppError("OutOfOffice.IsValid", "model.out_of_office.is_valid.message.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(o.DMMessage) > OutOfOfficeMessageMaxRunes || utf8.RuneCountInString(o.MentionMessage) > OutOfOfficeMessageMaxRunes {
+		return NewAppError("OutOfOffice.IsValid", "model.out_of_office.is_valid.message_length.app_error", map[string]any{"Max": OutOfOfficeMessageMaxRunes}, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if o.DelegateUserId != "" && (!IsValidId(o.DelegateUserId) || o.DelegateUserId == o.UserId) {
+		return NewAppError("OutOfOffice.IsValid", "model.out_of_office.is_valid.delegate_user_id.app_error", nil, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+
+	if len(o.ExcludedUserIds) > OutOfOfficeExcludedUsersMax {
+		return NewAppError("OutOfOffice.IsValid", "model.out_of_office.is_valid.excluded_user_ids.app_error", map[string]any{"Max": OutOfOfficeExcludedUsersMax}, "user_id="+o.UserId, http.StatusBadRequest)
+	}
+	for _, id := range o.ExcludedUserIds {
+		if !IsValidId(id) {
+			return NewAppError("OutOfOffice.IsValid", "model.out_of_office.is_valid.excluded_user_ids.app_error", map[string]any{"Max": OutOfOfficeExcludedUsersMax}, "user_id="+o.UserId, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// IsScheduledAt reports whether the given time, in milliseconds, falls within
+// the out of office period.
+func (o *OutOfOffice) IsScheduledAt(millis int64) bool {
+	return o.StartAt <= millis && millis < o.EndAt
+}
+
+// ShouldRespondTo reports whether a message from the sender gets an automatic
+// reply according to the exclusion rules.
+func (o *OutOfOffice) ShouldRespondTo(sender *User) bool {
+	if sender.IsBot && o.ExcludeBots {
+		return false
+	}
+
+	return !o.ExcludedUserIds.Contains(sender.Id)
+}
+
+// Message returns the message used to reply to a direct message or to a
+// mention in a channel.
+func (o *OutOfOffice) Message(responseType string) string {
+	if responseType == OutOfOfficeResponseTypeMention {
+		return o.MentionMessage
+	}
+	return o.DMMessage
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutOfOfficeIsValid(t *testing.T) {
+	o := OutOfOffice{}
+	assert.NotNil(t, o.IsValid())
+
+	o.UserId = NewId()
+	o.StartAt = 1000
+	o.EndAt = 2000
+	o.DMMessage = "I'm away"
+	o.PreSave()
+	assert.Nil(t, o.IsValid())
+	assert.NotNil(t, o.ExcludedUserIds)
+
+	o.EndAt = o.StartAt
+	assert.NotNil(t, o.IsValid())
+	o.EndAt = 2000
+
+	o.DMMessage = ""
+	assert.NotNil(t, o.IsValid())
+	o.MentionMessage = "I'm away, I'll reply when I'm back"
+	assert.Nil(t, o.IsValid())
+
+	o.MentionMessage = strings.Repeat("a", OutOfOfficeMessageMaxRunes+1)
+	assert.NotNil(t, o.IsValid())
+	o.MentionMessage = "I'm away"
+
+	o.DelegateUserId = o.UserId
+	assert.NotNil(t, o.IsValid())
+	o.DelegateUserId = "abc"
+	assert.NotNil(t, o.IsValid())
+	o.DelegateUserId = NewId()
+	assert.Nil(t, o.IsValid())
+
+	o.ExcludedUserIds = StringArray{"abc"}
+	assert.NotNil(t, o.IsValid())
+	o.ExcludedUserIds = StringArray{NewId()}
+	assert.Nil(t, o.IsValid())
+}
+
+func TestOutOfOfficeIsScheduledAt(t *testing.T) {
+	o := OutOfOffice{StartAt: 1000, EndAt: 2000}
+
+	assert.False(t, o.IsScheduledAt(999))
+	assert.True(t, o.IsScheduledAt(1000))
+	assert.True(t, o.IsScheduledAt(1999))
+	assert.False(t, o.IsScheduledAt(2000))
+}
+
+func TestOutOfOfficeShouldRespondTo(t *testing.T) {
+	excluded := NewId()
+	o := OutOfOffice{ExcludedUserIds: StringArray{excluded}}
+
+	assert.True(t, o.ShouldRespondTo(&User{Id: NewId()}))
+	assert.False(t, o.ShouldRespondTo(&User{Id: excluded}))
+	assert.True(t, o.ShouldRespondTo(&User{Id: NewId(), IsBot: true}))
+
+	o.ExcludeBots = true
+	assert.False(t, o.ShouldRespondTo(&User{Id: NewId(), IsBot: true}))
+	assert.True(t, o.ShouldRespondTo(&User{Id: NewId()}))
+}
+
+func TestOutOfOfficeMessage(t *testing.T) {
+	o := OutOfOffice{DMMessage: "dm", MentionMessage: "mention"}
+
+	assert.Equal(t, "dm", o.Message(OutOfOfficeResponseTypeDM))
+	assert.Equal(t, "mention", o.Message(OutOfOfficeResponseTypeMention))
+}
@@ -7,49 +7,71 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 )
 
 type OutgoingWebhook struct {
-	Id           string      `json:"id"`
-	Token        string      `json:"token"`
-	CreateAt     int64       `json:"create_at"`
-	UpdateAt     int64       `json:"update_at"`
-	DeleteAt     int64       `json:"delete_at"`
-	CreatorId    string      `json:"creator_id"`
-	ChannelId    string      `json:"channel_id"`
-	TeamId       string      `json:"team_id"`
-	TriggerWords StringArray `json:"trigger_words"`
-	TriggerWhen  int         `json:"trigger_when"`
-	CallbackURLs StringArray `json:"callback_urls"`
-	DisplayName  string      `json:"display_name"`
-	Description  string      `json:"description"`
-	ContentType  string      `json:"content_type"`
-	Username     string      `json:"username"`
-	IconURL      string      `json:"icon_url"`
+	Id            string      `json:"id"`
+	Token         string      `json:"token"`
+	SigningSecret string      `json:"signing_secret"`
+	CreateAt      int64       `json:"create_at"`
+	UpdateAt      int64       `json:"update_at"`
+	DeleteAt      int64       `json:"delete_at"`
+	CreatorId     string      `json:"creator_id"`
+	ChannelId     string      `json:"channel_id"`
+	TeamId        string      `json:"team_id"`
+	TriggerWords  StringArray `json:"trigger_words"`
+	TriggerWhen   int         `json:"trigger_when"`
+	CallbackURLs  StringArray `json:"callback_urls"`
+	DisplayName   string      `json:"display_name"`
+	Description   string      `json:"description"`
+	ContentType   string      `json:"content_type"`
+	Username      string      `json:"username"`
+	IconURL       string      `json:"icon_url"`
+
+	// PayloadTemplate is a text/template rendered with OutgoingWebhookTemplateData to build the body of the
+	// request instead of the default payload.
+	PayloadTemplate string `json:"payload_template"`
+
+	// Headers are custom headers sent with every request.
+	Headers StringMap `json:"headers"`
 }
 
 func (o *OutgoingWebhook) Auditable() map[string]interface{} {
 	return map[string]interface{}{
-		"id":            o.Id,
-		"create_at":     o.CreateAt,
-		"update_at":     o.UpdateAt,
-		"delete_at":     o.DeleteAt,
-		"creator_id":    o.CreatorId,
-		"channel_id":    o.ChannelId,
-		"team_id":       o.TeamId,
-		"trigger_words": o.TriggerWords,
-		"trigger_when":  o.TriggerWhen,
-		"callback_urls": o.CallbackURLs,
-		"display_name":  o.DisplayName,
-		"description":   o.Description,
-		"content_type":  o.ContentType,
-		"username":      o.Username,
-		"icon_url":      o.IconURL,
+		"id":               o.Id,
+		"create_at":        o.CreateAt,
+		"update_at":        o.UpdateAt,
+		"delete_at":        o.DeleteAt,
+		"creator_id":       o.CreatorId,
+		"channel_id":       o.ChannelId,
+		"team_id":          o.TeamId,
+		"trigger_words":    o.TriggerWords,
+		"trigger_when":     o.TriggerWhen,
+		"callback_urls":    o.CallbackURLs,
+		"display_name":     o.DisplayName,
+		"description":      o.Description,
+		"content_type":     o.ContentType,
+		"username":         o.Username,
+		"icon_url":         o.IconURL,
+		"payload_template": o.PayloadTemplate,
+		"header_names":     o.headerNames(),
 	}
 }
 
+// headerNames returns the names of the custom headers, whose values may hold credentials.
+func (o *OutgoingWebhook) headerNames() []string {
+	names := make([]string, 0, len(o.Headers))
+	for name := range o.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
 type OutgoingWebhookPayload struct {
 	Token       string `json:"token"`
 	TeamId      string `json:"team_id"`
@@ -171,6 +193,14 @@ func (o *OutgoingWebhook) IsValid() *AppError {
 		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.icon_url.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if len(o.SigningSecret) > OutgoingWebhookSigningSecretMaxLength {
+		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.signing_secret.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if err := o.IsValidPayloadTemplate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -183,6 +213,10 @@ func (o *OutgoingWebhook) PreSave() {
 		o.Token = NewId()
 	}
 
+	if o.SigningSecret == "" {
+		o.SigningSecret = NewOutgoingWebhookSigningSecret()
+	}
+
 	o.CreateAt = GetMillis()
 	o.UpdateAt = o.CreateAt
 }
@@ -0,0 +1,182 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	OutgoingWebhookDeliveryStatusPending  = "pending"
+	OutgoingWebhookDeliveryStatusRetrying = "retrying"
+	OutgoingWebhookDeliveryStatusSuccess  = "success"
+	OutgoingWebhookDeliveryStatusFailed   = "failed"
+
+	OutgoingWebhookSignatureHeader  = "X-Mattermost-Signature"
+	OutgoingWebhookIdHeader         = "X-Mattermost-Webhook-Id"
+	OutgoingWebhookDeliveryIdHeader = "X-Mattermost-Delivery-Id"
+
+	OutgoingWebhookSigningSecretLength    = 32
+	OutgoingWebhookSigningSecretMaxLength = 64
+
+	OutgoingWebhookDeliveryResponseExcerptMaxLength = 1024
+	OutgoingWebhookDeliveryErrorMaxLength           = 1024
+
+	OutgoingWebhookRetryBaseDelay = 30 * time.Second
+	OutgoingWebhookRetryMaxDelay  = time.Hour
+)
+
+// OutgoingWebhookDelivery records a payload sent to one of the callback URLs
+// of an outgoing webhook, along with the result of the latest attempt.
+type OutgoingWebhookDelivery struct {
+	Id              string `json:"id"`
+	HookId          string `json:"hook_id"`
+	PostId          string `json:"post_id"`
+	ChannelId       string `json:"channel_id"`
+	CallbackURL     string `json:"callback_url"`
+	ContentType     string `json:"content_type"`
+	Payload         string `json:"payload"`
+	Status          string `json:"status"`
+	Attempts        int    `json:"attempts"`
+	StatusCode      int    `json:"status_code"`
+	Latency         int64  `json:"latency"`
+	Error           string `json:"error"`
+	ResponseExcerpt string `json:"response_excerpt"`
+	CreateAt        int64  `json:"create_at"`
+	UpdateAt        int64  `json:"update_at"`
+	NextAttemptAt   int64  `json:"next_attempt_at"`
+}
+
+func (o *OutgoingWebhookDelivery) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"id":           o.Id,
+		"hook_id":      o.HookId,
+		"post_id":      o.PostId,
+		"channel_id":   o.ChannelId,
+		"callback_url": o.CallbackURL,
+		"status":       o.Status,
+		"attempts":     o.Attempts,
+		"status_code":  o.StatusCode,
+		"create_at":    o.CreateAt,
+		"update_at":    o.UpdateAt,
+	}
+}
+
+func (o *OutgoingWebhookDelivery) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.HookId) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.hook_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.PostId != "" && !IsValidId(o.PostId) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.post_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.ChannelId != "" && !IsValidId(o.ChannelId) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidHTTPURL(o.CallbackURL) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.callback_url.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	switch o.Status {
+	case OutgoingWebhookDeliveryStatusPending, OutgoingWebhookDeliveryStatusRetrying, OutgoingWebhookDeliveryStatusSuccess, OutgoingWebhookDeliveryStatusFailed:
+	default:
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.status.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (o *OutgoingWebhookDelivery) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.Status == "" {
+		o.Status = OutgoingWebhookDeliveryStatusPending
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+}
+
+func (o *OutgoingWebhookDelivery) PreUpdate() {
+	o.UpdateAt = GetMillis()
+	o.Error = truncateDeliveryField(o.Error, OutgoingWebhookDeliveryErrorMaxLength)
+	o.ResponseExcerpt = truncateDeliveryField(o.ResponseExcerpt, OutgoingWebhookDeliveryResponseExcerptMaxLength)
+}
+
+// IsRetryableOutgoingWebhookStatusCode reports whether a delivery that failed
+// with the given HTTP status code should be attempted again later.
+func IsRetryableOutgoingWebhookStatusCode(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// OutgoingWebhookRetryDelay returns how long to wait before the next attempt
+// of a delivery that has already been attempted the given number of times.
+func OutgoingWebhookRetryDelay(attempts int) time.Duration {
+	delay := OutgoingWebhookRetryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= OutgoingWebhookRetryMaxDelay {
+			return OutgoingWebhookRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+// NewOutgoingWebhookSigningSecret generates a secret used to sign the
+// payloads of an outgoing webhook. Webhooks get one when they are created or
+// their token is regenerated, and the webhooks created before payloads were
+// signed get one on upgrade.
+func NewOutgoingWebhookSigningSecret() string {
+	return NewRandomString(OutgoingWebhookSigningSecretLength)
+}
+
+// SignOutgoingWebhookPayload returns the value of the signature header for the
+// given payload. The signature is the hex encoded HMAC-SHA256 of the unix
+// timestamp and the body joined by a dot, keyed with the hook's secret, so
+// that receivers can reject replayed requests.
+func SignOutgoingWebhookPayload(secret string, timestamp int64, body []byte) string {
+	ts := strconv.FormatInt(timestamp, 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return fmt.Sprintf("t=%s,v1=%s", ts, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// truncateDeliveryField truncates s to at most maxLength bytes without
+// splitting a multi-byte character.
+func truncateDeliveryField(s string, maxLength int) string {
+	if len(s) <= maxLength {
+		return s
+	}
+	for maxLength > 0 && !utf8.RuneStart(s[maxLength]) {
+		maxLength--
+	}
+	return s[:maxLength]
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutgoingWebhookDeliveryIsValid(t *testing.T) {
+	d := OutgoingWebhookDelivery{}
+	assert.NotNil(t, d.IsValid())
+
+	d.HookId = NewId()
+	d.CallbackURL = "http://example.com/hook"
+	d.PreSave()
+	assert.Nil(t, d.IsValid())
+	assert.Equal(t, OutgoingWebhookDeliveryStatusPending, d.Status)
+
+	d.PostId = "123"
+	assert.NotNil(t, d.IsValid())
+	d.PostId = NewId()
+
+	d.CallbackURL = "example.com"
+	assert.NotNil(t, d.IsValid())
+	d.CallbackURL = "http://example.com/hook"
+
+	d.Status = "unknown"
+	assert.NotNil(t, d.IsValid())
+	d.Status = OutgoingWebhookDeliveryStatusFailed
+	assert.Nil(t, d.IsValid())
+}
+
+func TestOutgoingWebhookDeliveryPreUpdate(t *testing.T) {
+	d := OutgoingWebhookDelivery{
+		Error:           strings.Repeat("e", OutgoingWebhookDeliveryErrorMaxLength+1),
+		ResponseExcerpt: strings.Repeat("a", OutgoingWebhookDeliveryResponseExcerptMaxLength-1) + "é",
+	}
+	d.PreUpdate()
+
+	assert.NotZero(t, d.UpdateAt)
+	assert.Len(t, d.Error, OutgoingWebhookDeliveryErrorMaxLength)
+	assert.Equal(t, strings.Repeat("a", OutgoingWebhookDeliveryResponseExcerptMaxLength-1), d.ResponseExcerpt)
+}
+
+func TestOutgoingWebhookRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, OutgoingWebhookRetryDelay(1))
+	assert.Equal(t, time.Minute, OutgoingWebhookRetryDelay(2))
+	assert.Equal(t, 2*time.Minute, OutgoingWebhookRetryDelay(3))
+	assert.Equal(t, time.Hour, OutgoingWebhookRetryDelay(10))
+	assert.Equal(t, time.Hour, OutgoingWebhookRetryDelay(100))
+}
+
+func TestIsRetryableOutgoingWebhookStatusCode(t *testing.T) {
+	assert.True(t, IsRetryableOutgoingWebhookStatusCode(http.StatusTooManyRequests))
+	assert.True(t, IsRetryableOutgoingWebhookStatusCode(http.StatusBadGateway))
+	assert.False(t, IsRetryableOutgoingWebhookStatusCode(http.StatusOK))
+	assert.False(t, IsRetryableOutgoingWebhookStatusCode(http.StatusNotFound))
+}
+
+func TestSignOutgoingWebhookPayload(t *testing.T) {
+	secret := NewOutgoingWebhookSigningSecret()
+	require.Len(t, secret, OutgoingWebhookSigningSecretLength)
+
+	body := []byte(`{"text":"hello"}`)
+	signature := SignOutgoingWebhookPayload(secret, 1700000000, body)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("1700000000." + string(body)))
+	assert.Equal(t, "t=1700000000,v1="+hex.EncodeToString(mac.Sum(nil)), signature)
+
+	assert.NotEqual(t, signature, SignOutgoingWebhookPayload(secret, 1700000001, body))
+	assert.NotEqual(t, signature, SignOutgoingWebhookPayload(NewOutgoingWebhookSigningSecret(), 1700000000, body))
+}
@@ -0,0 +1,396 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+)
+
+const (
+	OutgoingWebhookPayloadTemplateMaxLength = 16 * 1024
+	OutgoingWebhookPayloadMaxSize           = 256 * 1024
+	OutgoingWebhookMaxHeaders               = 20
+	OutgoingWebhookHeaderNameMaxLength      = 128
+	OutgoingWebhookHeaderValueMaxLength     = 1024
+
+	// A payload template can't loop more than OutgoingWebhookPayloadMaxSteps times in total, or render for longer
+	// than OutgoingWebhookPayloadRenderTimeout.
+	OutgoingWebhookPayloadMaxSteps      = 10000
+	OutgoingWebhookPayloadRenderTimeout = time.Second
+	outgoingWebhookReservedHeaderPrefix = "X-Mattermost-"
+	outgoingWebhookTemplateStepFuncName = "_step"
+)
+
+// outgoingWebhookReservedHeaders can't be overridden by the custom headers of an outgoing webhook, as they are either
+// set by the server or describe the transport of the request.
+var outgoingWebhookReservedHeaders = map[string]bool{
+	"Accept":            true,
+	"Connection":        true,
+	"Content-Length":    true,
+	"Content-Type":      true,
+	"Host":              true,
+	"Transfer-Encoding": true,
+}
+
+var (
+	errOutgoingWebhookPayloadTooLarge = errors.New("rendered payload exceeds the maximum size")
+	errOutgoingWebhookPayloadTooSlow  = errors.New("rendering the payload takes too long")
+)
+
+// OutgoingWebhookTemplateData is the data available to the payload template of an outgoing webhook, such as
+// {{.Post.Message}} or {{.User.Username}}.
+type OutgoingWebhookTemplateData struct {
+	Token       string
+	TriggerWord string
+	Post        OutgoingWebhookTemplatePost
+	Channel     OutgoingWebhookTemplateChannel
+	Team        OutgoingWebhookTemplateTeam
+	User        OutgoingWebhookTemplateUser
+}
+
+type OutgoingWebhookTemplatePost struct {
+	Id       string
+	RootId   string
+	Message  string
+	Type     string
+	CreateAt int64
+	FileIds  []string
+	Hashtags string
+}
+
+type OutgoingWebhookTemplateChannel struct {
+	Id          string
+	Name        string
+	DisplayName string
+	Type        ChannelType
+	Header      string
+	Purpose     string
+}
+
+type OutgoingWebhookTemplateTeam struct {
+	Id          string
+	Name        string
+	DisplayName string
+}
+
+// OutgoingWebhookTemplateUser holds the fields of the author of the post. FirstName and LastName are only set when
+// the server is configured to show full names.
+type OutgoingWebhookTemplateUser struct {
+	Id        string
+	Username  string
+	Nickname  string
+	FirstName string
+	LastName  string
+	Position  string
+	Locale    string
+	IsBot     bool
+}
+
+// OutgoingWebhookRenderedPayload is a payload rendered from the template of an outgoing webhook, as it would be sent
+// to its callback URLs.
+type OutgoingWebhookRenderedPayload struct {
+	ContentType string            `json:"content_type"`
+	Headers     map[string]string `json:"headers"`
+	Payload     string            `json:"payload"`
+}
+
+// SampleOutgoingWebhookTemplateData returns placeholder data to check that payload templates render.
+func SampleOutgoingWebhookTemplateData() *OutgoingWebhookTemplateData {
+	return &OutgoingWebhookTemplateData{
+		Token:       NewId(),
+		TriggerWord: "trigger",
+		Post: OutgoingWebhookTemplatePost{
+			Id:       NewId(),
+			Message:  "trigger This is a sample message",
+			CreateAt: GetMillis(),
+			FileIds:  []string{NewId()},
+		},
+		Channel: OutgoingWebhookTemplateChannel{
+			Id:          NewId(),
+			Name:        "town-square",
+			DisplayName: "Town Square",
+			Type:        ChannelTypeOpen,
+		},
+		Team: OutgoingWebhookTemplateTeam{
+			Id:          NewId(),
+			Name:        "team",
+			DisplayName: "Team",
+		},
+		User: OutgoingWebhookTemplateUser{
+			Id:       NewId(),
+			Username: "sample.user",
+		},
+	}
+}
+
+var outgoingWebhookTemplateFuncs = template.FuncMap{
+	// json encodes a value, so that strings can safely be embedded in a JSON payload: {"text": {{json .Post.Message}}}
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+	// replace refuses to build strings larger than a payload, as nested calls would otherwise grow exponentially
+	"replace": func(s, old, new string) (string, error) {
+		if old == "" || len(new) > len(old) {
+			count := strings.Count(s, old)
+			if len(s)+count*(len(new)-len(old)) > OutgoingWebhookPayloadMaxSize {
+				return "", errOutgoingWebhookPayloadTooLarge
+			}
+		}
+		return strings.ReplaceAll(s, old, new), nil
+	},
+	"join": func(elems []string, sep string) string {
+		return strings.Join(elems, sep)
+	},
+	// rfc3339 formats a timestamp in milliseconds, such as .Post.CreateAt
+	"rfc3339": func(millis int64) string {
+		return time.UnixMilli(millis).UTC().Format(time.RFC3339)
+	},
+	// The step function is bound to the budget of each rendering, see RenderPayload
+	outgoingWebhookTemplateStepFuncName: func() string { return "" },
+}
+
+// ParsePayloadTemplate parses the payload template of the webhook, which must be set. Since templates are rendered
+// on every matching post, only constructs that render in bounded time are allowed: templates can't be defined or
+// called, and ranges can't be nested and only iterate over lists of the template data, such as .Post.FileIds.
+func (o *OutgoingWebhook) ParsePayloadTemplate() (*template.Template, error) {
+	tmpl, err := template.New("payload").Funcs(outgoingWebhookTemplateFuncs).Parse(o.PayloadTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tmpl.Templates()) > 1 {
+		return nil, errors.New("defining templates is not supported")
+	}
+	if tmpl.Tree == nil {
+		return tmpl, nil
+	}
+	if err := checkPayloadTemplateNode(tmpl.Tree.Root, reflect.TypeOf(OutgoingWebhookTemplateData{}), false); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+// checkPayloadTemplateNode rejects the nodes of a payload template that could take unbounded time to render, and
+// makes every iteration of a range call the step function. dot is the type of the data at the node, or nil if
+// unknown.
+func checkPayloadTemplateNode(node parse.Node, dot reflect.Type, inRange bool) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := checkPayloadTemplateNode(child, dot, inRange); err != nil {
+				return err
+			}
+		}
+	case *parse.IfNode:
+		if err := checkPayloadTemplateNode(n.List, dot, inRange); err != nil {
+			return err
+		}
+		return checkPayloadTemplateNode(n.ElseList, dot, inRange)
+	case *parse.WithNode:
+		if err := checkPayloadTemplateNode(n.List, payloadTemplatePipeType(n.Pipe, dot), inRange); err != nil {
+			return err
+		}
+		return checkPayloadTemplateNode(n.ElseList, dot, inRange)
+	case *parse.RangeNode:
+		if inRange {
+			return errors.New("nested range actions are not supported")
+		}
+		typ := payloadTemplatePipeType(n.Pipe, dot)
+		if typ == nil || (typ.Kind() != reflect.Slice && typ.Kind() != reflect.Map) {
+			return errors.New("range actions only support lists of the template data, such as .Post.FileIds")
+		}
+		if err := checkPayloadTemplateNode(n.List, typ.Elem(), true); err != nil {
+			return err
+		}
+		n.List.Nodes = append([]parse.Node{payloadTemplateStepNode()}, n.List.Nodes...)
+		return checkPayloadTemplateNode(n.ElseList, dot, inRange)
+	case *parse.TemplateNode:
+		return errors.New("template actions are not supported")
+	}
+
+	return nil
+}
+
+// payloadTemplatePipeType returns the type of a pipeline made of a single field of the template data, such as
+// .Post.FileIds or $.Post, or nil for any other pipeline.
+func payloadTemplatePipeType(pipe *parse.PipeNode, dot reflect.Type) reflect.Type {
+	if pipe == nil || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return nil
+	}
+
+	var typ reflect.Type
+	var fields []string
+	switch arg := pipe.Cmds[0].Args[0].(type) {
+	case *parse.FieldNode:
+		typ, fields = dot, arg.Ident
+	case *parse.VariableNode:
+		if arg.Ident[0] != "$" {
+			return nil
+		}
+		typ, fields = reflect.TypeOf(OutgoingWebhookTemplateData{}), arg.Ident[1:]
+	case *parse.DotNode:
+		typ = dot
+	default:
+		return nil
+	}
+
+	for _, name := range fields {
+		if typ == nil || typ.Kind() != reflect.Struct {
+			return nil
+		}
+		field, ok := typ.FieldByName(name)
+		if !ok {
+			return nil
+		}
+		typ = field.Type
+	}
+
+	return typ
+}
+
+// payloadTemplateStepNode returns an action calling the step function, which renders nothing.
+func payloadTemplateStepNode() parse.Node {
+	return &parse.ActionNode{
+		NodeType: parse.NodeAction,
+		Pipe: &parse.PipeNode{
+			NodeType: parse.NodePipe,
+			Cmds: []*parse.CommandNode{{
+				NodeType: parse.NodeCommand,
+				Args:     []parse.Node{parse.NewIdentifier(outgoingWebhookTemplateStepFuncName)},
+			}},
+		},
+	}
+}
+
+// PayloadContentType returns the content type of the payloads sent by the webhook. Templated payloads are sent as
+// JSON unless the webhook specifies otherwise.
+func (o *OutgoingWebhook) PayloadContentType() string {
+	if o.PayloadTemplate == "" {
+		if o.ContentType == "application/json" {
+			return "application/json"
+		}
+		return "application/x-www-form-urlencoded"
+	}
+
+	if o.ContentType == "" {
+		return "application/json"
+	}
+
+	return o.ContentType
+}
+
+// RenderPayload renders the payload template of the webhook with the given data. Payloads sent as JSON must render
+// to valid JSON.
+func (o *OutgoingWebhook) RenderPayload(data *OutgoingWebhookTemplateData) ([]byte, error) {
+	tmpl, err := o.ParsePayloadTemplate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Templates can't be interrupted, so each iteration of a range spends a step of the budget and fails the
+	// rendering once it runs out or the deadline has passed.
+	steps := 0
+	deadline := time.Now().Add(OutgoingWebhookPayloadRenderTimeout)
+	tmpl.Funcs(template.FuncMap{
+		outgoingWebhookTemplateStepFuncName: func() (string, error) {
+			steps++
+			if steps > OutgoingWebhookPayloadMaxSteps || time.Now().After(deadline) {
+				return "", errOutgoingWebhookPayloadTooSlow
+			}
+			return "", nil
+		},
+	})
+
+	buf := &limitedBuffer{limit: OutgoingWebhookPayloadMaxSize}
+	if err := tmpl.Execute(buf, data); err != nil {
+		return nil, err
+	}
+
+	if o.PayloadContentType() == "application/json" && !json.Valid(buf.Bytes()) {
+		return nil, errors.New("rendered payload is not valid JSON")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// IsValidPayloadTemplate checks that the payload template of the webhook parses and renders, and that its custom
+// headers can be sent.
+func (o *OutgoingWebhook) IsValidPayloadTemplate() *AppError {
+	if len(o.PayloadTemplate) > OutgoingWebhookPayloadTemplateMaxLength {
+		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.payload_template_length.app_error", map[string]any{"Max": OutgoingWebhookPayloadTemplateMaxLength}, "", http.StatusBadRequest)
+	}
+
+	if o.PayloadTemplate != "" {
+		// Rendering catches references to fields that don't exist, which parsing alone doesn't
+		if _, err := o.RenderPayload(SampleOutgoingWebhookTemplateData()); err != nil {
+			return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.payload_template.app_error", map[string]any{"Error": err.Error()}, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
+	if len(o.Headers) > OutgoingWebhookMaxHeaders {
+		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.headers.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for name, value := range o.Headers {
+		canonicalName := http.CanonicalHeaderKey(name)
+		if !isValidHeaderName(name) || len(name) > OutgoingWebhookHeaderNameMaxLength ||
+			outgoingWebhookReservedHeaders[canonicalName] || strings.HasPrefix(canonicalName, outgoingWebhookReservedHeaderPrefix) {
+			return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.header_name.app_error", map[string]any{"Name": name}, "", http.StatusBadRequest)
+		}
+
+		if len(value) > OutgoingWebhookHeaderValueMaxLength || strings.ContainsAny(value, "\r\n\x00") {
+			return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.header_value.app_error", map[string]any{"Name": name}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// isValidHeaderName reports whether name only contains the characters allowed in an HTTP header name.
+func isValidHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", r):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// limitedBuffer is a buffer that refuses to grow beyond its limit.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errOutgoingWebhookPayloadTooLarge
+	}
+
+	return b.Buffer.Write(p)
+}
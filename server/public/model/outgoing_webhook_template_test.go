@@ -0,0 +1,144 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutgoingWebhookRenderPayload(t *testing.T) {
+	data := &OutgoingWebhookTemplateData{
+		TriggerWord: "deploy",
+		Post:        OutgoingWebhookTemplatePost{Message: `deploy "api"`, CreateAt: 1700000000000, FileIds: []string{"a", "b"}},
+		Channel:     OutgoingWebhookTemplateChannel{Name: "ops"},
+		Team:        OutgoingWebhookTemplateTeam{Name: "eng"},
+		User:        OutgoingWebhookTemplateUser{Username: "jane"},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		hook := &OutgoingWebhook{
+			PayloadTemplate: `{"text": {{json .Post.Message}}, "where": "{{.Team.Name}}/{{.Channel.Name}}", "who": "{{upper .User.Username}}", "files": "{{join .Post.FileIds ","}}", "at": "{{rfc3339 .Post.CreateAt}}"}`,
+		}
+
+		payload, err := hook.RenderPayload(data)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"text": "deploy \"api\"", "where": "eng/ops", "who": "JANE", "files": "a,b", "at": "2023-11-14T22:13:20Z"}`, string(payload))
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		hook := &OutgoingWebhook{PayloadTemplate: `{"text": "{{.Post.Message}}"}`}
+
+		_, err := hook.RenderPayload(data)
+		require.Error(t, err)
+	})
+
+	t.Run("other content types are not checked", func(t *testing.T) {
+		hook := &OutgoingWebhook{PayloadTemplate: `{{.Post.Message}} by {{.User.Username}}`, ContentType: "text/plain"}
+
+		payload, err := hook.RenderPayload(data)
+		require.NoError(t, err)
+		assert.Equal(t, `deploy "api" by jane`, string(payload))
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		hook := &OutgoingWebhook{PayloadTemplate: `{{.Post.Unknown}}`, ContentType: "text/plain"}
+
+		_, err := hook.RenderPayload(data)
+		require.Error(t, err)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		hook := &OutgoingWebhook{PayloadTemplate: `{{range .Post.FileIds}}{{$.Post.Message}}{{end}}`, ContentType: "text/plain"}
+
+		large := *data
+		large.Post.Message = strings.Repeat("a", OutgoingWebhookPayloadMaxSize/2+1)
+		_, err := hook.RenderPayload(&large)
+		require.Error(t, err)
+	})
+
+	t.Run("replace too large", func(t *testing.T) {
+		hook := &OutgoingWebhook{PayloadTemplate: `{{replace (replace (replace (replace .Post.Message "" "aaaaaaaaaaaaaaaa") "" "aaaaaaaaaaaaaaaa") "" "aaaaaaaaaaaaaaaa") "" "aaaaaaaaaaaaaaaa"}}`, ContentType: "text/plain"}
+
+		_, err := hook.RenderPayload(data)
+		require.Error(t, err)
+	})
+
+	t.Run("range over a list", func(t *testing.T) {
+		hook := &OutgoingWebhook{PayloadTemplate: `{{range $i, $id := .Post.FileIds}}{{if $i}},{{end}}{{$id}}{{end}}{{with .Post}}{{range .FileIds}}.{{end}}{{end}}`, ContentType: "text/plain"}
+
+		payload, err := hook.RenderPayload(data)
+		require.NoError(t, err)
+		assert.Equal(t, "a,b..", string(payload))
+	})
+
+	t.Run("step budget", func(t *testing.T) {
+		hook := &OutgoingWebhook{PayloadTemplate: `{{range .Post.FileIds}}{{end}}`, ContentType: "text/plain"}
+
+		many := *data
+		many.Post.FileIds = make([]string, OutgoingWebhookPayloadMaxSteps+1)
+		_, err := hook.RenderPayload(&many)
+		require.ErrorIs(t, err, errOutgoingWebhookPayloadTooSlow)
+	})
+}
+
+func TestOutgoingWebhookParsePayloadTemplate(t *testing.T) {
+	for name, tmpl := range map[string]string{
+		"range over an integer":       `{{range 30000}}{{end}}`,
+		"range over an integer field": `{{range .Post.CreateAt}}{{end}}`,
+		"range over a variable":       `{{$n := 30000}}{{range $n}}{{end}}`,
+		"range over a function":       `{{range (json .)}}{{end}}`,
+		"nested range":                `{{range .Post.FileIds}}{{range $.Post.FileIds}}{{end}}{{end}}`,
+		"nested range in else":        `{{range .Post.FileIds}}{{else}}{{range .Post.FileIds}}{{range $.Post.FileIds}}{{end}}{{end}}{{end}}`,
+		"define":                      `{{define "a"}}{{template "a"}}{{end}}`,
+		"template":                    `{{template "a"}}`,
+		"block":                       `{{block "a" .}}{{end}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := (&OutgoingWebhook{PayloadTemplate: tmpl}).ParsePayloadTemplate()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestOutgoingWebhookPayloadContentType(t *testing.T) {
+	assert.Equal(t, "application/x-www-form-urlencoded", (&OutgoingWebhook{}).PayloadContentType())
+	assert.Equal(t, "application/x-www-form-urlencoded", (&OutgoingWebhook{ContentType: "text/plain"}).PayloadContentType())
+	assert.Equal(t, "application/json", (&OutgoingWebhook{ContentType: "application/json"}).PayloadContentType())
+	assert.Equal(t, "application/json", (&OutgoingWebhook{PayloadTemplate: "{}"}).PayloadContentType())
+	assert.Equal(t, "text/plain", (&OutgoingWebhook{PayloadTemplate: "{}", ContentType: "text/plain"}).PayloadContentType())
+}
+
+func TestOutgoingWebhookIsValidPayloadTemplate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		hook    OutgoingWebhook
+		errorId string
+	}{
+		"no template":       {hook: OutgoingWebhook{}},
+		"valid template":    {hook: OutgoingWebhook{PayloadTemplate: `{"text": {{json .Post.Message}}, "file": "{{index .Post.FileIds 0}}"}`}},
+		"parse error":       {hook: OutgoingWebhook{PayloadTemplate: `{{if}}`}, errorId: "model.outgoing_hook.is_valid.payload_template.app_error"},
+		"unknown function":  {hook: OutgoingWebhook{PayloadTemplate: `{{exec "ls"}}`}, errorId: "model.outgoing_hook.is_valid.payload_template.app_error"},
+		"unknown field":     {hook: OutgoingWebhook{PayloadTemplate: `{{.User.Password}}`, ContentType: "text/plain"}, errorId: "model.outgoing_hook.is_valid.payload_template.app_error"},
+		"not json":          {hook: OutgoingWebhook{PayloadTemplate: `text={{.Post.Message}}`}, errorId: "model.outgoing_hook.is_valid.payload_template.app_error"},
+		"too long":          {hook: OutgoingWebhook{PayloadTemplate: strings.Repeat("a", OutgoingWebhookPayloadTemplateMaxLength+1), ContentType: "text/plain"}, errorId: "model.outgoing_hook.is_valid.payload_template_length.app_error"},
+		"valid headers":     {hook: OutgoingWebhook{Headers: StringMap{"X-Api-Key": "secret", "Authorization": "Bearer token"}}},
+		"reserved header":   {hook: OutgoingWebhook{Headers: StringMap{"content-type": "text/plain"}}, errorId: "model.outgoing_hook.is_valid.header_name.app_error"},
+		"mattermost header": {hook: OutgoingWebhook{Headers: StringMap{"X-Mattermost-Signature": "forged"}}, errorId: "model.outgoing_hook.is_valid.header_name.app_error"},
+		"invalid name":      {hook: OutgoingWebhook{Headers: StringMap{"X Api Key": "secret"}}, errorId: "model.outgoing_hook.is_valid.header_name.app_error"},
+		"invalid value":     {hook: OutgoingWebhook{Headers: StringMap{"X-Api-Key": "secret\r\nHost: evil"}}, errorId: "model.outgoing_hook.is_valid.header_value.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			appErr := tc.hook.IsValidPayloadTemplate()
+			if tc.errorId == "" {
+				assert.Nil(t, appErr)
+			} else {
+				require.NotNil(t, appErr)
+				assert.Equal(t, tc.errorId, appErr.Id)
+			}
+		})
+	}
+}
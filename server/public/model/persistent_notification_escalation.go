@@ -0,0 +1,106 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	PersistentNotificationEscalationTypeGroup   = "group"
+	PersistentNotificationEscalationTypeChannel = "channel"
+
+	// PersistentNotificationEscalationsMax is the maximum number of
+	// escalation steps of a channel.
+	PersistentNotificationEscalationsMax = 10
+)
+
+// PersistentNotificationEscalation is a step of the escalation chain of a
+// channel. Once a persistent notification posted in the channel has been sent
+// AfterCount times without being acknowledged, the members of the group are
+// notified, or a message linking to the post is posted in the fallback
+// channel.
+type PersistentNotificationEscalation struct {
+	Id                string `json:"id"`
+	ChannelId         string `json:"channel_id"`
+	AfterCount        int    `json:"after_count"`
+	Type              string `json:"type"`
+	GroupId           string `json:"group_id,omitempty"`
+	FallbackChannelId string `json:"fallback_channel_id,omitempty"`
+	CreatorId         string `json:"creator_id"`
+	CreateAt          int64  `json:"create_at"`
+	UpdateAt          int64  `json:"update_at"`
+	DeleteAt          int64  `json:"delete_at"`
+}
+
+// PersistentNotificationEscalationStep is an escalation step of a persistent
+// notification post, telling whether it was already reached.
+type PersistentNotificationEscalationStep struct {
+	*PersistentNotificationEscalation
+	Escalated bool `json:"escalated"`
+}
+
+// PostPersistentNotificationEscalations is the escalation chain of a
+// persistent notification post.
+type PostPersistentNotificationEscalations struct {
+	PostId    string                                  `json:"post_id"`
+	SentCount int16                                   `json:"sent_count"`
+	Steps     []*PersistentNotificationEscalationStep `json:"steps"`
+}
+
+func (o *PersistentNotificationEscalation) Auditable() map[string]any {
+	return map[string]any{
+		"id":                  o.Id,
+		"channel_id":          o.ChannelId,
+		"after_count":         o.AfterCount,
+		"type":                o.Type,
+		"group_id":            o.GroupId,
+		"fallback_channel_id": o.FallbackChannelId,
+		"creator_id":          o.CreatorId,
+	}
+}
+
+func (o *PersistentNotificationEscalation) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+	o.UpdateAt = o.CreateAt
+	o.DeleteAt = 0
+}
+
+func (o *PersistentNotificationEscalation) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("PersistentNotificationEscalation.IsValid", "model.persistent_notification_escalation.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("PersistentNotificationEscalation.IsValid", "model.persistent_notification_escalation.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("PersistentNotificationEscalation.IsValid", "model.persistent_notification_escalation.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.AfterCount <= 0 {
+		return NewAppError("PersistentNotificationEscalation.IsValid", "model.persistent_notification_escalation.is_valid.after_count.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	switch o.Type {
+	case PersistentNotificationEscalationTypeGroup:
+		if !IsValidId(o.GroupId) || o.FallbackChannelId != "" {
+			return NewAppError("PersistentNotificationEscalation.IsValid", "model.persistent_notification_escalation.is_valid.group_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+		}
+	case PersistentNotificationEscalationTypeChannel:
+		if !IsValidId(o.FallbackChannelId) || o.FallbackChannelId == o.ChannelId || o.GroupId != "" {
+			return NewAppError("PersistentNotificationEscalation.IsValid", "model.persistent_notification_escalation.is_valid.fallback_channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+		}
+	default:
+		return NewAppError("PersistentNotificationEscalation.IsValid", "model.persistent_notification_escalation.is_valid.type.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersistentNotificationEscalationIsValid(t *testing.T) {
+	o := PersistentNotificationEscalation{}
+	assert.NotNil(t, o.IsValid())
+
+	o.PreSave()
+	assert.NotEmpty(t, o.Id)
+	assert.NotZero(t, o.CreateAt)
+	assert.NotNil(t, o.IsValid())
+
+	o.ChannelId = NewId()
+	o.CreatorId = NewId()
+	o.AfterCount = 3
+	o.Type = PersistentNotificationEscalationTypeGroup
+	o.GroupId = NewId()
+	assert.Nil(t, o.IsValid())
+
+	o.AfterCount = 0
+	assert.NotNil(t, o.IsValid())
+	o.AfterCount = 3
+
+	o.FallbackChannelId = NewId()
+	assert.NotNil(t, o.IsValid(), "a group step can't have a fallback channel")
+
+	o.Type = PersistentNotificationEscalationTypeChannel
+	assert.NotNil(t, o.IsValid(), "a channel step can't have a group")
+	o.GroupId = ""
+	assert.Nil(t, o.IsValid())
+
+	o.FallbackChannelId = o.ChannelId
+	assert.NotNil(t, o.IsValid(), "the fallback channel must be another channel")
+
+	o.Type = "email"
+	assert.NotNil(t, o.IsValid())
+}
@@ -80,6 +80,10 @@ const (
 	PostPriorityUrgent               = "urgent"
 	PostPropsRequestedAck            = "requested_ack"
 	PostPropsPersistentNotifications = "persistent_notifications"
+
+	// PostReminderSnoozeActionURL is the integration URL of the snooze buttons
+	// of reminder messages, which are handled by the server itself.
+	PostReminderSnoozeActionURL = "/post_reminders/snooze"
 )
 
 type Post struct {
@@ -166,9 +170,9 @@ type PostPatch struct {
 
 type PostReminder struct {
 	TargetTime int64 `json:"target_time"`
-	// These fields are only used internally for interacting with DB.
-	PostId string `json:",omitempty"`
-	UserId string `json:",omitempty"`
+	// These fields are taken from the URL when setting a reminder.
+	PostId string `json:"post_id,omitempty"`
+	UserId string `json:"user_id,omitempty"`
 }
 
 type PostPriority struct {
@@ -28,6 +28,9 @@ type PostMetadata struct {
 
 	// Acknowledgements holds acknowledgements made by users to the post
 	Acknowledgements []*PostAcknowledgement `json:"acknowledgements,omitempty"`
+
+	// Translation holds the message of the post translated to the preferred language of the user it is sent to.
+	Translation *PostTranslation `json:"translation,omitempty"`
 }
 
 func (p *PostMetadata) Auditable() map[string]any {
@@ -47,9 +50,17 @@ func (p *PostMetadata) Auditable() map[string]any {
 		"reactions":        p.Reactions,
 		"priority":         p.Priority,
 		"acknowledgements": p.Acknowledgements,
+		"translation":      p.Translation,
 	}
 }
 
+type PostTranslation struct {
+	// Language is the code of the language the message was translated to, such as "fr".
+	Language string `json:"language"`
+
+	Message string `json:"message"`
+}
+
 type PostImage struct {
 	Width  int `json:"width"`
 	Height int `json:"height"`
@@ -94,6 +105,14 @@ func (p *PostMetadata) Copy() *PostMetadata {
 		}
 	}
 
+	var translationCopy *PostTranslation
+	if p.Translation != nil {
+		translationCopy = &PostTranslation{
+			Language: p.Translation.Language,
+			Message:  p.Translation.Message,
+		}
+	}
+
 	return &PostMetadata{
 		Embeds:           embedsCopy,
 		Emojis:           emojisCopy,
@@ -102,5 +121,6 @@ func (p *PostMetadata) Copy() *PostMetadata {
 		Reactions:        reactionsCopy,
 		Priority:         postPriorityCopy,
 		Acknowledgements: acknowledgementsCopy,
+		Translation:      translationCopy,
 	}
 }
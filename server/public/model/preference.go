@@ -50,6 +50,7 @@ const (
 	// - PreferenceNameColorizeUsernames
 	// - PreferenceNameChannelDisplayMode
 	// - PreferenceNameNameFormat
+	// - PreferenceNameTranslationLanguage
 	PreferenceCategoryDisplaySettings = "display_settings"
 	// PreferenceCategorySystemNotice is used store system admin notices.
 	// Possible Name values are not defined here. It can be anything with the notice name.
@@ -85,6 +86,8 @@ const (
 	PreferenceNameColorizeUsernames       = "colorize_usernames"
 	PreferenceNameNameFormat              = "name_format"
 	PreferenceNameUseMilitaryTime         = "use_military_time"
+	// PreferenceNameTranslationLanguage is the language posts are automatically translated to for the user.
+	PreferenceNameTranslationLanguage = "translation_language"
 
 	PreferenceNameShowUnreadSection = "show_unread_section"
 	PreferenceLimitVisibleDmsGms    = "limit_visible_dms_gms"
@@ -6,6 +6,7 @@ package model
 import (
 	"fmt"
 	"net/http"
+	"time"
 )
 
 const (
@@ -32,6 +33,20 @@ type ScheduledPost struct {
 	ScheduledAt int64  `json:"scheduled_at"`
 	ProcessedAt int64  `json:"processed_at"`
 	ErrorCode   string `json:"error_code"`
+
+	// Recurrence is set for scheduled posts that are posted repeatedly.
+	// The series is kept as a single scheduled post whose ScheduledAt is
+	// moved to the next occurrence after each send.
+	Recurrence *ScheduledPostRecurrence `json:"recurrence,omitempty"`
+	PausedAt   int64                    `json:"paused_at"`
+}
+
+func (s *ScheduledPost) IsRecurring() bool {
+	return s.Recurrence != nil
+}
+
+func (s *ScheduledPost) IsPaused() bool {
+	return s.PausedAt != 0
 }
 
 func (s *ScheduledPost) IsValid(maxMessageSize int) *AppError {
@@ -64,6 +79,16 @@ func (s *ScheduledPost) BaseIsValid() *AppError {
 		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.processed_at.app_error", nil, "id="+s.Id, http.StatusBadRequest)
 	}
 
+	if s.Recurrence != nil {
+		if appErr := s.Recurrence.IsValid(); appErr != nil {
+			return appErr
+		}
+
+		if s.Recurrence.EndAt != 0 && s.Recurrence.EndAt < s.ScheduledAt {
+			return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.recurrence_end_at.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -74,15 +99,30 @@ func (s *ScheduledPost) PreSave() {
 
 	s.ProcessedAt = 0
 	s.ErrorCode = ""
+	s.PausedAt = 0
+	s.preCommitRecurrence()
 
 	s.Draft.PreSave()
 }
 
 func (s *ScheduledPost) PreUpdate() {
 	s.Draft.UpdateAt = GetMillis()
+	s.preCommitRecurrence()
 	s.Draft.PreCommit()
 }
 
+// preCommitRecurrence pins the day of monthly series, so that occurrences
+// moved to the end of shorter months don't drift.
+func (s *ScheduledPost) preCommitRecurrence() {
+	if s.Recurrence == nil || s.Recurrence.Frequency != ScheduledPostRecurrenceMonthly || s.Recurrence.DayOfMonth != 0 {
+		return
+	}
+
+	if loc, err := s.Recurrence.location(); err == nil {
+		s.Recurrence.DayOfMonth = time.UnixMilli(s.ScheduledAt).In(loc).Day()
+	}
+}
+
 // ToPost converts a scheduled post toa  regular, mattermost post object.
 func (s *ScheduledPost) ToPost() (*Post, error) {
 	post := &Post{
@@ -144,6 +184,8 @@ func (s *ScheduledPost) Auditable() map[string]interface{} {
 		"props":      s.GetProps(),
 		"file_ids":   s.FileIds,
 		"metadata":   metaData,
+		"recurrence": s.Recurrence,
+		"paused_at":  s.PausedAt,
 	}
 }
 
@@ -153,6 +195,7 @@ func (s *ScheduledPost) RestoreNonUpdatableFields(originalScheduledPost *Schedul
 	s.UserId = originalScheduledPost.UserId
 	s.ChannelId = originalScheduledPost.ChannelId
 	s.RootId = originalScheduledPost.RootId
+	s.PausedAt = originalScheduledPost.PausedAt
 }
 
 func (s *ScheduledPost) SanitizeInput() {
@@ -0,0 +1,214 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+)
+
+const (
+	ScheduledPostRecurrenceDaily   = "daily"
+	ScheduledPostRecurrenceWeekly  = "weekly"
+	ScheduledPostRecurrenceMonthly = "monthly"
+
+	scheduledPostRecurrenceMaxInterval = 365
+
+	// scheduledPostRecurrenceMaxIterations bounds the number of candidate
+	// occurrences generated while looking for the next one, so an unsatisfiable
+	// rule can never keep the scheduled post job busy.
+	scheduledPostRecurrenceMaxIterations = 5000
+)
+
+// ScheduledPostRecurrence describes how a scheduled post repeats. It is a
+// subset of the iCalendar RRULE: the time of the day of each occurrence is the
+// one of the series' first ScheduledAt, evaluated in TimeZone.
+type ScheduledPostRecurrence struct {
+	Frequency string `json:"frequency"`
+	// Interval is the number of days, weeks or months between occurrences.
+	// Zero is treated as one.
+	Interval int `json:"interval,omitempty"`
+	// Weekdays restricts weekly recurrences to the given days, with Sunday
+	// being 0. When empty the weekday of ScheduledAt is used.
+	Weekdays []int `json:"weekdays,omitempty"`
+	// DayOfMonth is the day monthly recurrences are posted on. Months
+	// shorter than DayOfMonth use their last day instead.
+	DayOfMonth int `json:"day_of_month,omitempty"`
+	// TimeZone is the IANA time zone used to compute the occurrences,
+	// defaulting to UTC.
+	TimeZone string `json:"time_zone,omitempty"`
+	// EndAt is the time after which no occurrence is posted. Zero means the
+	// series never ends.
+	EndAt int64 `json:"end_at,omitempty"`
+	// SkipWeekends drops the occurrences falling on a Saturday or a Sunday.
+	SkipWeekends bool `json:"skip_weekends,omitempty"`
+}
+
+func (r *ScheduledPostRecurrence) IsValid() *AppError {
+	switch r.Frequency {
+	case ScheduledPostRecurrenceDaily, ScheduledPostRecurrenceWeekly, ScheduledPostRecurrenceMonthly:
+	default:
+		return NewAppError("ScheduledPostRecurrence.IsValid", "model.scheduled_post.is_valid.recurrence_frequency.app_error", nil, "frequency="+r.Frequency, http.StatusBadRequest)
+	}
+
+	if r.Interval < 0 || r.Interval > scheduledPostRecurrenceMaxInterval {
+		return NewAppError("ScheduledPostRecurrence.IsValid", "model.scheduled_post.is_valid.recurrence_interval.app_error", map[string]any{"Max": scheduledPostRecurrenceMaxInterval}, "", http.StatusBadRequest)
+	}
+
+	for _, weekday := range r.Weekdays {
+		if weekday < int(time.Sunday) || weekday > int(time.Saturday) {
+			return NewAppError("ScheduledPostRecurrence.IsValid", "model.scheduled_post.is_valid.recurrence_weekdays.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	if r.SkipWeekends && r.Frequency == ScheduledPostRecurrenceWeekly && len(r.Weekdays) > 0 && !slices.ContainsFunc(r.Weekdays, isWorkingWeekday) {
+		return NewAppError("ScheduledPostRecurrence.IsValid", "model.scheduled_post.is_valid.recurrence_weekdays.app_error", nil, "only weekend days selected", http.StatusBadRequest)
+	}
+
+	if r.DayOfMonth < 0 || r.DayOfMonth > 31 {
+		return NewAppError("ScheduledPostRecurrence.IsValid", "model.scheduled_post.is_valid.recurrence_day_of_month.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if _, err := r.location(); err != nil {
+		return NewAppError("ScheduledPostRecurrence.IsValid", "model.scheduled_post.is_valid.recurrence_time_zone.app_error", nil, "time_zone="+r.TimeZone, http.StatusBadRequest).Wrap(err)
+	}
+
+	if r.EndAt < 0 {
+		return NewAppError("ScheduledPostRecurrence.IsValid", "model.scheduled_post.is_valid.recurrence_end_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (r *ScheduledPostRecurrence) location() (*time.Location, error) {
+	if r.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(r.TimeZone)
+}
+
+func (r *ScheduledPostRecurrence) interval() int {
+	if r.Interval <= 0 {
+		return 1
+	}
+	return r.Interval
+}
+
+func isWorkingWeekday(weekday int) bool {
+	return weekday != int(time.Saturday) && weekday != int(time.Sunday)
+}
+
+// NextOccurrence returns the first occurrence of the series strictly after
+// the given time, starting from the current occurrence. It returns zero when
+// the series has ended.
+func (r *ScheduledPostRecurrence) NextOccurrence(current, after int64) int64 {
+	if r.IsValid() != nil {
+		return 0
+	}
+
+	loc, err := r.location()
+	if err != nil {
+		return 0
+	}
+
+	start := time.UnixMilli(current).In(loc)
+	candidate := start
+	for i := 0; i < scheduledPostRecurrenceMaxIterations; i++ {
+		candidate = r.step(start, candidate)
+
+		millis := candidate.UnixMilli()
+		if r.EndAt > 0 && millis > r.EndAt {
+			return 0
+		}
+
+		if millis <= after || millis <= current {
+			continue
+		}
+
+		if r.SkipWeekends && !isWorkingWeekday(int(candidate.Weekday())) {
+			continue
+		}
+
+		return millis
+	}
+
+	return 0
+}
+
+// step returns the occurrence following current, for a series whose
+// occurrence at start is known.
+func (r *ScheduledPostRecurrence) step(start, current time.Time) time.Time {
+	switch r.Frequency {
+	case ScheduledPostRecurrenceDaily:
+		return current.AddDate(0, 0, r.interval())
+
+	case ScheduledPostRecurrenceWeekly:
+		weekdays := r.Weekdays
+		if len(weekdays) == 0 {
+			weekdays = []int{int(start.Weekday())}
+		}
+
+		startWeek := start.AddDate(0, 0, -int(start.Weekday()))
+		for candidate := current.AddDate(0, 0, 1); ; candidate = candidate.AddDate(0, 0, 1) {
+			if !slices.Contains(weekdays, int(candidate.Weekday())) {
+				continue
+			}
+
+			// AddDate keeps the wall clock time, so the number of days is
+			// rounded to absorb the daylight saving time transitions.
+			weeks := int(candidate.Sub(startWeek).Hours()/24+0.5) / 7
+			if weeks%r.interval() == 0 {
+				return candidate
+			}
+		}
+
+	case ScheduledPostRecurrenceMonthly:
+		day := r.DayOfMonth
+		if day == 0 {
+			day = start.Day()
+		}
+
+		year, month, _ := current.Date()
+		firstOfMonth := time.Date(year, month+time.Month(r.interval()), 1, current.Hour(), current.Minute(), current.Second(), current.Nanosecond(), current.Location())
+		lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+		return firstOfMonth.AddDate(0, 0, min(day, lastDay)-1)
+	}
+
+	return current
+}
+
+func (r *ScheduledPostRecurrence) Scan(value any) error {
+	if value == nil {
+		return nil
+	}
+
+	var buf []byte
+	switch v := value.(type) {
+	case []byte:
+		buf = v
+	case string:
+		buf = []byte(v)
+	default:
+		return errors.New("received value is neither a byte slice nor string")
+	}
+
+	if len(buf) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(buf, r)
+}
+
+func (r ScheduledPostRecurrence) Value() (driver.Value, error) {
+	j, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(j), nil
+}
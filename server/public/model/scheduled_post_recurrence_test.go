@@ -0,0 +1,149 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledPostRecurrenceIsValid(t *testing.T) {
+	testCases := []struct {
+		name       string
+		recurrence ScheduledPostRecurrence
+		valid      bool
+	}{
+		{"daily", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily}, true},
+		{"weekly with weekdays", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceWeekly, Weekdays: []int{1, 3, 5}}, true},
+		{"monthly in time zone", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceMonthly, DayOfMonth: 31, TimeZone: "Europe/Berlin"}, true},
+		{"unknown frequency", ScheduledPostRecurrence{Frequency: "hourly"}, false},
+		{"negative interval", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily, Interval: -1}, false},
+		{"interval too large", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily, Interval: 1000}, false},
+		{"invalid weekday", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceWeekly, Weekdays: []int{7}}, false},
+		{"only weekend days skipped", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceWeekly, Weekdays: []int{0, 6}, SkipWeekends: true}, false},
+		{"invalid day of month", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceMonthly, DayOfMonth: 32}, false},
+		{"invalid time zone", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily, TimeZone: "Mars/Olympus_Mons"}, false},
+		{"negative end", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily, EndAt: -1}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			appErr := tc.recurrence.IsValid()
+			if tc.valid {
+				assert.Nil(t, appErr)
+			} else {
+				assert.NotNil(t, appErr)
+			}
+		})
+	}
+}
+
+func TestScheduledPostRecurrenceNextOccurrence(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	at := func(year int, month time.Month, day, hour, minute int, loc *time.Location) int64 {
+		return time.Date(year, month, day, hour, minute, 0, 0, loc).UnixMilli()
+	}
+
+	t.Run("daily", func(t *testing.T) {
+		r := &ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily}
+		current := at(2024, time.January, 1, 9, 0, time.UTC)
+		assert.Equal(t, at(2024, time.January, 2, 9, 0, time.UTC), r.NextOccurrence(current, current))
+	})
+
+	t.Run("daily with interval", func(t *testing.T) {
+		r := &ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily, Interval: 3}
+		current := at(2024, time.January, 1, 9, 0, time.UTC)
+		assert.Equal(t, at(2024, time.January, 4, 9, 0, time.UTC), r.NextOccurrence(current, current))
+	})
+
+	t.Run("daily skipping weekends", func(t *testing.T) {
+		r := &ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily, SkipWeekends: true}
+		friday := at(2024, time.January, 5, 9, 0, time.UTC)
+		assert.Equal(t, at(2024, time.January, 8, 9, 0, time.UTC), r.NextOccurrence(friday, friday))
+	})
+
+	t.Run("daily catches up with missed occurrences", func(t *testing.T) {
+		r := &ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily}
+		current := at(2024, time.January, 1, 9, 0, time.UTC)
+		now := at(2024, time.January, 10, 12, 0, time.UTC)
+		assert.Equal(t, at(2024, time.January, 11, 9, 0, time.UTC), r.NextOccurrence(current, now))
+	})
+
+	t.Run("daily keeps the local time across daylight saving time changes", func(t *testing.T) {
+		r := &ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily, TimeZone: "Europe/Berlin"}
+		current := at(2024, time.March, 30, 9, 30, berlin)
+		assert.Equal(t, at(2024, time.March, 31, 9, 30, berlin), r.NextOccurrence(current, current))
+	})
+
+	t.Run("weekly on the weekday of the first occurrence", func(t *testing.T) {
+		r := &ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceWeekly}
+		monday := at(2024, time.January, 1, 9, 0, time.UTC)
+		assert.Equal(t, at(2024, time.January, 8, 9, 0, time.UTC), r.NextOccurrence(monday, monday))
+	})
+
+	t.Run("weekly on several weekdays every other week", func(t *testing.T) {
+		r := &ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceWeekly, Interval: 2, Weekdays: []int{1, 3}}
+		monday := at(2024, time.January, 1, 9, 0, time.UTC)
+		wednesday := r.NextOccurrence(monday, monday)
+		assert.Equal(t, at(2024, time.January, 3, 9, 0, time.UTC), wednesday)
+		assert.Equal(t, at(2024, time.January, 15, 9, 0, time.UTC), r.NextOccurrence(wednesday, wednesday))
+	})
+
+	t.Run("monthly clamps to the last day of the month", func(t *testing.T) {
+		r := &ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceMonthly, DayOfMonth: 31}
+		january := at(2024, time.January, 31, 9, 0, time.UTC)
+		february := r.NextOccurrence(january, january)
+		assert.Equal(t, at(2024, time.February, 29, 9, 0, time.UTC), february)
+		assert.Equal(t, at(2024, time.March, 31, 9, 0, time.UTC), r.NextOccurrence(february, february))
+	})
+
+	t.Run("monthly skipping weekends", func(t *testing.T) {
+		r := &ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceMonthly, DayOfMonth: 1, SkipWeekends: true}
+		current := at(2024, time.May, 1, 9, 0, time.UTC)
+		// June 1st 2024 is a Saturday.
+		assert.Equal(t, at(2024, time.July, 1, 9, 0, time.UTC), r.NextOccurrence(current, current))
+	})
+
+	t.Run("series end", func(t *testing.T) {
+		current := at(2024, time.January, 1, 9, 0, time.UTC)
+		r := &ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily, EndAt: at(2024, time.January, 2, 9, 0, time.UTC)}
+		next := r.NextOccurrence(current, current)
+		assert.Equal(t, at(2024, time.January, 2, 9, 0, time.UTC), next)
+		assert.Zero(t, r.NextOccurrence(next, next))
+	})
+
+	t.Run("invalid recurrence", func(t *testing.T) {
+		r := &ScheduledPostRecurrence{Frequency: "hourly"}
+		assert.Zero(t, r.NextOccurrence(GetMillis(), GetMillis()))
+	})
+}
+
+func TestScheduledPostRecurrencePreSave(t *testing.T) {
+	scheduledPost := &ScheduledPost{
+		ScheduledAt: time.Date(2024, time.January, 30, 23, 30, 0, 0, time.UTC).UnixMilli(),
+		Recurrence:  &ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceMonthly, TimeZone: "Asia/Tokyo"},
+		PausedAt:    GetMillis(),
+	}
+	scheduledPost.PreSave()
+
+	assert.Equal(t, 31, scheduledPost.Recurrence.DayOfMonth)
+	assert.Zero(t, scheduledPost.PausedAt)
+}
+
+func TestScheduledPostRecurrenceScan(t *testing.T) {
+	var r ScheduledPostRecurrence
+	require.NoError(t, r.Scan(`{"frequency":"weekly","weekdays":[1,2],"time_zone":"UTC"}`))
+	assert.Equal(t, ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceWeekly, Weekdays: []int{1, 2}, TimeZone: "UTC"}, r)
+
+	value, err := r.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `{"frequency":"weekly","weekdays":[1,2],"time_zone":"UTC"}`, value)
+
+	require.Error(t, r.Scan(1))
+}
@@ -166,6 +166,35 @@ type SharedChannelRemoteStatus struct {
 	Token            string `json:"token"`
 }
 
+// SharedChannelRemoteSyncErrorMaxRunes is the maximum length of the synchronization error kept for a shared
+// channel remote.
+const SharedChannelRemoteSyncErrorMaxRunes = 1024
+
+// SharedChannelRemoteSyncStatus describes how far a shared channel has been synchronized with one
+// of its remotes.
+type SharedChannelRemoteSyncStatus struct {
+	ChannelId        string `json:"channel_id"`
+	RemoteId         string `json:"remote_id"`
+	DisplayName      string `json:"display_name"`
+	SiteURL          string `json:"site_url"`
+	IsInviteAccepted bool   `json:"is_invite_accepted"`
+	LastPingAt       int64  `json:"last_ping_at"`
+
+	// LastSyncAt is the update time of the most recent post synchronized with the remote.
+	LastSyncAt int64 `json:"last_sync_at"`
+
+	// PendingAttachments is the number of files of the channel that haven't been sent to the remote yet.
+	PendingAttachments int64 `json:"pending_attachments"`
+
+	// LastSyncError is the error of the last failed synchronization, if no synchronization succeeded since.
+	LastSyncError   string `json:"last_sync_error,omitempty"`
+	LastSyncErrorAt int64  `json:"last_sync_error_at,omitempty"`
+}
+
+func (s *SharedChannelRemoteSyncStatus) IsOnline() bool {
+	return s.LastPingAt > GetMillis()-RemoteOfflineAfterMillis
+}
+
 // SharedChannelUser stores a lastSyncAt timestamp on behalf of a remote cluster for
 // each user that has been synchronized.
 type SharedChannelUser struct {
@@ -1161,6 +1161,41 @@ func (s *hooksRPCServer) GenerateSupportData(args *Z_GenerateSupportDataArgs, re
 	return nil
 }
 
+func init() {
+	hookNameToId["MessagesWillBeTranslated"] = MessagesWillBeTranslatedID
+}
+
+type Z_MessagesWillBeTranslatedArgs struct {
+	A []*model.Post
+	B string
+}
+
+type Z_MessagesWillBeTranslatedReturns struct {
+	A map[string]string
+}
+
+func (g *hooksRPCClient) MessagesWillBeTranslated(posts []*model.Post, language string) map[string]string {
+	_args := &Z_MessagesWillBeTranslatedArgs{posts, language}
+	_returns := &Z_MessagesWillBeTranslatedReturns{}
+	if g.implemented[MessagesWillBeTranslatedID] {
+		if err := g.client.Call("Plugin.MessagesWillBeTranslated", _args, _returns); err != nil {
+			g.log.Error("RPC call MessagesWillBeTranslated to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) MessagesWillBeTranslated(args *Z_MessagesWillBeTranslatedArgs, returns *Z_MessagesWillBeTranslatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		MessagesWillBeTranslated(posts []*model.Post, language string) map[string]string
+	}); ok {
+		returns.A = hook.MessagesWillBeTranslated(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook MessagesWillBeTranslated called but not implemented."))
+	}
+	return nil
+}
+
 type Z_RegisterCommandArgs struct {
 	A *model.Command
 }
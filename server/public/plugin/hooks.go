@@ -61,6 +61,7 @@ const (
 	OnSharedChannelsAttachmentSyncMsgID       = 43
 	OnSharedChannelsProfileImageSyncMsgID     = 44
 	GenerateSupportDataID                     = 45
+	MessagesWillBeTranslatedID                = 46
 	TotalHooksID                              = iota
 )
 
@@ -389,4 +390,14 @@ type Hooks interface {
 	//
 	// Minimum server version: 9.8
 	GenerateSupportData(c *Context) ([]*model.FileData, error)
+
+	// MessagesWillBeTranslated is invoked when messages are returned to a user who prefers to read them in another
+	// language. It allows the plugin to translate them into that language, given as a locale such as "es" or "pt-BR".
+	//
+	// To provide translations, return the translated messages keyed by post id. Messages left out are offered to the
+	// next plugin, and then to the translation service of the server, if any. The posts themselves are not modified,
+	// the translations are attached to their metadata.
+	//
+	// Minimum server version: 10.3
+	MessagesWillBeTranslated(posts []*model.Post, language string) map[string]string
 }
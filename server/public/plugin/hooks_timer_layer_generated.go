@@ -291,3 +291,10 @@ func (hooks *hooksTimerLayer) GenerateSupportData(c *Context) ([]*model.FileData
 	hooks.recordTime(startTime, "GenerateSupportData", _returnsB == nil)
 	return _returnsA, _returnsB
 }
+
+func (hooks *hooksTimerLayer) MessagesWillBeTranslated(posts []*model.Post, language string) map[string]string {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.MessagesWillBeTranslated(posts, language)
+	hooks.recordTime(startTime, "MessagesWillBeTranslated", true)
+	return _returnsA
+}
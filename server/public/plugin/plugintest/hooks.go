@@ -272,6 +272,26 @@ func (_m *Hooks) MessagesWillBeConsumed(posts []*model.Post) []*model.Post {
 	return r0
 }
 
+// MessagesWillBeTranslated provides a mock function with given fields: posts, language
+func (_m *Hooks) MessagesWillBeTranslated(posts []*model.Post, language string) map[string]string {
+	ret := _m.Called(posts, language)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MessagesWillBeTranslated")
+	}
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func([]*model.Post, string) map[string]string); ok {
+		r0 = rf(posts, language)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	return r0
+}
+
 // NotificationWillBePushed provides a mock function with given fields: pushNotification, userID
 func (_m *Hooks) NotificationWillBePushed(pushNotification *model.PushNotification, userID string) (*model.PushNotification, string) {
 	ret := _m.Called(pushNotification, userID)
@@ -189,6 +189,8 @@ export class MessageExportSettings extends OLDAdminSettings<BaseProps & WrappedC
             {value: exportFormats.EXPORT_FORMAT_ACTIANCE, text: this.props.intl.formatMessage({id: 'admin.complianceExport.exportFormat.actiance', defaultMessage: 'Actiance XML'})},
             {value: exportFormats.EXPORT_FORMAT_CSV, text: this.props.intl.formatMessage({id: 'admin.complianceExport.exportFormat.csv', defaultMessage: 'CSV'})},
             {value: exportFormats.EXPORT_FORMAT_GLOBALRELAY, text: this.props.intl.formatMessage({id: 'admin.complianceExport.exportFormat.globalrelay', defaultMessage: 'GlobalRelay EML'})},
+            {value: exportFormats.EXPORT_FORMAT_EML, text: this.props.intl.formatMessage({id: 'admin.complianceExport.exportFormat.eml', defaultMessage: 'EML'})},
+            {value: exportFormats.EXPORT_FORMAT_MBOX, text: this.props.intl.formatMessage({id: 'admin.complianceExport.exportFormat.mbox', defaultMessage: 'MBOX'})},
         ];
 
         // if the export format is globalrelay, the user needs to set some additional parameters
@@ -1446,6 +1446,8 @@ export const exportFormats = {
     EXPORT_FORMAT_CSV: 'csv',
     EXPORT_FORMAT_ACTIANCE: 'actiance',
     EXPORT_FORMAT_GLOBALRELAY: 'globalrelay',
+    EXPORT_FORMAT_EML: 'eml',
+    EXPORT_FORMAT_MBOX: 'mbox',
 };
 
 export const ZoomSettings = {
@@ -312,6 +312,7 @@ export type ServiceSettings = {
     EnableOutgoingOAuthConnections: boolean;
     EnableCommands: boolean;
     OutgoingIntegrationRequestsTimeout: number;
+    OutgoingWebhookMaxRetries: number;
     EnablePostUsernameOverride: boolean;
     EnablePostIconOverride: boolean;
     EnableLinkPreviews: boolean;
@@ -530,6 +531,16 @@ export type FileSettings = {
     EnablePublicLink: boolean;
     ExtractContent: boolean;
     ArchiveRecursion: boolean;
+    OCRServiceURL: string;
+    OCRServiceSecret: string;
+    OCRMaxImageSize: number;
+    OCRMaxPDFSize: number;
+    OCRTimeoutMilliseconds: number;
+    EnableLocalCache: boolean;
+    LocalCacheDirectory: string;
+    LocalCacheMaxSizeBytes: number;
+    LocalCacheMaxFileSizeBytes: number;
+    EnableImageVariants: boolean;
     PublicLinkSalt: string;
     InitialFont: string;
     AmazonS3AccessKeyId: string;
@@ -605,6 +616,15 @@ export type RateLimitSettings = {
     VaryByRemoteAddr: boolean;
     VaryByUser: boolean;
     VaryByHeader: string;
+    StoreType: string;
+    PostsPerSec: number;
+    PostsMaxBurst: number;
+    SearchPerSec: number;
+    SearchMaxBurst: number;
+    FileUploadPerSec: number;
+    FileUploadMaxBurst: number;
+    AccessTokenPerSec: number;
+    AccessTokenMaxBurst: number;
 };
 
 export type PrivacySettings = {
@@ -777,6 +797,7 @@ export type ClusterSettings = {
     EnableExperimentalGossipEncryption: boolean;
     ReadOnlyConfig: boolean;
     GossipPort: number;
+    Transport: string;
 };
 
 export type MetricsSettings = {
@@ -851,6 +872,14 @@ export type BleveSettings = {
     BatchSize: number;
 };
 
+export type PostgresSearchSettings = {
+    EnableIndexing: boolean;
+    EnableSearching: boolean;
+    EnableAutocomplete: boolean;
+    TextSearchConfig: string;
+    BatchSize: number;
+};
+
 export type DataRetentionSettings = {
     EnableMessageDeletion: boolean;
     EnableFileDeletion: boolean;
@@ -980,6 +1009,7 @@ export type AdminConfig = {
     CacheSettings: CacheSettings;
     ElasticsearchSettings: ElasticsearchSettings;
     BleveSettings: BleveSettings;
+    PostgresSearchSettings: PostgresSearchSettings;
     DataRetentionSettings: DataRetentionSettings;
     MessageExportSettings: MessageExportSettings;
     JobSettings: JobSettings;
@@ -6,10 +6,24 @@ import type {Post} from './posts';
 
 export type ScheduledPostErrorCode = 'unknown' | 'channel_archived' | 'channel_not_found' | 'user_missing' | 'user_deleted' | 'no_channel_permission' | 'no_channel_member' | 'thread_deleted' | 'unable_to_send' | 'invalid_post';
 
+export type ScheduledPostRecurrenceFrequency = 'daily' | 'weekly' | 'monthly';
+
+export type ScheduledPostRecurrence = {
+    frequency: ScheduledPostRecurrenceFrequency;
+    interval?: number;
+    weekdays?: number[];
+    day_of_month?: number;
+    time_zone?: string;
+    end_at?: number;
+    skip_weekends?: boolean;
+}
+
 export type SchedulingInfo = {
     scheduled_at: number;
     processed_at?: number;
     error_code?: ScheduledPostErrorCode;
+    recurrence?: ScheduledPostRecurrence;
+    paused_at?: number;
 }
 
 export type ScheduledPost = Omit<Draft, 'delete_at'> & SchedulingInfo & {